@@ -25,7 +25,7 @@ type API interface {
 
 	GetCollectionByID(ctx context.Context, id flow.Identifier) (*flow.LightCollection, error)
 
-	SendTransaction(ctx context.Context, tx *flow.TransactionBody) error
+	SendTransaction(ctx context.Context, tx *flow.TransactionBody) (*TransactionSubmissionReceipt, error)
 	GetTransaction(ctx context.Context, id flow.Identifier) (*flow.TransactionBody, error)
 	GetTransactionsByBlockID(ctx context.Context, blockID flow.Identifier) ([]*flow.TransactionBody, error)
 	GetTransactionResult(ctx context.Context, id flow.Identifier) (*TransactionResult, error)
@@ -47,6 +47,20 @@ type API interface {
 
 	GetExecutionResultForBlockID(ctx context.Context, blockID flow.Identifier) (*flow.ExecutionResult, error)
 	GetExecutionResultByID(ctx context.Context, id flow.Identifier) (*flow.ExecutionResult, error)
+
+	GetRegistersWithProof(ctx context.Context, blockID flow.Identifier, registerIDs []flow.RegisterID) (*RegistersWithProof, error)
+	GetAccountWithProof(ctx context.Context, address flow.Address, height uint64) (*RegistersWithProof, error)
+}
+
+// RegistersWithProof holds register values at the final state of a sealed block, together
+// with a batch proof of the values against the final state commitment of the sealed execution
+// result of the block. Clients can verify the values using the stateproof package.
+type RegistersWithProof struct {
+	BlockID           flow.Identifier
+	BlockHeight       uint64
+	ExecutionResultID flow.Identifier
+	Registers         []flow.RegisterEntry
+	Proof             flow.StorageProof
 }
 
 // TODO: Combine this with flow.TransactionResult?
@@ -10,6 +10,9 @@ import (
 // ErrUnknownReferenceBlock indicates that a transaction references an unknown block.
 var ErrUnknownReferenceBlock = errors.New("unknown reference block")
 
+// ErrDuplicateTransaction indicates that a transaction has already been ingested.
+var ErrDuplicateTransaction = errors.New("duplicate transaction")
+
 // IncompleteTransactionError indicates that a transaction is missing one or more required fields.
 type IncompleteTransactionError struct {
 	MissingFields []string
@@ -90,3 +93,40 @@ type InvalidTxByteSizeError struct {
 func (e InvalidTxByteSizeError) Error() string {
 	return fmt.Sprintf("transaction byte size (%d) exceeds the maximum byte size allowed for a transaction (%d)", e.Actual, e.Maximum)
 }
+
+// InsufficientBalanceError indicates that the payer of a transaction can't cover the estimated fees.
+type InsufficientBalanceError struct {
+	Payer    flow.Address
+	Required uint64
+	Actual   uint64
+}
+
+func (e InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("payer %s has insufficient balance to cover transaction fees (balance: %d, estimated fees: %d)", e.Payer, e.Actual, e.Required)
+}
+
+// InvalidProposalKeyError indicates that the proposal key of a transaction doesn't exist or has been revoked.
+type InvalidProposalKeyError struct {
+	Address  flow.Address
+	KeyIndex uint64
+	Revoked  bool
+}
+
+func (e InvalidProposalKeyError) Error() string {
+	if e.Revoked {
+		return fmt.Sprintf("proposal key has been revoked (address: %s, index: %d)", e.Address, e.KeyIndex)
+	}
+	return fmt.Sprintf("proposal key does not exist (address: %s, index: %d)", e.Address, e.KeyIndex)
+}
+
+// StaleSequenceNumberError indicates that the proposal key sequence number of a transaction has already been used.
+type StaleSequenceNumberError struct {
+	Address  flow.Address
+	KeyIndex uint64
+	Expected uint64
+	Actual   uint64
+}
+
+func (e StaleSequenceNumberError) Error() string {
+	return fmt.Sprintf("proposal key sequence number (%d) is stale, expected at least %d (address: %s, index: %d)", e.Actual, e.Expected, e.Address, e.KeyIndex)
+}
@@ -2,13 +2,17 @@ package access
 
 import (
 	"context"
+	"strings"
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/onflow/flow-go/access/stateproof/stateproofproto"
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/signature"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
@@ -174,11 +178,18 @@ func (h *Handler) SendTransaction(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = h.api.SendTransaction(ctx, &tx)
+	receipt, err := h.api.SendTransaction(ctx, &tx)
 	if err != nil {
 		return nil, err
 	}
 
+	// the response message has no fields for the submission receipt, so report the routing
+	// of the transaction in the response header. Errors are ignored, as the receipt is
+	// informational and the header can only be set when served by a gRPC server.
+	if receipt != nil {
+		_ = grpc.SetHeader(ctx, submissionReceiptMetadata(receipt))
+	}
+
 	txID := tx.ID()
 
 	return &access.SendTransactionResponse{
@@ -186,6 +197,19 @@ func (h *Handler) SendTransaction(
 	}, nil
 }
 
+// submissionReceiptMetadata returns the gRPC metadata reporting the cluster and collection
+// nodes a transaction was routed to.
+func submissionReceiptMetadata(receipt *TransactionSubmissionReceipt) metadata.MD {
+	collectorIDs := make([]string, 0, len(receipt.Collectors))
+	for _, collectorID := range receipt.Collectors {
+		collectorIDs = append(collectorIDs, collectorID.String())
+	}
+	return metadata.Pairs(
+		ClusterIDMetadataKey, receipt.ClusterID.String(),
+		CollectorIDsMetadataKey, strings.Join(collectorIDs, ","),
+	)
+}
+
 // GetTransaction gets a transaction by ID.
 func (h *Handler) GetTransaction(
 	ctx context.Context,
@@ -535,6 +559,65 @@ func executionResultToMessages(er *flow.ExecutionResult) (*access.ExecutionResul
 	return &access.ExecutionResultForBlockIDResponse{ExecutionResult: execResult}, nil
 }
 
+// GetRegistersWithProof gets the values of registers at the final state of a sealed block,
+// together with a proof.
+func (h *Handler) GetRegistersWithProof(
+	ctx context.Context,
+	req *stateproofproto.GetRegistersWithProofRequest,
+) (*stateproofproto.RegistersWithProofResponse, error) {
+	blockID, err := convert.BlockID(req.GetBlockId())
+	if err != nil {
+		return nil, err
+	}
+
+	registerIDs := make([]flow.RegisterID, len(req.GetRegisters()))
+	for i, register := range req.GetRegisters() {
+		registerIDs[i] = flow.NewRegisterID(string(register.GetOwner()), string(register.GetKey()))
+	}
+
+	proof, err := h.api.GetRegistersWithProof(ctx, blockID, registerIDs)
+	if err != nil {
+		return nil, err
+	}
+	return registersWithProofToMessage(proof), nil
+}
+
+// GetAccountWithProof gets the values of the registers storing the keys and contracts of an
+// account at the final state of the sealed block at the given height, together with a proof.
+func (h *Handler) GetAccountWithProof(
+	ctx context.Context,
+	req *stateproofproto.GetAccountWithProofRequest,
+) (*stateproofproto.RegistersWithProofResponse, error) {
+	address, err := convert.Address(req.GetAddress(), h.chain)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := h.api.GetAccountWithProof(ctx, address, req.GetBlockHeight())
+	if err != nil {
+		return nil, err
+	}
+	return registersWithProofToMessage(proof), nil
+}
+
+func registersWithProofToMessage(proof *RegistersWithProof) *stateproofproto.RegistersWithProofResponse {
+	registers := make([]*stateproofproto.Register, len(proof.Registers))
+	for i, entry := range proof.Registers {
+		registers[i] = &stateproofproto.Register{
+			Owner: []byte(entry.Key.Owner),
+			Key:   []byte(entry.Key.Key),
+			Value: entry.Value,
+		}
+	}
+	return &stateproofproto.RegistersWithProofResponse{
+		BlockId:           proof.BlockID[:],
+		BlockHeight:       proof.BlockHeight,
+		ExecutionResultId: proof.ExecutionResultID[:],
+		Registers:         registers,
+		Proof:             proof.Proof,
+	}
+}
+
 func blockEventsToMessages(blocks []flow.BlockEvents) ([]*access.EventsResponse_Result, error) {
 	results := make([]*access.EventsResponse_Result, len(blocks))
 
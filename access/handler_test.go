@@ -0,0 +1,74 @@
+package access_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access"
+	mockaccess "github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/access/stateproof/stateproofproto"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestHandler_RegistersWithProof tests that the gRPC handler serves registers and accounts with
+// proofs as returned by the API.
+func TestHandler_RegistersWithProof(t *testing.T) {
+	chain := flow.Testnet.Chain()
+	address := unittest.RandomAddressFixture()
+	ids := []flow.RegisterID{
+		flow.NewRegisterID(string(address.Bytes()), "a"),
+		flow.NewRegisterID(string(address.Bytes()), "b"),
+	}
+	proof := &access.RegistersWithProof{
+		BlockID:           unittest.IdentifierFixture(),
+		BlockHeight:       10,
+		ExecutionResultID: unittest.IdentifierFixture(),
+		Registers: []flow.RegisterEntry{
+			{Key: ids[0], Value: []byte{1}},
+			{Key: ids[1], Value: nil},
+		},
+		Proof: []byte{2, 3},
+	}
+	expected := &stateproofproto.RegistersWithProofResponse{
+		BlockId:           proof.BlockID[:],
+		BlockHeight:       proof.BlockHeight,
+		ExecutionResultId: proof.ExecutionResultID[:],
+		Registers: []*stateproofproto.Register{
+			{Owner: address.Bytes(), Key: []byte("a"), Value: []byte{1}},
+			{Owner: address.Bytes(), Key: []byte("b")},
+		},
+		Proof: proof.Proof,
+	}
+
+	api := mockaccess.NewAPI(t)
+	handler := access.NewHandler(api, chain)
+
+	t.Run("registers", func(t *testing.T) {
+		api.On("GetRegistersWithProof", context.Background(), proof.BlockID, ids).Return(proof, nil).Once()
+
+		resp, err := handler.GetRegistersWithProof(context.Background(), &stateproofproto.GetRegistersWithProofRequest{
+			BlockId: proof.BlockID[:],
+			Registers: []*stateproofproto.RegisterID{
+				{Owner: address.Bytes(), Key: []byte("a")},
+				{Owner: address.Bytes(), Key: []byte("b")},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp)
+	})
+
+	t.Run("account", func(t *testing.T) {
+		api.On("GetAccountWithProof", context.Background(), address, proof.BlockHeight).Return(proof, nil).Once()
+
+		resp, err := handler.GetAccountWithProof(context.Background(), &stateproofproto.GetAccountWithProofRequest{
+			Address:     address.Bytes(),
+			BlockHeight: proof.BlockHeight,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, expected, resp)
+	})
+}
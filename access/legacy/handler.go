@@ -49,7 +49,7 @@ func (h *Handler) SendTransaction(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	err = h.api.SendTransaction(ctx, &tx)
+	_, err = h.api.SendTransaction(ctx, &tx)
 	if err != nil {
 		return nil, err
 	}
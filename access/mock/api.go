@@ -155,6 +155,29 @@ func (_m *API) GetAccountAtLatestBlock(ctx context.Context, address flow.Address
 	return r0, r1
 }
 
+// GetAccountWithProof provides a mock function with given fields: ctx, address, height
+func (_m *API) GetAccountWithProof(ctx context.Context, address flow.Address, height uint64) (*access.RegistersWithProof, error) {
+	ret := _m.Called(ctx, address, height)
+
+	var r0 *access.RegistersWithProof
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Address, uint64) *access.RegistersWithProof); ok {
+		r0 = rf(ctx, address, height)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.RegistersWithProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Address, uint64) error); ok {
+		r1 = rf(ctx, address, height)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBlockByHeight provides a mock function with given fields: ctx, height
 func (_m *API) GetBlockByHeight(ctx context.Context, height uint64) (*flow.Block, flow.BlockStatus, error) {
 	ret := _m.Called(ctx, height)
@@ -487,6 +510,29 @@ func (_m *API) GetNetworkParameters(ctx context.Context) access.NetworkParameter
 	return r0
 }
 
+// GetRegistersWithProof provides a mock function with given fields: ctx, blockID, registerIDs
+func (_m *API) GetRegistersWithProof(ctx context.Context, blockID flow.Identifier, registerIDs []flow.RegisterID) (*access.RegistersWithProof, error) {
+	ret := _m.Called(ctx, blockID, registerIDs)
+
+	var r0 *access.RegistersWithProof
+	if rf, ok := ret.Get(0).(func(context.Context, flow.Identifier, []flow.RegisterID) *access.RegistersWithProof); ok {
+		r0 = rf(ctx, blockID, registerIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.RegistersWithProof)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, flow.Identifier, []flow.RegisterID) error); ok {
+		r1 = rf(ctx, blockID, registerIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransaction provides a mock function with given fields: ctx, id
 func (_m *API) GetTransaction(ctx context.Context, id flow.Identifier) (*flow.TransactionBody, error) {
 	ret := _m.Called(ctx, id)
@@ -617,17 +663,26 @@ func (_m *API) Ping(ctx context.Context) error {
 }
 
 // SendTransaction provides a mock function with given fields: ctx, tx
-func (_m *API) SendTransaction(ctx context.Context, tx *flow.TransactionBody) error {
+func (_m *API) SendTransaction(ctx context.Context, tx *flow.TransactionBody) (*access.TransactionSubmissionReceipt, error) {
 	ret := _m.Called(ctx, tx)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody) error); ok {
+	var r0 *access.TransactionSubmissionReceipt
+	if rf, ok := ret.Get(0).(func(context.Context, *flow.TransactionBody) *access.TransactionSubmissionReceipt); ok {
 		r0 = rf(ctx, tx)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*access.TransactionSubmissionReceipt)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *flow.TransactionBody) error); ok {
+		r1 = rf(ctx, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 type mockConstructorTestingTNewAPI interface {
@@ -0,0 +1,150 @@
+// Package stateproof verifies register values read from the execution state against the
+// state commitment of an execution result. It allows clients of the Access API to verify
+// reads end-to-end, rather than trusting the access node they are connected to.
+//
+// Verifying a proof only shows that the registers have the returned values in the final
+// state of the given execution result. Clients are responsible for checking that the
+// execution result was sealed, e.g. by following the seals in verified block headers.
+package stateproof
+
+import (
+	"errors"
+	"fmt"
+
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm/environment"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/partial"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrNotProven is returned when reading a register which is not covered by the proof.
+var ErrNotProven = errors.New("register is not covered by the proof")
+
+// Registers provides the values of the registers covered by a proof, which has been
+// verified against a state commitment.
+type Registers struct {
+	ledger *partial.Ledger
+	commit flow.StateCommitment
+}
+
+// Verify verifies that the proof was created from the final state of the given execution
+// result, and returns the registers covered by the proof.
+// Returns an error if the proof is invalid.
+func Verify(result *flow.ExecutionResult, proof flow.StorageProof) (*Registers, error) {
+	commit, err := result.FinalStateCommitment()
+	if err != nil {
+		return nil, fmt.Errorf("could not get final state commitment of result %v: %w", result.ID(), err)
+	}
+	return VerifyCommitment(commit, proof)
+}
+
+// VerifyCommitment verifies that the proof was created from the state with the given state
+// commitment, and returns the registers covered by the proof.
+// Returns an error if the proof is invalid.
+func VerifyCommitment(commit flow.StateCommitment, proof flow.StorageProof) (*Registers, error) {
+	// constructing the partial trie fails if the root hash computed from the proofs
+	// does not match the state commitment
+	psmt, err := partial.NewLedger(proof, ledger.State(commit), partial.DefaultPathFinderVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof for state commitment %x: %w", commit, err)
+	}
+	return &Registers{
+		ledger: psmt,
+		commit: commit,
+	}, nil
+}
+
+// StateCommitment returns the state commitment the proof was verified against.
+func (r *Registers) StateCommitment() flow.StateCommitment {
+	return r.commit
+}
+
+// Get returns the value of the given register, which is empty if the proof shows that
+// the register is not set.
+// Expected errors during normal operations:
+//   - ErrNotProven if the register is not covered by the proof
+func (r *Registers) Get(id flow.RegisterID) (flow.RegisterValue, error) {
+	query, err := ledger.NewQuerySingleValue(ledger.State(r.commit), executionState.RegisterIDToKey(id))
+	if err != nil {
+		return nil, fmt.Errorf("could not create query for register %v: %w", id, err)
+	}
+	value, err := r.ledger.GetSingleValue(query)
+	if errors.Is(err, ledger.ErrMissingKeys{}) {
+		return nil, fmt.Errorf("could not read register %v: %w", id, ErrNotProven)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read register %v: %w", id, err)
+	}
+	return value, nil
+}
+
+// Values returns the values of the given registers, in the same order as the register IDs.
+// Expected errors during normal operations:
+//   - ErrNotProven if any of the registers is not covered by the proof
+func (r *Registers) Values(ids []flow.RegisterID) ([]flow.RegisterValue, error) {
+	values := make([]flow.RegisterValue, 0, len(ids))
+	for _, id := range ids {
+		value, err := r.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// Account returns the keys and contracts of the account with the given address. The balance
+// of the account is not included, as it is stored in the account storage rather than in
+// dedicated registers.
+// Expected errors during normal operations:
+//   - ErrNotProven if the proof doesn't cover all registers of the account
+//   - an fvm AccountNotFoundError if the proof shows that the account doesn't exist
+func (r *Registers) Account(address flow.Address) (*flow.Account, error) {
+	var proofErr error
+	read := func(owner, key string) (flow.RegisterValue, error) {
+		value, err := r.Get(flow.NewRegisterID(owner, key))
+		if err != nil && proofErr == nil {
+			// keep the error, since the fvm wraps errors of the underlying storage
+			proofErr = err
+		}
+		return value, err
+	}
+
+	account, _, err := readAccount(address, read)
+	if proofErr != nil {
+		return nil, proofErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// AccountRegisters returns the registers storing the keys and contracts of the account with
+// the given address, reading register values with the given function. Proving these registers
+// allows clients to verify the account using Registers.Account. If the account doesn't exist,
+// the returned registers prove that it doesn't exist.
+// No errors are expected during normal operations.
+func AccountRegisters(address flow.Address, read delta.GetRegisterFunc) ([]flow.RegisterID, error) {
+	_, view, err := readAccount(address, read)
+	if err != nil && !fvmerrors.IsAccountNotFoundError(err) {
+		return nil, err
+	}
+	return view.Interactions().AllRegisters(), nil
+}
+
+// readAccount reads the account with the given address, using the same logic as the fvm.
+// It returns the view the account was read from, which tracks all registers read.
+func readAccount(address flow.Address, read delta.GetRegisterFunc) (*flow.Account, *delta.View, error) {
+	view := delta.NewView(read)
+	txnState := state.NewTransactionState(view, state.DefaultParameters())
+	account, err := environment.NewAccounts(txnState).Get(address)
+	if err != nil {
+		return nil, view, fmt.Errorf("could not read account %v: %w", address, err)
+	}
+	return account, view, nil
+}
@@ -0,0 +1,154 @@
+package stateproof
+
+import (
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	executionState "github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm/environment"
+	fvmerrors "github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testLedger is a ledger holding a single account with two keys and a contract.
+type testLedger struct {
+	ledger  *complete.Ledger
+	commit  flow.StateCommitment
+	account *flow.Account
+}
+
+func newTestLedger(t *testing.T) *testLedger {
+	ldg, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+	compactor := fixtures.NewNoopCompactor(ldg)
+	<-compactor.Ready()
+	t.Cleanup(func() {
+		<-ldg.Done()
+		<-compactor.Done()
+	})
+
+	address := unittest.RandomAddressFixture()
+	keys := make([]flow.AccountPublicKey, 2)
+	for i := range keys {
+		key, err := unittest.AccountKeyDefaultFixture()
+		require.NoError(t, err)
+		keys[i] = key.PublicKey(1000)
+	}
+
+	view := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+	accounts := environment.NewAccounts(state.NewTransactionState(view, state.DefaultParameters()))
+	require.NoError(t, accounts.Create(keys, address))
+	require.NoError(t, accounts.SetContract("Test", address, []byte("pub contract Test {}")))
+	account, err := accounts.Get(address)
+	require.NoError(t, err)
+
+	commit, _, err := executionState.CommitDelta(ldg, view, flow.StateCommitment(ldg.InitialState()))
+	require.NoError(t, err)
+
+	return &testLedger{
+		ledger:  ldg,
+		commit:  commit,
+		account: account,
+	}
+}
+
+// read reads the value of a register from the ledger.
+func (l *testLedger) read(t *testing.T) delta.GetRegisterFunc {
+	return func(owner, key string) (flow.RegisterValue, error) {
+		query, err := ledger.NewQuerySingleValue(ledger.State(l.commit), executionState.RegisterIDToKey(flow.NewRegisterID(owner, key)))
+		require.NoError(t, err)
+		return l.ledger.GetSingleValue(query)
+	}
+}
+
+// prove creates a proof for the given registers.
+func (l *testLedger) prove(t *testing.T, ids []flow.RegisterID) flow.StorageProof {
+	query, err := ledger.NewQuery(ledger.State(l.commit), executionState.RegisterIDSToKeys(ids))
+	require.NoError(t, err)
+	proof, err := l.ledger.Prove(query)
+	require.NoError(t, err)
+	return proof
+}
+
+func TestVerify_Account(t *testing.T) {
+	l := newTestLedger(t)
+
+	ids, err := AccountRegisters(l.account.Address, l.read(t))
+	require.NoError(t, err)
+
+	registers, err := VerifyCommitment(l.commit, l.prove(t, ids))
+	require.NoError(t, err)
+	account, err := registers.Account(l.account.Address)
+	require.NoError(t, err)
+	assert.Equal(t, l.account, account)
+
+	// a proof covering only some registers of the account is not sufficient
+	registers, err = VerifyCommitment(l.commit, l.prove(t, ids[:len(ids)-1]))
+	require.NoError(t, err)
+	_, err = registers.Account(l.account.Address)
+	assert.ErrorIs(t, err, ErrNotProven)
+}
+
+func TestVerify_AccountNotFound(t *testing.T) {
+	l := newTestLedger(t)
+	address := unittest.RandomAddressFixture()
+
+	ids, err := AccountRegisters(address, l.read(t))
+	require.NoError(t, err)
+	require.NotEmpty(t, ids)
+
+	registers, err := VerifyCommitment(l.commit, l.prove(t, ids))
+	require.NoError(t, err)
+	_, err = registers.Account(address)
+	assert.True(t, fvmerrors.IsAccountNotFoundError(err))
+}
+
+func TestVerify_Registers(t *testing.T) {
+	l := newTestLedger(t)
+	owner := string(l.account.Address.Bytes())
+	ids := []flow.RegisterID{
+		flow.NewRegisterID(owner, state.ContractNamesKey),
+		flow.NewRegisterID(owner, "unknown"),
+	}
+
+	registers, err := VerifyCommitment(l.commit, l.prove(t, ids))
+	require.NoError(t, err)
+	values, err := registers.Values(ids)
+	require.NoError(t, err)
+	assert.NotEmpty(t, values[0])
+	assert.Empty(t, values[1])
+
+	_, err = registers.Get(flow.NewRegisterID(owner, state.AccountStatusKey))
+	assert.ErrorIs(t, err, ErrNotProven)
+}
+
+func TestVerify_InvalidProof(t *testing.T) {
+	l := newTestLedger(t)
+	ids, err := AccountRegisters(l.account.Address, l.read(t))
+	require.NoError(t, err)
+	proof := l.prove(t, ids)
+
+	// proof for another state
+	_, err = VerifyCommitment(unittest.StateCommitmentFixture(), proof)
+	assert.Error(t, err)
+
+	// proof for the final state of an execution result
+	result := unittest.ExecutionResultFixture()
+	result.Chunks[len(result.Chunks)-1].EndState = l.commit
+	_, err = Verify(result, proof)
+	assert.NoError(t, err)
+
+	result.Chunks[len(result.Chunks)-1].EndState = unittest.StateCommitmentFixture()
+	_, err = Verify(result, proof)
+	assert.Error(t, err)
+}
@@ -0,0 +1,220 @@
+package access
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const (
+	// ClusterIDMetadataKey is the gRPC response header metadata key holding the ID of the
+	// cluster a submitted transaction was routed to.
+	ClusterIDMetadataKey = "x-flow-cluster-id"
+	// CollectorIDsMetadataKey is the gRPC response header metadata key holding the comma
+	// separated node IDs of the collection nodes selected to receive a submitted transaction.
+	CollectorIDsMetadataKey = "x-flow-collector-ids"
+)
+
+// rejectionReasonDomain is the domain of the gRPC ErrorInfo details with which collection
+// nodes report why they rejected a transaction.
+const rejectionReasonDomain = "flow.collection.ingest"
+
+// RejectionReason is the reason a collection node rejected a submitted transaction.
+type RejectionReason int
+
+const (
+	// RejectionReasonUnknown indicates that the collection node could not be reached or
+	// did not report a reason for rejecting the transaction.
+	RejectionReasonUnknown RejectionReason = iota
+	// RejectionReasonExpired indicates that the transaction reference block is expired.
+	RejectionReasonExpired
+	// RejectionReasonDuplicate indicates that the collection node already holds the transaction.
+	RejectionReasonDuplicate
+	// RejectionReasonInvalidSignature indicates that the transaction signatures are malformed or duplicated.
+	RejectionReasonInvalidSignature
+	// RejectionReasonRateLimited indicates that the collection node rate limited the submission.
+	RejectionReasonRateLimited
+	// RejectionReasonInvalid indicates that the transaction is malformed or failed any other
+	// validation which does not depend on the execution state.
+	RejectionReasonInvalid
+	// RejectionReasonAccountState indicates that the transaction failed validation against the
+	// state of the payer or proposer account, e.g. an insufficient balance or a stale sequence
+	// number. As the account state changes, and collection nodes might validate against stale
+	// state, such a rejection is not terminal.
+	RejectionReasonAccountState
+)
+
+func (r RejectionReason) String() string {
+	switch r {
+	case RejectionReasonExpired:
+		return "expired"
+	case RejectionReasonDuplicate:
+		return "duplicate"
+	case RejectionReasonInvalidSignature:
+		return "invalid_signature"
+	case RejectionReasonRateLimited:
+		return "rate_limited"
+	case RejectionReasonInvalid:
+		return "invalid"
+	case RejectionReasonAccountState:
+		return "account_state"
+	default:
+		return "unknown"
+	}
+}
+
+// Terminal returns true if a transaction rejected for this reason can never be included in
+// a collection, so there is no point in submitting it again. This only holds for reasons which
+// don't depend on the execution state.
+func (r RejectionReason) Terminal() bool {
+	return r == RejectionReasonExpired || r == RejectionReasonInvalidSignature || r == RejectionReasonInvalid
+}
+
+// ParseRejectionReason parses the string representation of a rejection reason.
+// Unrecognized strings are parsed as RejectionReasonUnknown.
+func ParseRejectionReason(s string) RejectionReason {
+	for _, r := range []RejectionReason{
+		RejectionReasonExpired,
+		RejectionReasonDuplicate,
+		RejectionReasonInvalidSignature,
+		RejectionReasonRateLimited,
+		RejectionReasonInvalid,
+		RejectionReasonAccountState,
+	} {
+		if r.String() == s {
+			return r
+		}
+	}
+	return RejectionReasonUnknown
+}
+
+// RejectionReasonOf returns the reason for rejecting a transaction which failed validation
+// with the given error.
+func RejectionReasonOf(err error) RejectionReason {
+	switch {
+	case errors.As(err, &ExpiredTransactionError{}):
+		return RejectionReasonExpired
+	case errors.Is(err, ErrDuplicateTransaction):
+		return RejectionReasonDuplicate
+	case errors.As(err, &InvalidSignatureError{}),
+		errors.As(err, &DuplicatedSignatureError{}):
+		return RejectionReasonInvalidSignature
+	case errors.As(err, &IncompleteTransactionError{}),
+		errors.As(err, &InvalidScriptError{}),
+		errors.As(err, &InvalidGasLimitError{}),
+		errors.As(err, &InvalidAddressError{}),
+		errors.As(err, &InvalidTxByteSizeError{}):
+		return RejectionReasonInvalid
+	case errors.As(err, &InsufficientBalanceError{}),
+		errors.As(err, &InvalidProposalKeyError{}),
+		errors.As(err, &StaleSequenceNumberError{}):
+		return RejectionReasonAccountState
+	default:
+		return RejectionReasonUnknown
+	}
+}
+
+// NewRejectionStatusError returns a gRPC status error with the given code, which reports
+// the reason for rejecting a transaction to the submitter.
+func NewRejectionStatusError(code codes.Code, reason RejectionReason, err error) error {
+	st := status.New(code, err.Error())
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason.String(),
+		Domain: rejectionReasonDomain,
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// RejectionReasonFromStatusError returns the reason for rejecting a transaction reported
+// by a collection node with the given gRPC status error.
+func RejectionReasonFromStatusError(err error) RejectionReason {
+	// the status error may be wrapped, which status.FromError does not handle
+	var statusErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &statusErr) {
+		return RejectionReasonUnknown
+	}
+	st := statusErr.GRPCStatus()
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.GetDomain() == rejectionReasonDomain {
+			return ParseRejectionReason(info.GetReason())
+		}
+	}
+	// the rate limiter rejects requests before they reach the collection node backend
+	if st.Code() == codes.ResourceExhausted {
+		return RejectionReasonRateLimited
+	}
+	return RejectionReasonUnknown
+}
+
+// CollectorResponse is the response of a collection node to a submitted transaction.
+type CollectorResponse struct {
+	CollectorID flow.Identifier // zero if the collection node is not known by its node ID
+	Accepted    bool
+	Reason      RejectionReason // unset if accepted
+	Message     string          // error returned by the collection node, empty if accepted
+}
+
+// TransactionSubmissionReceipt describes which cluster a transaction was routed to, and how
+// the collection nodes it was sent to responded.
+type TransactionSubmissionReceipt struct {
+	TransactionID flow.Identifier
+	// the cluster responsible for the transaction, empty if the access node is configured
+	// to send transactions to a fixed collection node
+	ClusterID flow.ChainID
+	// the collection nodes selected to receive the transaction, in the order they were tried
+	Collectors flow.IdentifierList
+	// the responses of the collection nodes the transaction was sent to, in order
+	Responses []CollectorResponse
+}
+
+// Accepted returns true if any collection node accepted the transaction, or already held it.
+func (r *TransactionSubmissionReceipt) Accepted() bool {
+	for _, response := range r.Responses {
+		if response.Accepted || response.Reason == RejectionReasonDuplicate {
+			return true
+		}
+	}
+	return false
+}
+
+// Dropped returns true if all collection nodes the transaction was sent to rejected it for the
+// same terminal reason, so the transaction will never be included in a collection.
+// A single collection node can't cause a transaction to be dropped, unless the access node is
+// configured to send transactions to a fixed collection node.
+func (r *TransactionSubmissionReceipt) Dropped() bool {
+	if len(r.Responses) == 0 {
+		return false
+	}
+	reason := r.Responses[0].Reason
+	if !reason.Terminal() {
+		return false
+	}
+	for _, response := range r.Responses {
+		if response.Accepted || response.Reason != reason {
+			return false
+		}
+	}
+	return true
+}
+
+// RejectionMessage summarizes why collection nodes rejected the transaction.
+func (r *TransactionSubmissionReceipt) RejectionMessage() string {
+	var reasons []string
+	for _, response := range r.Responses {
+		if response.Accepted {
+			continue
+		}
+		reasons = append(reasons, fmt.Sprintf("%s (%s)", response.Reason, response.Message))
+	}
+	return fmt.Sprintf("transaction rejected by collection nodes: %s", strings.Join(reasons, "; "))
+}
@@ -0,0 +1,31 @@
+package access
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSubmissionReceiptDropped tests that a transaction is only dropped if all collection nodes
+// rejected it for the same reason, and that reason doesn't depend on the execution state.
+func TestSubmissionReceiptDropped(t *testing.T) {
+	rejected := func(reasons ...RejectionReason) *TransactionSubmissionReceipt {
+		receipt := &TransactionSubmissionReceipt{}
+		for _, reason := range reasons {
+			receipt.Responses = append(receipt.Responses, CollectorResponse{Reason: reason})
+		}
+		return receipt
+	}
+
+	assert.True(t, rejected(RejectionReasonExpired, RejectionReasonExpired).Dropped())
+	assert.True(t, rejected(RejectionReasonInvalid).Dropped())
+	assert.False(t, rejected().Dropped())
+	assert.False(t, rejected(RejectionReasonExpired, RejectionReasonInvalidSignature).Dropped())
+	assert.False(t, rejected(RejectionReasonExpired, RejectionReasonUnknown).Dropped())
+	assert.False(t, rejected(RejectionReasonAccountState, RejectionReasonAccountState).Dropped())
+	assert.False(t, rejected(RejectionReasonRateLimited).Dropped())
+
+	accepted := rejected(RejectionReasonExpired)
+	accepted.Responses = append(accepted.Responses, CollectorResponse{Accepted: true})
+	assert.False(t, accepted.Dropped())
+}
@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/profiler"
+)
+
+var _ commands.AdminCommand = (*GetProfileCommand)(nil)
+
+type getProfileReqData struct {
+	id   string
+	name string
+}
+
+// GetProfileCommand is an admin command which returns a single profile of a capture kept by
+// the profiler's local store. The gzipped pprof data is returned base64 encoded.
+type GetProfileCommand struct {
+	store *profiler.Store
+}
+
+func NewGetProfileCommand(store *profiler.Store) *GetProfileCommand {
+	return &GetProfileCommand{
+		store: store,
+	}
+}
+
+func (c *GetProfileCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*getProfileReqData)
+
+	content, err := c.store.ReadProfile(data.id, data.name)
+	if errors.Is(err, profiler.ErrCaptureNotFound) {
+		return nil, admin.NewInvalidAdminReqErrorf("no %s profile found in capture %s", data.name, data.id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read profile: %w", err)
+	}
+
+	return map[string]interface{}{
+		"id":   data.id,
+		"name": data.name,
+		"data": base64.StdEncoding.EncodeToString(content),
+	}, nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *GetProfileCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	data := &getProfileReqData{}
+	req.ValidatorData = data
+
+	id, ok := input["id"].(string)
+	if !ok || id == "" {
+		return admin.NewInvalidAdminReqParameterError("id", "must be a capture ID returned by list-profiles", input["id"])
+	}
+	data.id = id
+
+	name, ok := input["name"].(string)
+	if !ok || name == "" {
+		return admin.NewInvalidAdminReqParameterError("name", "must be a profile name, e.g. cpu or heap", input["name"])
+	}
+	data.name = name
+
+	return nil
+}
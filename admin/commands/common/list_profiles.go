@@ -0,0 +1,38 @@
+package common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/profiler"
+)
+
+var _ commands.AdminCommand = (*ListProfilesCommand)(nil)
+
+// ListProfilesCommand is an admin command which lists the profile captures kept by the
+// profiler's local store, most recent first, together with what triggered them.
+type ListProfilesCommand struct {
+	store *profiler.Store
+}
+
+func NewListProfilesCommand(store *profiler.Store) *ListProfilesCommand {
+	return &ListProfilesCommand{
+		store: store,
+	}
+}
+
+func (c *ListProfilesCommand) Handler(_ context.Context, _ *admin.CommandRequest) (interface{}, error) {
+	captures, err := c.store.List()
+	if err != nil {
+		return nil, fmt.Errorf("could not list captures: %w", err)
+	}
+	return commands.ConvertToInterfaceList(captures)
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *ListProfilesCommand) Validator(_ *admin.CommandRequest) error {
+	return nil
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/module/profiler"
+)
+
+func TestProfiles(t *testing.T) {
+	store, err := profiler.NewStore(t.TempDir(), 0, 0)
+	require.NoError(t, err)
+
+	for _, id := range []string{"1-heap", "2-slow-block"} {
+		dir, err := store.CreateCaptureDir(id)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "heap.pb.gz"), []byte(id), 0644))
+		require.NoError(t, store.Save(profiler.Capture{
+			ID:       id,
+			Trigger:  profiler.TriggerHeap,
+			Start:    time.Now(),
+			Profiles: []profiler.ProfileFile{{Name: "heap", File: "heap.pb.gz", Size: int64(len(id))}},
+		}))
+	}
+
+	t.Run("list", func(t *testing.T) {
+		c := NewListProfilesCommand(store)
+		req := &admin.CommandRequest{}
+		require.NoError(t, c.Validator(req))
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		captures := result.([]interface{})
+		require.Len(t, captures, 2)
+		assert.Equal(t, "2-slow-block", captures[0].(map[string]interface{})["id"])
+		assert.Equal(t, profiler.TriggerHeap, captures[0].(map[string]interface{})["trigger"])
+	})
+
+	t.Run("get", func(t *testing.T) {
+		c := NewGetProfileCommand(store)
+		req := &admin.CommandRequest{Data: map[string]interface{}{"id": "1-heap", "name": "heap"}}
+		require.NoError(t, c.Validator(req))
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		content, err := base64.StdEncoding.DecodeString(result.(map[string]interface{})["data"].(string))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("1-heap"), content)
+	})
+
+	t.Run("get unknown profile", func(t *testing.T) {
+		c := NewGetProfileCommand(store)
+		req := &admin.CommandRequest{Data: map[string]interface{}{"id": "1-heap", "name": "cpu"}}
+		require.NoError(t, c.Validator(req))
+		_, err := c.Handler(context.Background(), req)
+		assert.True(t, admin.IsInvalidAdminParameterError(err))
+	})
+
+	t.Run("get invalid request", func(t *testing.T) {
+		c := NewGetProfileCommand(store)
+		for _, data := range []interface{}{
+			nil,
+			map[string]interface{}{"name": "heap"},
+			map[string]interface{}{"id": "1-heap"},
+			map[string]interface{}{"id": 1, "name": "heap"},
+		} {
+			err := c.Validator(&admin.CommandRequest{Data: data})
+			assert.True(t, admin.IsInvalidAdminParameterError(err), data)
+		}
+	})
+}
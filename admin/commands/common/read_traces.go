@@ -0,0 +1,99 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/trace"
+)
+
+var _ commands.AdminCommand = (*ReadTracesCommand)(nil)
+
+// DefaultReadTracesLimit is the maximum number of spans returned if no limit is specified.
+const DefaultReadTracesLimit = 100
+
+type readTracesReqData struct {
+	filter trace.SpanFilter
+	limit  uint
+}
+
+// ReadTracesCommand is an admin command which returns the most recent spans kept by the
+// in-memory trace exporter, optionally filtered by trace ID, span name prefix and minimum duration.
+type ReadTracesCommand struct {
+	buffer *trace.MemoryExporter // nil if the in-memory exporter is not configured
+}
+
+func NewReadTracesCommand(buffer *trace.MemoryExporter) *ReadTracesCommand {
+	return &ReadTracesCommand{
+		buffer: buffer,
+	}
+}
+
+func (c *ReadTracesCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	if c.buffer == nil {
+		return nil, fmt.Errorf("in-memory trace exporter is not enabled")
+	}
+
+	data := req.ValidatorData.(*readTracesReqData)
+	spans := c.buffer.Spans(data.filter, data.limit)
+	return commands.ConvertToInterfaceList(spans)
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *ReadTracesCommand) Validator(req *admin.CommandRequest) error {
+	data := &readTracesReqData{
+		limit: DefaultReadTracesLimit,
+	}
+	req.ValidatorData = data
+
+	if req.Data == nil {
+		return nil
+	}
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	if value, ok := input["trace_id"]; ok {
+		traceID, ok := value.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("trace_id", "must be a string", value)
+		}
+		data.filter.TraceID = traceID
+	}
+
+	if value, ok := input["name"]; ok {
+		prefix, ok := value.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("name", "must be a string", value)
+		}
+		data.filter.NamePrefix = prefix
+	}
+
+	if value, ok := input["min_duration"]; ok {
+		str, ok := value.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("min_duration", "must be a duration string, e.g. 100ms", value)
+		}
+		duration, err := time.ParseDuration(str)
+		if err != nil || duration < 0 {
+			return admin.NewInvalidAdminReqParameterError("min_duration", "must be a duration string, e.g. 100ms", value)
+		}
+		data.filter.MinDuration = duration
+	}
+
+	if value, ok := input["limit"]; ok {
+		limit, ok := value.(float64)
+		if !ok || limit <= 0 || limit != math.Trunc(limit) {
+			return admin.NewInvalidAdminReqParameterError("limit", "must be a positive integer", value)
+		}
+		data.limit = uint(limit)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestReadTraces(t *testing.T) {
+	config := trace.DefaultConfig()
+	config.Exporters = []string{trace.ExporterMemory}
+	tracer, err := trace.NewTracerWithConfig(zerolog.Nop(), "test", string(flow.Localnet), trace.SensitivityCaptureAll, config)
+	require.NoError(t, err)
+	unittest.RequireCloseBefore(t, tracer.Ready(), time.Second, "tracer not ready")
+	defer func() {
+		unittest.RequireCloseBefore(t, tracer.Done(), time.Second, "tracer not done")
+	}()
+
+	parent, _, sampled := tracer.StartBlockSpan(context.Background(), unittest.IdentifierFixture(), "block.execute")
+	require.True(t, sampled)
+	tracer.StartSpanFromParent(parent, "block.execute.fast").End()
+	tracer.StartSpanFromParent(parent, "block.execute.slow", oteltrace.WithTimestamp(time.Now().Add(-time.Second))).End()
+	parent.End()
+
+	c := NewReadTracesCommand(tracer.Buffer())
+
+	t.Run("all spans", func(t *testing.T) {
+		req := &admin.CommandRequest{}
+		require.NoError(t, c.Validator(req))
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+		// the block's root span, and the three spans started above
+		assert.Len(t, result, 4)
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		req := &admin.CommandRequest{Data: map[string]interface{}{
+			"name":         "block.execute.",
+			"min_duration": "500ms",
+			"trace_id":     parent.SpanContext().TraceID().String(),
+			"limit":        float64(10),
+		}}
+		require.NoError(t, c.Validator(req))
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+		spans := result.([]interface{})
+		require.Len(t, spans, 1)
+		assert.Equal(t, "block.execute.slow", spans[0].(map[string]interface{})["name"])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, data := range []map[string]interface{}{
+			{"limit": float64(-1)},
+			{"limit": "10"},
+			{"min_duration": "abc"},
+			{"name": 1},
+		} {
+			err := c.Validator(&admin.CommandRequest{Data: data})
+			assert.True(t, admin.IsInvalidAdminParameterError(err), data)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		c := NewReadTracesCommand(nil)
+		req := &admin.CommandRequest{}
+		require.NoError(t, c.Validator(req))
+		_, err := c.Handler(context.Background(), req)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,61 @@
+package common
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/consensus/hotstuff/evidence"
+)
+
+var _ commands.AdminCommand = (*GetSlashingEvidenceCommand)(nil)
+
+// GetSlashingEvidenceCommand is an admin command which reports the most recently collected
+// evidence of slashable HotStuff offenses, i.e. double proposals, double votes, invalid
+// proposals and votes for invalid proposals, including their compact encoding.
+type GetSlashingEvidenceCommand struct {
+	store *evidence.Store
+}
+
+func NewGetSlashingEvidenceCommand(store *evidence.Store) *GetSlashingEvidenceCommand {
+	return &GetSlashingEvidenceCommand{
+		store: store,
+	}
+}
+
+func (c *GetSlashingEvidenceCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	limit := req.ValidatorData.(uint)
+
+	reports, err := c.store.List(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return commands.ConvertToMap(map[string]interface{}{
+		"evidence": reports,
+	})
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *GetSlashingEvidenceCommand) Validator(req *admin.CommandRequest) error {
+	limit := uint(evidence.DefaultListLimit)
+
+	if req.Data != nil {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+		}
+		if value, ok := input["limit"]; ok {
+			n, ok := value.(float64)
+			if !ok || n < 1 || n != float64(uint(n)) {
+				return admin.NewInvalidAdminReqParameterError("limit", "must be a positive integer", value)
+			}
+			limit = uint(n)
+		}
+	}
+
+	req.ValidatorData = limit
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package consensus
+
+import (
+	"context"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/engine/consensus/sealing"
+)
+
+var _ commands.AdminCommand = (*GetSealingStatusCommand)(nil)
+
+// GetSealingStatusCommand is an admin command which reports the sealing progress of the
+// unsealed finalized blocks: the receipts seen, the results being sealed, the approvals
+// collected per chunk and whether emergency sealing applies.
+type GetSealingStatusCommand struct {
+	reporter *sealing.StatusReporter
+}
+
+func NewGetSealingStatusCommand(reporter *sealing.StatusReporter) *GetSealingStatusCommand {
+	return &GetSealingStatusCommand{
+		reporter: reporter,
+	}
+}
+
+func (c *GetSealingStatusCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	limit := req.ValidatorData.(uint)
+
+	status, err := c.reporter.SealingStatus(limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return commands.ConvertToMap(status)
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *GetSealingStatusCommand) Validator(req *admin.CommandRequest) error {
+	limit := uint(sealing.DefaultStatusBlockLimit)
+
+	if req.Data != nil {
+		input, ok := req.Data.(map[string]interface{})
+		if !ok {
+			return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+		}
+		if value, ok := input["limit"]; ok {
+			n, ok := value.(float64)
+			if !ok || n < 1 || n != float64(uint(n)) {
+				return admin.NewInvalidAdminReqParameterError("limit", "must be a positive integer", value)
+			}
+			limit = uint(n)
+		}
+	}
+
+	req.ValidatorData = limit
+
+	return nil
+}
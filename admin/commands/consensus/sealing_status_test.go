@@ -0,0 +1,34 @@
+package consensus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/engine/consensus/sealing"
+)
+
+func TestGetSealingStatus_Validator(t *testing.T) {
+	c := NewGetSealingStatusCommand(nil)
+
+	// without input, the default limit is used
+	req := &admin.CommandRequest{}
+	require.NoError(t, c.Validator(req))
+	assert.Equal(t, uint(sealing.DefaultStatusBlockLimit), req.ValidatorData)
+
+	req = &admin.CommandRequest{Data: map[string]interface{}{"limit": float64(5)}}
+	require.NoError(t, c.Validator(req))
+	assert.Equal(t, uint(5), req.ValidatorData)
+
+	for _, data := range []interface{}{
+		"limit",
+		map[string]interface{}{"limit": float64(0)},
+		map[string]interface{}{"limit": float64(1.5)},
+		map[string]interface{}{"limit": "5"},
+	} {
+		err := c.Validator(&admin.CommandRequest{Data: data})
+		assert.True(t, admin.IsInvalidAdminParameterError(err), data)
+	}
+}
@@ -0,0 +1,153 @@
+package jobqueue
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/module/jobqueue"
+	"github.com/onflow/flow-go/storage"
+)
+
+// deadLetterQueue is an in-memory jobqueue.DeadLetterQueue recording retried jobs.
+type deadLetterQueue struct {
+	letters map[uint64]*storage.DeadLetter
+	retried []uint64
+}
+
+func (q *deadLetterQueue) DeadLetters() ([]*storage.DeadLetter, error) {
+	letters := make([]*storage.DeadLetter, 0, len(q.letters))
+	for index := uint64(0); len(letters) < len(q.letters); index++ {
+		if letter, ok := q.letters[index]; ok {
+			letters = append(letters, letter)
+		}
+	}
+	return letters, nil
+}
+
+func (q *deadLetterQueue) RetryDeadLetter(index uint64) error {
+	if _, ok := q.letters[index]; !ok {
+		return fmt.Errorf("no dead letter: %w", storage.ErrNotFound)
+	}
+	q.retried = append(q.retried, index)
+	return nil
+}
+
+func (q *deadLetterQueue) DropDeadLetter(index uint64) error {
+	if _, ok := q.letters[index]; !ok {
+		return fmt.Errorf("no dead letter: %w", storage.ErrNotFound)
+	}
+	delete(q.letters, index)
+	return nil
+}
+
+func setupRegistry(t *testing.T) (*jobqueue.Registry, *deadLetterQueue) {
+	queue := &deadLetterQueue{letters: map[uint64]*storage.DeadLetter{
+		3: {Index: 3, JobID: "3", Error: "failed", Attempts: 5},
+		7: {Index: 7, JobID: "7", Error: "failed", Attempts: 5},
+	}}
+	registry := jobqueue.NewRegistry()
+	require.NoError(t, registry.Register("test", queue))
+	require.NoError(t, registry.Register("empty", &deadLetterQueue{}))
+	return registry, queue
+}
+
+func TestListDeadLetters(t *testing.T) {
+	registry, _ := setupRegistry(t)
+	c := NewListDeadLettersCommand(registry)
+
+	t.Run("queues", func(t *testing.T) {
+		req := &admin.CommandRequest{}
+		require.NoError(t, c.Validator(req))
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"name": "empty", "dead_letters": 0},
+			map[string]interface{}{"name": "test", "dead_letters": 2},
+		}, result)
+	})
+
+	t.Run("dead letters", func(t *testing.T) {
+		req := &admin.CommandRequest{Data: map[string]interface{}{"queue": "test"}}
+		require.NoError(t, c.Validator(req))
+		result, err := c.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		listing := result.(map[string]interface{})
+		assert.Equal(t, "test", listing["queue"])
+		listed := listing["dead_letters"].([]interface{})
+		require.Len(t, listed, 2)
+		assert.Equal(t, float64(3), listed[0].(map[string]interface{})["Index"])
+		assert.Equal(t, float64(7), listed[1].(map[string]interface{})["Index"])
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		for name, data := range map[string]interface{}{
+			"not a map":     "test",
+			"unknown queue": map[string]interface{}{"queue": "unknown"},
+			"invalid queue": map[string]interface{}{"queue": float64(1)},
+		} {
+			err := c.Validator(&admin.CommandRequest{Data: data})
+			require.Error(t, err, name)
+			assert.ErrorAs(t, err, &admin.InvalidAdminReqError{}, name)
+		}
+	})
+}
+
+func TestRetryDeadLetter(t *testing.T) {
+	registry, queue := setupRegistry(t)
+	c := NewRetryDeadLetterCommand(registry)
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{"queue": "test", "index": float64(3)}}
+	require.NoError(t, c.Validator(req))
+	_, err := c.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{3}, queue.retried)
+
+	req = &admin.CommandRequest{Data: map[string]interface{}{"queue": "test", "index": float64(4)}}
+	require.NoError(t, c.Validator(req))
+	_, err = c.Handler(context.Background(), req)
+	assert.ErrorAs(t, err, &admin.InvalidAdminReqError{})
+}
+
+func TestDropDeadLetter(t *testing.T) {
+	registry, queue := setupRegistry(t)
+	c := NewDropDeadLetterCommand(registry)
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{"queue": "test", "index": float64(7)}}
+	require.NoError(t, c.Validator(req))
+	_, err := c.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.NotContains(t, queue.letters, uint64(7))
+
+	// dropping twice fails, since the job is no longer dead-lettered
+	_, err = c.Handler(context.Background(), req)
+	assert.ErrorAs(t, err, &admin.InvalidAdminReqError{})
+}
+
+func TestQueueAndIndexValidation(t *testing.T) {
+	registry, _ := setupRegistry(t)
+
+	for name, data := range map[string]interface{}{
+		"not a map":      "test",
+		"missing queue":  map[string]interface{}{"index": float64(3)},
+		"unknown queue":  map[string]interface{}{"queue": "unknown", "index": float64(3)},
+		"missing index":  map[string]interface{}{"queue": "test"},
+		"negative index": map[string]interface{}{"queue": "test", "index": float64(-1)},
+		"invalid index":  map[string]interface{}{"queue": "test", "index": float64(1.5)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, command := range []interface {
+				Validator(*admin.CommandRequest) error
+			}{NewRetryDeadLetterCommand(registry), NewDropDeadLetterCommand(registry)} {
+				err := command.Validator(&admin.CommandRequest{Data: data})
+				require.Error(t, err)
+				assert.ErrorAs(t, err, &admin.InvalidAdminReqError{})
+			}
+		})
+	}
+}
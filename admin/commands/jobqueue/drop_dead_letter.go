@@ -0,0 +1,52 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/jobqueue"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*DropDeadLetterCommand)(nil)
+
+// DropDeadLetterCommand is an admin command which removes a dead letter without processing the job,
+// e.g. once the job has been dealt with manually.
+type DropDeadLetterCommand struct {
+	registry *jobqueue.Registry
+}
+
+func NewDropDeadLetterCommand(registry *jobqueue.Registry) *DropDeadLetterCommand {
+	return &DropDeadLetterCommand{
+		registry: registry,
+	}
+}
+
+func (c *DropDeadLetterCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*queueAndIndexReqData)
+
+	err := data.queue.DeadLetters.DropDeadLetter(data.index)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, admin.NewInvalidAdminReqParameterError("index", "job is not dead-lettered", data.index)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("module", "admin-tool").
+		Str("queue", data.queue.Name).
+		Uint64("index", data.index).
+		Msg("dropped dead-lettered job")
+
+	return "ok", nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *DropDeadLetterCommand) Validator(req *admin.CommandRequest) error {
+	return validateQueueAndIndex(c.registry, req)
+}
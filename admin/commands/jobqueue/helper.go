@@ -0,0 +1,70 @@
+package jobqueue
+
+import (
+	"math"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/module/jobqueue"
+)
+
+// findQueue returns the job queue with the name given by the "queue" field of the input.
+// Returns admin.InvalidAdminReqError if the field is missing or no such job queue is registered.
+func findQueue(registry *jobqueue.Registry, input map[string]interface{}) (jobqueue.RegisteredQueue, error) {
+	value, ok := input["queue"]
+	if !ok {
+		return jobqueue.RegisteredQueue{}, admin.NewInvalidAdminReqErrorf("missing required field 'queue'")
+	}
+	name, ok := value.(string)
+	if !ok {
+		return jobqueue.RegisteredQueue{}, admin.NewInvalidAdminReqParameterError("queue", "must be a string", value)
+	}
+	queue, ok := registry.ByName(name)
+	if !ok {
+		return jobqueue.RegisteredQueue{}, admin.NewInvalidAdminReqParameterError("queue", "unknown job queue", name)
+	}
+	return queue, nil
+}
+
+// parseIndex parses the job index given by the "index" field of the input.
+// Returns admin.InvalidAdminReqError if the field is missing or not a non-negative integer.
+func parseIndex(input map[string]interface{}) (uint64, error) {
+	value, ok := input["index"]
+	if !ok {
+		return 0, admin.NewInvalidAdminReqErrorf("missing required field 'index'")
+	}
+	n, ok := value.(float64)
+	if !ok || math.Trunc(n) != n || n < 0 {
+		return 0, admin.NewInvalidAdminReqParameterError("index", "must be a non-negative integer", value)
+	}
+	return uint64(n), nil
+}
+
+// queueAndIndexReqData is the validated input of commands operating on a single dead letter.
+type queueAndIndexReqData struct {
+	queue jobqueue.RegisteredQueue
+	index uint64
+}
+
+// validateQueueAndIndex validates requests with the required "queue" and "index" fields.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func validateQueueAndIndex(registry *jobqueue.Registry, req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	queue, err := findQueue(registry, input)
+	if err != nil {
+		return err
+	}
+	index, err := parseIndex(input)
+	if err != nil {
+		return err
+	}
+
+	req.ValidatorData = &queueAndIndexReqData{
+		queue: queue,
+		index: index,
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package jobqueue
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/jobqueue"
+)
+
+var _ commands.AdminCommand = (*ListDeadLettersCommand)(nil)
+
+type listDeadLettersReqData struct {
+	queue *jobqueue.RegisteredQueue // nil if the registered job queues should be listed
+}
+
+// ListDeadLettersCommand is an admin command which lists the registered job queues together with
+// their number of dead letters, or, if a job queue is specified, the dead letters of that queue.
+type ListDeadLettersCommand struct {
+	registry *jobqueue.Registry
+}
+
+func NewListDeadLettersCommand(registry *jobqueue.Registry) *ListDeadLettersCommand {
+	return &ListDeadLettersCommand{
+		registry: registry,
+	}
+}
+
+func (c *ListDeadLettersCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*listDeadLettersReqData)
+
+	if data.queue == nil {
+		queues := make([]interface{}, 0)
+		for _, queue := range c.registry.All() {
+			letters, err := queue.DeadLetters.DeadLetters()
+			if err != nil {
+				return nil, fmt.Errorf("could not read dead letters of job queue %s: %w", queue.Name, err)
+			}
+			queues = append(queues, map[string]interface{}{
+				"name":         queue.Name,
+				"dead_letters": len(letters),
+			})
+		}
+		return queues, nil
+	}
+
+	letters, err := data.queue.DeadLetters.DeadLetters()
+	if err != nil {
+		return nil, fmt.Errorf("could not read dead letters of job queue %s: %w", data.queue.Name, err)
+	}
+
+	listed, err := commands.ConvertToInterfaceList(letters)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"queue":        data.queue.Name,
+		"dead_letters": listed,
+	}, nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *ListDeadLettersCommand) Validator(req *admin.CommandRequest) error {
+	data := &listDeadLettersReqData{}
+
+	if req.Data == nil {
+		req.ValidatorData = data
+		return nil
+	}
+
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	if _, ok := input["queue"]; ok {
+		queue, err := findQueue(c.registry, input)
+		if err != nil {
+			return err
+		}
+		data.queue = &queue
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
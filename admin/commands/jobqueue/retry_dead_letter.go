@@ -0,0 +1,52 @@
+package jobqueue
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/jobqueue"
+	"github.com/onflow/flow-go/storage"
+)
+
+var _ commands.AdminCommand = (*RetryDeadLetterCommand)(nil)
+
+// RetryDeadLetterCommand is an admin command which processes a dead-lettered job again. The dead
+// letter is removed once the job succeeds, and updated if it fails again.
+type RetryDeadLetterCommand struct {
+	registry *jobqueue.Registry
+}
+
+func NewRetryDeadLetterCommand(registry *jobqueue.Registry) *RetryDeadLetterCommand {
+	return &RetryDeadLetterCommand{
+		registry: registry,
+	}
+}
+
+func (c *RetryDeadLetterCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*queueAndIndexReqData)
+
+	err := data.queue.DeadLetters.RetryDeadLetter(data.index)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, admin.NewInvalidAdminReqParameterError("index", "job is not dead-lettered", data.index)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info().Str("module", "admin-tool").
+		Str("queue", data.queue.Name).
+		Uint64("index", data.index).
+		Msg("retrying dead-lettered job")
+
+	return "ok", nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *RetryDeadLetterCommand) Validator(req *admin.CommandRequest) error {
+	return validateQueueAndIndex(c.registry, req)
+}
@@ -0,0 +1,133 @@
+package mempool
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
+)
+
+var _ commands.AdminCommand = (*EvictEntriesCommand)(nil)
+
+type evictEntriesReqData struct {
+	mempool mempool.RegisteredMempool
+	ids     map[flow.Identifier]struct{} // nil if entries are not filtered by ID
+	minAge  time.Duration                // 0 if entries are not filtered by age
+}
+
+// matches returns true if the given entry matches all filters of the request.
+// Entries without a known insertion time never match an age filter.
+func (d *evictEntriesReqData) matches(entry mempool.EntryInfo, now time.Time) bool {
+	if d.ids != nil {
+		if _, ok := d.ids[entry.EntityID]; !ok {
+			return false
+		}
+	}
+	if d.minAge > 0 {
+		if entry.Added.IsZero() || now.Sub(entry.Added) < d.minAge {
+			return false
+		}
+	}
+	return true
+}
+
+// EvictEntriesCommand is an admin command which removes the entries matching a filter from a
+// registered mempool. Entries can be filtered by ID, by minimum age, or both, in which case
+// only entries matching both filters are evicted.
+type EvictEntriesCommand struct {
+	registry *mempool.Registry
+}
+
+func NewEvictEntriesCommand(registry *mempool.Registry) *EvictEntriesCommand {
+	return &EvictEntriesCommand{
+		registry: registry,
+	}
+}
+
+func (c *EvictEntriesCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*evictEntriesReqData)
+
+	now := time.Now()
+	evicted := make([]flow.Identifier, 0)
+	for _, entry := range data.mempool.Introspector.Entries() {
+		if !data.matches(entry, now) {
+			continue
+		}
+		if data.mempool.Remove(entry.EntityID) {
+			evicted = append(evicted, entry.EntityID)
+		}
+	}
+
+	log.Info().Str("module", "admin-tool").
+		Str("mempool", data.mempool.Name).
+		Int("evicted", len(evicted)).
+		Msg("evicted mempool entries")
+
+	ids, err := commands.ConvertToInterfaceList(evicted)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"mempool": data.mempool.Name,
+		"evicted": len(evicted),
+		"ids":     ids,
+	}, nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *EvictEntriesCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	pool, err := findMempool(c.registry, input)
+	if err != nil {
+		return err
+	}
+	data := &evictEntriesReqData{
+		mempool: pool,
+	}
+
+	if value, ok := input["ids"]; ok {
+		list, ok := value.([]interface{})
+		if !ok || len(list) == 0 {
+			return admin.NewInvalidAdminReqParameterError("ids", "must be a non-empty list of hex encoded IDs", value)
+		}
+		data.ids = make(map[flow.Identifier]struct{}, len(list))
+		for _, item := range list {
+			id, err := parseID("ids", item)
+			if err != nil {
+				return err
+			}
+			data.ids[id] = struct{}{}
+		}
+	}
+
+	if value, ok := input["min_age"]; ok {
+		str, ok := value.(string)
+		if !ok {
+			return admin.NewInvalidAdminReqParameterError("min_age", "must be a duration string, e.g. \"10m\"", value)
+		}
+		minAge, err := time.ParseDuration(str)
+		if err != nil || minAge <= 0 {
+			return admin.NewInvalidAdminReqParameterError("min_age", "must be a positive duration, e.g. \"10m\"", value)
+		}
+		data.minAge = minAge
+	}
+
+	// require at least one filter, so that a mempool is never emptied by accident
+	if data.ids == nil && data.minAge == 0 {
+		return admin.NewInvalidAdminReqErrorf("at least one of the filters 'ids' and 'min_age' must be specified")
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package mempool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// entries is a mempool.Introspector over a fixed set of entries.
+type entries map[flow.Identifier]mempool.EntryInfo
+
+func (e entries) Entries() []mempool.EntryInfo {
+	all := make([]mempool.EntryInfo, 0, len(e))
+	for _, entry := range e {
+		all = append(all, entry)
+	}
+	return all
+}
+
+func (e entries) Entry(id flow.Identifier) (mempool.EntryInfo, bool) {
+	entry, ok := e[id]
+	return entry, ok
+}
+
+func (e entries) Remove(id flow.Identifier) bool {
+	_, ok := e[id]
+	delete(e, id)
+	return ok
+}
+
+// setupRegistry returns a registry with a mempool named "test", holding entities added
+// at the given times.
+func setupRegistry(t *testing.T, added ...time.Time) (*mempool.Registry, entries) {
+	pool := make(entries)
+	for _, at := range added {
+		entity := unittest.MockEntityFixture()
+		pool[entity.ID()] = mempool.EntryInfo{EntityID: entity.ID(), Entity: entity, Added: at}
+	}
+	registry := mempool.NewRegistry()
+	require.NoError(t, registry.Register("test", pool, pool.Remove))
+	return registry, pool
+}
+
+func TestEvictEntries_Validation(t *testing.T) {
+	registry, _ := setupRegistry(t)
+	c := NewEvictEntriesCommand(registry)
+
+	for name, data := range map[string]interface{}{
+		"not a map":       "test",
+		"missing mempool": map[string]interface{}{"min_age": "1m"},
+		"unknown mempool": map[string]interface{}{"mempool": "unknown", "min_age": "1m"},
+		"no filter":       map[string]interface{}{"mempool": "test"},
+		"empty ids":       map[string]interface{}{"mempool": "test", "ids": []interface{}{}},
+		"invalid id":      map[string]interface{}{"mempool": "test", "ids": []interface{}{"abc"}},
+		"invalid min_age": map[string]interface{}{"mempool": "test", "min_age": "ten minutes"},
+		"negative age":    map[string]interface{}{"mempool": "test", "min_age": "-1m"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := c.Validator(&admin.CommandRequest{Data: data})
+			require.Error(t, err)
+			assert.ErrorAs(t, err, &admin.InvalidAdminReqError{})
+		})
+	}
+}
+
+func TestEvictEntries(t *testing.T) {
+	now := time.Now()
+	registry, pool := setupRegistry(t, now.Add(-time.Hour), now.Add(-time.Hour), now, time.Time{})
+	c := NewEvictEntriesCommand(registry)
+
+	var old, recent, unknown []flow.Identifier
+	for id, entry := range pool {
+		switch {
+		case entry.Added.IsZero():
+			unknown = append(unknown, id)
+		case entry.Added.Before(now):
+			old = append(old, id)
+		default:
+			recent = append(recent, id)
+		}
+	}
+
+	// filtering by age and ID evicts only entries matching both filters
+	req := &admin.CommandRequest{Data: map[string]interface{}{
+		"mempool": "test",
+		"ids":     []interface{}{old[0].String(), recent[0].String(), unknown[0].String()},
+		"min_age": "10m",
+	}}
+	require.NoError(t, c.Validator(req))
+	result, err := c.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.(map[string]interface{})["evicted"])
+	assert.Equal(t, []interface{}{old[0].String()}, result.(map[string]interface{})["ids"])
+	assert.NotContains(t, pool, old[0])
+
+	// filtering by ID only evicts entries without a known insertion time as well
+	req = &admin.CommandRequest{Data: map[string]interface{}{
+		"mempool": "test",
+		"ids":     []interface{}{unknown[0].String()},
+	}}
+	require.NoError(t, c.Validator(req))
+	result, err = c.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.(map[string]interface{})["evicted"])
+	assert.Len(t, pool, 2)
+	assert.Contains(t, pool, old[1])
+	assert.Contains(t, pool, recent[0])
+}
@@ -0,0 +1,80 @@
+package mempool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
+)
+
+var _ commands.AdminCommand = (*GetEntryCommand)(nil)
+
+type getEntryReqData struct {
+	mempool mempool.RegisteredMempool
+	id      flow.Identifier
+}
+
+// GetEntryCommand is an admin command which retrieves a single entry of a registered mempool
+// by its ID, including the full content of the entity.
+type GetEntryCommand struct {
+	registry *mempool.Registry
+}
+
+func NewGetEntryCommand(registry *mempool.Registry) *GetEntryCommand {
+	return &GetEntryCommand{
+		registry: registry,
+	}
+}
+
+func (c *GetEntryCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*getEntryReqData)
+
+	entry, ok := data.mempool.Introspector.Entry(data.id)
+	if !ok {
+		return nil, fmt.Errorf("mempool %s has no entry %v", data.mempool.Name, data.id)
+	}
+
+	result := map[string]interface{}{
+		"id":   entry.EntityID,
+		"type": fmt.Sprintf("%T", entry.Entity),
+	}
+	if !entry.Added.IsZero() {
+		result["added"] = entry.Added
+	}
+	result["entity"] = entry.Entity
+
+	return commands.ConvertToMap(result)
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *GetEntryCommand) Validator(req *admin.CommandRequest) error {
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	pool, err := findMempool(c.registry, input)
+	if err != nil {
+		return err
+	}
+
+	value, ok := input["id"]
+	if !ok {
+		return admin.NewInvalidAdminReqErrorf("missing required field 'id'")
+	}
+	id, err := parseID("id", value)
+	if err != nil {
+		return err
+	}
+
+	req.ValidatorData = &getEntryReqData{
+		mempool: pool,
+		id:      id,
+	}
+
+	return nil
+}
@@ -0,0 +1,108 @@
+package mempool
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
+)
+
+// entrySummary is the representation of a mempool entry returned when listing entries.
+type entrySummary struct {
+	ID     flow.Identifier        `json:"id"`
+	Type   string                 `json:"type"`
+	Added  *time.Time             `json:"added,omitempty"`
+	Age    string                 `json:"age,omitempty"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+}
+
+// summarize returns the summary of the given entry. The key fields of the entry are the
+// top level fields of its JSON representation holding scalar values, nested objects and
+// lists are omitted to keep the listing concise.
+func summarize(entry mempool.EntryInfo, now time.Time) entrySummary {
+	summary := entrySummary{
+		ID:   entry.EntityID,
+		Type: fmt.Sprintf("%T", entry.Entity),
+	}
+	if !entry.Added.IsZero() {
+		added := entry.Added
+		summary.Added = &added
+		summary.Age = now.Sub(added).Truncate(time.Millisecond).String()
+	}
+
+	fields, err := commands.ConvertToMap(entry.Entity)
+	if err != nil {
+		// not every entity is represented as a JSON object, those are listed without fields
+		return summary
+	}
+	summary.Fields = make(map[string]interface{})
+	for name, value := range fields {
+		switch value.(type) {
+		case string, float64, bool:
+			summary.Fields[name] = value
+		}
+	}
+	return summary
+}
+
+// sortByAge sorts the given entries from oldest to newest. Entries without a known
+// insertion time are sorted last, by ID.
+func sortByAge(entries []mempool.EntryInfo) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Added.IsZero() != b.Added.IsZero() {
+			return !a.Added.IsZero()
+		}
+		if !a.Added.Equal(b.Added) {
+			return a.Added.Before(b.Added)
+		}
+		return string(a.EntityID[:]) < string(b.EntityID[:])
+	})
+}
+
+// findMempool returns the mempool with the name given by the "mempool" field of the input.
+// Returns admin.InvalidAdminReqError if the field is missing or no such mempool is registered.
+func findMempool(registry *mempool.Registry, input map[string]interface{}) (mempool.RegisteredMempool, error) {
+	value, ok := input["mempool"]
+	if !ok {
+		return mempool.RegisteredMempool{}, admin.NewInvalidAdminReqErrorf("missing required field 'mempool'")
+	}
+	name, ok := value.(string)
+	if !ok {
+		return mempool.RegisteredMempool{}, admin.NewInvalidAdminReqParameterError("mempool", "must be a string", value)
+	}
+	pool, ok := registry.ByName(name)
+	if !ok {
+		return mempool.RegisteredMempool{}, admin.NewInvalidAdminReqParameterError("mempool", "unknown mempool", name)
+	}
+	return pool, nil
+}
+
+// parseID parses a hex encoded entity ID.
+// Returns admin.InvalidAdminReqError if the value is not a valid ID.
+func parseID(field string, value interface{}) (flow.Identifier, error) {
+	str, ok := value.(string)
+	if !ok {
+		return flow.ZeroID, admin.NewInvalidAdminReqParameterError(field, "must be a hex encoded ID", value)
+	}
+	id, err := flow.HexStringToIdentifier(str)
+	if err != nil {
+		return flow.ZeroID, admin.NewInvalidAdminReqParameterError(field, "must be a hex encoded ID", value)
+	}
+	return id, nil
+}
+
+// parsePositiveInt parses an integral float64 value >= 1.
+// Returns admin.InvalidAdminReqError if the value is not a positive integer.
+func parsePositiveInt(field string, value interface{}) (uint, error) {
+	n, ok := value.(float64)
+	if !ok || math.Trunc(n) != n || n < 1 {
+		return 0, admin.NewInvalidAdminReqParameterError(field, "must be a positive integer", value)
+	}
+	return uint(n), nil
+}
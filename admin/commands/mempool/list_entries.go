@@ -0,0 +1,104 @@
+package mempool
+
+import (
+	"context"
+	"time"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/admin/commands"
+	"github.com/onflow/flow-go/module/mempool"
+)
+
+var _ commands.AdminCommand = (*ListEntriesCommand)(nil)
+
+// DefaultListLimit is the maximum number of entries listed if no limit is specified.
+const DefaultListLimit = 100
+
+type listEntriesReqData struct {
+	mempool *mempool.RegisteredMempool // nil if the registered mempools should be listed
+	limit   uint
+}
+
+// ListEntriesCommand is an admin command which lists the registered mempools together with
+// their sizes, or, if a mempool is specified, the entries of that mempool from oldest to newest.
+type ListEntriesCommand struct {
+	registry *mempool.Registry
+}
+
+func NewListEntriesCommand(registry *mempool.Registry) *ListEntriesCommand {
+	return &ListEntriesCommand{
+		registry: registry,
+	}
+}
+
+func (c *ListEntriesCommand) Handler(_ context.Context, req *admin.CommandRequest) (interface{}, error) {
+	data := req.ValidatorData.(*listEntriesReqData)
+
+	if data.mempool == nil {
+		pools := make([]interface{}, 0)
+		for _, pool := range c.registry.All() {
+			pools = append(pools, map[string]interface{}{
+				"name": pool.Name,
+				"size": len(pool.Introspector.Entries()),
+			})
+		}
+		return pools, nil
+	}
+
+	entries := data.mempool.Introspector.Entries()
+	sortByAge(entries)
+
+	now := time.Now()
+	summaries := make([]entrySummary, 0, len(entries))
+	for i := 0; i < len(entries) && uint(i) < data.limit; i++ {
+		summaries = append(summaries, summarize(entries[i], now))
+	}
+
+	listed, err := commands.ConvertToInterfaceList(summaries)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"mempool": data.mempool.Name,
+		"size":    len(entries),
+		"entries": listed,
+	}, nil
+}
+
+// Validator validates the request.
+// Returns admin.InvalidAdminReqError for invalid/malformed requests.
+func (c *ListEntriesCommand) Validator(req *admin.CommandRequest) error {
+	data := &listEntriesReqData{
+		limit: DefaultListLimit,
+	}
+
+	if req.Data == nil {
+		req.ValidatorData = data
+		return nil
+	}
+
+	input, ok := req.Data.(map[string]interface{})
+	if !ok {
+		return admin.NewInvalidAdminReqFormatError("expected map[string]any")
+	}
+
+	if _, ok := input["mempool"]; ok {
+		pool, err := findMempool(c.registry, input)
+		if err != nil {
+			return err
+		}
+		data.mempool = &pool
+	}
+
+	if limit, ok := input["limit"]; ok {
+		n, err := parsePositiveInt("limit", limit)
+		if err != nil {
+			return err
+		}
+		data.limit = n
+	}
+
+	req.ValidatorData = data
+
+	return nil
+}
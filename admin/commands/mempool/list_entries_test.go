@@ -0,0 +1,82 @@
+package mempool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/admin"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+func TestListEntries_Mempools(t *testing.T) {
+	registry, _ := setupRegistry(t, time.Now(), time.Now())
+	c := NewListEntriesCommand(registry)
+
+	req := &admin.CommandRequest{}
+	require.NoError(t, c.Validator(req))
+	result, err := c.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"name": "test", "size": 2},
+	}, result)
+
+	// unregistered mempools are no longer listed
+	assert.True(t, registry.Unregister("test"))
+	assert.False(t, registry.Unregister("test"))
+	result, err = c.Handler(context.Background(), req)
+	require.NoError(t, err)
+	assert.Empty(t, result)
+}
+
+func TestListEntries_Entries(t *testing.T) {
+	now := time.Now()
+	registry, pool := setupRegistry(t, time.Time{}, now, now.Add(-time.Minute))
+	c := NewListEntriesCommand(registry)
+
+	req := &admin.CommandRequest{Data: map[string]interface{}{
+		"mempool": "test",
+		"limit":   float64(2),
+	}}
+	require.NoError(t, c.Validator(req))
+	result, err := c.Handler(context.Background(), req)
+	require.NoError(t, err)
+
+	listing := result.(map[string]interface{})
+	assert.Equal(t, "test", listing["mempool"])
+	assert.Equal(t, 3, listing["size"])
+
+	// entries are listed from oldest to newest, up to the limit
+	listed := listing["entries"].([]interface{})
+	require.Len(t, listed, 2)
+	for i, expected := range []time.Time{now.Add(-time.Minute), now} {
+		entry := listed[i].(map[string]interface{})
+		id, err := flow.HexStringToIdentifier(entry["id"].(string))
+		require.NoError(t, err)
+		assert.Equal(t, expected, pool[id].Added)
+		assert.Equal(t, "*unittest.MockEntity", entry["type"])
+		assert.Contains(t, entry, "age")
+		assert.Equal(t, id.String(), entry["fields"].(map[string]interface{})["Identifier"])
+	}
+}
+
+func TestListEntries_Validation(t *testing.T) {
+	registry, _ := setupRegistry(t)
+	c := NewListEntriesCommand(registry)
+
+	for name, data := range map[string]interface{}{
+		"not a map":       "test",
+		"unknown mempool": map[string]interface{}{"mempool": "unknown"},
+		"invalid limit":   map[string]interface{}{"mempool": "test", "limit": float64(1.5)},
+		"zero limit":      map[string]interface{}{"mempool": "test", "limit": float64(0)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := c.Validator(&admin.CommandRequest{Data: data})
+			require.Error(t, err)
+			assert.ErrorAs(t, err, &admin.InvalidAdminReqError{})
+		})
+	}
+}
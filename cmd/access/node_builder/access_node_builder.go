@@ -34,6 +34,7 @@ import (
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/engine/access/ingestion"
 	pingeng "github.com/onflow/flow-go/engine/access/ping"
+	"github.com/onflow/flow-go/engine/access/ratelimit"
 	"github.com/onflow/flow-go/engine/access/rpc"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	"github.com/onflow/flow-go/engine/access/state_stream"
@@ -50,6 +51,7 @@ import (
 	"github.com/onflow/flow-go/module/compliance"
 	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/health"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
@@ -108,6 +110,7 @@ type AccessNodeConfig struct {
 	nodeInfoFile                 string
 	apiRatelimits                map[string]int
 	apiBurstlimits               map[string]int
+	clientRateLimitsConfigFile   string
 	rpcConf                      rpc.Config
 	ExecutionNodeAddress         string // deprecated
 	HistoricalAccessRPCs         []access.AccessAPIClient
@@ -163,6 +166,7 @@ func DefaultAccessNodeConfig() *AccessNodeConfig {
 		nodeInfoFile:                 "",
 		apiRatelimits:                nil,
 		apiBurstlimits:               nil,
+		clientRateLimitsConfigFile:   "",
 		PublicNetworkConfig: PublicNetworkConfig{
 			BindAddress: cmd.NotSet,
 			Metrics:     metrics.NewNoopCollector(),
@@ -253,7 +257,10 @@ func (builder *FlowAccessNodeBuilder) buildSyncCore() *FlowAccessNodeBuilder {
 		syncCore, err := chainsync.New(node.Logger, node.SyncCoreConfig, metrics.NewChainSyncCollector())
 		builder.SyncCore = syncCore
 
-		return err
+		if err != nil {
+			return err
+		}
+		return node.HealthRegistry.AddCheck(health.FinalizedHeightLagCheck, health.NewFinalizedHeightLagCheck(node.State, syncCore.ReportedHeight, node.HealthConfig.MaxFinalizedHeightLag))
 	})
 
 	return builder
@@ -596,6 +603,8 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.IntVar(&builder.rpcConf.MaxExecutionDataMsgSize, "max-block-msg-size", defaultConfig.rpcConf.MaxExecutionDataMsgSize, "maximum size for a gRPC message containing block execution data")
 		flags.StringSliceVar(&builder.rpcConf.PreferredExecutionNodeIDs, "preferred-execution-node-ids", defaultConfig.rpcConf.PreferredExecutionNodeIDs, "comma separated list of execution nodes ids to choose from when making an upstream call e.g. b4a4dbdcd443d...,fb386a6a... etc.")
 		flags.StringSliceVar(&builder.rpcConf.FixedExecutionNodeIDs, "fixed-execution-node-ids", defaultConfig.rpcConf.FixedExecutionNodeIDs, "comma separated list of execution nodes ids to choose from when making an upstream call if no matching preferred execution id is found e.g. b4a4dbdcd443d...,fb386a6a... etc.")
+		flags.UintVar(&builder.rpcConf.RegisterProofPort, "execution-register-proof-port", defaultConfig.rpcConf.RegisterProofPort, "the port of the register proof server of all execution nodes, used to serve registers with proofs (disabled if zero)")
+		flags.StringVar(&builder.rpcConf.RegisterProofToken, "execution-register-proof-token", defaultConfig.rpcConf.RegisterProofToken, "bearer token presented to the register proof server of execution nodes")
 		flags.BoolVar(&builder.logTxTimeToFinalized, "log-tx-time-to-finalized", defaultConfig.logTxTimeToFinalized, "log transaction time to finalized")
 		flags.BoolVar(&builder.logTxTimeToExecuted, "log-tx-time-to-executed", defaultConfig.logTxTimeToExecuted, "log transaction time to executed")
 		flags.BoolVar(&builder.logTxTimeToFinalizedExecuted, "log-tx-time-to-finalized-executed", defaultConfig.logTxTimeToFinalizedExecuted, "log transaction time to finalized and executed")
@@ -605,6 +614,7 @@ func (builder *FlowAccessNodeBuilder) extraFlags() {
 		flags.StringVarP(&builder.nodeInfoFile, "node-info-file", "", defaultConfig.nodeInfoFile, "full path to a json file which provides more details about nodes when reporting its reachability metrics")
 		flags.StringToIntVar(&builder.apiRatelimits, "api-rate-limits", defaultConfig.apiRatelimits, "per second rate limits for Access API methods e.g. Ping=300,GetTransaction=500 etc.")
 		flags.StringToIntVar(&builder.apiBurstlimits, "api-burst-limits", defaultConfig.apiBurstlimits, "burst limits for Access API methods e.g. Ping=100,GetTransaction=100 etc.")
+		flags.StringVar(&builder.clientRateLimitsConfigFile, "client-rate-limits-config", defaultConfig.clientRateLimitsConfigFile, "full path to a json file defining per-client rate limit tiers and the api keys assigned to them (if empty, clients are not rate limited)")
 		flags.BoolVar(&builder.supportsObserver, "supports-observer", defaultConfig.supportsObserver, "true if this staked access node supports observer or follower connections")
 		flags.StringVar(&builder.PublicNetworkConfig.BindAddress, "public-network-address", defaultConfig.PublicNetworkConfig.BindAddress, "staked access node's public network bind address")
 
@@ -750,6 +760,10 @@ func (builder *FlowAccessNodeBuilder) Initialize() error {
 		return err
 	}
 
+	if builder.HealthConfig.Addr != "" {
+		builder.EnqueueHealthServerInit()
+	}
+
 	builder.EnqueueTracer()
 	builder.PreInit(cmd.DynamicStartPreInit)
 	return nil
@@ -832,7 +846,22 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			}
 
 			builder.BlocksToMarkExecuted, err = stdmap.NewTimes(1 * 300) // assume 1 block per second * 300 seconds
-			return err
+			if err != nil {
+				return err
+			}
+
+			for name, pool := range map[string]*stdmap.Backend{
+				"transaction-timings":           builder.TransactionTimings.Backend,
+				"collections-to-mark-finalized": builder.CollectionsToMarkFinalized.Backend,
+				"collections-to-mark-executed":  builder.CollectionsToMarkExecuted.Backend,
+				"blocks-to-mark-executed":       builder.BlocksToMarkExecuted.Backend,
+			} {
+				err = node.MempoolRegistry.Register(name, pool, pool.Remove)
+				if err != nil {
+					return fmt.Errorf("could not register mempool: %w", err)
+				}
+			}
+			return nil
 		}).
 		Module("transaction metrics", func(node *cmd.NodeConfig) error {
 			builder.TransactionMetrics = metrics.NewTransactionCollector(builder.TransactionTimings, node.Logger, builder.logTxTimeToFinalized,
@@ -857,6 +886,17 @@ func (builder *FlowAccessNodeBuilder) Build() (cmd.Node, error) {
 			builder.rpcConf.TransportCredentials = credentials.NewTLS(tlsConfig)
 			return nil
 		}).
+		Module("client rate limits", func(node *cmd.NodeConfig) error {
+			if builder.clientRateLimitsConfigFile == "" {
+				return nil
+			}
+			config, err := ratelimit.LoadConfig(builder.clientRateLimitsConfigFile)
+			if err != nil {
+				return err
+			}
+			builder.rpcConf.ClientRateLimits = config
+			return nil
+		}).
 		Component("RPC engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			engineBuilder, err := rpc.NewBuilder(
 				node.Logger,
@@ -5,9 +5,6 @@ import (
 	model "github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/cluster"
 	"github.com/onflow/flow-go/model/flow"
-	"github.com/onflow/flow-go/model/flow/assignment"
-	"github.com/onflow/flow-go/model/flow/factory"
-	"github.com/onflow/flow-go/model/flow/filter"
 )
 
 // Construct cluster assignment with internal and partner nodes uniformly
@@ -15,32 +12,10 @@ import (
 // assignments for the same partner and internal lists, and the same seed.
 func constructClusterAssignment(partnerNodes, internalNodes []model.NodeInfo, seed int64) (flow.AssignmentList, flow.ClusterList) {
 
-	partners := model.ToIdentityList(partnerNodes).Filter(filter.HasRole(flow.RoleCollection))
-	internals := model.ToIdentityList(internalNodes).Filter(filter.HasRole(flow.RoleCollection))
+	partners := model.ToIdentityList(partnerNodes)
+	internals := model.ToIdentityList(internalNodes)
 
-	// deterministically shuffle both collector lists based on the input seed
-	// by using a different seed each spork, we will have different clusters
-	// even with the same collectors
-	partners = partners.DeterministicShuffle(seed)
-	internals = internals.DeterministicShuffle(seed)
-
-	nClusters := flagCollectionClusters
-	identifierLists := make([]flow.IdentifierList, nClusters)
-
-	// first, round-robin internal nodes into each cluster
-	for i, node := range internals {
-		identifierLists[i%len(identifierLists)] = append(identifierLists[i%len(identifierLists)], node.NodeID)
-	}
-
-	// next, round-robin partner nodes into each cluster
-	for i, node := range partners {
-		identifierLists[i%len(identifierLists)] = append(identifierLists[i%len(identifierLists)], node.NodeID)
-	}
-
-	assignments := assignment.FromIdentifierLists(identifierLists)
-
-	collectors := append(partners, internals...)
-	clusters, err := factory.NewClusterList(assignments, collectors)
+	assignments, clusters, err := run.ConstructClusterAssignment(partners, internals, flagCollectionClusters, seed)
 	if err != nil {
 		log.Fatal().Err(err).Msg("could not create cluster list")
 	}
@@ -1,5 +0,0 @@
-package cmd
-
-const (
-	minNodesPerCluster = 3
-)
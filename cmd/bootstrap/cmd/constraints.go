@@ -1,29 +1,10 @@
 package cmd
 
 import (
+	"github.com/onflow/flow-go/cmd/bootstrap/run"
 	model "github.com/onflow/flow-go/model/bootstrap"
-	"github.com/onflow/flow-go/model/flow"
-	"github.com/onflow/flow-go/model/flow/filter"
 )
 
-// ensureUniformNodeWeightsPerRole verifies that the following condition is satisfied for each role R:
-// * all node with role R must have the same weight
-func ensureUniformNodeWeightsPerRole(allNodes flow.IdentityList) {
-	// ensure all nodes of the same role have equal weight
-	for _, role := range flow.Roles() {
-		withRole := allNodes.Filter(filter.HasRole(role))
-		expectedWeight := withRole[0].Weight
-		for _, node := range withRole {
-			if node.Weight != expectedWeight {
-				log.Fatal().Msgf(
-					"will not bootstrap configuration with non-equal weights\n"+
-						"found nodes with role %s and weight1=%d, weight2=%d",
-					role, expectedWeight, node.Weight)
-			}
-		}
-	}
-}
-
 // Checks constraints about the number of partner and internal nodes.
 //   - Internal nodes must comprise >2/3 of each collector cluster.
 //   - for all roles R:
@@ -33,7 +14,10 @@ func checkConstraints(partnerNodes, internalNodes []model.NodeInfo) {
 	internals := model.ToIdentityList(internalNodes)
 	all := append(partners, internals...)
 
-	ensureUniformNodeWeightsPerRole(all)
+	err := run.EnsureUniformNodeWeightsPerRole(all)
+	if err != nil {
+		log.Fatal().Err(err).Msg("will not bootstrap configuration with non-equal weights")
+	}
 
 	// check collection committee Byzantine threshold for each cluster
 	// for checking Byzantine constraints, the seed doesn't matter
@@ -51,22 +35,17 @@ func checkConstraints(partnerNodes, internalNodes []model.NodeInfo) {
 				clusterInternalCount++
 			}
 		}
-		if clusterInternalCount <= clusterPartnerCount*2 {
-			log.Fatal().Msgf(
-				"will not bootstrap configuration without Byzantine majority within cluster: "+
-					"(partners=%d, internals=%d, min_internals=%d)",
-				clusterPartnerCount, clusterInternalCount, clusterPartnerCount*2+1)
+		err = run.CheckClusterByzantineThreshold(clusterPartnerCount, clusterInternalCount)
+		if err != nil {
+			log.Fatal().Err(err).Msg("will not bootstrap configuration without Byzantine majority within cluster")
 		}
 		partnerCOLCount += clusterPartnerCount
 		internalCOLCount += clusterInternalCount
 	}
 
 	// ensure we have enough total collectors
-	totalCollectors := partnerCOLCount + internalCOLCount
-	if totalCollectors < flagCollectionClusters*minNodesPerCluster {
-		log.Fatal().Msgf(
-			"will not bootstrap configuration with insufficient # of collectors for cluster count: "+
-				"(total_collectors=%d, clusters=%d, min_total_collectors=%d)",
-			totalCollectors, flagCollectionClusters, flagCollectionClusters*minNodesPerCluster)
+	err = run.CheckCollectorCount(partnerCOLCount+internalCOLCount, flagCollectionClusters)
+	if err != nil {
+		log.Fatal().Err(err).Msg("will not bootstrap configuration with insufficient # of collectors for cluster count")
 	}
 }
@@ -0,0 +1,53 @@
+package run
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/assignment"
+	"github.com/onflow/flow-go/model/flow/factory"
+	"github.com/onflow/flow-go/model/flow/filter"
+)
+
+// ConstructClusterAssignment constructs a cluster assignment with internal and
+// partner collection nodes uniformly distributed across nClusters clusters.
+// This function will produce the same cluster assignments for the same partner
+// and internal lists, and the same seed. Nodes with roles other than collection
+// are ignored.
+func ConstructClusterAssignment(partners, internals flow.IdentityList, nClusters uint, seed int64) (flow.AssignmentList, flow.ClusterList, error) {
+
+	if nClusters == 0 {
+		return nil, nil, fmt.Errorf("need at least one collection cluster")
+	}
+
+	partners = partners.Filter(filter.HasRole(flow.RoleCollection))
+	internals = internals.Filter(filter.HasRole(flow.RoleCollection))
+
+	// deterministically shuffle both collector lists based on the input seed
+	// by using a different seed each spork, we will have different clusters
+	// even with the same collectors
+	partners = partners.DeterministicShuffle(seed)
+	internals = internals.DeterministicShuffle(seed)
+
+	identifierLists := make([]flow.IdentifierList, nClusters)
+
+	// first, round-robin internal nodes into each cluster
+	for i, node := range internals {
+		identifierLists[i%len(identifierLists)] = append(identifierLists[i%len(identifierLists)], node.NodeID)
+	}
+
+	// next, round-robin partner nodes into each cluster
+	for i, node := range partners {
+		identifierLists[i%len(identifierLists)] = append(identifierLists[i%len(identifierLists)], node.NodeID)
+	}
+
+	assignments := assignment.FromIdentifierLists(identifierLists)
+
+	collectors := append(partners, internals...)
+	clusters, err := factory.NewClusterList(assignments, collectors)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create cluster list: %w", err)
+	}
+
+	return assignments, clusters, nil
+}
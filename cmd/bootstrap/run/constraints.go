@@ -0,0 +1,47 @@
+package run
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+)
+
+// MinNodesPerCluster is the smallest number of collection nodes per cluster
+// which we consider a healthy configuration.
+const MinNodesPerCluster = 3
+
+// EnsureUniformNodeWeightsPerRole verifies that the following condition is satisfied for each role R:
+// * all node with role R must have the same weight
+func EnsureUniformNodeWeightsPerRole(nodes flow.IdentityList) error {
+	for _, role := range flow.Roles() {
+		withRole := nodes.Filter(filter.HasRole(role))
+		for _, node := range withRole {
+			if node.Weight != withRole[0].Weight {
+				return fmt.Errorf("found nodes with role %s and non-equal weights (weight1=%d, weight2=%d)",
+					role, withRole[0].Weight, node.Weight)
+			}
+		}
+	}
+	return nil
+}
+
+// CheckClusterByzantineThreshold verifies that internal nodes comprise >2/3 of a collector
+// cluster with the given number of partner and internal nodes.
+func CheckClusterByzantineThreshold(partners, internals uint) error {
+	if internals <= partners*2 {
+		return fmt.Errorf("no Byzantine majority of internal nodes within cluster (partners=%d, internals=%d, min_internals=%d)",
+			partners, internals, partners*2+1)
+	}
+	return nil
+}
+
+// CheckCollectorCount verifies that there are enough collection nodes to fill the given
+// number of clusters with at least MinNodesPerCluster nodes each.
+func CheckCollectorCount(collectors, clusters uint) error {
+	if collectors < clusters*MinNodesPerCluster {
+		return fmt.Errorf("insufficient # of collectors for cluster count (total_collectors=%d, clusters=%d, min_total_collectors=%d)",
+			collectors, clusters, clusters*MinNodesPerCluster)
+	}
+	return nil
+}
@@ -10,10 +10,13 @@ import (
 	"github.com/onflow/flow-go/module/mempool/queue"
 
 	sdkcrypto "github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go/admin/commands"
+	commonCommands "github.com/onflow/flow-go/admin/commands/common"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/cmd/util/cmd/common"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/evidence"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
 	hotsignature "github.com/onflow/flow-go/consensus/hotstuff/signature"
@@ -38,6 +41,7 @@ import (
 	modulecompliance "github.com/onflow/flow-go/module/compliance"
 	"github.com/onflow/flow-go/module/epochs"
 	confinalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/health"
 	"github.com/onflow/flow-go/module/mempool"
 	epochpool "github.com/onflow/flow-go/module/mempool/epochs"
 	"github.com/onflow/flow-go/module/mempool/herocache"
@@ -71,9 +75,12 @@ func main() {
 		blockRateDelay                         time.Duration
 		startupTimeString                      string
 		startupTime                            time.Time
+		slashingEvidenceAddr                   string
 
 		followerState           protocol.MutableState
 		ingestConf              = ingest.DefaultConfig()
+		preValidationEnabled    bool
+		preValidationConf       = ingest.DefaultPreValidationConfig()
 		rpcConf                 rpc.Config
 		clusterComplianceConfig modulecompliance.Config
 
@@ -87,6 +94,8 @@ func main() {
 		mainChainSyncCore *chainsync.Core
 		followerEng       *followereng.Engine
 		colMetrics        module.CollectionMetrics
+		slashingEvidence  *evidence.Store
+		evidenceCollector *evidence.Collector
 		err               error
 
 		// epoch qc contract client
@@ -118,6 +127,20 @@ func main() {
 			"expiry buffer for inbound transactions")
 		flags.UintVar(&ingestConf.PropagationRedundancy, "ingest-tx-propagation-redundancy", 10,
 			"how many additional cluster members we propagate transactions to")
+		flags.BoolVar(&preValidationEnabled, "ingest-pre-validation-enabled", false,
+			"whether we pre-validate inbound transactions against the account state read from the access node")
+		flags.Uint64Var(&preValidationConf.BaseFee, "ingest-pre-validation-base-fee", preValidationConf.BaseFee,
+			"fee (in 10^-8 FLOW) every transaction payer is expected to cover, used to pre-validate payer balances")
+		flags.Uint64Var(&preValidationConf.FeePerGasUnit, "ingest-pre-validation-fee-per-gas-unit", preValidationConf.FeePerGasUnit,
+			"fee (in 10^-8 FLOW) per unit of transaction gas limit a payer is expected to cover, used to pre-validate payer balances")
+		flags.DurationVar(&preValidationConf.ReadTimeout, "ingest-pre-validation-timeout", preValidationConf.ReadTimeout,
+			"maximum time to wait for the account state when pre-validating a transaction")
+		flags.UintVar(&preValidationConf.CacheSize, "ingest-pre-validation-cache-size", preValidationConf.CacheSize,
+			"maximum number of accounts cached for pre-validation, 0 disables caching")
+		flags.DurationVar(&preValidationConf.CacheTTL, "ingest-pre-validation-cache-ttl", preValidationConf.CacheTTL,
+			"how long accounts cached for pre-validation are used before they are read again")
+		flags.DurationVar(&preValidationConf.CacheMaxStaleness, "ingest-pre-validation-cache-max-staleness", preValidationConf.CacheMaxStaleness,
+			"how long expired accounts cached for pre-validation are still used, while they are read again in the background")
 		flags.UintVar(&builderExpiryBuffer, "builder-expiry-buffer", builder.DefaultExpiryBuffer,
 			"expiry buffer for transactions in proposed collections")
 		flags.BoolVar(&builderPayerRateLimitDryRun, "builder-rate-limit-dry-run", false,
@@ -150,6 +173,7 @@ func main() {
 		flags.Uint64Var(&clusterComplianceConfig.SkipNewProposalsThreshold,
 			"cluster-compliance-skip-proposals-threshold", modulecompliance.DefaultConfig().SkipNewProposalsThreshold, "threshold at which new proposals are discarded rather than cached, if their height is this much above local finalized height (cluster compliance engine)")
 		flags.StringVar(&startupTimeString, "hotstuff-startup-time", cmd.NotSet, "specifies date and time (in ISO 8601 format) after which the consensus participant may enter the first view (e.g (e.g 1996-04-24T15:04:05-07:00))")
+		flags.StringVar(&slashingEvidenceAddr, "slashing-evidence-addr", "", "address to serve the collected slashing evidence on over http (e.g. localhost:9011), disabled if empty")
 		flags.Uint32Var(&maxCollectionRequestCacheSize, "max-collection-provider-cache-size", provider.DefaultEntityRequestCacheSize, "maximum number of collection requests to cache for collection provider")
 		flags.UintVar(&collectionProviderWorkers, "collection-provider-workers", provider.DefaultRequestProviderWorkers, "number of workers to use for collection provider")
 		// epoch qc contract flags
@@ -193,18 +217,40 @@ func main() {
 			return err
 		}).
 		Module("transactions mempool", func(node *cmd.NodeConfig) error {
+			mempoolName := func(epoch uint64) string {
+				return fmt.Sprintf("transactions-epoch-%d", epoch)
+			}
+			// metrics of the pools, which are unregistered once the pool of the epoch is removed
+			poolMetrics := make(map[uint64]*metrics.HeroCacheCollector)
+
 			create := func(epoch uint64) mempool.Transactions {
 				var heroCacheMetricsCollector module.HeroCacheMetrics = metrics.NewNoopCollector()
 				if node.BaseConfig.HeroCacheMetricsEnable {
-					heroCacheMetricsCollector = metrics.CollectionNodeTransactionsCacheMetrics(node.MetricsRegisterer, epoch)
+					collector := metrics.CollectionNodeTransactionsCacheMetrics(node.MetricsRegisterer, epoch)
+					poolMetrics[epoch] = collector
+					heroCacheMetricsCollector = collector
 				}
-				return herocache.NewTransactions(
+				transactions := herocache.NewTransactions(
 					uint32(txLimit),
 					node.Logger,
 					heroCacheMetricsCollector)
+				name := mempoolName(epoch)
+				err := node.MempoolRegistry.Register(name, transactions, transactions.Remove)
+				if err != nil {
+					node.Logger.Warn().Err(err).Str("mempool", name).Msg("could not register transactions mempool for introspection")
+				}
+				return transactions
+			}
+			// create and release are both invoked holding the lock of the pools
+			release := func(epoch uint64) {
+				node.MempoolRegistry.Unregister(mempoolName(epoch))
+				if collector, ok := poolMetrics[epoch]; ok {
+					collector.Unregister(node.MetricsRegisterer)
+					delete(poolMetrics, epoch)
+				}
 			}
 
-			pools = epochpool.NewTransactionPools(create)
+			pools = epochpool.NewTransactionPools(create, epochpool.WithRelease(release))
 			err := node.Metrics.Mempool.Register(metrics.ResourceTransaction, pools.CombinedSize)
 			return err
 		}).
@@ -216,9 +262,20 @@ func main() {
 			colMetrics = metrics.NewCollectionCollector(node.Tracer)
 			return nil
 		}).
+		Module("slashing evidence store", func(node *cmd.NodeConfig) error {
+			slashingEvidence = evidence.NewStore(node.DB)
+			evidenceCollector, err = evidence.NewCollector(node.Logger, slashingEvidence)
+			return err
+		}).
+		AdminCommand("get-slashing-evidence", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return commonCommands.NewGetSlashingEvidenceCommand(slashingEvidence)
+		}).
 		Module("main chain sync core", func(node *cmd.NodeConfig) error {
 			mainChainSyncCore, err = chainsync.New(node.Logger, node.SyncCoreConfig, metrics.NewChainSyncCollector())
-			return err
+			if err != nil {
+				return err
+			}
+			return node.HealthRegistry.AddCheck(health.FinalizedHeightLagCheck, health.NewFinalizedHeightLagCheck(node.State, mainChainSyncCore.ReportedHeight, node.HealthConfig.MaxFinalizedHeightLag))
 		}).
 		Module("machine account config", func(node *cmd.NodeConfig) error {
 			machineAccountInfo, err = cmd.LoadNodeMachineAccountInfoFile(node.BootstrapDir, node.NodeID)
@@ -367,7 +424,40 @@ func main() {
 				pools,
 				ingestConf,
 			)
-			return ing, err
+			if err != nil {
+				return nil, err
+			}
+
+			if preValidationEnabled {
+				flowClient, err := common.FlowClient(flowClientConfigs[0])
+				if err != nil {
+					return nil, fmt.Errorf("failed to get flow client connection option for access node (0): %s %w", flowClientConfigs[0].AccessAddress, err)
+				}
+
+				var reader ingest.AccountReader = ingest.NewSDKAccountReader(flowClient)
+				if preValidationConf.CacheSize > 0 {
+					reader, err = ingest.NewCachingAccountReader(
+						reader,
+						preValidationConf.CacheSize,
+						preValidationConf.CacheTTL,
+						preValidationConf.CacheMaxStaleness,
+						preValidationConf.ReadTimeout,
+					)
+					if err != nil {
+						return nil, fmt.Errorf("could not create pre-validation account cache: %w", err)
+					}
+				}
+
+				// disable balance checks for transient networks, which do not have transaction fees
+				if node.RootChainID.Transient() {
+					preValidationConf.BaseFee = 0
+					preValidationConf.FeePerGasUnit = 0
+				}
+
+				ing.WithPreValidator(ingest.NewPreValidator(node.Logger, reader, preValidationConf))
+			}
+
+			return ing, nil
 		}).
 		Component("transaction ingress rpc server", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			server := rpc.New(
@@ -418,6 +508,15 @@ func main() {
 			)
 			return push, err
 		}).
+		Component("slashing evidence collector", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			return evidenceCollector, nil
+		}).
+		Component("slashing evidence server", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			if slashingEvidenceAddr == "" {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			return evidence.NewServer(node.Logger, slashingEvidence, slashingEvidenceAddr), nil
+		}).
 		// Epoch manager encapsulates and manages epoch-dependent engines as we
 		// transition between epochs
 		Component("epoch manager", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
@@ -500,6 +599,7 @@ func main() {
 				node.Me,
 				node.DB,
 				node.State,
+				evidenceCollector,
 				createMetrics,
 				opts...,
 			)
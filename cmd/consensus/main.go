@@ -14,12 +14,17 @@ import (
 
 	client "github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/onflow/flow-go/admin/commands"
+	commonCommands "github.com/onflow/flow-go/admin/commands/common"
+	consensusCommands "github.com/onflow/flow-go/admin/commands/consensus"
 	"github.com/onflow/flow-go/cmd"
 	"github.com/onflow/flow-go/cmd/util/cmd/common"
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/blockproducer"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/evidence"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 	"github.com/onflow/flow-go/consensus/hotstuff/pacemaker/timeout"
 	"github.com/onflow/flow-go/consensus/hotstuff/persister"
@@ -50,10 +55,12 @@ import (
 	dkgmodule "github.com/onflow/flow-go/module/dkg"
 	"github.com/onflow/flow-go/module/epochs"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/health"
 	"github.com/onflow/flow-go/module/mempool"
 	consensusMempools "github.com/onflow/flow-go/module/mempool/consensus"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/profiler"
 	"github.com/onflow/flow-go/module/updatable_configs"
 	"github.com/onflow/flow-go/module/validation"
 	"github.com/onflow/flow-go/network/channels"
@@ -90,6 +97,8 @@ func main() {
 		emergencySealing                       bool
 		dkgControllerConfig                    dkgmodule.ControllerConfig
 		startupTimeString                      string
+		sealingStatusAddr                      string
+		slashingEvidenceAddr                   string
 		startupTime                            time.Time
 
 		// DKG contract client
@@ -121,6 +130,9 @@ func main() {
 		dkgState                *bstorage.DKGState
 		safeBeaconKeys          *bstorage.SafeBeaconPrivateKeys
 		getSealingConfigs       module.SealingConfigsGetter
+		sealingStatus           *sealing.StatusReporter
+		slashingEvidence        *evidence.Store
+		evidenceCollector       *evidence.Collector
 	)
 
 	nodeBuilder := cmd.FlowNode(flow.RoleConsensus.String())
@@ -151,6 +163,8 @@ func main() {
 		flags.DurationVar(&dkgControllerConfig.BaseStartDelay, "dkg-controller-base-start-delay", dkgmodule.DefaultBaseStartDelay, "used to define the range for jitter prior to DKG start (eg. 500µs) - the base value is scaled quadratically with the # of DKG participants")
 		flags.DurationVar(&dkgControllerConfig.BaseHandleFirstBroadcastDelay, "dkg-controller-base-handle-first-broadcast-delay", dkgmodule.DefaultBaseHandleFirstBroadcastDelay, "used to define the range for jitter prior to DKG handling the first broadcast messages (eg. 50ms) - the base value is scaled quadratically with the # of DKG participants")
 		flags.DurationVar(&dkgControllerConfig.HandleSubsequentBroadcastDelay, "dkg-controller-handle-subsequent-broadcast-delay", dkgmodule.DefaultHandleSubsequentBroadcastDelay, "used to define the constant delay introduced prior to DKG handling subsequent broadcast messages (eg. 2s)")
+		flags.StringVar(&sealingStatusAddr, "sealing-status-addr", "", "address to serve the sealing status on over http (e.g. localhost:9010), disabled if empty")
+		flags.StringVar(&slashingEvidenceAddr, "slashing-evidence-addr", "", "address to serve the collected slashing evidence on over http (e.g. localhost:9011), disabled if empty")
 		flags.StringVar(&startupTimeString, "hotstuff-startup-time", cmd.NotSet, "specifies date and time (in ISO 8601 format) after which the consensus participant may enter the first view (e.g 1996-04-24T15:04:05-07:00)")
 	}).ValidateFlags(func() error {
 		nodeBuilder.Logger.Info().Str("startup_time_str", startupTimeString).Msg("got startup_time_str")
@@ -318,8 +332,12 @@ func main() {
 			return nil
 		}).
 		Module("collection guarantees mempool", func(node *cmd.NodeConfig) error {
-			guarantees, err = stdmap.NewGuarantees(guaranteeLimit)
-			return err
+			guaranteesMempool, err := stdmap.NewGuarantees(guaranteeLimit)
+			if err != nil {
+				return err
+			}
+			guarantees = guaranteesMempool
+			return node.MempoolRegistry.Register("guarantees", guaranteesMempool, guaranteesMempool.Remove)
 		}).
 		Module("execution receipts mempool", func(node *cmd.NodeConfig) error {
 			receipts = consensusMempools.NewExecutionTree()
@@ -345,19 +363,48 @@ func main() {
 				return fmt.Errorf("failed to wrap seals mempool into ExecStateForkSuppressor: %w", err)
 			}
 			err = node.Metrics.Mempool.Register(metrics.ResourcePendingIncorporatedSeal, seals.Size)
+			// entries are removed through the wrapping mempools, so that they stay consistent
+			err = node.MempoolRegistry.Register("incorporated-result-seals", rawMempool, seals.Remove)
+			if err != nil {
+				return fmt.Errorf("could not register seals mempool: %w", err)
+			}
 			return nil
 		}).
 		Module("pending receipts mempool", func(node *cmd.NodeConfig) error {
-			pendingReceipts = stdmap.NewPendingReceipts(node.Storage.Headers, pendingReceiptsLimit)
+			pendingReceiptsMempool := stdmap.NewPendingReceipts(node.Storage.Headers, pendingReceiptsLimit)
+			pendingReceipts = pendingReceiptsMempool
+			return node.MempoolRegistry.Register("pending-receipts", pendingReceiptsMempool, pendingReceiptsMempool.Remove)
+		}).
+		Module("sealing status reporter", func(node *cmd.NodeConfig) error {
+			sealingStatus = sealing.NewStatusReporter(node.State, node.Storage.Headers, receipts, getSealingConfigs)
 			return nil
 		}).
+		AdminCommand("get-sealing-status", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return consensusCommands.NewGetSealingStatusCommand(sealingStatus)
+		}).
+		Module("slashing evidence store", func(node *cmd.NodeConfig) error {
+			slashingEvidence = evidence.NewStore(node.DB)
+			evidenceCollector, err = evidence.NewCollector(node.Logger, slashingEvidence)
+			return err
+		}).
+		AdminCommand("get-slashing-evidence", func(config *cmd.NodeConfig) commands.AdminCommand {
+			return commonCommands.NewGetSlashingEvidenceCommand(slashingEvidence)
+		}).
 		Module("hotstuff main metrics", func(node *cmd.NodeConfig) error {
-			mainMetrics = metrics.NewHotstuffCollector(node.RootChainID)
+			mainMetrics = profiler.NewSlowBlockHotstuffMetrics(
+				metrics.NewHotstuffCollector(node.RootChainID),
+				node.Profiler,
+				node.Profiler.Triggers().SlowBlockThreshold,
+				metrics.HotstuffEventTypeOnProposal,
+			)
 			return nil
 		}).
 		Module("sync core", func(node *cmd.NodeConfig) error {
 			syncCore, err = chainsync.New(node.Logger, node.SyncCoreConfig, metrics.NewChainSyncCollector())
-			return err
+			if err != nil {
+				return err
+			}
+			return node.HealthRegistry.AddCheck(health.FinalizedHeightLagCheck, health.NewFinalizedHeightLagCheck(node.State, syncCore.ReportedHeight, node.HealthConfig.MaxFinalizedHeightLag))
 		}).
 		Module("finalization distributor", func(node *cmd.NodeConfig) error {
 			finalizationDistributor = pubsub.NewFinalizationDistributor()
@@ -425,11 +472,32 @@ func main() {
 				getSealingConfigs,
 			)
 
+			if err != nil {
+				return nil, err
+			}
+
 			// subscribe for finalization events from hotstuff
 			finalizationDistributor.AddOnBlockFinalizedConsumer(e.OnFinalizedBlock)
 			finalizationDistributor.AddOnBlockIncorporatedConsumer(e.OnBlockIncorporated)
 
-			return e, err
+			sealingStatus.Attach(e)
+
+			return e, nil
+		}).
+		Component("sealing status server", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			if sealingStatusAddr == "" {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			return sealing.NewStatusServer(node.Logger, sealingStatus, sealingStatusAddr), nil
+		}).
+		Component("slashing evidence collector", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			return evidenceCollector, nil
+		}).
+		Component("slashing evidence server", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
+			if slashingEvidenceAddr == "" {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+			return evidence.NewServer(node.Logger, slashingEvidence, slashingEvidenceAddr), nil
 		}).
 		Component("matching engine", func(node *cmd.NodeConfig) (module.ReadyDoneAware, error) {
 			receiptRequester, err = requester.New(
@@ -550,6 +618,15 @@ func main() {
 				node.Me,
 				beaconKeyStore,
 			)
+			// refuse signing proposals and votes conflicting with previously signed ones
+			protection := persister.NewSlashingProtection(node.DB, node.RootChainID)
+			finalizationDistributor.AddOnBlockFinalizedConsumer(func(block *model.Block) {
+				err := protection.Prune(block.View)
+				if err != nil {
+					node.Logger.Error().Err(err).Uint64("view", block.View).Msg("could not prune slashing protection")
+				}
+			})
+			signer = verification.NewProtectedSigner(signer, protection)
 			signer = verification.NewMetricsWrapper(signer, mainMetrics) // wrapper for measuring time spent with crypto-related operations
 
 			// initialize a logging notifier for hotstuff
@@ -584,6 +661,15 @@ func main() {
 
 			qcDistributor := pubsub.NewQCCreatedDistributor()
 			validator := consensus.NewValidator(mainMetrics, committee, forks)
+
+			// collect evidence of slashable offenses of other replicas
+			notifier.AddConsumer(evidenceCollector.Consumer(
+				node.RootChainID,
+				committee,
+				verification.NewCombinedVerifier(committee, hotsignature.NewConsensusSigDataPacker(committee)),
+				node.Storage.Headers,
+			))
+
 			voteProcessorFactory := votecollector.NewCombinedVoteProcessorFactory(committee, qcDistributor.OnQcConstructedFromVotes)
 			lowestViewForVoteProcessing := finalizedBlock.View + 1
 			aggregator, err := consensus.NewVoteAggregator(node.Logger,
@@ -784,6 +870,7 @@ func main() {
 					node.Me,
 					dkgContractClients,
 					dkgBrokerTunnel,
+					dkgState,
 					dkgControllerConfig,
 				),
 				viewsObserver,
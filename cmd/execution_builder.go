@@ -5,14 +5,17 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	goruntime "runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	badgerdb "github.com/dgraph-io/badger/v2"
 	"github.com/ipfs/go-cid"
 	badger "github.com/ipfs/go-ds-badger2"
 	"github.com/onflow/flow-core-contracts/lib/go/templates"
@@ -40,12 +43,14 @@ import (
 	"github.com/onflow/flow-go/engine/common/requester"
 	"github.com/onflow/flow-go/engine/common/synchronization"
 	"github.com/onflow/flow-go/engine/execution/checker"
+	"github.com/onflow/flow-go/engine/execution/checkpoint"
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/computation/committer"
 	"github.com/onflow/flow-go/engine/execution/computation/computer"
 	"github.com/onflow/flow-go/engine/execution/computation/computer/uploader"
 	"github.com/onflow/flow-go/engine/execution/ingestion"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
+	"github.com/onflow/flow-go/engine/execution/registers"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/engine/execution/state"
 	"github.com/onflow/flow-go/engine/execution/state/bootstrap"
@@ -54,6 +59,8 @@ import (
 	"github.com/onflow/flow-go/fvm/systemcontracts"
 	"github.com/onflow/flow-go/ledger/common/pathfinder"
 	ledger "github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/mtrie"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/payloadstore"
 	"github.com/onflow/flow-go/ledger/complete/wal"
 	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/model/flow"
@@ -63,13 +70,16 @@ import (
 	"github.com/onflow/flow-go/module/buffer"
 	"github.com/onflow/flow-go/module/chainsync"
 	"github.com/onflow/flow-go/module/compliance"
+	"github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack"
 	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 	exedataprovider "github.com/onflow/flow-go/module/executiondatasync/provider"
 	"github.com/onflow/flow-go/module/executiondatasync/pruner"
 	"github.com/onflow/flow-go/module/executiondatasync/tracker"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/health"
 	"github.com/onflow/flow-go/module/mempool/queue"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/module/profiler"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/channels"
 	"github.com/onflow/flow-go/network/p2p/blob"
@@ -79,6 +89,7 @@ import (
 	storageerr "github.com/onflow/flow-go/storage"
 	storage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/storage/badger/procedure"
+	sutil "github.com/onflow/flow-go/storage/util"
 )
 
 const (
@@ -143,6 +154,9 @@ type ExecutionNode struct {
 	executionDataTracker    tracker.Storage
 	blobService             network.BlobService
 	blobserviceDependable   *module.ProxiedReadyDoneAware
+	chunkDataPackPublisher  chunk_data_pack.Publisher
+	chunkDataPackDependable *module.ProxiedReadyDoneAware
+	checkpointExporter      *checkpoint.Exporter
 }
 
 func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
@@ -192,8 +206,12 @@ func (builder *ExecutionNodeBuilder) LoadComponentsAndModules() {
 		Component("execution state", exeNode.LoadExecutionState).
 		Component("stop control", exeNode.LoadStopControl).
 		Component("execution state ledger WAL compactor", exeNode.LoadExecutionStateLedgerWALCompactor).
+		Component("checkpoint exporter", exeNode.LoadCheckpointExporter).
+		Component("checkpoint export server", exeNode.LoadCheckpointExportServer).
+		Component("register proof server", exeNode.LoadRegisterProofServer).
 		Component("execution data pruner", exeNode.LoadExecutionDataPruner).
 		Component("blob service", exeNode.LoadBlobService).
+		Component("chunk data pack blob service", exeNode.LoadChunkDataPackBlobService).
 		Component("GCP block data uploader", exeNode.LoadGCPBlockDataUploader).
 		Component("S3 block data uploader", exeNode.LoadS3BlockDataUploader).
 		Component("provider engine", exeNode.LoadProviderEngine).
@@ -236,7 +254,11 @@ func (exeNode *ExecutionNode) LoadSystemSpecs(node *NodeConfig) error {
 }
 
 func (exeNode *ExecutionNode) LoadExecutionMetrics(node *NodeConfig) error {
-	exeNode.collector = metrics.NewExecutionCollector(node.Tracer)
+	exeNode.collector = profiler.NewSlowBlockExecutionMetrics(
+		metrics.NewExecutionCollector(node.Tracer),
+		node.Profiler,
+		node.Profiler.Triggers().SlowBlockThreshold,
+	)
 
 	// report the highest executed block height as soon as possible
 	// this is guaranteed to exist because LoadBootstrapper has inserted
@@ -259,7 +281,10 @@ func (exeNode *ExecutionNode) LoadExecutionMetrics(node *NodeConfig) error {
 func (exeNode *ExecutionNode) LoadSyncCore(node *NodeConfig) error {
 	var err error
 	exeNode.syncCore, err = chainsync.New(node.Logger, node.SyncCoreConfig, metrics.NewChainSyncCollector())
-	return err
+	if err != nil {
+		return err
+	}
+	return node.HealthRegistry.AddCheck(health.FinalizedHeightLagCheck, health.NewFinalizedHeightLagCheck(node.State, exeNode.syncCore.ReportedHeight, node.HealthConfig.MaxFinalizedHeightLag))
 }
 
 func (exeNode *ExecutionNode) LoadExecutionReceiptsStorage(
@@ -339,6 +364,85 @@ func (exeNode *ExecutionNode) LoadBlobService(
 	return &module.NoopReadyDoneAware{}, nil
 }
 
+// LoadChunkDataPackBlobService sets up publishing of chunk data packs as content-addressed blobs, if enabled.
+// The blobs are stored in a dedicated blobstore next to the execution data, served to execution and verification
+// nodes through a dedicated blob service, and pruned once their blocks are sealed.
+func (exeNode *ExecutionNode) LoadChunkDataPackBlobService(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if !exeNode.exeConf.chunkDataPackBlobsEnabled {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	datastoreDir := filepath.Join(exeNode.exeConf.executionDataDir, "chunk_data_packs", "blobstore")
+	err := os.MkdirAll(datastoreDir, 0700)
+	if err != nil {
+		return nil, err
+	}
+	ds, err := badger.NewDatastore(datastoreDir, &badger.DefaultOptions)
+	if err != nil {
+		return nil, err
+	}
+	exeNode.builder.ShutdownFunc(ds.Close)
+	blobstore := blobs.NewBlobstore(ds)
+
+	sealed, err := node.State.Sealed().Head()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get the sealed block: %w", err)
+	}
+
+	trackerDir := filepath.Join(exeNode.exeConf.executionDataDir, "chunk_data_packs", "tracker")
+	chdpTracker, err := tracker.OpenStorage(
+		trackerDir,
+		sealed.Height,
+		node.Logger,
+		tracker.WithPruneCallback(func(c cid.Cid) error {
+			// TODO: use a proper context here
+			return blobstore.DeleteBlob(context.TODO(), c)
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	bs, err := node.Network.RegisterBlobService(channels.ChunkDataPackService, ds,
+		blob.WithBitswapOptions(
+			// Only allow block requests from staked ENs and VNs
+			bitswap.WithPeerBlockRequestFilter(
+				blob.AuthorizedChunkDataPackRequester(exeNode.builder.IdentityProvider, exeNode.builder.Logger),
+			),
+			bitswap.WithTracer(
+				blob.NewTracer(node.Logger.With().Str("blob_service", channels.ChunkDataPackService.String()).Logger()),
+			),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to register chunk data pack blob service: %w", err)
+	}
+	exeNode.chunkDataPackDependable.Init(bs)
+
+	exeNode.chunkDataPackPublisher = chunk_data_pack.NewPublisher(node.Logger, execution_data.DefaultSerializer, bs, chdpTracker)
+
+	// pruner metrics are not collected, as they are registered by the execution data pruner already
+	retention, err := chunk_data_pack.NewRetention(
+		node.Logger,
+		metrics.NewNoopCollector(),
+		node.State,
+		chdpTracker,
+		exeNode.exeConf.chunkDataPackBlobsPrunerThreshold,
+		ds.CollectGarbage,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create chunk data pack retention: %w", err)
+	}
+	node.ProtocolEvents.AddConsumer(retention)
+
+	return retention, nil
+}
+
 func (exeNode *ExecutionNode) LoadGCPBlockDataUploader(
 	node *NodeConfig,
 ) (
@@ -500,6 +604,9 @@ func (exeNode *ExecutionNode) LoadProviderEngine(
 	if err != nil {
 		return nil, err
 	}
+	if exeNode.chunkDataPackPublisher != nil {
+		exeNode.providerEngine.WithChunkDataPackPublisher(exeNode.chunkDataPackPublisher)
+	}
 
 	// Get latest executed block and a view at that block
 	ctx := context.Background()
@@ -588,6 +695,11 @@ func (exeNode *ExecutionNode) LoadExecutionDataDatastore(
 func (exeNode *ExecutionNode) LoadBlobservicePeerManagerDependencies(node *NodeConfig) error {
 	exeNode.blobserviceDependable = module.NewProxiedReadyDoneAware()
 	exeNode.builder.PeerManagerDependencies.Add(exeNode.blobserviceDependable)
+
+	if exeNode.exeConf.chunkDataPackBlobsEnabled {
+		exeNode.chunkDataPackDependable = module.NewProxiedReadyDoneAware()
+		exeNode.builder.PeerManagerDependencies.Add(exeNode.chunkDataPackDependable)
+	}
 	return nil
 }
 
@@ -627,6 +739,15 @@ func (exeNode *ExecutionNode) LoadExecutionState(
 		node.Tracer,
 	)
 
+	executedHeight := func() (uint64, error) {
+		height, _, err := exeNode.executionState.GetHighestExecutedBlockID(context.Background())
+		return height, err
+	}
+	err := node.HealthRegistry.AddCheck(health.ExecutedHeightLagCheck, health.NewExecutedHeightLagCheck(node.State, executedHeight, node.HealthConfig.MaxExecutedHeightLag))
+	if err != nil {
+		return nil, fmt.Errorf("could not add executed height lag health check: %w", err)
+	}
+
 	return &module.NoopReadyDoneAware{}, nil
 }
 
@@ -664,11 +785,47 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedger(
 		return nil, fmt.Errorf("failed to initialize wal: %w", err)
 	}
 
+	var forestOpts []mtrie.ForestOption
+	if exeNode.exeConf.mTriePayloadStoreDir != "" {
+		store, err := exeNode.openPayloadStore(node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize mtrie payload store: %w", err)
+		}
+		forestOpts = append(forestOpts, mtrie.WithPayloadStore(store))
+	}
+
 	exeNode.ledgerStorage, err = ledger.NewLedger(exeNode.diskWAL, int(exeNode.exeConf.mTrieCacheSize), exeNode.collector, node.Logger.With().Str("subcomponent",
-		"ledger").Logger(), ledger.DefaultPathFinderVersion)
+		"ledger").Logger(), ledger.DefaultPathFinderVersion, forestOpts...)
 	return exeNode.ledgerStorage, err
 }
 
+// openPayloadStore opens the store the mtrie leaf payloads are paged out to.
+// The store is kept across restarts: it records the checkpoint whose payloads it holds, so
+// loading the same checkpoint again doesn't write its payloads again. If a different checkpoint
+// is loaded, its payloads are written to the store, and payloads of the previous run which are
+// not referenced anymore are deleted by the next garbage collection.
+func (exeNode *ExecutionNode) openPayloadStore(node *NodeConfig) (*payloadstore.Store, error) {
+	dir := exeNode.exeConf.mTriePayloadStoreDir
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create payload store dir (path: %s): %w", dir, err)
+	}
+
+	opts := badgerdb.DefaultOptions(dir).WithLogger(sutil.NewLogger(node.Logger))
+	db, err := badgerdb.Open(opts)
+	if err != nil {
+		return nil, fmt.Errorf("could not open payload store db: %w", err)
+	}
+	exeNode.builder.ShutdownFunc(func() error {
+		if err := db.Close(); err != nil {
+			return fmt.Errorf("error closing mtrie payload store database: %w", err)
+		}
+		return nil
+	})
+
+	return payloadstore.New(db, int(exeNode.exeConf.mTriePayloadCacheSize))
+}
+
 func (exeNode *ExecutionNode) LoadExecutionStateLedgerWALCompactor(
 	node *NodeConfig,
 ) (
@@ -686,6 +843,69 @@ func (exeNode *ExecutionNode) LoadExecutionStateLedgerWALCompactor(
 	)
 }
 
+func (exeNode *ExecutionNode) LoadCheckpointExporter(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if exeNode.exeConf.checkpointExportAddr == "" {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+	if exeNode.exeConf.checkpointExportToken == "" {
+		return nil, fmt.Errorf("checkpoint-export-token must be set when the checkpoint export server is enabled")
+	}
+
+	exeNode.checkpointExporter = checkpoint.NewExporter(
+		node.Logger,
+		exeNode.ledgerStorage,
+		node.Storage.Seals,
+		exeNode.exeConf.checkpointExportDir,
+		checkpoint.DefaultChunkSize,
+		checkpoint.DefaultMaxExports,
+	)
+	return exeNode.checkpointExporter, nil
+}
+
+func (exeNode *ExecutionNode) LoadCheckpointExportServer(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if exeNode.exeConf.checkpointExportAddr == "" {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+
+	return checkpoint.NewServer(
+		node.Logger,
+		exeNode.checkpointExporter,
+		exeNode.exeConf.checkpointExportAddr,
+		exeNode.exeConf.checkpointExportToken,
+	), nil
+}
+
+func (exeNode *ExecutionNode) LoadRegisterProofServer(
+	node *NodeConfig,
+) (
+	module.ReadyDoneAware,
+	error,
+) {
+	if exeNode.exeConf.registerProofAddr == "" {
+		return &module.NoopReadyDoneAware{}, nil
+	}
+	if exeNode.exeConf.registerProofToken == "" {
+		return nil, fmt.Errorf("register-proof-token must be set when the register proof server is enabled")
+	}
+
+	return registers.NewServer(
+		node.Logger,
+		exeNode.executionState,
+		exeNode.exeConf.registerProofAddr,
+		exeNode.exeConf.registerProofToken,
+	), nil
+}
+
 func (exeNode *ExecutionNode) LoadExecutionDataPruner(
 	node *NodeConfig,
 ) (
@@ -999,11 +1219,31 @@ func (exeNode *ExecutionNode) LoadBootstrapper(node *NodeConfig) error {
 
 	// if the execution database does not exist, then we need to bootstrap the execution database.
 	if !bootstrapped {
-		// when bootstrapping, the bootstrap folder must have a checkpoint file
-		// we need to cover this file to the trie folder to restore the trie to restore the execution state.
-		err = copyBootstrapState(node.BootstrapDir, exeNode.exeConf.triedir)
-		if err != nil {
-			return fmt.Errorf("could not load bootstrap state from checkpoint file: %w", err)
+		if exeNode.exeConf.fastSyncCheckpointURL != "" {
+			// download the checkpoint for the root block from another execution node. The downloaded
+			// checkpoint is verified against the state commitment sealed for the root block, so we
+			// start executing from the block following the root block.
+			downloader := checkpoint.NewDownloader(
+				node.Logger,
+				checkpoint.NewHTTPClient(exeNode.exeConf.fastSyncCheckpointRequestTimeout),
+				exeNode.exeConf.fastSyncCheckpointURL,
+				exeNode.exeConf.fastSyncCheckpointToken,
+			)
+			// the node only handles shutdown signals once it started, so the download, which may
+			// take a long time, is cancelled when the node is shut down during startup
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			err = downloader.Download(ctx, node.RootSeal.BlockID, node.RootSeal.FinalState, exeNode.exeConf.triedir)
+			cancel()
+			if err != nil {
+				return fmt.Errorf("could not download checkpoint from %s: %w", exeNode.exeConf.fastSyncCheckpointURL, err)
+			}
+		} else {
+			// when bootstrapping, the bootstrap folder must have a checkpoint file
+			// we need to cover this file to the trie folder to restore the trie to restore the execution state.
+			err = copyBootstrapState(node.BootstrapDir, exeNode.exeConf.triedir)
+			if err != nil {
+				return fmt.Errorf("could not load bootstrap state from checkpoint file: %w", err)
+			}
 		}
 
 		// TODO: check that the checkpoint file contains the root block's statecommit hash
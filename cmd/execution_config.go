@@ -10,13 +10,16 @@ import (
 	"github.com/spf13/pflag"
 
 	"github.com/onflow/flow-go/engine/common/provider"
+	"github.com/onflow/flow-go/engine/execution/checkpoint"
 	exeprovider "github.com/onflow/flow-go/engine/execution/provider"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack"
 	"github.com/onflow/flow-go/module/mempool"
 
 	"github.com/onflow/flow-go/engine/execution/computation"
 	"github.com/onflow/flow-go/engine/execution/rpc"
 	"github.com/onflow/flow-go/fvm/derived"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/payloadstore"
 	storage "github.com/onflow/flow-go/storage/badger"
 )
 
@@ -52,6 +55,18 @@ type ExecutionConfig struct {
 	blobstoreRateLimit                   int
 	blobstoreBurstLimit                  int
 	chunkDataPackRequestWorkers          uint
+	checkpointExportAddr                 string
+	checkpointExportDir                  string
+	checkpointExportToken                string
+	fastSyncCheckpointURL                string
+	fastSyncCheckpointToken              string
+	fastSyncCheckpointRequestTimeout     time.Duration
+	registerProofAddr                    string
+	registerProofToken                   string
+	mTriePayloadStoreDir                 string
+	mTriePayloadCacheSize                uint
+	chunkDataPackBlobsEnabled            bool
+	chunkDataPackBlobsPrunerThreshold    uint64
 
 	computationConfig        computation.ComputationConfig
 	receiptRequestWorkers    uint   // common provider engine workers
@@ -67,6 +82,8 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&exeConf.triedir, "triedir", datadir, "directory to store the execution State")
 	flags.StringVar(&exeConf.executionDataDir, "execution-data-dir", filepath.Join(homedir, ".flow", "execution_data"), "directory to use for storing Execution Data")
 	flags.Uint32Var(&exeConf.mTrieCacheSize, "mtrie-cache-size", 500, "cache size for MTrie")
+	flags.StringVar(&exeConf.mTriePayloadStoreDir, "mtrie-payload-store-dir", "", "directory to page out MTrie leaf payloads to, reducing memory usage at the cost of disk reads (disabled if empty)")
+	flags.UintVar(&exeConf.mTriePayloadCacheSize, "mtrie-payload-cache-size", payloadstore.DefaultCacheSize, "number of paged out MTrie leaf payloads cached in memory")
 	flags.UintVar(&exeConf.checkpointDistance, "checkpoint-distance", 20, "number of WAL segments between checkpoints")
 	flags.UintVar(&exeConf.checkpointsToKeep, "checkpoints-to-keep", 5, "number of recent checkpoints to keep (0 to keep all)")
 	flags.UintVar(&exeConf.stateDeltasLimit, "state-deltas-limit", 100, "maximum number of state deltas in the memory pool")
@@ -106,6 +123,17 @@ func (exeConf *ExecutionConfig) SetupFlags(flags *pflag.FlagSet) {
 	flags.StringToIntVar(&exeConf.apiBurstlimits, "api-burst-limits", map[string]int{}, "burst limits for gRPC API methods e.g. Ping=100,ExecuteScriptAtBlockID=100 etc. note limits apply globally to all clients.")
 	flags.IntVar(&exeConf.blobstoreRateLimit, "blobstore-rate-limit", 0, "per second outgoing rate limit for Execution Data blobstore")
 	flags.IntVar(&exeConf.blobstoreBurstLimit, "blobstore-burst-limit", 0, "outgoing burst limit for Execution Data blobstore")
+	flags.StringVar(&exeConf.checkpointExportAddr, "checkpoint-export-addr", "", "the address the checkpoint export server listens on, serving checkpoints to new execution nodes (disabled if empty)")
+	flags.StringVar(&exeConf.checkpointExportDir, "checkpoint-export-dir", filepath.Join(homedir, ".flow", "checkpoint_export"), "directory to store exported checkpoints, any exports in it are removed on startup")
+	flags.StringVar(&exeConf.checkpointExportToken, "checkpoint-export-token", "", "bearer token clients must present to the checkpoint export server (required if the server is enabled)")
+	flags.StringVar(&exeConf.fastSyncCheckpointURL, "fast-sync-checkpoint-url", "", "URL of the checkpoint export server of another execution node, "+
+		"used to download the checkpoint for the root block when bootstrapping instead of reading it from the bootstrap folder")
+	flags.StringVar(&exeConf.fastSyncCheckpointToken, "fast-sync-checkpoint-token", "", "bearer token presented to the checkpoint export server given by fast-sync-checkpoint-url")
+	flags.DurationVar(&exeConf.fastSyncCheckpointRequestTimeout, "fast-sync-checkpoint-request-timeout", checkpoint.DefaultRequestTimeout, "timeout of a single request to the checkpoint export server given by fast-sync-checkpoint-url, including downloading one chunk")
+	flags.BoolVar(&exeConf.chunkDataPackBlobsEnabled, "chunk-data-pack-blobs-enabled", false, "whether to publish chunk data packs as content-addressed blobs, which verification nodes can retrieve from any node holding them")
+	flags.Uint64Var(&exeConf.chunkDataPackBlobsPrunerThreshold, "chunk-data-pack-blobs-height-range-threshold", chunk_data_pack.DefaultRetentionThreshold, "number of sealed heights after which published chunk data pack blobs are pruned")
+	flags.StringVar(&exeConf.registerProofAddr, "register-proof-addr", "", "the address the register proof server listens on, serving register values with proofs to access nodes (disabled if empty)")
+	flags.StringVar(&exeConf.registerProofToken, "register-proof-token", "", "bearer token access nodes must present to the register proof server (required if the server is enabled)")
 }
 
 func (exeConf *ExecutionConfig) ValidateFlags() error {
@@ -22,7 +22,11 @@ import (
 	"github.com/onflow/flow-go/module/chainsync"
 	"github.com/onflow/flow-go/module/compliance"
 	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/health"
+	"github.com/onflow/flow-go/module/jobqueue"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/profiler"
+	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/module/updatable_configs"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/codec/cbor"
@@ -143,6 +147,10 @@ type BaseConfig struct {
 	AdminKey                    string
 	AdminClientCAs              string
 	AdminMaxMsgSize             uint
+	RemoteSignerAddr            string
+	RemoteSignerCert            string
+	RemoteSignerKey             string
+	RemoteSignerCAs             string
 	BindAddr                    string
 	NodeRole                    string
 	DynamicStartupANAddress     string
@@ -161,6 +169,8 @@ type BaseConfig struct {
 	profilerConfig              profiler.ProfilerConfig
 	tracerEnabled               bool
 	tracerSensitivity           uint
+	tracerConfig                trace.Config
+	tracerSpanSampleRatios      map[string]string
 	MetricsEnabled              bool
 	guaranteesCacheSize         uint
 	receiptsCacheSize           uint
@@ -172,6 +182,7 @@ type BaseConfig struct {
 	// ComplianceConfig configures either the compliance engine (consensus nodes)
 	// or the follower engine (all other node roles)
 	ComplianceConfig compliance.Config
+	HealthConfig     health.Config
 }
 
 type NetworkConfig struct {
@@ -210,7 +221,12 @@ type NodeConfig struct {
 	NodeID            flow.Identifier
 	Me                module.Local
 	Tracer            module.Tracer
+	TraceBuffer       *trace.MemoryExporter // nil unless the in-memory trace exporter is enabled
+	Profiler          *profiler.AutoProfiler
 	ConfigManager     *updatable_configs.Manager
+	MempoolRegistry   *mempool.Registry
+	JobQueueRegistry  *jobqueue.Registry
+	HealthRegistry    *health.Registry
 	MetricsRegisterer prometheus.Registerer
 	Metrics           Metrics
 	DB                *badger.DB
@@ -282,6 +298,10 @@ func DefaultBaseConfig() *BaseConfig {
 		AdminKey:         NotSet,
 		AdminClientCAs:   NotSet,
 		AdminMaxMsgSize:  grpcutils.DefaultMaxMsgSize,
+		RemoteSignerAddr: NotSet,
+		RemoteSignerCert: NotSet,
+		RemoteSignerKey:  NotSet,
+		RemoteSignerCAs:  NotSet,
 		BindAddr:         NotSet,
 		BootstrapDir:     "bootstrap",
 		datadir:          datadir,
@@ -293,6 +313,7 @@ func DefaultBaseConfig() *BaseConfig {
 		metricsPort:         8080,
 		tracerEnabled:       false,
 		tracerSensitivity:   4,
+		tracerConfig:        trace.DefaultConfig(),
 		MetricsEnabled:      true,
 		receiptsCacheSize:   bstorage.DefaultCacheSize,
 		guaranteesCacheSize: bstorage.DefaultCacheSize,
@@ -301,15 +322,23 @@ func DefaultBaseConfig() *BaseConfig {
 			Enabled:         false,
 			UploaderEnabled: false,
 
-			Dir:      "profiler",
-			Interval: 15 * time.Minute,
-			Duration: 10 * time.Second,
+			Dir:         "profiler",
+			Interval:    15 * time.Minute,
+			Duration:    10 * time.Second,
+			MaxCaptures: 20,
+
+			Triggers: profiler.TriggerConfig{
+				CheckInterval: 10 * time.Second,
+				Cooldown:      10 * time.Minute,
+				MaxCaptures:   10,
+			},
 		},
 
 		HeroCacheMetricsEnable: false,
 		SyncCoreConfig:         chainsync.DefaultConfig(),
 		CodecFactory:           codecFactory,
 		ComplianceConfig:       compliance.DefaultConfig(),
+		HealthConfig:           health.DefaultConfig(),
 	}
 }
 
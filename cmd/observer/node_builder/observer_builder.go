@@ -16,6 +16,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/routing"
+	"github.com/onflow/flow/protobuf/go/flow/access"
 	"github.com/onflow/go-bitswap"
 	"github.com/rs/zerolog"
 	"github.com/spf13/pflag"
@@ -45,6 +46,7 @@ import (
 	"github.com/onflow/flow-go/module/compliance"
 	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/health"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/local"
 	"github.com/onflow/flow-go/module/metrics"
@@ -113,6 +115,7 @@ type ObserverServiceConfig struct {
 	apiTimeout                time.Duration
 	upstreamNodeAddresses     []string
 	upstreamNodePublicKeys    []string
+	upstreamCacheSize         uint
 	upstreamIdentities        flow.IdentityList // the identity list of upstream peers the node uses to forward API requests to
 }
 
@@ -152,6 +155,7 @@ func DefaultObserverServiceConfig() *ObserverServiceConfig {
 		apiTimeout:             3 * time.Second,
 		upstreamNodeAddresses:  []string{},
 		upstreamNodePublicKeys: []string{},
+		upstreamCacheSize:      apiproxy.DefaultResponseCacheSize,
 	}
 }
 
@@ -285,7 +289,10 @@ func (builder *ObserverServiceBuilder) buildSyncCore() *ObserverServiceBuilder {
 		syncCore, err := chainsync.New(node.Logger, node.SyncCoreConfig, metrics.NewChainSyncCollector())
 		builder.SyncCore = syncCore
 
-		return err
+		if err != nil {
+			return err
+		}
+		return node.HealthRegistry.AddCheck(health.FinalizedHeightLagCheck, health.NewFinalizedHeightLagCheck(node.State, syncCore.ReportedHeight, node.HealthConfig.MaxFinalizedHeightLag))
 	})
 
 	return builder
@@ -585,6 +592,7 @@ func (builder *ObserverServiceBuilder) extraFlags() {
 		flags.DurationVar(&builder.apiTimeout, "upstream-api-timeout", defaultConfig.apiTimeout, "tcp timeout for Flow API gRPC sockets to upstrem nodes")
 		flags.StringSliceVar(&builder.upstreamNodeAddresses, "upstream-node-addresses", defaultConfig.upstreamNodeAddresses, "the gRPC network addresses of the upstream access node. e.g. access-001.mainnet.flow.org:9000,access-002.mainnet.flow.org:9000")
 		flags.StringSliceVar(&builder.upstreamNodePublicKeys, "upstream-node-public-keys", defaultConfig.upstreamNodePublicKeys, "the networking public key of the upstream access node (in the same order as the upstream node addresses) e.g. \"d57a5e9c5.....\",\"44ded42d....\"")
+		flags.UintVar(&builder.upstreamCacheSize, "upstream-response-cache-size", defaultConfig.upstreamCacheSize, "maximum number of responses of upstream nodes for immutable data to cache (if zero, responses are not cached)")
 		flags.BoolVar(&builder.rpcMetricsEnabled, "rpc-metrics-enabled", defaultConfig.rpcMetricsEnabled, "whether to enable the rpc metrics")
 
 		// ExecutionDataRequester config
@@ -792,6 +800,10 @@ func (builder *ObserverServiceBuilder) Initialize() error {
 		}
 	}
 
+	if builder.HealthConfig.Addr != "" {
+		builder.EnqueueHealthServerInit()
+	}
+
 	builder.PreInit(builder.initObserverLocal())
 
 	return nil
@@ -871,6 +883,12 @@ func (builder *ObserverServiceBuilder) initLibP2PFactory(networkKey crypto.Priva
 
 		builder.LibP2PNode = node
 
+		peerCount := func() int { return len(node.Host().Network().Peers()) }
+		err = builder.HealthRegistry.AddCheck(health.PeerCountCheck, health.NewPeerCountCheck(peerCount, builder.HealthConfig.MinPeers))
+		if err != nil {
+			return nil, fmt.Errorf("could not add peer count health check: %w", err)
+		}
+
 		return builder.LibP2PNode, nil
 	}
 }
@@ -1004,10 +1022,26 @@ func (builder *ObserverServiceBuilder) enqueueRPCServer() {
 			return nil, err
 		}
 
+		observerMetrics := metrics.NewObserverCollector()
+		var upstream access.AccessAPIServer = forwarder
+		if builder.upstreamCacheSize > 0 {
+			upstream, err = apiproxy.NewFlowAccessAPICache(
+				node.Logger,
+				observerMetrics,
+				forwarder,
+				node.State,
+				node.Storage.Headers,
+				int(builder.upstreamCacheSize),
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		proxy := &apiproxy.FlowAccessAPIRouter{
 			Logger:   builder.Logger,
-			Metrics:  metrics.NewObserverCollector(),
-			Upstream: forwarder,
+			Metrics:  observerMetrics,
+			Upstream: upstream,
 			Observer: protocol.NewHandler(protocol.New(
 				node.State,
 				node.Storage.Blocks,
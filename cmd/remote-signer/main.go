@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+
+	"github.com/rs/zerolog"
+	"github.com/spf13/pflag"
+
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/remotesigner"
+)
+
+// remote-signer is a reference implementation of a remote signer for Flow nodes. It holds the
+// staking and networking keys of a node, and serves signatures to the node over a mutually
+// authenticated gRPC connection. It refuses to sign consensus votes conflicting with votes it
+// signed before, see remotesigner.VoteProtection.
+//
+// The node connects to it with the --remote-signer-* flags, and doesn't need its staking key.
+func main() {
+	var (
+		nodeIDHex      string
+		bootstrapDir   string
+		listenAddr     string
+		certFile       string
+		keyFile        string
+		clientCAsFile  string
+		protectionFile string
+		level          string
+	)
+
+	pflag.StringVar(&nodeIDHex, "nodeid", "", "identity of the node whose keys are held by the signer")
+	pflag.StringVar(&bootstrapDir, "bootstrapdir", "bootstrap", "path to the bootstrap directory containing the private node info of the node")
+	pflag.StringVar(&listenAddr, "listen", "unix:///var/run/flow/remote-signer.sock", "address to listen on, either unix:///path/to/socket or host:port")
+	pflag.StringVar(&certFile, "cert", "", "server cert file (for mutual TLS)")
+	pflag.StringVar(&keyFile, "key", "", "server key file (for mutual TLS)")
+	pflag.StringVar(&clientCAsFile, "client-certs", "", "certs of the CAs of the node's client cert (for mutual TLS)")
+	pflag.StringVar(&protectionFile, "protection-file", "signed-votes.json", "file persisting the last signed consensus vote, to refuse signing conflicting votes")
+	pflag.StringVar(&level, "loglevel", "info", "level for logging output")
+	pflag.Parse()
+
+	log := zerolog.New(os.Stderr).With().Timestamp().Logger()
+	lvl, err := zerolog.ParseLevel(level)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid log level")
+	}
+	log = log.Level(lvl)
+
+	nodeID, err := flow.HexStringToIdentifier(nodeIDHex)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not parse node ID")
+	}
+
+	info, err := loadPrivateNodeInfo(bootstrapDir, nodeID)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load private node info")
+	}
+	if info.StakingPrivKey.PrivateKey == nil {
+		log.Fatal().Msg("private node info contains no staking key")
+	}
+
+	votes, err := remotesigner.NewVoteProtection(protectionFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not initialize vote protection")
+	}
+	if view, ok := votes.LastView(); ok {
+		log.Info().Uint64("last_signed_view", view).Msg("loaded signed votes")
+	}
+
+	creds, err := remotesigner.ServerCredentials(certFile, keyFile, clientCAsFile)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not load credentials")
+	}
+
+	listener, err := remotesigner.Listen(listenAddr)
+	if err != nil {
+		log.Fatal().Err(err).Str("address", listenAddr).Msg("could not listen")
+	}
+
+	signer := remotesigner.NewServer(log, info.StakingPrivKey.PrivateKey, info.NetworkPrivKey.PrivateKey, votes)
+	server := remotesigner.NewGRPCServer(signer, creds)
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-sig
+		log.Info().Msg("shutting down")
+		server.GracefulStop()
+	}()
+
+	log.Info().Str("address", listenAddr).Hex("node_id", nodeID[:]).Msg("remote signer listening")
+	err = server.Serve(listener)
+	if err != nil {
+		log.Fatal().Err(err).Msg("remote signer failed")
+	}
+}
+
+func loadPrivateNodeInfo(dir string, nodeID flow.Identifier) (*bootstrap.NodeInfoPriv, error) {
+	path := filepath.Join(dir, fmt.Sprintf(bootstrap.PathNodeInfoPriv, nodeID))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read private node info (path=%s): %w", path, err)
+	}
+	var info bootstrap.NodeInfoPriv
+	err = json.Unmarshal(data, &info)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode private node info: %w", err)
+	}
+	return &info, nil
+}
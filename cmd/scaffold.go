@@ -26,6 +26,8 @@ import (
 	"github.com/onflow/flow-go/admin"
 	"github.com/onflow/flow-go/admin/commands"
 	"github.com/onflow/flow-go/admin/commands/common"
+	jobqueueCommands "github.com/onflow/flow-go/admin/commands/jobqueue"
+	mempoolCommands "github.com/onflow/flow-go/admin/commands/mempool"
 	storageCommands "github.com/onflow/flow-go/admin/commands/storage"
 	"github.com/onflow/flow-go/cmd/build"
 	"github.com/onflow/flow-go/consensus/hotstuff/persister"
@@ -38,12 +40,16 @@ import (
 	"github.com/onflow/flow-go/module/chainsync"
 	"github.com/onflow/flow-go/module/compliance"
 	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/health"
 	"github.com/onflow/flow-go/module/id"
 	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/jobqueue"
 	"github.com/onflow/flow-go/module/local"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/herocache"
 	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/module/profiler"
+	"github.com/onflow/flow-go/module/remotesigner"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/module/updatable_configs"
 	"github.com/onflow/flow-go/module/util"
@@ -155,11 +161,44 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 		"the interval between auto-profiler runs")
 	fnb.flags.DurationVar(&fnb.BaseConfig.profilerConfig.Duration, "profiler-duration", defaultConfig.profilerConfig.Duration,
 		"the duration to run the auto-profile for")
+	fnb.flags.UintVar(&fnb.BaseConfig.profilerConfig.MaxCaptures, "profiler-max-captures", defaultConfig.profilerConfig.MaxCaptures,
+		"the maximum number of periodic and manual profile captures kept in the profiler directory, 0 to keep all")
+	fnb.flags.DurationVar(&fnb.BaseConfig.profilerConfig.Triggers.CheckInterval, "profiler-trigger-check-interval", defaultConfig.profilerConfig.Triggers.CheckInterval,
+		"the interval to check the profile trigger thresholds at, 0 to disable checking")
+	fnb.flags.Float64Var(&fnb.BaseConfig.profilerConfig.Triggers.CPUThreshold, "profiler-trigger-cpu", defaultConfig.profilerConfig.Triggers.CPUThreshold,
+		"CPU usage in percent of a single core which triggers a profile capture, 0 to disable")
+	fnb.flags.Uint64Var(&fnb.BaseConfig.profilerConfig.Triggers.HeapThreshold, "profiler-trigger-heap", defaultConfig.profilerConfig.Triggers.HeapThreshold,
+		"heap size in bytes which triggers a profile capture, 0 to disable")
+	fnb.flags.UintVar(&fnb.BaseConfig.profilerConfig.Triggers.GoroutineThreshold, "profiler-trigger-goroutines", defaultConfig.profilerConfig.Triggers.GoroutineThreshold,
+		"number of goroutines which triggers a profile capture, 0 to disable")
+	fnb.flags.DurationVar(&fnb.BaseConfig.profilerConfig.Triggers.FinalizationStall, "profiler-trigger-finalization-stall", defaultConfig.profilerConfig.Triggers.FinalizationStall,
+		"duration without a newly finalized block which triggers a profile capture, 0 to disable")
+	fnb.flags.DurationVar(&fnb.BaseConfig.profilerConfig.Triggers.SlowBlockThreshold, "profiler-trigger-slow-block", defaultConfig.profilerConfig.Triggers.SlowBlockThreshold,
+		"duration of executing (execution nodes) or processing (consensus nodes) a block which triggers a profile capture, 0 to disable")
+	fnb.flags.DurationVar(&fnb.BaseConfig.profilerConfig.Triggers.Cooldown, "profiler-trigger-cooldown", defaultConfig.profilerConfig.Triggers.Cooldown,
+		"the minimum duration between triggered profile captures")
+	fnb.flags.DurationVar(&fnb.BaseConfig.profilerConfig.Triggers.Duration, "profiler-trigger-duration", defaultConfig.profilerConfig.Triggers.Duration,
+		"the duration to run triggered profile captures for, the profiler duration if 0")
+	fnb.flags.UintVar(&fnb.BaseConfig.profilerConfig.Triggers.MaxCaptures, "profiler-trigger-max-captures", defaultConfig.profilerConfig.Triggers.MaxCaptures,
+		"the maximum number of triggered profile captures kept in the profiler directory, 0 to keep all")
 
 	fnb.flags.BoolVar(&fnb.BaseConfig.tracerEnabled, "tracer-enabled", defaultConfig.tracerEnabled,
 		"whether to enable tracer")
 	fnb.flags.UintVar(&fnb.BaseConfig.tracerSensitivity, "tracer-sensitivity", defaultConfig.tracerSensitivity,
 		"adjusts the level of sampling when tracing is enabled. 0 means capture everything, higher value results in less samples")
+	fnb.flags.StringSliceVar(&fnb.BaseConfig.tracerConfig.Exporters, "tracer-exporters", defaultConfig.tracerConfig.Exporters,
+		fmt.Sprintf("exporters to export spans to when tracing is enabled, one or more of: %s, %s, %s, %s",
+			trace.ExporterOTLPGRPC, trace.ExporterOTLPHTTP, trace.ExporterFile, trace.ExporterMemory))
+	fnb.flags.StringVar(&fnb.BaseConfig.tracerConfig.FilePath, "tracer-file-path", defaultConfig.tracerConfig.FilePath,
+		"file the file exporter appends spans to as JSON lines")
+	fnb.flags.UintVar(&fnb.BaseConfig.tracerConfig.MemoryBufferSize, "tracer-memory-buffer-size", defaultConfig.tracerConfig.MemoryBufferSize,
+		"number of most recent spans kept by the memory exporter, which can be read via the read-traces admin command")
+	fnb.flags.StringToStringVar(&fnb.BaseConfig.tracerSpanSampleRatios, "tracer-span-sample-ratios", map[string]string{},
+		"fraction of spans with the given name to sample, e.g. execution.computation.runTransaction=0.1. spans not listed are always sampled")
+	fnb.flags.DurationVar(&fnb.BaseConfig.tracerConfig.Sampling.SlowSpanThreshold, "tracer-slow-span-threshold", defaultConfig.tracerConfig.Sampling.SlowSpanThreshold,
+		"spans taking longer than this threshold are always exported, even if they were not sampled (0 to disable)")
+	fnb.flags.Float64Var(&fnb.BaseConfig.tracerConfig.Sampling.BlockRatio, "tracer-block-sample-ratio", defaultConfig.tracerConfig.Sampling.BlockRatio,
+		"fraction of blocks to trace, the decision is keyed by block ID so either the whole lifecycle of a block is traced or none of it")
 
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminAddr, "admin-addr", defaultConfig.AdminAddr, "address to bind on for admin HTTP server")
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminCert, "admin-cert", defaultConfig.AdminCert, "admin cert file (for TLS)")
@@ -167,6 +206,12 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 	fnb.flags.StringVar(&fnb.BaseConfig.AdminClientCAs, "admin-client-certs", defaultConfig.AdminClientCAs, "admin client certs (for mutual TLS)")
 	fnb.flags.UintVar(&fnb.BaseConfig.AdminMaxMsgSize, "admin-max-response-size", defaultConfig.AdminMaxMsgSize, "admin server max response size in bytes")
 
+	fnb.flags.StringVar(&fnb.BaseConfig.RemoteSignerAddr, "remote-signer-addr", defaultConfig.RemoteSignerAddr,
+		"address of the remote signer holding the staking key, either unix:///path/to/socket or host:port (the staking key is loaded from the bootstrap directory if not set)")
+	fnb.flags.StringVar(&fnb.BaseConfig.RemoteSignerCert, "remote-signer-cert", defaultConfig.RemoteSignerCert, "client cert file to authenticate with the remote signer (for mutual TLS)")
+	fnb.flags.StringVar(&fnb.BaseConfig.RemoteSignerKey, "remote-signer-key", defaultConfig.RemoteSignerKey, "client key file to authenticate with the remote signer (for mutual TLS)")
+	fnb.flags.StringVar(&fnb.BaseConfig.RemoteSignerCAs, "remote-signer-certs", defaultConfig.RemoteSignerCAs, "CA certs of the remote signer (for mutual TLS)")
+
 	fnb.flags.Float64Var(&fnb.BaseConfig.LibP2PResourceManagerConfig.FileDescriptorsRatio, "libp2p-fd-ratio", defaultConfig.LibP2PResourceManagerConfig.FileDescriptorsRatio, "ratio of available file descriptors to be used by libp2p (in (0,1])")
 	fnb.flags.Float64Var(&fnb.BaseConfig.LibP2PResourceManagerConfig.MemoryLimitRatio, "libp2p-memory-limit", defaultConfig.LibP2PResourceManagerConfig.MemoryLimitRatio, "ratio of available memory to be used by libp2p (in (0,1])")
 	fnb.flags.DurationVar(&fnb.BaseConfig.DNSCacheTTL, "dns-cache-ttl", defaultConfig.DNSCacheTTL, "time-to-live for dns cache")
@@ -195,6 +240,13 @@ func (fnb *FlowNodeBuilder) BaseFlags() {
 	fnb.flags.UintVar(&fnb.BaseConfig.SyncCoreConfig.MaxSize, "sync-max-size", defaultConfig.SyncCoreConfig.MaxSize, "the maximum number of blocks we request in the same block request message")
 	fnb.flags.UintVar(&fnb.BaseConfig.SyncCoreConfig.MaxRequests, "sync-max-requests", defaultConfig.SyncCoreConfig.MaxRequests, "the maximum number of requests we send during each scanning period")
 
+	// health flags
+	fnb.flags.StringVar(&fnb.BaseConfig.HealthConfig.Addr, "health-addr", defaultConfig.HealthConfig.Addr, "address to serve the /health/live and /health/ready endpoints on over http (e.g. :8082), disabled if empty")
+	fnb.flags.Uint64Var(&fnb.BaseConfig.HealthConfig.MaxFinalizedHeightLag, "health-max-finalized-height-lag", defaultConfig.HealthConfig.MaxFinalizedHeightLag, "maximum number of blocks the finalized height may be behind the height reported by other nodes for the node to be ready")
+	fnb.flags.Uint64Var(&fnb.BaseConfig.HealthConfig.MaxSealedHeightLag, "health-max-sealed-height-lag", defaultConfig.HealthConfig.MaxSealedHeightLag, "maximum number of blocks the sealed height may be behind the finalized height for the node to be ready")
+	fnb.flags.Uint64Var(&fnb.BaseConfig.HealthConfig.MaxExecutedHeightLag, "health-max-executed-height-lag", defaultConfig.HealthConfig.MaxExecutedHeightLag, "maximum number of blocks the executed height may be behind the finalized height for an execution node to be ready")
+	fnb.flags.UintVar(&fnb.BaseConfig.HealthConfig.MinPeers, "health-min-peers", defaultConfig.HealthConfig.MinPeers, "minimum number of connected peers for the node to be ready")
+
 	fnb.flags.Uint64Var(&fnb.BaseConfig.ComplianceConfig.SkipNewProposalsThreshold, "compliance-skip-proposals-threshold", defaultConfig.ComplianceConfig.SkipNewProposalsThreshold, "threshold at which new proposals are discarded rather than cached, if their height is this much above local finalized height")
 
 	// unicast stream handler rate limits
@@ -365,6 +417,12 @@ func (fnb *FlowNodeBuilder) EnqueueNetworkInit() {
 		}
 		fnb.LibP2PNode = libp2pNode
 
+		peerCount := func() int { return len(libp2pNode.Host().Network().Peers()) }
+		err = fnb.HealthRegistry.AddCheck(health.PeerCountCheck, health.NewPeerCountCheck(peerCount, fnb.HealthConfig.MinPeers))
+		if err != nil {
+			return nil, fmt.Errorf("could not add peer count health check: %w", err)
+		}
+
 		return libp2pNode, nil
 	})
 
@@ -471,6 +529,13 @@ func (fnb *FlowNodeBuilder) EnqueueMetricsServerInit() {
 	})
 }
 
+func (fnb *FlowNodeBuilder) EnqueueHealthServerInit() {
+	fnb.Component("health server", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+		server := health.NewServer(fnb.Logger, fnb.HealthRegistry, fnb.HealthConfig.Addr)
+		return server, nil
+	})
+}
+
 func (fnb *FlowNodeBuilder) EnqueueAdminServerInit() error {
 	if fnb.AdminAddr == NotSet {
 		return nil
@@ -626,19 +691,27 @@ func (fnb *FlowNodeBuilder) initMetrics() error {
 			nodeIdHex = nodeIdHex[:8]
 		}
 
+		spanRatios, err := trace.ParseSpanRatios(fnb.tracerSpanSampleRatios)
+		if err != nil {
+			return fmt.Errorf("invalid tracer span sample ratios: %w", err)
+		}
+		fnb.tracerConfig.Sampling.SpanRatios = spanRatios
+
 		serviceName := fnb.BaseConfig.NodeRole + "-" + nodeIdHex
-		tracer, err := trace.NewTracer(
+		tracer, err := trace.NewTracerWithConfig(
 			fnb.Logger,
 			serviceName,
 			fnb.RootChainID.String(),
 			fnb.tracerSensitivity,
+			fnb.tracerConfig,
 		)
 		if err != nil {
 			return fmt.Errorf("could not initialize tracer: %w", err)
 		}
 
-		fnb.Logger.Info().Msg("Tracer Started")
+		fnb.Logger.Info().Strs("exporters", fnb.tracerConfig.Exporters).Msg("Tracer Started")
 		fnb.Tracer = tracer
+		fnb.TraceBuffer = tracer.Buffer()
 	}
 
 	fnb.Metrics = Metrics{
@@ -722,13 +795,13 @@ func (fnb *FlowNodeBuilder) initProfiler() error {
 		uploader = &profiler.NoopUploader{}
 	}
 
-	profiler, err := profiler.New(fnb.Logger, uploader, fnb.BaseConfig.profilerConfig)
+	autoProfiler, err := profiler.New(fnb.Logger, uploader, fnb.BaseConfig.profilerConfig)
 	if err != nil {
 		return fmt.Errorf("could not initialize profiler: %w", err)
 	}
 
 	// register the enabled state of the profiler for dynamic configuring
-	err = fnb.ConfigManager.RegisterBoolConfig("profiler-enabled", profiler.Enabled, profiler.SetEnabled)
+	err = fnb.ConfigManager.RegisterBoolConfig("profiler-enabled", autoProfiler.Enabled, autoProfiler.SetEnabled)
 	if err != nil {
 		return fmt.Errorf("could not register profiler-enabled config: %w", err)
 	}
@@ -736,7 +809,7 @@ func (fnb *FlowNodeBuilder) initProfiler() error {
 	err = fnb.ConfigManager.RegisterDurationConfig(
 		"profiler-trigger",
 		func() time.Duration { return fnb.BaseConfig.profilerConfig.Duration },
-		func(d time.Duration) error { return profiler.TriggerRun(d) },
+		func(d time.Duration) error { return autoProfiler.TriggerRun(d) },
 	)
 	if err != nil {
 		return fmt.Errorf("could not register profiler-trigger config: %w", err)
@@ -771,12 +844,25 @@ func (fnb *FlowNodeBuilder) initProfiler() error {
 		return fmt.Errorf("could not register profiler-set-mutex-profile-fraction setting: %w", err)
 	}
 
+	fnb.Profiler = autoProfiler
+
 	// registering as a DependableComponent with no dependencies so that it's started immediately on startup
 	// without being blocked by other component's Ready()
 	fnb.DependableComponent("profiler", func(node *NodeConfig) (module.ReadyDoneAware, error) {
-		return profiler, nil
+		return autoProfiler, nil
 	}, NewDependencyList())
 
+	fnb.Component("profiler anomaly monitor", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+		finalizedHeight := func() (uint64, error) {
+			head, err := node.State.Final().Head()
+			if err != nil {
+				return 0, err
+			}
+			return head.Height, nil
+		}
+		return profiler.NewAnomalyMonitor(node.Logger, node.Profiler, node.profilerConfig.Triggers, finalizedHeight)
+	})
+
 	return nil
 }
 
@@ -1112,6 +1198,16 @@ func (fnb *FlowNodeBuilder) setRootSnapshot(rootSnapshot protocol.Snapshot) erro
 	return nil
 }
 
+// initHealthChecks adds the health checks common to all node roles, which only depend on the
+// protocol state. Checks depending on other modules are added when these are initialized.
+func (fnb *FlowNodeBuilder) initHealthChecks() error {
+	err := fnb.HealthRegistry.AddCheck(health.SealedHeightLagCheck, health.NewSealedHeightLagCheck(fnb.State, fnb.HealthConfig.MaxSealedHeightLag))
+	if err != nil {
+		return fmt.Errorf("could not add sealed height lag health check: %w", err)
+	}
+	return nil
+}
+
 func (fnb *FlowNodeBuilder) initLocal() error {
 	// Verify that my ID (as given in the configuration) is known to the network
 	// (i.e. protocol state). There are two cases that will cause the following error:
@@ -1148,11 +1244,17 @@ func (fnb *FlowNodeBuilder) initLocal() error {
 			fnb.BaseConfig.NodeRole)
 	}
 
+	if fnb.RemoteSignerAddr != NotSet {
+		// the remote signer checks that it holds the keys of the protocol state
+		return fnb.initRemoteLocal(self)
+	}
+
 	// ensure that the configured staking/network keys are consistent with the protocol state
-	if !self.NetworkPubKey.Equals(fnb.NetworkKey.PublicKey()) {
+	if fnb.NetworkKey == nil || !self.NetworkPubKey.Equals(fnb.NetworkKey.PublicKey()) {
 		return fmt.Errorf("configured networking key does not match protocol state")
 	}
-	if !self.StakingPubKey.Equals(fnb.StakingKey.PublicKey()) {
+
+	if fnb.StakingKey == nil || !self.StakingPubKey.Equals(fnb.StakingKey.PublicKey()) {
 		return fmt.Errorf("configured staking key does not match protocol state")
 	}
 
@@ -1164,12 +1266,55 @@ func (fnb *FlowNodeBuilder) initLocal() error {
 	return nil
 }
 
+// initRemoteLocal initializes a local which delegates signing with the staking key to the remote
+// signer, so that the node doesn't need to hold the staking key. If the private node info holds no
+// networking key, signing with the networking key is delegated to the remote signer as well.
+func (fnb *FlowNodeBuilder) initRemoteLocal(self *flow.Identity) error {
+	if fnb.RemoteSignerCert == NotSet || fnb.RemoteSignerKey == NotSet || fnb.RemoteSignerCAs == NotSet {
+		return fmt.Errorf("remote signer cert / key and certs must all be provided to authenticate with the remote signer")
+	}
+
+	creds, err := remotesigner.ClientCredentials(fnb.RemoteSignerCert, fnb.RemoteSignerKey, fnb.RemoteSignerCAs, "")
+	if err != nil {
+		return fmt.Errorf("could not load remote signer credentials: %w", err)
+	}
+
+	client, conn, err := remotesigner.Dial(fnb.RemoteSignerAddr, creds)
+	if err != nil {
+		return err
+	}
+	fnb.ShutdownFunc(conn.Close)
+
+	remoteNetworkKey := fnb.NetworkKey == nil
+	if remoteNetworkKey {
+		fnb.NetworkKey, err = remotesigner.NewNetworkingKey(self, client)
+		if err != nil {
+			return fmt.Errorf("could not initialize remote networking key: %w", err)
+		}
+	} else if !self.NetworkPubKey.Equals(fnb.NetworkKey.PublicKey()) {
+		return fmt.Errorf("configured networking key does not match protocol state")
+	}
+
+	fnb.Me, err = remotesigner.New(self, client)
+	if err != nil {
+		return fmt.Errorf("could not initialize remote local: %w", err)
+	}
+
+	fnb.Logger.Info().
+		Str("remote_signer", fnb.RemoteSignerAddr).
+		Bool("remote_networking_key", remoteNetworkKey).
+		Msg("signing with remote signer")
+	return nil
+}
+
 func (fnb *FlowNodeBuilder) initFvmOptions() {
 	blockFinder := environment.NewBlockFinder(fnb.Storage.Headers)
 	vmOpts := []fvm.Option{
 		fvm.WithChain(fnb.RootChainID.Chain()),
 		fvm.WithBlocks(blockFinder),
 		fvm.WithAccountStorageLimit(true),
+		// the activation height is derived from the chain, so all execution and verification nodes agree on the random source
+		fvm.WithBeaconRandomSourceActivationHeight(environment.BeaconRandomSourceActivationHeight(fnb.RootChainID)),
 	}
 	if fnb.RootChainID == flow.Testnet || fnb.RootChainID == flow.Sandboxnet || fnb.RootChainID == flow.Mainnet {
 		vmOpts = append(vmOpts,
@@ -1225,6 +1370,12 @@ func (fnb *FlowNodeBuilder) handleComponents() error {
 
 	// Run all components
 	for _, f := range fnb.components {
+		// restartable components are not required for the node to be healthy
+		err = fnb.HealthRegistry.RegisterComponent(f.name, f.errorHandler != nil)
+		if err != nil {
+			fnb.Logger.Warn().Err(err).Str("component", f.name).Msg("component state will not be reported")
+		}
+
 		// Components with explicit dependencies are not started serially
 		if f.dependencies != nil {
 			asyncComponents = append(asyncComponents, f)
@@ -1288,7 +1439,9 @@ func (fnb *FlowNodeBuilder) handleComponent(v namedComponentFunc, dependencies <
 		// First, build the component using the factory method.
 		readyAware, err := v.fn(fnb.NodeConfig)
 		if err != nil {
-			ctx.Throw(fmt.Errorf("component %s initialization failed: %w", v.name, err))
+			err = fmt.Errorf("component %s initialization failed: %w", v.name, err)
+			fnb.HealthRegistry.SetComponentState(v.name, health.ComponentFailed, err)
+			ctx.Throw(err)
 		}
 		logger.Info().Msg("component initialization complete")
 
@@ -1296,7 +1449,7 @@ func (fnb *FlowNodeBuilder) handleComponent(v namedComponentFunc, dependencies <
 		// Ready() will launch it.
 		cmp, isComponent := readyAware.(component.Component)
 		if isComponent {
-			cmp.Start(ctx)
+			fnb.startReportingErrors(ctx, v.name, cmp)
 		}
 
 		// Wait until the component is ready
@@ -1311,6 +1464,7 @@ func (fnb *FlowNodeBuilder) handleComponent(v namedComponentFunc, dependencies <
 			}
 		} else {
 			logger.Info().Msg("component startup complete")
+			fnb.HealthRegistry.SetComponentState(v.name, health.ComponentReady, nil)
 			ready()
 
 			// Signal to the next component that we're ready.
@@ -1323,12 +1477,41 @@ func (fnb *FlowNodeBuilder) handleComponent(v namedComponentFunc, dependencies <
 
 		// Finally, wait until component has finished shutting down.
 		<-readyAware.Done()
+		fnb.HealthRegistry.SetComponentState(v.name, health.ComponentStopped, nil)
 		logger.Info().Msg("component shutdown complete")
 	})
 
 	return nil
 }
 
+// startReportingErrors starts the named component with a child of the given context. Any
+// irrecoverable error thrown by the component is reported to the HealthRegistry before it is
+// propagated to the parent context.
+func (fnb *FlowNodeBuilder) startReportingErrors(parent irrecoverable.SignalerContext, name string, cmp component.Component) {
+	ctx, errChan := irrecoverable.WithSignaler(parent)
+
+	go func() {
+		forward := func(err error) {
+			fnb.HealthRegistry.SetComponentState(name, health.ComponentFailed, err)
+			parent.Throw(err)
+		}
+
+		select {
+		case err := <-errChan:
+			forward(err)
+		case <-cmp.Done():
+			// the component may have thrown right before it was done, don't drop the error
+			select {
+			case err := <-errChan:
+				forward(err)
+			default:
+			}
+		}
+	}()
+
+	cmp.Start(ctx)
+}
+
 // handleRestartableComponent constructs a component using the provided ReadyDoneFactory, and
 // registers a worker with the ComponentManager to be run when the node is started.
 //
@@ -1367,6 +1550,7 @@ func (fnb *FlowNodeBuilder) handleRestartableComponent(v namedComponentFunc, par
 					log.Info().Msg("component startup aborted")
 				} else {
 					log.Info().Msg("component startup complete")
+					fnb.HealthRegistry.SetComponentState(v.name, health.ComponentReady, nil)
 				}
 
 				<-ctx.Done()
@@ -1375,11 +1559,25 @@ func (fnb *FlowNodeBuilder) handleRestartableComponent(v namedComponentFunc, par
 			return c.(component.Component), nil
 		}
 
-		err := component.RunComponent(ctx, componentFactory, v.errorHandler)
+		// report the outcome of the error handling before the component is restarted or stopped
+		errorHandler := func(err error) component.ErrorHandlingResult {
+			result := v.errorHandler(err)
+			if result == component.ErrorHandlingRestart {
+				fnb.HealthRegistry.SetComponentState(v.name, health.ComponentRestarting, err)
+			} else {
+				fnb.HealthRegistry.SetComponentState(v.name, health.ComponentFailed, err)
+			}
+			return result
+		}
+
+		err := component.RunComponent(ctx, componentFactory, errorHandler)
 		if err != nil && !errors.Is(err, ctx.Err()) {
-			ctx.Throw(fmt.Errorf("component %s encountered an unhandled irrecoverable error: %w", v.name, err))
+			err = fmt.Errorf("component %s encountered an unhandled irrecoverable error: %w", v.name, err)
+			fnb.HealthRegistry.SetComponentState(v.name, health.ComponentFailed, err)
+			ctx.Throw(err)
 		}
 
+		fnb.HealthRegistry.SetComponentState(v.name, health.ComponentStopped, nil)
 		log.Info().Msg("component shutdown complete")
 	})
 
@@ -1592,6 +1790,9 @@ func FlowNode(role string, opts ...Option) *FlowNodeBuilder {
 			Logger:                  zerolog.New(os.Stderr),
 			PeerManagerDependencies: NewDependencyList(),
 			ConfigManager:           updatable_configs.NewManager(),
+			MempoolRegistry:         mempool.NewRegistry(),
+			JobQueueRegistry:        jobqueue.NewRegistry(),
+			HealthRegistry:          health.NewRegistry(),
 		},
 		flags:                    pflag.CommandLine,
 		adminCommandBootstrapper: admin.NewCommandRunnerBootstrapper(),
@@ -1626,6 +1827,10 @@ func (fnb *FlowNodeBuilder) Initialize() error {
 		}
 	}
 
+	if fnb.HealthConfig.Addr != "" {
+		fnb.EnqueueHealthServerInit()
+	}
+
 	fnb.EnqueueTracer()
 
 	return nil
@@ -1650,6 +1855,24 @@ func (fnb *FlowNodeBuilder) RegisterDefaultAdminCommands() {
 		return storageCommands.NewReadSealsCommand(config.State, config.Storage.Seals, config.Storage.Index)
 	}).AdminCommand("get-latest-identity", func(config *NodeConfig) commands.AdminCommand {
 		return common.NewGetIdentityCommand(config.IdentityProvider)
+	}).AdminCommand("list-mempool-entries", func(config *NodeConfig) commands.AdminCommand {
+		return mempoolCommands.NewListEntriesCommand(config.MempoolRegistry)
+	}).AdminCommand("get-mempool-entry", func(config *NodeConfig) commands.AdminCommand {
+		return mempoolCommands.NewGetEntryCommand(config.MempoolRegistry)
+	}).AdminCommand("evict-mempool-entries", func(config *NodeConfig) commands.AdminCommand {
+		return mempoolCommands.NewEvictEntriesCommand(config.MempoolRegistry)
+	}).AdminCommand("list-dead-letters", func(config *NodeConfig) commands.AdminCommand {
+		return jobqueueCommands.NewListDeadLettersCommand(config.JobQueueRegistry)
+	}).AdminCommand("retry-dead-letter", func(config *NodeConfig) commands.AdminCommand {
+		return jobqueueCommands.NewRetryDeadLetterCommand(config.JobQueueRegistry)
+	}).AdminCommand("drop-dead-letter", func(config *NodeConfig) commands.AdminCommand {
+		return jobqueueCommands.NewDropDeadLetterCommand(config.JobQueueRegistry)
+	}).AdminCommand("read-traces", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewReadTracesCommand(config.TraceBuffer)
+	}).AdminCommand("list-profiles", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewListProfilesCommand(config.Profiler.Store())
+	}).AdminCommand("get-profile", func(config *NodeConfig) commands.AdminCommand {
+		return common.NewGetProfileCommand(config.Profiler.Store())
 	})
 }
 
@@ -1711,6 +1934,10 @@ func (fnb *FlowNodeBuilder) onStart() error {
 		return err
 	}
 
+	if err := fnb.initHealthChecks(); err != nil {
+		return err
+	}
+
 	if err := fnb.initProfiler(); err != nil {
 		return err
 	}
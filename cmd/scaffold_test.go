@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go/model/bootstrap"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/health"
 	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/module/profiler"
 	"github.com/onflow/flow-go/utils/unittest"
@@ -129,6 +130,71 @@ func TestComponentsRunSerially(t *testing.T) {
 	}, doneLogs)
 }
 
+// Test the state of components is reported to the health registry, including irrecoverable errors
+func TestComponentStatesReported(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalerCtx, errChan := irrecoverable.WithSignaler(ctx)
+
+	nb := FlowNode("scaffold test")
+	nb.componentBuilder = component.NewComponentManagerBuilder()
+
+	logger := &testLog{}
+
+	name1 := "component 1"
+	nb.Component(name1, func(node *NodeConfig) (module.ReadyDoneAware, error) {
+		return newMockReadyDone(logger, name1), nil
+	})
+
+	name2 := "component 2"
+	thrown := fmt.Errorf("%s error", name2)
+	throw := make(chan struct{})
+	nb.Component(name2, func(node *NodeConfig) (module.ReadyDoneAware, error) {
+		c := newMockComponent(logger, name2)
+		c.startFn = func(ctx irrecoverable.SignalerContext, name string) {
+			go func() {
+				<-throw
+				ctx.Throw(thrown)
+			}()
+		}
+		return c, nil
+	})
+
+	err := nb.handleComponents()
+	require.NoError(t, err)
+
+	cm := nb.componentBuilder.Build()
+	cm.Start(signalerCtx)
+	unittest.RequireCloseBefore(t, cm.Ready(), time.Second, "components should be ready")
+
+	report := nb.HealthRegistry.Readiness()
+	assert.True(t, report.Healthy)
+	require.Len(t, report.Components, 2)
+	for _, status := range report.Components {
+		assert.Equal(t, health.ComponentReady, status.State)
+	}
+
+	// the irrecoverable error is reported before it is propagated to the node
+	close(throw)
+	select {
+	case err := <-errChan:
+		assert.ErrorIs(t, err, thrown)
+	case <-time.After(time.Second):
+		t.Fatal("irrecoverable error should be propagated")
+	}
+
+	report = nb.HealthRegistry.Liveness()
+	assert.False(t, report.Healthy)
+	assert.Equal(t, name2, report.Components[1].Name)
+	assert.Equal(t, health.ComponentFailed, report.Components[1].State)
+	assert.Equal(t, thrown.Error(), report.Components[1].Error)
+
+	unittest.RequireCloseBefore(t, cm.Done(), time.Second, "components should shut down")
+	report = nb.HealthRegistry.Liveness()
+	assert.Equal(t, health.ComponentStopped, report.Components[0].State)
+	assert.Equal(t, health.ComponentFailed, report.Components[1].State)
+}
+
 func TestPostShutdown(t *testing.T) {
 	nb := FlowNode("scaffold test")
 
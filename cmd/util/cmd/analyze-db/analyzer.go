@@ -0,0 +1,240 @@
+package analyze_db
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// sizeBuckets are the upper bounds of the value size histogram buckets, in bytes.
+// Values larger than the last bound are counted in an additional overflow bucket.
+var sizeBuckets = []uint64{64, 256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20}
+
+// Config configures the analysis.
+type Config struct {
+	HeightRange uint64 // number of heights grouped into a single height range
+	PruneHeight uint64 // estimate the bytes reclaimed by pruning entries below this height, 0 to skip
+}
+
+// Usage is the space used by a set of entries.
+type Usage struct {
+	Count      uint64 `json:"count"`
+	KeyBytes   uint64 `json:"key_bytes"`
+	ValueBytes uint64 `json:"value_bytes"`
+}
+
+func (u *Usage) add(keySize uint64, valueSize uint64) {
+	u.Count++
+	u.KeyBytes += keySize
+	u.ValueBytes += valueSize
+}
+
+// TotalBytes returns the sum of the key and value bytes.
+func (u Usage) TotalBytes() uint64 {
+	return u.KeyBytes + u.ValueBytes
+}
+
+// HistogramBucket counts the values with a size up to MaxSize bytes, and above the MaxSize of
+// the previous bucket. MaxSize is 0 for the overflow bucket.
+type HistogramBucket struct {
+	MaxSize uint64 `json:"max_size"`
+	Count   uint64 `json:"count"`
+}
+
+// HeightRangeUsage is the space used by entries of blocks within a range of heights.
+type HeightRangeUsage struct {
+	Start uint64 `json:"start"` // first height of the range
+	End   uint64 `json:"end"`   // last height of the range
+	Usage
+}
+
+// PrefixReport is the space used by the entries stored under a single prefix code.
+type PrefixReport struct {
+	Code byte   `json:"code"`
+	Name string `json:"name"`
+	Usage
+	ValueSizes []HistogramBucket `json:"value_sizes"`
+	// HeightRanges is the usage per height range of entries whose key embeds a height or the ID
+	// of a finalized block.
+	HeightRanges []HeightRangeUsage `json:"height_ranges,omitempty"`
+
+	heightRanges map[uint64]*HeightRangeUsage
+}
+
+// PruneEstimate is the space used by entries which would be deleted by pruning all blocks
+// below the given height. Only entries whose key embeds a height or the ID of a finalized block
+// are accounted for, entities referenced by ID, like transactions or collections, are not.
+type PruneEstimate struct {
+	Height uint64 `json:"height"`
+	Usage
+}
+
+// Report is the result of analyzing a database.
+type Report struct {
+	Usage
+	Prefixes     []*PrefixReport    `json:"prefixes"`      // sorted by total bytes, largest first
+	HeightRanges []HeightRangeUsage `json:"height_ranges"` // usage per height range, over all prefixes
+	Prune        *PruneEstimate     `json:"prune,omitempty"`
+}
+
+// Analyze scans all keys of the database, and reports the space used per prefix code.
+// Values are not read, their size is taken from the key's metadata.
+// No errors are expected during normal operation.
+func Analyze(db *badger.DB, config Config) (*Report, error) {
+	if config.HeightRange == 0 {
+		return nil, fmt.Errorf("height range must be positive")
+	}
+
+	// heights of finalized blocks, to relate entries keyed by block ID to a height
+	heights := make(map[flow.Identifier]uint64)
+	err := db.View(operation.TraverseBlockHeights(func(height uint64, blockID flow.Identifier) error {
+		heights[blockID] = height
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("could not read finalized block heights: %w", err)
+	}
+
+	report := &Report{}
+	if config.PruneHeight > 0 {
+		report.Prune = &PruneEstimate{Height: config.PruneHeight}
+	}
+	prefixes := make(map[byte]*PrefixReport)
+	heightRanges := make(map[uint64]*HeightRangeUsage)
+
+	err = db.View(func(tx *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			key := item.Key()
+			if len(key) == 0 {
+				continue
+			}
+			keySize := uint64(len(key))
+			valueSize := uint64(item.ValueSize())
+
+			prefix, ok := prefixes[key[0]]
+			if !ok {
+				prefix = newPrefixReport(key[0])
+				prefixes[key[0]] = prefix
+			}
+			prefix.Usage.add(keySize, valueSize)
+			prefix.ValueSizes[sizeBucket(valueSize)].Count++
+			report.Usage.add(keySize, valueSize)
+
+			height, ok := entryHeight(key, heights)
+			if !ok {
+				continue
+			}
+			start := height - height%config.HeightRange
+			addToRange(prefix.heightRanges, start, config.HeightRange, keySize, valueSize)
+			addToRange(heightRanges, start, config.HeightRange, keySize, valueSize)
+			if report.Prune != nil && height < report.Prune.Height {
+				report.Prune.add(keySize, valueSize)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not scan keys: %w", err)
+	}
+
+	for _, prefix := range prefixes {
+		prefix.HeightRanges = sortedRanges(prefix.heightRanges)
+		report.Prefixes = append(report.Prefixes, prefix)
+	}
+	sort.Slice(report.Prefixes, func(i, j int) bool {
+		a, b := report.Prefixes[i], report.Prefixes[j]
+		if a.TotalBytes() != b.TotalBytes() {
+			return a.TotalBytes() > b.TotalBytes()
+		}
+		return a.Code < b.Code
+	})
+	report.HeightRanges = sortedRanges(heightRanges)
+
+	return report, nil
+}
+
+func newPrefixReport(code byte) *PrefixReport {
+	name := fmt.Sprintf("unknown (%d)", code)
+	if prefix, ok := operation.LookupKeyPrefix(code); ok {
+		name = prefix.Name
+	}
+
+	return &PrefixReport{
+		Code:         code,
+		Name:         name,
+		ValueSizes:   newHistogram(),
+		heightRanges: make(map[uint64]*HeightRangeUsage),
+	}
+}
+
+// newHistogram returns the value size histogram buckets, without counts.
+func newHistogram() []HistogramBucket {
+	buckets := make([]HistogramBucket, len(sizeBuckets)+1)
+	for i, size := range sizeBuckets {
+		buckets[i].MaxSize = size
+	}
+	return buckets
+}
+
+// sizeBucket returns the index of the histogram bucket counting values of the given size.
+func sizeBucket(size uint64) int {
+	for i, bound := range sizeBuckets {
+		if size <= bound {
+			return i
+		}
+	}
+	return len(sizeBuckets)
+}
+
+// entryHeight returns the height of the block the entry with the given key belongs to, and
+// false if the key embeds neither a height nor the ID of a finalized block.
+func entryHeight(key []byte, heights map[flow.Identifier]uint64) (uint64, bool) {
+	prefix, ok := operation.LookupKeyPrefix(key[0])
+	if !ok {
+		return 0, false
+	}
+
+	switch prefix.Layout {
+	case operation.KeyLayoutHeight:
+		return operation.HeightFromKey(key)
+	case operation.KeyLayoutBlockID:
+		blockID, ok := operation.BlockIDFromKey(key)
+		if !ok {
+			return 0, false
+		}
+		height, ok := heights[blockID]
+		return height, ok
+	default:
+		return 0, false
+	}
+}
+
+func addToRange(ranges map[uint64]*HeightRangeUsage, start uint64, width uint64, keySize uint64, valueSize uint64) {
+	usage, ok := ranges[start]
+	if !ok {
+		usage = &HeightRangeUsage{Start: start, End: start + width - 1}
+		ranges[start] = usage
+	}
+	usage.add(keySize, valueSize)
+}
+
+func sortedRanges(ranges map[uint64]*HeightRangeUsage) []HeightRangeUsage {
+	sorted := make([]HeightRangeUsage, 0, len(ranges))
+	for _, usage := range ranges {
+		sorted = append(sorted, *usage)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+	return sorted
+}
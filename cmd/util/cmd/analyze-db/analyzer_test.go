@@ -0,0 +1,119 @@
+package analyze_db
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage/badger/operation"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestAnalyze(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		// 10 finalized blocks with a header, a height index and a commit each
+		for height := uint64(0); height < 10; height++ {
+			header := unittest.BlockHeaderFixture()
+			header.Height = height
+			blockID := header.ID()
+			require.NoError(t, db.Update(operation.InsertHeader(blockID, header)))
+			require.NoError(t, db.Update(operation.IndexBlockHeight(height, blockID)))
+			require.NoError(t, db.Update(operation.InsertExecutionStateInteractions(blockID, nil)))
+		}
+		// a block which is not finalized
+		header := unittest.BlockHeaderFixture()
+		require.NoError(t, db.Update(operation.InsertHeader(header.ID(), header)))
+		// an entity keyed by its own ID
+		tx := unittest.TransactionBodyFixture()
+		require.NoError(t, db.Update(operation.InsertTransaction(tx.ID(), &tx)))
+
+		report, err := Analyze(db, Config{HeightRange: 4, PruneHeight: 5})
+		require.NoError(t, err)
+
+		prefixes := make(map[string]*PrefixReport)
+		for _, prefix := range report.Prefixes {
+			prefixes[prefix.Name] = prefix
+		}
+		require.Len(t, prefixes, 4)
+
+		headers := prefixes["header"]
+		require.NotNil(t, headers)
+		assert.Equal(t, uint64(11), headers.Count)
+		assert.Equal(t, uint64(11*(1+flow.IdentifierLen)), headers.KeyBytes)
+		assert.Greater(t, headers.ValueBytes, uint64(0))
+		// the header of the block which is not finalized has no height
+		require.Len(t, headers.HeightRanges, 3)
+		assert.Equal(t, HeightRangeUsage{Start: 0, End: 3}, rangeWithoutUsage(headers.HeightRanges[0]))
+		assert.Equal(t, uint64(4), headers.HeightRanges[0].Count)
+		assert.Equal(t, uint64(2), headers.HeightRanges[2].Count)
+
+		index := prefixes["height to block"]
+		require.NotNil(t, index)
+		assert.Equal(t, uint64(10), index.Count)
+		require.Len(t, index.HeightRanges, 3)
+
+		transactions := prefixes["transaction"]
+		require.NotNil(t, transactions)
+		assert.Equal(t, uint64(1), transactions.Count)
+		assert.Empty(t, transactions.HeightRanges)
+
+		var histogramCount uint64
+		for _, bucket := range headers.ValueSizes {
+			histogramCount += bucket.Count
+		}
+		assert.Equal(t, headers.Count, histogramCount)
+
+		// header, height index and interactions of heights 0 to 4
+		require.NotNil(t, report.Prune)
+		assert.Equal(t, uint64(15), report.Prune.Count)
+
+		require.Len(t, report.HeightRanges, 3)
+		assert.Equal(t, uint64(12), report.HeightRanges[0].Count)
+
+		var total uint64
+		for _, prefix := range report.Prefixes {
+			total += prefix.TotalBytes()
+		}
+		assert.Equal(t, total, report.TotalBytes())
+		assert.Equal(t, uint64(32), report.Count)
+
+		// the largest prefix is listed first
+		for i := 1; i < len(report.Prefixes); i++ {
+			assert.GreaterOrEqual(t, report.Prefixes[i-1].TotalBytes(), report.Prefixes[i].TotalBytes())
+		}
+
+		var table bytes.Buffer
+		require.NoError(t, report.WriteTable(&table))
+		assert.Contains(t, table.String(), "height to block")
+		assert.Contains(t, table.String(), "pruning below height 5")
+
+		var decoded Report
+		var encoded bytes.Buffer
+		require.NoError(t, report.WriteJSON(&encoded))
+		require.NoError(t, json.Unmarshal(encoded.Bytes(), &decoded))
+		assert.Equal(t, report.Count, decoded.Count)
+		assert.Len(t, decoded.Prefixes, 4)
+	})
+}
+
+func TestSizeBucket(t *testing.T) {
+	assert.Equal(t, 0, sizeBucket(0))
+	assert.Equal(t, 0, sizeBucket(64))
+	assert.Equal(t, 1, sizeBucket(65))
+	assert.Equal(t, len(sizeBuckets), sizeBucket(1<<20+1))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "512B", formatBytes(512))
+	assert.Equal(t, "1.5KiB", formatBytes(1536))
+	assert.Equal(t, "2GiB", formatBytes(2<<30))
+}
+
+func rangeWithoutUsage(usage HeightRangeUsage) HeightRangeUsage {
+	return HeightRangeUsage{Start: usage.Start, End: usage.End}
+}
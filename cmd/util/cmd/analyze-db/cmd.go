@@ -0,0 +1,78 @@
+package analyze_db
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var (
+	flagDatadir     string
+	flagHeightRange uint64
+	flagPruneHeight uint64
+	flagOutput      string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "analyze-db",
+	Short: "Reports the space used by each data type of a badger database",
+	Long: `Scans all keys of a badger database, and reports count, key and value bytes and a histogram
+of value sizes per prefix code. Entries whose key embeds a height or the ID of a finalized block
+are additionally grouped by height range, and can be used to estimate the space reclaimed by pruning.`,
+	Run: run,
+}
+
+func init() {
+	Cmd.Flags().StringVarP(&flagDatadir, "datadir", "d", "",
+		"directory of the badger database")
+	_ = Cmd.MarkFlagRequired("datadir")
+
+	Cmd.Flags().Uint64Var(&flagHeightRange, "height-range", 100_000,
+		"number of heights grouped into a single height range")
+
+	Cmd.Flags().Uint64Var(&flagPruneHeight, "prune-height", 0,
+		"estimate the space reclaimed by pruning all blocks below this height, 0 to skip")
+
+	Cmd.Flags().StringVarP(&flagOutput, "output", "o", outputTable,
+		fmt.Sprintf("output format, one of: %s, %s", outputTable, outputJSON))
+}
+
+func run(*cobra.Command, []string) {
+	if flagOutput != outputTable && flagOutput != outputJSON {
+		log.Fatal().Str("output", flagOutput).Msg("unsupported output format")
+	}
+
+	// the database is opened read-only, so that analyzing never modifies it
+	db, err := badger.Open(badger.DefaultOptions(flagDatadir).WithReadOnly(true).WithLogger(nil))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open badger database, it must not be in use by a running node")
+	}
+	defer db.Close()
+
+	log.Info().Str("datadir", flagDatadir).Msg("analyzing database")
+
+	report, err := Analyze(db, Config{
+		HeightRange: flagHeightRange,
+		PruneHeight: flagPruneHeight,
+	})
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not analyze database")
+	}
+
+	if flagOutput == outputJSON {
+		err = report.WriteJSON(os.Stdout)
+	} else {
+		err = report.WriteTable(os.Stdout)
+	}
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not write report")
+	}
+}
@@ -0,0 +1,109 @@
+package analyze_db
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteTable writes the report as human-readable tables.
+func (r *Report) WriteTable(w io.Writer) error {
+	tw := newTableWriter(w)
+	fmt.Fprintln(tw, "CODE\tPREFIX\tCOUNT\tKEYS\tVALUES\tTOTAL\tSHARE")
+	for _, prefix := range r.Prefixes {
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%s\t%s\t%s\t%.1f%%\n",
+			prefix.Code, prefix.Name, prefix.Count,
+			formatBytes(prefix.KeyBytes), formatBytes(prefix.ValueBytes), formatBytes(prefix.TotalBytes()),
+			share(prefix.TotalBytes(), r.TotalBytes()))
+	}
+	fmt.Fprintf(tw, "\ttotal\t%d\t%s\t%s\t%s\t\n",
+		r.Count, formatBytes(r.KeyBytes), formatBytes(r.ValueBytes), formatBytes(r.TotalBytes()))
+	err := tw.Flush()
+	if err != nil {
+		return err
+	}
+
+	tw = newTableWriter(w)
+	fmt.Fprintln(tw)
+	header := []string{"CODE", "VALUE SIZES"}
+	for _, bucket := range newHistogram() {
+		header = append(header, bucketLabel(bucket))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, prefix := range r.Prefixes {
+		row := []string{fmt.Sprint(prefix.Code), prefix.Name}
+		for _, bucket := range prefix.ValueSizes {
+			row = append(row, fmt.Sprint(bucket.Count))
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	err = tw.Flush()
+	if err != nil {
+		return err
+	}
+
+	if len(r.HeightRanges) > 0 {
+		tw = newTableWriter(w)
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "HEIGHTS\tCOUNT\tKEYS\tVALUES\tTOTAL")
+		for _, usage := range r.HeightRanges {
+			fmt.Fprintf(tw, "%d-%d\t%d\t%s\t%s\t%s\n",
+				usage.Start, usage.End, usage.Count,
+				formatBytes(usage.KeyBytes), formatBytes(usage.ValueBytes), formatBytes(usage.TotalBytes()))
+		}
+		err = tw.Flush()
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.Prune != nil {
+		_, err = fmt.Fprintf(w, "\npruning below height %d would reclaim %s in %d entries (%.1f%% of all bytes)\n",
+			r.Prune.Height, formatBytes(r.Prune.TotalBytes()), r.Prune.Count, share(r.Prune.TotalBytes(), r.TotalBytes()))
+	}
+	return err
+}
+
+func newTableWriter(w io.Writer) *tabwriter.Writer {
+	return tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+}
+
+func bucketLabel(bucket HistogramBucket) string {
+	if bucket.MaxSize == 0 {
+		return ">" + formatBytes(sizeBuckets[len(sizeBuckets)-1])
+	}
+	return "<=" + formatBytes(bucket.MaxSize)
+}
+
+func share(part uint64, total uint64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return 100 * float64(part) / float64(total)
+}
+
+// formatBytes formats a number of bytes using binary units, e.g. 1KiB or 1.5MiB.
+func formatBytes(bytes uint64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	div, exp := uint64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	if bytes%div == 0 {
+		return fmt.Sprintf("%d%ciB", bytes/div, "KMGTPE"[exp])
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
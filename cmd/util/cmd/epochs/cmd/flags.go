@@ -8,6 +8,16 @@ var (
 
 	flagFlowSupplyIncreasePercentage string
 
+	// epoch simulation flags
+	flagSnapshotPath             string
+	flagIdentitiesPath           string
+	flagInternalNodeIDsPath      string
+	flagNumViewsInEpoch          uint64
+	flagNumViewsInStakingAuction uint64
+	flagNumViewsInDKGPhase       uint64
+	flagCollectionClusters       uint
+	flagRandomSource             []byte
+
 	// contract address flags
 	flagFungibleTokenAddress string
 	flagFlowTokenAddress     string
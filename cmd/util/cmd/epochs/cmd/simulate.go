@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/bootstrap/dkg"
+	"github.com/onflow/flow-go/cmd/bootstrap/run"
+	"github.com/onflow/flow-go/consensus/hotstuff/committees/leader"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/badger"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+)
+
+// simulateCmd represents a command to preview the transition into the next epoch.
+//
+// The command uses the same cluster assignment as the bootstrap tools, constructs the
+// EpochSetup and EpochCommit service events for the next epoch, and validates them
+// using the same checks the protocol state applies when the service events are sealed.
+// Since no DKG or cluster QC voting takes place, the EpochCommit event contains
+// placeholder keys and QCs.
+var simulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Previews the transition into the next epoch for a proposed identity table",
+	Long: "Constructs the next epoch from a protocol state snapshot, a proposed identity table and epoch " +
+		"configuration, then writes the resulting clusters, leader schedule and any invariant violations as JSON to STDOUT.",
+	Run: simulateRun,
+}
+
+func init() {
+	rootCmd.AddCommand(simulateCmd)
+	addSimulateCmdFlags()
+}
+
+func addSimulateCmdFlags() {
+	simulateCmd.Flags().StringVar(&flagSnapshotPath, "snapshot", "", "path to the protocol state snapshot (defaults to the root snapshot in --boot-dir)")
+	simulateCmd.Flags().StringVar(&flagIdentitiesPath, "identities", "", "path to a JSON file containing the proposed identity table for the next epoch")
+	simulateCmd.Flags().StringVar(&flagInternalNodeIDsPath, "internal-node-ids", "", "path to a JSON file containing the IDs of internal nodes (all nodes are considered internal if not set)")
+	simulateCmd.Flags().Uint64Var(&flagNumViewsInEpoch, "epoch-length", 4000, "length of the next epoch in number of views")
+	simulateCmd.Flags().Uint64Var(&flagNumViewsInStakingAuction, "epoch-staking-phase-length", 100, "length of the next epoch's staking phase in number of views")
+	simulateCmd.Flags().Uint64Var(&flagNumViewsInDKGPhase, "epoch-dkg-phase-length", 1000, "length of each DKG phase of the next epoch in number of views")
+	simulateCmd.Flags().UintVar(&flagCollectionClusters, "collection-clusters", 2, "number of collection clusters in the next epoch")
+	simulateCmd.Flags().BytesHexVar(&flagRandomSource, "random-source", nil, "hex-encoded random source for the next epoch (randomly generated if not set)")
+
+	_ = simulateCmd.MarkFlagRequired("identities")
+}
+
+// simulationReport is the output of the simulate command.
+type simulationReport struct {
+	Counter            uint64
+	FirstView          uint64
+	FinalView          uint64
+	DKGPhase1FinalView uint64
+	DKGPhase2FinalView uint64
+	DKGPhase3FinalView uint64
+	RandomSource       string
+	Clusters           []simulatedCluster
+	LeaderSchedule     []simulatedLeader
+	Violations         []string
+}
+
+// simulatedCluster describes one collection cluster of the simulated epoch.
+type simulatedCluster struct {
+	Index     uint
+	Members   flow.IdentifierList
+	Internals uint
+	Partners  uint
+}
+
+// simulatedLeader describes how many views of the simulated epoch a consensus node leads.
+type simulatedLeader struct {
+	NodeID flow.Identifier
+	Weight uint64
+	Views  uint64
+}
+
+// simulateRun simulates the transition into the next epoch and writes the report to stdout.
+func simulateRun(cmd *cobra.Command, args []string) {
+
+	stdout := cmd.OutOrStdout()
+
+	snapshotPath := flagSnapshotPath
+	if snapshotPath == "" {
+		if flagBootDir == "" {
+			log.Fatal().Msg("must provide a source for the snapshot (specify either --snapshot or --boot-dir)")
+		}
+		snapshotPath = filepath.Join(flagBootDir, bootstrap.PathRootProtocolStateSnapshot)
+	}
+	snapshot, err := getSnapshotFromLocalBootstrapDir(snapshotPath)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", snapshotPath).Msg("failed to retrieve snapshot")
+	}
+
+	var identities flow.IdentityList
+	err = readJSON(flagIdentitiesPath, &identities)
+	if err != nil {
+		log.Fatal().Err(err).Str("path", flagIdentitiesPath).Msg("failed to read identity table")
+	}
+
+	var internalNodeIDs flow.IdentifierList
+	if flagInternalNodeIDsPath != "" {
+		err = readJSON(flagInternalNodeIDsPath, &internalNodeIDs)
+		if err != nil {
+			log.Fatal().Err(err).Str("path", flagInternalNodeIDsPath).Msg("failed to read internal node IDs")
+		}
+	} else {
+		internalNodeIDs = identities.NodeIDs()
+	}
+
+	randomSource := flagRandomSource
+	if len(randomSource) == 0 {
+		randomSource = make([]byte, flow.EpochSetupRandomSourceLength)
+		_, err = rand.Read(randomSource)
+		if err != nil {
+			log.Fatal().Err(err).Msg("could not generate random source")
+		}
+	}
+	if len(randomSource) != flow.EpochSetupRandomSourceLength {
+		log.Fatal().Int("expected", flow.EpochSetupRandomSourceLength).Int("actual", len(randomSource)).Msg("random source provided length is not valid")
+	}
+
+	report, err := simulateEpochTransition(snapshot, identities, internalNodeIDs, randomSource)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not simulate epoch transition")
+	}
+
+	encoded, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not encode simulation report")
+	}
+	_, err = stdout.Write(encoded)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not write simulation report")
+	}
+}
+
+// simulateEpochTransition constructs the EpochSetup and EpochCommit service events for
+// the epoch following the current epoch of the given snapshot, validates them and
+// computes the resulting clusters and leader schedule. Invalid configurations are
+// reported as violations, errors are only returned if the simulation could not be run.
+func simulateEpochTransition(
+	snapshot protocol.Snapshot,
+	identities flow.IdentityList,
+	internalNodeIDs flow.IdentifierList,
+	randomSource []byte,
+) (*simulationReport, error) {
+
+	activeSetup, err := epochSetupFromEpoch(snapshot.Epochs().Current())
+	if err != nil {
+		return nil, fmt.Errorf("could not get current epoch: %w", err)
+	}
+
+	participants := identities.Sort(order.Canonical)
+	partners := participants.Filter(filter.Not(filter.HasNodeID(internalNodeIDs...)))
+	internals := participants.Filter(filter.HasNodeID(internalNodeIDs...))
+
+	report := &simulationReport{
+		Violations: make([]string, 0),
+	}
+	violation := func(msg string, args ...interface{}) {
+		report.Violations = append(report.Violations, fmt.Sprintf(msg, args...))
+	}
+
+	// STEP 1: cluster assignment, using the same procedure as the bootstrap tools
+	clusterAssignmentSeed := int64(binary.BigEndian.Uint64(randomSource))
+	assignments, clusters, err := run.ConstructClusterAssignment(partners, internals, flagCollectionClusters, clusterAssignmentSeed)
+	if err != nil {
+		violation("invalid cluster assignment: %s", err)
+	}
+	for i, cluster := range clusters {
+		simulated := simulatedCluster{
+			Index:   uint(i),
+			Members: cluster.NodeIDs(),
+		}
+		for _, node := range cluster {
+			if internalNodeIDs.Contains(node.NodeID) {
+				simulated.Internals++
+			} else {
+				simulated.Partners++
+			}
+		}
+		err = run.CheckClusterByzantineThreshold(simulated.Partners, simulated.Internals)
+		if err != nil {
+			violation("cluster %d: %s", i, err)
+		}
+		report.Clusters = append(report.Clusters, simulated)
+	}
+
+	// the same constraints as for bootstrapping apply to the next epoch
+	err = run.CheckCollectorCount(uint(len(participants.Filter(filter.HasRole(flow.RoleCollection)))), flagCollectionClusters)
+	if err != nil {
+		violation("%s", err)
+	}
+	err = run.EnsureUniformNodeWeightsPerRole(participants)
+	if err != nil {
+		violation("%s", err)
+	}
+
+	// STEP 2: construct the service events for the next epoch
+	firstView := activeSetup.FinalView + 1
+	setup := &flow.EpochSetup{
+		Counter:            activeSetup.Counter + 1,
+		FirstView:          firstView,
+		FinalView:          firstView + flagNumViewsInEpoch - 1,
+		DKGPhase1FinalView: firstView + flagNumViewsInStakingAuction + flagNumViewsInDKGPhase - 1,
+		DKGPhase2FinalView: firstView + flagNumViewsInStakingAuction + flagNumViewsInDKGPhase*2 - 1,
+		DKGPhase3FinalView: firstView + flagNumViewsInStakingAuction + flagNumViewsInDKGPhase*3 - 1,
+		Participants:       participants,
+		Assignments:        assignments,
+		RandomSource:       randomSource,
+	}
+	if setup.DKGPhase3FinalView >= setup.FinalView {
+		violation("DKG phase 3 final view (%d) must be before the epoch final view (%d)", setup.DKGPhase3FinalView, setup.FinalView)
+	}
+
+	commit, err := placeholderEpochCommit(setup)
+	if err != nil {
+		return nil, fmt.Errorf("could not construct epoch commit: %w", err)
+	}
+
+	report.Counter = setup.Counter
+	report.FirstView = setup.FirstView
+	report.FinalView = setup.FinalView
+	report.DKGPhase1FinalView = setup.DKGPhase1FinalView
+	report.DKGPhase2FinalView = setup.DKGPhase2FinalView
+	report.DKGPhase3FinalView = setup.DKGPhase3FinalView
+	report.RandomSource = fmt.Sprintf("%x", randomSource)
+
+	// STEP 3: validate the service events as the protocol state would
+	err = badger.ValidateEpochTransition(activeSetup, setup, commit)
+	if err != nil {
+		violation("invalid epoch transition: %s", err)
+		// the leader selection requires a valid setup event
+		return report, nil
+	}
+
+	// STEP 4: compute the leader schedule for the consensus committee
+	epoch, err := inmem.NewSetupEpoch(setup)
+	if err != nil {
+		return nil, fmt.Errorf("could not create epoch: %w", err)
+	}
+	selection, err := leader.SelectionForConsensus(epoch)
+	if err != nil {
+		return nil, fmt.Errorf("could not compute leader selection: %w", err)
+	}
+	views := make(map[flow.Identifier]uint64)
+	for view := setup.FirstView; view <= setup.FinalView; view++ {
+		leaderID, err := selection.LeaderForView(view)
+		if err != nil {
+			return nil, fmt.Errorf("could not get leader for view %d: %w", view, err)
+		}
+		views[leaderID]++
+	}
+	for _, node := range participants.Filter(filter.HasRole(flow.RoleConsensus)) {
+		report.LeaderSchedule = append(report.LeaderSchedule, simulatedLeader{
+			NodeID: node.NodeID,
+			Weight: node.Weight,
+			Views:  views[node.NodeID],
+		})
+	}
+
+	return report, nil
+}
+
+// epochSetupFromEpoch reconstructs the EpochSetup service event of the given epoch.
+func epochSetupFromEpoch(epoch protocol.Epoch) (*flow.EpochSetup, error) {
+	counter, err := epoch.Counter()
+	if err != nil {
+		return nil, fmt.Errorf("could not get counter: %w", err)
+	}
+	firstView, err := epoch.FirstView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get first view: %w", err)
+	}
+	finalView, err := epoch.FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get final view: %w", err)
+	}
+	dkgPhase1FinalView, err := epoch.DKGPhase1FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get dkg phase 1 final view: %w", err)
+	}
+	dkgPhase2FinalView, err := epoch.DKGPhase2FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get dkg phase 2 final view: %w", err)
+	}
+	dkgPhase3FinalView, err := epoch.DKGPhase3FinalView()
+	if err != nil {
+		return nil, fmt.Errorf("could not get dkg phase 3 final view: %w", err)
+	}
+	participants, err := epoch.InitialIdentities()
+	if err != nil {
+		return nil, fmt.Errorf("could not get initial identities: %w", err)
+	}
+	clustering, err := epoch.Clustering()
+	if err != nil {
+		return nil, fmt.Errorf("could not get clustering: %w", err)
+	}
+	randomSource, err := epoch.RandomSource()
+	if err != nil {
+		return nil, fmt.Errorf("could not get random source: %w", err)
+	}
+
+	assignments := make(flow.AssignmentList, 0, len(clustering))
+	for _, cluster := range clustering {
+		assignments = append(assignments, cluster.NodeIDs())
+	}
+
+	return &flow.EpochSetup{
+		Counter:            counter,
+		FirstView:          firstView,
+		FinalView:          finalView,
+		DKGPhase1FinalView: dkgPhase1FinalView,
+		DKGPhase2FinalView: dkgPhase2FinalView,
+		DKGPhase3FinalView: dkgPhase3FinalView,
+		Participants:       participants,
+		Assignments:        assignments,
+		RandomSource:       randomSource,
+	}, nil
+}
+
+// placeholderEpochCommit constructs an EpochCommit event for the given setup event.
+// Since neither the DKG nor the cluster QC voting are run, the commit contains keys
+// generated locally and cluster QCs without signatures, voted on by all cluster members.
+func placeholderEpochCommit(setup *flow.EpochSetup) (*flow.EpochCommit, error) {
+
+	qcs := make([]*flow.QuorumCertificateWithSignerIDs, 0, len(setup.Assignments))
+	for _, members := range setup.Assignments {
+		qcs = append(qcs, &flow.QuorumCertificateWithSignerIDs{
+			SignerIDs: members,
+		})
+	}
+
+	commit := &flow.EpochCommit{
+		Counter:    setup.Counter,
+		ClusterQCs: flow.ClusterQCVoteDatasFromQCs(qcs),
+	}
+
+	nDKG := len(setup.Participants.Filter(filter.IsValidDKGParticipant))
+	if nDKG == 0 {
+		// leave the DKG keys empty, the validation will report the missing consensus nodes
+		return commit, nil
+	}
+	seed := make([]byte, crypto.KeyGenSeedMinLenBLSBLS12381)
+	_, err := rand.Read(seed)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate seed: %w", err)
+	}
+	dkgData, err := dkg.RunFastKG(nDKG, seed)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate dkg keys: %w", err)
+	}
+	commit.DKGGroupKey = dkgData.PubGroupKey
+	commit.DKGParticipantKeys = dkgData.PubKeyShares
+
+	return commit, nil
+}
+
+// readJSON reads the JSON file at the given path and decodes it into target.
+func readJSON(path string, target interface{}) error {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read file (path=%s): %w", path, err)
+	}
+	err = json.Unmarshal(bz, target)
+	if err != nil {
+		return fmt.Errorf("could not decode file (path=%s): %w", path, err)
+	}
+	return nil
+}
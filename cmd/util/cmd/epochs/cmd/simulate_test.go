@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSimulate tests simulating the transition into the next epoch.
+func TestSimulate(t *testing.T) {
+
+	// 2 clusters of 6 collectors each, plus nodes of all other roles
+	collectors := unittest.IdentityListFixture(12, unittest.WithRole(flow.RoleCollection))
+	identities := append(unittest.IdentityListFixture(8, unittest.WithAllRolesExcept(flow.RoleCollection)), collectors...)
+
+	run := func(t *testing.T, bootDir string, internalNodeIDs flow.IdentifierList) simulationReport {
+		rootSnapshot := unittest.RootSnapshotFixture(unittest.IdentityListFixture(10, unittest.WithAllRoles()))
+		err := writeRootSnapshot(bootDir, rootSnapshot)
+		require.NoError(t, err)
+
+		flagIdentitiesPath = filepath.Join(bootDir, "identities.json")
+		err = writeJSON(flagIdentitiesPath, identities)
+		require.NoError(t, err)
+
+		flagInternalNodeIDsPath = ""
+		if internalNodeIDs != nil {
+			flagInternalNodeIDsPath = filepath.Join(bootDir, "internal-node-ids.json")
+			err = writeJSON(flagInternalNodeIDsPath, internalNodeIDs)
+			require.NoError(t, err)
+		}
+
+		// set initial flag values
+		flagBootDir = bootDir
+		flagSnapshotPath = ""
+		flagNumViewsInEpoch = 4000
+		flagNumViewsInStakingAuction = 100
+		flagNumViewsInDKGPhase = 1000
+		flagCollectionClusters = 2
+		flagRandomSource = unittest.RandomBytes(flow.EpochSetupRandomSourceLength)
+
+		// run command with overwritten stdout
+		stdout := bytes.NewBuffer(nil)
+		simulateCmd.SetOut(stdout)
+		simulateRun(simulateCmd, nil)
+
+		var report simulationReport
+		err = json.NewDecoder(stdout).Decode(&report)
+		require.NoError(t, err)
+
+		currentFinalView, err := rootSnapshot.Epochs().Current().FinalView()
+		require.NoError(t, err)
+		assert.Equal(t, currentFinalView+1, report.FirstView)
+		assert.Equal(t, currentFinalView+flagNumViewsInEpoch, report.FinalView)
+
+		return report
+	}
+
+	// with only internal nodes, the transition should be valid, and each
+	// consensus node should lead some views of the next epoch
+	t.Run("happy path", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(bootDir string) {
+			report := run(t, bootDir, nil)
+
+			assert.Empty(t, report.Violations)
+			require.Len(t, report.Clusters, 2)
+			for _, cluster := range report.Clusters {
+				assert.Len(t, cluster.Members, 6)
+				assert.Equal(t, uint(6), cluster.Internals)
+				assert.Equal(t, uint(0), cluster.Partners)
+			}
+
+			consensusNodes := identities.Filter(filter.HasRole(flow.RoleConsensus))
+			require.Len(t, report.LeaderSchedule, len(consensusNodes))
+			totalViews := uint64(0)
+			for _, leader := range report.LeaderSchedule {
+				_, ok := consensusNodes.ByNodeID(leader.NodeID)
+				assert.True(t, ok)
+				assert.Greater(t, leader.Views, uint64(0))
+				totalViews += leader.Views
+			}
+			assert.Equal(t, flagNumViewsInEpoch, totalViews)
+		})
+	})
+
+	// with too many partner collectors, the clusters have no Byzantine
+	// majority of internal nodes, which should be reported as violation
+	t.Run("partner majority in clusters", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(bootDir string) {
+			internals := identities.Filter(filter.Not(filter.HasRole(flow.RoleCollection))).NodeIDs()
+			internals = append(internals, collectors[:4].NodeIDs()...)
+			report := run(t, bootDir, internals)
+
+			require.Len(t, report.Clusters, 2)
+			assert.Len(t, report.Violations, 2)
+			for _, violation := range report.Violations {
+				assert.Contains(t, violation, "no Byzantine majority")
+			}
+		})
+	})
+
+	// without any consensus nodes, the epoch setup event is invalid
+	t.Run("invalid epoch setup", func(t *testing.T) {
+		unittest.RunWithTempDir(t, func(bootDir string) {
+			original := identities
+			identities = identities.Filter(filter.Not(filter.HasRole(flow.RoleConsensus)))
+			defer func() { identities = original }()
+
+			report := run(t, bootDir, nil)
+
+			require.NotEmpty(t, report.Violations)
+			assert.Contains(t, report.Violations[len(report.Violations)-1], "need at least one consensus node")
+			assert.Empty(t, report.LeaderSchedule)
+		})
+	})
+}
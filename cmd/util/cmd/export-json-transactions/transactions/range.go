@@ -22,10 +22,13 @@ type CollectionData struct {
 }
 
 type Transaction struct {
-	TxID   string `json:"tx_id"`
-	Index  int    `json:"tx_index"`
-	Script string `json:"script"`
-	Payer  string `json:"payer_address"`
+	TxID        string   `json:"tx_id"`
+	Index       int      `json:"tx_index"`
+	Script      string   `json:"script"`
+	Arguments   []string `json:"arguments"` // JSON-CDC encoded arguments
+	Payer       string   `json:"payer_address"`
+	Authorizers []string `json:"authorizers"`
+	GasLimit    uint64   `json:"gas_limit"`
 }
 
 type Finder struct {
@@ -102,11 +105,22 @@ func FindBlockTransactions(
 		}
 		txs := make([]*Transaction, 0, len(col.Transactions))
 		for txIndex, tx := range col.Transactions {
+			arguments := make([]string, 0, len(tx.Arguments))
+			for _, argument := range tx.Arguments {
+				arguments = append(arguments, string(argument))
+			}
+			authorizers := make([]string, 0, len(tx.Authorizers))
+			for _, authorizer := range tx.Authorizers {
+				authorizers = append(authorizers, authorizer.String())
+			}
 			txs = append(txs, &Transaction{
-				TxID:   tx.ID().String(),
-				Index:  txIndex,
-				Script: string(tx.Script),
-				Payer:  tx.Payer.String(),
+				TxID:        tx.ID().String(),
+				Index:       txIndex,
+				Script:      string(tx.Script),
+				Arguments:   arguments,
+				Payer:       tx.Payer.String(),
+				Authorizers: authorizers,
+				GasLimit:    tx.GasLimit,
 			})
 		}
 		cols = append(cols, &CollectionData{
@@ -91,6 +91,16 @@ func TestFindBlockTransactions(t *testing.T) {
 			col1.Collection.Transactions[0].ID().String(),
 		)
 
+		// arguments and authorizers are exported, so that transactions can be replayed
+		tx := col1.Collection.Transactions[0]
+		exported := fetched[0].Collections[0].Transactions[0]
+		require.Len(t, exported.Arguments, len(tx.Arguments))
+		for i, argument := range tx.Arguments {
+			require.Equal(t, string(argument), exported.Arguments[i])
+		}
+		require.Len(t, exported.Authorizers, len(tx.Authorizers))
+		require.Equal(t, tx.GasLimit, exported.GasLimit)
+
 		// unhappy path: endHeight is lower than startHeight
 		_, err = f.GetByHeightRange(5, 4)
 		require.Error(t, err)
@@ -0,0 +1,59 @@
+package slashing_protection
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/persister"
+)
+
+var (
+	flagOutputFile string
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the slashing protection of all chains as JSON",
+	Run:   runExport,
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&flagOutputFile, "output-file", "o", "slashing-protection.json",
+		"file to write the slashing protection to")
+}
+
+func runExport(*cobra.Command, []string) {
+	// the database is opened read-only, so that exporting never modifies it
+	db, err := badger.Open(badger.DefaultOptions(flagDatadir).WithReadOnly(true).WithLogger(nil))
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not open badger database, it must not be in use by a running node")
+	}
+	defer db.Close()
+
+	states, err := persister.ExportSlashingProtection(db)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not export slashing protection")
+	}
+
+	encoded, err := json.MarshalIndent(states, "", "  ")
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not encode slashing protection")
+	}
+	err = os.WriteFile(flagOutputFile, encoded, 0600)
+	if err != nil {
+		log.Fatal().Err(err).Str("file", flagOutputFile).Msg("could not write slashing protection")
+	}
+
+	for _, state := range states {
+		log.Info().
+			Str("chain_id", state.ChainID.String()).
+			Uint64("lower_bound", state.LowerBound).
+			Int("signed_views", len(state.Signed)).
+			Msg("exported slashing protection")
+	}
+	log.Info().Str("file", flagOutputFile).Msgf("exported slashing protection of %d chains", len(states))
+}
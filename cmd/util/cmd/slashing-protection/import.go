@@ -0,0 +1,52 @@
+package slashing_protection
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/common"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/persister"
+)
+
+var (
+	flagInputFile string
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import the slashing protection exported on another machine",
+	Long: `Merges the exported slashing protection into the database. Existing records are kept, so importing
+never allows signing messages refused before. Conflicting records are refused, and nothing is imported.`,
+	Run: runImport,
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&flagInputFile, "input-file", "i", "slashing-protection.json",
+		"file to read the exported slashing protection from")
+}
+
+func runImport(*cobra.Command, []string) {
+	data, err := os.ReadFile(flagInputFile)
+	if err != nil {
+		log.Fatal().Err(err).Str("file", flagInputFile).Msg("could not read slashing protection")
+	}
+	var states []*model.SlashingProtectionState
+	err = json.Unmarshal(data, &states)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not decode slashing protection")
+	}
+
+	db := common.InitStorage(flagDatadir)
+	defer db.Close()
+
+	err = persister.ImportSlashingProtection(db, states)
+	if err != nil {
+		log.Fatal().Err(err).Msg("could not import slashing protection")
+	}
+
+	log.Info().Str("file", flagInputFile).Msgf("imported slashing protection of %d chains", len(states))
+}
@@ -0,0 +1,27 @@
+package slashing_protection
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	flagDatadir string
+)
+
+var Cmd = &cobra.Command{
+	Use:   "slashing-protection",
+	Short: "Export or import the slashing protection of hotstuff signers",
+	Long: `The slashing protection records the blocks a consensus or collection node signed proposals and
+votes for, so that it refuses signing conflicting messages. When migrating a node to another machine,
+export the slashing protection on the old machine, and import it on the new machine before starting
+the node. The node must not be running while exporting or importing.`,
+}
+
+func init() {
+	Cmd.PersistentFlags().StringVarP(&flagDatadir, "datadir", "d", "/var/flow/data/protocol",
+		"directory of the badger database")
+	_ = Cmd.MarkPersistentFlagRequired("datadir")
+
+	Cmd.AddCommand(exportCmd)
+	Cmd.AddCommand(importCmd)
+}
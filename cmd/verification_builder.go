@@ -1,9 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"time"
 
+	"github.com/ipfs/go-cid"
+	badgerds "github.com/ipfs/go-ds-badger2"
+	"github.com/onflow/go-bitswap"
 	"github.com/spf13/pflag"
 
 	flowconsensus "github.com/onflow/flow-go/consensus"
@@ -23,14 +29,22 @@ import (
 	"github.com/onflow/flow-go/fvm"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/blobs"
 	"github.com/onflow/flow-go/module/buffer"
 	"github.com/onflow/flow-go/module/chainsync"
 	"github.com/onflow/flow-go/module/chunks"
 	"github.com/onflow/flow-go/module/compliance"
+	"github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
 	finalizer "github.com/onflow/flow-go/module/finalizer/consensus"
+	"github.com/onflow/flow-go/module/health"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/channels"
+	"github.com/onflow/flow-go/network/p2p/blob"
 	"github.com/onflow/flow-go/state/protocol"
 	badgerState "github.com/onflow/flow-go/state/protocol/badger"
 	"github.com/onflow/flow-go/state/protocol/blocktimer"
@@ -42,6 +56,7 @@ type VerificationConfig struct {
 	chunkLimit uint // size of chunk-related memory pools.
 
 	requestInterval    time.Duration // time interval that requester engine tries requesting chunk data packs.
+	maxDispatches      uint          // maximum number of chunk data pack requests dispatched at each request interval.
 	backoffMinInterval time.Duration // minimum time interval a chunk data pack request waits before dispatching.
 	backoffMaxInterval time.Duration // maximum time interval a chunk data pack request waits before dispatching.
 	backoffMultiplier  float64       // base of exponent in exponential backoff multiplier for backing off requests for chunk data packs.
@@ -51,6 +66,10 @@ type VerificationConfig struct {
 	chunkWorkers uint64 // number of chunks processed in parallel.
 
 	stopAtHeight uint64 // height to stop the node on
+
+	chunkDataPackBlobsEnabled         bool   // whether to retrieve chunk data packs published as content-addressed blobs.
+	chunkDataPackBlobsDir             string // directory to store the retrieved chunk data pack blobs.
+	chunkDataPackBlobsPrunerThreshold uint64 // number of sealed heights after which retrieved chunk data pack blobs are pruned.
 }
 
 type VerificationNodeBuilder struct {
@@ -66,11 +85,13 @@ func NewVerificationNodeBuilder(nodeBuilder *FlowNodeBuilder) *VerificationNodeB
 }
 
 func (v *VerificationNodeBuilder) LoadFlags() {
+	homedir, _ := os.UserHomeDir()
 	v.FlowNodeBuilder.
 		ExtraFlags(func(flags *pflag.FlagSet) {
 			flags.UintVar(&v.verConf.chunkLimit, "chunk-limit", 10000, "maximum number of chunk states in the memory pool")
 			flags.UintVar(&v.verConf.chunkAlpha, "chunk-alpha", flow.DefaultChunkAssignmentAlpha, "number of verifiers should be assigned to each chunk")
 			flags.DurationVar(&v.verConf.requestInterval, "chunk-request-interval", requester.DefaultRequestInterval, "time interval chunk data pack request is processed")
+			flags.UintVar(&v.verConf.maxDispatches, "chunk-request-max-dispatches", requester.DefaultMaxDispatchesPerInterval, "maximum number of chunk data pack requests dispatched at each request interval, closest to their sealing deadline first")
 			flags.DurationVar(&v.verConf.backoffMinInterval, "backoff-min-interval", requester.DefaultBackoffMinInterval, "min time interval a chunk data pack request waits before dispatching")
 			flags.DurationVar(&v.verConf.backoffMaxInterval, "backoff-max-interval", requester.DefaultBackoffMaxInterval, "min time interval a chunk data pack request waits before dispatching")
 			flags.Float64Var(&v.verConf.backoffMultiplier, "backoff-multiplier", requester.DefaultBackoffMultiplier, "base of exponent in exponential backoff requesting mechanism")
@@ -78,6 +99,9 @@ func (v *VerificationNodeBuilder) LoadFlags() {
 			flags.Uint64Var(&v.verConf.blockWorkers, "block-workers", blockconsumer.DefaultBlockWorkers, "maximum number of blocks being processed in parallel")
 			flags.Uint64Var(&v.verConf.chunkWorkers, "chunk-workers", chunkconsumer.DefaultChunkWorkers, "maximum number of execution nodes a chunk data pack request is dispatched to")
 			flags.Uint64Var(&v.verConf.stopAtHeight, "stop-at-height", 0, "height to stop the node at (0 to disable)")
+			flags.BoolVar(&v.verConf.chunkDataPackBlobsEnabled, "chunk-data-pack-blobs-enabled", false, "whether to retrieve chunk data packs published by execution nodes as content-addressed blobs")
+			flags.StringVar(&v.verConf.chunkDataPackBlobsDir, "chunk-data-pack-blobs-dir", filepath.Join(homedir, ".flow", "chunk_data_packs"), "directory to use for storing retrieved chunk data pack blobs")
+			flags.Uint64Var(&v.verConf.chunkDataPackBlobsPrunerThreshold, "chunk-data-pack-blobs-height-range-threshold", chunk_data_pack.DefaultRetentionThreshold, "number of sealed heights after which retrieved chunk data pack blobs are pruned")
 		})
 }
 
@@ -85,11 +109,11 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 	var (
 		followerState protocol.MutableState
 
-		chunkStatuses        *stdmap.ChunkStatuses    // used in fetcher engine
-		chunkRequests        *stdmap.ChunkRequests    // used in requester engine
-		processedChunkIndex  *badger.ConsumerProgress // used in chunk consumer
-		processedBlockHeight *badger.ConsumerProgress // used in block consumer
-		chunkQueue           *badger.ChunksQueue      // used in chunk consumer
+		chunkStatuses        *stdmap.ChunkStatuses      // used in fetcher engine
+		chunkRequests        *stdmap.ChunkRequests      // used in requester engine
+		processedChunkIndex  *badger.ConsumerProgress   // used in chunk consumer
+		processedBlockHeight *badger.ConsumerProgress   // used in block consumer
+		chunkQueue           *chunkconsumer.ChunksQueue // used in chunk consumer
 
 		syncCore                *chainsync.Core       // used in follower engine
 		pendingBlocks           *buffer.PendingBlocks // used in follower engine
@@ -102,6 +126,11 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 		finalizationDistributor *pubsub.FinalizationDistributor
 		finalizedHeader         *commonsync.FinalizedHeaderCache
 
+		chdpDownloader chunk_data_pack.Downloader    // used in requester engine, if chunk data pack blobs are enabled
+		chdpBlobstore  blobs.Blobstore               // used in chunk data pack downloader
+		chdpDatastore  *badgerds.Datastore           // used in chunk data pack blob service
+		chdpDependable *module.ProxiedReadyDoneAware // used in peer manager to wait for chunk data pack blob service
+
 		followerEng *follower.Engine           // the follower engine
 		collector   module.VerificationMetrics // used to collect metrics of all engines
 	)
@@ -138,6 +167,11 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 			if err != nil {
 				return fmt.Errorf("could not register backend metric: %w", err)
 			}
+			// chunk statuses are keyed by chunk index and result ID, so entries are removed by their ID from the backend
+			err = node.MempoolRegistry.Register("chunk-statuses", chunkStatuses, chunkStatuses.Backend.Remove)
+			if err != nil {
+				return fmt.Errorf("could not register mempool: %w", err)
+			}
 			return nil
 		}).
 		Module("chunk requests memory pool", func(node *NodeConfig) error {
@@ -148,10 +182,14 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 			if err != nil {
 				return fmt.Errorf("could not register backend metric: %w", err)
 			}
+			err = node.MempoolRegistry.Register("chunk-requests", chunkRequests, chunkRequests.Remove)
+			if err != nil {
+				return fmt.Errorf("could not register mempool: %w", err)
+			}
 			return nil
 		}).
 		Module("processed chunk index consumer progress", func(node *NodeConfig) error {
-			processedChunkIndex = badger.NewConsumerProgress(node.DB, module.ConsumeProgressVerificationChunkIndex)
+			processedChunkIndex = badger.NewConsumerProgress(node.DB, module.ConsumeProgressVerificationChunkLocatorIndex)
 			return nil
 		}).
 		Module("processed block height consumer progress", func(node *NodeConfig) error {
@@ -159,17 +197,31 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 			return nil
 		}).
 		Module("chunks queue", func(node *NodeConfig) error {
-			chunkQueue = badger.NewChunkQueue(node.DB)
-			ok, err := chunkQueue.Init(chunkconsumer.DefaultJobIndex)
-			if err != nil {
-				return fmt.Errorf("could not initialize default index in chunks queue: %w", err)
+			chunkQueue = chunkconsumer.NewChunksQueue(node.Logger,
+				badger.NewPersistentJobQueue(node.DB, chunkconsumer.JobQueueChunkLocators),
+				badger.NewDeadLetters(node.DB, chunkconsumer.JobQueueChunkLocators))
+			return nil
+		}).
+		Module("chunk data pack datastore", func(node *NodeConfig) error {
+			if !v.verConf.chunkDataPackBlobsEnabled {
+				return nil
 			}
 
-			node.Logger.Info().
-				Str("component", "node-builder").
-				Bool("init_to_default", ok).
-				Msg("chunks queue index has been initialized")
+			datastoreDir := filepath.Join(v.verConf.chunkDataPackBlobsDir, "blobstore")
+			err := os.MkdirAll(datastoreDir, 0700)
+			if err != nil {
+				return err
+			}
+			chdpDatastore, err = badgerds.NewDatastore(datastoreDir, &badgerds.DefaultOptions)
+			if err != nil {
+				return err
+			}
+			v.ShutdownFunc(chdpDatastore.Close)
+			chdpBlobstore = blobs.NewBlobstore(chdpDatastore)
 
+			// configures peer manager to wait for the chunk data pack blob service to be ready before starting
+			chdpDependable = module.NewProxiedReadyDoneAware()
+			node.PeerManagerDependencies.Add(chdpDependable)
 			return nil
 		}).
 		Module("pending block cache", func(node *NodeConfig) error {
@@ -190,7 +242,10 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 			var err error
 
 			syncCore, err = chainsync.New(node.Logger, node.SyncCoreConfig, metrics.NewChainSyncCollector())
-			return err
+			if err != nil {
+				return err
+			}
+			return node.HealthRegistry.AddCheck(health.FinalizedHeightLagCheck, health.NewFinalizedHeightLagCheck(node.State, syncCore.ReportedHeight, node.HealthConfig.MaxFinalizedHeightLag))
 		}).
 		Component("verifier engine", func(node *NodeConfig) (module.ReadyDoneAware, error) {
 			var err error
@@ -214,6 +269,64 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 				approvalStorage)
 			return verifierEng, err
 		}).
+		Component("chunk data pack blob service", func(node *NodeConfig) (module.ReadyDoneAware, error) {
+			if !v.verConf.chunkDataPackBlobsEnabled {
+				return &module.NoopReadyDoneAware{}, nil
+			}
+
+			sealed, err := node.State.Sealed().Head()
+			if err != nil {
+				return nil, fmt.Errorf("cannot get the sealed block: %w", err)
+			}
+
+			chdpTracker, err := tracker.OpenStorage(
+				filepath.Join(v.verConf.chunkDataPackBlobsDir, "tracker"),
+				sealed.Height,
+				node.Logger,
+				tracker.WithPruneCallback(func(c cid.Cid) error {
+					// TODO: use a proper context here
+					return chdpBlobstore.DeleteBlob(context.TODO(), c)
+				}),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			var bs network.BlobService
+			bs, err = node.Network.RegisterBlobService(channels.ChunkDataPackService, chdpDatastore,
+				blob.WithBitswapOptions(
+					// Only allow block requests from staked ENs and VNs
+					bitswap.WithPeerBlockRequestFilter(
+						blob.AuthorizedChunkDataPackRequester(node.IdentityProvider, node.Logger),
+					),
+					bitswap.WithTracer(
+						blob.NewTracer(node.Logger.With().Str("blob_service", channels.ChunkDataPackService.String()).Logger()),
+					),
+				),
+			)
+			if err != nil {
+				return nil, fmt.Errorf("failed to register chunk data pack blob service: %w", err)
+			}
+			chdpDependable.Init(bs)
+
+			chdpDownloader = chunk_data_pack.NewDownloader(bs, execution_data.DefaultSerializer, chunk_data_pack.WithTracker(chdpTracker))
+
+			// pruner metrics are not collected, as chunk data pack blobs are only retained until sealing
+			retention, err := chunk_data_pack.NewRetention(
+				node.Logger,
+				metrics.NewNoopCollector(),
+				node.State,
+				chdpTracker,
+				v.verConf.chunkDataPackBlobsPrunerThreshold,
+				chdpDatastore.CollectGarbage,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("could not create chunk data pack retention: %w", err)
+			}
+			node.ProtocolEvents.AddConsumer(retention)
+
+			return retention, nil
+		}).
 		Component("chunk consumer, requester, and fetcher engines", func(node *NodeConfig) (module.ReadyDoneAware, error) {
 			var err error
 
@@ -235,6 +348,10 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 			if err != nil {
 				return nil, fmt.Errorf("could not create requester engine: %w", err)
 			}
+			requesterEngine.WithMaxDispatchesPerInterval(v.verConf.maxDispatches)
+			if chdpDownloader != nil {
+				requesterEngine.WithChunkDataPackDownloader(chdpDownloader)
+			}
 
 			fetcherEngine = fetcher.New(
 				node.Logger,
@@ -264,6 +381,13 @@ func (v *VerificationNodeBuilder) LoadComponentsAndModules() {
 				return nil, fmt.Errorf("could not register backend metric: %w", err)
 			}
 
+			// chunks which could not be fetched after several attempts are dead-lettered, and can be
+			// inspected and retried through the admin commands
+			err = node.JobQueueRegistry.Register(chunkconsumer.JobQueueChunkLocators, chunkConsumer)
+			if err != nil {
+				return nil, fmt.Errorf("could not register chunk consumer dead letters: %w", err)
+			}
+
 			return chunkConsumer, nil
 		}).
 		Component("assigner engine", func(node *NodeConfig) (module.ReadyDoneAware, error) {
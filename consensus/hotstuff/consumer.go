@@ -153,6 +153,13 @@ type Consumer interface {
 	// Implementation must be concurrency safe; Non-blocking;
 	// and must handle repetition of the same events (with some processing overhead).
 	OnVoteForInvalidBlockDetected(vote *model.Vote, invalidProposal *model.Proposal)
+
+	// OnInvalidBlockDetected notifications are produced by the EventHandler
+	// whenever an invalid proposal was detected. The error describes why the proposal is invalid.
+	// Prerequisites:
+	// Implementation must be concurrency safe; Non-blocking;
+	// and must handle repetition of the same events (with some processing overhead).
+	OnInvalidBlockDetected(proposal *model.Proposal, err error)
 }
 
 // QCCreatedConsumer consumes outbound notifications produced by HotStuff and its components.
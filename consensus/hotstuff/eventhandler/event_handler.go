@@ -120,6 +120,7 @@ func (e *EventHandler) OnReceiveProposal(proposal *model.Proposal) error {
 		// validate the block. exit if the proposal is invalid
 		err := e.validator.ValidateProposal(proposal)
 		if model.IsInvalidBlockError(err) {
+			e.notifier.OnInvalidBlockDetected(proposal, err)
 			perr := e.voteAggregator.InvalidBlock(proposal)
 			if mempool.IsDecreasingPruningHeightError(perr) {
 				log.Warn().Err(err).Msgf("invalid block proposal, but vote aggregator has pruned this height: %v", perr)
@@ -256,6 +257,12 @@ func (e *EventHandler) startNewView() error {
 		}
 
 		proposal, err := e.blockProducer.MakeBlockProposal(qc, curView)
+		if model.IsConflictingSignatureError(err) {
+			// we already signed a proposal or vote for another block of this view, e.g. before a
+			// restart; proposing another block would be an equivocation, so we skip proposing
+			log.Warn().Err(err).Msg("refused to propose a conflicting block")
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf("can not make block proposal for curView %v: %w", curView, err)
 		}
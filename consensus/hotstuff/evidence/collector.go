@@ -0,0 +1,313 @@
+package evidence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/common/fifoqueue"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// defaultCollectorWorkers is the number of workers verifying and persisting evidence.
+const defaultCollectorWorkers = 2
+
+// defaultCollectorQueueCapacity is the maximum number of offenses waiting to be collected.
+// Offenses detected while the queue is full are dropped.
+const defaultCollectorQueueCapacity = 1000
+
+// Collector collects cryptographic evidence of slashable offenses of the replicas of the chains
+// a node participates in, and persists it in the Store. The offenses of a chain are reported by
+// its HotStuff notifications consumer, see Consumer:
+//   - double proposals, i.e. proposals of a leader for two different blocks of a view
+//   - double votes, i.e. votes of a replica for two different blocks of a view
+//   - invalid proposals, if the proposer's signature is valid
+//   - votes for invalid proposals
+//
+// Votes are verified before collecting them as evidence, since double votes and votes for
+// invalid proposals are detected before the votes are verified. The signature of a vote can
+// only be verified if the voted block is known, so double votes for unknown blocks are not
+// collected. Invalid votes are not evidence, since anyone can create them.
+//
+// The evidence is verified and persisted asynchronously by a fixed number of workers, so that
+// the notifications don't block HotStuff.
+type Collector struct {
+	*component.ComponentManager
+	log            zerolog.Logger
+	store          *Store
+	queuedOffenses *fifoqueue.FifoQueue
+	notifier       engine.Notifier
+}
+
+var _ component.Component = (*Collector)(nil)
+
+// NewCollector creates a collector persisting the evidence in the given store.
+func NewCollector(log zerolog.Logger, store *Store) (*Collector, error) {
+	queue, err := fifoqueue.NewFifoQueue(defaultCollectorQueueCapacity)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize offenses queue: %w", err)
+	}
+
+	c := &Collector{
+		log:            log.With().Str("component", "slashing_evidence_collector").Logger(),
+		store:          store,
+		queuedOffenses: queue,
+		notifier:       engine.NewNotifier(),
+	}
+
+	builder := component.NewComponentManagerBuilder()
+	for i := 0; i < defaultCollectorWorkers; i++ {
+		builder.AddWorker(func(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+			ready()
+			c.processingLoop(ctx)
+		})
+	}
+	c.ComponentManager = builder.Build()
+
+	return c, nil
+}
+
+// Consumer returns the HotStuff notifications consumer collecting the evidence of the given chain.
+// The committee and verifier are used to verify the signatures of votes, and the headers to
+// retrieve the signed headers of proposals.
+func (c *Collector) Consumer(
+	chainID flow.ChainID,
+	committee hotstuff.Committee,
+	verifier hotstuff.Verifier,
+	headers storage.Headers,
+) hotstuff.Consumer {
+	return &consumer{
+		collector: c,
+		log:       c.log.With().Str("chain_id", chainID.String()).Logger(),
+		chainID:   chainID,
+		committee: committee,
+		verifier:  verifier,
+		headers:   headers,
+	}
+}
+
+// offense returns the evidence of an offense, once it is processed by a worker.
+type offense struct {
+	log      zerolog.Logger
+	evidence func() (*model.SlashingEvidence, error)
+}
+
+// submit queues the offense to be collected. Offenses are dropped if the queue is full.
+func (c *Collector) submit(o offense) {
+	if !c.queuedOffenses.Push(o) {
+		o.log.Warn().Msg("dropped offense, as the slashing evidence queue is full")
+		return
+	}
+	c.notifier.Notify()
+}
+
+func (c *Collector) processingLoop(ctx irrecoverable.SignalerContext) {
+	notifier := c.notifier.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-notifier:
+			c.processQueuedOffenses(ctx)
+		}
+	}
+}
+
+func (c *Collector) processQueuedOffenses(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		msg, ok := c.queuedOffenses.Pop()
+		if !ok {
+			return
+		}
+		c.collect(msg.(offense))
+	}
+}
+
+// errNotEvidence is returned for offenses which can't be proven, e.g. because a signature is invalid.
+var errNotEvidence = errors.New("no evidence")
+
+// collect persists the evidence of the given offense.
+func (c *Collector) collect(o offense) {
+	e, err := o.evidence()
+	if errors.Is(err, errNotEvidence) {
+		o.log.Info().Err(err).Msg("offense can't be proven")
+		return
+	}
+	if err != nil {
+		o.log.Error().Err(err).Msg("could not collect slashing evidence")
+		return
+	}
+
+	added, err := c.store.Add(e)
+	if err != nil {
+		o.log.Error().Err(err).Msg("could not persist slashing evidence")
+		return
+	}
+	if !added {
+		return
+	}
+	o.log.Warn().
+		Str("kind", string(e.Kind)).
+		Uint64("view", e.View).
+		Hex("offender_id", e.OffenderID[:]).
+		Hex("evidence_id", logging.ID(e.ID())).
+		Bool(logging.KeySuspicious, true).
+		Msg("collected slashing evidence")
+}
+
+// consumer is the HotStuff notifications consumer submitting the offenses of a chain to the Collector.
+type consumer struct {
+	notifications.NoopConsumer
+	collector *Collector
+	log       zerolog.Logger
+	chainID   flow.ChainID
+	committee hotstuff.Committee
+	verifier  hotstuff.Verifier
+	headers   storage.Headers
+}
+
+var _ hotstuff.Consumer = (*consumer)(nil)
+
+func (c *consumer) OnDoubleProposeDetected(block1 *model.Block, block2 *model.Block) {
+	c.submit(func() (*model.SlashingEvidence, error) {
+		return c.doubleProposal(block1, block2)
+	})
+}
+
+func (c *consumer) OnDoubleVotingDetected(vote1 *model.Vote, vote2 *model.Vote) {
+	c.submit(func() (*model.SlashingEvidence, error) {
+		return c.doubleVote(vote1, vote2)
+	})
+}
+
+func (c *consumer) OnInvalidBlockDetected(proposal *model.Proposal, err error) {
+	c.submit(func() (*model.SlashingEvidence, error) {
+		return c.invalidProposal(proposal, err)
+	})
+}
+
+func (c *consumer) OnVoteForInvalidBlockDetected(vote *model.Vote, invalidProposal *model.Proposal) {
+	c.submit(func() (*model.SlashingEvidence, error) {
+		return c.voteForInvalidProposal(vote, invalidProposal)
+	})
+}
+
+func (c *consumer) submit(evidence func() (*model.SlashingEvidence, error)) {
+	c.collector.submit(offense{log: c.log, evidence: evidence})
+}
+
+func (c *consumer) doubleProposal(block1 *model.Block, block2 *model.Block) (*model.SlashingEvidence, error) {
+	// both blocks were validated by Forks, so their proposer signatures are valid
+	header1, err := c.headers.ByBlockID(block1.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve proposal %v: %w", block1.BlockID, err)
+	}
+	header2, err := c.headers.ByBlockID(block2.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve proposal %v: %w", block2.BlockID, err)
+	}
+
+	return model.NewDoubleSigningEvidence(model.DoubleProposal, c.chainID, block1.ProposerID,
+		model.SignedMessage{View: block1.View, BlockID: block1.BlockID, SigData: header1.ProposerSigData},
+		model.SignedMessage{View: block2.View, BlockID: block2.BlockID, SigData: header2.ProposerSigData},
+	), nil
+}
+
+func (c *consumer) doubleVote(vote1 *model.Vote, vote2 *model.Vote) (*model.SlashingEvidence, error) {
+	for _, vote := range []*model.Vote{vote1, vote2} {
+		err := c.verifyVote(vote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return model.NewDoubleSigningEvidence(model.DoubleVote, c.chainID, vote1.SignerID,
+		model.SignedMessage{View: vote1.View, BlockID: vote1.BlockID, SigData: vote1.SigData},
+		model.SignedMessage{View: vote2.View, BlockID: vote2.BlockID, SigData: vote2.SigData},
+	), nil
+}
+
+func (c *consumer) invalidProposal(proposal *model.Proposal, reason error) (*model.SlashingEvidence, error) {
+	// a proposal with an invalid proposer signature might have been created by anyone
+	if model.IsInvalidVoteError(reason) {
+		return nil, fmt.Errorf("invalid proposer signature of proposal %v: %w", proposal.Block.BlockID, errNotEvidence)
+	}
+
+	block := proposal.Block
+	return &model.SlashingEvidence{
+		Kind:       model.InvalidProposal,
+		ChainID:    c.chainID,
+		View:       block.View,
+		OffenderID: block.ProposerID,
+		Messages:   []model.SignedMessage{{View: block.View, BlockID: block.BlockID, SigData: proposal.SigData}},
+		Proposal:   c.header(block.BlockID),
+		Reason:     reason.Error(),
+		DetectedAt: time.Now().UTC(),
+	}, nil
+}
+
+func (c *consumer) voteForInvalidProposal(vote *model.Vote, proposal *model.Proposal) (*model.SlashingEvidence, error) {
+	err := c.verifyVote(vote)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.SlashingEvidence{
+		Kind:       model.VoteForInvalidProposal,
+		ChainID:    c.chainID,
+		View:       vote.View,
+		OffenderID: vote.SignerID,
+		Messages:   []model.SignedMessage{{View: vote.View, BlockID: vote.BlockID, SigData: vote.SigData}},
+		Proposal:   c.header(proposal.Block.BlockID),
+		DetectedAt: time.Now().UTC(),
+	}, nil
+}
+
+// verifyVote verifies the signature of the vote. Returns an errNotEvidence error if the vote
+// is invalid or can't be verified.
+func (c *consumer) verifyVote(vote *model.Vote) error {
+	voter, err := c.committee.Identity(vote.BlockID, vote.SignerID)
+	if model.IsInvalidSignerError(err) {
+		return fmt.Errorf("vote of invalid signer %v: %w", vote.SignerID, errNotEvidence)
+	}
+	if err != nil {
+		// the voted block is unknown, so the signature can't be verified
+		return fmt.Errorf("could not retrieve voter of vote for block %v: %v: %w", vote.BlockID, err, errNotEvidence)
+	}
+
+	err = c.verifier.VerifyVote(voter, vote.SigData, &model.Block{View: vote.View, BlockID: vote.BlockID})
+	if model.IsInvalidFormatError(err) || errors.Is(err, model.ErrInvalidSignature) {
+		return fmt.Errorf("invalid vote of %v for block %v: %v: %w", vote.SignerID, vote.BlockID, err, errNotEvidence)
+	}
+	if err != nil {
+		return fmt.Errorf("could not verify vote of %v for block %v: %w", vote.SignerID, vote.BlockID, err)
+	}
+	return nil
+}
+
+// header returns the header of the given block, or nil if it is unknown.
+func (c *consumer) header(blockID flow.Identifier) *flow.Header {
+	header, err := c.headers.ByBlockID(blockID)
+	if err != nil {
+		c.log.Warn().Err(err).Hex("block_id", blockID[:]).Msg("could not retrieve header of invalid proposal")
+		return nil
+	}
+	return header
+}
@@ -0,0 +1,223 @@
+package evidence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// collectorTest wires the consumer of a running Collector with mocked dependencies to a store in
+// a badger database.
+type collectorTest struct {
+	collector hotstuff.Consumer
+	store     *Store
+	committee *mocks.Committee
+	verifier  *mocks.Verifier
+	headers   *storagemock.Headers
+}
+
+func runCollectorTest(t *testing.T, f func(*collectorTest)) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		ct := &collectorTest{
+			store:     NewStore(db),
+			committee: &mocks.Committee{},
+			verifier:  &mocks.Verifier{},
+			headers:   &storagemock.Headers{},
+		}
+		collector, err := NewCollector(zerolog.Nop(), ct.store)
+		require.NoError(t, err)
+		ct.collector = collector.Consumer(flow.Emulator, ct.committee, ct.verifier, ct.headers)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		collector.Start(irrecoverable.NewMockSignalerContext(t, ctx))
+		unittest.RequireComponentsReadyBefore(t, time.Second, collector)
+		defer func() {
+			cancel()
+			unittest.RequireComponentsDoneBefore(t, time.Second, collector)
+		}()
+
+		f(ct)
+	})
+}
+
+// requireEvidence waits until the given number of evidence was collected.
+func (ct *collectorTest) requireEvidence(t *testing.T, count int) []Report {
+	var reports []Report
+	require.Eventually(t, func() bool {
+		var err error
+		reports, err = ct.store.List(DefaultListLimit)
+		require.NoError(t, err)
+		return len(reports) >= count
+	}, time.Second, 10*time.Millisecond)
+	require.Len(t, reports, count)
+	return reports
+}
+
+// requireNoEvidence verifies that no evidence is collected.
+func (ct *collectorTest) requireNoEvidence(t *testing.T) {
+	require.Never(t, func() bool {
+		reports, err := ct.store.List(DefaultListLimit)
+		require.NoError(t, err)
+		return len(reports) > 0
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestCollector_DoubleProposal(t *testing.T) {
+	runCollectorTest(t, func(ct *collectorTest) {
+		header1 := unittest.BlockHeaderFixture()
+		header2 := unittest.BlockHeaderFixture(func(header *flow.Header) {
+			header.View = header1.View
+			header.ProposerID = header1.ProposerID
+		})
+		ct.headers.On("ByBlockID", header1.ID()).Return(header1, nil)
+		ct.headers.On("ByBlockID", header2.ID()).Return(header2, nil)
+
+		block1 := model.BlockFromFlow(header1, header1.View-1)
+		block2 := model.BlockFromFlow(header2, header2.View-1)
+		ct.collector.OnDoubleProposeDetected(block1, block2)
+		// repeated notifications don't duplicate the evidence
+		ct.collector.OnDoubleProposeDetected(block2, block1)
+
+		reports := ct.requireEvidence(t, 1)
+		evidence := reports[0].Evidence
+		assert.Equal(t, model.DoubleProposal, evidence.Kind)
+		assert.Equal(t, header1.View, evidence.View)
+		assert.Equal(t, header1.ProposerID, evidence.OffenderID)
+		require.Len(t, evidence.Messages, 2)
+		sigs := [][]byte{evidence.Messages[0].SigData, evidence.Messages[1].SigData}
+		assert.ElementsMatch(t, [][]byte{header1.ProposerSigData, header2.ProposerSigData}, sigs)
+	})
+}
+
+func TestCollector_DoubleVote(t *testing.T) {
+	signerID := unittest.IdentifierFixture()
+	voter := &flow.Identity{NodeID: signerID}
+	vote1 := unittest.VoteFixture(unittest.WithVoteSignerID(signerID), unittest.WithVoteView(10))
+	vote2 := unittest.VoteFixture(unittest.WithVoteSignerID(signerID), unittest.WithVoteView(10))
+
+	t.Run("valid votes", func(t *testing.T) {
+		runCollectorTest(t, func(ct *collectorTest) {
+			ct.committee.On("Identity", mock.Anything, signerID).Return(voter, nil)
+			ct.verifier.On("VerifyVote", voter, mock.Anything, mock.Anything).Return(nil)
+
+			ct.collector.OnDoubleVotingDetected(vote1, vote2)
+
+			reports := ct.requireEvidence(t, 1)
+			assert.Equal(t, model.DoubleVote, reports[0].Evidence.Kind)
+			assert.Equal(t, signerID, reports[0].Evidence.OffenderID)
+			assert.Equal(t, reports[0].Evidence.ID(), reports[0].ID)
+		})
+	})
+
+	t.Run("invalid vote", func(t *testing.T) {
+		runCollectorTest(t, func(ct *collectorTest) {
+			ct.committee.On("Identity", mock.Anything, signerID).Return(voter, nil)
+			ct.verifier.On("VerifyVote", voter, vote1.SigData, mock.Anything).Return(nil)
+			ct.verifier.On("VerifyVote", voter, vote2.SigData, mock.Anything).Return(model.ErrInvalidSignature)
+
+			ct.collector.OnDoubleVotingDetected(vote1, vote2)
+			ct.requireNoEvidence(t)
+		})
+	})
+
+	t.Run("unknown block", func(t *testing.T) {
+		runCollectorTest(t, func(ct *collectorTest) {
+			ct.committee.On("Identity", mock.Anything, signerID).Return(nil, errors.New("unknown block"))
+
+			ct.collector.OnDoubleVotingDetected(vote1, vote2)
+			ct.requireNoEvidence(t)
+		})
+	})
+}
+
+func TestCollector_InvalidProposal(t *testing.T) {
+	header := unittest.BlockHeaderFixture()
+	proposal := model.ProposalFromFlow(header, header.View-1)
+
+	t.Run("valid proposer signature", func(t *testing.T) {
+		runCollectorTest(t, func(ct *collectorTest) {
+			ct.headers.On("ByBlockID", header.ID()).Return(header, nil)
+
+			ct.collector.OnInvalidBlockDetected(proposal, model.InvalidBlockError{
+				BlockID: header.ID(),
+				View:    header.View,
+				Err:     errors.New("invalid qc"),
+			})
+
+			reports := ct.requireEvidence(t, 1)
+			evidence := reports[0].Evidence
+			assert.Equal(t, model.InvalidProposal, evidence.Kind)
+			assert.Equal(t, header.ProposerID, evidence.OffenderID)
+			assert.Equal(t, header.ID(), evidence.Proposal.ID())
+
+			// the compact format contains the proposal body, whose hash is the block ID
+			compact, err := model.DecodeCompactSlashingEvidence(evidence.Compact())
+			require.NoError(t, err)
+			assert.Equal(t, header.ID(), flow.MakeIDFromFingerPrint(compact.ProposalBody))
+			require.Len(t, compact.Messages, 1)
+			assert.Equal(t, header.ProposerSigData, compact.Messages[0].SigData)
+		})
+	})
+
+	t.Run("invalid proposer signature", func(t *testing.T) {
+		runCollectorTest(t, func(ct *collectorTest) {
+			ct.collector.OnInvalidBlockDetected(proposal, model.InvalidBlockError{
+				BlockID: header.ID(),
+				View:    header.View,
+				Err:     model.InvalidVoteError{Err: model.ErrInvalidSignature},
+			})
+			ct.requireNoEvidence(t)
+		})
+	})
+}
+
+func TestCollector_VoteForInvalidProposal(t *testing.T) {
+	runCollectorTest(t, func(ct *collectorTest) {
+		header := unittest.BlockHeaderFixture()
+		proposal := model.ProposalFromFlow(header, header.View-1)
+		vote := unittest.VoteFixture(unittest.WithVoteView(header.View), func(vote *model.Vote) {
+			vote.BlockID = header.ID()
+		})
+		voter := &flow.Identity{NodeID: vote.SignerID}
+		ct.committee.On("Identity", header.ID(), vote.SignerID).Return(voter, nil)
+		ct.verifier.On("VerifyVote", voter, vote.SigData, mock.Anything).Return(nil)
+		ct.headers.On("ByBlockID", header.ID()).Return(header, nil)
+
+		ct.collector.OnVoteForInvalidBlockDetected(vote, proposal)
+
+		reports := ct.requireEvidence(t, 1)
+		assert.Equal(t, model.VoteForInvalidProposal, reports[0].Evidence.Kind)
+		assert.Equal(t, vote.SignerID, reports[0].Evidence.OffenderID)
+	})
+}
+
+// TestCollector_BoundedQueue tests that offenses are dropped once the queue is full, so that
+// pending offenses can't grow without bound while the workers are busy.
+func TestCollector_BoundedQueue(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		collector, err := NewCollector(zerolog.Nop(), NewStore(db))
+		require.NoError(t, err)
+		consumer := collector.Consumer(flow.Emulator, &mocks.Committee{}, &mocks.Verifier{}, &storagemock.Headers{})
+
+		// the collector is not started, so the offenses stay queued
+		for i := 0; i < defaultCollectorQueueCapacity+10; i++ {
+			consumer.OnDoubleVotingDetected(unittest.VoteFixture(), unittest.VoteFixture())
+		}
+		assert.Equal(t, defaultCollectorQueueCapacity, collector.queuedOffenses.Len())
+	})
+}
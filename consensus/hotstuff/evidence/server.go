@@ -0,0 +1,67 @@
+package evidence
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/module/httpserver"
+)
+
+// Endpoint serves the reports of the collected slashing evidence, most recently detected first.
+// The optional `limit` query parameter sets the maximum number of reports, it defaults to
+// DefaultListLimit.
+const Endpoint = "/v1/slashing_evidence"
+
+// Server is the http server serving the slashing evidence collected by a node.
+type Server struct {
+	*httpserver.Server
+}
+
+// NewServer creates a new server that will listen on the specified address and serve the
+// evidence persisted in the given store.
+func NewServer(log zerolog.Logger, store *Store, addr string) *Server {
+	log = log.With().Str("component", "slashing_evidence_server").Logger()
+	return &Server{
+		Server: httpserver.NewServer(log, "slashing evidence", addr, newHandler(log, store)),
+	}
+}
+
+// newHandler returns the handler serving the slashing evidence endpoint.
+func newHandler(log zerolog.Logger, store *Store) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(Endpoint, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		limit := uint64(DefaultListLimit)
+		if value := r.URL.Query().Get("limit"); value != "" {
+			var err error
+			limit, err = strconv.ParseUint(value, 10, 32)
+			if err != nil || limit == 0 {
+				http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+
+		reports, err := store.List(uint(limit))
+		if err != nil {
+			log.Error().Err(err).Msg("could not list slashing evidence")
+			http.Error(w, "could not list slashing evidence", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(reports)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not write slashing evidence")
+		}
+	})
+
+	return mux
+}
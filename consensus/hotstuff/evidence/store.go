@@ -0,0 +1,78 @@
+package evidence
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// DefaultListLimit is the default maximum number of evidence reported by the admin command and
+// the evidence server.
+const DefaultListLimit = 100
+
+// Store persists the slashing evidence collected by the Collectors of a node, for all chains.
+type Store struct {
+	db *badger.DB
+}
+
+// NewStore creates a store persisting the evidence in the given database.
+func NewStore(db *badger.DB) *Store {
+	return &Store{
+		db: db,
+	}
+}
+
+// Add persists the evidence. Returns false if the evidence was persisted before.
+// No errors are expected during normal operations.
+func (s *Store) Add(evidence *model.SlashingEvidence) (bool, error) {
+	err := operation.RetryOnConflict(s.db.Update, operation.InsertSlashingEvidence(evidence.ID(), evidence))
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not insert evidence: %w", err)
+	}
+	return true, nil
+}
+
+// Report is the evidence as reported by the admin command and the evidence server.
+type Report struct {
+	ID       flow.Identifier
+	Evidence *model.SlashingEvidence
+	// Compact is the hex encoded evidence in the compact format, see model.CompactSlashingEvidence.
+	Compact string
+}
+
+// List returns the reports of the most recently detected evidence, at most limit.
+// No errors are expected during normal operations.
+func (s *Store) List(limit uint) ([]Report, error) {
+	var reports []Report
+	err := s.db.View(operation.TraverseSlashingEvidence(func(evidence *model.SlashingEvidence) error {
+		listed := *evidence
+		reports = append(reports, Report{
+			ID:       listed.ID(),
+			Evidence: &listed,
+			Compact:  hex.EncodeToString(listed.Compact()),
+		})
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("could not read evidence: %w", err)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Evidence.DetectedAt.After(reports[j].Evidence.DetectedAt)
+	})
+	if uint(len(reports)) > limit {
+		reports = reports[:limit]
+	}
+	return reports, nil
+}
@@ -50,6 +50,11 @@ func (_m *Consumer) OnForkChoiceGenerated(_a0 uint64, _a1 *flow.QuorumCertificat
 	_m.Called(_a0, _a1)
 }
 
+// OnInvalidBlockDetected provides a mock function with given fields: proposal, err
+func (_m *Consumer) OnInvalidBlockDetected(proposal *model.Proposal, err error) {
+	_m.Called(proposal, err)
+}
+
 // OnInvalidVoteDetected provides a mock function with given fields: _a0
 func (_m *Consumer) OnInvalidVoteDetected(_a0 *model.Vote) {
 	_m.Called(_a0)
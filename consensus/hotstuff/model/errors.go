@@ -276,3 +276,30 @@ func IsInvalidSignerError(err error) bool {
 	var e InvalidSignerError
 	return errors.As(err, &e)
 }
+
+// ConflictingSignatureError indicates that the replica was requested to sign a proposal or vote
+// for a block, while it already signed a proposal or vote for a different block of the same view,
+// or that the view was already pruned from the slashing protection.
+// Signing the message would make the replica equivocate, so it must be refused.
+type ConflictingSignatureError struct {
+	View    uint64
+	BlockID flow.Identifier
+	err     error
+}
+
+func (e ConflictingSignatureError) Error() string { return e.err.Error() }
+func (e ConflictingSignatureError) Unwrap() error { return e.err }
+
+// IsConflictingSignatureError returns whether err is a ConflictingSignatureError
+func IsConflictingSignatureError(err error) bool {
+	var e ConflictingSignatureError
+	return errors.As(err, &e)
+}
+
+func NewConflictingSignatureErrorf(view uint64, blockID flow.Identifier, msg string, args ...interface{}) error {
+	return ConflictingSignatureError{
+		View:    view,
+		BlockID: blockID,
+		err:     fmt.Errorf(msg, args...),
+	}
+}
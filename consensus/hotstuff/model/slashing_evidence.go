@@ -0,0 +1,136 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/onflow/flow-go/model/encoding/rlp"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// EvidenceKind is the kind of slashable offense proven by SlashingEvidence.
+type EvidenceKind string
+
+const (
+	// DoubleProposal proves that a leader signed proposals for two different blocks of a view.
+	DoubleProposal EvidenceKind = "double_proposal"
+	// DoubleVote proves that a replica signed votes for two different blocks of a view.
+	DoubleVote EvidenceKind = "double_vote"
+	// InvalidProposal proves that a leader signed a proposal for an invalid block.
+	InvalidProposal EvidenceKind = "invalid_proposal"
+	// VoteForInvalidProposal proves that a replica signed a vote for an invalid block.
+	VoteForInvalidProposal EvidenceKind = "vote_for_invalid_proposal"
+)
+
+// evidenceKindCodes are the codes of the evidence kinds in the compact format. They must not
+// be changed, since they are part of the format.
+var evidenceKindCodes = map[EvidenceKind]uint8{
+	DoubleProposal:         1,
+	DoubleVote:             2,
+	InvalidProposal:        3,
+	VoteForInvalidProposal: 4,
+}
+
+// SignedMessage is a message signed by the offender, i.e. a vote or the proposer's vote of a
+// proposal. The signature is over MakeVoteMessage(View, BlockID).
+type SignedMessage struct {
+	View    uint64
+	BlockID flow.Identifier
+	SigData []byte
+}
+
+// SlashingEvidence is cryptographic evidence of a slashable offense of a consensus replica.
+// It contains the signed messages of the offender, which can be verified with its staking
+// key (and random beacon key for votes with a threshold signature) of the epoch of the view.
+type SlashingEvidence struct {
+	Kind       EvidenceKind
+	ChainID    flow.ChainID
+	View       uint64
+	OffenderID flow.Identifier
+	// Messages are the signed messages of the offender: both conflicting messages for double
+	// proposals and double votes, ordered by block ID, or the single proposal or vote otherwise.
+	Messages []SignedMessage
+	// Proposal is the header of the invalid proposal, for InvalidProposal and
+	// VoteForInvalidProposal evidence. It is nil, if the header wasn't available.
+	Proposal *flow.Header
+	// Reason describes why the proposal is invalid.
+	Reason string
+	// DetectedAt is the time the offense was detected by the node. It is not part of the
+	// compact format.
+	DetectedAt time.Time
+}
+
+// NewDoubleSigningEvidence returns the evidence of a DoubleProposal or DoubleVote of the
+// offender. The messages are ordered by block ID, so that the evidence doesn't depend on the
+// order in which the messages were received.
+func NewDoubleSigningEvidence(kind EvidenceKind, chainID flow.ChainID, offenderID flow.Identifier, first, second SignedMessage) *SlashingEvidence {
+	messages := []SignedMessage{first, second}
+	sort.Slice(messages, func(i, j int) bool {
+		return bytes.Compare(messages[i].BlockID[:], messages[j].BlockID[:]) < 0
+	})
+	return &SlashingEvidence{
+		Kind:       kind,
+		ChainID:    chainID,
+		View:       first.View,
+		OffenderID: offenderID,
+		Messages:   messages,
+		DetectedAt: time.Now().UTC(),
+	}
+}
+
+// ID returns the identifier of the evidence, i.e. the hash of its compact encoding.
+func (e *SlashingEvidence) ID() flow.Identifier {
+	return flow.MakeIDFromFingerPrint(e.Compact())
+}
+
+// CompactSlashingEvidence is the compact, RLP encoded format of SlashingEvidence, which can be
+// submitted to the staking contract. Header fields are replaced by the encoded body of the
+// proposal, whose hash is the block ID signed by the proposer.
+type CompactSlashingEvidence struct {
+	Kind         uint8
+	ChainID      string
+	View         uint64
+	OffenderID   []byte
+	Messages     []CompactSignedMessage
+	ProposalBody []byte // RLP encoded body of the invalid proposal, empty for other kinds
+}
+
+// CompactSignedMessage is a SignedMessage in the compact format. The view is omitted, since
+// all messages are for the view of the evidence.
+type CompactSignedMessage struct {
+	BlockID []byte
+	SigData []byte
+}
+
+// Compact encodes the evidence in the compact format.
+func (e *SlashingEvidence) Compact() []byte {
+	compact := CompactSlashingEvidence{
+		Kind:       evidenceKindCodes[e.Kind],
+		ChainID:    e.ChainID.String(),
+		View:       e.View,
+		OffenderID: e.OffenderID[:],
+		Messages:   make([]CompactSignedMessage, 0, len(e.Messages)),
+	}
+	for _, message := range e.Messages {
+		compact.Messages = append(compact.Messages, CompactSignedMessage{
+			BlockID: message.BlockID[:],
+			SigData: message.SigData,
+		})
+	}
+	if e.Proposal != nil {
+		compact.ProposalBody = e.Proposal.Fingerprint()
+	}
+	return rlp.NewMarshaler().MustMarshal(compact)
+}
+
+// DecodeCompactSlashingEvidence decodes evidence in the compact format.
+func DecodeCompactSlashingEvidence(encoded []byte) (*CompactSlashingEvidence, error) {
+	var compact CompactSlashingEvidence
+	err := rlp.NewMarshaler().Unmarshal(encoded, &compact)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode compact slashing evidence: %w", err)
+	}
+	return &compact, nil
+}
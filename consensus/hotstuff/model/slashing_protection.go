@@ -0,0 +1,119 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SignedView records the block for which a replica signed a proposal and/or a vote at a view.
+type SignedView struct {
+	View     uint64
+	BlockID  flow.Identifier
+	Proposal bool // whether a proposal for the block was signed
+	Vote     bool // whether a vote for the block was signed
+}
+
+// SlashingProtectionState is the slashing protection state of a replica for one chain, i.e. the
+// views the replica signed messages for, and the block it signed at each view.
+type SlashingProtectionState struct {
+	ChainID flow.ChainID
+	// LowerBound is the lowest view the replica may sign messages for. Records of lower views
+	// were pruned, so signing messages for them is refused.
+	LowerBound uint64
+	// Signed contains the signed views, ordered by view.
+	Signed []SignedView
+}
+
+// NewSlashingProtectionState returns an empty slashing protection state for the given chain.
+func NewSlashingProtectionState(chainID flow.ChainID) *SlashingProtectionState {
+	return &SlashingProtectionState{
+		ChainID: chainID,
+		Signed:  []SignedView{},
+	}
+}
+
+// Check returns a ConflictingSignatureError if signing a message for the given view and block
+// would conflict with a previously signed message, i.e. if a message for another block of the
+// same view was signed, or if the view is below the lower bound.
+func (s *SlashingProtectionState) Check(view uint64, blockID flow.Identifier) error {
+	if view < s.LowerBound {
+		return NewConflictingSignatureErrorf(view, blockID, "view %d is below the lowest view %d allowed to be signed", view, s.LowerBound)
+	}
+	signed, ok := s.find(view)
+	if ok && signed.BlockID != blockID {
+		return NewConflictingSignatureErrorf(view, blockID, "block %v at view %d conflicts with signed block %v", blockID, view, signed.BlockID)
+	}
+	return nil
+}
+
+// RecordProposal records that a proposal for the given block was signed. Returns a
+// ConflictingSignatureError if it conflicts with a previously signed message.
+func (s *SlashingProtectionState) RecordProposal(view uint64, blockID flow.Identifier) error {
+	return s.record(SignedView{View: view, BlockID: blockID, Proposal: true})
+}
+
+// RecordVote records that a vote for the given block was signed. Returns a
+// ConflictingSignatureError if it conflicts with a previously signed message.
+func (s *SlashingProtectionState) RecordVote(view uint64, blockID flow.Identifier) error {
+	return s.record(SignedView{View: view, BlockID: blockID, Vote: true})
+}
+
+// Prune removes the records below the given view, and refuses signing messages for these
+// views from now on. The lower bound never decreases.
+func (s *SlashingProtectionState) Prune(view uint64) {
+	if view <= s.LowerBound {
+		return
+	}
+	s.LowerBound = view
+	i := sort.Search(len(s.Signed), func(i int) bool { return s.Signed[i].View >= view })
+	s.Signed = append([]SignedView{}, s.Signed[i:]...)
+}
+
+// Merge merges the records of other into s, e.g. when importing the state of another machine.
+// The lower bound of the result is the higher of both lower bounds. Returns a
+// ConflictingSignatureError if both states contain records of different blocks for a view, in
+// which case s may be partially merged and must be discarded.
+func (s *SlashingProtectionState) Merge(other *SlashingProtectionState) error {
+	if other.ChainID != s.ChainID {
+		return fmt.Errorf("cannot merge slashing protection of chain %s into chain %s", other.ChainID, s.ChainID)
+	}
+	s.Prune(other.LowerBound)
+	for _, signed := range other.Signed {
+		if signed.View < s.LowerBound {
+			// signing messages for the view is refused anyway
+			continue
+		}
+		err := s.record(signed)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SlashingProtectionState) record(signed SignedView) error {
+	err := s.Check(signed.View, signed.BlockID)
+	if err != nil {
+		return err
+	}
+	i := sort.Search(len(s.Signed), func(i int) bool { return s.Signed[i].View >= signed.View })
+	if i < len(s.Signed) && s.Signed[i].View == signed.View {
+		s.Signed[i].Proposal = s.Signed[i].Proposal || signed.Proposal
+		s.Signed[i].Vote = s.Signed[i].Vote || signed.Vote
+		return nil
+	}
+	s.Signed = append(s.Signed, SignedView{})
+	copy(s.Signed[i+1:], s.Signed[i:])
+	s.Signed[i] = signed
+	return nil
+}
+
+func (s *SlashingProtectionState) find(view uint64) (SignedView, bool) {
+	i := sort.Search(len(s.Signed), func(i int) bool { return s.Signed[i].View >= view })
+	if i < len(s.Signed) && s.Signed[i].View == view {
+		return s.Signed[i], true
+	}
+	return SignedView{}, false
+}
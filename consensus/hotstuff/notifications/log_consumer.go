@@ -154,6 +154,12 @@ func (lc *LogConsumer) OnVoteForInvalidBlockDetected(vote *model.Vote, proposal
 		Msg("vote for invalid proposal detected")
 }
 
+func (lc *LogConsumer) OnInvalidBlockDetected(proposal *model.Proposal, err error) {
+	lc.logBasicBlockData(lc.log.Warn(), proposal.Block).
+		Err(err).
+		Msg("invalid proposal detected")
+}
+
 func (lc *LogConsumer) logBasicBlockData(loggerEvent *zerolog.Event, block *model.Block) *zerolog.Event {
 	loggerEvent.
 		Uint64("block_view", block.View).
@@ -52,3 +52,5 @@ func (*NoopConsumer) OnDoubleVotingDetected(*model.Vote, *model.Vote) {}
 func (*NoopConsumer) OnInvalidVoteDetected(*model.Vote) {}
 
 func (*NoopConsumer) OnVoteForInvalidBlockDetected(*model.Vote, *model.Proposal) {}
+
+func (*NoopConsumer) OnInvalidBlockDetected(*model.Proposal, error) {}
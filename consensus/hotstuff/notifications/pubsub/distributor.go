@@ -172,3 +172,11 @@ func (p *Distributor) OnVoteForInvalidBlockDetected(vote *model.Vote, invalidPro
 		subscriber.OnVoteForInvalidBlockDetected(vote, invalidProposal)
 	}
 }
+
+func (p *Distributor) OnInvalidBlockDetected(proposal *model.Proposal, err error) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	for _, subscriber := range p.subscribers {
+		subscriber.OnInvalidBlockDetected(proposal, err)
+	}
+}
@@ -107,3 +107,5 @@ func (p *FinalizationDistributor) OnDoubleVotingDetected(*model.Vote, *model.Vot
 func (p *FinalizationDistributor) OnInvalidVoteDetected(*model.Vote) {}
 
 func (p *FinalizationDistributor) OnVoteForInvalidBlockDetected(*model.Vote, *model.Proposal) {}
+
+func (p *FinalizationDistributor) OnInvalidBlockDetected(*model.Proposal, error) {}
@@ -49,6 +49,16 @@ func (c *SlashingViolationsConsumer) OnVoteForInvalidBlockDetected(vote *model.V
 		Msg("OnVoteForInvalidBlockDetected")
 }
 
+func (c *SlashingViolationsConsumer) OnInvalidBlockDetected(proposal *model.Proposal, err error) {
+	c.log.Warn().
+		Uint64("block_view", proposal.Block.View).
+		Hex("block_id", proposal.Block.BlockID[:]).
+		Hex("proposer_id", proposal.Block.ProposerID[:]).
+		Err(err).
+		Bool(logging.KeySuspicious, true).
+		Msg("OnInvalidBlockDetected")
+}
+
 func (c *SlashingViolationsConsumer) OnDoubleProposeDetected(block1 *model.Block, block2 *model.Block) {
 	c.log.Warn().
 		Hex("proposer_id", block1.ProposerID[:]).
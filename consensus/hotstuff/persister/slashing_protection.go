@@ -0,0 +1,129 @@
+package persister
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// SlashingProtection is a hotstuff.SlashingProtection persisting the signed views of a chain
+// in the badger database, next to the views persisted by the Persister.
+type SlashingProtection struct {
+	db      *badger.DB
+	chainID flow.ChainID
+}
+
+var _ hotstuff.SlashingProtection = (*SlashingProtection)(nil)
+
+// NewSlashingProtection creates a slashing protection for the given chain.
+func NewSlashingProtection(db *badger.DB, chainID flow.ChainID) *SlashingProtection {
+	return &SlashingProtection{
+		db:      db,
+		chainID: chainID,
+	}
+}
+
+// ProtectProposal records that a proposal for the given block is signed.
+// Expected error returns during normal operations:
+//   - model.ConflictingSignatureError if a message for another block of the same view was
+//     signed before, or the view was pruned
+func (p *SlashingProtection) ProtectProposal(view uint64, blockID flow.Identifier) error {
+	return p.update(func(state *model.SlashingProtectionState) error {
+		return state.RecordProposal(view, blockID)
+	})
+}
+
+// ProtectVote records that a vote for the given block is signed.
+// Expected error returns during normal operations:
+//   - model.ConflictingSignatureError if a message for another block of the same view was
+//     signed before, or the view was pruned
+func (p *SlashingProtection) ProtectVote(view uint64, blockID flow.Identifier) error {
+	return p.update(func(state *model.SlashingProtectionState) error {
+		return state.RecordVote(view, blockID)
+	})
+}
+
+// Prune removes the records below the given view.
+func (p *SlashingProtection) Prune(view uint64) error {
+	return p.update(func(state *model.SlashingProtectionState) error {
+		state.Prune(view)
+		return nil
+	})
+}
+
+// update applies the given function to the state of the chain within a single transaction.
+func (p *SlashingProtection) update(apply func(state *model.SlashingProtectionState) error) error {
+	return operation.RetryOnConflict(p.db.Update, func(tx *badger.Txn) error {
+		state, err := retrieveSlashingProtection(p.chainID)(tx)
+		if err != nil {
+			return err
+		}
+		err = apply(state)
+		if err != nil {
+			return err
+		}
+		return operation.UpsertSlashingProtection(p.chainID, state)(tx)
+	})
+}
+
+// ExportSlashingProtection returns the slashing protection states of all chains in the database.
+func ExportSlashingProtection(db *badger.DB) ([]*model.SlashingProtectionState, error) {
+	var states []*model.SlashingProtectionState
+	err := db.View(operation.TraverseSlashingProtection(func(state *model.SlashingProtectionState) error {
+		exported := *state
+		states = append(states, &exported)
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("could not read slashing protection: %w", err)
+	}
+	return states, nil
+}
+
+// ImportSlashingProtection merges the given slashing protection states into the database, e.g.
+// when migrating a node to another machine. Existing records are kept, so importing never
+// allows signing messages which were refused before.
+// Returns a model.ConflictingSignatureError if the imported records conflict with the records
+// in the database, in which case nothing is imported.
+func ImportSlashingProtection(db *badger.DB, states []*model.SlashingProtectionState) error {
+	return operation.RetryOnConflict(db.Update, func(tx *badger.Txn) error {
+		for _, imported := range states {
+			state, err := retrieveSlashingProtection(imported.ChainID)(tx)
+			if err != nil {
+				return err
+			}
+			err = state.Merge(imported)
+			if err != nil {
+				return fmt.Errorf("could not merge slashing protection of chain %s: %w", imported.ChainID, err)
+			}
+			err = operation.UpsertSlashingProtection(imported.ChainID, state)(tx)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// retrieveSlashingProtection retrieves the state of the given chain, or an empty state if
+// nothing was signed for the chain yet.
+func retrieveSlashingProtection(chainID flow.ChainID) func(*badger.Txn) (*model.SlashingProtectionState, error) {
+	return func(tx *badger.Txn) (*model.SlashingProtectionState, error) {
+		var state model.SlashingProtectionState
+		err := operation.RetrieveSlashingProtection(chainID, &state)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			return model.NewSlashingProtectionState(chainID), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve slashing protection of chain %s: %w", chainID, err)
+		}
+		return &state, nil
+	}
+}
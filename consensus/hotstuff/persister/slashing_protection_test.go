@@ -0,0 +1,98 @@
+package persister
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestSlashingProtection_Protect verifies that messages for the same block of a view are
+// allowed, while messages for other blocks of the view are refused, also after a restart.
+func TestSlashingProtection_Protect(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		protection := NewSlashingProtection(db, flow.Emulator)
+		blockID := unittest.IdentifierFixture()
+
+		require.NoError(t, protection.ProtectProposal(10, blockID))
+		require.NoError(t, protection.ProtectVote(10, blockID))
+		require.NoError(t, protection.ProtectVote(10, blockID))
+
+		// the protection is persisted
+		protection = NewSlashingProtection(db, flow.Emulator)
+		err := protection.ProtectVote(10, unittest.IdentifierFixture())
+		require.True(t, model.IsConflictingSignatureError(err))
+		err = protection.ProtectProposal(10, unittest.IdentifierFixture())
+		require.True(t, model.IsConflictingSignatureError(err))
+
+		// other views and chains are not affected
+		require.NoError(t, protection.ProtectVote(9, unittest.IdentifierFixture()))
+		require.NoError(t, NewSlashingProtection(db, flow.Localnet).ProtectVote(10, unittest.IdentifierFixture()))
+	})
+}
+
+// TestSlashingProtection_Prune verifies that views below the pruned view are refused.
+func TestSlashingProtection_Prune(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		protection := NewSlashingProtection(db, flow.Emulator)
+		require.NoError(t, protection.ProtectVote(10, unittest.IdentifierFixture()))
+		require.NoError(t, protection.ProtectVote(11, unittest.IdentifierFixture()))
+
+		require.NoError(t, protection.Prune(11))
+		// pruning never lowers the lower bound
+		require.NoError(t, protection.Prune(5))
+
+		err := protection.ProtectVote(10, unittest.IdentifierFixture())
+		require.True(t, model.IsConflictingSignatureError(err))
+		err = protection.ProtectVote(11, unittest.IdentifierFixture())
+		require.True(t, model.IsConflictingSignatureError(err))
+
+		states, err := ExportSlashingProtection(db)
+		require.NoError(t, err)
+		require.Len(t, states, 1)
+		require.Equal(t, uint64(11), states[0].LowerBound)
+		require.Len(t, states[0].Signed, 1)
+	})
+}
+
+// TestSlashingProtection_ExportImport verifies that the exported protection of a node can be
+// imported on another machine, and that conflicting imports are refused.
+func TestSlashingProtection_ExportImport(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(source *badger.DB) {
+		unittest.RunWithBadgerDB(t, func(target *badger.DB) {
+			blockID := unittest.IdentifierFixture()
+			require.NoError(t, NewSlashingProtection(source, flow.Emulator).ProtectProposal(10, blockID))
+			require.NoError(t, NewSlashingProtection(source, flow.Emulator).Prune(8))
+			require.NoError(t, NewSlashingProtection(source, flow.Localnet).ProtectVote(3, blockID))
+			require.NoError(t, NewSlashingProtection(target, flow.Emulator).ProtectVote(12, blockID))
+
+			states, err := ExportSlashingProtection(source)
+			require.NoError(t, err)
+			require.Len(t, states, 2)
+
+			require.NoError(t, ImportSlashingProtection(target, states))
+			// importing is idempotent
+			require.NoError(t, ImportSlashingProtection(target, states))
+
+			protection := NewSlashingProtection(target, flow.Emulator)
+			err = protection.ProtectVote(10, unittest.IdentifierFixture())
+			require.True(t, model.IsConflictingSignatureError(err))
+			err = protection.ProtectVote(7, blockID)
+			require.True(t, model.IsConflictingSignatureError(err))
+			err = protection.ProtectVote(12, unittest.IdentifierFixture())
+			require.True(t, model.IsConflictingSignatureError(err))
+			err = NewSlashingProtection(target, flow.Localnet).ProtectVote(3, unittest.IdentifierFixture())
+			require.True(t, model.IsConflictingSignatureError(err))
+
+			// a conflicting import is refused as a whole
+			conflicting := model.NewSlashingProtectionState(flow.Emulator)
+			require.NoError(t, conflicting.RecordVote(10, unittest.IdentifierFixture()))
+			err = ImportSlashingProtection(target, []*model.SlashingProtectionState{conflicting})
+			require.True(t, model.IsConflictingSignatureError(err))
+		})
+	})
+}
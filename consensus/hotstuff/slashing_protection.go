@@ -0,0 +1,34 @@
+package hotstuff
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// SlashingProtection keeps a persistent record of the blocks the replica signed proposals and
+// votes for, keyed by view, and refuses signing messages which conflict with this record. It
+// prevents the replica from equivocating after a crash, a restore from a backup, or when a
+// duplicate instance of the node is running against the same database.
+//
+// A message is recorded _before_ it is signed, so a crash between recording and signing can only
+// lead to a refused signature, but never to an equivocation.
+//
+// Note: this version of HotStuff doesn't sign timeouts. Once it does, timeouts need to be
+// recorded as well.
+type SlashingProtection interface {
+
+	// ProtectProposal records that a proposal for the given block is signed.
+	// Expected error returns during normal operations:
+	//  * model.ConflictingSignatureError if a message for another block of the same view was
+	//    signed before, or the view was pruned
+	ProtectProposal(view uint64, blockID flow.Identifier) error
+
+	// ProtectVote records that a vote for the given block is signed.
+	// Expected error returns during normal operations:
+	//  * model.ConflictingSignatureError if a message for another block of the same view was
+	//    signed before, or the view was pruned
+	ProtectVote(view uint64, blockID flow.Identifier) error
+
+	// Prune removes the records below the given view, usually the finalized view. Messages
+	// for views below it are refused from now on.
+	Prune(view uint64) error
+}
@@ -98,7 +98,7 @@ func (c *CombinedSigner) genSigData(block *model.Block) ([]byte, error) {
 	// create the message to be signed and generate signatures
 	msg := MakeVoteMessage(block.View, block.BlockID)
 
-	stakingSig, err := c.staking.Sign(msg, c.stakingHasher)
+	stakingSig, err := signVote(c.staking, block.View, block.BlockID, c.stakingHasher)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate staking signature: %w", err)
 	}
@@ -100,7 +100,7 @@ func (c *CombinedSignerV3) genSigData(block *model.Block) ([]byte, error) {
 		if errors.Is(err, module.DKGFailError) {
 			// if the node failed DKG, then using the staking key to sign the block as a
 			// fallback
-			stakingSig, err := c.staking.Sign(msg, c.stakingHasher)
+			stakingSig, err := signVote(c.staking, block.View, block.BlockID, c.stakingHasher)
 			if err != nil {
 				return nil, fmt.Errorf("could not generate staking signature: %w", err)
 			}
@@ -111,7 +111,12 @@ func (c *CombinedSignerV3) genSigData(block *model.Block) ([]byte, error) {
 	}
 
 	// if the node is a Random Beacon participant and has succeeded DKG, then using the random beacon key
-	// to sign the block
+	// to sign the block. The vote signer doesn't hold the random beacon key, so we let it check that the
+	// vote doesn't conflict with previous votes before signing.
+	err = protectVote(c.staking, block.View, block.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("could not protect vote: %w", err)
+	}
 	beaconShare, err := beaconKey.Sign(msg, c.beaconHasher)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate beacon signature: %w", err)
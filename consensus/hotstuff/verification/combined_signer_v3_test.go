@@ -1,6 +1,7 @@
 package verification
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -14,6 +15,7 @@ import (
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/local"
 	modulemock "github.com/onflow/flow-go/module/mock"
 	msig "github.com/onflow/flow-go/module/signature"
@@ -89,6 +91,60 @@ func TestCombinedSignWithDKGKeyV3(t *testing.T) {
 	require.True(t, model.IsInvalidSignerError(err))
 }
 
+// voteSignerLocal is a local which protects the node from signing conflicting votes, like the remote signer.
+type voteSignerLocal struct {
+	module.Local
+	*modulemock.VoteSigner
+}
+
+// Test that when DKG key is available for a view, and the local is a vote signer, the vote is
+// protected by the vote signer before it is signed with the random beacon key, which the vote
+// signer doesn't hold.
+func TestCombinedSignWithDKGKeyProtectedV3(t *testing.T) {
+	dkgKey := unittest.RandomBeaconPriv()
+	view := uint64(20)
+
+	fblock := unittest.BlockFixture()
+	fblock.Header.View = view
+	block := model.BlockFromFlow(fblock.Header, 10)
+
+	epochCounter := uint64(3)
+	epochLookup := &modulemock.EpochLookup{}
+	epochLookup.On("EpochForViewWithFallback", view).Return(epochCounter, nil)
+
+	keys := &storagemock.SafeBeaconKeys{}
+	keys.On("RetrieveMyBeaconPrivateKey", epochCounter).Return(dkgKey, true, nil)
+	beaconKeyStore := signature.NewEpochAwareRandomBeaconKeyStore(epochLookup, keys)
+
+	me := &modulemock.Local{}
+	me.On("NodeID").Return(fblock.Header.ProposerID)
+	voteSigner := modulemock.NewVoteSigner(t)
+	signer := NewCombinedSignerV3(&voteSignerLocal{Local: me, VoteSigner: voteSigner}, beaconKeyStore)
+
+	t.Run("protected vote", func(t *testing.T) {
+		voteSigner.On("ProtectVote", view, block.BlockID).Return(nil).Once()
+
+		vote, err := signer.CreateVote(block)
+		require.NoError(t, err)
+
+		beaconSig, err := dkgKey.Sign(MakeVoteMessage(view, block.BlockID), msig.NewBLSHasher(msig.RandomBeaconTag))
+		require.NoError(t, err)
+		require.Equal(t, msig.EncodeSingleSig(encoding.SigTypeRandomBeacon, beaconSig), vote.SigData)
+	})
+
+	t.Run("conflicting vote", func(t *testing.T) {
+		conflicting := errors.New("conflicting vote")
+		voteSigner.On("ProtectVote", view, block.BlockID).Return(conflicting).Once()
+
+		_, err := signer.CreateVote(block)
+		require.ErrorIs(t, err, conflicting)
+	})
+
+	// the staking key is never used, since the vote is signed with the random beacon key
+	voteSigner.AssertNotCalled(t, "SignVote", mock.Anything, mock.Anything, mock.Anything)
+	me.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything)
+}
+
 // Test that when DKG key is not available for a view, a signed block can pass the validation
 // the sig is a staking sig
 func TestCombinedSignWithNoDKGKeyV3(t *testing.T) {
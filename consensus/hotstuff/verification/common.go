@@ -1,7 +1,10 @@
 package verification
 
 import (
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
 )
 
 // MakeVoteMessage generates the message we have to sign in order to be able
@@ -19,3 +22,23 @@ func MakeVoteMessage(view uint64, blockID flow.Identifier) []byte {
 	})
 	return msg[:]
 }
+
+// signVote signs the vote message for the given view and block with the staking key of the
+// given local. If the local is a module.VoteSigner, e.g. a remote signer protecting the node
+// from signing conflicting votes, the vote is signed with SignVote.
+func signVote(me module.Local, view uint64, blockID flow.Identifier, hasher hash.Hasher) (crypto.Signature, error) {
+	if voteSigner, ok := me.(module.VoteSigner); ok {
+		return voteSigner.SignVote(view, blockID, hasher)
+	}
+	return me.Sign(MakeVoteMessage(view, blockID), hasher)
+}
+
+// protectVote records the vote for the given view and block with the given local before the vote
+// is signed with the random beacon key, if the local is a module.VoteSigner. Otherwise, nothing
+// protects the node from signing conflicting votes, and protectVote does nothing.
+func protectVote(me module.Local, view uint64, blockID flow.Identifier) error {
+	if voteSigner, ok := me.(module.VoteSigner); ok {
+		return voteSigner.ProtectVote(view, blockID)
+	}
+	return nil
+}
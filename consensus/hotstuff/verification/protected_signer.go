@@ -0,0 +1,52 @@
+package verification
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+)
+
+// ProtectedSigner implements the hotstuff.Signer interface.
+// It wraps a hotstuff.Signer instance and records every proposal and vote in a
+// hotstuff.SlashingProtection before signing it. Proposals and votes which conflict with
+// previously signed messages are refused, so that the replica doesn't equivocate after a
+// crash, a restore from a backup, or when running as a duplicate instance.
+type ProtectedSigner struct {
+	signer     hotstuff.Signer
+	protection hotstuff.SlashingProtection
+}
+
+var _ hotstuff.Signer = (*ProtectedSigner)(nil)
+
+func NewProtectedSigner(signer hotstuff.Signer, protection hotstuff.SlashingProtection) *ProtectedSigner {
+	return &ProtectedSigner{
+		signer:     signer,
+		protection: protection,
+	}
+}
+
+// CreateProposal creates a proposal for the given block, unless it conflicts with a
+// previously signed message.
+// Expected error returns during normal operations:
+//   - model.ConflictingSignatureError if a message for another block of the same view was signed
+func (s *ProtectedSigner) CreateProposal(block *model.Block) (*model.Proposal, error) {
+	err := s.protection.ProtectProposal(block.View, block.BlockID)
+	if err != nil {
+		return nil, fmt.Errorf("refused to sign proposal for block %v: %w", block.BlockID, err)
+	}
+	return s.signer.CreateProposal(block)
+}
+
+// CreateVote creates a vote for the given block, unless it conflicts with a previously
+// signed message, in which case a model.NoVoteError is returned.
+func (s *ProtectedSigner) CreateVote(block *model.Block) (*model.Vote, error) {
+	err := s.protection.ProtectVote(block.View, block.BlockID)
+	if model.IsConflictingSignatureError(err) {
+		return nil, model.NoVoteError{Msg: fmt.Sprintf("refused to sign conflicting vote: %v", err)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not record vote for block %v: %w", block.BlockID, err)
+	}
+	return s.signer.CreateVote(block)
+}
@@ -0,0 +1,79 @@
+package verification
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/helper"
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+)
+
+// TestProtectedSigner_CreateProposal verifies that ProtectedSigner only signs proposals which
+// were recorded by the slashing protection.
+func TestProtectedSigner_CreateProposal(t *testing.T) {
+	block := helper.MakeBlock()
+
+	t.Run("recorded", func(t *testing.T) {
+		protection := mocks.NewSlashingProtection(t)
+		protection.On("ProtectProposal", block.View, block.BlockID).Return(nil).Once()
+		signer := mocks.NewSigner(t)
+		expected := helper.MakeProposal(helper.WithBlock(block))
+		signer.On("CreateProposal", block).Return(expected, nil).Once()
+
+		proposal, err := NewProtectedSigner(signer, protection).CreateProposal(block)
+		require.NoError(t, err)
+		require.Equal(t, expected, proposal)
+	})
+	t.Run("conflicting", func(t *testing.T) {
+		protection := mocks.NewSlashingProtection(t)
+		protection.On("ProtectProposal", block.View, block.BlockID).
+			Return(model.NewConflictingSignatureErrorf(block.View, block.BlockID, "conflict")).Once()
+		signer := mocks.NewSigner(t)
+
+		proposal, err := NewProtectedSigner(signer, protection).CreateProposal(block)
+		require.True(t, model.IsConflictingSignatureError(err))
+		require.Nil(t, proposal)
+	})
+}
+
+// TestProtectedSigner_CreateVote verifies that ProtectedSigner only signs votes which were
+// recorded by the slashing protection, and doesn't vote for conflicting blocks.
+func TestProtectedSigner_CreateVote(t *testing.T) {
+	block := helper.MakeBlock()
+
+	t.Run("recorded", func(t *testing.T) {
+		protection := mocks.NewSlashingProtection(t)
+		protection.On("ProtectVote", block.View, block.BlockID).Return(nil).Once()
+		signer := mocks.NewSigner(t)
+		expected := &model.Vote{View: block.View, BlockID: block.BlockID}
+		signer.On("CreateVote", block).Return(expected, nil).Once()
+
+		vote, err := NewProtectedSigner(signer, protection).CreateVote(block)
+		require.NoError(t, err)
+		require.Equal(t, expected, vote)
+	})
+	t.Run("conflicting", func(t *testing.T) {
+		protection := mocks.NewSlashingProtection(t)
+		protection.On("ProtectVote", block.View, block.BlockID).
+			Return(model.NewConflictingSignatureErrorf(block.View, block.BlockID, "conflict")).Once()
+		signer := mocks.NewSigner(t)
+
+		vote, err := NewProtectedSigner(signer, protection).CreateVote(block)
+		require.True(t, model.IsNoVoteError(err))
+		require.Nil(t, vote)
+	})
+	t.Run("exception", func(t *testing.T) {
+		exception := errors.New("exception")
+		protection := mocks.NewSlashingProtection(t)
+		protection.On("ProtectVote", block.View, block.BlockID).Return(exception).Once()
+		signer := mocks.NewSigner(t)
+
+		vote, err := NewProtectedSigner(signer, protection).CreateVote(block)
+		require.ErrorIs(t, err, exception)
+		require.False(t, model.IsNoVoteError(err))
+		require.Nil(t, vote)
+	})
+}
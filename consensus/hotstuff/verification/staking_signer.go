@@ -82,10 +82,8 @@ func (c *StakingSigner) CreateVote(block *model.Block) (*model.Vote, error) {
 //   - (stakingSig, nil) signature signed with staking key.  The sig is 48 bytes long
 //   - (nil, error) if there is any exception
 func (c *StakingSigner) genSigData(block *model.Block) ([]byte, error) {
-	// create the message to be signed and generate signatures
-	msg := MakeVoteMessage(block.View, block.BlockID)
-
-	stakingSig, err := c.me.Sign(msg, c.stakingHasher)
+	// generate the staking signature of the vote message
+	stakingSig, err := signVote(c.me, block.View, block.BlockID, c.stakingHasher)
 	if err != nil {
 		return nil, fmt.Errorf("could not generate staking signature for block (%v) at view %v: %w", block.BlockID, block.View, err)
 	}
@@ -130,6 +130,8 @@ func (suite *Suite) RunTest(
 			nil,
 			suite.log,
 			backend.DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		handler := access.NewHandler(suite.backend, suite.chainID.Chain(), access.WithBlockSignerDecoder(suite.signerIndicesDecoder))
@@ -259,6 +261,7 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 		collNode2 := clusters[1][0]
 		epoch := new(protocol.Epoch)
 		suite.epochQuery.On("Current").Return(epoch)
+		epoch.On("Counter").Return(uint64(1), nil)
 		epoch.On("Clustering").Return(clusters, nil)
 
 		// create two transactions bound for each of the cluster
@@ -305,6 +308,8 @@ func (suite *Suite) TestSendTransactionToRandomCollectionNode() {
 			nil,
 			suite.log,
 			backend.DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		handler := access.NewHandler(backend, suite.chainID.Chain())
@@ -616,6 +621,8 @@ func (suite *Suite) TestGetSealedTransaction() {
 			enNodeIDs.Strings(),
 			suite.log,
 			backend.DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		handler := access.NewHandler(backend, suite.chainID.Chain())
@@ -709,6 +716,8 @@ func (suite *Suite) TestExecuteScript() {
 			flow.IdentifierList(identities.NodeIDs()).Strings(),
 			suite.log,
 			backend.DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		handler := access.NewHandler(suite.backend, suite.chainID.Chain())
@@ -28,7 +28,7 @@ import (
 type FlowAccessAPIRouter struct {
 	Logger   zerolog.Logger
 	Metrics  *metrics.ObserverCollector
-	Upstream *FlowAccessAPIForwarder
+	Upstream access.AccessAPIServer // the forwarder to the upstream access nodes, optionally behind a response cache
 	Observer *protocol.Handler
 }
 
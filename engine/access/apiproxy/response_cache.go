@@ -0,0 +1,293 @@
+package apiproxy
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocolstate "github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultResponseCacheSize is the default number of upstream responses kept in the response cache.
+const DefaultResponseCacheSize = 10_000
+
+// upstreamFetchTimeout bounds upstream requests, which are not cancelled with the request of
+// any single caller as they may be shared by several callers.
+const upstreamFetchTimeout = time.Minute
+
+// FlowAccessAPICache sits in front of the upstream access nodes, and caches responses to requests
+// for immutable data: collections and transactions by ID, sealed transaction results, and data
+// of sealed blocks. All other requests are passed through to the upstream.
+//
+// Whether a block is sealed is determined by the protocol state of the observer, so a response is
+// only cached once the observer itself has seen the block sealed. Responses for "latest" queries,
+// and for finalized but unsealed blocks, are never cached.
+//
+// Concurrent identical requests are coalesced into a single upstream request, whether or not the
+// response is cached.
+type FlowAccessAPICache struct {
+	access.AccessAPIServer // the upstream, serving all requests which are not cached
+
+	log       zerolog.Logger
+	metrics   *metrics.ObserverCollector
+	state     protocolstate.State
+	headers   storage.Headers
+	responses *lru.Cache // cache key -> response
+	inflight  singleflight.Group
+}
+
+var _ access.AccessAPIServer = (*FlowAccessAPICache)(nil)
+
+// NewFlowAccessAPICache creates a cache of at most size responses of the given upstream.
+func NewFlowAccessAPICache(
+	log zerolog.Logger,
+	metrics *metrics.ObserverCollector,
+	upstream access.AccessAPIServer,
+	state protocolstate.State,
+	headers storage.Headers,
+	size int,
+) (*FlowAccessAPICache, error) {
+	responses, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("could not create response cache: %w", err)
+	}
+
+	return &FlowAccessAPICache{
+		AccessAPIServer: upstream,
+		log:             log.With().Str("component", "upstream_response_cache").Logger(),
+		metrics:         metrics,
+		state:           state,
+		headers:         headers,
+		responses:       responses,
+	}, nil
+}
+
+func (c *FlowAccessAPICache) GetCollectionByID(ctx context.Context, req *access.GetCollectionByIDRequest) (*access.CollectionResponse, error) {
+	return cached(c, ctx, "GetCollectionByID", req, func(ctx context.Context) (*access.CollectionResponse, error) {
+		return c.AccessAPIServer.GetCollectionByID(ctx, req)
+	}, func(*access.CollectionResponse) bool {
+		// collections are identified by their content
+		return true
+	})
+}
+
+func (c *FlowAccessAPICache) GetTransaction(ctx context.Context, req *access.GetTransactionRequest) (*access.TransactionResponse, error) {
+	return cached(c, ctx, "GetTransaction", req, func(ctx context.Context) (*access.TransactionResponse, error) {
+		return c.AccessAPIServer.GetTransaction(ctx, req)
+	}, func(*access.TransactionResponse) bool {
+		// transactions are identified by their content
+		return true
+	})
+}
+
+func (c *FlowAccessAPICache) GetTransactionResult(ctx context.Context, req *access.GetTransactionRequest) (*access.TransactionResultResponse, error) {
+	return cached(c, ctx, "GetTransactionResult", req, func(ctx context.Context) (*access.TransactionResultResponse, error) {
+		return c.AccessAPIServer.GetTransactionResult(ctx, req)
+	}, isSealedResult)
+}
+
+func (c *FlowAccessAPICache) GetTransactionResultByIndex(ctx context.Context, req *access.GetTransactionByIndexRequest) (*access.TransactionResultResponse, error) {
+	return cached(c, ctx, "GetTransactionResultByIndex", req, func(ctx context.Context) (*access.TransactionResultResponse, error) {
+		return c.AccessAPIServer.GetTransactionResultByIndex(ctx, req)
+	}, isSealedResult)
+}
+
+func (c *FlowAccessAPICache) GetTransactionResultsByBlockID(ctx context.Context, req *access.GetTransactionsByBlockIDRequest) (*access.TransactionResultsResponse, error) {
+	sealed := c.isSealedBlock(req.GetBlockId())
+	return cached(c, ctx, "GetTransactionResultsByBlockID", req, func(ctx context.Context) (*access.TransactionResultsResponse, error) {
+		return c.AccessAPIServer.GetTransactionResultsByBlockID(ctx, req)
+	}, func(res *access.TransactionResultsResponse) bool {
+		if !sealed {
+			return false
+		}
+		for _, result := range res.GetTransactionResults() {
+			if !isSealedResult(result) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func (c *FlowAccessAPICache) GetTransactionsByBlockID(ctx context.Context, req *access.GetTransactionsByBlockIDRequest) (*access.TransactionsResponse, error) {
+	sealed := c.isSealedBlock(req.GetBlockId())
+	return cached(c, ctx, "GetTransactionsByBlockID", req, func(ctx context.Context) (*access.TransactionsResponse, error) {
+		return c.AccessAPIServer.GetTransactionsByBlockID(ctx, req)
+	}, func(*access.TransactionsResponse) bool { return sealed })
+}
+
+func (c *FlowAccessAPICache) GetAccountAtBlockHeight(ctx context.Context, req *access.GetAccountAtBlockHeightRequest) (*access.AccountResponse, error) {
+	sealed := c.isSealedHeight(req.GetBlockHeight())
+	return cached(c, ctx, "GetAccountAtBlockHeight", req, func(ctx context.Context) (*access.AccountResponse, error) {
+		return c.AccessAPIServer.GetAccountAtBlockHeight(ctx, req)
+	}, func(*access.AccountResponse) bool { return sealed })
+}
+
+func (c *FlowAccessAPICache) ExecuteScriptAtBlockID(ctx context.Context, req *access.ExecuteScriptAtBlockIDRequest) (*access.ExecuteScriptResponse, error) {
+	sealed := c.isSealedBlock(req.GetBlockId())
+	return cached(c, ctx, "ExecuteScriptAtBlockID", req, func(ctx context.Context) (*access.ExecuteScriptResponse, error) {
+		return c.AccessAPIServer.ExecuteScriptAtBlockID(ctx, req)
+	}, func(*access.ExecuteScriptResponse) bool { return sealed })
+}
+
+func (c *FlowAccessAPICache) ExecuteScriptAtBlockHeight(ctx context.Context, req *access.ExecuteScriptAtBlockHeightRequest) (*access.ExecuteScriptResponse, error) {
+	sealed := c.isSealedHeight(req.GetBlockHeight())
+	return cached(c, ctx, "ExecuteScriptAtBlockHeight", req, func(ctx context.Context) (*access.ExecuteScriptResponse, error) {
+		return c.AccessAPIServer.ExecuteScriptAtBlockHeight(ctx, req)
+	}, func(*access.ExecuteScriptResponse) bool { return sealed })
+}
+
+func (c *FlowAccessAPICache) GetEventsForHeightRange(ctx context.Context, req *access.GetEventsForHeightRangeRequest) (*access.EventsResponse, error) {
+	sealed := c.isSealedHeight(req.GetEndHeight())
+	return cached(c, ctx, "GetEventsForHeightRange", req, func(ctx context.Context) (*access.EventsResponse, error) {
+		return c.AccessAPIServer.GetEventsForHeightRange(ctx, req)
+	}, func(res *access.EventsResponse) bool {
+		// the upstream clips the range to its own latest sealed block, so a response of an
+		// upstream lagging behind the observer is truncated and must not be cached
+		return sealed && coversHeightRange(res, req.GetStartHeight(), req.GetEndHeight())
+	})
+}
+
+func (c *FlowAccessAPICache) GetEventsForBlockIDs(ctx context.Context, req *access.GetEventsForBlockIDsRequest) (*access.EventsResponse, error) {
+	sealed := true
+	for _, blockID := range req.GetBlockIds() {
+		if !c.isSealedBlock(blockID) {
+			sealed = false
+			break
+		}
+	}
+	return cached(c, ctx, "GetEventsForBlockIDs", req, func(ctx context.Context) (*access.EventsResponse, error) {
+		return c.AccessAPIServer.GetEventsForBlockIDs(ctx, req)
+	}, func(*access.EventsResponse) bool { return sealed })
+}
+
+func (c *FlowAccessAPICache) GetExecutionResultForBlockID(ctx context.Context, req *access.GetExecutionResultForBlockIDRequest) (*access.ExecutionResultForBlockIDResponse, error) {
+	sealed := c.isSealedBlock(req.GetBlockId())
+	return cached(c, ctx, "GetExecutionResultForBlockID", req, func(ctx context.Context) (*access.ExecutionResultForBlockIDResponse, error) {
+		return c.AccessAPIServer.GetExecutionResultForBlockID(ctx, req)
+	}, func(*access.ExecutionResultForBlockIDResponse) bool { return sealed })
+}
+
+// cached returns the cached response to the given request, or fetches it from the upstream.
+// Concurrent identical requests are coalesced into a single call of fetch. A fetched response
+// is cached only if immutable returns true for it.
+//
+// Whether a request is for sealed data must be determined before fetching the response, so a
+// block being sealed while the request is in flight can't cause a response for an unsealed block
+// to be cached.
+//
+// As a coalesced fetch serves all waiting callers, it is not bound to the context of the caller
+// which started it: a caller cancelling its request only stops waiting for the response.
+func cached[Resp any](c *FlowAccessAPICache, ctx context.Context, method string, req proto.Message, fetch func(context.Context) (Resp, error), immutable func(Resp) bool) (Resp, error) {
+	var empty Resp
+
+	encoded, err := proto.Marshal(req)
+	if err != nil {
+		// should never happen for a request we have already decoded, so just pass it through
+		c.log.Warn().Err(err).Str("grpc_method", method).Msg("could not encode request for cache lookup")
+		return fetch(ctx)
+	}
+	key := method + "/" + string(encoded)
+
+	if res, ok := c.responses.Get(key); ok {
+		c.metrics.ResponseCacheHit(method)
+		return res.(Resp), nil
+	}
+	c.metrics.ResponseCacheMiss(method)
+
+	fetched := c.inflight.DoChan(key, func() (interface{}, error) {
+		fetchCtx, cancel := context.WithTimeout(detachedContext{ctx}, upstreamFetchTimeout)
+		defer cancel()
+
+		res, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		if immutable(res) {
+			c.responses.Add(key, res)
+		}
+		return res, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return empty, status.FromContextError(ctx.Err()).Err()
+	case result := <-fetched:
+		if result.Err != nil {
+			return empty, result.Err
+		}
+		return result.Val.(Resp), nil
+	}
+}
+
+// detachedContext carries the values of its parent context, but is never cancelled.
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (d detachedContext) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
+
+// coversHeightRange returns true if the response holds the events of every block in the
+// given height range.
+func coversHeightRange(res *access.EventsResponse, startHeight, endHeight uint64) bool {
+	results := res.GetResults()
+	if endHeight < startHeight || uint64(len(results)) != endHeight-startHeight+1 {
+		return false
+	}
+	return results[len(results)-1].GetBlockHeight() == endHeight
+}
+
+// isSealedHeight returns true if the block at the given height is sealed.
+func (c *FlowAccessAPICache) isSealedHeight(height uint64) bool {
+	sealed, err := c.state.Sealed().Head()
+	if err != nil {
+		c.log.Warn().Err(err).Msg("could not get latest sealed block")
+		return false
+	}
+	return height <= sealed.Height
+}
+
+// isSealedBlock returns true if the block with the given ID is known to be sealed.
+// Blocks which are not known to the observer are considered not sealed.
+func (c *FlowAccessAPICache) isSealedBlock(blockID []byte) bool {
+	if len(blockID) != len(flow.ZeroID) {
+		return false
+	}
+	id := flow.HashToID(blockID)
+
+	header, err := c.headers.ByBlockID(id)
+	if err != nil {
+		return false
+	}
+	if !c.isSealedHeight(header.Height) {
+		return false
+	}
+
+	// the block must be the finalized block at its height, and not a block of an orphaned fork
+	finalized, err := c.headers.ByHeight(header.Height)
+	if err != nil {
+		return false
+	}
+	return finalized.ID() == id
+}
+
+// isSealedResult returns true if the transaction result is for a sealed transaction.
+func isSealedResult(res *access.TransactionResultResponse) bool {
+	return res.GetStatus() == entities.TransactionStatus_SEALED
+}
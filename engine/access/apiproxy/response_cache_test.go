@@ -0,0 +1,282 @@
+package apiproxy
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow/protobuf/go/flow/access"
+	"github.com/onflow/flow/protobuf/go/flow/entities"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	protocolmock "github.com/onflow/flow-go/state/protocol/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// countingUpstream is an upstream which counts the requests it serves.
+type countingUpstream struct {
+	access.AccessAPIServer
+	calls   atomic.Int32
+	status  entities.TransactionStatus
+	sealed  uint64        // latest sealed height of the upstream, to which event queries are clipped
+	entered chan struct{} // if not nil, signalled when a request is received
+	release chan struct{} // if not nil, requests block until closed
+}
+
+func (u *countingUpstream) wait() {
+	if u.entered != nil {
+		u.entered <- struct{}{}
+	}
+	if u.release != nil {
+		<-u.release
+	}
+}
+
+func (u *countingUpstream) GetTransaction(ctx context.Context, _ *access.GetTransactionRequest) (*access.TransactionResponse, error) {
+	u.calls.Inc()
+	u.wait()
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	return &access.TransactionResponse{}, nil
+}
+
+func (u *countingUpstream) GetTransactionResult(context.Context, *access.GetTransactionRequest) (*access.TransactionResultResponse, error) {
+	u.calls.Inc()
+	return &access.TransactionResultResponse{Status: u.status}, nil
+}
+
+func (u *countingUpstream) GetTransactionsByBlockID(context.Context, *access.GetTransactionsByBlockIDRequest) (*access.TransactionsResponse, error) {
+	u.calls.Inc()
+	return &access.TransactionsResponse{}, nil
+}
+
+func (u *countingUpstream) ExecuteScriptAtBlockHeight(context.Context, *access.ExecuteScriptAtBlockHeightRequest) (*access.ExecuteScriptResponse, error) {
+	u.calls.Inc()
+	return &access.ExecuteScriptResponse{}, nil
+}
+
+func (u *countingUpstream) GetEventsForHeightRange(_ context.Context, req *access.GetEventsForHeightRangeRequest) (*access.EventsResponse, error) {
+	u.calls.Inc()
+	endHeight := req.GetEndHeight()
+	if endHeight > u.sealed {
+		endHeight = u.sealed
+	}
+	res := &access.EventsResponse{}
+	for height := req.GetStartHeight(); height <= endHeight; height++ {
+		res.Results = append(res.Results, &access.EventsResponse_Result{BlockHeight: height})
+	}
+	return res, nil
+}
+
+func (u *countingUpstream) GetAccountAtLatestBlock(context.Context, *access.GetAccountAtLatestBlockRequest) (*access.AccountResponse, error) {
+	u.calls.Inc()
+	return &access.AccountResponse{}, nil
+}
+
+// observerMetrics is shared by all tests, as the collector registers its metrics globally.
+var observerMetrics = metrics.NewObserverCollector()
+
+func identifierBytes() []byte {
+	id := unittest.IdentifierFixture()
+	return id[:]
+}
+
+// TestFlowAccessAPICache tests that only responses for immutable data are cached, and that
+// concurrent identical requests are coalesced.
+func TestFlowAccessAPICache(t *testing.T) {
+	ctx := context.Background()
+
+	// blocks up to the sealed block are sealed, the orphan is a sealed height but not finalized
+	sealed := unittest.BlockHeaderFixture()
+	unsealed := unittest.BlockHeaderWithParentFixture(sealed)
+	orphan := unittest.BlockHeaderFixture(func(header *flow.Header) {
+		header.Height = sealed.Height
+	})
+
+	snapshot := protocolmock.NewSnapshot(t)
+	snapshot.On("Head").Return(sealed, nil).Maybe()
+	state := protocolmock.NewState(t)
+	state.On("Sealed").Return(snapshot).Maybe()
+
+	headers := storagemock.NewHeaders(t)
+	for _, header := range []*flow.Header{sealed, unsealed, orphan} {
+		headers.On("ByBlockID", header.ID()).Return(header, nil).Maybe()
+	}
+	headers.On("ByHeight", sealed.Height).Return(sealed, nil).Maybe()
+
+	newCache := func(upstream access.AccessAPIServer) *FlowAccessAPICache {
+		cache, err := NewFlowAccessAPICache(zerolog.Nop(), observerMetrics, upstream, state, headers, 100)
+		require.NoError(t, err)
+		return cache
+	}
+
+	t.Run("transactions by ID are cached", func(t *testing.T) {
+		upstream := &countingUpstream{}
+		cache := newCache(upstream)
+
+		req := &access.GetTransactionRequest{Id: identifierBytes()}
+		for i := 0; i < 3; i++ {
+			_, err := cache.GetTransaction(ctx, req)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(1), upstream.calls.Load())
+
+		// different requests are not served from the cache
+		_, err := cache.GetTransaction(ctx, &access.GetTransactionRequest{Id: identifierBytes()})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), upstream.calls.Load())
+	})
+
+	t.Run("only sealed transaction results are cached", func(t *testing.T) {
+		upstream := &countingUpstream{status: entities.TransactionStatus_FINALIZED}
+		cache := newCache(upstream)
+
+		req := &access.GetTransactionRequest{Id: identifierBytes()}
+		for i := 0; i < 2; i++ {
+			_, err := cache.GetTransactionResult(ctx, req)
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), upstream.calls.Load())
+
+		upstream.status = entities.TransactionStatus_SEALED
+		for i := 0; i < 2; i++ {
+			res, err := cache.GetTransactionResult(ctx, req)
+			require.NoError(t, err)
+			assert.Equal(t, entities.TransactionStatus_SEALED, res.GetStatus())
+		}
+		assert.Equal(t, int32(3), upstream.calls.Load())
+	})
+
+	t.Run("only responses for sealed heights are cached", func(t *testing.T) {
+		upstream := &countingUpstream{}
+		cache := newCache(upstream)
+
+		for _, height := range []uint64{sealed.Height, sealed.Height, unsealed.Height, unsealed.Height} {
+			_, err := cache.ExecuteScriptAtBlockHeight(ctx, &access.ExecuteScriptAtBlockHeightRequest{BlockHeight: height})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(3), upstream.calls.Load())
+	})
+
+	t.Run("only responses for sealed blocks are cached", func(t *testing.T) {
+		upstream := &countingUpstream{}
+		cache := newCache(upstream)
+
+		for _, header := range []*flow.Header{sealed, sealed, unsealed, unsealed, orphan, orphan} {
+			blockID := header.ID()
+			_, err := cache.GetTransactionsByBlockID(ctx, &access.GetTransactionsByBlockIDRequest{BlockId: blockID[:]})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(5), upstream.calls.Load())
+	})
+
+	t.Run("truncated event ranges are not cached", func(t *testing.T) {
+		// the upstream lags behind the sealed height of the observer
+		upstream := &countingUpstream{sealed: sealed.Height - 1}
+		cache := newCache(upstream)
+
+		req := &access.GetEventsForHeightRangeRequest{StartHeight: sealed.Height - 5, EndHeight: sealed.Height}
+		for i := 0; i < 2; i++ {
+			res, err := cache.GetEventsForHeightRange(ctx, req)
+			require.NoError(t, err)
+			assert.Len(t, res.GetResults(), 5)
+		}
+		assert.Equal(t, int32(2), upstream.calls.Load())
+
+		// once the upstream has caught up, the complete response is cached
+		upstream.sealed = sealed.Height
+		for i := 0; i < 2; i++ {
+			res, err := cache.GetEventsForHeightRange(ctx, req)
+			require.NoError(t, err)
+			assert.Len(t, res.GetResults(), 6)
+		}
+		assert.Equal(t, int32(3), upstream.calls.Load())
+	})
+
+	t.Run("latest queries are not cached", func(t *testing.T) {
+		upstream := &countingUpstream{}
+		cache := newCache(upstream)
+
+		for i := 0; i < 2; i++ {
+			_, err := cache.GetAccountAtLatestBlock(ctx, &access.GetAccountAtLatestBlockRequest{})
+			require.NoError(t, err)
+		}
+		assert.Equal(t, int32(2), upstream.calls.Load())
+	})
+
+	t.Run("concurrent requests are coalesced", func(t *testing.T) {
+		upstream := &countingUpstream{
+			entered: make(chan struct{}, 10),
+			release: make(chan struct{}),
+		}
+		cache := newCache(upstream)
+		req := &access.GetTransactionRequest{Id: identifierBytes()}
+
+		var wg sync.WaitGroup
+		request := func() {
+			defer wg.Done()
+			_, err := cache.GetTransaction(ctx, req)
+			assert.NoError(t, err)
+		}
+
+		wg.Add(1)
+		go request()
+		<-upstream.entered
+
+		// requests arriving while the first one is in flight wait for its response, and
+		// requests arriving afterwards are served from the cache
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go request()
+		}
+		close(upstream.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), upstream.calls.Load())
+	})
+
+	t.Run("cancelled request does not fail coalesced requests", func(t *testing.T) {
+		upstream := &countingUpstream{
+			entered: make(chan struct{}, 10),
+			release: make(chan struct{}),
+		}
+		cache := newCache(upstream)
+		req := &access.GetTransactionRequest{Id: identifierBytes()}
+
+		firstCtx, cancel := context.WithCancel(ctx)
+		firstDone := make(chan error)
+		go func() {
+			_, err := cache.GetTransaction(firstCtx, req)
+			firstDone <- err
+		}()
+		<-upstream.entered
+
+		secondDone := make(chan error)
+		go func() {
+			_, err := cache.GetTransaction(ctx, req)
+			secondDone <- err
+		}()
+
+		// the first caller gives up, while the upstream request it started is still in flight
+		cancel()
+		unittest.RequireReturnsBefore(t, func() {
+			assert.Equal(t, codes.Canceled, status.Code(<-firstDone))
+		}, time.Second, "cancelled request did not return")
+
+		close(upstream.release)
+		unittest.RequireReturnsBefore(t, func() {
+			assert.NoError(t, <-secondDone)
+		}, time.Second, "coalesced request did not return")
+		assert.Equal(t, int32(1), upstream.calls.Load())
+	})
+}
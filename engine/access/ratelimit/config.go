@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// DefaultTier is the tier of clients which don't present an API key, and are identified by their
+// source IP address. If the default tier is not configured, these clients are not rate limited.
+const DefaultTier = "default"
+
+// TierConfig defines the limits applied to each client in a tier.
+type TierConfig struct {
+	// RequestsPerSecond is the sustained number of requests per second allowed for each client.
+	// Zero disables the request rate limit.
+	RequestsPerSecond float64 `json:"requests_per_second"`
+
+	// Burst is the maximum number of requests a client can make at once.
+	Burst int `json:"burst"`
+
+	// DailyScriptComputeMillis is the total time in milliseconds spent executing scripts allowed
+	// for each client per UTC day. Zero disables the script compute budget.
+	DailyScriptComputeMillis uint64 `json:"daily_script_compute_ms"`
+}
+
+// Config defines the client tiers and the API keys assigned to them.
+type Config struct {
+	// Tiers maps the name of a tier to its limits.
+	Tiers map[string]TierConfig `json:"tiers"`
+
+	// APIKeys maps each API key to the name of the tier of its client.
+	APIKeys map[string]string `json:"api_keys"`
+}
+
+// LoadConfig reads a JSON encoded rate limit config from the given file.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read rate limit config: %w", err)
+	}
+
+	var config Config
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode rate limit config: %w", err)
+	}
+
+	err = config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit config: %w", err)
+	}
+
+	return &config, nil
+}
+
+// Validate checks that the limits of all tiers are valid, and that all API keys are assigned to a
+// configured tier.
+func (c *Config) Validate() error {
+	for name, tier := range c.Tiers {
+		if tier.RequestsPerSecond < 0 {
+			return fmt.Errorf("tier %s: requests per second must not be negative", name)
+		}
+		if tier.RequestsPerSecond > 0 && tier.Burst <= 0 {
+			return fmt.Errorf("tier %s: burst must be positive when requests per second is set", name)
+		}
+	}
+
+	for key, tier := range c.APIKeys {
+		if key == "" {
+			return fmt.Errorf("api key must not be empty")
+		}
+		if _, ok := c.Tiers[tier]; !ok {
+			return fmt.Errorf("api key assigned to unknown tier %s", tier)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,106 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"math"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+const (
+	// APIKeyMetadataKey is the gRPC metadata key clients present their API key with.
+	APIKeyMetadataKey = "x-api-key"
+
+	// retryAfterMetadataKey is the gRPC header metadata key holding the number of seconds after
+	// which a rate limited request can be retried.
+	retryAfterMetadataKey = "retry-after"
+)
+
+// scriptMethods are the Access API methods executing scripts, which are subject to the daily
+// script compute budget.
+var scriptMethods = map[string]struct{}{
+	"ExecuteScriptAtLatestBlock": {},
+	"ExecuteScriptAtBlockID":     {},
+	"ExecuteScriptAtBlockHeight": {},
+}
+
+// UnaryServerInterceptor rate limits gRPC requests by the client presenting them. Clients are
+// identified by the API key in the request metadata, or by their source IP address.
+// Rate limited requests are rejected with a RESOURCE_EXHAUSTED status carrying a RetryInfo detail.
+func (l *Limiter) UnaryServerInterceptor(ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (resp interface{}, err error) {
+
+	var apiKey string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(APIKeyMetadataKey); len(values) > 0 {
+			apiKey = values[0]
+		}
+	}
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+
+	client, err := l.Identify(apiKey, remoteAddr)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	// remove the package name (e.g. "/flow.access.AccessAPI/Ping" to "Ping")
+	_, script := scriptMethods[filepath.Base(info.FullMethod)]
+
+	err = l.Allow(client, script)
+	if err != nil {
+		var rateLimitedErr RateLimitedError
+		if errors.As(err, &rateLimitedErr) {
+			return nil, l.resourceExhausted(ctx, rateLimitedErr)
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	if !script {
+		return handler(ctx, req)
+	}
+
+	start := time.Now()
+	resp, err = handler(ctx, req)
+	l.ChargeScriptCompute(client, time.Since(start))
+
+	return resp, err
+}
+
+// resourceExhausted returns the gRPC status error for a rate limited request, and sets the
+// retry-after response header.
+func (l *Limiter) resourceExhausted(ctx context.Context, rateLimitedErr RateLimitedError) error {
+	err := grpc.SetHeader(ctx, metadata.Pairs(retryAfterMetadataKey, RetryAfterSeconds(rateLimitedErr.RetryAfter)))
+	if err != nil {
+		l.log.Debug().Err(err).Msg("could not set retry-after header")
+	}
+
+	st := status.New(codes.ResourceExhausted, rateLimitedErr.Error())
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(rateLimitedErr.RetryAfter),
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// RetryAfterSeconds formats the given retry delay as a whole number of seconds, rounded up, as
+// used by the Retry-After HTTP header.
+func RetryAfterSeconds(retryAfter time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(retryAfter.Seconds())), 10)
+}
@@ -0,0 +1,231 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/module"
+)
+
+const (
+	// ReasonRequestRate is the reason of rejecting requests which exceed the request rate limit.
+	ReasonRequestRate = "request_rate"
+
+	// ReasonScriptCompute is the reason of rejecting scripts which exceed the daily script compute budget.
+	ReasonScriptCompute = "script_compute"
+)
+
+// clientCacheSize is the number of clients for which the limiter keeps track of their usage.
+// When the cache is full, the least recently seen client is evicted, and its usage is reset.
+const clientCacheSize = 100_000
+
+// ErrUnknownAPIKey is returned when a client presents an API key which is not configured.
+var ErrUnknownAPIKey = errors.New("unknown api key")
+
+// RateLimitedError is returned when a request of a client exceeds the limits of its tier.
+type RateLimitedError struct {
+	Tier       string
+	Reason     string
+	RetryAfter time.Duration // the time after which the request is expected to be allowed
+}
+
+func (e RateLimitedError) Error() string {
+	return fmt.Sprintf("client rate limit exceeded (tier: %s, reason: %s), retry after %s", e.Tier, e.Reason, e.RetryAfter)
+}
+
+// IsRateLimitedError returns true if the given error is a RateLimitedError.
+func IsRateLimitedError(err error) bool {
+	var errRateLimited RateLimitedError
+	return errors.As(err, &errRateLimited)
+}
+
+// Client identifies the client of a request.
+type Client struct {
+	ID   string // the hashed API key, or the source IP address of clients without API key
+	Tier string
+}
+
+// clientState holds the usage of a client.
+type clientState struct {
+	requests    *rate.Limiter
+	computeDay  time.Time     // the start of the UTC day computeUsed is tracked for
+	computeUsed time.Duration // the script compute used during computeDay
+}
+
+// Limiter enforces the limits of client tiers on the requests of clients. It is shared by the
+// gRPC and REST servers of the Access API, so that a client has the same budget across both APIs.
+type Limiter struct {
+	log     zerolog.Logger
+	config  Config
+	metrics module.ClientRateLimitMetrics
+	now     func() time.Time
+
+	mu      sync.Mutex
+	clients *lru.Cache // client ID -> *clientState
+}
+
+// NewLimiter creates a new limiter enforcing the limits of the given config.
+func NewLimiter(log zerolog.Logger, config Config, metrics module.ClientRateLimitMetrics) (*Limiter, error) {
+	err := config.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate limit config: %w", err)
+	}
+
+	clients, err := lru.New(clientCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create client cache: %w", err)
+	}
+
+	if _, ok := config.Tiers[DefaultTier]; !ok {
+		log.Warn().Msg("default tier not configured, clients without api key are not rate limited")
+	}
+
+	return &Limiter{
+		log:     log.With().Str("component", "client_rate_limiter").Logger(),
+		config:  config,
+		metrics: metrics,
+		now:     time.Now,
+		clients: clients,
+	}, nil
+}
+
+// Identify returns the client of a request, given the API key presented by the client and its
+// remote address. Clients without an API key are identified by their source IP address and
+// belong to the DefaultTier.
+// Expected errors during normal operations:
+//   - ErrUnknownAPIKey if the API key is not configured
+func (l *Limiter) Identify(apiKey string, remoteAddr string) (Client, error) {
+	if apiKey != "" {
+		tier, ok := l.config.APIKeys[apiKey]
+		if !ok {
+			return Client{}, ErrUnknownAPIKey
+		}
+		// don't keep the API key itself around, so it doesn't end up in logs
+		hash := sha256.Sum256([]byte(apiKey))
+		return Client{
+			ID:   "key:" + hex.EncodeToString(hash[:8]),
+			Tier: tier,
+		}, nil
+	}
+
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		// the address has no port
+		host = remoteAddr
+	}
+	return Client{
+		ID:   "ip:" + host,
+		Tier: DefaultTier,
+	}, nil
+}
+
+// Allow checks whether a request of the given client is within the limits of its tier, and
+// accounts for the request if it is. Script executions are additionally checked against the
+// daily script compute budget of the tier.
+// Expected errors during normal operations:
+//   - RateLimitedError if the request exceeds the limits of the client's tier
+func (l *Limiter) Allow(client Client, script bool) error {
+	tier, ok := l.config.Tiers[client.Tier]
+	if !ok {
+		// clients of tiers which are not configured are not limited
+		l.metrics.ClientRequestAllowed(client.Tier)
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	state := l.state(client.ID, tier)
+
+	if script && tier.DailyScriptComputeMillis > 0 {
+		state.resetComputeIfNewDay(now)
+		if state.computeUsed >= time.Duration(tier.DailyScriptComputeMillis)*time.Millisecond {
+			return l.rateLimited(client, ReasonScriptCompute, state.computeDay.Add(24*time.Hour).Sub(now))
+		}
+	}
+
+	reservation := state.requests.ReserveN(now, 1)
+	if !reservation.OK() {
+		return l.rateLimited(client, ReasonRequestRate, time.Second)
+	}
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		// the request is rejected, so return the token for future requests
+		reservation.CancelAt(now)
+		return l.rateLimited(client, ReasonRequestRate, delay)
+	}
+
+	l.metrics.ClientRequestAllowed(client.Tier)
+	return nil
+}
+
+// ChargeScriptCompute accounts the given time spent executing a script against the daily script
+// compute budget of the client.
+func (l *Limiter) ChargeScriptCompute(client Client, duration time.Duration) {
+	l.metrics.ClientScriptComputeUsed(client.Tier, duration)
+
+	tier, ok := l.config.Tiers[client.Tier]
+	if !ok || tier.DailyScriptComputeMillis == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	state := l.state(client.ID, tier)
+	state.resetComputeIfNewDay(l.now())
+	state.computeUsed += duration
+}
+
+// state returns the usage of the given client, creating it if the client is not known.
+// Must be called while holding the lock.
+func (l *Limiter) state(clientID string, tier TierConfig) *clientState {
+	if cached, ok := l.clients.Get(clientID); ok {
+		return cached.(*clientState)
+	}
+
+	limit := rate.Inf
+	if tier.RequestsPerSecond > 0 {
+		limit = rate.Limit(tier.RequestsPerSecond)
+	}
+	state := &clientState{
+		requests: rate.NewLimiter(limit, tier.Burst),
+	}
+	l.clients.Add(clientID, state)
+	return state
+}
+
+func (l *Limiter) rateLimited(client Client, reason string, retryAfter time.Duration) error {
+	l.metrics.ClientRequestRateLimited(client.Tier, reason)
+	l.log.Debug().
+		Str("client", client.ID).
+		Str("tier", client.Tier).
+		Str("reason", reason).
+		Dur("retry_after", retryAfter).
+		Msg("client rate limit exceeded")
+
+	return RateLimitedError{
+		Tier:       client.Tier,
+		Reason:     reason,
+		RetryAfter: retryAfter,
+	}
+}
+
+// resetComputeIfNewDay resets the script compute used by the client when a new UTC day has started.
+func (s *clientState) resetComputeIfNewDay(now time.Time) {
+	day := now.UTC().Truncate(24 * time.Hour)
+	if day.After(s.computeDay) {
+		s.computeDay = day
+		s.computeUsed = 0
+	}
+}
@@ -0,0 +1,186 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+func testConfig() Config {
+	return Config{
+		Tiers: map[string]TierConfig{
+			DefaultTier: {
+				RequestsPerSecond: 1,
+				Burst:             2,
+			},
+			"premium": {
+				RequestsPerSecond:        100,
+				Burst:                    100,
+				DailyScriptComputeMillis: 1000,
+			},
+		},
+		APIKeys: map[string]string{
+			"premium-key": "premium",
+		},
+	}
+}
+
+func newTestLimiter(t *testing.T, now *time.Time) *Limiter {
+	limiter, err := NewLimiter(zerolog.Nop(), testConfig(), metrics.NewNoopCollector())
+	require.NoError(t, err)
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func TestConfig_Validate(t *testing.T) {
+	config := testConfig()
+	require.NoError(t, config.Validate())
+
+	config.APIKeys["other-key"] = "unknown"
+	assert.Error(t, config.Validate())
+
+	config = testConfig()
+	config.Tiers["zero-burst"] = TierConfig{RequestsPerSecond: 1}
+	assert.Error(t, config.Validate())
+}
+
+func TestLimiter_Identify(t *testing.T) {
+	now := time.Now()
+	limiter := newTestLimiter(t, &now)
+
+	client, err := limiter.Identify("", "10.0.0.1:3569")
+	require.NoError(t, err)
+	assert.Equal(t, "ip:10.0.0.1", client.ID)
+	assert.Equal(t, DefaultTier, client.Tier)
+
+	client, err = limiter.Identify("premium-key", "10.0.0.1:3569")
+	require.NoError(t, err)
+	assert.Equal(t, "premium", client.Tier)
+	assert.NotContains(t, client.ID, "premium-key")
+
+	_, err = limiter.Identify("unknown-key", "10.0.0.1:3569")
+	assert.ErrorIs(t, err, ErrUnknownAPIKey)
+}
+
+// TestLimiter_RequestRate tests that clients are limited independently of each other, and that
+// rate limited requests don't consume tokens.
+func TestLimiter_RequestRate(t *testing.T) {
+	now := time.Now()
+	limiter := newTestLimiter(t, &now)
+
+	client1 := Client{ID: "ip:10.0.0.1", Tier: DefaultTier}
+	client2 := Client{ID: "ip:10.0.0.2", Tier: DefaultTier}
+
+	// the burst is available immediately
+	require.NoError(t, limiter.Allow(client1, false))
+	require.NoError(t, limiter.Allow(client1, false))
+
+	err := limiter.Allow(client1, false)
+	require.True(t, IsRateLimitedError(err))
+	var rateLimitedErr RateLimitedError
+	require.ErrorAs(t, err, &rateLimitedErr)
+	assert.Equal(t, ReasonRequestRate, rateLimitedErr.Reason)
+	assert.Equal(t, time.Second, rateLimitedErr.RetryAfter)
+
+	// other clients of the same tier are not affected
+	require.NoError(t, limiter.Allow(client2, false))
+
+	// after the retry delay, the request is allowed
+	now = now.Add(time.Second)
+	require.NoError(t, limiter.Allow(client1, false))
+	assert.True(t, IsRateLimitedError(limiter.Allow(client1, false)))
+}
+
+// TestLimiter_ScriptCompute tests that scripts are rejected once the daily script compute
+// budget is used up, until the next UTC day.
+func TestLimiter_ScriptCompute(t *testing.T) {
+	now := time.Date(2022, 10, 5, 23, 0, 0, 0, time.UTC)
+	limiter := newTestLimiter(t, &now)
+
+	client, err := limiter.Identify("premium-key", "10.0.0.1:3569")
+	require.NoError(t, err)
+
+	require.NoError(t, limiter.Allow(client, true))
+	limiter.ChargeScriptCompute(client, 600*time.Millisecond)
+	require.NoError(t, limiter.Allow(client, true))
+	limiter.ChargeScriptCompute(client, 600*time.Millisecond)
+
+	err = limiter.Allow(client, true)
+	var rateLimitedErr RateLimitedError
+	require.ErrorAs(t, err, &rateLimitedErr)
+	assert.Equal(t, ReasonScriptCompute, rateLimitedErr.Reason)
+	assert.Equal(t, time.Hour, rateLimitedErr.RetryAfter)
+
+	// other requests are still allowed
+	require.NoError(t, limiter.Allow(client, false))
+
+	// the budget is reset at the start of the next UTC day
+	now = now.Add(time.Hour)
+	require.NoError(t, limiter.Allow(client, true))
+}
+
+// TestLimiter_UnconfiguredTier tests that clients without API key are not limited if the default
+// tier is not configured.
+func TestLimiter_UnconfiguredTier(t *testing.T) {
+	config := testConfig()
+	delete(config.Tiers, DefaultTier)
+	limiter, err := NewLimiter(zerolog.Nop(), config, metrics.NewNoopCollector())
+	require.NoError(t, err)
+
+	client, err := limiter.Identify("", "10.0.0.1:3569")
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.Allow(client, true))
+	}
+}
+
+func TestLimiter_UnaryServerInterceptor(t *testing.T) {
+	now := time.Now()
+	limiter := newTestLimiter(t, &now)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/flow.access.AccessAPI/Ping"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 3569},
+	})
+
+	for i := 0; i < 2; i++ {
+		resp, err := limiter.UnaryServerInterceptor(ctx, nil, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", resp)
+	}
+
+	_, err := limiter.UnaryServerInterceptor(ctx, nil, info, handler)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retryInfo, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, time.Second, retryInfo.RetryDelay.AsDuration())
+
+	// unknown api keys are rejected
+	keyCtx := metadata.NewIncomingContext(ctx, metadata.Pairs(APIKeyMetadataKey, "unknown-key"))
+	_, err = limiter.UnaryServerInterceptor(keyCtx, nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestRetryAfterSeconds(t *testing.T) {
+	assert.Equal(t, "1", RetryAfterSeconds(100*time.Millisecond))
+	assert.Equal(t, "60", RetryAfterSeconds(time.Minute))
+}
@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/onflow/flow-go/engine/access/ratelimit"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+)
+
+// APIKeyHeader is the HTTP header clients present their API key with.
+const APIKeyHeader = "X-API-Key"
+
+// scriptRouteName is the name of the route executing scripts, which are subject to the daily
+// script compute budget.
+const scriptRouteName = "executeScript"
+
+// RateLimitMiddleware creates a middleware which rate limits requests by the client presenting them.
+// Clients are identified by the API key header, or by their source IP address. Rate limited
+// requests are rejected with status 429 and a Retry-After header.
+func RateLimitMiddleware(limiter *ratelimit.Limiter) mux.MiddlewareFunc {
+	return func(inner http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			client, err := limiter.Identify(req.Header.Get(APIKeyHeader), req.RemoteAddr)
+			if err != nil {
+				writeError(w, http.StatusUnauthorized, err.Error())
+				return
+			}
+
+			script := false
+			if route := mux.CurrentRoute(req); route != nil {
+				script = route.GetName() == scriptRouteName
+			}
+
+			err = limiter.Allow(client, script)
+			if err != nil {
+				var rateLimitedErr ratelimit.RateLimitedError
+				if errors.As(err, &rateLimitedErr) {
+					w.Header().Set("Retry-After", ratelimit.RetryAfterSeconds(rateLimitedErr.RetryAfter))
+					writeError(w, http.StatusTooManyRequests, rateLimitedErr.Error())
+					return
+				}
+				writeError(w, http.StatusInternalServerError, "internal server error")
+				return
+			}
+
+			if !script {
+				inner.ServeHTTP(w, req)
+				return
+			}
+
+			start := time.Now()
+			inner.ServeHTTP(w, req)
+			limiter.ChargeScriptCompute(client, time.Since(start))
+		})
+	}
+}
+
+// writeError writes a model error with the given code and message as the response.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	// encoding the model error can't fail
+	encoded, _ := json.Marshal(models.ModelError{
+		Code:    int32(code),
+		Message: message,
+	})
+	_, _ = w.Write(encoded)
+}
@@ -0,0 +1,56 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/access/ratelimit"
+	"github.com/onflow/flow-go/module/metrics"
+)
+
+// TestRateLimitMiddleware tests that requests exceeding the limits of the client are rejected with
+// status 429 and a Retry-After header, and that unknown API keys are rejected.
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter, err := ratelimit.NewLimiter(zerolog.Nop(), ratelimit.Config{
+		Tiers: map[string]ratelimit.TierConfig{
+			ratelimit.DefaultTier: {RequestsPerSecond: 1, Burst: 1},
+			"premium":             {RequestsPerSecond: 100, Burst: 100},
+		},
+		APIKeys: map[string]string{"premium-key": "premium"},
+	}, metrics.NewNoopCollector())
+	require.NoError(t, err)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Use(RateLimitMiddleware(limiter))
+
+	serve := func(apiKey string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = "10.0.0.1:3569"
+		if apiKey != "" {
+			req.Header.Set(APIKeyHeader, apiKey)
+		}
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assert.Equal(t, http.StatusOK, serve("").Code)
+
+	rr := serve("")
+	assert.Equal(t, http.StatusTooManyRequests, rr.Code)
+	assert.Equal(t, "1", rr.Header().Get("Retry-After"))
+	assert.Contains(t, rr.Body.String(), `"code":429`)
+
+	// clients with api key are limited separately from the same source address
+	assert.Equal(t, http.StatusOK, serve("premium-key").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve("unknown-key").Code)
+}
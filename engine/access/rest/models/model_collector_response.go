@@ -0,0 +1,17 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type CollectorResponse struct {
+	CollectorId string `json:"collector_id"`
+	Accepted    bool   `json:"accepted"`
+	// The reason the collection node rejected the transaction, if it was not accepted.
+	RejectionReason string `json:"rejection_reason,omitempty"`
+	Message         string `json:"message,omitempty"`
+}
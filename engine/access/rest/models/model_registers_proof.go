@@ -0,0 +1,19 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type RegistersProof struct {
+	BlockId           string `json:"block_id"`
+	BlockHeight       string `json:"block_height"`
+	ExecutionResultId string `json:"execution_result_id"`
+	// The values of the registers at the final state of the block.
+	Registers []Register `json:"registers"`
+	// Base64 encoded proof of the register values against the final state commitment of the execution result.
+	Proof string `json:"proof"`
+}
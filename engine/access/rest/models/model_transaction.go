@@ -23,6 +23,7 @@ type Transaction struct {
 	PayloadSignatures  []TransactionSignature `json:"payload_signatures"`
 	EnvelopeSignatures []TransactionSignature `json:"envelope_signatures"`
 	Result             *TransactionResult     `json:"result,omitempty"`
+	Submission         *TransactionSubmission `json:"submission,omitempty"`
 	Expandable         *TransactionExpandable `json:"_expandable"`
 	Links              *Links                 `json:"_links,omitempty"`
 }
@@ -0,0 +1,17 @@
+/*
+ * Access API
+ *
+ * No description provided (generated by Swagger Codegen https://github.com/swagger-api/swagger-codegen)
+ *
+ * API version: 1.0.0
+ * Generated by: Swagger Codegen (https://github.com/swagger-api/swagger-codegen.git)
+ */
+package models
+
+type TransactionSubmission struct {
+	// The cluster of collection nodes responsible for the transaction.
+	ClusterId string `json:"cluster_id"`
+	// The collection nodes selected to receive the transaction, in the order they were tried.
+	Collectors []string            `json:"collectors"`
+	Responses  []CollectorResponse `json:"responses"`
+}
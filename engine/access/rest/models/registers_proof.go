@@ -0,0 +1,23 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+)
+
+func (r *RegistersProof) Build(proof *access.RegistersWithProof) {
+	registers := make([]Register, len(proof.Registers))
+	for i, entry := range proof.Registers {
+		registers[i] = Register{
+			Owner: util.ToBase64([]byte(entry.Key.Owner)),
+			Key:   util.ToBase64([]byte(entry.Key.Key)),
+			Value: util.ToBase64(entry.Value),
+		}
+	}
+
+	r.BlockId = proof.BlockID.String()
+	r.BlockHeight = util.FromUint64(proof.BlockHeight)
+	r.ExecutionResultId = proof.ExecutionResultID.String()
+	r.Registers = registers
+	r.Proof = util.ToBase64(proof.Proof)
+}
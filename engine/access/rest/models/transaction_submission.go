@@ -0,0 +1,32 @@
+package models
+
+import (
+	"github.com/onflow/flow-go/access"
+)
+
+func (s *TransactionSubmission) Build(receipt *access.TransactionSubmissionReceipt) {
+	collectors := make([]string, len(receipt.Collectors))
+	for i, collectorID := range receipt.Collectors {
+		collectors[i] = collectorID.String()
+	}
+
+	responses := make([]CollectorResponse, len(receipt.Responses))
+	for i, response := range receipt.Responses {
+		var collectorResponse CollectorResponse
+		collectorResponse.Build(response)
+		responses[i] = collectorResponse
+	}
+
+	s.ClusterId = receipt.ClusterID.String()
+	s.Collectors = collectors
+	s.Responses = responses
+}
+
+func (r *CollectorResponse) Build(response access.CollectorResponse) {
+	r.CollectorId = response.CollectorID.String()
+	r.Accepted = response.Accepted
+	if !response.Accepted {
+		r.RejectionReason = response.Reason.String()
+	}
+	r.Message = response.Message
+}
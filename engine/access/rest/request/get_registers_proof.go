@@ -0,0 +1,72 @@
+package request
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+const MaxRegistersLength = 1000
+
+type registerBody struct {
+	Owner string `json:"owner"`
+	Key   string `json:"key"`
+}
+
+type registersBody struct {
+	Registers []registerBody `json:"registers"`
+}
+
+type GetRegistersProof struct {
+	BlockID   flow.Identifier
+	Registers []flow.RegisterID
+}
+
+func (g *GetRegistersProof) Build(r *Request) error {
+	return g.Parse(
+		r.GetQueryParam(blockIDQuery),
+		r.Body,
+	)
+}
+
+func (g *GetRegistersProof) Parse(rawID string, rawRegisters io.Reader) error {
+	var id ID
+	err := id.Parse(rawID)
+	if err != nil {
+		return err
+	}
+	if id.Flow() == flow.ZeroID {
+		return fmt.Errorf("block ID must be provided")
+	}
+	g.BlockID = id.Flow()
+
+	var body registersBody
+	err = parseBody(rawRegisters, &body)
+	if err != nil {
+		return err
+	}
+	if len(body.Registers) == 0 {
+		return fmt.Errorf("at least one register must be provided")
+	}
+	if len(body.Registers) > MaxRegistersLength {
+		return fmt.Errorf("at most %d registers can be requested at once", MaxRegistersLength)
+	}
+
+	registers := make([]flow.RegisterID, len(body.Registers))
+	for i, register := range body.Registers {
+		owner, err := util.FromBase64(register.Owner)
+		if err != nil {
+			return fmt.Errorf("invalid register owner encoding")
+		}
+		key, err := util.FromBase64(register.Key)
+		if err != nil {
+			return fmt.Errorf("invalid register key encoding")
+		}
+		registers[i] = flow.NewRegisterID(string(owner), string(key))
+	}
+	g.Registers = registers
+
+	return nil
+}
@@ -7,17 +7,21 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/ratelimit"
 	"github.com/onflow/flow-go/engine/access/rest/middleware"
 	"github.com/onflow/flow-go/engine/access/rest/models"
 	"github.com/onflow/flow-go/model/flow"
 )
 
-func newRouter(backend access.API, logger zerolog.Logger, chain flow.Chain) (*mux.Router, error) {
+func newRouter(backend access.API, logger zerolog.Logger, chain flow.Chain, limiter *ratelimit.Limiter) (*mux.Router, error) {
 	router := mux.NewRouter().StrictSlash(true)
 	v1SubRouter := router.PathPrefix("/v1").Subrouter()
 
 	// common middleware for all request
 	v1SubRouter.Use(middleware.LoggingMiddleware(logger))
+	if limiter != nil {
+		v1SubRouter.Use(middleware.RateLimitMiddleware(limiter))
+	}
 	v1SubRouter.Use(middleware.QueryExpandable())
 	v1SubRouter.Use(middleware.QuerySelect())
 
@@ -96,6 +100,16 @@ var Routes = []route{{
 	Pattern: "/accounts/{address}",
 	Name:    "getAccount",
 	Handler: GetAccount,
+}, {
+	Method:  http.MethodGet,
+	Pattern: "/accounts/{address}/proof",
+	Name:    "getAccountWithProof",
+	Handler: GetAccountWithProof,
+}, {
+	Method:  http.MethodPost,
+	Pattern: "/registers/proof",
+	Name:    "getRegistersWithProof",
+	Handler: GetRegistersWithProof,
 }, {
 	Method:  http.MethodGet,
 	Pattern: "/events",
@@ -0,0 +1,52 @@
+package rest
+
+import (
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine/access/rest/models"
+	"github.com/onflow/flow-go/engine/access/rest/request"
+)
+
+// GetAccountWithProof handler retrieves the registers of an account by address together with
+// a proof against the sealed execution result of the requested block
+func GetAccountWithProof(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetAccountRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	// in case we receive special height values 'final' and 'sealed', fetch that height and overwrite request with it
+	if req.Height == request.FinalHeight || req.Height == request.SealedHeight {
+		header, _, err := backend.GetLatestBlockHeader(r.Context(), req.Height == request.SealedHeight)
+		if err != nil {
+			return nil, err
+		}
+		req.Height = header.Height
+	}
+
+	proof, err := backend.GetAccountWithProof(r.Context(), req.Address, req.Height)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.RegistersProof
+	response.Build(proof)
+	return response, nil
+}
+
+// GetRegistersWithProof handler retrieves register values of a block together with a proof
+// against the sealed execution result of the block
+func GetRegistersWithProof(r *request.Request, backend access.API, _ models.LinkGenerator) (interface{}, error) {
+	req, err := r.GetRegistersProofRequest()
+	if err != nil {
+		return nil, NewBadRequestError(err)
+	}
+
+	proof, err := backend.GetRegistersWithProof(r.Context(), req.BlockID, req.Registers)
+	if err != nil {
+		return nil, err
+	}
+
+	var response models.RegistersProof
+	response.Build(proof)
+	return response, nil
+}
@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	mocktestify "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/mock"
+	"github.com/onflow/flow-go/engine/access/rest/util"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func accountProofURL(t *testing.T, address string, height string) string {
+	u, err := url.ParseRequestURI(fmt.Sprintf("/v1/accounts/%s/proof", address))
+	require.NoError(t, err)
+	q := u.Query()
+
+	if height != "" {
+		q.Add("block_height", height)
+	}
+
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func registersProofReq(t *testing.T, id string, body interface{}) *http.Request {
+	u, err := url.ParseRequestURI("/v1/registers/proof")
+	require.NoError(t, err)
+	q := u.Query()
+
+	if id != "" {
+		q.Add("block_id", id)
+	}
+
+	u.RawQuery = q.Encode()
+
+	jsonBody, err := json.Marshal(body)
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", u.String(), bytes.NewBuffer(jsonBody))
+	require.NoError(t, err)
+	return req
+}
+
+func registersWithProofFixture(registers ...flow.RegisterEntry) *access.RegistersWithProof {
+	return &access.RegistersWithProof{
+		BlockID:           unittest.IdentifierFixture(),
+		BlockHeight:       100,
+		ExecutionResultID: unittest.IdentifierFixture(),
+		Registers:         registers,
+		Proof:             []byte("proof"),
+	}
+}
+
+func expectedRegistersProofResponse(proof *access.RegistersWithProof) string {
+	return fmt.Sprintf(`{
+		"block_id":"%s",
+		"block_height":"100",
+		"execution_result_id":"%s",
+		"registers":[{"owner":"b3duZXI=","key":"a2V5","value":"dmFsdWU="}],
+		"proof":"cHJvb2Y="
+	}`, proof.BlockID, proof.ExecutionResultID)
+}
+
+func TestGetAccountWithProof(t *testing.T) {
+	register := flow.RegisterEntry{Key: flow.NewRegisterID("owner", "key"), Value: []byte("value")}
+
+	t.Run("get by address at latest sealed block", func(t *testing.T) {
+		backend := &mock.API{}
+		address := unittest.AddressFixture()
+		block := unittest.BlockHeaderFixture(unittest.WithHeaderHeight(100))
+		proof := registersWithProofFixture(register)
+
+		backend.Mock.
+			On("GetLatestBlockHeader", mocktestify.Anything, true).
+			Return(block, flow.BlockStatusSealed, nil)
+		backend.Mock.
+			On("GetAccountWithProof", mocktestify.Anything, address, uint64(100)).
+			Return(proof, nil)
+
+		req, err := http.NewRequest("GET", accountProofURL(t, address.String(), sealedHeightQueryParam), nil)
+		require.NoError(t, err)
+
+		assertOKResponse(t, req, expectedRegistersProofResponse(proof), backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get by address at unsealed height", func(t *testing.T) {
+		backend := &mock.API{}
+		address := unittest.AddressFixture()
+
+		backend.Mock.
+			On("GetAccountWithProof", mocktestify.Anything, address, uint64(1337)).
+			Return(nil, status.Error(codes.InvalidArgument, "block is not sealed"))
+
+		req, err := http.NewRequest("GET", accountProofURL(t, address.String(), "1337"), nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"Invalid Flow argument: block is not sealed"}`, backend)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		backend := &mock.API{}
+		req, err := http.NewRequest("GET", accountProofURL(t, "123", ""), nil)
+		require.NoError(t, err)
+
+		assertResponse(t, req, http.StatusBadRequest, `{"code":400, "message":"invalid address"}`, backend)
+	})
+}
+
+func TestGetRegistersWithProof(t *testing.T) {
+	register := flow.RegisterEntry{Key: flow.NewRegisterID("owner", "key"), Value: []byte("value")}
+	validBody := map[string]interface{}{
+		"registers": []map[string]string{{
+			"owner": util.ToBase64([]byte("owner")),
+			"key":   util.ToBase64([]byte("key")),
+		}},
+	}
+
+	t.Run("get by block ID", func(t *testing.T) {
+		backend := &mock.API{}
+		proof := registersWithProofFixture(register)
+
+		backend.Mock.
+			On("GetRegistersWithProof", mocktestify.Anything, proof.BlockID, []flow.RegisterID{register.Key}).
+			Return(proof, nil)
+
+		req := registersProofReq(t, proof.BlockID.String(), validBody)
+
+		assertOKResponse(t, req, expectedRegistersProofResponse(proof), backend)
+		mocktestify.AssertExpectationsForObjects(t, backend)
+	})
+
+	t.Run("get invalid", func(t *testing.T) {
+		backend := &mock.API{}
+		id := unittest.IdentifierFixture().String()
+
+		tests := []struct {
+			req *http.Request
+			out string
+		}{
+			{registersProofReq(t, "", validBody), `{"code":400, "message":"block ID must be provided"}`},
+			{registersProofReq(t, id, map[string]interface{}{"registers": []string{}}), `{"code":400, "message":"at least one register must be provided"}`},
+			{registersProofReq(t, id, map[string]interface{}{
+				"registers": []map[string]string{{"owner": "!", "key": ""}},
+			}), `{"code":400, "message":"invalid register owner encoding"}`},
+		}
+
+		for _, test := range tests {
+			assertResponse(t, test.req, http.StatusBadRequest, test.out, backend)
+		}
+	})
+}
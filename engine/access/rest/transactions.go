@@ -56,12 +56,19 @@ func CreateTransaction(r *request.Request, backend access.API, link models.LinkG
 		return nil, NewBadRequestError(err)
 	}
 
-	err = backend.SendTransaction(r.Context(), &req.Transaction)
+	receipt, err := backend.SendTransaction(r.Context(), &req.Transaction)
 	if err != nil {
 		return nil, err
 	}
 
 	var response models.Transaction
 	response.Build(&req.Transaction, nil, link)
+
+	// report which cluster and collection nodes the transaction was routed to
+	if receipt != nil {
+		var submission models.TransactionSubmission
+		submission.Build(receipt)
+		response.Submission = &submission
+	}
 	return response, nil
 }
@@ -9,7 +9,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	mocks "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
@@ -331,7 +333,7 @@ func TestCreateTransaction(t *testing.T) {
 
 		backend.Mock.
 			On("SendTransaction", mocks.Anything, &tx).
-			Return(nil)
+			Return(nil, nil)
 
 		expected := fmt.Sprintf(`
 			{
@@ -374,6 +376,45 @@ func TestCreateTransaction(t *testing.T) {
 		assertOKResponse(t, req, expected, backend)
 	})
 
+	t.Run("create with submission receipt", func(t *testing.T) {
+		backend := &mock.API{}
+		tx := unittest.TransactionBodyFixture()
+		tx.PayloadSignatures = []flow.TransactionSignature{unittest.TransactionSignatureFixture()}
+		tx.Arguments = [][]uint8{}
+		req := createTransactionReq(validCreateBody(tx))
+
+		collectors := unittest.IdentifierListFixture(2)
+		receipt := &access.TransactionSubmissionReceipt{
+			TransactionID: tx.ID(),
+			ClusterID:     "cluster-1",
+			Collectors:    collectors,
+			Responses: []access.CollectorResponse{
+				{CollectorID: collectors[0], Reason: access.RejectionReasonRateLimited, Message: "rate limited"},
+				{CollectorID: collectors[1], Accepted: true},
+			},
+		}
+		backend.Mock.
+			On("SendTransaction", mocks.Anything, &tx).
+			Return(receipt, nil)
+
+		rr, err := executeRequest(req, backend)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, rr.Code)
+
+		var response struct {
+			Submission models.TransactionSubmission `json:"submission"`
+		}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &response))
+		assert.Equal(t, models.TransactionSubmission{
+			ClusterId:  "cluster-1",
+			Collectors: []string{collectors[0].String(), collectors[1].String()},
+			Responses: []models.CollectorResponse{
+				{CollectorId: collectors[0].String(), RejectionReason: "rate_limited", Message: "rate limited"},
+				{CollectorId: collectors[1].String(), Accepted: true},
+			},
+		}, response.Submission)
+	})
+
 	t.Run("post invalid transaction", func(t *testing.T) {
 		backend := &mock.API{}
 		tests := []struct {
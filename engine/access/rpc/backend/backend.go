@@ -3,6 +3,7 @@ package backend
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -39,6 +40,9 @@ const DefaultSnapshotHistoryLimit = 50
 // limiting cache size to 16MB and does not affect script execution, only for keeping logs tidy
 const DefaultLoggedScriptsCacheSize = 1_000_000
 
+// DefaultRegisterProofTimeout is the timeout for reading registers with proofs from an execution node
+const DefaultRegisterProofTimeout = 10 * time.Second
+
 // DefaultConnectionPoolSize is the default size for the connection pool to collection and execution nodes
 const DefaultConnectionPoolSize = 250
 
@@ -55,6 +59,7 @@ var fixedENIdentifiers flow.IdentifierList
 // Block details related calls are handled by backendBlockDetails.
 // Event related calls are handled by backendEvents.
 // Account related calls are handled by backendAccounts.
+// Verifiable register reads are handled by backendStateProofs.
 //
 // All remaining calls are handled by the base Backend in this file.
 type Backend struct {
@@ -66,6 +71,7 @@ type Backend struct {
 	backendAccounts
 	backendExecutionResults
 	backendNetwork
+	backendStateProofs
 
 	state             protocol.State
 	chainID           flow.ChainID
@@ -93,6 +99,8 @@ func New(
 	fixedExecutionNodeIDs []string,
 	log zerolog.Logger,
 	snapshotHistoryLimit int,
+	registerProofPort uint,
+	registerProofToken string,
 ) *Backend {
 	retry := newRetry()
 	if retryEnabled {
@@ -104,6 +112,11 @@ func New(
 		log.Fatal().Err(err).Msg("failed to initialize script logging cache")
 	}
 
+	submissions, err := newSubmissionReceipts(DefaultSubmissionReceiptsCacheSize)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to initialize submission receipts cache")
+	}
+
 	b := &Backend{
 		state: state,
 		// create the sub-backends
@@ -128,6 +141,7 @@ func New(
 			transactionMetrics:   transactionMetrics,
 			retry:                retry,
 			connFactory:          connFactory,
+			submissions:          submissions,
 			previousAccessNodes:  historicalAccessNodes,
 			log:                  log,
 		},
@@ -162,6 +176,16 @@ func New(
 			chainID:              chainID,
 			snapshotHistoryLimit: snapshotHistoryLimit,
 		},
+		backendStateProofs: backendStateProofs{
+			state:              state,
+			headers:            headers,
+			executionReceipts:  executionReceipts,
+			executionResults:   executionResults,
+			httpClient:         &http.Client{Timeout: DefaultRegisterProofTimeout},
+			registerProofPort:  registerProofPort,
+			registerProofToken: registerProofToken,
+			log:                log,
+		},
 		collections:       collections,
 		executionReceipts: executionReceipts,
 		connFactory:       connFactory,
@@ -0,0 +1,178 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/access/stateproof"
+	"github.com/onflow/flow-go/engine/common/rpc"
+	"github.com/onflow/flow-go/engine/execution/registers"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// backendStateProofs serves register values together with proofs against the state commitment
+// of sealed execution results. The registers and proofs are read from the register proof server
+// of execution nodes, and verified before they are returned.
+type backendStateProofs struct {
+	state              protocol.State
+	headers            storage.Headers
+	executionReceipts  storage.ExecutionReceipts
+	executionResults   storage.ExecutionResults
+	httpClient         *http.Client
+	registerProofPort  uint   // port of the register proof server of execution nodes, 0 if disabled
+	registerProofToken string // bearer token presented to the register proof server of execution nodes
+	log                zerolog.Logger
+}
+
+// GetRegistersWithProof returns the values of the given registers at the final state of the
+// given sealed block, together with a proof.
+func (b *backendStateProofs) GetRegistersWithProof(
+	ctx context.Context,
+	blockID flow.Identifier,
+	registerIDs []flow.RegisterID,
+) (*access.RegistersWithProof, error) {
+	if len(registerIDs) == 0 || len(registerIDs) > registers.MaxRegistersPerRequest {
+		return nil, status.Errorf(codes.InvalidArgument, "between 1 and %d registers must be requested", registers.MaxRegistersPerRequest)
+	}
+
+	header, err := b.headers.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	return b.readWithProof(ctx, header, func(client *registers.Client) (*registers.Response, error) {
+		return client.Registers(ctx, blockID, registerIDs)
+	})
+}
+
+// GetAccountWithProof returns the values of the registers storing the keys and contracts of
+// the given account at the final state of the sealed block at the given height, together
+// with a proof.
+func (b *backendStateProofs) GetAccountWithProof(
+	ctx context.Context,
+	address flow.Address,
+	height uint64,
+) (*access.RegistersWithProof, error) {
+	header, err := b.headers.ByHeight(height)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+
+	return b.readWithProof(ctx, header, func(client *registers.Client) (*registers.Response, error) {
+		return client.Account(ctx, header.ID(), address)
+	})
+}
+
+// readWithProof reads registers with a proof at the final state of the given block from any
+// execution node which executed the block.
+func (b *backendStateProofs) readWithProof(
+	ctx context.Context,
+	header *flow.Header,
+	read func(client *registers.Client) (*registers.Response, error),
+) (*access.RegistersWithProof, error) {
+	if b.registerProofPort == 0 {
+		return nil, status.Error(codes.Unimplemented, "reading registers with proofs is not enabled")
+	}
+
+	sealed, err := b.state.Sealed().Head()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get latest sealed header: %v", err)
+	}
+	blockID := header.ID()
+	if header.Height > sealed.Height {
+		return nil, status.Errorf(codes.InvalidArgument, "block %v at height %d is not sealed", blockID, header.Height)
+	}
+
+	result, err := b.executionResults.ByBlockID(blockID)
+	if err != nil {
+		return nil, rpc.ConvertStorageError(err)
+	}
+	commit, err := result.FinalStateCommitment()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get final state commitment of result %v: %v", result.ID(), err)
+	}
+
+	execNodes, err := executionNodesForBlockID(ctx, blockID, b.executionReceipts, b.state, b.log)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to find execution nodes for block %v: %v", blockID, err)
+	}
+
+	var errors *multierror.Error
+	for _, execNode := range execNodes {
+		start := time.Now()
+		resp, err := b.tryReadWithProof(execNode, commit, read)
+		duration := time.Since(start)
+		if err == nil {
+			b.log.Debug().
+				Str("execution_node", execNode.String()).
+				Hex("block_id", blockID[:]).
+				Int64("rtt_ms", duration.Milliseconds()).
+				Msg("successfully read registers with proof")
+
+			return &access.RegistersWithProof{
+				BlockID:           blockID,
+				BlockHeight:       header.Height,
+				ExecutionResultID: result.ID(),
+				Registers:         resp.Entries(),
+				Proof:             resp.Proof,
+			}, nil
+		}
+		b.log.Error().
+			Str("execution_node", execNode.String()).
+			Hex("block_id", blockID[:]).
+			Int64("rtt_ms", duration.Milliseconds()).
+			Err(err).
+			Msg("failed to read registers with proof")
+		errors = multierror.Append(errors, err)
+	}
+
+	return nil, status.Errorf(codes.Internal, "failed to read registers with proof from the execution nodes: %v", errors.ErrorOrNil())
+}
+
+// tryReadWithProof reads registers with a proof from the given execution node, and verifies
+// the proof against the given sealed state commitment, so that clients don't receive invalid
+// proofs from faulty execution nodes.
+func (b *backendStateProofs) tryReadWithProof(
+	execNode *flow.Identity,
+	commit flow.StateCommitment,
+	read func(client *registers.Client) (*registers.Response, error),
+) (*registers.Response, error) {
+	address, err := getGRPCAddress(execNode.Address, b.registerProofPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address of execution node: %w", err)
+	}
+
+	resp, err := read(registers.NewClient(b.httpClient, "http://"+address, b.registerProofToken))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StateCommitment != commit {
+		return nil, fmt.Errorf("registers read at state %x, expected sealed state %x", resp.StateCommitment, commit)
+	}
+
+	proven, err := stateproof.VerifyCommitment(commit, resp.Proof)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range resp.Entries() {
+		value, err := proven.Get(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		if string(value) != string(entry.Value) {
+			return nil, fmt.Errorf("value of register %v does not match proof", entry.Key)
+		}
+	}
+
+	return resp, nil
+}
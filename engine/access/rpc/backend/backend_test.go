@@ -19,17 +19,21 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	flowaccess "github.com/onflow/flow-go/access"
 	access "github.com/onflow/flow-go/engine/access/mock"
 	backendmock "github.com/onflow/flow-go/engine/access/rpc/backend/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/factory"
 	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/state/cluster"
 	bprotocol "github.com/onflow/flow-go/state/protocol/badger"
 	protocol "github.com/onflow/flow-go/state/protocol/mock"
 	"github.com/onflow/flow-go/state/protocol/util"
 	"github.com/onflow/flow-go/storage"
 	storagemock "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
+	"github.com/onflow/flow-go/utils/unittest/mocks"
 )
 
 type Suite struct {
@@ -106,6 +110,8 @@ func (suite *Suite) TestPing() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	err := backend.Ping(context.Background())
@@ -140,6 +146,8 @@ func (suite *Suite) TestGetLatestFinalizedBlockHeader() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// query the handler for the latest finalized block
@@ -204,6 +212,8 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_NoTransitionSpan() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// query the handler for the latest finalized snapshot
@@ -275,6 +285,8 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_TransitionSpans() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// query the handler for the latest finalized snapshot
@@ -339,6 +351,8 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_PhaseTransitionSpan() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// query the handler for the latest finalized snapshot
@@ -414,6 +428,8 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_EpochTransitionSpan() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// query the handler for the latest finalized snapshot
@@ -473,6 +489,7 @@ func (suite *Suite) TestGetLatestProtocolStateSnapshot_HistoryLimit() {
 			nil,
 			suite.log,
 			snapshotHistoryLimit,
+			0,
 		)
 
 		// the handler should return a snapshot history limit error
@@ -510,6 +527,8 @@ func (suite *Suite) TestGetLatestSealedBlockHeader() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// query the handler for the latest sealed block
@@ -555,6 +574,8 @@ func (suite *Suite) TestGetTransaction() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	actual, err := backend.GetTransaction(context.Background(), transaction.ID())
@@ -594,6 +615,8 @@ func (suite *Suite) TestGetCollection() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	actual, err := backend.GetCollectionByID(context.Background(), expected.ID())
@@ -656,6 +679,8 @@ func (suite *Suite) TestGetTransactionResultByIndex() {
 		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 	suite.execClient.
 		On("GetTransactionResultByIndex", ctx, &exeEventReq).
@@ -718,6 +743,8 @@ func (suite *Suite) TestGetTransactionResultsByBlockID() {
 		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 	suite.execClient.
 		On("GetTransactionResultsByBlockID", ctx, &exeEventReq).
@@ -803,6 +830,8 @@ func (suite *Suite) TestTransactionStatusTransition() {
 		flow.IdentifierList(fixedENIDs.NodeIDs()).Strings(),
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// Successfully return empty event list
@@ -922,6 +951,8 @@ func (suite *Suite) TestTransactionExpiredStatusTransition() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// should return pending status when we have not observed an expiry block
@@ -1080,6 +1111,8 @@ func (suite *Suite) TestTransactionPendingToFinalizedStatusTransition() {
 		flow.IdentifierList(enIDs.NodeIDs()).Strings(),
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
@@ -1137,6 +1170,8 @@ func (suite *Suite) TestTransactionResultUnknown() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// first call - when block under test is greater height than the sealed head, but execution node does not know about Tx
@@ -1147,6 +1182,182 @@ func (suite *Suite) TestTransactionResultUnknown() {
 	suite.Assert().Equal(flow.TransactionStatusUnknown, result.Status)
 }
 
+// TestSendTransactionReceipt tests that the receipt of a submitted transaction reports the cluster
+// responsible for the transaction, and the collection nodes it was routed to.
+func (suite *Suite) TestSendTransactionReceipt() {
+	ctx := context.Background()
+	tx, clusters := suite.setupTransactionSubmission()
+
+	suite.colClient.
+		On("SendTransaction", mock.Anything, mock.Anything).
+		Return(&accessproto.SendTransactionResponse{}, nil).
+		Once()
+	suite.transactions.On("Store", &tx).Return(nil).Once()
+
+	backend := suite.transactionSubmissionBackend()
+
+	receipt, err := backend.SendTransaction(ctx, &tx)
+	suite.Require().NoError(err)
+
+	suite.Assert().Equal(tx.ID(), receipt.TransactionID)
+	suite.Assert().Equal(cluster.CanonicalClusterID(1, clusters[0]), receipt.ClusterID)
+	suite.Assert().ElementsMatch(clusters[0].NodeIDs(), receipt.Collectors)
+	suite.Require().Len(receipt.Responses, 1)
+	suite.Assert().True(receipt.Responses[0].Accepted)
+	suite.Assert().Equal(receipt.Collectors[0], receipt.Responses[0].CollectorID)
+	suite.Assert().True(receipt.Accepted())
+
+	suite.colClient.AssertExpectations(suite.T())
+	suite.transactions.AssertExpectations(suite.T())
+}
+
+// TestSendTransactionRejected tests that a transaction rejected by all collection nodes for the same
+// reason preventing it from ever being included is reported as terminal.
+func (suite *Suite) TestSendTransactionRejected() {
+	ctx := context.Background()
+	tx, _ := suite.setupTransactionSubmission()
+
+	rejection := flowaccess.NewRejectionStatusError(
+		codes.InvalidArgument,
+		flowaccess.RejectionReasonExpired,
+		flowaccess.ExpiredTransactionError{},
+	)
+	suite.colClient.
+		On("SendTransaction", mock.Anything, mock.Anything).
+		Return(nil, rejection).
+		Times(int(collectionNodesToTry))
+	suite.transactions.On("ByID", tx.ID()).Return(nil, storage.ErrNotFound)
+
+	backend := suite.transactionSubmissionBackend()
+
+	_, err := backend.SendTransaction(ctx, &tx)
+	suite.Require().Error(err)
+	suite.Assert().Equal(codes.InvalidArgument, status.Code(err))
+
+	// the rejected transaction is not stored, but its result reports it as dropped
+	result, err := backend.GetTransactionResult(ctx, tx.ID())
+	suite.Require().NoError(err)
+	suite.Assert().Equal(flow.TransactionStatusExpired, result.Status)
+	suite.Assert().Contains(result.ErrorMessage, flowaccess.RejectionReasonExpired.String())
+
+	suite.colClient.AssertExpectations(suite.T())
+	suite.transactions.AssertNotCalled(suite.T(), "Store", mock.Anything)
+}
+
+// TestSendTransactionRejectedBySingleCollector tests that a terminal rejection by a single collection
+// node doesn't prevent the transaction from being sent to the other collection nodes, and that the
+// transaction is not reported as dropped unless all collection nodes agree.
+func (suite *Suite) TestSendTransactionRejectedBySingleCollector() {
+	tx, _ := suite.setupTransactionSubmission()
+
+	expired := flowaccess.NewRejectionStatusError(
+		codes.InvalidArgument,
+		flowaccess.RejectionReasonExpired,
+		flowaccess.ExpiredTransactionError{},
+	)
+	insufficientBalance := flowaccess.NewRejectionStatusError(
+		codes.InvalidArgument,
+		flowaccess.RejectionReasonAccountState,
+		flowaccess.InsufficientBalanceError{},
+	)
+
+	suite.Run("accepted by another collector", func() {
+		suite.colClient.
+			On("SendTransaction", mock.Anything, mock.Anything).
+			Return(nil, expired).
+			Once()
+		suite.colClient.
+			On("SendTransaction", mock.Anything, mock.Anything).
+			Return(&accessproto.SendTransactionResponse{}, nil).
+			Once()
+		suite.transactions.On("Store", &tx).Return(nil).Once()
+
+		receipt, err := suite.transactionSubmissionBackend().SendTransaction(context.Background(), &tx)
+		suite.Require().NoError(err)
+		suite.Require().Len(receipt.Responses, 2)
+		suite.Assert().Equal(flowaccess.RejectionReasonExpired, receipt.Responses[0].Reason)
+		suite.Assert().True(receipt.Accepted())
+		suite.Assert().False(receipt.Dropped())
+	})
+
+	suite.Run("rejected for different reasons", func() {
+		suite.colClient.
+			On("SendTransaction", mock.Anything, mock.Anything).
+			Return(nil, expired).
+			Once()
+		suite.colClient.
+			On("SendTransaction", mock.Anything, mock.Anything).
+			Return(nil, insufficientBalance).
+			Times(int(collectionNodesToTry) - 1)
+		suite.transactions.On("ByID", tx.ID()).Return(nil, storage.ErrNotFound).Once()
+
+		backend := suite.transactionSubmissionBackend()
+		_, err := backend.SendTransaction(context.Background(), &tx)
+		suite.Require().Error(err)
+		suite.Assert().Equal(codes.Internal, status.Code(err))
+
+		// the collection nodes don't agree, so the transaction is not reported as dropped
+		result, err := backend.GetTransactionResult(context.Background(), tx.ID())
+		suite.Require().NoError(err)
+		suite.Assert().Equal(flow.TransactionStatusUnknown, result.Status)
+	})
+
+	suite.colClient.AssertExpectations(suite.T())
+	suite.transactions.AssertExpectations(suite.T())
+}
+
+// setupTransactionSubmission sets up the protocol state for submitting a valid transaction to a
+// single cluster of collection nodes, served by suite.colClient.
+func (suite *Suite) setupTransactionSubmission() (flow.TransactionBody, flow.ClusterList) {
+	referenceBlock := unittest.BlockHeaderFixture()
+	tx := unittest.TransactionBodyFixture()
+	tx.ReferenceBlockID = referenceBlock.ID()
+
+	collectors := unittest.IdentityListFixture(3, unittest.WithRole(flow.RoleCollection))
+	clusters, err := factory.NewClusterList(unittest.ClusterAssignment(1, collectors), collectors)
+	suite.Require().NoError(err)
+
+	epoch := new(protocol.Epoch)
+	epoch.On("Counter").Return(uint64(1), nil)
+	epoch.On("Clustering").Return(clusters, nil)
+
+	suite.state.On("AtBlockID", referenceBlock.ID()).Return(suite.snapshot)
+	suite.state.On("Final").Return(suite.snapshot)
+	suite.snapshot.On("Head").Return(referenceBlock, nil)
+	suite.snapshot.On("Epochs").Return(mocks.NewEpochQuery(suite.T(), 1, epoch))
+
+	suite.connectionFactory.On("GetAccessAPIClient", mock.Anything).Return(suite.colClient, &mockCloser{}, nil)
+
+	return tx, clusters
+}
+
+// transactionSubmissionBackend returns a backend sending transactions to the collection nodes
+// of the current epoch.
+func (suite *Suite) transactionSubmissionBackend() *Backend {
+	return New(
+		suite.state,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		suite.transactions,
+		nil,
+		nil,
+		suite.chainID,
+		metrics.NewNoopCollector(),
+		suite.connectionFactory,
+		false,
+		DefaultMaxHeightRange,
+		nil,
+		nil,
+		suite.log,
+		DefaultSnapshotHistoryLimit,
+		0,
+		"",
+	)
+}
+
 func (suite *Suite) TestGetLatestFinalizedBlock() {
 	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
 	suite.state.On("Final").Return(suite.snapshot, nil).Maybe()
@@ -1190,6 +1401,8 @@ func (suite *Suite) TestGetLatestFinalizedBlock() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// query the handler for the latest finalized header
@@ -1319,6 +1532,8 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// execute request
@@ -1350,6 +1565,8 @@ func (suite *Suite) TestGetEventsForBlockIDs() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// execute request with an empty block id list and expect an empty list of events and no error
@@ -1408,6 +1625,8 @@ func (suite *Suite) TestGetExecutionResultByID() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// execute request
@@ -1437,6 +1656,8 @@ func (suite *Suite) TestGetExecutionResultByID() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// execute request
@@ -1499,6 +1720,8 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// execute request
@@ -1529,6 +1752,8 @@ func (suite *Suite) TestGetExecutionResultByBlockID() {
 			validENIDs.Strings(), // set the fixed EN Identifiers to the generated execution IDs
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// execute request
@@ -1678,6 +1903,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			nil,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), maxHeight, minHeight)
@@ -1716,6 +1943,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			fixedENIdentifiersStr,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		// execute request
@@ -1753,6 +1982,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			fixedENIdentifiersStr,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		actualResp, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), minHeight, maxHeight)
@@ -1789,6 +2020,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			fixedENIdentifiersStr,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), minHeight, minHeight+1)
@@ -1825,6 +2058,8 @@ func (suite *Suite) TestGetEventsForHeightRange() {
 			fixedENIdentifiersStr,
 			suite.log,
 			DefaultSnapshotHistoryLimit,
+			0,
+			"",
 		)
 
 		_, err := backend.GetEventsForHeightRange(ctx, string(flow.EventAccountCreated), minHeight, maxHeight)
@@ -1901,6 +2136,8 @@ func (suite *Suite) TestGetAccount() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
@@ -1981,6 +2218,8 @@ func (suite *Suite) TestGetAccountAtBlockHeight() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	preferredENIdentifiers = flow.IdentifierList{receipts[0].ExecutorID}
@@ -2018,6 +2257,8 @@ func (suite *Suite) TestGetNetworkParameters() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	params := backend.GetNetworkParameters(context.Background())
@@ -2025,6 +2266,51 @@ func (suite *Suite) TestGetNetworkParameters() {
 	suite.Require().Equal(expectedChainID, params.ChainID)
 }
 
+// TestGetAccountWithProof tests that reads with proofs are rejected if they are not enabled,
+// or if the requested block is not sealed.
+func (suite *Suite) TestGetAccountWithProof() {
+	suite.state.On("Sealed").Return(suite.snapshot, nil).Maybe()
+
+	sealed := unittest.BlockHeaderFixture()
+	unsealed := unittest.BlockHeaderWithParentFixture(sealed)
+	suite.snapshot.On("Head").Return(sealed, nil)
+	suite.headers.On("ByHeight", unsealed.Height).Return(unsealed, nil)
+
+	newBackend := func(registerProofPort uint) *Backend {
+		return New(suite.state,
+			nil,
+			nil,
+			nil,
+			suite.headers,
+			nil,
+			nil,
+			suite.receipts,
+			suite.results,
+			suite.chainID,
+			metrics.NewNoopCollector(),
+			nil,
+			false,
+			DefaultMaxHeightRange,
+			nil,
+			nil,
+			suite.log,
+			DefaultSnapshotHistoryLimit,
+			registerProofPort,
+			"",
+		)
+	}
+
+	suite.Run("disabled", func() {
+		_, err := newBackend(0).GetAccountWithProof(context.Background(), unittest.AddressFixture(), unsealed.Height)
+		suite.Require().Equal(codes.Unimplemented, status.Code(err))
+	})
+
+	suite.Run("unsealed block", func() {
+		_, err := newBackend(9006).GetAccountWithProof(context.Background(), unittest.AddressFixture(), unsealed.Height)
+		suite.Require().Equal(codes.InvalidArgument, status.Code(err))
+	})
+}
+
 // TestExecutionNodesForBlockID tests the common method backend.executionNodesForBlockID used for serving all API calls
 // that need to talk to an execution node.
 func (suite *Suite) TestExecutionNodesForBlockID() {
@@ -2196,6 +2482,8 @@ func (suite *Suite) TestExecuteScriptOnExecutionNode() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// mock parameters
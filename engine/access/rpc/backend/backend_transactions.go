@@ -20,6 +20,7 @@ import (
 	"github.com/onflow/flow-go/fvm/blueprints"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/state/cluster"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 )
@@ -38,28 +39,33 @@ type backendTransactions struct {
 	transactionValidator *access.TransactionValidator
 	retry                *Retry
 	connFactory          ConnectionFactory
+	submissions          *submissionReceipts
 
 	previousAccessNodes []accessproto.AccessAPIClient
 	log                 zerolog.Logger
 }
 
-// SendTransaction forwards the transaction to the collection node
+// SendTransaction forwards the transaction to the collection node, and returns the receipt of
+// its submission.
 func (b *backendTransactions) SendTransaction(
 	ctx context.Context,
 	tx *flow.TransactionBody,
-) error {
+) (*access.TransactionSubmissionReceipt, error) {
 	now := time.Now().UTC()
 
 	err := b.transactionValidator.Validate(tx)
 	if err != nil {
-		return status.Errorf(codes.InvalidArgument, "invalid transaction: %s", err.Error())
+		return nil, status.Errorf(codes.InvalidArgument, "invalid transaction: %s", err.Error())
 	}
 
 	// send the transaction to the collection node if valid
-	err = b.trySendTransaction(ctx, tx)
+	receipt, err := b.trySendTransaction(ctx, tx)
 	if err != nil {
 		b.transactionMetrics.TransactionSubmissionFailed()
-		return status.Error(codes.Internal, fmt.Sprintf("failed to send transaction to a collection node: %v", err))
+		if receipt != nil && receipt.Dropped() {
+			return nil, status.Error(codes.InvalidArgument, receipt.RejectionMessage())
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("failed to send transaction to a collection node: %v", err))
 	}
 
 	b.transactionMetrics.TransactionReceived(tx.ID(), now)
@@ -68,29 +74,38 @@ func (b *backendTransactions) SendTransaction(
 	err = b.transactions.Store(tx)
 	if err != nil {
 		// TODO: why would this be InvalidArgument?
-		return status.Error(codes.InvalidArgument, fmt.Sprintf("failed to store transaction: %v", err))
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("failed to store transaction: %v", err))
 	}
 
 	if b.retry.IsActive() {
 		go b.registerTransactionForRetry(tx)
 	}
 
-	return nil
+	return receipt, nil
 }
 
-// trySendTransaction tries to transaction to a collection node
-func (b *backendTransactions) trySendTransaction(ctx context.Context, tx *flow.TransactionBody) error {
+// trySendTransaction tries to transaction to a collection node. The receipt of the submission
+// is recorded and returned, unless no collection node could be chosen for the transaction.
+func (b *backendTransactions) trySendTransaction(ctx context.Context, tx *flow.TransactionBody) (*access.TransactionSubmissionReceipt, error) {
+	receipt := &access.TransactionSubmissionReceipt{
+		TransactionID: tx.ID(),
+	}
 
 	// if a collection node rpc client was provided at startup, just use that
 	if b.staticCollectionRPC != nil {
-		return b.grpcTxSend(ctx, b.staticCollectionRPC, tx)
+		err := b.grpcTxSend(ctx, b.staticCollectionRPC, tx)
+		receipt.Responses = append(receipt.Responses, collectorResponse(flow.ZeroID, err))
+		b.submissions.Add(receipt)
+		return receipt, err
 	}
 
 	// otherwise choose a random set of collections nodes to try
-	collAddrs, err := b.chooseCollectionNodes(tx, collectionNodesToTry)
+	clusterID, targetNodes, err := b.chooseCollectionNodes(tx, collectionNodesToTry)
 	if err != nil {
-		return fmt.Errorf("failed to determine collection node for tx %x: %w", tx, err)
+		return nil, fmt.Errorf("failed to determine collection node for tx %x: %w", tx, err)
 	}
+	receipt.ClusterID = clusterID
+	receipt.Collectors = targetNodes.NodeIDs()
 
 	var sendErrors *multierror.Error
 	logAnyError := func() {
@@ -102,43 +117,67 @@ func (b *backendTransactions) trySendTransaction(ctx context.Context, tx *flow.T
 	defer logAnyError()
 
 	// try sending the transaction to one of the chosen collection nodes
-	for _, addr := range collAddrs {
-		err = b.sendTransactionToCollector(ctx, tx, addr)
-		if err == nil {
-			return nil
-		}
+	for _, target := range targetNodes {
+		err = b.sendTransactionToCollector(ctx, tx, target.Address)
+		response := collectorResponse(target.NodeID, err)
+		receipt.Responses = append(receipt.Responses, response)
+
+		// a collection node already holding the transaction has accepted it before
+		if err == nil || response.Reason == access.RejectionReasonDuplicate {
+			b.submissions.Add(receipt)
+			return receipt, nil
+		}
+		// even if the transaction was rejected for a terminal reason, the other collection nodes
+		// are tried, so a single faulty collection node can't censor the transaction
 		sendErrors = multierror.Append(sendErrors, err)
 	}
 
-	return sendErrors.ErrorOrNil()
+	b.submissions.Add(receipt)
+	return receipt, sendErrors.ErrorOrNil()
+}
+
+// collectorResponse returns the response of the given collection node to a submitted
+// transaction, based on the error returned by it.
+func collectorResponse(collectorID flow.Identifier, err error) access.CollectorResponse {
+	if err == nil {
+		return access.CollectorResponse{
+			CollectorID: collectorID,
+			Accepted:    true,
+		}
+	}
+	return access.CollectorResponse{
+		CollectorID: collectorID,
+		Reason:      access.RejectionReasonFromStatusError(err),
+		Message:     err.Error(),
+	}
 }
 
-// chooseCollectionNodes finds a random subset of size sampleSize of collection node addresses from the
-// collection node cluster responsible for the given tx
-func (b *backendTransactions) chooseCollectionNodes(tx *flow.TransactionBody, sampleSize uint) ([]string, error) {
+// chooseCollectionNodes finds a random subset of size sampleSize of collection nodes from the
+// collection node cluster responsible for the given tx, together with the ID of the cluster.
+func (b *backendTransactions) chooseCollectionNodes(tx *flow.TransactionBody, sampleSize uint) (flow.ChainID, flow.IdentityList, error) {
+
+	epoch := b.state.Final().Epochs().Current()
+	counter, err := epoch.Counter()
+	if err != nil {
+		return "", nil, fmt.Errorf("could not get epoch counter: %w", err)
+	}
 
 	// retrieve the set of collector clusters
-	clusters, err := b.state.Final().Epochs().Current().Clustering()
+	clusters, err := epoch.Clustering()
 	if err != nil {
-		return nil, fmt.Errorf("could not cluster collection nodes: %w", err)
+		return "", nil, fmt.Errorf("could not cluster collection nodes: %w", err)
 	}
 
 	// get the cluster responsible for the transaction
 	txCluster, ok := clusters.ByTxID(tx.ID())
 	if !ok {
-		return nil, fmt.Errorf("could not get local cluster by txID: %x", tx.ID())
+		return "", nil, fmt.Errorf("could not get local cluster by txID: %x", tx.ID())
 	}
 
 	// select a random subset of collection nodes from the cluster to be tried in order
 	targetNodes := txCluster.Sample(sampleSize)
 
-	// collect the addresses of all the chosen collection nodes
-	var targetAddrs = make([]string, len(targetNodes))
-	for i, id := range targetNodes {
-		targetAddrs[i] = id.Address
-	}
-
-	return targetAddrs, nil
+	return cluster.CanonicalClusterID(counter, txCluster), targetNodes, nil
 }
 
 // sendTransactionToCollection sends the transaction to the given collection node via grpc
@@ -157,7 +196,7 @@ func (b *backendTransactions) sendTransactionToCollector(ctx context.Context,
 		if status.Code(err) == codes.Unavailable {
 			b.connFactory.InvalidateAccessAPIClient(collectionNodeAddr)
 		}
-		return fmt.Errorf("failed to send transaction to collection node at %s: %v", collectionNodeAddr, err)
+		return fmt.Errorf("failed to send transaction to collection node at %s: %w", collectionNodeAddr, err)
 	}
 	return nil
 }
@@ -181,7 +220,8 @@ func (b *backendTransactions) SendRawTransaction(
 ) error {
 
 	// send the transaction to the collection node
-	return b.trySendTransaction(ctx, tx)
+	_, err := b.trySendTransaction(ctx, tx)
+	return err
 }
 
 func (b *backendTransactions) GetTransaction(ctx context.Context, txID flow.Identifier) (*flow.TransactionBody, error) {
@@ -242,6 +282,11 @@ func (b *backendTransactions) GetTransactionResult(
 	txErr := rpc.ConvertStorageError(err)
 	if txErr != nil {
 		if status.Code(txErr) == codes.NotFound {
+			// Tx not stored, as it was rejected by the collection nodes when submitted
+			if result, ok := b.droppedTransactionResult(txID); ok {
+				return result, nil
+			}
+
 			// Tx not found. If we have historical Sporks setup, lets look through those as well
 			historicalTxResult, err := b.getHistoricalTransactionResult(ctx, txID)
 			if err != nil {
@@ -286,6 +331,13 @@ func (b *backendTransactions) GetTransactionResult(
 		return nil, rpc.ConvertStorageError(err)
 	}
 
+	// a pending transaction may have been rejected by the collection nodes when resubmitted
+	if txStatus == flow.TransactionStatusPending {
+		if result, ok := b.droppedTransactionResult(txID); ok {
+			return result, nil
+		}
+	}
+
 	b.transactionMetrics.TransactionResultFetched(time.Since(start), len(tx.Script))
 
 	return &access.TransactionResult{
@@ -459,6 +511,24 @@ func (b *backendTransactions) GetTransactionResultByIndex(
 	}, nil
 }
 
+// droppedTransactionResult returns the result of a transaction which the collection nodes
+// rejected for a reason preventing it from ever being included in a collection, as recorded
+// in the receipts of its submissions. As there is no dedicated status for rejected
+// transactions, they are reported as expired with the rejection reasons as error message.
+func (b *backendTransactions) droppedTransactionResult(txID flow.Identifier) (*access.TransactionResult, bool) {
+	receipt, ok := b.submissions.ByID(txID)
+	if !ok || !receipt.Dropped() {
+		return nil, false
+	}
+
+	return &access.TransactionResult{
+		Status:        flow.TransactionStatusExpired,
+		StatusCode:    1,
+		ErrorMessage:  receipt.RejectionMessage(),
+		TransactionID: txID,
+	}, true
+}
+
 // deriveTransactionStatus derives the transaction status based on current protocol state
 func (b *backendTransactions) deriveTransactionStatus(
 	tx *flow.TransactionBody,
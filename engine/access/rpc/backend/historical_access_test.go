@@ -55,6 +55,8 @@ func (suite *Suite) TestHistoricalTransactionResult() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// Successfully return the transaction from the historical node
@@ -112,6 +114,8 @@ func (suite *Suite) TestHistoricalTransaction() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 
 	// Successfully return the transaction from the historical node
@@ -60,6 +60,8 @@ func (suite *Suite) TestTransactionRetry() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
@@ -140,6 +142,8 @@ func (suite *Suite) TestSuccessfulTransactionsDontRetry() {
 		nil,
 		suite.log,
 		DefaultSnapshotHistoryLimit,
+		0,
+		"",
 	)
 	retry := newRetry().SetBackend(backend).Activate()
 	backend.retry = retry
@@ -0,0 +1,60 @@
+package backend
+
+import (
+	"fmt"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DefaultSubmissionReceiptsCacheSize is the default number of transactions for which the
+// receipts of their submission to collection nodes are kept.
+const DefaultSubmissionReceiptsCacheSize = 100_000
+
+// submissionReceipts records the receipts of submitting transactions to collection nodes, by
+// transaction ID. The receipts of repeated submissions of a transaction, e.g. by the retry
+// mechanism, are merged so the responses of all collection nodes are kept.
+type submissionReceipts struct {
+	mu       sync.Mutex
+	receipts *lru.Cache // transaction ID -> *access.TransactionSubmissionReceipt
+}
+
+func newSubmissionReceipts(size int) (*submissionReceipts, error) {
+	receipts, err := lru.New(size)
+	if err != nil {
+		return nil, fmt.Errorf("could not create submission receipts cache: %w", err)
+	}
+	return &submissionReceipts{
+		receipts: receipts,
+	}, nil
+}
+
+// Add records the receipt of a submission of a transaction.
+func (s *submissionReceipts) Add(receipt *access.TransactionSubmissionReceipt) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	merged := &access.TransactionSubmissionReceipt{
+		TransactionID: receipt.TransactionID,
+		ClusterID:     receipt.ClusterID,
+		Collectors:    receipt.Collectors,
+	}
+	if existing, ok := s.receipts.Get(receipt.TransactionID); ok {
+		merged.Responses = append(merged.Responses, existing.(*access.TransactionSubmissionReceipt).Responses...)
+	}
+	merged.Responses = append(merged.Responses, receipt.Responses...)
+
+	s.receipts.Add(receipt.TransactionID, merged)
+}
+
+// ByID returns the merged receipt of all recorded submissions of the given transaction.
+func (s *submissionReceipts) ByID(txID flow.Identifier) (*access.TransactionSubmissionReceipt, bool) {
+	receipt, ok := s.receipts.Get(txID)
+	if !ok {
+		return nil, false
+	}
+	return receipt.(*access.TransactionSubmissionReceipt), true
+}
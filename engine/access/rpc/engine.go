@@ -17,11 +17,13 @@ import (
 	"google.golang.org/grpc/credentials"
 
 	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/engine/access/ratelimit"
 	"github.com/onflow/flow-go/engine/access/rest"
 	"github.com/onflow/flow-go/engine/access/rpc/backend"
 	"github.com/onflow/flow-go/engine/common/rpc"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/state/protocol"
 	"github.com/onflow/flow-go/storage"
 	"github.com/onflow/flow-go/utils/grpcutils"
@@ -47,6 +49,9 @@ type Config struct {
 	MaxHeightRange            uint                             // max size of height range requests
 	PreferredExecutionNodeIDs []string                         // preferred list of upstream execution node IDs
 	FixedExecutionNodeIDs     []string                         // fixed list of execution node IDs to choose from if no node node ID can be chosen from the PreferredExecutionNodeIDs
+	RegisterProofPort         uint                             // the port of the register proof server of execution nodes (if zero, reading registers with proofs is disabled)
+	RegisterProofToken        string                           // the bearer token presented to the register proof server of execution nodes
+	ClientRateLimits          *ratelimit.Config                // the per-client rate limits shared by the gRPC and REST servers (if nil, clients are not rate limited)
 }
 
 // Engine exposes the server with a simplified version of the Access API.
@@ -62,6 +67,7 @@ type Engine struct {
 	restServer         *http.Server
 	config             Config
 	chain              flow.Chain
+	limiter            *ratelimit.Limiter // the per-client rate limiter shared by the gRPC and REST servers
 
 	addrLock            sync.RWMutex
 	unsecureGrpcAddress net.Addr
@@ -117,6 +123,22 @@ func NewBuilder(log zerolog.Logger,
 		interceptors = append(interceptors, rateLimitInterceptor)
 	}
 
+	var limiter *ratelimit.Limiter
+	if config.ClientRateLimits != nil {
+		var limiterMetrics module.ClientRateLimitMetrics = metrics.NewNoopCollector()
+		if accessMetrics != nil {
+			limiterMetrics = accessMetrics
+		}
+		var err error
+		limiter, err = ratelimit.NewLimiter(log, *config.ClientRateLimits, limiterMetrics)
+		if err != nil {
+			return nil, fmt.Errorf("could not create client rate limiter: %w", err)
+		}
+		// append the client rate limit interceptor after the global one, so requests rejected
+		// globally don't count against the client's limits
+		interceptors = append(interceptors, limiter.UnaryServerInterceptor)
+	}
+
 	// add the logging interceptor, ensure it is innermost wrapper
 	interceptors = append(interceptors, rpc.LoggingInterceptor(log)...)
 
@@ -187,6 +209,8 @@ func NewBuilder(log zerolog.Logger,
 		config.FixedExecutionNodeIDs,
 		log,
 		backend.DefaultSnapshotHistoryLimit,
+		config.RegisterProofPort,
+		config.RegisterProofToken,
 	)
 
 	eng := &Engine{
@@ -198,6 +222,7 @@ func NewBuilder(log zerolog.Logger,
 		httpServer:         httpServer,
 		config:             config,
 		chain:              chainID.Chain(),
+		limiter:            limiter,
 	}
 
 	builder := NewRPCEngineBuilder(eng)
@@ -354,7 +379,7 @@ func (e *Engine) serveREST() {
 
 	e.log.Info().Str("rest_api_address", e.config.RESTListenAddr).Msg("starting REST server on address")
 
-	r, err := rest.NewServer(e.backend, e.config.RESTListenAddr, e.log, e.chain)
+	r, err := rest.NewServer(e.backend, e.config.RESTListenAddr, e.log, e.chain, e.limiter)
 	if err != nil {
 		e.log.Err(err).Msg("failed to initialize the REST server")
 		return
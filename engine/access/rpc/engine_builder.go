@@ -9,6 +9,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	legacyaccess "github.com/onflow/flow-go/access/legacy"
+	"github.com/onflow/flow-go/access/stateproof/stateproofproto"
 	"github.com/onflow/flow-go/consensus/hotstuff"
 )
 
@@ -96,5 +97,10 @@ func (builder *RPCEngineBuilder) Build() (*Engine, error) {
 	}
 	accessproto.RegisterAccessAPIServer(builder.unsecureGrpcServer, handler)
 	accessproto.RegisterAccessAPIServer(builder.secureGrpcServer, handler)
+	// registers and accounts with proofs are served by a separate service, as the access API doesn't define them.
+	if stateProofHandler, ok := handler.(stateproofproto.StateProofAPIServer); ok {
+		stateproofproto.RegisterStateProofAPIServer(builder.unsecureGrpcServer, stateProofHandler)
+		stateproofproto.RegisterStateProofAPIServer(builder.secureGrpcServer, stateProofHandler)
+	}
 	return builder.Engine, nil
 }
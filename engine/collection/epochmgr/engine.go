@@ -404,7 +404,7 @@ func (e *Engine) stopEpochComponents(counter uint64) error {
 	select {
 	case <-components.Done():
 		delete(e.epochs, counter)
-		e.pools.ForEpoch(counter).Clear()
+		e.pools.RemoveForEpoch(counter)
 		return nil
 	case <-time.After(e.startupTimeout):
 		return fmt.Errorf("could not stop epoch %d components after %s", counter, e.startupTimeout)
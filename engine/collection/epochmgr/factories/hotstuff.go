@@ -10,6 +10,8 @@ import (
 	"github.com/onflow/flow-go/consensus/hotstuff"
 	"github.com/onflow/flow-go/consensus/hotstuff/blockproducer"
 	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/evidence"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
 	"github.com/onflow/flow-go/consensus/hotstuff/persister"
@@ -32,6 +34,7 @@ type HotStuffFactory struct {
 	me            module.Local
 	db            *badger.DB
 	protoState    protocol.State
+	evidence      *evidence.Collector
 	createMetrics HotStuffMetricsFunc
 	opts          []consensus.Option
 }
@@ -41,6 +44,7 @@ func NewHotStuffFactory(
 	me module.Local,
 	db *badger.DB,
 	protoState protocol.State,
+	evidenceCollector *evidence.Collector,
 	createMetrics HotStuffMetricsFunc,
 	opts ...consensus.Option,
 ) (*HotStuffFactory, error) {
@@ -50,6 +54,7 @@ func NewHotStuffFactory(
 		me:            me,
 		db:            db,
 		protoState:    protoState,
+		evidence:      evidenceCollector,
 		createMetrics: createMetrics,
 		opts:          opts,
 	}
@@ -86,6 +91,15 @@ func (f *HotStuffFactory) CreateModules(
 
 	// create a signing provider
 	var signer hotstuff.Signer = verification.NewStakingSigner(f.me)
+	// refuse signing proposals and votes conflicting with previously signed ones
+	protection := persister.NewSlashingProtection(f.db, cluster.ChainID())
+	finalizationDistributor.AddOnBlockFinalizedConsumer(func(block *model.Block) {
+		err := protection.Prune(block.View)
+		if err != nil {
+			f.log.Error().Err(err).Uint64("view", block.View).Msg("could not prune slashing protection")
+		}
+	})
+	signer = verification.NewProtectedSigner(signer, protection)
 	signer = verification.NewMetricsWrapper(signer, metrics) // wrapper for measuring time spent with crypto-related operations
 
 	finalizedBlock, err := clusterState.Final().Head()
@@ -109,6 +123,10 @@ func (f *HotStuffFactory) CreateModules(
 
 	verifier := verification.NewStakingVerifier()
 	validator := validatorImpl.NewMetricsWrapper(validatorImpl.New(committee, forks, verifier), metrics)
+
+	// collect evidence of slashable offenses of other cluster members
+	notifier.AddConsumer(f.evidence.Consumer(cluster.ChainID(), committee, verifier, headers))
+
 	voteProcessorFactory := votecollector.NewStakingVoteProcessorFactory(committee, qcDistributor.OnQcConstructedFromVotes)
 	aggregator, err := consensus.NewVoteAggregator(
 		f.log,
@@ -1,6 +1,8 @@
 package ingest
 
 import (
+	"time"
+
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -35,3 +37,32 @@ func DefaultConfig() Config {
 		MaxMessageQueueSize:    10_000,
 	}
 }
+
+// PreValidationConfig defines configuration for pre-validating transactions
+// against the account state, before they are ingested.
+type PreValidationConfig struct {
+	// the fee, in units of 10^-8 FLOW, every transaction is expected to pay,
+	// regardless of its gas limit
+	BaseFee uint64
+	// the fee, in units of 10^-8 FLOW, expected per unit of the transaction gas limit
+	FeePerGasUnit uint64
+	// the maximum time to wait for the account state of a transaction
+	ReadTimeout time.Duration
+	// the maximum number of accounts to cache, 0 disables caching
+	CacheSize uint
+	// how long a cached account is used before it is read again
+	CacheTTL time.Duration
+	// how long an expired cached account is still used, while it is read again in the background
+	CacheMaxStaleness time.Duration
+}
+
+func DefaultPreValidationConfig() PreValidationConfig {
+	return PreValidationConfig{
+		BaseFee:           1_000,
+		FeePerGasUnit:     0,
+		ReadTimeout:       time.Second,
+		CacheSize:         10_000,
+		CacheTTL:          5 * time.Second,
+		CacheMaxStaleness: 30 * time.Second,
+	}
+}
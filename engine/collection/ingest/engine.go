@@ -39,6 +39,7 @@ type Engine struct {
 	messageHandler       *engine.MessageHandler
 	pools                *epochs.TransactionPools
 	transactionValidator *access.TransactionValidator
+	preValidator         *PreValidator // optional, nil if pre-validation is disabled
 
 	config Config
 }
@@ -126,6 +127,16 @@ func New(
 	return e, nil
 }
 
+// WithPreValidator sets the pre-validator, which checks transactions against the
+// account state after they passed validation. Pre-validation is disabled unless set.
+// Only transactions submitted locally (see ProcessTransaction) are pre-validated, so
+// reading the account state never blocks the processing of transactions received from
+// other collection nodes, which pre-validated them already.
+// Must be called before the engine is started.
+func (e *Engine) WithPreValidator(preValidator *PreValidator) {
+	e.preValidator = preValidator
+}
+
 // Process processes a transaction message from the network and enqueues the
 // message. Validation and ingestion is performed in the processQueuedTransactions
 // worker.
@@ -149,7 +160,8 @@ func (e *Engine) Process(channel channels.Channel, originID flow.Identifier, eve
 }
 
 // ProcessTransaction processes a transaction message submitted from another
-// local component. The transaction is validated and ingested synchronously.
+// local component. The transaction is validated, pre-validated if enabled, and
+// ingested synchronously, on the caller's goroutine.
 // This is used by the GRPC API, for transactions from Access nodes.
 func (e *Engine) ProcessTransaction(tx *flow.TransactionBody) error {
 	// do not process transactions after the engine has shut down
@@ -198,7 +210,9 @@ func (e *Engine) processAvailableMessages(ctx context.Context) error {
 		if ok {
 			err := e.onTransaction(msg.OriginID, msg.Payload.(*flow.TransactionBody))
 			// log warnings for expected error conditions
-			if engine.IsUnverifiableInputError(err) {
+			if errors.Is(err, access.ErrDuplicateTransaction) {
+				e.log.Debug().Msg("received dupe transaction")
+			} else if engine.IsUnverifiableInputError(err) {
 				e.log.Warn().Err(err).Msg("unable to process unverifiable transaction")
 			} else if engine.IsInvalidInputError(err) {
 				e.log.Warn().Err(err).Msg("discarding invalid transaction")
@@ -222,6 +236,7 @@ func (e *Engine) processAvailableMessages(ctx context.Context) error {
 //   - engine.UnverifiableInputError if the reference block is unknown or if the
 //     node is not a member of any cluster in the reference epoch.
 //   - engine.InvalidInputError if the transaction is invalid.
+//   - access.ErrDuplicateTransaction if the transaction has already been ingested.
 //   - other error for any other unexpected error condition.
 func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBody) error {
 
@@ -270,7 +285,10 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 
 	// validate and ingest the transaction, so it is eligible for inclusion in
 	// a future collection proposed by this node
-	err = e.ingestTransaction(log, refEpoch, tx, txID, localClusterFingerPrint, txClusterFingerPrint)
+	// only transactions submitted locally are pre-validated, the transactions propagated
+	// by other collection nodes were pre-validated by them
+	submittedLocally := originID == e.me.NodeID()
+	err = e.ingestTransaction(log, refEpoch, tx, txID, localClusterFingerPrint, txClusterFingerPrint, submittedLocally)
 	if err != nil {
 		return fmt.Errorf("could not ingest transaction: %w", err)
 	}
@@ -278,7 +296,7 @@ func (e *Engine) onTransaction(originID flow.Identifier, tx *flow.TransactionBod
 	// if the message was submitted internally (ie. via the Access API)
 	// propagate it to members of the responsible cluster (either our cluster
 	// or a different cluster)
-	if originID == e.me.NodeID() {
+	if submittedLocally {
 		e.propagateTransaction(log, tx, txCluster)
 	}
 
@@ -326,10 +344,12 @@ func (e *Engine) getLocalCluster(refEpoch protocol.Epoch) (flow.IdentityList, er
 }
 
 // ingestTransaction validates and ingests the transaction, if it is routed to
-// our local cluster, is valid, and has not been seen previously.
+// our local cluster, is valid, and has not been seen previously. The transaction
+// is pre-validated against the account state if preValidate is true.
 //
 // Returns:
 // * engine.InvalidInputError if the transaction is invalid.
+// * access.ErrDuplicateTransaction if the transaction has already been ingested.
 // * other error for any other unexpected error condition.
 func (e *Engine) ingestTransaction(
 	log zerolog.Logger,
@@ -338,6 +358,7 @@ func (e *Engine) ingestTransaction(
 	txID flow.Identifier,
 	localClusterFingerprint flow.Identifier,
 	txClusterFingerprint flow.Identifier,
+	preValidate bool,
 ) error {
 	epochCounter, err := refEpoch.Counter()
 	if err != nil {
@@ -349,8 +370,7 @@ func (e *Engine) ingestTransaction(
 
 	// short-circuit if we have already stored the transaction
 	if pool.Has(txID) {
-		log.Debug().Msg("received dupe transaction")
-		return nil
+		return access.ErrDuplicateTransaction
 	}
 
 	// check if the transaction is valid
@@ -359,6 +379,14 @@ func (e *Engine) ingestTransaction(
 		return engine.NewInvalidInputErrorf("invalid transaction (%x): %w", txID, err)
 	}
 
+	// drop the transaction if the account state shows it will fail in execution
+	if preValidate && e.preValidator != nil {
+		err = e.preValidator.Validate(tx)
+		if err != nil {
+			return engine.NewInvalidInputErrorf("transaction (%x) failed pre-validation: %w", txID, err)
+		}
+	}
+
 	// if our cluster is responsible for the transaction, add it to our local mempool
 	if localClusterFingerprint == txClusterFingerprint {
 		_ = pool.Add(tx)
@@ -13,6 +13,7 @@ import (
 
 	"github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine"
+	mockingest "github.com/onflow/flow-go/engine/collection/ingest/mock"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/flow/factory"
 	"github.com/onflow/flow-go/model/flow/filter"
@@ -270,6 +271,58 @@ func (suite *Suite) TestInvalidTransaction() {
 
 }
 
+// should reject transactions failing pre-validation against the account state,
+// and not store them in the mempool
+func (suite *Suite) TestPreValidation() {
+	tx := unittest.TransactionBodyFixture()
+	tx.ReferenceBlockID = suite.root.ID()
+	tx.ProposalKey.SequenceNumber = 1
+
+	// the proposal key sequence number has already been used
+	proposer := &flow.Account{
+		Address: tx.ProposalKey.Address,
+		Balance: 0,
+		Keys: []flow.AccountPublicKey{{
+			Index:     int(tx.ProposalKey.KeyIndex),
+			SeqNumber: 2,
+		}},
+	}
+	reader := mockingest.NewAccountReader(suite.T())
+	reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).Return(proposer, nil)
+	suite.engine.WithPreValidator(NewPreValidator(zerolog.Nop(), reader, DefaultPreValidationConfig()))
+
+	err := suite.engine.ProcessTransaction(&tx)
+	suite.Assert().True(engine.IsInvalidInputError(err))
+	suite.Assert().True(errors.As(err, &access.StaleSequenceNumberError{}))
+
+	counter, err := suite.epochQuery.Current().Counter()
+	suite.Require().NoError(err)
+	suite.Assert().False(suite.pools.ForEpoch(counter).Has(tx.ID()))
+}
+
+// should not pre-validate transactions received from other collection nodes, which
+// pre-validated them already
+func (suite *Suite) TestPreValidation_FromOtherNode() {
+	local, _, ok := suite.clusters.ByNodeID(suite.me.NodeID())
+	suite.Require().True(ok)
+	sender := local.Filter(filter.Not(filter.HasNodeID(suite.me.NodeID())))[0]
+
+	tx := unittest.TransactionBodyFixture()
+	tx.ReferenceBlockID = suite.root.ID()
+	tx = unittest.AlterTransactionForCluster(tx, suite.clusters, local, func(transaction *flow.TransactionBody) {})
+
+	// the account state is never read
+	reader := mockingest.NewAccountReader(suite.T())
+	suite.engine.WithPreValidator(NewPreValidator(zerolog.Nop(), reader, DefaultPreValidationConfig()))
+
+	err := suite.engine.onTransaction(sender.NodeID, &tx)
+	suite.Assert().NoError(err)
+
+	counter, err := suite.epochQuery.Current().Counter()
+	suite.Require().NoError(err)
+	suite.Assert().True(suite.pools.ForEpoch(counter).Has(tx.ID()))
+}
+
 // should return an error if the engine is shutdown and not processing transactions
 func (suite *Suite) TestComponentShutdown() {
 	tx := unittest.TransactionBodyFixture()
@@ -313,6 +366,31 @@ func (suite *Suite) TestRoutingLocalCluster() {
 	suite.conduit.AssertExpectations(suite.T())
 }
 
+// should reject transactions which have already been ingested, without propagating them again
+func (suite *Suite) TestDuplicateTransaction() {
+
+	local, _, ok := suite.clusters.ByNodeID(suite.me.NodeID())
+	suite.Require().True(ok)
+
+	// get a transaction that will be routed to local cluster
+	tx := unittest.TransactionBodyFixture()
+	tx.ReferenceBlockID = suite.root.ID()
+	tx = unittest.AlterTransactionForCluster(tx, suite.clusters, local, func(transaction *flow.TransactionBody) {})
+
+	// should be propagated only once
+	suite.conduit.
+		On("Multicast", &tx, suite.conf.PropagationRedundancy+1, local.NodeIDs()[0], local.NodeIDs()[1]).
+		Return(nil).
+		Once()
+
+	err := suite.engine.ProcessTransaction(&tx)
+	suite.Require().NoError(err)
+
+	err = suite.engine.ProcessTransaction(&tx)
+	suite.Assert().ErrorIs(err, access.ErrDuplicateTransaction)
+	suite.conduit.AssertExpectations(suite.T())
+}
+
 // should not store transactions for a different cluster and should propagate
 // to the responsible cluster
 func (suite *Suite) TestRoutingRemoteCluster() {
@@ -0,0 +1,351 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	sdk "github.com/onflow/flow-go-sdk"
+	"github.com/rs/zerolog"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/utils/logging"
+)
+
+// AccountReader reads the state of accounts, which is used to pre-validate transactions.
+type AccountReader interface {
+	// GetAccount returns the latest known state of the account at the given address.
+	GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
+}
+
+// LatestAccountReader is an AccountReader which may return outdated accounts, e.g. from a cache,
+// and which can read the latest state of an account on demand.
+type LatestAccountReader interface {
+	AccountReader
+	// GetLatestAccount returns the latest state of the account at the given address, bypassing
+	// any outdated state.
+	GetLatestAccount(ctx context.Context, address flow.Address) (*flow.Account, error)
+}
+
+// SDKAccountReader is an AccountReader which reads accounts at the latest block from an
+// access node, using the flow-go-sdk client.
+type SDKAccountReader struct {
+	client module.SDKClientWrapper
+}
+
+var _ AccountReader = (*SDKAccountReader)(nil)
+
+// NewSDKAccountReader creates a new account reader using the given client.
+func NewSDKAccountReader(client module.SDKClientWrapper) *SDKAccountReader {
+	return &SDKAccountReader{
+		client: client,
+	}
+}
+
+func (r *SDKAccountReader) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	account, err := r.client.GetAccountAtLatestBlock(ctx, sdk.Address(address))
+	if err != nil {
+		return nil, fmt.Errorf("could not get account %s: %w", address, err)
+	}
+
+	keys := make([]flow.AccountPublicKey, 0, len(account.Keys))
+	for _, key := range account.Keys {
+		keys = append(keys, flow.AccountPublicKey{
+			Index:     key.Index,
+			PublicKey: key.PublicKey,
+			SignAlgo:  key.SigAlgo,
+			HashAlgo:  key.HashAlgo,
+			SeqNumber: key.SequenceNumber,
+			Weight:    key.Weight,
+			Revoked:   key.Revoked,
+		})
+	}
+
+	return &flow.Account{
+		Address:   flow.Address(account.Address),
+		Balance:   account.Balance,
+		Keys:      keys,
+		Contracts: account.Contracts,
+	}, nil
+}
+
+// maxConcurrentRefreshes is the maximum number of expired accounts the CachingAccountReader
+// reads again in the background at the same time.
+const maxConcurrentRefreshes = 10
+
+// cachedAccount is an account held by the CachingAccountReader, until its expiry.
+type cachedAccount struct {
+	account *flow.Account
+	expiry  time.Time
+}
+
+// CachingAccountReader sits in front of another AccountReader and caches the accounts
+// read from it for a fixed time. Concurrent reads of the same account are coalesced into
+// a single read of the underlying reader.
+//
+// Once a cached account expired, it is still used for up to maxStaleness, while it is read
+// again in the background. Hence, callers only wait for the underlying reader if the cache
+// holds no usable state of the account.
+//
+// Cached accounts may lag behind the latest state by up to the cache TTL plus the max
+// staleness, so they must only be used for checks which tolerate slightly outdated state.
+// Checks which don't tolerate it read the account with GetLatestAccount instead.
+type CachingAccountReader struct {
+	reader      AccountReader
+	ttl         time.Duration
+	maxStale    time.Duration
+	readTimeout time.Duration
+	accounts    *lru.Cache // address -> cachedAccount
+	inflight    singleflight.Group
+	refreshing  sync.Map      // addresses of the accounts being read in the background
+	refreshes   chan struct{} // slots for background reads
+}
+
+var _ LatestAccountReader = (*CachingAccountReader)(nil)
+
+// NewCachingAccountReader creates a cache of at most size accounts read from the given reader,
+// each of which is used for the given ttl, and for up to maxStaleness longer while it is read
+// again in the background, with the given timeout.
+func NewCachingAccountReader(reader AccountReader, size uint, ttl time.Duration, maxStaleness time.Duration, readTimeout time.Duration) (*CachingAccountReader, error) {
+	accounts, err := lru.New(int(size))
+	if err != nil {
+		return nil, fmt.Errorf("could not create account cache: %w", err)
+	}
+
+	return &CachingAccountReader{
+		reader:      reader,
+		ttl:         ttl,
+		maxStale:    maxStaleness,
+		readTimeout: readTimeout,
+		accounts:    accounts,
+		refreshes:   make(chan struct{}, maxConcurrentRefreshes),
+	}, nil
+}
+
+func (r *CachingAccountReader) GetAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	if cached, ok := r.accounts.Get(address); ok {
+		entry := cached.(cachedAccount)
+		now := time.Now()
+		if now.Before(entry.expiry) {
+			return entry.account, nil
+		}
+		if now.Before(entry.expiry.Add(r.maxStale)) {
+			r.refresh(address)
+			return entry.account, nil
+		}
+	}
+
+	return r.read(ctx, address)
+}
+
+// GetLatestAccount reads the account from the underlying reader, regardless of the cached state,
+// and caches it.
+func (r *CachingAccountReader) GetLatestAccount(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	return r.read(ctx, address)
+}
+
+// refresh reads the account again in the background, unless it is being read already or the
+// maximum number of background reads is running. In that case, the account is read again by a
+// later call.
+func (r *CachingAccountReader) refresh(address flow.Address) {
+	if _, loaded := r.refreshing.LoadOrStore(address, struct{}{}); loaded {
+		return
+	}
+	select {
+	case r.refreshes <- struct{}{}:
+	default:
+		r.refreshing.Delete(address)
+		return
+	}
+
+	go func() {
+		defer func() {
+			<-r.refreshes
+			r.refreshing.Delete(address)
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), r.readTimeout)
+		defer cancel()
+		// a failed read is retried by the next call using the stale account
+		_, _ = r.read(ctx, address)
+	}()
+}
+
+// read reads the account from the underlying reader and caches it. Concurrent reads of the
+// same account are coalesced.
+func (r *CachingAccountReader) read(ctx context.Context, address flow.Address) (*flow.Account, error) {
+	account, err, _ := r.inflight.Do(address.Hex(), func() (interface{}, error) {
+		account, err := r.reader.GetAccount(ctx, address)
+		if err != nil {
+			return nil, err
+		}
+		r.accounts.Add(address, cachedAccount{
+			account: account,
+			expiry:  time.Now().Add(r.ttl),
+		})
+		return account, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account.(*flow.Account), nil
+}
+
+// PreValidator checks transactions against the state of the accounts they use, to drop
+// transactions which are certain to fail in execution before they are ingested. It checks
+// that the proposal key exists, is not revoked and its sequence number is not stale, and
+// that the payer balance covers the estimated fees.
+//
+// Pre-validation complements, and does not replace, the validation of transactions in
+// execution: transactions are only dropped if the state read for them proves that they
+// will fail. If the state can't be read, the transaction is accepted. If the reader may
+// return outdated accounts, the latest state is read before a transaction is dropped for
+// a missing proposal key or an insufficient balance, since keys can be added and balances
+// topped up in the meantime.
+type PreValidator struct {
+	log    zerolog.Logger
+	reader AccountReader
+	config PreValidationConfig
+}
+
+// NewPreValidator creates a new pre-validator reading accounts from the given reader.
+func NewPreValidator(log zerolog.Logger, reader AccountReader, config PreValidationConfig) *PreValidator {
+	return &PreValidator{
+		log:    log.With().Str("component", "transaction_pre_validator").Logger(),
+		reader: reader,
+		config: config,
+	}
+}
+
+// Validate pre-validates the transaction against the state of its proposer and payer accounts.
+//
+// Returns:
+//   - access.InvalidProposalKeyError if the proposal key doesn't exist or has been revoked.
+//   - access.StaleSequenceNumberError if the proposal key sequence number has already been used.
+//   - access.InsufficientBalanceError if the payer balance doesn't cover the estimated fees.
+func (v *PreValidator) Validate(tx *flow.TransactionBody) error {
+	ctx, cancel := context.WithTimeout(context.Background(), v.config.ReadTimeout)
+	defer cancel()
+
+	proposer, err := v.reader.GetAccount(ctx, tx.ProposalKey.Address)
+	if err != nil {
+		v.log.Warn().Err(err).
+			Hex("tx_id", logging.Entity(tx)).
+			Msg("could not read proposer account, skipping pre-validation")
+		return nil
+	}
+
+	err = checkProposalKey(tx, proposer)
+	var keyErr access.InvalidProposalKeyError
+	if errors.As(err, &keyErr) && !keyErr.Revoked {
+		// the key might have been added since the account state was read
+		proposer, ok := v.latestAccount(ctx, tx, proposer, tx.ProposalKey.Address)
+		if !ok {
+			return nil
+		}
+		err = checkProposalKey(tx, proposer)
+	}
+	if err != nil {
+		return err
+	}
+
+	fees := v.estimateFees(tx)
+	if fees == 0 {
+		return nil
+	}
+
+	payer := proposer
+	if tx.Payer != tx.ProposalKey.Address {
+		payer, err = v.reader.GetAccount(ctx, tx.Payer)
+		if err != nil {
+			v.log.Warn().Err(err).
+				Hex("tx_id", logging.Entity(tx)).
+				Msg("could not read payer account, skipping balance pre-validation")
+			return nil
+		}
+	}
+
+	if payer.Balance < fees {
+		// the balance might have been topped up since the account state was read
+		payer, ok := v.latestAccount(ctx, tx, payer, tx.Payer)
+		if !ok {
+			return nil
+		}
+		if payer.Balance < fees {
+			return access.InsufficientBalanceError{
+				Payer:    tx.Payer,
+				Required: fees,
+				Actual:   payer.Balance,
+			}
+		}
+	}
+
+	return nil
+}
+
+// latestAccount returns the latest state of the given account, which is read again if the reader
+// may return outdated accounts. The boolean return value is false if the account can't be read.
+func (v *PreValidator) latestAccount(ctx context.Context, tx *flow.TransactionBody, account *flow.Account, address flow.Address) (*flow.Account, bool) {
+	reader, ok := v.reader.(LatestAccountReader)
+	if !ok {
+		return account, true
+	}
+
+	latest, err := reader.GetLatestAccount(ctx, address)
+	if err != nil {
+		v.log.Warn().Err(err).
+			Hex("tx_id", logging.Entity(tx)).
+			Str("address", address.Hex()).
+			Msg("could not read latest account state, skipping pre-validation")
+		return nil, false
+	}
+	return latest, true
+}
+
+// estimateFees returns the fees the payer of the transaction is expected to pay,
+// in units of 10^-8 FLOW.
+func (v *PreValidator) estimateFees(tx *flow.TransactionBody) uint64 {
+	return v.config.BaseFee + v.config.FeePerGasUnit*tx.GasLimit
+}
+
+// checkProposalKey checks that the proposal key of the transaction exists on the proposer
+// account, is not revoked and that the transaction sequence number has not been used yet.
+// A sequence number ahead of the account state is accepted, as the transactions using the
+// preceding sequence numbers may not have been executed yet.
+func checkProposalKey(tx *flow.TransactionBody, proposer *flow.Account) error {
+	proposalKey := tx.ProposalKey
+
+	for _, key := range proposer.Keys {
+		if uint64(key.Index) != proposalKey.KeyIndex {
+			continue
+		}
+
+		if key.Revoked {
+			return access.InvalidProposalKeyError{
+				Address:  proposalKey.Address,
+				KeyIndex: proposalKey.KeyIndex,
+				Revoked:  true,
+			}
+		}
+		if proposalKey.SequenceNumber < key.SeqNumber {
+			return access.StaleSequenceNumberError{
+				Address:  proposalKey.Address,
+				KeyIndex: proposalKey.KeyIndex,
+				Expected: key.SeqNumber,
+				Actual:   proposalKey.SequenceNumber,
+			}
+		}
+		return nil
+	}
+
+	return access.InvalidProposalKeyError{
+		Address:  proposalKey.Address,
+		KeyIndex: proposalKey.KeyIndex,
+	}
+}
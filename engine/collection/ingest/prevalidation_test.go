@@ -0,0 +1,270 @@
+package ingest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/access"
+	mockingest "github.com/onflow/flow-go/engine/collection/ingest/mock"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// accountFixture returns an account at the given address with the given balance, and a single
+// proposal key with the given index and sequence number.
+func accountFixture(address flow.Address, balance uint64, keyIndex uint64, seqNumber uint64) *flow.Account {
+	return &flow.Account{
+		Address: address,
+		Balance: balance,
+		Keys: []flow.AccountPublicKey{{
+			Index:     int(keyIndex),
+			SeqNumber: seqNumber,
+			Weight:    1000,
+		}},
+	}
+}
+
+func TestPreValidator(t *testing.T) {
+	config := DefaultPreValidationConfig()
+	config.BaseFee = 100
+	config.FeePerGasUnit = 10
+
+	// transaction with estimated fees of 100 + 10 * 10 = 200
+	tx := unittest.TransactionBodyFixture()
+	tx.Payer = unittest.RandomAddressFixture()
+	tx.GasLimit = 10
+	tx.ProposalKey.SequenceNumber = 5
+	fees := uint64(200)
+
+	t.Run("valid transaction", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).
+			Return(accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex, 5), nil)
+		reader.On("GetAccount", mock.Anything, tx.Payer).
+			Return(accountFixture(tx.Payer, fees, 0, 0), nil)
+
+		err := NewPreValidator(zerolog.Nop(), reader, config).Validate(&tx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("sequence number ahead of the account state", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).
+			Return(accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex, 3), nil)
+		reader.On("GetAccount", mock.Anything, tx.Payer).
+			Return(accountFixture(tx.Payer, fees, 0, 0), nil)
+
+		err := NewPreValidator(zerolog.Nop(), reader, config).Validate(&tx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("stale sequence number", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).
+			Return(accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex, 6), nil)
+
+		err := NewPreValidator(zerolog.Nop(), reader, config).Validate(&tx)
+		var staleErr access.StaleSequenceNumberError
+		require.ErrorAs(t, err, &staleErr)
+		assert.Equal(t, uint64(6), staleErr.Expected)
+		assert.Equal(t, uint64(5), staleErr.Actual)
+	})
+
+	t.Run("missing proposal key", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).
+			Return(accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex+1, 5), nil)
+
+		err := NewPreValidator(zerolog.Nop(), reader, config).Validate(&tx)
+		var keyErr access.InvalidProposalKeyError
+		require.ErrorAs(t, err, &keyErr)
+		assert.False(t, keyErr.Revoked)
+	})
+
+	t.Run("revoked proposal key", func(t *testing.T) {
+		proposer := accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex, 5)
+		proposer.Keys[0].Revoked = true
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).Return(proposer, nil)
+
+		err := NewPreValidator(zerolog.Nop(), reader, config).Validate(&tx)
+		var keyErr access.InvalidProposalKeyError
+		require.ErrorAs(t, err, &keyErr)
+		assert.True(t, keyErr.Revoked)
+	})
+
+	t.Run("insufficient payer balance", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).
+			Return(accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex, 5), nil)
+		reader.On("GetAccount", mock.Anything, tx.Payer).
+			Return(accountFixture(tx.Payer, fees-1, 0, 0), nil)
+
+		err := NewPreValidator(zerolog.Nop(), reader, config).Validate(&tx)
+		var balanceErr access.InsufficientBalanceError
+		require.ErrorAs(t, err, &balanceErr)
+		assert.Equal(t, fees, balanceErr.Required)
+		assert.Equal(t, fees-1, balanceErr.Actual)
+	})
+
+	t.Run("balance not checked without fees", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).
+			Return(accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex, 5), nil)
+
+		noFees := config
+		noFees.BaseFee = 0
+		noFees.FeePerGasUnit = 0
+		err := NewPreValidator(zerolog.Nop(), reader, noFees).Validate(&tx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("accepted if account state can't be read", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).
+			Return(nil, errors.New("unavailable"))
+
+		err := NewPreValidator(zerolog.Nop(), reader, config).Validate(&tx)
+		assert.NoError(t, err)
+	})
+}
+
+// TestPreValidator_CachedAccounts evaluates that transactions are not rejected because of outdated
+// cached accounts, by reading the latest state of the accounts before rejecting a transaction for
+// a missing proposal key or an insufficient balance.
+func TestPreValidator_CachedAccounts(t *testing.T) {
+	config := DefaultPreValidationConfig()
+	config.BaseFee = 100
+	config.FeePerGasUnit = 10
+
+	// transaction with estimated fees of 100 + 10 * 10 = 200
+	tx := unittest.TransactionBodyFixture()
+	tx.Payer = unittest.RandomAddressFixture()
+	tx.GasLimit = 10
+	tx.ProposalKey.SequenceNumber = 5
+	fees := uint64(200)
+
+	proposer := accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex, 5)
+
+	t.Run("balance topped up", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).Return(proposer, nil).Once()
+		reader.On("GetAccount", mock.Anything, tx.Payer).Return(accountFixture(tx.Payer, fees-1, 0, 0), nil).Twice()
+		cache, err := NewCachingAccountReader(reader, 10, time.Minute, 0, time.Second)
+		require.NoError(t, err)
+		validator := NewPreValidator(zerolog.Nop(), cache, config)
+
+		// the first transaction is rejected, since the latest balance is insufficient
+		err = validator.Validate(&tx)
+		var balanceErr access.InsufficientBalanceError
+		require.ErrorAs(t, err, &balanceErr)
+
+		// the payer tops up the balance, which is not reflected in the cached account
+		reader.On("GetAccount", mock.Anything, tx.Payer).Return(accountFixture(tx.Payer, fees, 0, 0), nil).Once()
+		err = validator.Validate(&tx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("proposal key added", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).
+			Return(accountFixture(tx.ProposalKey.Address, 0, tx.ProposalKey.KeyIndex+1, 5), nil).Once()
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).Return(proposer, nil).Once()
+		reader.On("GetAccount", mock.Anything, tx.Payer).Return(accountFixture(tx.Payer, fees, 0, 0), nil).Once()
+		cache, err := NewCachingAccountReader(reader, 10, time.Minute, 0, time.Second)
+		require.NoError(t, err)
+
+		err = NewPreValidator(zerolog.Nop(), cache, config).Validate(&tx)
+		assert.NoError(t, err)
+	})
+
+	t.Run("accepted if latest account state can't be read", func(t *testing.T) {
+		reader := mockingest.NewAccountReader(t)
+		reader.On("GetAccount", mock.Anything, tx.ProposalKey.Address).Return(proposer, nil).Once()
+		reader.On("GetAccount", mock.Anything, tx.Payer).Return(accountFixture(tx.Payer, fees-1, 0, 0), nil).Once()
+		reader.On("GetAccount", mock.Anything, tx.Payer).Return(nil, errors.New("unavailable")).Once()
+		cache, err := NewCachingAccountReader(reader, 10, time.Minute, 0, time.Second)
+		require.NoError(t, err)
+
+		err = NewPreValidator(zerolog.Nop(), cache, config).Validate(&tx)
+		assert.NoError(t, err)
+	})
+}
+
+// TestCachingAccountReader evaluates that accounts are read from the underlying reader
+// at most once per TTL.
+func TestCachingAccountReader(t *testing.T) {
+	address := unittest.AddressFixture()
+	account := accountFixture(address, 100, 0, 0)
+
+	reader := mockingest.NewAccountReader(t)
+	reader.On("GetAccount", mock.Anything, address).Return(account, nil).Twice()
+
+	ttl := 50 * time.Millisecond
+	cache, err := NewCachingAccountReader(reader, 10, ttl, 0, time.Second)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		cached, err := cache.GetAccount(context.Background(), address)
+		require.NoError(t, err)
+		assert.Equal(t, account, cached)
+	}
+
+	// the cached account is read again once expired
+	time.Sleep(ttl)
+	cached, err := cache.GetAccount(context.Background(), address)
+	require.NoError(t, err)
+	assert.Equal(t, account, cached)
+}
+
+// TestCachingAccountReader_Stale evaluates that an expired account is used until its max staleness,
+// while it is read again in the background, and that callers only wait for the underlying reader
+// once the account is too stale.
+func TestCachingAccountReader_Stale(t *testing.T) {
+	address := unittest.AddressFixture()
+	account := accountFixture(address, 100, 0, 0)
+	refreshed := accountFixture(address, 200, 0, 1)
+	latest := accountFixture(address, 300, 0, 2)
+
+	unblock := make(chan struct{})
+	reader := mockingest.NewAccountReader(t)
+	reader.On("GetAccount", mock.Anything, address).Return(account, nil).Once()
+	reader.On("GetAccount", mock.Anything, address).Return(refreshed, nil).Once().
+		Run(func(mock.Arguments) { <-unblock })
+	reader.On("GetAccount", mock.Anything, address).Return(latest, nil).Once()
+
+	ttl := 50 * time.Millisecond
+	cache, err := NewCachingAccountReader(reader, 10, ttl, time.Minute, time.Second)
+	require.NoError(t, err)
+
+	cached, err := cache.GetAccount(context.Background(), address)
+	require.NoError(t, err)
+	assert.Equal(t, account, cached)
+
+	// the expired account is used without waiting for the blocked background read
+	time.Sleep(ttl)
+	cached, err = cache.GetAccount(context.Background(), address)
+	require.NoError(t, err)
+	assert.Equal(t, account, cached)
+
+	// once the background read completed, the refreshed account is used
+	close(unblock)
+	require.Eventually(t, func() bool {
+		cached, err := cache.GetAccount(context.Background(), address)
+		return err == nil && cached == refreshed
+	}, time.Second, 5*time.Millisecond)
+
+	// an account exceeding its max staleness is read again before it is used
+	cache.maxStale = 0
+	time.Sleep(ttl)
+	cached, err = cache.GetAccount(context.Background(), address)
+	require.NoError(t, err)
+	assert.Equal(t, latest, cached)
+}
@@ -4,6 +4,7 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 
@@ -14,10 +15,12 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	flowaccess "github.com/onflow/flow-go/access"
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/rpc"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
 	"github.com/onflow/flow-go/utils/grpcutils"
 )
 
@@ -160,10 +163,30 @@ func (h *handler) SendTransaction(_ context.Context, req *access.SendTransaction
 
 	err = h.backend.ProcessTransaction(&tx)
 	if err != nil {
-		return nil, err
+		return nil, rejectionStatusError(err)
 	}
 
 	txID := tx.ID()
 
 	return &access.SendTransactionResponse{Id: txID[:]}, nil
 }
+
+// rejectionStatusError converts an error processing a submitted transaction to a gRPC status
+// error, which reports the reason for rejecting the transaction to the submitting access node.
+func rejectionStatusError(err error) error {
+	if errors.Is(err, component.ErrComponentShutdown) {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	reason := flowaccess.RejectionReasonOf(err)
+	switch {
+	case reason == flowaccess.RejectionReasonDuplicate:
+		return flowaccess.NewRejectionStatusError(codes.AlreadyExists, reason, err)
+	case engine.IsInvalidInputError(err):
+		return flowaccess.NewRejectionStatusError(codes.InvalidArgument, reason, err)
+	case engine.IsUnverifiableInputError(err):
+		return flowaccess.NewRejectionStatusError(codes.FailedPrecondition, reason, err)
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
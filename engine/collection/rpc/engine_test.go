@@ -3,13 +3,18 @@ package rpc
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/onflow/flow/protobuf/go/flow/access"
 
+	flowaccess "github.com/onflow/flow-go/access"
+	"github.com/onflow/flow-go/engine"
 	rpcmock "github.com/onflow/flow-go/engine/collection/rpc/mock"
 	"github.com/onflow/flow-go/engine/common/rpc/convert"
 	"github.com/onflow/flow-go/model/flow"
@@ -41,16 +46,14 @@ func TestSubmitTransaction(t *testing.T) {
 		assert.Equal(t, tx.ID(), flow.HashToID(res.Id))
 	})
 
-	t.Run("should pass through error", func(t *testing.T) {
-		expected := errors.New("error")
-		backend.On("ProcessTransaction", &tx).Return(expected).Once()
+	t.Run("should return unexpected error as internal error", func(t *testing.T) {
+		backend.On("ProcessTransaction", &tx).Return(errors.New("error")).Once()
 
 		res, err := h.SendTransaction(context.Background(), &access.SendTransactionRequest{
 			Transaction: convert.TransactionToMessage(tx),
 		})
-		if assert.Error(t, err) {
-			assert.Equal(t, expected, err)
-		}
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, flowaccess.RejectionReasonUnknown, flowaccess.RejectionReasonFromStatusError(err))
 
 		// should submit the transaction to the engine
 		backend.AssertCalled(t, "ProcessTransaction", &tx)
@@ -58,4 +61,43 @@ func TestSubmitTransaction(t *testing.T) {
 		// should only return the error
 		assert.Nil(t, res)
 	})
+
+	t.Run("should report rejection reason", func(t *testing.T) {
+		cases := []struct {
+			err    error
+			code   codes.Code
+			reason flowaccess.RejectionReason
+		}{
+			{
+				err:    engine.NewInvalidInputErrorf("invalid transaction: %w", flowaccess.ExpiredTransactionError{}),
+				code:   codes.InvalidArgument,
+				reason: flowaccess.RejectionReasonExpired,
+			},
+			{
+				err:    engine.NewInvalidInputErrorf("invalid transaction: %w", flowaccess.InvalidSignatureError{}),
+				code:   codes.InvalidArgument,
+				reason: flowaccess.RejectionReasonInvalidSignature,
+			},
+			{
+				err:    engine.NewInvalidInputErrorf("invalid transaction: %w", flowaccess.InsufficientBalanceError{}),
+				code:   codes.InvalidArgument,
+				reason: flowaccess.RejectionReasonAccountState,
+			},
+			{
+				err:    fmt.Errorf("could not ingest transaction: %w", flowaccess.ErrDuplicateTransaction),
+				code:   codes.AlreadyExists,
+				reason: flowaccess.RejectionReasonDuplicate,
+			},
+		}
+
+		for _, c := range cases {
+			backend.On("ProcessTransaction", &tx).Return(c.err).Once()
+
+			_, err := h.SendTransaction(context.Background(), &access.SendTransactionRequest{
+				Transaction: convert.TransactionToMessage(tx),
+			})
+			assert.Equal(t, c.code, status.Code(err))
+			assert.Equal(t, c.reason, flowaccess.RejectionReasonFromStatusError(err))
+		}
+	})
 }
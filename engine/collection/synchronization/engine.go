@@ -291,7 +291,7 @@ func (e *Engine) onSyncResponse(originID flow.Identifier, res *messages.SyncResp
 		e.log.Error().Err(err).Msg("could not get last finalized header")
 		return
 	}
-	e.core.HandleHeight(final, res.Height)
+	e.core.HandleHeight(final, res.Height, originID)
 }
 
 // onBlockResponse processes a response containing a specifically requested block.
@@ -176,7 +176,7 @@ func (r *RequestHandlerEngine) onSyncRequest(originID flow.Identifier, req *mess
 	}
 
 	// queue any missing heights as needed
-	r.core.HandleHeight(final, req.Height)
+	r.core.HandleHeight(final, req.Height, originID)
 
 	// don't bother sending a response if we're within tolerance or if we're
 	// behind the requester
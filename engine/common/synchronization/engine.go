@@ -284,7 +284,7 @@ func (e *Engine) processAvailableResponses() {
 func (e *Engine) onSyncResponse(originID flow.Identifier, res *messages.SyncResponse) {
 	e.log.Debug().Str("origin_id", originID.String()).Msg("received sync response")
 	final := e.finalizedHeader.Get()
-	e.core.HandleHeight(final, res.Height)
+	e.core.HandleHeight(final, res.Height, originID)
 }
 
 // onBlockResponse processes a response containing a specifically requested block.
@@ -196,7 +196,7 @@ func (ss *SyncSuite) TestOnSyncRequest() {
 	}
 
 	// regardless of request height, if within tolerance, we should not respond
-	ss.core.On("HandleHeight", ss.head, req.Height)
+	ss.core.On("HandleHeight", ss.head, req.Height, originID)
 	ss.core.On("WithinTolerance", ss.head, req.Height).Return(true)
 	err := ss.e.requestHandler.onSyncRequest(originID, req)
 	ss.Assert().NoError(err, "same height sync request should pass")
@@ -204,7 +204,7 @@ func (ss *SyncSuite) TestOnSyncRequest() {
 
 	// if request height is higher than local finalized, we should not respond
 	req.Height = ss.head.Height + 1
-	ss.core.On("HandleHeight", ss.head, req.Height)
+	ss.core.On("HandleHeight", ss.head, req.Height, originID)
 	ss.core.On("WithinTolerance", ss.head, req.Height).Return(false)
 	err = ss.e.requestHandler.onSyncRequest(originID, req)
 	ss.Assert().NoError(err, "same height sync request should pass")
@@ -212,7 +212,7 @@ func (ss *SyncSuite) TestOnSyncRequest() {
 
 	// if the request height is lower than head and outside tolerance, we should submit correct response
 	req.Height = ss.head.Height - 1
-	ss.core.On("HandleHeight", ss.head, req.Height)
+	ss.core.On("HandleHeight", ss.head, req.Height, originID)
 	ss.core.On("WithinTolerance", ss.head, req.Height).Return(false)
 	ss.con.On("Unicast", mock.Anything, mock.Anything).Return(nil).Run(
 		func(args mock.Arguments) {
@@ -239,7 +239,7 @@ func (ss *SyncSuite) TestOnSyncResponse() {
 	}
 
 	// the height should be handled
-	ss.core.On("HandleHeight", ss.head, res.Height)
+	ss.core.On("HandleHeight", ss.head, res.Height, originID)
 	ss.e.onSyncResponse(originID, res)
 	ss.core.AssertExpectations(ss.T())
 }
@@ -531,7 +531,7 @@ func (ss *SyncSuite) TestProcessingMultipleItems() {
 			Nonce:  uint64(i),
 			Height: uint64(1000 + i),
 		}
-		ss.core.On("HandleHeight", mock.Anything, msg.Height).Once()
+		ss.core.On("HandleHeight", mock.Anything, msg.Height, originID).Once()
 		require.NoError(ss.T(), ss.e.Process(channels.SyncCommittee, originID, msg))
 	}
 
@@ -544,7 +544,7 @@ func (ss *SyncSuite) TestProcessingMultipleItems() {
 
 		originID := unittest.IdentifierFixture()
 		ss.core.On("WithinTolerance", mock.Anything, mock.Anything).Return(false)
-		ss.core.On("HandleHeight", mock.Anything, msg.Height).Once()
+		ss.core.On("HandleHeight", mock.Anything, msg.Height, originID).Once()
 		ss.con.On("Unicast", mock.Anything, mock.Anything).Return(nil)
 
 		require.NoError(ss.T(), ss.e.Process(channels.SyncCommittee, originID, msg))
@@ -159,7 +159,7 @@ func (r *RequestHandler) onSyncRequest(originID flow.Identifier, req *messages.S
 
 	if r.queueMissingHeights {
 		// queue any missing heights as needed
-		r.core.HandleHeight(final, req.Height)
+		r.core.HandleHeight(final, req.Height, originID)
 	}
 
 	// don't bother sending a response if we're within tolerance or if we're
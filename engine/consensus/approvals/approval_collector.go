@@ -187,3 +187,22 @@ func (c *ApprovalCollector) CollectMissingVerifiers() map[uint64]flow.Identifier
 
 	return targetIDs
 }
+
+// SealingStatus returns the approval progress for every chunk of the incorporated result,
+// and whether a candidate seal for it is in the seals mempool.
+func (c *ApprovalCollector) SealingStatus() IncorporatedResultSealingStatus {
+	chunks := make([]ChunkSealingStatus, 0, len(c.chunkCollectors))
+	for chunkIndex, collector := range c.chunkCollectors {
+		status := collector.Status(uint64(chunkIndex))
+		status.Approved = c.aggregatedSignatures.HasSignature(uint64(chunkIndex))
+		chunks = append(chunks, status)
+	}
+	_, sealCandidate := c.seals.ByID(c.incorporatedResult.ID())
+
+	return IncorporatedResultSealingStatus{
+		IncorporatedBlockID:     c.IncorporatedBlockID(),
+		IncorporatedBlockHeight: c.incorporatedBlock.Height,
+		Chunks:                  chunks,
+		SealCandidate:           sealCandidate,
+	}
+}
@@ -127,3 +127,47 @@ func (s *ApprovalCollectorTestSuite) TestCollectMissingVerifiers() {
 	// skip first ID since we should have approval for it
 	require.Empty(s.T(), s.collector.CollectMissingVerifiers())
 }
+
+// TestSealingStatus tests that the sealing status reports the approvals collected and the verifiers
+// missing for each chunk, and whether a candidate seal was generated for the incorporated result.
+func (s *ApprovalCollectorTestSuite) TestSealingStatus() {
+	incorporatedResultID := s.IncorporatedResult.ID()
+	s.sealsPL.On("ByID", incorporatedResultID).Return(nil, false).Once()
+
+	// no approvals processed
+	status := s.collector.SealingStatus()
+	require.Equal(s.T(), s.IncorporatedBlock.ID(), status.IncorporatedBlockID)
+	require.Equal(s.T(), s.IncorporatedBlock.Height, status.IncorporatedBlockHeight)
+	require.False(s.T(), status.SealCandidate)
+	require.Len(s.T(), status.Chunks, s.Chunks.Len())
+	for _, chunk := range status.Chunks {
+		require.Equal(s.T(), uint(0), chunk.Approvals)
+		require.Equal(s.T(), uint(len(s.AuthorizedVerifiers)), chunk.RequiredApprovals)
+		require.Equal(s.T(), uint(len(s.AuthorizedVerifiers)), chunk.AssignedVerifiers)
+		require.ElementsMatch(s.T(), s.ChunksAssignment.Verifiers(s.Chunks[chunk.ChunkIndex]), chunk.MissingVerifiers)
+		require.False(s.T(), chunk.Approved)
+	}
+
+	// approve the first chunk by one verifier, and the second chunk by all verifiers
+	verifiers := s.ChunksAssignment.Verifiers(s.Chunks[0])
+	approval := unittest.ResultApprovalFixture(unittest.WithChunk(s.Chunks[0].Index), unittest.WithApproverID(verifiers[0]))
+	require.NoError(s.T(), s.collector.ProcessApproval(approval))
+	for _, verID := range verifiers {
+		approval := unittest.ResultApprovalFixture(unittest.WithChunk(s.Chunks[1].Index), unittest.WithApproverID(verID))
+		require.NoError(s.T(), s.collector.ProcessApproval(approval))
+	}
+
+	s.sealsPL.On("ByID", incorporatedResultID).Return(&flow.IncorporatedResultSeal{}, true).Once()
+	status = s.collector.SealingStatus()
+	require.True(s.T(), status.SealCandidate)
+
+	first := status.Chunks[0]
+	require.Equal(s.T(), uint(1), first.Approvals)
+	require.False(s.T(), first.Approved)
+	require.ElementsMatch(s.T(), verifiers[1:], first.MissingVerifiers)
+
+	second := status.Chunks[1]
+	require.Equal(s.T(), uint(len(verifiers)), second.Approvals)
+	require.True(s.T(), second.Approved)
+	require.Empty(s.T(), second.MissingVerifiers)
+}
@@ -93,4 +93,9 @@ type AssignmentCollectorState interface {
 
 	// ProcessingStatus returns the AssignmentCollector's ProcessingStatus (state descriptor).
 	ProcessingStatus() ProcessingStatus
+
+	// SealingStatus returns a snapshot of the progress towards sealing the result, for
+	// debugging purposes. The finalized block height is used to determine whether
+	// incorporated results qualify for emergency sealing.
+	SealingStatus(finalizedBlockHeight uint64) *ResultSealingStatus
 }
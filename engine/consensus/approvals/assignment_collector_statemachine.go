@@ -121,6 +121,12 @@ func (asm *AssignmentCollectorStateMachine) RequestMissingApprovals(observer con
 	return collector.RequestMissingApprovals(observer, maxHeightForRequesting)
 }
 
+// SealingStatus returns a snapshot of the progress towards sealing the result.
+func (asm *AssignmentCollectorStateMachine) SealingStatus(finalizedBlockHeight uint64) *ResultSealingStatus {
+	collector := asm.atomicLoadCollector()
+	return collector.SealingStatus(finalizedBlockHeight)
+}
+
 // ProcessingStatus returns the AssignmentCollector's ProcessingStatus (state descriptor).
 func (asm *AssignmentCollectorStateMachine) ProcessingStatus() ProcessingStatus {
 	collector := asm.atomicLoadCollector()
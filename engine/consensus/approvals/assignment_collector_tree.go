@@ -223,6 +223,22 @@ func (t *AssignmentCollectorTree) GetCollectorsByInterval(from, to uint64) []Ass
 	return vertices
 }
 
+// GetCollectorsAtHeight returns all collectors, regardless of their processing status,
+// whose executed block has the given height.
+func (t *AssignmentCollectorTree) GetCollectorsAtHeight(height uint64) []AssignmentCollector {
+	var collectors []AssignmentCollector
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	iter := t.forest.GetVerticesAtLevel(height)
+	for iter.HasNext() {
+		vertex := iter.NextVertex().(*assignmentCollectorVertex)
+		collectors = append(collectors, vertex.collector)
+	}
+
+	return collectors
+}
+
 // LazyInitCollector is a helper structure that is used to return collector which is lazy initialized
 type LazyInitCollector struct {
 	Collector AssignmentCollector
@@ -36,6 +36,24 @@ func (ac *CachingAssignmentCollector) RequestMissingApprovals(consensus.SealingO
 	return 0, nil
 }
 
+// SealingStatus returns the incorporated results and number of approvals cached by the collector.
+// Approvals are not verified yet, hence no per-chunk progress is available.
+func (ac *CachingAssignmentCollector) SealingStatus(uint64) *ResultSealingStatus {
+	status := ac.sealingStatus(CachingApprovals)
+	status.CachedApprovals = uint(len(ac.approvalsCache.All()))
+	for _, incRes := range ac.incResCache.All() {
+		incorporated := IncorporatedResultSealingStatus{
+			IncorporatedBlockID: incRes.IncorporatedBlockID,
+		}
+		// the incorporating block was validated when the incorporated result was processed
+		if header, err := ac.headers.ByBlockID(incRes.IncorporatedBlockID); err == nil {
+			incorporated.IncorporatedBlockHeight = header.Height
+		}
+		status.IncorporatedResults = append(status.IncorporatedResults, incorporated)
+	}
+	return status
+}
+
 // ProcessIncorporatedResult starts tracking the approval for IncorporatedResult.
 // Method is idempotent.
 // Error Returns:
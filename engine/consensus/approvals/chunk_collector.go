@@ -53,3 +53,21 @@ func (c *ChunkApprovalCollector) GetMissingSigners() flow.IdentifierList {
 
 	return result
 }
+
+// Status returns the number of approvals collected for the chunk, the number of approvals
+// required for sealing, the number of assigned verifiers, and the assigned verifiers which
+// haven't provided an approval yet.
+func (c *ChunkApprovalCollector) Status(chunkIndex uint64) ChunkSealingStatus {
+	missing := c.GetMissingSigners()
+	c.lock.Lock()
+	approvals := c.chunkApprovals.NumberSignatures()
+	c.lock.Unlock()
+
+	return ChunkSealingStatus{
+		ChunkIndex:        chunkIndex,
+		Approvals:         approvals,
+		RequiredApprovals: c.requiredApprovalsForSealConstruction,
+		AssignedVerifiers: uint(len(c.assignment)),
+		MissingVerifiers:  missing,
+	}
+}
@@ -157,6 +157,22 @@ func (_m *AssignmentCollectorState) ResultID() flow.Identifier {
 	return r0
 }
 
+// SealingStatus provides a mock function with given fields: finalizedBlockHeight
+func (_m *AssignmentCollectorState) SealingStatus(finalizedBlockHeight uint64) *approvals.ResultSealingStatus {
+	ret := _m.Called(finalizedBlockHeight)
+
+	var r0 *approvals.ResultSealingStatus
+	if rf, ok := ret.Get(0).(func(uint64) *approvals.ResultSealingStatus); ok {
+		r0 = rf(finalizedBlockHeight)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*approvals.ResultSealingStatus)
+		}
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewAssignmentCollectorState interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,66 @@
+package approvals
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ResultSealingStatus describes the progress towards sealing an execution result. It is
+// a snapshot of the state of the AssignmentCollector for the result, intended for
+// debugging why a block has not been sealed yet.
+type ResultSealingStatus struct {
+	ResultID         flow.Identifier `json:"result_id"`
+	PreviousResultID flow.Identifier `json:"previous_result_id"`
+	BlockID          flow.Identifier `json:"block_id"`
+	BlockHeight      uint64          `json:"block_height"`
+	// ProcessingStatus is the state of the AssignmentCollector for the result. Approvals are
+	// only verified once the result's parent is sealable, i.e. in state VerifyingApprovals.
+	ProcessingStatus string `json:"processing_status"`
+	// CachedApprovals is the number of approvals that were received, but are not verified
+	// yet, because the collector is caching approvals.
+	CachedApprovals uint `json:"cached_approvals"`
+	// IncorporatedResults lists the status for each block incorporating the result.
+	// Each incorporating block determines its own verifier assignment.
+	IncorporatedResults []IncorporatedResultSealingStatus `json:"incorporated_results"`
+}
+
+// IncorporatedResultSealingStatus describes the progress towards sealing an execution
+// result for the verifier assignment determined by one incorporating block.
+type IncorporatedResultSealingStatus struct {
+	IncorporatedBlockID     flow.Identifier `json:"incorporated_block_id"`
+	IncorporatedBlockHeight uint64          `json:"incorporated_block_height"`
+	// Chunks lists the approval progress for each chunk. It is only populated
+	// while the collector is verifying approvals.
+	Chunks []ChunkSealingStatus `json:"chunks,omitempty"`
+	// SealCandidate is true if a candidate seal for the incorporated result is in the
+	// seals mempool, i.e. the result has all approvals it needs (or was emergency sealed).
+	SealCandidate bool `json:"seal_candidate"`
+	// EmergencySealable is true if the incorporated result is old enough to qualify
+	// for emergency sealing. Emergency sealing only applies if it is enabled.
+	EmergencySealable bool `json:"emergency_sealable"`
+}
+
+// ChunkSealingStatus describes the approvals collected for a single chunk.
+type ChunkSealingStatus struct {
+	ChunkIndex        uint64 `json:"chunk_index"`
+	Approvals         uint   `json:"approvals"`
+	RequiredApprovals uint   `json:"required_approvals"`
+	// Approved is true if sufficient approvals were collected for the chunk.
+	Approved bool `json:"approved"`
+	// AssignedVerifiers is the number of verifiers assigned to the chunk.
+	AssignedVerifiers uint `json:"assigned_verifiers"`
+	// MissingVerifiers lists the assigned verifiers which have not yet approved the chunk.
+	MissingVerifiers flow.IdentifierList `json:"missing_verifiers"`
+}
+
+// sealingStatus returns the status of the result with the given processing status, without
+// any information about incorporated results.
+func (cb *AssignmentCollectorBase) sealingStatus(status ProcessingStatus) *ResultSealingStatus {
+	return &ResultSealingStatus{
+		ResultID:            cb.resultID,
+		PreviousResultID:    cb.result.PreviousResultID,
+		BlockID:             cb.result.BlockID,
+		BlockHeight:         cb.executedBlock.Height,
+		ProcessingStatus:    status.String(),
+		IncorporatedResults: []IncorporatedResultSealingStatus{},
+	}
+}
@@ -109,6 +109,18 @@ func (ac *VerifyingAssignmentCollector) CheckEmergencySealing(observer consensus
 	return nil
 }
 
+// SealingStatus returns the progress towards sealing the result, for every assignment
+// determined by the blocks incorporating it.
+func (ac *VerifyingAssignmentCollector) SealingStatus(finalizedBlockHeight uint64) *ResultSealingStatus {
+	status := ac.sealingStatus(VerifyingApprovals)
+	for _, collector := range ac.allCollectors() {
+		incorporated := collector.SealingStatus()
+		incorporated.EmergencySealable = ac.emergencySealable(collector, finalizedBlockHeight)
+		status.IncorporatedResults = append(status.IncorporatedResults, incorporated)
+	}
+	return status
+}
+
 func (ac *VerifyingAssignmentCollector) ProcessingStatus() ProcessingStatus {
 	return VerifyingApprovals
 }
@@ -133,6 +133,8 @@ func (e *ReactorEngine) EpochCommittedPhaseStarted(currentEpochCounter uint64, f
 // startDKGForEpoch starts the DKG instance for the given epoch, only if we have
 // never started the DKG during setup phase for the given epoch. This allows consensus nodes which
 // boot from a state snapshot within the EpochSetup phase to run the DKG.
+// If the DKG was started before, for example by this node prior to a restart,
+// we attempt to resume it from its persisted progress (see resumeDKGForEpoch).
 //
 // It starts a new controller for the epoch and registers the triggers to regularly
 // query the DKG smart-contract and transition between phases at the specified views.
@@ -147,14 +149,14 @@ func (e *ReactorEngine) startDKGForEpoch(currentEpochCounter uint64, first *flow
 		Hex("first_block_id", firstID[:]).        // id of first block in EpochSetup phase
 		Logger()
 
-	// if we have started the dkg for this epoch already, exit
+	// if we have started the dkg for this epoch already, try to resume it
 	started, err := e.dkgState.GetDKGStarted(nextEpochCounter)
 	if err != nil {
 		// unexpected storage-level error
 		log.Fatal().Err(err).Msg("could not check whether DKG is started")
 	}
 	if started {
-		log.Warn().Msg("DKG started before, skipping starting the DKG for this epoch")
+		e.resumeDKGForEpoch(currentEpochCounter, first)
 		return
 	}
 
@@ -188,6 +190,7 @@ func (e *ReactorEngine) startDKGForEpoch(currentEpochCounter uint64, first *flow
 	controller, err := e.controllerFactory.Create(
 		dkgmodule.CanonicalInstanceID(first.ChainID, nextEpochCounter),
 		committee,
+		nextEpochCounter,
 		curDKGInfo.seed,
 	)
 	if err != nil {
@@ -205,32 +208,152 @@ func (e *ReactorEngine) startDKGForEpoch(currentEpochCounter uint64, first *flow
 		}
 	})
 
-	// NOTE:
-	// We register two callbacks for views that mark a state transition: one for
-	// polling broadcast messages, and one for triggering the phase transition.
-	// It is essential that all polled broadcast messages are processed before
-	// starting the phase transition. Here we register the polling callback
-	// before the phase transition, which guarantees that it will be called
-	// before because callbacks for the same views are executed on a FIFO basis.
-	// Moreover, the poll callback does not return until all received messages
-	// are processed by the underlying DKG controller (as guaranteed by the
-	// specifications and implementations of the DKGBroker and DKGController
-	// interfaces).
-
-	for view := curDKGInfo.phase1FinalView; view > first.View; view -= e.pollStep {
-		e.registerPoll(view)
+	e.registerPhaseTriggers(curDKGInfo, first.View, dkgmodule.Phase1, nextEpochCounter)
+}
+
+// resumeDKGForEpoch resumes the DKG instance for the given epoch, which was
+// started before this node restarted. The DKG is resumed only if it has not
+// ended yet, and its progress was persisted. Otherwise, this node will not
+// participate in the DKG for this epoch.
+//
+// The controller is recreated from the persisted progress, and the triggers for
+// the remaining polls and phase transitions are registered. Triggers for phase
+// transitions which passed while the node was offline fire upon the next
+// finalized block, preceded by a poll of the DKG smart-contract.
+func (e *ReactorEngine) resumeDKGForEpoch(currentEpochCounter uint64, first *flow.Header) {
+
+	firstID := first.ID()
+	nextEpochCounter := currentEpochCounter + 1
+	log := e.log.With().
+		Uint64("cur_epoch", currentEpochCounter).
+		Uint64("next_epoch", nextEpochCounter).
+		Uint64("first_block_view", first.View).
+		Hex("first_block_id", firstID[:]).
+		Logger()
+
+	_, err := e.dkgState.GetDKGEndState(nextEpochCounter)
+	if err == nil {
+		log.Warn().Msg("DKG started and ended before, skipping starting the DKG for this epoch")
+		return
+	}
+	if !errors.Is(err, storage.ErrNotFound) {
+		// unexpected storage-level error
+		log.Fatal().Err(err).Msg("could not check whether DKG has ended")
+	}
+
+	progress, err := e.dkgState.GetDKGResumeState(nextEpochCounter)
+	if errors.Is(err, storage.ErrNotFound) {
+		log.Warn().Msg("DKG started before, but its progress was not persisted, skipping starting the DKG for this epoch")
+		return
+	}
+	if err != nil {
+		// unexpected storage-level error
+		log.Fatal().Err(err).Msg("could not retrieve DKG progress")
+	}
+
+	// the controller resumes in the phase reached by the logged timeouts, which
+	// may precede the persisted phase, so the triggers are registered from there
+	replayLog, err := e.dkgState.RetrieveDKGLog(nextEpochCounter)
+	if err != nil {
+		// unexpected storage-level error
+		log.Fatal().Err(err).Msg("could not retrieve DKG log")
+	}
+	phase, err := dkgmodule.ResumePhase(replayLog)
+	if err != nil {
+		// the log is only written by the controller, so it can't contain more timeouts
+		log.Fatal().Err(err).Msg("could not determine DKG phase to resume in")
 	}
-	e.registerPhaseTransition(curDKGInfo.phase1FinalView, dkgmodule.Phase1, e.controller.EndPhase1)
 
-	for view := curDKGInfo.phase2FinalView; view > curDKGInfo.phase1FinalView; view -= e.pollStep {
-		e.registerPoll(view)
+	curDKGInfo, err := e.getDKGInfo(firstID)
+	if err != nil {
+		// unexpected storage-level error
+		log.Fatal().Err(err).Msg("could not retrieve epoch info")
 	}
-	e.registerPhaseTransition(curDKGInfo.phase2FinalView, dkgmodule.Phase2, e.controller.EndPhase2)
+	committee := curDKGInfo.identities.Filter(filter.IsVotingConsensusCommitteeMember)
 
-	for view := curDKGInfo.phase3FinalView; view > curDKGInfo.phase2FinalView; view -= e.pollStep {
-		e.registerPoll(view)
+	finalized, err := e.State.Final().Head()
+	if err != nil {
+		// unexpected storage-level error
+		log.Fatal().Err(err).Msg("could not retrieve finalized header")
+	}
+
+	log.Info().
+		Str("persisted_phase", dkgmodule.State(progress.Phase).String()).
+		Str("resume_phase", phase.String()).
+		Uint64("finalized_view", finalized.View).
+		Uint64("phase1", curDKGInfo.phase1FinalView).
+		Uint64("phase2", curDKGInfo.phase2FinalView).
+		Uint64("phase3", curDKGInfo.phase3FinalView).
+		Msg("resuming DKG")
+
+	controller, err := e.controllerFactory.Resume(
+		dkgmodule.CanonicalInstanceID(first.ChainID, nextEpochCounter),
+		committee,
+		nextEpochCounter,
+	)
+	if err != nil {
+		// the progress was found above, so no errors are expected here
+		log.Fatal().Err(err).Msg("could not resume DKG controller")
+	}
+	e.controller = controller
+
+	e.unit.Launch(func() {
+		log.Info().Msg("DKG Run (resumed)")
+		err := e.controller.Run()
+		if err != nil {
+			log.Fatal().Err(err).Msg("DKG Run error")
+		}
+	})
+
+	// a DKG which ended locally, but whose results were not stored before the
+	// restart, is resumed in phase 3 and ended again
+	e.registerPhaseTriggers(curDKGInfo, finalized.View, phase, nextEpochCounter)
+}
+
+// registerPhaseTriggers registers the callbacks to poll the DKG smart-contract
+// and transition between phases, for all phases starting from the given phase.
+// Polls are registered every pollStep views, for views after startView and
+// after the final view of the preceding phase. If the final view of a phase is not after startView, a single poll is
+// registered for the final view, so that all broadcast messages are read
+// before the phase transition.
+//
+// NOTE:
+// We register two callbacks for views that mark a state transition: one for
+// polling broadcast messages, and one for triggering the phase transition.
+// It is essential that all polled broadcast messages are processed before
+// starting the phase transition. Here we register the polling callback
+// before the phase transition, which guarantees that it will be called
+// before because callbacks for the same views are executed on a FIFO basis.
+// Moreover, the poll callback does not return until all received messages
+// are processed by the underlying DKG controller (as guaranteed by the
+// specifications and implementations of the DKGBroker and DKGController
+// interfaces).
+func (e *ReactorEngine) registerPhaseTriggers(info *dkgInfo, startView uint64, fromPhase dkgmodule.State, nextEpochCounter uint64) {
+	phases := []struct {
+		phase      dkgmodule.State
+		finalView  uint64
+		transition func() error
+	}{
+		{dkgmodule.Phase1, info.phase1FinalView, e.controller.EndPhase1},
+		{dkgmodule.Phase2, info.phase2FinalView, e.controller.EndPhase2},
+		{dkgmodule.Phase3, info.phase3FinalView, e.end(nextEpochCounter)},
+	}
+
+	lowerView := startView
+	for _, p := range phases {
+		if p.phase >= fromPhase {
+			if p.finalView <= lowerView {
+				e.registerPoll(p.finalView)
+			}
+			for view := p.finalView; view > lowerView; view -= e.pollStep {
+				e.registerPoll(view)
+			}
+			e.registerPhaseTransition(p.finalView, p.phase, p.transition)
+		}
+		if p.finalView > lowerView {
+			lowerView = p.finalView
+		}
 	}
-	e.registerPhaseTransition(curDKGInfo.phase3FinalView, dkgmodule.Phase3, e.end(nextEpochCounter))
 }
 
 // handleEpochCommittedPhaseStarted is invoked upon the transition to the EpochCommitted
@@ -14,6 +14,7 @@ import (
 
 	"github.com/onflow/flow-go/crypto"
 	"github.com/onflow/flow-go/engine/consensus/dkg"
+	model "github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
 	dkgmodule "github.com/onflow/flow-go/module/dkg"
 	module "github.com/onflow/flow-go/module/mock"
@@ -163,6 +164,7 @@ func (suite *ReactorEngineSuite_SetupPhase) SetupTest() {
 	suite.factory.On("Create",
 		dkgmodule.CanonicalInstanceID(suite.firstBlock.ChainID, suite.NextEpochCounter()),
 		suite.committee,
+		suite.NextEpochCounter(),
 		mock.Anything,
 	).Return(suite.controller, nil)
 
@@ -238,8 +240,10 @@ func (suite *ReactorEngineSuite_SetupPhase) TestRunDKG_StartupInSetupPhase_DKGAl
 
 	// we are in the EpochSetup phase
 	suite.snapshot.On("Phase").Return(flow.EpochPhaseSetup, nil).Once()
-	// the dkg for this epoch has been started
+	// the dkg for this epoch has been started, but its progress was not persisted
 	suite.dkgState.On("GetDKGStarted", suite.NextEpochCounter()).Return(true, nil).Once()
+	suite.dkgState.On("GetDKGEndState", suite.NextEpochCounter()).Return(flow.DKGEndStateUnknown, storerr.ErrNotFound).Once()
+	suite.dkgState.On("GetDKGResumeState", suite.NextEpochCounter()).Return(nil, storerr.ErrNotFound).Once()
 
 	// start up the engine
 	unittest.AssertClosesBefore(suite.T(), suite.engine.Ready(), time.Second)
@@ -248,13 +252,88 @@ func (suite *ReactorEngineSuite_SetupPhase) TestRunDKG_StartupInSetupPhase_DKGAl
 	suite.factory.AssertNotCalled(suite.T(), "Create",
 		dkgmodule.CanonicalInstanceID(suite.firstBlock.ChainID, suite.NextEpochCounter()),
 		suite.committee,
+		suite.NextEpochCounter(),
 		mock.Anything,
 	)
+	suite.factory.AssertNotCalled(suite.T(), "Resume",
+		dkgmodule.CanonicalInstanceID(suite.firstBlock.ChainID, suite.NextEpochCounter()),
+		suite.committee,
+		suite.NextEpochCounter(),
+	)
 
 	// we should log a warning that the DKG has already started
 	suite.Assert().Equal(1, suite.warnsLogged)
 }
 
+// TestRunDKG_StartupInSetupPhase_ResumeDKG tests that the DKG is resumed from
+// its persisted progress, when the engine starts up during the EpochSetup phase,
+// and the DKG for this epoch has been started previously but not ended. This is
+// the case for consensus nodes which restart during the DKG.
+//
+// The node restarts in phase 2, after the final view of phase 1 (150) was
+// finalized and the transition to phase 2 was persisted, but before the timeout
+// ending phase 1 was logged. Hence, the controller resumes in phase 1, which
+// must be ended upon the next finalized block, and the remaining polls and
+// phase transitions must be triggered.
+func (suite *ReactorEngineSuite_SetupPhase) TestRunDKG_StartupInSetupPhase_ResumeDKG() {
+
+	// the node restarts after the block with view 160 was finalized
+	restartView := uint64(160)
+	finalSnapshot := new(protocol.Snapshot)
+	finalSnapshot.On("Head").Return(suite.blocksByView[restartView], nil)
+	suite.state.ExpectedCalls = nil
+	suite.state.On("AtBlockID", suite.firstBlock.ID()).Return(suite.snapshot)
+	suite.state.On("Final").Return(finalSnapshot)
+
+	// the dkg for this epoch has been started, and its progress was persisted in
+	// phase 2, but no timeout was logged
+	suite.dkgState.On("GetDKGStarted", suite.NextEpochCounter()).Return(true, nil).Once()
+	suite.dkgState.On("GetDKGEndState", suite.NextEpochCounter()).Return(flow.DKGEndStateUnknown, storerr.ErrNotFound).Once()
+	suite.dkgState.On("GetDKGResumeState", suite.NextEpochCounter()).Return(&model.ResumeState{
+		Seed:  unittest.SeedFixture(crypto.SeedMinLenDKG),
+		Phase: uint32(dkgmodule.Phase2),
+	}, nil).Once()
+	suite.dkgState.On("RetrieveDKGLog", suite.NextEpochCounter()).Return([]*model.LogEntry{
+		{Seq: 0, Type: model.LogEntryBroadcastMessage, Data: unittest.RandomBytes(16)},
+	}, nil).Once()
+
+	// we expect one catch-up poll for phase 1 at view 150, followed by the
+	// regular polls for views 170..250
+	suite.controller.ExpectedCalls = nil
+	suite.controller.On("Run").Return(nil).Once()
+	suite.controller.On("EndPhase1").Return(nil).Once()
+	suite.controller.On("EndPhase2").Return(nil).Once()
+	suite.controller.On("End").Return(nil).Once()
+	suite.controller.On("Poll", mock.Anything).Return(nil).Times(10)
+	suite.controller.On("GetArtifacts").Return(suite.expectedPrivateKey, nil, nil).Once()
+	suite.controller.On("SubmitResult").Return(nil).Once()
+	suite.factory.On("Resume",
+		dkgmodule.CanonicalInstanceID(suite.firstBlock.ChainID, suite.NextEpochCounter()),
+		suite.committee,
+		suite.NextEpochCounter(),
+	).Return(suite.controller, nil).Once()
+
+	// protocol event indicating the setup phase is starting
+	suite.engine.EpochSetupPhaseStarted(suite.epochCounter, suite.firstBlock)
+
+	for view := restartView + dkg.DefaultPollStep; view <= 250; view += dkg.DefaultPollStep {
+		suite.viewEvents.BlockFinalized(suite.blocksByView[view])
+	}
+
+	// check that the appropriate callbacks were registered
+	time.Sleep(50 * time.Millisecond)
+	suite.controller.AssertExpectations(suite.T())
+	suite.factory.AssertNotCalled(suite.T(), "Create",
+		dkgmodule.CanonicalInstanceID(suite.firstBlock.ChainID, suite.NextEpochCounter()),
+		suite.committee,
+		suite.NextEpochCounter(),
+		mock.Anything,
+	)
+	suite.dkgState.AssertNotCalled(suite.T(), "SetDKGStarted", suite.NextEpochCounter())
+	// happy path - no warn logs expected
+	suite.Assert().Equal(0, suite.warnsLogged)
+}
+
 // ReactorEngineSuite_CommittedPhase tests the Reactor engine's operation
 // during the transition to the EpochCommitted phase, after the DKG has
 // completed locally, and we are comparing our local results to the
@@ -642,6 +642,29 @@ func (c *Core) requestPendingApprovals(observation consensus.SealingObservation,
 	return nil
 }
 
+// ResultSealingStatuses returns the sealing status of all results for the given block which
+// are tracked by the assignment collector tree. Concurrency safe.
+func (c *Core) ResultSealingStatuses(block *flow.Header) []*approvals.ResultSealingStatus {
+	finalizedHeight := c.counterLastFinalizedHeight.Value()
+	emergencySealingActive := c.sealingConfigsGetter.EmergencySealingActiveConst()
+
+	blockID := block.ID()
+	var statuses []*approvals.ResultSealingStatus
+	for _, collector := range c.collectorTree.GetCollectorsAtHeight(block.Height) {
+		if collector.BlockID() != blockID {
+			continue
+		}
+		status := collector.SealingStatus(finalizedHeight)
+		if !emergencySealingActive {
+			for i := range status.IncorporatedResults {
+				status.IncorporatedResults[i].EmergencySealable = false
+			}
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
 // getOutdatedBlockIDsFromRootSealingSegment finds all references to unknown blocks
 // by execution results within the sealing segment. In general we disallow references
 // to unknown blocks, but execution results incorporated within the sealing segment
@@ -270,6 +270,47 @@ func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ApprovalsAfter
 	s.SealsPL.AssertCalled(s.T(), "Add", mock.Anything)
 }
 
+// TestResultSealingStatuses tests that the sealing status is reported for the results of the requested
+// block tracked by the assignment collector tree, including the approvals verified for each chunk.
+func (s *ApprovalProcessingCoreTestSuite) TestResultSealingStatuses() {
+	s.PublicKey.On("Verify", mock.Anything, mock.Anything, mock.Anything).Return(true, nil)
+	s.SealsPL.On("ByID", mock.Anything).Return(nil, false)
+
+	// no results are tracked yet
+	require.Empty(s.T(), s.core.ResultSealingStatuses(s.Block))
+
+	err := s.core.processIncorporatedResult(s.IncorporatedResult)
+	require.NoError(s.T(), err)
+	approval := unittest.ResultApprovalFixture(unittest.WithChunk(s.Chunks[0].Index),
+		unittest.WithApproverID(s.VerID),
+		unittest.WithBlockID(s.Block.ID()),
+		unittest.WithExecutionResultID(s.IncorporatedResult.Result.ID()))
+	err = s.core.processApproval(approval)
+	require.NoError(s.T(), err)
+
+	statuses := s.core.ResultSealingStatuses(s.Block)
+	require.Len(s.T(), statuses, 1)
+	status := statuses[0]
+	require.Equal(s.T(), s.IncorporatedResult.Result.ID(), status.ResultID)
+	require.Equal(s.T(), s.Block.ID(), status.BlockID)
+	require.Equal(s.T(), approvals.VerifyingApprovals.String(), status.ProcessingStatus)
+
+	require.Len(s.T(), status.IncorporatedResults, 1)
+	incorporated := status.IncorporatedResults[0]
+	require.Equal(s.T(), s.IncorporatedBlock.ID(), incorporated.IncorporatedBlockID)
+	require.False(s.T(), incorporated.SealCandidate)
+	// emergency sealing is not active
+	require.False(s.T(), incorporated.EmergencySealable)
+	require.Len(s.T(), incorporated.Chunks, s.Chunks.Len())
+	require.Equal(s.T(), uint(1), incorporated.Chunks[0].Approvals)
+	require.NotContains(s.T(), incorporated.Chunks[0].MissingVerifiers, s.VerID)
+	require.Equal(s.T(), uint(0), incorporated.Chunks[1].Approvals)
+
+	// results of other blocks at the same height are not reported
+	sibling := unittest.BlockHeaderWithParentFixture(s.ParentBlock)
+	require.Empty(s.T(), s.core.ResultSealingStatuses(sibling))
+}
+
 // TestProcessIncorporated_ProcessingInvalidApproval tests that processing invalid approval when result is discovered
 // is correctly handled in case of sentinel error
 func (s *ApprovalProcessingCoreTestSuite) TestProcessIncorporated_ProcessingInvalidApproval() {
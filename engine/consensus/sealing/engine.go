@@ -10,6 +10,7 @@ import (
 	"github.com/onflow/flow-go/engine"
 	"github.com/onflow/flow-go/engine/common/fifoqueue"
 	"github.com/onflow/flow-go/engine/consensus"
+	"github.com/onflow/flow-go/engine/consensus/approvals"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module"
@@ -58,6 +59,7 @@ type Engine struct {
 	unit                       *engine.Unit
 	workerPool                 *workerpool.WorkerPool
 	core                       consensus.SealingCore
+	statuses                   ResultStatusProvider
 	log                        zerolog.Logger
 	me                         module.Local
 	headers                    storage.Headers
@@ -149,6 +151,7 @@ func NewEngine(log zerolog.Logger,
 		return nil, fmt.Errorf("could not repopulate assignment collectors tree: %w", err)
 	}
 	e.core = core
+	e.statuses = core
 
 	return e, nil
 }
@@ -423,6 +426,12 @@ func (e *Engine) Done() <-chan struct{} {
 	})
 }
 
+// ResultSealingStatuses returns the sealing status of all results for the given block,
+// which are tracked by the sealing core. Implements ResultStatusProvider.
+func (e *Engine) ResultSealingStatuses(block *flow.Header) []*approvals.ResultSealingStatus {
+	return e.statuses.ResultSealingStatuses(block)
+}
+
 // OnFinalizedBlock implements the `OnFinalizedBlock` callback from the `hotstuff.FinalizationConsumer`
 // (1) Informs sealing.Core about finalization of respective block.
 //
@@ -0,0 +1,156 @@
+package sealing
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultStatusBlockLimit is the default maximum number of unsealed blocks included in a SealingStatus.
+const DefaultStatusBlockLimit = 20
+
+// ResultStatusProvider provides the sealing status of the results tracked by the sealing logic.
+// Implementations must be concurrency safe.
+type ResultStatusProvider interface {
+	// ResultSealingStatuses returns the sealing status of all results for the given block.
+	ResultSealingStatuses(block *flow.Header) []*approvals.ResultSealingStatus
+}
+
+// SealingStatus describes why the finalized blocks following the latest sealed block are not
+// sealed yet. It is intended for debugging stalled sealing.
+type SealingStatus struct {
+	LatestSealedBlockID   flow.Identifier `json:"latest_sealed_block_id"`
+	LatestSealedHeight    uint64          `json:"latest_sealed_height"`
+	LatestFinalizedHeight uint64          `json:"latest_finalized_height"`
+	// RequiredApprovals is the number of approvals required per chunk to construct a seal.
+	RequiredApprovals uint `json:"required_approvals"`
+	// EmergencySealingActive is true if emergency sealing is enabled on this node.
+	EmergencySealingActive bool `json:"emergency_sealing_active"`
+	// Blocks lists the unsealed finalized blocks from lowest to highest height.
+	Blocks []BlockSealingStatus `json:"blocks"`
+}
+
+// BlockSealingStatus describes the sealing progress of a single unsealed block.
+type BlockSealingStatus struct {
+	BlockID flow.Identifier `json:"block_id"`
+	Height  uint64          `json:"height"`
+	// Receipts lists the execution receipts for the block held in the receipts mempool.
+	Receipts []ReceiptStatus `json:"receipts"`
+	// SealingResultIDs lists the results for the block whose approvals are currently being
+	// verified, i.e. the results which are candidates for being sealed.
+	SealingResultIDs flow.IdentifierList `json:"sealing_result_ids"`
+	// Results lists the progress towards sealing for every known result for the block.
+	Results []*approvals.ResultSealingStatus `json:"results"`
+}
+
+// ReceiptStatus describes an execution receipt seen for an unsealed block.
+type ReceiptStatus struct {
+	ReceiptID  flow.Identifier `json:"receipt_id"`
+	ResultID   flow.Identifier `json:"result_id"`
+	ExecutorID flow.Identifier `json:"executor_id"`
+}
+
+// StatusReporter compiles the SealingStatus from the protocol state, the receipts mempool and
+// the assignment collectors of the sealing engine. The reporter is created before the sealing
+// engine, which is attached once it is instantiated. Until then, reports contain no results.
+// StatusReporter is concurrency safe.
+type StatusReporter struct {
+	state          protocol.State
+	headers        storage.Headers
+	receipts       mempool.ExecutionTree
+	sealingConfigs module.SealingConfigsGetter
+
+	lock     sync.RWMutex
+	statuses ResultStatusProvider
+}
+
+// NewStatusReporter creates a new StatusReporter.
+func NewStatusReporter(state protocol.State, headers storage.Headers, receipts mempool.ExecutionTree, sealingConfigs module.SealingConfigsGetter) *StatusReporter {
+	return &StatusReporter{
+		state:          state,
+		headers:        headers,
+		receipts:       receipts,
+		sealingConfigs: sealingConfigs,
+	}
+}
+
+// Attach sets the provider for the status of the results tracked by the sealing logic.
+func (r *StatusReporter) Attach(statuses ResultStatusProvider) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.statuses = statuses
+}
+
+// SealingStatus returns the sealing status of at most `limit` of the lowest unsealed finalized blocks.
+// No errors are expected during normal operation.
+func (r *StatusReporter) SealingStatus(limit uint) (*SealingStatus, error) {
+	sealed, err := r.state.Sealed().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest sealed block: %w", err)
+	}
+	finalized, err := r.state.Final().Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get latest finalized block: %w", err)
+	}
+
+	status := &SealingStatus{
+		LatestSealedBlockID:    sealed.ID(),
+		LatestSealedHeight:     sealed.Height,
+		LatestFinalizedHeight:  finalized.Height,
+		RequiredApprovals:      r.sealingConfigs.RequireApprovalsForSealConstructionDynamicValue(),
+		EmergencySealingActive: r.sealingConfigs.EmergencySealingActiveConst(),
+		Blocks:                 []BlockSealingStatus{},
+	}
+
+	r.lock.RLock()
+	statuses := r.statuses
+	r.lock.RUnlock()
+
+	for height := sealed.Height + 1; height <= finalized.Height && uint(len(status.Blocks)) < limit; height++ {
+		block, err := r.headers.ByHeight(height)
+		if err != nil {
+			return nil, fmt.Errorf("could not get finalized block at height %d: %w", height, err)
+		}
+		status.Blocks = append(status.Blocks, r.blockStatus(block, statuses))
+	}
+
+	return status, nil
+}
+
+// blockStatus returns the sealing status of the given block. If no ResultStatusProvider is
+// attached yet, the status contains only the known receipts.
+func (r *StatusReporter) blockStatus(block *flow.Header, statuses ResultStatusProvider) BlockSealingStatus {
+	status := BlockSealingStatus{
+		BlockID:          block.ID(),
+		Height:           block.Height,
+		Receipts:         []ReceiptStatus{},
+		SealingResultIDs: flow.IdentifierList{},
+		Results:          []*approvals.ResultSealingStatus{},
+	}
+
+	for _, receipt := range r.receipts.ReceiptsForBlock(block) {
+		status.Receipts = append(status.Receipts, ReceiptStatus{
+			ReceiptID:  receipt.ID(),
+			ResultID:   receipt.ExecutionResult.ID(),
+			ExecutorID: receipt.ExecutorID,
+		})
+	}
+
+	if statuses == nil {
+		return status
+	}
+	for _, result := range statuses.ResultSealingStatuses(block) {
+		if result.ProcessingStatus == approvals.VerifyingApprovals.String() {
+			status.SealingResultIDs = append(status.SealingResultIDs, result.ResultID)
+		}
+		status.Results = append(status.Results, result)
+	}
+
+	return status
+}
@@ -0,0 +1,67 @@
+package sealing
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/module/httpserver"
+)
+
+// StatusEndpoint serves the SealingStatus. The optional `limit` query parameter sets the
+// maximum number of unsealed blocks to report, it defaults to DefaultStatusBlockLimit.
+const StatusEndpoint = "/v1/sealing_status"
+
+// StatusServer is the http server serving the SealingStatus of a consensus node.
+type StatusServer struct {
+	*httpserver.Server
+}
+
+// NewStatusServer creates a new server that will listen on the specified address and serve
+// the sealing status compiled by the given reporter.
+func NewStatusServer(log zerolog.Logger, reporter *StatusReporter, addr string) *StatusServer {
+	log = log.With().Str("component", "sealing_status_server").Str("endpoint", StatusEndpoint).Logger()
+
+	return &StatusServer{
+		Server: httpserver.NewServer(log, "sealing status", addr, newStatusHandler(log, reporter)),
+	}
+}
+
+// newStatusHandler returns the handler serving the sealing status endpoint.
+func newStatusHandler(log zerolog.Logger, reporter *StatusReporter) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(StatusEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		limit := uint64(DefaultStatusBlockLimit)
+		if value := r.URL.Query().Get("limit"); value != "" {
+			var err error
+			limit, err = strconv.ParseUint(value, 10, 32)
+			if err != nil || limit == 0 {
+				http.Error(w, "limit must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+
+		status, err := reporter.SealingStatus(uint(limit))
+		if err != nil {
+			log.Error().Err(err).Msg("could not compile sealing status")
+			http.Error(w, "could not compile sealing status", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(status)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not write sealing status")
+		}
+	})
+
+	return mux
+}
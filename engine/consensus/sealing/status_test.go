@@ -0,0 +1,158 @@
+package sealing
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/consensus/approvals"
+	"github.com/onflow/flow-go/model/flow"
+	mempool "github.com/onflow/flow-go/module/mempool/mock"
+	mockmodule "github.com/onflow/flow-go/module/mock"
+	mockprotocol "github.com/onflow/flow-go/state/protocol/mock"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// resultStatuses is a ResultStatusProvider serving fixed statuses per block.
+type resultStatuses map[flow.Identifier][]*approvals.ResultSealingStatus
+
+func (r resultStatuses) ResultSealingStatuses(block *flow.Header) []*approvals.ResultSealingStatus {
+	return r[block.ID()]
+}
+
+// TestStatusReporter tests that the sealing status reports the unsealed finalized blocks, from lowest
+// to highest height up to the limit, along with their receipts and the status of their results.
+func TestStatusReporter(t *testing.T) {
+	// finalized chain: sealed <- blocks[0] <- blocks[1] <- blocks[2]
+	sealed := unittest.BlockHeaderFixture()
+	blocks := []*flow.Header{unittest.BlockHeaderWithParentFixture(sealed)}
+	for i := 1; i < 3; i++ {
+		blocks = append(blocks, unittest.BlockHeaderWithParentFixture(blocks[i-1]))
+	}
+
+	state := mockprotocol.NewState(t)
+	sealedSnapshot := mockprotocol.NewSnapshot(t)
+	sealedSnapshot.On("Head").Return(sealed, nil)
+	state.On("Sealed").Return(sealedSnapshot)
+	finalSnapshot := mockprotocol.NewSnapshot(t)
+	finalSnapshot.On("Head").Return(blocks[2], nil)
+	state.On("Final").Return(finalSnapshot)
+
+	headers := mockstorage.NewHeaders(t)
+	for _, block := range blocks {
+		headers.On("ByHeight", block.Height).Return(block, nil).Maybe()
+	}
+
+	receipt := unittest.ExecutionReceiptFixture(unittest.WithResult(unittest.ExecutionResultFixture(unittest.WithBlock(&flow.Block{Header: blocks[0]}))))
+	receipts := mempool.NewExecutionTree(t)
+	receipts.On("ReceiptsForBlock", blocks[0]).Return([]*flow.ExecutionReceipt{receipt}).Maybe()
+	for _, block := range blocks[1:] {
+		receipts.On("ReceiptsForBlock", block).Return(nil).Maybe()
+	}
+
+	configs := mockmodule.NewSealingConfigsGetter(t)
+	configs.On("RequireApprovalsForSealConstructionDynamicValue").Return(uint(2))
+	configs.On("EmergencySealingActiveConst").Return(true)
+
+	reporter := NewStatusReporter(state, headers, receipts, configs)
+
+	t.Run("without sealing engine", func(t *testing.T) {
+		status, err := reporter.SealingStatus(DefaultStatusBlockLimit)
+		require.NoError(t, err)
+
+		assert.Equal(t, sealed.ID(), status.LatestSealedBlockID)
+		assert.Equal(t, sealed.Height, status.LatestSealedHeight)
+		assert.Equal(t, blocks[2].Height, status.LatestFinalizedHeight)
+		assert.Equal(t, uint(2), status.RequiredApprovals)
+		assert.True(t, status.EmergencySealingActive)
+
+		require.Len(t, status.Blocks, 3)
+		for i, block := range status.Blocks {
+			assert.Equal(t, blocks[i].ID(), block.BlockID)
+			assert.Equal(t, blocks[i].Height, block.Height)
+			assert.Empty(t, block.Results)
+		}
+		assert.Equal(t, []ReceiptStatus{{
+			ReceiptID:  receipt.ID(),
+			ResultID:   receipt.ExecutionResult.ID(),
+			ExecutorID: receipt.ExecutorID,
+		}}, status.Blocks[0].Receipts)
+	})
+
+	t.Run("with sealing engine", func(t *testing.T) {
+		verifying := &approvals.ResultSealingStatus{
+			ResultID:         receipt.ExecutionResult.ID(),
+			ProcessingStatus: approvals.VerifyingApprovals.String(),
+		}
+		caching := &approvals.ResultSealingStatus{
+			ResultID:         unittest.IdentifierFixture(),
+			ProcessingStatus: approvals.CachingApprovals.String(),
+		}
+		reporter.Attach(resultStatuses{
+			blocks[0].ID(): {verifying, caching},
+		})
+
+		// the limit caps the number of reported blocks
+		status, err := reporter.SealingStatus(2)
+		require.NoError(t, err)
+		require.Len(t, status.Blocks, 2)
+
+		// only results whose approvals are being verified are candidates for sealing
+		first := status.Blocks[0]
+		assert.Equal(t, []*approvals.ResultSealingStatus{verifying, caching}, first.Results)
+		assert.Equal(t, flow.IdentifierList{verifying.ResultID}, first.SealingResultIDs)
+
+		second := status.Blocks[1]
+		assert.Empty(t, second.Results)
+		assert.Empty(t, second.SealingResultIDs)
+	})
+}
+
+// TestStatusHandler tests that the sealing status endpoint serves the status compiled by the
+// reporter as json, and rejects invalid limits.
+func TestStatusHandler(t *testing.T) {
+	// the latest finalized block is sealed, so no unsealed blocks are reported
+	sealed := unittest.BlockHeaderFixture()
+	state := mockprotocol.NewState(t)
+	snapshot := mockprotocol.NewSnapshot(t)
+	snapshot.On("Head").Return(sealed, nil)
+	state.On("Sealed").Return(snapshot).Maybe()
+	state.On("Final").Return(snapshot).Maybe()
+
+	configs := mockmodule.NewSealingConfigsGetter(t)
+	configs.On("RequireApprovalsForSealConstructionDynamicValue").Return(uint(2)).Maybe()
+	configs.On("EmergencySealingActiveConst").Return(false).Maybe()
+
+	reporter := NewStatusReporter(state, mockstorage.NewHeaders(t), mempool.NewExecutionTree(t), configs)
+	handler := newStatusHandler(unittest.Logger(), reporter)
+
+	t.Run("status", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, StatusEndpoint+"?limit=5", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var status SealingStatus
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+		assert.Equal(t, sealed.ID(), status.LatestSealedBlockID)
+		assert.Equal(t, sealed.Height, status.LatestFinalizedHeight)
+		assert.Equal(t, uint(2), status.RequiredApprovals)
+		assert.Empty(t, status.Blocks)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, StatusEndpoint+"?limit=0", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, StatusEndpoint, nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+}
@@ -0,0 +1,324 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/storage"
+	storagemock "github.com/onflow/flow-go/storage/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const testToken = "secret"
+
+// tries is a TrieProvider holding tries in memory.
+type tries map[ledger.RootHash]*trie.MTrie
+
+func (t tries) Trie(rootHash ledger.RootHash) (*trie.MTrie, error) {
+	mtrie, ok := t[rootHash]
+	if !ok {
+		return nil, fmt.Errorf("trie %s not found", rootHash)
+	}
+	return mtrie, nil
+}
+
+// randomTrie creates a trie with random payloads.
+func randomTrie(t *testing.T) *trie.MTrie {
+	paths := testutils.RandomPaths(1000)
+	payloads := testutils.RandomPayloads(1000, 10, 100)
+	values := make([]ledger.Payload, 0, len(payloads))
+	for _, payload := range payloads {
+		values = append(values, *payload)
+	}
+	mtrie, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, values, true)
+	require.NoError(t, err)
+	return mtrie
+}
+
+// setup creates and starts an exporter holding the given tries, served by a test server. The
+// state of the i-th trie is sealed for the i-th returned block, no other block is sealed.
+func setup(t *testing.T, exportDir string, chunkSize uint64, maxExports int, mtries ...*trie.MTrie) (*Exporter, *httptest.Server, []flow.Identifier) {
+	provider := make(tries)
+	seals := storagemock.NewSeals(t)
+	blockIDs := make([]flow.Identifier, 0, len(mtries))
+	for _, mtrie := range mtries {
+		provider[mtrie.RootHash()] = mtrie
+		seal := unittest.Seal.Fixture()
+		seal.FinalState = flow.StateCommitment(mtrie.RootHash())
+		seals.On("FinalizedSealForBlock", seal.BlockID).Return(seal, nil).Maybe()
+		blockIDs = append(blockIDs, seal.BlockID)
+	}
+	seals.On("FinalizedSealForBlock", mock.Anything).Return(nil, storage.ErrNotFound).Maybe()
+
+	exporter := NewExporter(unittest.Logger(), provider, seals, exportDir, chunkSize, maxExports)
+	ctx, cancel := context.WithCancel(context.Background())
+	exporter.Start(irrecoverable.NewMockSignalerContext(t, ctx))
+	unittest.RequireCloseBefore(t, exporter.Ready(), time.Second, "exporter did not start")
+	t.Cleanup(func() {
+		cancel()
+		unittest.RequireCloseBefore(t, exporter.Done(), time.Second, "exporter did not stop")
+	})
+
+	server := httptest.NewServer(newHandler(unittest.Logger(), exporter, testToken))
+	t.Cleanup(server.Close)
+	return exporter, server, blockIDs
+}
+
+// newDownloader creates a downloader for the given test server, which retries quickly.
+func newDownloader(server *httptest.Server) *Downloader {
+	downloader := NewDownloader(unittest.Logger(), server.Client(), server.URL, testToken)
+	downloader.retryInterval = 10 * time.Millisecond
+	return downloader
+}
+
+// export waits until the checkpoint for the given block is exported and returns its manifest.
+func export(t *testing.T, exporter *Exporter, blockID flow.Identifier) *Manifest {
+	var manifest *Manifest
+	require.Eventually(t, func() bool {
+		var err error
+		manifest, err = exporter.Manifest(blockID)
+		if errors.Is(err, ErrExportPending) {
+			return false
+		}
+		require.NoError(t, err)
+		return true
+	}, 10*time.Second, 10*time.Millisecond)
+	return manifest
+}
+
+// TestDownload tests that a checkpoint exported by one node is downloaded and
+// verified by another node.
+func TestDownload(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		mtrie := randomTrie(t)
+		commit := flow.StateCommitment(mtrie.RootHash())
+		// use a small chunk size, so that files are split into several chunks
+		_, server, blockIDs := setup(t, filepath.Join(dir, "export"), 16*1024, DefaultMaxExports, mtrie)
+
+		// the checkpoint is not exported yet, so the downloader waits for the export
+		downloadDir := filepath.Join(dir, "download")
+		err := newDownloader(server).Download(context.Background(), blockIDs[0], commit, downloadDir)
+		require.NoError(t, err)
+
+		logger := unittest.Logger()
+		decoded, err := wal.OpenAndReadCheckpointV6(downloadDir, bootstrapFilenames.FilenameWALRootCheckpoint, &logger)
+		require.NoError(t, err)
+		require.Len(t, decoded, 1)
+		assert.True(t, mtrie.Equals(decoded[0]))
+	})
+}
+
+// TestDownload_NotSealed tests that no checkpoint is exported for a block which is not
+// sealed, and the download fails without leaving any files behind.
+func TestDownload_NotSealed(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		mtrie := randomTrie(t)
+		exporter, server, _ := setup(t, filepath.Join(dir, "export"), DefaultChunkSize, DefaultMaxExports, mtrie)
+
+		// the state is held by the ledger, but not sealed for the requested block
+		blockID := unittest.IdentifierFixture()
+		_, err := exporter.Manifest(blockID)
+		assert.ErrorIs(t, err, ErrNotSealed)
+
+		downloadDir := filepath.Join(dir, "download")
+		err = newDownloader(server).Download(context.Background(), blockID, flow.StateCommitment(mtrie.RootHash()), downloadDir)
+		require.Error(t, err)
+		assert.NoDirExists(t, downloadDir)
+	})
+}
+
+// TestDownload_Timeout tests that requests to an unresponsive server time out, and that the download
+// is aborted when the context is cancelled while waiting for a checkpoint to be exported.
+func TestDownload_Timeout(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		blockID := unittest.IdentifierFixture()
+		commit := unittest.StateCommitmentFixture()
+
+		unblock := make(chan struct{})
+		unresponsive := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-unblock
+		}))
+		defer unresponsive.Close()
+		defer close(unblock)
+
+		downloader := NewDownloader(unittest.Logger(), NewHTTPClient(50*time.Millisecond), unresponsive.URL, testToken)
+		err := downloader.Download(context.Background(), blockID, commit, dir)
+		require.Error(t, err)
+
+		pending := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer pending.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		downloader = NewDownloader(unittest.Logger(), NewHTTPClient(DefaultRequestTimeout), pending.URL, testToken)
+		err = downloader.Download(ctx, blockID, commit, dir)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// TestDownload_CorruptedChunk tests that a chunk which does not match the hash
+// in the manifest is rejected, and the downloaded files are removed.
+func TestDownload_CorruptedChunk(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		mtrie := randomTrie(t)
+		commit := flow.StateCommitment(mtrie.RootHash())
+		exportDir := filepath.Join(dir, "export")
+		exporter, server, blockIDs := setup(t, exportDir, 16*1024, DefaultMaxExports, mtrie)
+
+		// export the checkpoint, then corrupt the file of the top level tries
+		manifest := export(t, exporter, blockIDs[0])
+		names := wal.CheckpointFileNames(manifest.FileName)
+		path := filepath.Join(exporter.exportDir(commit), names[len(names)-1])
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		data[0] ^= 0xff
+		require.NoError(t, os.WriteFile(path, data, 0600))
+
+		downloadDir := filepath.Join(dir, "download")
+		err = newDownloader(server).Download(context.Background(), blockIDs[0], commit, downloadDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match manifest")
+
+		files, err := os.ReadDir(downloadDir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
+
+// TestDownload_ForgedPayload tests that a checkpoint whose root hash matches the state
+// commitment is rejected, if the stored node hashes do not match the payloads.
+func TestDownload_ForgedPayload(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		path := testutils.PathByUint8(1)
+		honest := node.NewLeaf(path, testutils.LightPayload8(1, 1), ledger.NodeMaxHeight)
+		// the forged leaf claims the hash of the honest leaf, but holds a different payload
+		forged := node.NewNode(ledger.NodeMaxHeight, nil, nil, path, testutils.LightPayload8(1, 2), honest.Hash())
+		mtrie, err := trie.NewMTrie(forged, 1, 0)
+		require.NoError(t, err)
+		commit := flow.StateCommitment(mtrie.RootHash())
+		require.Equal(t, ledger.RootHash(honest.Hash()), mtrie.RootHash())
+
+		_, server, blockIDs := setup(t, filepath.Join(dir, "export"), DefaultChunkSize, DefaultMaxExports, mtrie)
+
+		downloadDir := filepath.Join(dir, "download")
+		err = newDownloader(server).Download(context.Background(), blockIDs[0], commit, downloadDir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "hashes which do not match")
+
+		files, err := os.ReadDir(downloadDir)
+		require.NoError(t, err)
+		assert.Empty(t, files)
+	})
+}
+
+// TestExport_KeepsRecentExports tests that the most recent exports are kept, and chunks of
+// older exports are no longer served once they are evicted.
+func TestExport_KeepsRecentExports(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		mtries := []*trie.MTrie{randomTrie(t), randomTrie(t), randomTrie(t)}
+		exporter, _, blockIDs := setup(t, dir, DefaultChunkSize, 2, mtries...)
+		commits := make([]flow.StateCommitment, 0, len(mtries))
+		for _, mtrie := range mtries {
+			commits = append(commits, flow.StateCommitment(mtrie.RootHash()))
+		}
+
+		manifest := export(t, exporter, blockIDs[0])
+		assert.Equal(t, commits[0], manifest.StateCommitment)
+		assert.Len(t, manifest.Files, len(wal.CheckpointFileNames(manifest.FileName)))
+
+		// requesting the same state again reuses the export
+		again, err := exporter.Manifest(blockIDs[0])
+		require.NoError(t, err)
+		assert.Same(t, manifest, again)
+
+		// the second export is kept along with the first one
+		export(t, exporter, blockIDs[1])
+		_, err = exporter.ReadChunk(commits[0], manifest.FileName, 0)
+		assert.NoError(t, err)
+
+		// the third export evicts the first one
+		export(t, exporter, blockIDs[2])
+		assert.NoDirExists(t, exporter.exportDir(commits[0]))
+		_, err = exporter.ReadChunk(commits[0], manifest.FileName, 0)
+		assert.ErrorIs(t, err, ErrNotExported)
+		for _, commit := range commits[1:] {
+			_, err = exporter.ReadChunk(commit, manifest.FileName, 0)
+			assert.NoError(t, err)
+		}
+	})
+}
+
+// get sends a GET request for the given path to the test server, authenticated with the given token.
+func get(t *testing.T, server *httptest.Server, path string, token string) int {
+	req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+// TestServer_Authentication tests that requests without a valid token are rejected.
+func TestServer_Authentication(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		_, server, blockIDs := setup(t, dir, DefaultChunkSize, DefaultMaxExports, randomTrie(t))
+		path := ManifestEndpoint + "?block=" + blockIDs[0].String()
+
+		assert.Equal(t, http.StatusUnauthorized, get(t, server, path, ""))
+		assert.Equal(t, http.StatusUnauthorized, get(t, server, path, "wrong"))
+		assert.Equal(t, http.StatusAccepted, get(t, server, path, testToken))
+	})
+}
+
+// TestServer_RateLimit tests that manifest requests exceeding the rate limit are rejected.
+func TestServer_RateLimit(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		_, server, _ := setup(t, dir, DefaultChunkSize, DefaultMaxExports)
+		path := ManifestEndpoint + "?block=" + unittest.IdentifierFixture().String()
+
+		for i := 0; i < manifestBurst; i++ {
+			assert.Equal(t, http.StatusNotFound, get(t, server, path, testToken))
+		}
+		assert.Equal(t, http.StatusTooManyRequests, get(t, server, path, testToken))
+	})
+}
+
+// TestServer_InvalidRequests tests that malformed requests are rejected.
+func TestServer_InvalidRequests(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		_, server, _ := setup(t, dir, DefaultChunkSize, DefaultMaxExports)
+
+		for _, path := range []string{
+			ManifestEndpoint + "?block=not-hex",
+			ManifestEndpoint + "?block=abcd",
+			ChunkEndpoint + "?commit=abcd&file=root.checkpoint&index=0",
+			ChunkEndpoint + "?commit=" + fmt.Sprintf("%x", unittest.StateCommitmentFixture()) + "&file=root.checkpoint&index=-1",
+		} {
+			assert.Equal(t, http.StatusBadRequest, get(t, server, path, testToken), path)
+		}
+	})
+}
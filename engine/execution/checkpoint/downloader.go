@@ -0,0 +1,282 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DefaultRetryInterval is the default interval at which requests for checkpoints which are
+// being exported, or requests which were rate limited, are retried.
+const DefaultRetryInterval = 10 * time.Second
+
+// DefaultRequestTimeout is the default timeout of a single request of the Downloader, including
+// reading the response body, which is at most one chunk of a checkpoint file.
+const DefaultRequestTimeout = 5 * time.Minute
+
+// NewHTTPClient creates an HTTP client for the Downloader, which fails requests to an unresponsive
+// server after the given timeout, instead of blocking the download forever.
+func NewHTTPClient(requestTimeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: time.Minute,
+			IdleConnTimeout:       90 * time.Second,
+			MaxIdleConns:          10,
+		},
+	}
+}
+
+// errRetry is returned by requests which the server asks to be retried later.
+var errRetry = errors.New("retry later")
+
+// Downloader downloads checkpoints served by the Server of another execution node.
+// Every chunk is verified against the hash listed in the manifest, and the downloaded
+// checkpoint is verified against the expected state commitment before it is used.
+type Downloader struct {
+	log           zerolog.Logger
+	client        *http.Client
+	baseURL       string
+	token         string
+	retryInterval time.Duration
+}
+
+// NewDownloader creates a new Downloader for checkpoints served at the given base URL,
+// for example `http://execution-1:9005`, authenticating with the given token.
+func NewDownloader(log zerolog.Logger, client *http.Client, baseURL string, token string) *Downloader {
+	return &Downloader{
+		log:           log.With().Str("component", "checkpoint_downloader").Logger(),
+		client:        client,
+		baseURL:       baseURL,
+		token:         token,
+		retryInterval: DefaultRetryInterval,
+	}
+}
+
+// Download downloads the checkpoint of the execution state sealed for the given block into dir,
+// and verifies that the downloaded trie is consistent and its root hash matches the given state
+// commitment. The commitment must be obtained from a trusted source, typically the seal of the
+// root block in the protocol state. If the serving node has not exported the checkpoint yet,
+// the download waits until the export completes or the context is cancelled. The checkpoint is
+// stored as the root checkpoint, so the ledger loads it when it starts. If the download or the
+// verification fails, all downloaded files are removed.
+// No errors are expected if the peer serves a valid checkpoint for the state commitment.
+func (d *Downloader) Download(ctx context.Context, blockID flow.Identifier, commit flow.StateCommitment, dir string) (errToReturn error) {
+	log := d.log.With().Hex("block_id", blockID[:]).Hex("state_commitment", commit[:]).Logger()
+
+	manifest, err := d.manifest(ctx, blockID)
+	if err != nil {
+		return fmt.Errorf("could not download checkpoint manifest: %w", err)
+	}
+	err = validateManifest(manifest, commit)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoint manifest: %w", err)
+	}
+
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create directory %s: %w", dir, err)
+	}
+	defer func() {
+		if errToReturn == nil {
+			return
+		}
+		for _, file := range manifest.Files {
+			_ = os.Remove(filepath.Join(dir, file.Name))
+		}
+	}()
+
+	for _, file := range manifest.Files {
+		err = d.downloadFile(ctx, commit, file, manifest.ChunkSize, filepath.Join(dir, file.Name))
+		if err != nil {
+			return fmt.Errorf("could not download checkpoint file %s: %w", file.Name, err)
+		}
+		log.Info().Str("file", file.Name).Uint64("size", file.Size).Msg("checkpoint file downloaded")
+	}
+
+	tries, err := wal.OpenAndReadCheckpointV6(dir, manifest.FileName, &log)
+	if err != nil {
+		return fmt.Errorf("could not read downloaded checkpoint: %w", err)
+	}
+	if len(tries) != 1 {
+		return fmt.Errorf("downloaded checkpoint contains %d tries, expected a single trie", len(tries))
+	}
+	// the hashes of the decoded nodes are read from the checkpoint, so they must be recomputed
+	// from the payloads, otherwise the root hash check below proves nothing about the payloads
+	if !tries[0].IsAValidTrie() {
+		return fmt.Errorf("downloaded checkpoint contains node hashes which do not match their content")
+	}
+	rootHash := tries[0].RootHash()
+	if rootHash != ledger.RootHash(commit) {
+		return fmt.Errorf("root hash of downloaded checkpoint (%x) does not match state commitment (%x)", rootHash, commit)
+	}
+
+	log.Info().Msg("checkpoint downloaded and verified")
+
+	return nil
+}
+
+// manifest downloads the manifest of the checkpoint of the execution state sealed for the given block.
+func (d *Downloader) manifest(ctx context.Context, blockID flow.Identifier) (*Manifest, error) {
+	query := url.Values{}
+	query.Set("block", blockID.String())
+
+	body, err := d.get(ctx, ManifestEndpoint, query)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	var manifest Manifest
+	err = json.NewDecoder(body).Decode(&manifest)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// downloadFile downloads all chunks of the given file and writes them to the given path,
+// verifying each chunk against its hash in the manifest.
+func (d *Downloader) downloadFile(ctx context.Context, commit flow.StateCommitment, file FileManifest, chunkSize uint64, path string) (errToReturn error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("could not create file: %w", err)
+	}
+	defer func() {
+		err := f.Close()
+		if errToReturn == nil {
+			errToReturn = err
+		}
+	}()
+
+	for index, expected := range file.ChunkHashes {
+		chunk, err := d.chunk(ctx, commit, file.Name, uint64(index), chunkSize)
+		if err != nil {
+			return fmt.Errorf("could not download chunk %d: %w", index, err)
+		}
+		if !hashChunk(chunk).Equal(expected) {
+			return fmt.Errorf("hash of chunk %d does not match manifest", index)
+		}
+		_, err = f.Write(chunk)
+		if err != nil {
+			return fmt.Errorf("could not write chunk %d: %w", index, err)
+		}
+	}
+
+	return f.Sync()
+}
+
+// chunk downloads a single chunk of a checkpoint file.
+func (d *Downloader) chunk(ctx context.Context, commit flow.StateCommitment, fileName string, index uint64, chunkSize uint64) ([]byte, error) {
+	query := url.Values{}
+	query.Set("commit", hex.EncodeToString(commit[:]))
+	query.Set("file", fileName)
+	query.Set("index", strconv.FormatUint(index, 10))
+
+	body, err := d.get(ctx, ChunkEndpoint, query)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	// read at most one byte more than a chunk, oversized chunks then fail the hash check
+	return io.ReadAll(io.LimitReader(body, int64(chunkSize)+1))
+}
+
+// get sends a GET request to the given endpoint and returns the response body. Requests the
+// server asks to be retried later are retried until they succeed or the context is cancelled.
+func (d *Downloader) get(ctx context.Context, endpoint string, query url.Values) (io.ReadCloser, error) {
+	for {
+		body, err := d.getOnce(ctx, endpoint, query)
+		if !errors.Is(err, errRetry) {
+			return body, err
+		}
+		d.log.Debug().Str("endpoint", endpoint).Err(err).Msg("retrying checkpoint request")
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(d.retryInterval):
+		}
+	}
+}
+
+// getOnce sends a single GET request to the given endpoint and returns the response body.
+// Expected errors:
+//   - errRetry if the server asks for the request to be retried later
+func (d *Downloader) getOnce(ctx context.Context, endpoint string, query url.Values) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.baseURL+endpoint+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+d.token)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		_ = resp.Body.Close()
+		if resp.StatusCode == http.StatusAccepted || resp.StatusCode == http.StatusTooManyRequests {
+			return nil, fmt.Errorf("%s: %w", msg, errRetry)
+		}
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, msg)
+	}
+	return resp.Body, nil
+}
+
+// validateManifest checks that the manifest describes a root checkpoint for the given state
+// commitment, consisting of exactly the files of a checkpoint V6, each split into chunks of
+// the given chunk size.
+func validateManifest(manifest *Manifest, commit flow.StateCommitment) error {
+	if manifest.StateCommitment != commit {
+		return fmt.Errorf("manifest is for state commitment %x, expected %x", manifest.StateCommitment, commit)
+	}
+	if manifest.FileName != bootstrapFilenames.FilenameWALRootCheckpoint {
+		return fmt.Errorf("unexpected checkpoint file name %s", manifest.FileName)
+	}
+	if manifest.ChunkSize == 0 {
+		return fmt.Errorf("chunk size must be positive")
+	}
+
+	// the file names must be exactly those of a checkpoint V6, which also ensures
+	// that no file is written outside the target directory
+	names := wal.CheckpointFileNames(manifest.FileName)
+	if len(manifest.Files) != len(names) {
+		return fmt.Errorf("manifest lists %d files, expected %d", len(manifest.Files), len(names))
+	}
+	for i, file := range manifest.Files {
+		if file.Name != names[i] {
+			return fmt.Errorf("unexpected file %s at position %d, expected %s", file.Name, i, names[i])
+		}
+		if uint64(len(file.ChunkHashes)) != chunkCount(file.Size, manifest.ChunkSize) {
+			return fmt.Errorf("file %s of size %d has %d chunks, expected %d", file.Name, file.Size, len(file.ChunkHashes), chunkCount(file.Size, manifest.ChunkSize))
+		}
+	}
+
+	return nil
+}
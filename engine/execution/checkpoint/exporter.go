@@ -0,0 +1,314 @@
+package checkpoint
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+	"github.com/onflow/flow-go/ledger/complete/wal"
+	bootstrapFilenames "github.com/onflow/flow-go/model/bootstrap"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// DefaultMaxExports is the default number of exported checkpoints kept on disk. When a new
+	// checkpoint is exported, the least recently exported checkpoint beyond this limit is removed.
+	DefaultMaxExports = 3
+
+	// maxQueuedExports is the number of exports which can be queued while another export runs.
+	maxQueuedExports = 1
+)
+
+var (
+	// ErrNotSealed is returned when a checkpoint is requested for a block which is not sealed.
+	// Only sealed execution states are exported.
+	ErrNotSealed = errors.New("block not sealed")
+
+	// ErrStateNotAvailable is returned when the trie for a state commitment is
+	// not held in memory by the ledger, so no checkpoint can be exported for it.
+	ErrStateNotAvailable = errors.New("execution state not available")
+
+	// ErrExportPending is returned when the checkpoint for a state commitment is not exported
+	// yet, but its export is scheduled or running. The request should be retried later.
+	ErrExportPending = errors.New("checkpoint export pending")
+
+	// ErrNotExported is returned when a chunk is requested which is not part of
+	// an exported checkpoint.
+	ErrNotExported = errors.New("checkpoint chunk not exported")
+)
+
+// TrieProvider provides read-only access to the tries of the execution state.
+type TrieProvider interface {
+	// Trie returns the trie with the given root hash, or an error if the trie is not held in memory.
+	Trie(rootHash ledger.RootHash) (*trie.MTrie, error)
+}
+
+// Exporter exports checkpoints of single sealed execution state tries, so that they can be
+// served to new execution nodes. Exports run in a single background worker, so requests never
+// block on writing a checkpoint, and at most one export runs at a time. The most recent
+// exports are kept on disk, older exports are removed once the limit is exceeded.
+type Exporter struct {
+	component.Component
+	cm *component.ComponentManager
+
+	log        zerolog.Logger
+	tries      TrieProvider
+	seals      storage.Seals
+	dir        string
+	chunkSize  uint64
+	maxExports int
+	requests   chan flow.StateCommitment // exports to run by the worker
+
+	// lock protects manifests, exported and pending. It is also held while chunks are read, so
+	// that the files of an export are not removed while they are served.
+	lock      sync.RWMutex
+	manifests map[flow.StateCommitment]*Manifest
+	exported  []flow.StateCommitment // exported state commitments, least recently exported first
+	pending   map[flow.StateCommitment]struct{}
+}
+
+// NewExporter creates a new Exporter which stores the exported checkpoints in the given directory.
+// The directory is owned by the exporter, and any previous exports in it are removed on startup.
+func NewExporter(log zerolog.Logger, tries TrieProvider, seals storage.Seals, dir string, chunkSize uint64, maxExports int) *Exporter {
+	e := &Exporter{
+		log:        log.With().Str("component", "checkpoint_exporter").Logger(),
+		tries:      tries,
+		seals:      seals,
+		dir:        dir,
+		chunkSize:  chunkSize,
+		maxExports: maxExports,
+		requests:   make(chan flow.StateCommitment, maxQueuedExports),
+		manifests:  make(map[flow.StateCommitment]*Manifest),
+		pending:    make(map[flow.StateCommitment]struct{}),
+	}
+
+	e.cm = component.NewComponentManagerBuilder().
+		AddWorker(e.loop).
+		Build()
+	e.Component = e.cm
+
+	return e
+}
+
+// Manifest returns the manifest of the checkpoint of the execution state sealed for the given
+// block. If the checkpoint is not exported yet, its export is scheduled.
+// Expected errors during normal operation:
+//   - ErrNotSealed if no seal for the block is finalized
+//   - ErrStateNotAvailable if the ledger does not hold the trie for the sealed state commitment
+//   - ErrExportPending if the checkpoint is being exported, or no export can be scheduled currently
+func (e *Exporter) Manifest(blockID flow.Identifier) (*Manifest, error) {
+	seal, err := e.seals.FinalizedSealForBlock(blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("no finalized seal for block %v: %w", blockID, ErrNotSealed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get seal for block %v: %w", blockID, err)
+	}
+	commit := seal.FinalState
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	manifest, ok := e.manifests[commit]
+	if ok {
+		return manifest, nil
+	}
+	if _, ok := e.pending[commit]; ok {
+		return nil, fmt.Errorf("checkpoint for state commitment %x is being exported: %w", commit, ErrExportPending)
+	}
+
+	_, err = e.tries.Trie(ledger.RootHash(commit))
+	if err != nil {
+		return nil, fmt.Errorf("could not get trie for state commitment %x: %v: %w", commit, err, ErrStateNotAvailable)
+	}
+
+	select {
+	case e.requests <- commit:
+		e.pending[commit] = struct{}{}
+		return nil, fmt.Errorf("checkpoint export for state commitment %x scheduled: %w", commit, ErrExportPending)
+	default:
+		return nil, fmt.Errorf("checkpoint export queue is full: %w", ErrExportPending)
+	}
+}
+
+// ReadChunk reads the chunk with the given index of a file of the checkpoint exported for
+// the given state commitment.
+// Expected errors during normal operation:
+//   - ErrNotExported if no checkpoint is exported for the state commitment, or the file or chunk does not exist
+func (e *Exporter) ReadChunk(commit flow.StateCommitment, fileName string, index uint64) ([]byte, error) {
+	// hold the lock while reading, so that the export is not removed concurrently
+	e.lock.RLock()
+	defer e.lock.RUnlock()
+
+	manifest, ok := e.manifests[commit]
+	if !ok {
+		return nil, fmt.Errorf("no checkpoint exported for state commitment %x: %w", commit, ErrNotExported)
+	}
+
+	var file *FileManifest
+	for i := range manifest.Files {
+		if manifest.Files[i].Name == fileName {
+			file = &manifest.Files[i]
+			break
+		}
+	}
+	if file == nil {
+		return nil, fmt.Errorf("checkpoint has no file %s: %w", fileName, ErrNotExported)
+	}
+	if index >= uint64(len(file.ChunkHashes)) {
+		return nil, fmt.Errorf("checkpoint file %s has no chunk %d: %w", fileName, index, ErrNotExported)
+	}
+
+	f, err := os.Open(filepath.Join(e.exportDir(commit), fileName))
+	if err != nil {
+		return nil, fmt.Errorf("could not open checkpoint file %s: %w", fileName, err)
+	}
+	defer f.Close()
+
+	chunk := make([]byte, manifest.ChunkSize)
+	n, err := f.ReadAt(chunk, int64(index*manifest.ChunkSize))
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("could not read chunk %d of checkpoint file %s: %w", index, fileName, err)
+	}
+
+	return chunk[:n], nil
+}
+
+// loop runs the scheduled exports until the component is stopped.
+func (e *Exporter) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	// exports of a previous run are not tracked anymore, so they are removed
+	err := os.RemoveAll(e.dir)
+	if err != nil {
+		ctx.Throw(fmt.Errorf("could not clean up export directory %s: %w", e.dir, err))
+	}
+	ready()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case commit := <-e.requests:
+			manifest, err := e.export(commit)
+			if err != nil {
+				e.log.Error().Err(err).Hex("state_commitment", commit[:]).Msg("could not export checkpoint")
+			}
+			e.complete(commit, manifest)
+		}
+	}
+}
+
+// export writes the checkpoint of the execution state with the given state commitment and
+// returns its manifest.
+// No errors are expected during normal operation, unless the trie was evicted from the
+// ledger since the export was scheduled.
+func (e *Exporter) export(commit flow.StateCommitment) (*Manifest, error) {
+	t, err := e.tries.Trie(ledger.RootHash(commit))
+	if err != nil {
+		return nil, fmt.Errorf("could not get trie: %w", err)
+	}
+
+	dir := e.exportDir(commit)
+	// remove leftovers from a failed export, the checkpoint writer refuses to overwrite files
+	err = os.RemoveAll(dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not clean up export directory %s: %w", dir, err)
+	}
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, fmt.Errorf("could not create export directory %s: %w", dir, err)
+	}
+
+	log := e.log.With().Hex("state_commitment", commit[:]).Logger()
+	log.Info().Msg("exporting checkpoint")
+
+	fileName := bootstrapFilenames.FilenameWALRootCheckpoint
+	err = wal.StoreCheckpointV6Concurrently([]*trie.MTrie{t}, dir, fileName, &log)
+	if err != nil {
+		return nil, fmt.Errorf("could not store checkpoint: %w", err)
+	}
+
+	manifest := &Manifest{
+		StateCommitment: commit,
+		FileName:        fileName,
+		ChunkSize:       e.chunkSize,
+	}
+	for _, name := range wal.CheckpointFileNames(fileName) {
+		file, err := e.hashFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("could not hash checkpoint file %s: %w", name, err)
+		}
+		file.Name = name
+		manifest.Files = append(manifest.Files, *file)
+	}
+
+	log.Info().Int("files", len(manifest.Files)).Msg("checkpoint exported")
+
+	return manifest, nil
+}
+
+// complete records the result of the export of the given state commitment. If the export
+// failed, the manifest is nil, and the export can be scheduled again. Otherwise, the least
+// recently exported checkpoints beyond the limit are removed.
+func (e *Exporter) complete(commit flow.StateCommitment, manifest *Manifest) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	delete(e.pending, commit)
+	if manifest == nil {
+		return
+	}
+
+	e.manifests[commit] = manifest
+	e.exported = append(e.exported, commit)
+	for len(e.exported) > e.maxExports {
+		evicted := e.exported[0]
+		e.exported = e.exported[1:]
+		delete(e.manifests, evicted)
+
+		err := os.RemoveAll(e.exportDir(evicted))
+		if err != nil {
+			e.log.Warn().Err(err).Hex("state_commitment", evicted[:]).Msg("could not remove evicted export")
+		}
+	}
+}
+
+// exportDir returns the directory containing the checkpoint exported for the given state commitment.
+func (e *Exporter) exportDir(commit flow.StateCommitment) string {
+	return filepath.Join(e.dir, hex.EncodeToString(commit[:]))
+}
+
+// hashFile computes the size and the chunk hashes of the file at the given path.
+func (e *Exporter) hashFile(path string) (*FileManifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open file: %w", err)
+	}
+	defer f.Close()
+
+	file := &FileManifest{}
+	chunk := make([]byte, e.chunkSize)
+	for {
+		n, err := io.ReadFull(f, chunk)
+		if n > 0 {
+			file.Size += uint64(n)
+			file.ChunkHashes = append(file.ChunkHashes, hashChunk(chunk[:n]))
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return file, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read file: %w", err)
+		}
+	}
+}
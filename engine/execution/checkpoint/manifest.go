@@ -0,0 +1,37 @@
+package checkpoint
+
+import (
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// DefaultChunkSize is the default size of the chunks the checkpoint files are split into.
+const DefaultChunkSize = 16 * 1024 * 1024 // 16 MB
+
+// Manifest describes an exported checkpoint V6 containing a single trie. Each file of
+// the checkpoint is split into chunks of ChunkSize bytes (the last chunk of a file may
+// be smaller), and the manifest lists the SHA3-256 hash of every chunk, so that each
+// chunk can be verified as soon as it is downloaded.
+type Manifest struct {
+	StateCommitment flow.StateCommitment
+	FileName        string
+	ChunkSize       uint64
+	Files           []FileManifest
+}
+
+// FileManifest describes one file of an exported checkpoint.
+type FileManifest struct {
+	Name        string
+	Size        uint64
+	ChunkHashes []hash.Hash
+}
+
+// chunkCount returns the number of chunks a file of the given size is split into.
+func chunkCount(size uint64, chunkSize uint64) uint64 {
+	return (size + chunkSize - 1) / chunkSize
+}
+
+// hashChunk computes the hash of a checkpoint chunk.
+func hashChunk(chunk []byte) hash.Hash {
+	return hash.NewSHA3_256().ComputeHash(chunk)
+}
@@ -0,0 +1,132 @@
+package checkpoint
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/httpserver"
+)
+
+const (
+	// ManifestEndpoint serves the manifest of the checkpoint of the execution state sealed for
+	// the block given by the `block` query parameter. If the checkpoint is not exported yet, its
+	// export is scheduled and the server responds with 202 Accepted.
+	ManifestEndpoint = "/checkpoint/manifest"
+
+	// ChunkEndpoint serves the chunk with the index given by the `index` query parameter of
+	// the checkpoint file given by the `file` query parameter, for the state commitment given
+	// by the `commit` query parameter.
+	ChunkEndpoint = "/checkpoint/chunk"
+
+	// retryAfterSeconds is the delay in seconds after which clients should retry requests for
+	// checkpoints which are being exported, or which were rate limited.
+	retryAfterSeconds = 10
+)
+
+var (
+	// manifestRateLimit limits the rate of manifest requests, each of which may schedule an export.
+	manifestRateLimit = rate.Limit(1)
+	manifestBurst     = 10
+
+	// chunkRateLimit limits the rate of chunk requests, and with it the bandwidth used for serving checkpoints.
+	chunkRateLimit = rate.Limit(8)
+	chunkBurst     = 16
+)
+
+// Server is the http server serving checkpoints of the execution state to new execution nodes.
+// Every request must carry the configured token as bearer token in its Authorization header.
+type Server struct {
+	*httpserver.Server
+}
+
+// NewServer creates a new server that will listen on the specified address and serve
+// checkpoints exported by the given exporter to clients authenticated with the given token.
+func NewServer(log zerolog.Logger, exporter *Exporter, addr string, token string) *Server {
+	log = log.With().Str("component", "checkpoint_server").Logger()
+	return &Server{
+		Server: httpserver.NewServer(log, "checkpoint", addr, newHandler(log, exporter, token)),
+	}
+}
+
+// newHandler returns the handler serving the manifest and chunk endpoints.
+func newHandler(log zerolog.Logger, exporter *Exporter, token string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle(ManifestEndpoint, httpserver.RateLimit(rate.NewLimiter(manifestRateLimit, manifestBurst), retryAfterSeconds, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		blockID, err := flow.HexStringToIdentifier(r.URL.Query().Get("block"))
+		if err != nil {
+			http.Error(w, "invalid block ID", http.StatusBadRequest)
+			return
+		}
+
+		manifest, err := exporter.Manifest(blockID)
+		if errors.Is(err, ErrExportPending) {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+			http.Error(w, err.Error(), http.StatusAccepted)
+			return
+		}
+		if errors.Is(err, ErrNotSealed) || errors.Is(err, ErrStateNotAvailable) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Hex("block_id", blockID[:]).Msg("could not get checkpoint manifest")
+			http.Error(w, "could not get checkpoint manifest", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(w).Encode(manifest)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not write checkpoint manifest")
+		}
+	})))
+
+	mux.Handle(ChunkEndpoint, httpserver.RateLimit(rate.NewLimiter(chunkRateLimit, chunkBurst), retryAfterSeconds, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		commit, err := parseCommit(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		index, err := strconv.ParseUint(r.URL.Query().Get("index"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid chunk index", http.StatusBadRequest)
+			return
+		}
+
+		chunk, err := exporter.ReadChunk(commit, r.URL.Query().Get("file"), index)
+		if errors.Is(err, ErrNotExported) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			log.Error().Err(err).Hex("state_commitment", commit[:]).Msg("could not read checkpoint chunk")
+			http.Error(w, "could not read checkpoint chunk", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, err = w.Write(chunk)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not write checkpoint chunk")
+		}
+	})))
+
+	return httpserver.Authenticate(token, mux)
+}
+
+// parseCommit parses the hex encoded state commitment given by the `commit` query parameter.
+func parseCommit(r *http.Request) (flow.StateCommitment, error) {
+	bytes, err := hex.DecodeString(r.URL.Query().Get("commit"))
+	if err != nil {
+		return flow.DummyStateCommitment, errors.New("state commitment is not hex encoded")
+	}
+	return flow.ToStateCommitment(bytes)
+}
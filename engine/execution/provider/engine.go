@@ -7,9 +7,11 @@ import (
 	"math/rand"
 	"time"
 
+	lru "github.com/hashicorp/golang-lru"
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack"
 	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/module/mempool"
 
@@ -41,6 +43,9 @@ const (
 	// DefaultChunkDataPackDeliveryTimeout is the default timeout value for delivery of a chunk data pack to a verification
 	// node.
 	DefaultChunkDataPackDeliveryTimeout = 10 * time.Second
+	// DefaultPublishedChunkDataPackCacheSize is the default number of chunk data packs whose root blob IDs are cached
+	// after being published, so that repeated requests for the same chunk do not publish it again.
+	DefaultPublishedChunkDataPackCacheSize = 1000
 )
 
 // An Engine provides means of accessing data about execution state and broadcasts execution receipts to nodes in the network.
@@ -68,6 +73,11 @@ type Engine struct {
 	chunkDataPackDeliveryTimeout time.Duration
 	// timeout for querying chunk data pack through database.
 	chunkDataPackQueryTimeout time.Duration
+
+	// optional publisher of chunk data packs as content-addressed blobs, and the cache of
+	// root blob IDs of the chunk data packs published so far (chunk ID -> blob ID).
+	chdpPublisher  chunk_data_pack.Publisher
+	publishedChdps *lru.Cache
 }
 
 func New(
@@ -105,12 +115,17 @@ func New(
 			},
 			// Map is called on messages that are Match(ed) successfully, i.e.,
 			// ChunkDataRequests.
-			// It replaces the payload of message with requested chunk id.
+			// It replaces the payload of message with a chunk data pack request, dropping
+			// the nonce so that duplicate requests are deduplicated by the queue.
 			Map: func(message *engine.Message) (*engine.Message, bool) {
 				chdpReq := message.Payload.(*messages.ChunkDataRequest)
 				return &engine.Message{
 					OriginID: message.OriginID,
-					Payload:  chdpReq.ChunkID,
+					Payload: mempool.ChunkDataPackRequest{
+						ChunkId:       chdpReq.ChunkID,
+						RequesterId:   message.OriginID,
+						BlobsAccepted: chdpReq.BlobsAccepted,
+					},
 				}, true
 			},
 			Store: chunkDataPackRequestQueue,
@@ -132,6 +147,11 @@ func New(
 
 	var err error
 
+	engine.publishedChdps, err = lru.New(DefaultPublishedChunkDataPackCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create published chunk data pack cache: %w", err)
+	}
+
 	engine.receiptCon, err = net.Register(channels.PushReceipts, &engine)
 	if err != nil {
 		return nil, fmt.Errorf("could not register receipt provider engine: %w", err)
@@ -155,6 +175,14 @@ func New(
 	return &engine, nil
 }
 
+// WithChunkDataPackPublisher configures the engine to publish the requested chunk data packs as
+// content-addressed blobs through the given publisher, and reply to requesters that are able to
+// retrieve blobs with the ID of the root blob instead of the chunk data pack itself.
+// Note: this method should be called before the engine is started.
+func (e *Engine) WithChunkDataPackPublisher(publisher chunk_data_pack.Publisher) {
+	e.chdpPublisher = publisher
+}
+
 // processQueuedChunkDataPackRequestsShovelerWorker is constantly listening on the MessageHandler for ChunkDataRequests,
 // and pushes new ChunkDataRequests into the request channel to be picked by workers.
 func (e *Engine) processQueuedChunkDataPackRequestsShovelerWorker(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
@@ -193,17 +221,14 @@ func (e *Engine) processAvailableMesssages(ctx irrecoverable.SignalerContext) {
 			return
 		}
 
-		chunkId, ok := msg.Payload.(flow.Identifier)
+		chdpRequest, ok := msg.Payload.(mempool.ChunkDataPackRequest)
 		if !ok {
 			// should never happen.
 			// if it does happen, it means there is a bug in the queue implementation.
-			ctx.Throw(fmt.Errorf("invalid chunk id type in chunk data pack request queue: %T", msg.Payload))
+			ctx.Throw(fmt.Errorf("invalid request type in chunk data pack request queue: %T", msg.Payload))
 		}
 
-		request := &mempool.ChunkDataPackRequest{
-			RequesterId: msg.OriginID,
-			ChunkId:     chunkId,
-		}
+		request := &chdpRequest
 		lg := e.log.With().
 			Hex("chunk_id", logging.ID(request.ChunkId)).
 			Hex("origin_id", logging.ID(request.RequesterId)).Logger()
@@ -301,14 +326,60 @@ func (e *Engine) onChunkDataRequest(request *mempool.ChunkDataPackRequest) {
 		return
 	}
 
-	e.deliverChunkDataResponse(chunkDataPack, request.RequesterId)
+	if request.BlobsAccepted && e.chdpPublisher != nil {
+		blobID, err := e.publishChunkDataPack(chunkDataPack)
+		if err == nil {
+			e.deliverChunkDataResponse(chunkDataPack, blobID, request.RequesterId)
+			return
+		}
+
+		// falls back to delivering the chunk data pack itself
+		lg.Warn().
+			Err(err).
+			Msg("could not publish chunk data pack, delivering it directly")
+	}
+
+	e.deliverChunkDataResponse(chunkDataPack, flow.ZeroID, request.RequesterId)
+}
+
+// publishChunkDataPack publishes the chunk data pack as content-addressed blobs (if not published already),
+// and returns the ID of its root blob. The blobs are tracked at the height of the block of the chunk, so that
+// they are retained until the block is sealed.
+// No errors are expected during normal operation.
+func (e *Engine) publishChunkDataPack(chunkDataPack *flow.ChunkDataPack) (flow.Identifier, error) {
+	if blobID, ok := e.publishedChdps.Get(chunkDataPack.ChunkID); ok {
+		return blobID.(flow.Identifier), nil
+	}
+
+	blockID, err := e.execState.GetBlockIDByChunkID(chunkDataPack.ChunkID)
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("cannot find block ID of chunk: %w", err)
+	}
+
+	header, err := e.state.AtBlockID(blockID).Head()
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("cannot get header of block %v: %w", blockID, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.chunkDataPackDeliveryTimeout)
+	defer cancel()
+
+	blobID, err := e.chdpPublisher.Publish(ctx, header.Height, chunkDataPack)
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("could not publish chunk data pack: %w", err)
+	}
+
+	e.publishedChdps.Add(chunkDataPack.ChunkID, blobID)
+	return blobID, nil
 }
 
 // deliverChunkDataResponse delivers chunk data pack to the requester through network.
-func (e *Engine) deliverChunkDataResponse(chunkDataPack *flow.ChunkDataPack, requesterId flow.Identifier) {
+// If blobID is non-zero, only the ID of the root blob of the published chunk data pack is delivered.
+func (e *Engine) deliverChunkDataResponse(chunkDataPack *flow.ChunkDataPack, blobID flow.Identifier, requesterId flow.Identifier) {
 	lg := e.log.With().
 		Hex("origin_id", logging.ID(requesterId)).
 		Hex("chunk_id", logging.ID(chunkDataPack.ChunkID)).
+		Hex("blob_id", logging.ID(blobID)).
 		Logger()
 	lg.Info().Msg("sending chunk data pack response")
 
@@ -319,6 +390,11 @@ func (e *Engine) deliverChunkDataResponse(chunkDataPack *flow.ChunkDataPack, req
 		ChunkDataPack: *chunkDataPack,
 		Nonce:         rand.Uint64(),
 	}
+	if blobID != flow.ZeroID {
+		// the requester retrieves the content of the chunk data pack as blobs
+		response.ChunkDataPack = flow.ChunkDataPack{ChunkID: chunkDataPack.ChunkID}
+		response.BlobID = blobID
+	}
 
 	err := e.chunksConduit.Unicast(response, requesterId)
 	if err != nil {
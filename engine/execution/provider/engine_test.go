@@ -16,6 +16,7 @@ import (
 	state "github.com/onflow/flow-go/engine/execution/state/mock"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/model/messages"
+	mockchdp "github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack/mock"
 	"github.com/onflow/flow-go/module/irrecoverable"
 	"github.com/onflow/flow-go/module/mempool/queue"
 	"github.com/onflow/flow-go/module/metrics"
@@ -407,4 +408,82 @@ func TestProviderEngine_onChunkDataRequest(t *testing.T) {
 		cancel()
 		unittest.RequireCloseBefore(t, e.Done(), 100*time.Millisecond, "could not stop engine")
 	})
+
+	t.Run("reply with published blob ID when requester accepts blobs", func(t *testing.T) {
+		ps := mockprotocol.NewState(t)
+		ss := mockprotocol.NewSnapshot(t)
+		net := mocknetwork.NewNetwork(t)
+		chunkConduit := mocknetwork.NewConduit(t)
+		execState := state.NewExecutionState(t)
+		publisher := mockchdp.NewPublisher(t)
+
+		net.On("Register", channels.PushReceipts, mock.Anything).Return(&mocknetwork.Conduit{}, nil)
+		net.On("Register", channels.ProvideChunks, mock.Anything).Return(chunkConduit, nil)
+		requestQueue := queue.NewHeroStore(10, unittest.Logger(), metrics.NewNoopCollector())
+
+		e, err := New(
+			unittest.Logger(),
+			trace.NewNoopTracer(),
+			net,
+			ps,
+			execState,
+			metrics.NewNoopCollector(),
+			func(_ flow.Identifier) (bool, error) { return true, nil },
+			requestQueue,
+			DefaultChunkDataPackRequestWorker,
+			DefaultChunkDataPackQueryTimeout,
+			DefaultChunkDataPackDeliveryTimeout)
+		require.NoError(t, err)
+		e.WithChunkDataPackPublisher(publisher)
+
+		originIdentity := unittest.IdentityFixture(unittest.WithRole(flow.RoleVerification))
+
+		chunkID := unittest.IdentifierFixture()
+		chunkDataPack := unittest.ChunkDataPackFixture(chunkID)
+		header := unittest.BlockHeaderFixture()
+		blobID := unittest.IdentifierFixture()
+
+		execState.On("GetBlockIDByChunkID", chunkID).Return(header.ID(), nil)
+		ps.On("AtBlockID", header.ID()).Return(ss)
+		ss.On("Identity", originIdentity.NodeID).Return(originIdentity, nil)
+		ss.On("Head").Return(header, nil).Once()
+		execState.On("ChunkDataPackByChunkID", chunkID).Return(chunkDataPack, nil).Twice()
+
+		// chunk data pack is published only once, as its blob ID is cached for the second request.
+		publisher.On("Publish", mock.Anything, header.Height, chunkDataPack).Return(blobID, nil).Once()
+
+		responded := make(chan struct{}, 2)
+		chunkConduit.On("Unicast", mock.Anything, originIdentity.NodeID).
+			Run(func(args mock.Arguments) {
+				res, ok := args[0].(*messages.ChunkDataResponse)
+				require.True(t, ok)
+
+				// response only carries the chunk ID and the root blob ID of the chunk data pack.
+				assert.Equal(t, chunkID, res.ChunkDataPack.ChunkID)
+				assert.Nil(t, res.ChunkDataPack.Collection)
+				assert.Equal(t, blobID, res.BlobID)
+				responded <- struct{}{}
+			}).
+			Return(nil).Twice()
+
+		req := &messages.ChunkDataRequest{
+			ChunkID:       chunkID,
+			Nonce:         rand.Uint64(),
+			BlobsAccepted: true,
+		}
+
+		cancelCtx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ctx, _ := irrecoverable.WithSignaler(cancelCtx)
+		e.Start(ctx)
+		unittest.RequireCloseBefore(t, e.Ready(), 100*time.Millisecond, "could not start engine")
+
+		for i := 0; i < 2; i++ {
+			require.NoError(t, e.Process(channels.RequestChunks, originIdentity.NodeID, req))
+			unittest.RequireReturnsBefore(t, func() { <-responded }, 1*time.Second, "could not receive response")
+		}
+
+		cancel()
+		unittest.RequireCloseBefore(t, e.Done(), 100*time.Millisecond, "could not stop engine")
+	})
 }
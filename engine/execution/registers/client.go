@@ -0,0 +1,79 @@
+package registers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// Client reads registers with proofs from the Server of an execution node.
+// The client does not verify the proofs, this is left to the caller.
+type Client struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+// NewClient creates a new Client for the server at the given base URL, for example
+// `http://execution-1:9006`, authenticating with the given token.
+func NewClient(client *http.Client, baseURL string, token string) *Client {
+	return &Client{
+		client:  client,
+		baseURL: baseURL,
+		token:   token,
+	}
+}
+
+// Registers reads the given registers at the final state of the given block.
+func (c *Client) Registers(ctx context.Context, blockID flow.Identifier, ids []flow.RegisterID) (*Response, error) {
+	return c.post(ctx, RegistersEndpoint, &RegistersRequest{
+		BlockID:   blockID,
+		Registers: toRegisters(ids),
+	})
+}
+
+// Account reads the registers storing the keys and contracts of the given account at the
+// final state of the given block.
+func (c *Client) Account(ctx context.Context, blockID flow.Identifier, address flow.Address) (*Response, error) {
+	return c.post(ctx, AccountEndpoint, &AccountRequest{
+		BlockID: blockID,
+		Address: address,
+	})
+}
+
+// post sends the request to the given endpoint and decodes the response.
+func (c *Client) post(ctx context.Context, endpoint string, request interface{}) (*Response, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, msg)
+	}
+
+	var response Response
+	err = json.NewDecoder(resp.Body).Decode(&response)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode response: %w", err)
+	}
+	return &response, nil
+}
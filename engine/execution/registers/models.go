@@ -0,0 +1,72 @@
+package registers
+
+import (
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// MaxRegistersPerRequest is the maximum number of registers which can be read in a single request.
+const MaxRegistersPerRequest = 1000
+
+// Register is a register as sent over the wire. The owner and key of registers are arbitrary
+// bytes, so they are sent as byte slices rather than strings.
+type Register struct {
+	Owner []byte
+	Key   []byte
+	Value []byte
+}
+
+// RegistersRequest requests the values of the given registers, together with a proof, at the
+// final state of the given block.
+type RegistersRequest struct {
+	BlockID   flow.Identifier
+	Registers []Register
+}
+
+// AccountRequest requests the values of all registers storing the keys and contracts of the
+// given account, together with a proof, at the final state of the given block.
+type AccountRequest struct {
+	BlockID flow.Identifier
+	Address flow.Address
+}
+
+// Response holds the values of the requested registers at the final state of a block, and
+// a batch proof of the values against the state commitment of the block.
+type Response struct {
+	BlockID         flow.Identifier
+	StateCommitment flow.StateCommitment
+	Registers       []Register
+	Proof           flow.StorageProof
+}
+
+// Entries returns the registers of the response.
+func (r *Response) Entries() []flow.RegisterEntry {
+	entries := make([]flow.RegisterEntry, 0, len(r.Registers))
+	for _, register := range r.Registers {
+		entries = append(entries, flow.RegisterEntry{
+			Key:   flow.NewRegisterID(string(register.Owner), string(register.Key)),
+			Value: register.Value,
+		})
+	}
+	return entries
+}
+
+// toRegisters converts the given register IDs to wire registers.
+func toRegisters(ids []flow.RegisterID) []Register {
+	registers := make([]Register, 0, len(ids))
+	for _, id := range ids {
+		registers = append(registers, Register{
+			Owner: []byte(id.Owner),
+			Key:   []byte(id.Key),
+		})
+	}
+	return registers
+}
+
+// toRegisterIDs converts the given wire registers to register IDs.
+func toRegisterIDs(registers []Register) []flow.RegisterID {
+	ids := make([]flow.RegisterID, 0, len(registers))
+	for _, register := range registers {
+		ids = append(ids, flow.NewRegisterID(string(register.Owner), string(register.Key)))
+	}
+	return ids
+}
@@ -0,0 +1,209 @@
+package registers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/access/stateproof"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/engine/execution/state/delta"
+	"github.com/onflow/flow-go/fvm/environment"
+	fvmState "github.com/onflow/flow-go/fvm/state"
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/complete"
+	"github.com/onflow/flow-go/ledger/complete/wal/fixtures"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testToken is the token the test clients authenticate with.
+const testToken = "test-token"
+
+// ledgerState is an execution state backed by a ledger, holding the final states of the
+// blocks in commits.
+type ledgerState struct {
+	state.ReadOnlyExecutionState
+	ledger  *complete.Ledger
+	commits map[flow.Identifier]flow.StateCommitment
+}
+
+func (s *ledgerState) StateCommitmentByBlockID(_ context.Context, blockID flow.Identifier) (flow.StateCommitment, error) {
+	commit, ok := s.commits[blockID]
+	if !ok {
+		return flow.DummyStateCommitment, storage.ErrNotFound
+	}
+	return commit, nil
+}
+
+func (s *ledgerState) HasState(commit flow.StateCommitment) bool {
+	return s.ledger.HasState(ledger.State(commit))
+}
+
+func (s *ledgerState) GetRegisters(_ context.Context, commit flow.StateCommitment, ids []flow.RegisterID) ([]flow.RegisterValue, error) {
+	query, err := ledger.NewQuery(ledger.State(commit), state.RegisterIDSToKeys(ids))
+	if err != nil {
+		return nil, err
+	}
+	values, err := s.ledger.Get(query)
+	if err != nil {
+		return nil, err
+	}
+	registerValues := make([]flow.RegisterValue, len(values))
+	for i, value := range values {
+		registerValues[i] = value
+	}
+	return registerValues, nil
+}
+
+func (s *ledgerState) GetProof(_ context.Context, commit flow.StateCommitment, ids []flow.RegisterID) (flow.StorageProof, error) {
+	query, err := ledger.NewQuery(ledger.State(commit), state.RegisterIDSToKeys(ids))
+	if err != nil {
+		return nil, err
+	}
+	return s.ledger.Prove(query)
+}
+
+// setup creates an execution state holding a single account at the final state of the
+// returned block, and a client for a test server serving the state.
+func setup(t *testing.T) (*Client, flow.Identifier, *flow.Account) {
+	ldg, err := complete.NewLedger(&fixtures.NoopWAL{}, 100, &metrics.NoopCollector{}, zerolog.Nop(), complete.DefaultPathFinderVersion)
+	require.NoError(t, err)
+	compactor := fixtures.NewNoopCompactor(ldg)
+	<-compactor.Ready()
+	t.Cleanup(func() {
+		<-ldg.Done()
+		<-compactor.Done()
+	})
+
+	key, err := unittest.AccountKeyDefaultFixture()
+	require.NoError(t, err)
+	view := delta.NewView(delta.AlwaysEmptyGetRegisterFunc)
+	accounts := environment.NewAccounts(fvmState.NewTransactionState(view, fvmState.DefaultParameters()))
+	address := unittest.RandomAddressFixture()
+	require.NoError(t, accounts.Create([]flow.AccountPublicKey{key.PublicKey(1000)}, address))
+	account, err := accounts.Get(address)
+	require.NoError(t, err)
+
+	commit, _, err := state.CommitDelta(ldg, view, flow.StateCommitment(ldg.InitialState()))
+	require.NoError(t, err)
+
+	blockID := unittest.IdentifierFixture()
+	execState := &ledgerState{
+		ledger:  ldg,
+		commits: map[flow.Identifier]flow.StateCommitment{blockID: commit},
+	}
+	server := httptest.NewServer(newHandler(unittest.Logger(), execState, testToken))
+	t.Cleanup(server.Close)
+
+	return NewClient(server.Client(), server.URL, testToken), blockID, account
+}
+
+// TestAccount tests that the registers of an account are served with a valid proof.
+func TestAccount(t *testing.T) {
+	client, blockID, account := setup(t)
+
+	resp, err := client.Account(context.Background(), blockID, account.Address)
+	require.NoError(t, err)
+	assert.Equal(t, blockID, resp.BlockID)
+
+	registers, err := stateproof.VerifyCommitment(resp.StateCommitment, resp.Proof)
+	require.NoError(t, err)
+	verified, err := registers.Account(account.Address)
+	require.NoError(t, err)
+	assert.Equal(t, account, verified)
+
+	// the served values match the proof
+	for _, entry := range resp.Entries() {
+		value, err := registers.Get(entry.Key)
+		require.NoError(t, err)
+		assert.Equal(t, value, entry.Value)
+	}
+}
+
+// TestRegisters tests that the requested registers are served with a valid proof.
+func TestRegisters(t *testing.T) {
+	client, blockID, account := setup(t)
+	owner := string(account.Address.Bytes())
+	ids := []flow.RegisterID{
+		flow.NewRegisterID(owner, fvmState.AccountStatusKey),
+		flow.NewRegisterID(owner, "unknown"),
+	}
+
+	resp, err := client.Registers(context.Background(), blockID, ids)
+	require.NoError(t, err)
+
+	entries := resp.Entries()
+	require.Len(t, entries, len(ids))
+	for i, entry := range entries {
+		assert.Equal(t, ids[i], entry.Key)
+	}
+	assert.NotEmpty(t, entries[0].Value)
+	assert.Empty(t, entries[1].Value)
+
+	registers, err := stateproof.VerifyCommitment(resp.StateCommitment, resp.Proof)
+	require.NoError(t, err)
+	values, err := registers.Values(ids)
+	require.NoError(t, err)
+	assert.Equal(t, entries[0].Value, values[0])
+}
+
+// TestInvalidRequests tests that invalid requests and requests for unknown blocks are rejected.
+func TestInvalidRequests(t *testing.T) {
+	client, blockID, account := setup(t)
+
+	_, err := client.Account(context.Background(), unittest.IdentifierFixture(), account.Address)
+	assert.ErrorContains(t, err, "404")
+
+	_, err = client.Registers(context.Background(), blockID, nil)
+	assert.ErrorContains(t, err, "400")
+
+	_, err = client.Registers(context.Background(), blockID, make([]flow.RegisterID, MaxRegistersPerRequest+1))
+	assert.ErrorContains(t, err, "400")
+
+	req, err := http.NewRequest(http.MethodGet, client.baseURL+RegistersEndpoint, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	resp, err := client.client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+// TestServer_Authentication tests that requests without a valid token are rejected.
+func TestServer_Authentication(t *testing.T) {
+	client, blockID, account := setup(t)
+
+	for _, token := range []string{"", "wrong"} {
+		unauthenticated := NewClient(client.client, client.baseURL, token)
+		_, err := unauthenticated.Account(context.Background(), blockID, account.Address)
+		assert.ErrorContains(t, err, "401")
+	}
+}
+
+// TestServer_RateLimit tests that requests exceeding the rate limit are rejected.
+func TestServer_RateLimit(t *testing.T) {
+	// no tokens are refilled during the test, so that only the burst is allowed
+	defaultRateLimit := requestRateLimit
+	requestRateLimit = rate.Limit(0.01)
+	t.Cleanup(func() {
+		requestRateLimit = defaultRateLimit
+	})
+	client, _, account := setup(t)
+
+	// requests for unknown blocks are cheap, but count towards the rate limit all the same
+	for i := 0; i < requestBurst; i++ {
+		_, err := client.Account(context.Background(), unittest.IdentifierFixture(), account.Address)
+		require.ErrorContains(t, err, "404")
+	}
+	_, err := client.Account(context.Background(), unittest.IdentifierFixture(), account.Address)
+	assert.ErrorContains(t, err, "429")
+}
@@ -0,0 +1,194 @@
+package registers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/zerolog"
+	"golang.org/x/time/rate"
+
+	"github.com/onflow/flow-go/access/stateproof"
+	"github.com/onflow/flow-go/engine/execution/state"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/httpserver"
+	"github.com/onflow/flow-go/storage"
+)
+
+const (
+	// RegistersEndpoint serves the values of the registers given by a RegistersRequest,
+	// together with a proof.
+	RegistersEndpoint = "/v1/registers/proof"
+
+	// AccountEndpoint serves the values of the registers storing the keys and contracts of
+	// the account given by an AccountRequest, together with a proof.
+	AccountEndpoint = "/v1/accounts/proof"
+)
+
+const (
+	// maxRequestSize is the maximum size of a request body.
+	maxRequestSize = 1 << 20 // 1 MB
+
+	// retryAfterSeconds is the delay in seconds after which clients should retry rate limited requests.
+	retryAfterSeconds = 1
+)
+
+var (
+	// requestRateLimit limits the rate of requests to both endpoints, as each request reads
+	// registers from the execution state and creates a proof for them.
+	requestRateLimit = rate.Limit(50)
+	requestBurst     = 100
+)
+
+// errNotAvailable is returned when the state of the requested block is not available.
+var errNotAvailable = errors.New("state not available")
+
+// Server is the http server serving register values with proofs from the execution state,
+// so that access nodes can provide verifiable reads to their clients.
+// Every request must carry the configured token as bearer token in its Authorization header.
+type Server struct {
+	*httpserver.Server
+}
+
+// NewServer creates a new server that will listen on the specified address and serve
+// registers read from the given execution state to clients authenticated with the given token.
+func NewServer(log zerolog.Logger, execState state.ReadOnlyExecutionState, addr string, token string) *Server {
+	log = log.With().Str("component", "register_proof_server").Logger()
+	return &Server{
+		Server: httpserver.NewServer(log, "register proof", addr, newHandler(log, execState, token)),
+	}
+}
+
+// newHandler returns the handler serving the registers and account endpoints.
+func newHandler(log zerolog.Logger, execState state.ReadOnlyExecutionState, token string) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(RegistersEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		var req RegistersRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+		if len(req.Registers) == 0 || len(req.Registers) > MaxRegistersPerRequest {
+			http.Error(w, fmt.Sprintf("between 1 and %d registers must be requested", MaxRegistersPerRequest), http.StatusBadRequest)
+			return
+		}
+
+		serve(log, w, r.Context(), execState, req.BlockID, func(flow.StateCommitment) ([]flow.RegisterID, error) {
+			return toRegisterIDs(req.Registers), nil
+		})
+	})
+
+	mux.HandleFunc(AccountEndpoint, func(w http.ResponseWriter, r *http.Request) {
+		var req AccountRequest
+		if !decodeRequest(w, r, &req) {
+			return
+		}
+
+		serve(log, w, r.Context(), execState, req.BlockID, func(commit flow.StateCommitment) ([]flow.RegisterID, error) {
+			return stateproof.AccountRegisters(req.Address, func(owner, key string) (flow.RegisterValue, error) {
+				values, err := execState.GetRegisters(r.Context(), commit, []flow.RegisterID{flow.NewRegisterID(owner, key)})
+				if err != nil {
+					return nil, err
+				}
+				return values[0], nil
+			})
+		})
+	})
+
+	limiter := rate.NewLimiter(requestRateLimit, requestBurst)
+	return httpserver.Authenticate(token, httpserver.RateLimit(limiter, retryAfterSeconds, mux))
+}
+
+// serve responds with the values of the registers returned by getIDs at the final state of
+// the given block, together with a proof.
+func serve(
+	log zerolog.Logger,
+	w http.ResponseWriter,
+	ctx context.Context,
+	execState state.ReadOnlyExecutionState,
+	blockID flow.Identifier,
+	getIDs func(commit flow.StateCommitment) ([]flow.RegisterID, error),
+) {
+	log = log.With().Hex("block_id", blockID[:]).Logger()
+
+	resp, err := read(ctx, execState, blockID, getIDs)
+	if errors.Is(err, errNotAvailable) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("could not read registers with proof")
+		http.Error(w, "could not read registers with proof", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(resp)
+	if err != nil {
+		log.Warn().Err(err).Msg("could not write registers with proof")
+	}
+}
+
+// read reads the values of the registers returned by getIDs at the final state of the given
+// block, and creates a proof for them.
+// Expected errors during normal operations:
+//   - errNotAvailable if the block has not been executed, or its state has been pruned
+func read(
+	ctx context.Context,
+	execState state.ReadOnlyExecutionState,
+	blockID flow.Identifier,
+	getIDs func(commit flow.StateCommitment) ([]flow.RegisterID, error),
+) (*Response, error) {
+	commit, err := execState.StateCommitmentByBlockID(ctx, blockID)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, fmt.Errorf("block %v has not been executed: %w", blockID, errNotAvailable)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not get state commitment of block %v: %w", blockID, err)
+	}
+	if !execState.HasState(commit) {
+		return nil, fmt.Errorf("state of block %v has been pruned: %w", blockID, errNotAvailable)
+	}
+
+	ids, err := getIDs(commit)
+	if err != nil {
+		return nil, fmt.Errorf("could not get registers to read: %w", err)
+	}
+	values, err := execState.GetRegisters(ctx, commit, ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not read registers: %w", err)
+	}
+	proof, err := execState.GetProof(ctx, commit, ids)
+	if err != nil {
+		return nil, fmt.Errorf("could not create proof: %w", err)
+	}
+
+	registers := toRegisters(ids)
+	for i := range registers {
+		registers[i].Value = values[i]
+	}
+
+	return &Response{
+		BlockID:         blockID,
+		StateCommitment: commit,
+		Registers:       registers,
+		Proof:           proof,
+	}, nil
+}
+
+// decodeRequest decodes the JSON body of a POST request into req. It responds with an error
+// and returns false if the request is invalid.
+func decodeRequest(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return false
+	}
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(req)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return false
+	}
+	return true
+}
@@ -24,6 +24,7 @@ import (
 
 	"github.com/onflow/flow-go/consensus"
 	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/evidence"
 	mockhotstuff "github.com/onflow/flow-go/consensus/hotstuff/mocks"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications"
 	"github.com/onflow/flow-go/consensus/hotstuff/notifications/pubsub"
@@ -331,11 +332,15 @@ func CollectionNode(t *testing.T, ctx irrecoverable.SignalerContext, hub *stub.H
 	createMetrics := func(chainID flow.ChainID) module.HotstuffMetrics {
 		return metrics.NewNoopCollector()
 	}
+	// the evidence collector is not started, offenses detected by the test nodes are not collected
+	evidenceCollector, err := evidence.NewCollector(node.Log, evidence.NewStore(node.PublicDB))
+	require.NoError(t, err)
 	hotstuffFactory, err := factories.NewHotStuffFactory(
 		node.Log,
 		node.Me,
 		node.PublicDB,
 		node.State,
+		evidenceCollector,
 		createMetrics,
 		consensus.WithInitialTimeout(time.Second*2),
 	)
@@ -872,14 +877,13 @@ func VerificationNode(t testing.TB,
 	}
 
 	if node.ProcessedChunkIndex == nil {
-		node.ProcessedChunkIndex = storage.NewConsumerProgress(node.PublicDB, module.ConsumeProgressVerificationChunkIndex)
+		node.ProcessedChunkIndex = storage.NewConsumerProgress(node.PublicDB, module.ConsumeProgressVerificationChunkLocatorIndex)
 	}
 
 	if node.ChunksQueue == nil {
-		node.ChunksQueue = storage.NewChunkQueue(node.PublicDB)
-		ok, err := node.ChunksQueue.Init(chunkconsumer.DefaultJobIndex)
-		require.NoError(t, err)
-		require.True(t, ok)
+		node.ChunksQueue = chunkconsumer.NewChunksQueue(node.Log,
+			storage.NewPersistentJobQueue(node.PublicDB, chunkconsumer.JobQueueChunkLocators),
+			storage.NewDeadLetters(node.PublicDB, chunkconsumer.JobQueueChunkLocators))
 	}
 
 	if node.ProcessedBlockHeight == nil {
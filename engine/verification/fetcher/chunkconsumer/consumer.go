@@ -14,33 +14,48 @@ import (
 const (
 	DefaultJobIndex     = uint64(0)
 	DefaultChunkWorkers = uint64(5)
+	// DefaultOutOfOrderWindow is the max number of chunks which are verified while a chunk with a
+	// lower index is still being fetched. Fetching a chunk data pack can take long, the window keeps
+	// the completed chunks cached in the meantime bounded.
+	DefaultOutOfOrderWindow = uint64(1000)
 )
 
 // ChunkConsumer consumes the jobs from the job queue, and pass it to the
 // Worker for processing.
 // It wraps the generic job consumer in order to be used as a ReadyDoneAware
 // on startup
+// Chunks which the processor failed to process are retried, and set aside as dead letters if
+// they failed too many times and the chunks queue has dead letters.
 type ChunkConsumer struct {
-	consumer       module.JobConsumer
+	consumer       *jobqueue.Consumer
 	chunkProcessor fetcher.AssignedChunkProcessor
 	metrics        module.VerificationMetrics
 }
 
+var _ jobqueue.DeadLetterQueue = (*ChunkConsumer)(nil)
+
+// NewChunkConsumer creates a chunk consumer. The given options are applied to the underlying job consumer,
+// e.g. to configure how failed chunks are retried.
 func NewChunkConsumer(
 	log zerolog.Logger,
 	metrics module.VerificationMetrics,
 	processedIndex storage.ConsumerProgress, // to persist the processed index
-	chunksQueue storage.ChunksQueue, // to read jobs (chunks) from
+	chunksQueue *ChunksQueue, // to read jobs (chunks) from
 	chunkProcessor fetcher.AssignedChunkProcessor, // to process jobs (chunks)
 	maxProcessing uint64, // max number of jobs to be processed in parallel
+	opts ...jobqueue.ConsumerOption,
 ) *ChunkConsumer {
 	worker := NewWorker(chunkProcessor)
 	chunkProcessor.WithChunkConsumerNotifier(worker)
 
-	jobs := &ChunkJobs{locators: chunksQueue}
+	consumerOpts := []jobqueue.ConsumerOption{jobqueue.WithOutOfOrderWindow(DefaultOutOfOrderWindow)}
+	if chunksQueue.deadLetters != nil {
+		consumerOpts = append(consumerOpts, jobqueue.WithDeadLetters(chunksQueue.deadLetters))
+	}
+	consumerOpts = append(consumerOpts, opts...)
 
 	lg := log.With().Str("module", "chunk_consumer").Logger()
-	consumer := jobqueue.NewConsumer(lg, jobs, processedIndex, worker, maxProcessing, 0)
+	consumer := jobqueue.NewConsumer(lg, chunksQueue.jobs, processedIndex, worker, maxProcessing, 0, consumerOpts...)
 
 	chunkConsumer := &ChunkConsumer{
 		consumer:       consumer,
@@ -58,6 +73,34 @@ func (c *ChunkConsumer) NotifyJobIsDone(jobID module.JobID) {
 	c.metrics.OnChunkConsumerJobDone(processedIndex)
 }
 
+// NotifyJobFailed lets the consumer know that processing the chunk of the given job failed, so that it
+// is retried later, or dead-lettered.
+func (c *ChunkConsumer) NotifyJobFailed(jobID module.JobID, err error) {
+	processedIndex := c.consumer.NotifyJobFailed(jobID, err)
+	c.metrics.OnChunkConsumerJobDone(processedIndex)
+}
+
+// DeadLetters returns the dead-lettered chunk jobs, ordered by job index.
+func (c *ChunkConsumer) DeadLetters() ([]*storage.DeadLetter, error) {
+	return c.consumer.DeadLetters()
+}
+
+// RetryDeadLetter processes the dead-lettered chunk job at the given index again.
+// Expected errors during normal operations:
+//   - jobqueue.ErrDeadLettersDisabled if the chunks queue has no dead letters
+//   - storage.ErrNotFound if the job at the given index is not dead-lettered
+func (c *ChunkConsumer) RetryDeadLetter(index uint64) error {
+	return c.consumer.RetryDeadLetter(index)
+}
+
+// DropDeadLetter removes the dead letter of the chunk job at the given index, without processing it.
+// Expected errors during normal operations:
+//   - jobqueue.ErrDeadLettersDisabled if the chunks queue has no dead letters
+//   - storage.ErrNotFound if the job at the given index is not dead-lettered
+func (c *ChunkConsumer) DropDeadLetter(index uint64) error {
+	return c.consumer.DropDeadLetter(index)
+}
+
 // Size returns number of in-memory chunk jobs that chunk consumer is processing.
 func (c *ChunkConsumer) Size() uint {
 	return c.consumer.Size()
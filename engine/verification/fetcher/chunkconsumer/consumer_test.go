@@ -1,20 +1,27 @@
 package chunkconsumer_test
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/atomic"
 
+	"github.com/onflow/flow-go/admin"
+	jobqueueCommands "github.com/onflow/flow-go/admin/commands/jobqueue"
 	"github.com/onflow/flow-go/engine/verification/fetcher/chunkconsumer"
 	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/jobqueue"
 	"github.com/onflow/flow-go/module/metrics"
-	storage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -27,6 +34,48 @@ func TestChunkLocatorToJob(t *testing.T) {
 	require.Equal(t, locator, actual)
 }
 
+// TestChunksQueue evaluates that chunk locators are stored uniquely with increasing indices, and
+// removed once processed by the chunk consumer.
+func TestChunksQueue(t *testing.T) {
+	finish := func(notifier module.ProcessingFailureNotifier, locator *chunks.Locator) {
+		go notifier.Notify(locator.ID())
+	}
+	WithConsumer(t, finish, func(consumer *chunkconsumer.ChunkConsumer, chunksQueue *chunkconsumer.ChunksQueue) {
+		locators := unittest.ChunkLocatorListFixture(3)
+		for i, locator := range locators {
+			ok, err := chunksQueue.StoreChunkLocator(locator)
+			require.NoError(t, err)
+			require.True(t, ok)
+
+			latest, err := chunksQueue.LatestIndex()
+			require.NoError(t, err)
+			require.Equal(t, uint64(i+1), latest)
+
+			stored, err := chunksQueue.AtIndex(latest)
+			require.NoError(t, err)
+			require.Equal(t, locator, stored)
+		}
+
+		// duplicate locators are not stored
+		ok, err := chunksQueue.StoreChunkLocator(locators[1])
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		<-consumer.Ready()
+		consumer.Check()
+		require.Eventually(t, func() bool {
+			_, err := chunksQueue.AtIndex(3)
+			return errors.Is(err, storage.ErrNotFound)
+		}, time.Second, 10*time.Millisecond, "processed chunk locators should be removed")
+		<-consumer.Done()
+
+		// processed locators are still not stored again
+		ok, err = chunksQueue.StoreChunkLocator(locators[0])
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
+
 // TestProduceConsume evaluates different scenarios on passing jobs to chunk queue with 3 workers on the consumer side. It evaluates blocking and
 // none-blocking engines attached to the workers in sequential and concurrent scenarios.
 func TestProduceConsume(t *testing.T) {
@@ -35,12 +84,12 @@ func TestProduceConsume(t *testing.T) {
 	t.Run("pushing 10 jobs receive 3", func(t *testing.T) {
 		var called chunks.LocatorList
 		lock := &sync.Mutex{}
-		neverFinish := func(notifier module.ProcessingNotifier, locator *chunks.Locator) {
+		neverFinish := func(notifier module.ProcessingFailureNotifier, locator *chunks.Locator) {
 			lock.Lock()
 			defer lock.Unlock()
 			called = append(called, locator)
 		}
-		WithConsumer(t, neverFinish, func(consumer *chunkconsumer.ChunkConsumer, chunksQueue *storage.ChunksQueue) {
+		WithConsumer(t, neverFinish, func(consumer *chunkconsumer.ChunkConsumer, chunksQueue *chunkconsumer.ChunksQueue) {
 			<-consumer.Ready()
 
 			locators := unittest.ChunkLocatorListFixture(10)
@@ -66,7 +115,7 @@ func TestProduceConsume(t *testing.T) {
 		var called chunks.LocatorList
 		lock := &sync.Mutex{}
 		var finishAll sync.WaitGroup
-		alwaysFinish := func(notifier module.ProcessingNotifier, locator *chunks.Locator) {
+		alwaysFinish := func(notifier module.ProcessingFailureNotifier, locator *chunks.Locator) {
 			lock.Lock()
 			defer lock.Unlock()
 			called = append(called, locator)
@@ -76,7 +125,7 @@ func TestProduceConsume(t *testing.T) {
 				finishAll.Done()
 			}()
 		}
-		WithConsumer(t, alwaysFinish, func(consumer *chunkconsumer.ChunkConsumer, chunksQueue *storage.ChunksQueue) {
+		WithConsumer(t, alwaysFinish, func(consumer *chunkconsumer.ChunkConsumer, chunksQueue *chunkconsumer.ChunksQueue) {
 			<-consumer.Ready()
 
 			locators := unittest.ChunkLocatorListFixture(10)
@@ -102,7 +151,7 @@ func TestProduceConsume(t *testing.T) {
 		lock := &sync.Mutex{}
 		var finishAll sync.WaitGroup
 		finishAll.Add(100)
-		alwaysFinish := func(notifier module.ProcessingNotifier, locator *chunks.Locator) {
+		alwaysFinish := func(notifier module.ProcessingFailureNotifier, locator *chunks.Locator) {
 			lock.Lock()
 			defer lock.Unlock()
 			called = append(called, locator)
@@ -111,7 +160,7 @@ func TestProduceConsume(t *testing.T) {
 				finishAll.Done()
 			}()
 		}
-		WithConsumer(t, alwaysFinish, func(consumer *chunkconsumer.ChunkConsumer, chunksQueue *storage.ChunksQueue) {
+		WithConsumer(t, alwaysFinish, func(consumer *chunkconsumer.ChunkConsumer, chunksQueue *chunkconsumer.ChunksQueue) {
 			<-consumer.Ready()
 			total := atomic.NewUint32(0)
 
@@ -136,19 +185,95 @@ func TestProduceConsume(t *testing.T) {
 	})
 }
 
+// TestDeadLetters evaluates that a chunk which the processor fails to process is retried, dead-lettered
+// once it failed too many times, and can be listed and retried through the admin commands.
+func TestDeadLetters(t *testing.T) {
+	locators := unittest.ChunkLocatorListFixture(3)
+	failing := locators[1].ID()
+
+	var attempts atomic.Uint32
+	fixed := atomic.NewBool(false)
+	process := func(notifier module.ProcessingFailureNotifier, locator *chunks.Locator) {
+		if locator.ID() == failing && !fixed.Load() {
+			attempts.Inc()
+			go notifier.NotifyFailed(locator.ID(), fmt.Errorf("could not fetch chunk data pack"))
+			return
+		}
+		go notifier.Notify(locator.ID())
+	}
+	WithConsumer(t, process, func(consumer *chunkconsumer.ChunkConsumer, chunksQueue *chunkconsumer.ChunksQueue) {
+		registry := jobqueue.NewRegistry()
+		require.NoError(t, registry.Register(chunkconsumer.JobQueueChunkLocators, consumer))
+
+		<-consumer.Ready()
+		for _, locator := range locators {
+			ok, err := chunksQueue.StoreChunkLocator(locator)
+			require.NoError(t, err)
+			require.True(t, ok)
+		}
+		consumer.Check()
+
+		// the failing chunk is dead-lettered after two attempts, and no longer blocks the others
+		require.Eventually(t, func() bool {
+			letters, err := consumer.DeadLetters()
+			require.NoError(t, err)
+			return len(letters) == 1
+		}, time.Second, 10*time.Millisecond, "failing chunk should be dead-lettered")
+		require.Equal(t, uint32(2), attempts.Load())
+		require.Eventually(t, func() bool {
+			_, err := chunksQueue.AtIndex(3)
+			return errors.Is(err, storage.ErrNotFound)
+		}, time.Second, 10*time.Millisecond, "processed chunk locators should be removed")
+
+		// the dead-lettered chunk is kept in the queue, and listed by the admin command
+		stored, err := chunksQueue.AtIndex(2)
+		require.NoError(t, err)
+		require.Equal(t, locators[1], stored)
+
+		list := jobqueueCommands.NewListDeadLettersCommand(registry)
+		req := &admin.CommandRequest{Data: map[string]interface{}{"queue": chunkconsumer.JobQueueChunkLocators}}
+		require.NoError(t, list.Validator(req))
+		result, err := list.Handler(context.Background(), req)
+		require.NoError(t, err)
+		listed := result.(map[string]interface{})["dead_letters"].([]interface{})
+		require.Len(t, listed, 1)
+		letter := listed[0].(map[string]interface{})
+		require.Equal(t, float64(2), letter["Index"])
+		require.Equal(t, failing.String(), letter["JobID"])
+		require.Contains(t, letter["Error"], "could not fetch chunk data pack")
+
+		// once the cause is fixed, the admin command retries the dead-lettered chunk successfully
+		fixed.Store(true)
+		retry := jobqueueCommands.NewRetryDeadLetterCommand(registry)
+		req = &admin.CommandRequest{Data: map[string]interface{}{"queue": chunkconsumer.JobQueueChunkLocators, "index": float64(2)}}
+		require.NoError(t, retry.Validator(req))
+		_, err = retry.Handler(context.Background(), req)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			_, err := chunksQueue.AtIndex(2)
+			return errors.Is(err, storage.ErrNotFound)
+		}, time.Second, 10*time.Millisecond, "retried chunk locator should be removed once processed")
+		letters, err := consumer.DeadLetters()
+		require.NoError(t, err)
+		require.Empty(t, letters)
+
+		<-consumer.Done()
+	})
+}
+
 func WithConsumer(
 	t *testing.T,
-	process func(module.ProcessingNotifier, *chunks.Locator),
-	withConsumer func(*chunkconsumer.ChunkConsumer, *storage.ChunksQueue),
+	process func(module.ProcessingFailureNotifier, *chunks.Locator),
+	withConsumer func(*chunkconsumer.ChunkConsumer, *chunkconsumer.ChunksQueue),
 ) {
 	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
 		maxProcessing := uint64(3)
 
-		processedIndex := storage.NewConsumerProgress(db, module.ConsumeProgressVerificationChunkIndex)
-		chunksQueue := storage.NewChunkQueue(db)
-		ok, err := chunksQueue.Init(chunkconsumer.DefaultJobIndex)
-		require.NoError(t, err)
-		require.True(t, ok)
+		processedIndex := bstorage.NewConsumerProgress(db, module.ConsumeProgressVerificationChunkLocatorIndex)
+		chunksQueue := chunkconsumer.NewChunksQueue(unittest.Logger(),
+			bstorage.NewPersistentJobQueue(db, chunkconsumer.JobQueueChunkLocators),
+			bstorage.NewDeadLetters(db, chunkconsumer.JobQueueChunkLocators))
 
 		engine := &mockChunkProcessor{
 			process: process,
@@ -162,6 +287,11 @@ func WithConsumer(
 			chunksQueue,
 			engine,
 			maxProcessing,
+			jobqueue.WithRetries(jobqueue.RetryConfig{
+				MaxAttempts:    2,
+				InitialBackoff: time.Millisecond,
+				MaxBackoff:     time.Millisecond,
+			}),
 		)
 
 		withConsumer(consumer, chunksQueue)
@@ -170,8 +300,8 @@ func WithConsumer(
 
 // mockChunkProcessor provides an AssignedChunkProcessor with a plug-and-play process method.
 type mockChunkProcessor struct {
-	notifier module.ProcessingNotifier
-	process  func(notifier module.ProcessingNotifier, locator *chunks.Locator)
+	notifier module.ProcessingFailureNotifier
+	process  func(notifier module.ProcessingFailureNotifier, locator *chunks.Locator)
 }
 
 func (e *mockChunkProcessor) Ready() <-chan struct{} {
@@ -190,6 +320,6 @@ func (e *mockChunkProcessor) ProcessAssignedChunk(locator *chunks.Locator) {
 	e.process(e.notifier, locator)
 }
 
-func (e *mockChunkProcessor) WithChunkConsumerNotifier(notifier module.ProcessingNotifier) {
+func (e *mockChunkProcessor) WithChunkConsumerNotifier(notifier module.ProcessingFailureNotifier) {
 	e.notifier = notifier
 }
@@ -1,25 +1,90 @@
 package chunkconsumer
 
 import (
+	"errors"
 	"fmt"
 
+	"github.com/rs/zerolog"
+	"github.com/vmihailenco/msgpack"
+
+	"github.com/onflow/flow-go/model/chunks"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/jobqueue"
 	"github.com/onflow/flow-go/storage"
 )
 
-// ChunkJobs wraps the storage layer to provide an abstraction for consumers to read jobs.
-type ChunkJobs struct {
-	locators storage.ChunksQueue
+// JobQueueChunkLocators is the name of the persistent job queue of the chunk locators assigned to this node.
+const JobQueueChunkLocators = "JobQueueChunkLocators"
+
+// ChunksQueue is the queue of chunk locators assigned to this node. The assigner engine pushes the
+// chunk locators, which are persisted as the jobs of a push-style job queue, and the chunk consumer
+// reads them by index. Chunk locators stored in this queue are unique, and are removed once the
+// chunk consumer processed them, unless they were dead-lettered.
+type ChunksQueue struct {
+	jobs        *jobqueue.PersistentJobProducer
+	deadLetters storage.DeadLetters // nil if the chunk consumer retries failed chunks forever
+}
+
+var _ storage.ChunksQueue = (*ChunksQueue)(nil)
+
+// NewChunksQueue creates a chunks queue persisting the chunk locators in the given job storage.
+// The chunk consumer sets chunks which failed too many times aside in the given dead letters,
+// deadLetters may be nil to retry failed chunks forever instead.
+func NewChunksQueue(log zerolog.Logger, store storage.PersistentJobQueue, deadLetters storage.DeadLetters) *ChunksQueue {
+	return &ChunksQueue{
+		jobs:        jobqueue.NewPersistentJobProducer(log, store, deadLetters, encodeChunkJob, decodeChunkJob),
+		deadLetters: deadLetters,
+	}
 }
 
-func (j *ChunkJobs) AtIndex(index uint64) (module.Job, error) {
-	locator, err := j.locators.AtIndex(index)
+// StoreChunkLocator stores a new chunk locator that assigned to me to the job queue.
+// A true will be returned, if the locator was new.
+// A false will be returned, if the locator was duplicate.
+func (q *ChunksQueue) StoreChunkLocator(locator *chunks.Locator) (bool, error) {
+	_, err := q.jobs.Push(ChunkLocatorToJob(locator))
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to store chunk locator: %w", err)
+	}
+	return true, nil
+}
+
+// LatestIndex returns the index of the latest chunk locator stored in the queue.
+func (q *ChunksQueue) LatestIndex() (uint64, error) {
+	return q.jobs.Head()
+}
+
+// AtIndex returns the chunk locator stored at the given index in the queue.
+// Error returns:
+//   - storage.ErrNotFound if no chunk locator was stored at the given index, or if it was processed
+func (q *ChunksQueue) AtIndex(index uint64) (*chunks.Locator, error) {
+	job, err := q.jobs.AtIndex(index)
 	if err != nil {
 		return nil, fmt.Errorf("could not read chunk: %w", err)
 	}
-	return ChunkLocatorToJob(locator), nil
+	return JobToChunkLocator(job)
+}
+
+func encodeChunkJob(job module.Job) ([]byte, error) {
+	locator, err := JobToChunkLocator(job)
+	if err != nil {
+		return nil, err
+	}
+	return msgpack.Marshal(locator)
 }
 
-func (j *ChunkJobs) Head() (uint64, error) {
-	return j.locators.LatestIndex()
+func decodeChunkJob(id module.JobID, payload []byte) (module.Job, error) {
+	var locator chunks.Locator
+	err := msgpack.Unmarshal(payload, &locator)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode chunk locator: %w", err)
+	}
+
+	job := ChunkLocatorToJob(&locator)
+	if job.ID() != id {
+		return nil, fmt.Errorf("chunk locator does not match job id %v", id)
+	}
+	return job, nil
 }
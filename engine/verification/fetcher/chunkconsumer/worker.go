@@ -20,7 +20,7 @@ func NewWorker(engine fetcher.AssignedChunkProcessor) *Worker {
 }
 
 // Run converts the job to Chunk, it's guaranteed to work, because
-// ChunkJobs converted chunk into job symmetrically
+// ChunksQueue converted chunk into job symmetrically
 func (w *Worker) Run(job module.Job) error {
 	chunk, err := JobToChunkLocator(job)
 	if err != nil {
@@ -35,3 +35,9 @@ func (w *Worker) Notify(chunkLocatorID flow.Identifier) {
 	jobID := locatorIDToJobID(chunkLocatorID)
 	w.consumer.NotifyJobIsDone(jobID)
 }
+
+// NotifyFailed lets the consumer know that processing the chunk failed, so that it is retried later.
+func (w *Worker) NotifyFailed(chunkLocatorID flow.Identifier, err error) {
+	jobID := locatorIDToJobID(chunkLocatorID)
+	w.consumer.NotifyJobFailed(jobID, err)
+}
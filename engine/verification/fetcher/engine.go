@@ -50,9 +50,9 @@ type Engine struct {
 	receipts      storage.ExecutionReceipts // used to find executor ids of a chunk, for requesting chunk data pack.
 
 	// output interfaces
-	verifier              network.Engine            // used to push verifiable chunk down the verification pipeline.
-	requester             ChunkDataPackRequester    // used to request chunk data packs from network.
-	chunkConsumerNotifier module.ProcessingNotifier // used to notify chunk consumer that it is done processing a chunk.
+	verifier              network.Engine                   // used to push verifiable chunk down the verification pipeline.
+	requester             ChunkDataPackRequester           // used to request chunk data packs from network.
+	chunkConsumerNotifier module.ProcessingFailureNotifier // used to notify chunk consumer that it is done processing a chunk.
 
 	stopAtHeight uint64
 }
@@ -94,8 +94,8 @@ func New(
 
 // WithChunkConsumerNotifier sets the processing notifier of fetcher.
 // The fetcher engine uses this notifier to inform the chunk consumer that it is done processing a given chunk, and
-// is ready to receive a new chunk to process.
-func (e *Engine) WithChunkConsumerNotifier(notifier module.ProcessingNotifier) {
+// is ready to receive a new chunk to process, or that it failed processing the chunk.
+func (e *Engine) WithChunkConsumerNotifier(notifier module.ProcessingFailureNotifier) {
 	e.chunkConsumerNotifier = notifier
 }
 
@@ -125,7 +125,8 @@ func (e *Engine) Done() <-chan struct{} {
 // It should not be blocking since multiple chunk consumer workers might be calling it concurrently.
 // It fetches the chunk data pack, once received, verifier engine will be verifying
 // Once a chunk has been processed, it will call the processing notifier callback to notify
-// the chunk consumer in order to process the next chunk.
+// the chunk consumer in order to process the next chunk. If the chunk could not be processed,
+// the chunk consumer is notified of the failure, so that it retries the chunk later.
 func (e *Engine) ProcessAssignedChunk(locator *chunks.Locator) {
 	locatorID := locator.ID()
 	lg := e.log.With().
@@ -155,7 +156,10 @@ func (e *Engine) ProcessAssignedChunk(locator *chunks.Locator) {
 	lg = lg.With().Uint64("block_height", blockHeight).Logger()
 
 	if err != nil {
-		lg.Fatal().Err(err).Msg("could not process assigned chunk")
+		// the chunk consumer retries the chunk later, or sets it aside if it failed too many times
+		lg.Error().Err(err).Msg("could not process assigned chunk")
+		e.chunkConsumerNotifier.NotifyFailed(locatorID, err)
+		return
 	}
 
 	lg.Info().Bool("requested", requested).Msg("assigned chunk processed successfully")
@@ -185,15 +189,29 @@ func (e *Engine) processAssignedChunkWithTracing(chunk *flow.Chunk, result *flow
 func (e *Engine) processAssignedChunk(chunk *flow.Chunk, result *flow.ExecutionResult, chunkLocatorID flow.Identifier) (bool, uint64, error) {
 	// skips processing a chunk if it belongs to a sealed block.
 	chunkID := chunk.ID()
-	sealed, blockHeight, err := e.blockIsSealed(chunk.ChunkBody.BlockID)
+	sealing, err := sealingStatus(e.state, e.headers, chunk.ChunkBody.BlockID)
 	if err != nil {
 		return false, 0, fmt.Errorf("could not determine whether block has been sealed: %w", err)
 	}
-	if sealed {
+	blockHeight := sealing.BlockHeight
+	if sealing.Sealed() {
+		e.metrics.OnSealedChunkSkippedByFetcher()
 		e.chunkConsumerNotifier.Notify(chunkLocatorID) // tells consumer that we are done with this chunk.
 		return false, blockHeight, nil
 	}
 
+	// chunks of blocks past their emergency-sealing deadline are still verified, but reported
+	// as an indicator of this verification node falling behind.
+	if sealing.Late(DefaultEmergencySealingThreshold) {
+		e.metrics.OnLateChunkReceivedAtFetcher()
+		e.log.Warn().
+			Hex("chunk_id", logging.ID(chunkID)).
+			Uint64("block_height", blockHeight).
+			Uint64("finalized_height", sealing.FinalizedHeight).
+			Uint64("sealed_height", sealing.SealedHeight).
+			Msg("assigned chunk is past its emergency-sealing deadline")
+	}
+
 	// skip chunk if it verifies a block at or above stop height
 	if e.stopAtHeight > 0 && blockHeight >= e.stopAtHeight {
 		e.log.Warn().Msgf("Skipping chunk %s - height  %d at or above stop height requested (%d)", chunkID, blockHeight, e.stopAtHeight)
@@ -214,6 +232,8 @@ func (e *Engine) processAssignedChunk(chunk *flow.Chunk, result *flow.ExecutionR
 
 	err = e.requestChunkDataPack(chunk.Index, chunkID, result.ID(), chunk.BlockID)
 	if err != nil {
+		// the chunk is pending again once processing it is retried
+		e.pendingChunks.Remove(chunk.Index, result.ID())
 		return false, blockHeight, fmt.Errorf("could not request chunk data pack: %w", err)
 	}
 
@@ -494,6 +514,9 @@ func (e *Engine) NotifyChunkDataPackSealed(chunkIndex uint64, resultID flow.Iden
 		Uint64("block_height", status.BlockHeight).
 		Hex("result_id", logging.ID(status.ExecutionResult.ID())).Logger()
 	removed := e.pendingChunks.Remove(chunkIndex, resultID)
+	if removed {
+		e.metrics.OnSealedChunkSkippedByFetcher()
+	}
 
 	e.chunkConsumerNotifier.Notify(chunkLocatorID)
 	lg.Info().
@@ -625,23 +648,6 @@ func (e *Engine) getAgreeAndDisagreeExecutors(blockID flow.Identifier, resultID
 	return agrees, disagrees, nil
 }
 
-// blockIsSealed returns true if the block at specified height by block ID is sealed.
-func (e Engine) blockIsSealed(blockID flow.Identifier) (bool, uint64, error) {
-	// TODO: as an optimization, we can keep record of last sealed height on a local variable.
-	header, err := e.headers.ByBlockID(blockID)
-	if err != nil {
-		return false, 0, fmt.Errorf("could not get block: %w", err)
-	}
-
-	lastSealed, err := e.state.Sealed().Head()
-	if err != nil {
-		return false, 0, fmt.Errorf("could not get last sealed: %w", err)
-	}
-
-	sealed := header.Height <= lastSealed.Height
-	return sealed, header.Height, nil
-}
-
 // executorsOf segregates the executors of the given receipts based on the given execution result id.
 // The agree set contains the executors who made receipt with the same result as the given result id.
 // The disagree set contains the executors who made receipt with different result than the given result id.
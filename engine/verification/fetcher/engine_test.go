@@ -38,7 +38,7 @@ type FetcherEngineTestSuite struct {
 	pendingChunks         *mempool.ChunkStatuses              // used to store all the pending chunks that assigned to this node
 	blocks                *storage.Blocks                     // used to for verifying collection ID.
 	headers               *storage.Headers                    // used for building verifiable chunk data.
-	chunkConsumerNotifier *module.ProcessingNotifier          // to report a chunk has been processed
+	chunkConsumerNotifier *module.ProcessingFailureNotifier   // to report a chunk has been processed
 	results               *storage.ExecutionResults           // to retrieve execution result of an assigned chunk
 	receipts              *storage.ExecutionReceipts          // used to find executor of the chunk
 	requester             *mockfetcher.ChunkDataPackRequester // used to request chunk data packs from network
@@ -55,7 +55,7 @@ func setupTest() *FetcherEngineTestSuite {
 		pendingChunks:         &mempool.ChunkStatuses{},
 		headers:               &storage.Headers{},
 		blocks:                &storage.Blocks{},
-		chunkConsumerNotifier: &module.ProcessingNotifier{},
+		chunkConsumerNotifier: &module.ProcessingFailureNotifier{},
 		results:               &storage.ExecutionResults{},
 		receipts:              &storage.ExecutionReceipts{},
 		requester:             &mockfetcher.ChunkDataPackRequester{},
@@ -254,6 +254,7 @@ func TestProcessAssignChunkSealedAfterRequest(t *testing.T) {
 	// as the response it receives a notification that chunk belongs to a sealed block.
 	// we mock this as the block is getting sealed after request dispatch.
 	s.metrics.On("OnChunkDataPackRequestSentByFetcher").Return().Times(len(requests))
+	s.metrics.On("OnSealedChunkSkippedByFetcher").Return().Times(len(requests))
 	requesterWg := mockRequester(t, s.requester, requests, responses, func(originID flow.Identifier,
 		response *verification.ChunkDataPackResponse) {
 		e.NotifyChunkDataPackSealed(response.Index, response.ResultID)
@@ -456,6 +457,7 @@ func TestSkipChunkOfSealedBlock(t *testing.T) {
 	statuses := unittest.ChunkStatusListFixture(t, block.Header.Height, result, 1)
 	locators := unittest.ChunkStatusListToChunkLocatorFixture(statuses)
 	s.metrics.On("OnAssignedChunkReceivedAtFetcher").Return().Once()
+	s.metrics.On("OnSealedChunkSkippedByFetcher").Return().Once()
 
 	mockBlockSealingStatus(s.state, s.headers, block.Header, true)
 	mockResultsByIDs(s.results, []*flow.ExecutionResult{result})
@@ -476,6 +478,38 @@ func TestSkipChunkOfSealedBlock(t *testing.T) {
 	s.pendingChunks.AssertNotCalled(t, "Add")
 }
 
+// TestReportLateChunk evaluates that if fetcher engine receives a chunk belonging to an unsealed block
+// that is past its emergency-sealing deadline, it reports the chunk as late and still processes it.
+func TestReportLateChunk(t *testing.T) {
+	s := setupTest()
+	e := newFetcherEngine(s)
+
+	// creates a single chunk locator, and mocks its block unsealed while enough blocks
+	// have been finalized on top of it to pass its emergency-sealing deadline.
+	block := unittest.BlockFixture()
+	result := unittest.ExecutionResultFixture(unittest.WithExecutionResultBlockID(block.ID()))
+	statuses := unittest.ChunkStatusListFixture(t, block.Header.Height, result, 1)
+	locators := unittest.ChunkStatusListToChunkLocatorFixture(statuses)
+	s.metrics.On("OnAssignedChunkReceivedAtFetcher").Return().Once()
+	s.metrics.On("OnLateChunkReceivedAtFetcher").Return().Once()
+
+	s.headers.On("ByBlockID", block.ID()).Return(block.Header, nil)
+	vertestutils.MockLastSealedHeight(s.state, block.Header.Height-1)
+	vertestutils.MockLastFinalizedHeight(s.state, block.Header.Height+fetcher.DefaultEmergencySealingThreshold)
+	mockResultsByIDs(s.results, []*flow.ExecutionResult{result})
+
+	// mocks the chunk as already pending, so that the fetcher engine does not request it again.
+	s.pendingChunks.On("Add", mock.Anything).Return(false).Once()
+
+	for _, locator := range locators {
+		e.ProcessAssignedChunk(locator)
+	}
+
+	mock.AssertExpectationsForObjects(t, s.results, s.metrics, s.pendingChunks)
+	s.requester.AssertNotCalled(t, "Request")
+	s.chunkConsumerNotifier.AssertNotCalled(t, "Notify", mock.Anything)
+}
+
 // TestSkipChunkOfSealedBlock evaluates that if fetcher engine receives a chunk belonging to a sealed block,
 // it drops it without processing it any further and notifies consumer
 // that it is done with processing that chunk.
@@ -752,7 +786,7 @@ func mockVerifierEngine(t *testing.T,
 
 // mockChunkConsumerNotifier mocks the notify method of processing notifier to be notified exactly once per
 // given chunk IDs.
-func mockChunkConsumerNotifier(t *testing.T, notifier *module.ProcessingNotifier, locatorIDs flow.IdentifierList) {
+func mockChunkConsumerNotifier(t *testing.T, notifier *module.ProcessingFailureNotifier, locatorIDs flow.IdentifierList) {
 	mu := &sync.Mutex{}
 	seen := make(map[flow.Identifier]struct{})
 	notifier.On("Notify", mock.Anything).Run(func(args mock.Arguments) {
@@ -773,6 +807,7 @@ func mockChunkConsumerNotifier(t *testing.T, notifier *module.ProcessingNotifier
 }
 
 // mockBlockSealingStatus mocks protocol state sealing status at height of given block.
+// The block is mocked as the last finalized one, i.e., it is far from its emergency-sealing deadline.
 func mockBlockSealingStatus(state *protocol.State, headers *storage.Headers, header *flow.Header, sealed bool) {
 	headers.On("ByBlockID", header.ID()).Return(header, nil)
 	if sealed {
@@ -780,6 +815,7 @@ func mockBlockSealingStatus(state *protocol.State, headers *storage.Headers, hea
 	} else {
 		vertestutils.MockLastSealedHeight(state, header.Height-1)
 	}
+	vertestutils.MockLastFinalizedHeight(state, header.Height)
 }
 
 // mockBlocksStorage mocks blocks and headers storages for given block.
@@ -53,7 +53,7 @@ func (_m *AssignedChunkProcessor) Ready() <-chan struct{} {
 }
 
 // WithChunkConsumerNotifier provides a mock function with given fields: notifier
-func (_m *AssignedChunkProcessor) WithChunkConsumerNotifier(notifier module.ProcessingNotifier) {
+func (_m *AssignedChunkProcessor) WithChunkConsumerNotifier(notifier module.ProcessingFailureNotifier) {
 	_m.Called(notifier)
 }
 
@@ -24,6 +24,7 @@ type AssignedChunkProcessor interface {
 	// WithChunkConsumerNotifier sets the notifier of this chunk processor.
 	// The notifier is called by the internal logic of the processor to let the consumer know that
 	// the processor is done by processing a chunk so that the next chunk may be passed to the processor
-	// by the consumer through invoking ProcessAssignedChunk of this processor.
-	WithChunkConsumerNotifier(notifier module.ProcessingNotifier)
+	// by the consumer through invoking ProcessAssignedChunk of this processor, or that processing the
+	// chunk failed, so that the consumer retries it later.
+	WithChunkConsumerNotifier(notifier module.ProcessingFailureNotifier)
 }
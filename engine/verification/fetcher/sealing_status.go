@@ -0,0 +1,71 @@
+package fetcher
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/storage"
+)
+
+// DefaultEmergencySealingThreshold is the number of finalized but unsealed descendants a block must have
+// before consensus nodes consider it for emergency sealing. Verifying a chunk is most valuable before
+// this deadline, as afterwards its block may get sealed without our approval.
+// It mirrors approvals.DefaultEmergencySealingThresholdForFinalization of the consensus nodes.
+const DefaultEmergencySealingThreshold = uint64(100)
+
+// ChunkSealingStatus is a snapshot of the sealing progress of the block a chunk belongs to,
+// as seen by the protocol state of this node.
+type ChunkSealingStatus struct {
+	BlockHeight     uint64 // height of the block the chunk belongs to.
+	SealedHeight    uint64 // height of the latest sealed block.
+	FinalizedHeight uint64 // height of the latest finalized block.
+}
+
+// Sealed returns true if the block of the chunk is already sealed, i.e., the consensus nodes
+// have collected enough approvals for it (from other verification nodes), and verifying
+// the chunk no longer contributes to sealing.
+func (s ChunkSealingStatus) Sealed() bool {
+	return s.BlockHeight <= s.SealedHeight
+}
+
+// BlocksToDeadline returns the number of blocks that can still be finalized before the block of the
+// chunk passes its emergency-sealing deadline, given the threshold. Zero means the deadline is
+// reached or passed, i.e., the chunk is late.
+func (s ChunkSealingStatus) BlocksToDeadline(threshold uint64) uint64 {
+	deadline := s.BlockHeight + threshold
+	if s.FinalizedHeight >= deadline {
+		return 0
+	}
+	return deadline - s.FinalizedHeight
+}
+
+// Late returns true if the block of the chunk is not sealed yet, while it has already passed
+// its emergency-sealing deadline given the threshold.
+func (s ChunkSealingStatus) Late(threshold uint64) bool {
+	return !s.Sealed() && s.BlocksToDeadline(threshold) == 0
+}
+
+// sealingStatus returns the sealing status of the given block based on the protocol state.
+func sealingStatus(state protocol.State, headers storage.Headers, blockID flow.Identifier) (ChunkSealingStatus, error) {
+	header, err := headers.ByBlockID(blockID)
+	if err != nil {
+		return ChunkSealingStatus{}, fmt.Errorf("could not get block: %w", err)
+	}
+
+	lastSealed, err := state.Sealed().Head()
+	if err != nil {
+		return ChunkSealingStatus{}, fmt.Errorf("could not get last sealed: %w", err)
+	}
+
+	lastFinalized, err := state.Final().Head()
+	if err != nil {
+		return ChunkSealingStatus{}, fmt.Errorf("could not get last finalized: %w", err)
+	}
+
+	return ChunkSealingStatus{
+		BlockHeight:     header.Height,
+		SealedHeight:    lastSealed.Height,
+		FinalizedHeight: lastFinalized.Height,
+	}, nil
+}
@@ -0,0 +1,39 @@
+package fetcher_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/engine/verification/fetcher"
+)
+
+// TestChunkSealingStatus evaluates the sealing and emergency-sealing deadline status of a chunk
+// against the sealed and finalized heights of the protocol state.
+func TestChunkSealingStatus(t *testing.T) {
+	threshold := uint64(10)
+
+	// block of chunk is sealed, hence it is neither pending nor late.
+	status := fetcher.ChunkSealingStatus{BlockHeight: 100, SealedHeight: 100, FinalizedHeight: 200}
+	require.True(t, status.Sealed())
+	require.False(t, status.Late(threshold))
+
+	// block of chunk is unsealed and just finalized, hence it is far from its deadline.
+	status = fetcher.ChunkSealingStatus{BlockHeight: 100, SealedHeight: 99, FinalizedHeight: 100}
+	require.False(t, status.Sealed())
+	require.Equal(t, threshold, status.BlocksToDeadline(threshold))
+	require.False(t, status.Late(threshold))
+
+	// one more finalized block passes the deadline of the block.
+	status = fetcher.ChunkSealingStatus{BlockHeight: 100, SealedHeight: 99, FinalizedHeight: 109}
+	require.Equal(t, uint64(1), status.BlocksToDeadline(threshold))
+	require.False(t, status.Late(threshold))
+
+	status = fetcher.ChunkSealingStatus{BlockHeight: 100, SealedHeight: 99, FinalizedHeight: 110}
+	require.Equal(t, uint64(0), status.BlocksToDeadline(threshold))
+	require.True(t, status.Late(threshold))
+
+	status = fetcher.ChunkSealingStatus{BlockHeight: 100, SealedHeight: 99, FinalizedHeight: 500}
+	require.Equal(t, uint64(0), status.BlocksToDeadline(threshold))
+	require.True(t, status.Late(threshold))
+}
@@ -3,6 +3,8 @@ package requester
 import (
 	"context"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/rs/zerolog"
@@ -15,6 +17,7 @@ import (
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack"
 	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/trace"
 	"github.com/onflow/flow-go/network"
@@ -38,6 +41,17 @@ const (
 
 	// DefaultRequestTargets is the  maximum number of execution nodes a chunk data pack request is dispatched to.
 	DefaultRequestTargets = 2
+
+	// DefaultBlobDownloadTimeout is the maximum time spent on downloading the blobs of a chunk data pack published by
+	// an execution node.
+	DefaultBlobDownloadTimeout = 10 * time.Second
+
+	// DefaultMaxConcurrentBlobDownloads is the maximum number of chunk data packs downloaded as blobs concurrently.
+	DefaultMaxConcurrentBlobDownloads = uint(20)
+
+	// DefaultMaxDispatchesPerInterval is the maximum number of chunk data pack requests dispatched to the network
+	// at each request interval.
+	DefaultMaxDispatchesPerInterval = uint(100)
 )
 
 // Engine implements a ChunkDataPackRequester that is responsible of receiving chunk data pack requests,
@@ -57,8 +71,15 @@ type Engine struct {
 	// output interfaces
 	handler fetcher.ChunkDataPackHandler // contains callbacks for handling received chunk data packs.
 
+	// optional downloader of chunk data packs published as content-addressed blobs by execution nodes.
+	downloader   chunk_data_pack.Downloader
+	maxDownloads uint                         // maximum number of concurrent blob downloads.
+	downloadsMu  sync.Mutex                   // protects downloads.
+	downloads    map[flow.Identifier]struct{} // chunk IDs of the in-flight blob downloads.
+
 	// internal logic
 	retryInterval    time.Duration                          // determines time in milliseconds for retrying chunk data requests.
+	maxDispatches    uint                                   // maximum number of chunk data requests dispatched at each retry interval.
 	requestTargets   uint64                                 // maximum number of execution nodes being asked for a chunk data pack.
 	pendingRequests  mempool.ChunkRequests                  // used to track requested chunks.
 	reqQualifierFunc RequestQualifierFunc                   // used to decide whether to dispatch a request at a certain cycle.
@@ -83,6 +104,9 @@ func New(log zerolog.Logger,
 		tracer:           tracer,
 		metrics:          metrics,
 		retryInterval:    retryInterval,
+		maxDispatches:    DefaultMaxDispatchesPerInterval,
+		maxDownloads:     DefaultMaxConcurrentBlobDownloads,
+		downloads:        make(map[flow.Identifier]struct{}),
 		requestTargets:   requestTargets,
 		pendingRequests:  pendingRequests,
 		reqUpdaterFunc:   reqUpdaterFunc,
@@ -102,6 +126,27 @@ func (e *Engine) WithChunkDataPackHandler(handler fetcher.ChunkDataPackHandler)
 	e.handler = handler
 }
 
+// WithChunkDataPackDownloader configures the engine to accept chunk data packs published as content-addressed
+// blobs by execution nodes, and to download them through the given downloader.
+// Note: this method should be called before the engine is started.
+func (e *Engine) WithChunkDataPackDownloader(downloader chunk_data_pack.Downloader) {
+	e.downloader = downloader
+}
+
+// WithMaxConcurrentBlobDownloads sets the maximum number of chunk data packs downloaded as blobs concurrently.
+// Note: this method should be called before the engine is started.
+func (e *Engine) WithMaxConcurrentBlobDownloads(maxDownloads uint) {
+	e.maxDownloads = maxDownloads
+}
+
+// WithMaxDispatchesPerInterval sets the maximum number of chunk data pack requests dispatched to the network
+// at each retry interval. Requests closest to their emergency-sealing deadline are dispatched first, and the
+// rest wait for the next intervals.
+// Note: this method should be called before the engine is started.
+func (e *Engine) WithMaxDispatchesPerInterval(maxDispatches uint) {
+	e.maxDispatches = maxDispatches
+}
+
 // SubmitLocal submits an event originating on the local node.
 func (e *Engine) SubmitLocal(event interface{}) {
 	e.log.Fatal().Msg("engine is not supposed to be invoked on SubmitLocal")
@@ -156,6 +201,10 @@ func (e *Engine) Done() <-chan struct{} {
 func (e *Engine) process(originID flow.Identifier, event interface{}) error {
 	switch resource := event.(type) {
 	case *messages.ChunkDataResponse:
+		if resource.BlobID != flow.ZeroID {
+			e.handleChunkDataPackBlob(originID, resource.ChunkDataPack.ChunkID, resource.BlobID)
+			return nil
+		}
 		e.handleChunkDataPackWithTracing(originID, &resource.ChunkDataPack)
 	default:
 		return fmt.Errorf("invalid event type (%T)", event)
@@ -177,6 +226,77 @@ func (e *Engine) handleChunkDataPackWithTracing(originID flow.Identifier, chunkD
 	e.handleChunkDataPack(originID, chunkDataPack)
 }
 
+// handleChunkDataPackBlob downloads the chunk data pack published as content-addressed blobs rooted at the given blob ID
+// asynchronously, and handles it once downloaded. The downloaded blobs are validated against their content hashes,
+// hence any node holding them may serve them. On failure, the request remains pending, and is retried in the next rounds.
+// At most one download runs per chunk, and at most maxDownloads downloads run concurrently. Responses received while
+// their chunk is being downloaded, or while all download slots are taken, are dropped, as their requests remain pending.
+func (e *Engine) handleChunkDataPackBlob(originID flow.Identifier, chunkID flow.Identifier, blobID flow.Identifier) {
+	lg := e.log.With().
+		Hex("chunk_id", logging.ID(chunkID)).
+		Hex("blob_id", logging.ID(blobID)).
+		Hex("origin_id", logging.ID(originID)).
+		Logger()
+
+	if e.downloader == nil {
+		// we never ask for blobs without a downloader, hence the response is unsolicited.
+		lg.Warn().
+			Bool(logging.KeySuspicious, true).
+			Msg("dropping chunk data pack blob response, as blobs are not accepted")
+		return
+	}
+
+	request, ok := e.pendingRequests.ByID(chunkID)
+	if !ok {
+		lg.Debug().Msg("chunk request status not found in mempool, dropping chunk data pack blob response")
+		return
+	}
+
+	if !e.startDownload(chunkID) {
+		lg.Debug().Msg("chunk data pack blobs are being downloaded or all download slots are taken, dropping chunk data pack blob response")
+		return
+	}
+
+	e.unit.Launch(func() {
+		defer e.finishDownload(chunkID)
+
+		ctx, cancel := context.WithTimeout(e.unit.Ctx(), DefaultBlobDownloadTimeout)
+		defer cancel()
+
+		chunkDataPack, err := e.downloader.Download(ctx, request.Height, blobID, chunkID)
+		if err != nil {
+			lg.Warn().Err(err).Msg("could not download chunk data pack blobs")
+			return
+		}
+
+		e.handleChunkDataPackWithTracing(originID, chunkDataPack)
+	})
+}
+
+// startDownload reserves a download slot for the given chunk. It returns false if the chunk is already being
+// downloaded, or all download slots are taken.
+func (e *Engine) startDownload(chunkID flow.Identifier) bool {
+	e.downloadsMu.Lock()
+	defer e.downloadsMu.Unlock()
+
+	if _, ok := e.downloads[chunkID]; ok {
+		return false
+	}
+	if uint(len(e.downloads)) >= e.maxDownloads {
+		return false
+	}
+	e.downloads[chunkID] = struct{}{}
+	return true
+}
+
+// finishDownload releases the download slot of the given chunk.
+func (e *Engine) finishDownload(chunkID flow.Identifier) {
+	e.downloadsMu.Lock()
+	defer e.downloadsMu.Unlock()
+
+	delete(e.downloads, chunkID)
+}
+
 // handleChunkDataPack sends the received chunk data pack to the registered handler, and cleans up its request status.
 func (e *Engine) handleChunkDataPack(originID flow.Identifier, chunkDataPack *flow.ChunkDataPack) {
 	chunkID := chunkDataPack.ChunkID
@@ -231,6 +351,10 @@ func (e *Engine) Request(request *verification.ChunkDataPackRequest) {
 
 // onTimer should run periodically, it goes through all pending requests, and requests their chunk data pack.
 // It also retries the chunk data request if the data hasn't been received for a while.
+//
+// At most maxDispatches requests are dispatched at each round. Pending requests are handled in ascending order
+// of the blocks left to their emergency-sealing deadline, i.e., chunks closest to their deadline are dispatched
+// first, while the rest wait for the next rounds. Requests of sealed blocks are always cleaned up.
 func (e *Engine) onTimer() {
 	pendingReqs := e.pendingRequests.All()
 
@@ -248,13 +372,53 @@ func (e *Engine) onTimer() {
 			Msg("could not determine whether block has been sealed")
 	}
 
+	lastFinalized, err := e.state.Final().Head()
+	if err != nil {
+		e.log.Fatal().
+			Err(err).
+			Msg("could not determine last finalized height")
+	}
+
+	blocksToDeadline := func(request *verification.ChunkDataPackRequestInfo) uint64 {
+		status := fetcher.ChunkSealingStatus{
+			BlockHeight:     request.Height,
+			SealedHeight:    lastSealed.Height,
+			FinalizedHeight: lastFinalized.Height,
+		}
+		return status.BlocksToDeadline(fetcher.DefaultEmergencySealingThreshold)
+	}
+	sort.SliceStable(pendingReqs, func(i, j int) bool {
+		left, right := blocksToDeadline(pendingReqs[i]), blocksToDeadline(pendingReqs[j])
+		if left != right {
+			return left < right
+		}
+		return pendingReqs[i].Height < pendingReqs[j].Height
+	})
+
+	dispatched, deferred := uint(0), 0
 	for _, request := range pendingReqs {
+		if request.Height > lastSealed.Height && dispatched >= e.maxDispatches {
+			// the budget of this round is exhausted, the request is dispatched in the next rounds.
+			deferred++
+			continue
+		}
+
 		attempts := e.handleChunkDataPackRequestWithTracing(request, lastSealed.Height)
+		if attempts > 0 {
+			dispatched++
+		}
 		if attempts > maxAttempts && request.Height == lastSealed.Height+uint64(1) {
 			maxAttempts = attempts
 		}
 	}
 
+	if deferred > 0 {
+		e.log.Debug().
+			Uint("dispatched", dispatched).
+			Int("deferred", deferred).
+			Msg("dispatching budget of this round exhausted, deferring remaining chunk data requests")
+	}
+
 	e.metrics.SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester(maxAttempts)
 }
 
@@ -328,8 +492,9 @@ func (e *Engine) requestChunkDataPackWithTracing(ctx context.Context, request *v
 // requestChunkDataPack dispatches request for the chunk data pack to the execution nodes.
 func (e *Engine) requestChunkDataPack(request *verification.ChunkDataPackRequestInfo) error {
 	req := &messages.ChunkDataRequest{
-		ChunkID: request.ChunkID,
-		Nonce:   rand.Uint64(), // prevent the request from being deduplicated by the receiver
+		ChunkID:       request.ChunkID,
+		Nonce:         rand.Uint64(), // prevent the request from being deduplicated by the receiver
+		BlobsAccepted: e.downloader != nil,
 	}
 
 	// publishes the chunk data request to the network
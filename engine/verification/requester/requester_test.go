@@ -1,6 +1,8 @@
 package requester_test
 
 import (
+	"fmt"
+	"math/rand"
 	"sync"
 	"testing"
 	"time"
@@ -17,6 +19,7 @@ import (
 	"github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/model/verification"
 	"github.com/onflow/flow-go/module"
+	mockchdp "github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack/mock"
 	flowmempool "github.com/onflow/flow-go/module/mempool"
 	mempool "github.com/onflow/flow-go/module/mempool/mock"
 	"github.com/onflow/flow-go/module/mock"
@@ -198,6 +201,132 @@ func TestHandleChunkDataPack_FailedRequestRemoval(t *testing.T) {
 	s.handler.AssertNotCalled(t, "HandleChunkDataPack")
 }
 
+// TestHandleChunkDataPack_Blob evaluates the happy path of receiving the root blob ID of a requested chunk data pack
+// published as blobs. The chunk data pack should be downloaded at the height of its request, and passed to the
+// registered handler, and the resources should be cleaned up.
+func TestHandleChunkDataPack_Blob(t *testing.T) {
+	s := setupTest()
+	e := newRequesterEngine(t, s)
+	downloader := mockchdp.NewDownloader(t)
+	e.WithChunkDataPackDownloader(downloader)
+
+	chunkDataPack := unittest.ChunkDataPackFixture(unittest.IdentifierFixture())
+	request := unittest.ChunkDataPackRequestFixture(unittest.WithChunkID(chunkDataPack.ChunkID))
+	response := &messages.ChunkDataResponse{
+		ChunkDataPack: flow.ChunkDataPack{ChunkID: chunkDataPack.ChunkID},
+		Nonce:         rand.Uint64(),
+		BlobID:        unittest.IdentifierFixture(),
+	}
+	originID := unittest.IdentifierFixture()
+
+	s.pendingRequests.On("ByID", request.ChunkID).Return(&request.ChunkDataPackRequestInfo, true).Once()
+	downloader.On("Download", testifymock.Anything, request.Height, response.BlobID, chunkDataPack.ChunkID).
+		Return(chunkDataPack, nil).Once()
+
+	// we remove pending request on receiving the downloaded chunk data pack
+	locators := chunks.LocatorMap{}
+	locators[chunks.ChunkLocatorID(request.ResultID, request.Index)] = &request.Locator
+	s.pendingRequests.On("PopAll", chunkDataPack.ChunkID).Return(locators, true).Once()
+
+	handled := make(chan struct{})
+	s.handler.On("HandleChunkDataPack", originID, &verification.ChunkDataPackResponse{
+		Locator: request.Locator,
+		Cdp:     chunkDataPack,
+	}).Run(func(args testifymock.Arguments) {
+		close(handled)
+	}).Return().Once()
+	s.metrics.On("OnChunkDataPackResponseReceivedFromNetworkByRequester").Return().Once()
+	s.metrics.On("OnChunkDataPackSentToFetcher").Return().Once()
+
+	err := e.Process(channels.RequestChunks, originID, response)
+	require.Nil(t, err)
+
+	unittest.RequireCloseBefore(t, handled, time.Second, "could not handle downloaded chunk data pack on time")
+	testifymock.AssertExpectationsForObjects(t, s.con, s.handler, s.pendingRequests, s.metrics)
+}
+
+// TestHandleChunkDataPack_BlobDownloadFailure evaluates that failing to download the blobs of a chunk data pack
+// (e.g., because the blobs do not match their content hashes) keeps its request pending for the next retries,
+// without passing anything to the handler.
+func TestHandleChunkDataPack_BlobDownloadFailure(t *testing.T) {
+	s := setupTest()
+	e := newRequesterEngine(t, s)
+	downloader := mockchdp.NewDownloader(t)
+	e.WithChunkDataPackDownloader(downloader)
+
+	request := unittest.ChunkDataPackRequestFixture(unittest.WithChunkID(unittest.IdentifierFixture()))
+	response := &messages.ChunkDataResponse{
+		ChunkDataPack: flow.ChunkDataPack{ChunkID: request.ChunkID},
+		Nonce:         rand.Uint64(),
+		BlobID:        unittest.IdentifierFixture(),
+	}
+	originID := unittest.IdentifierFixture()
+
+	s.pendingRequests.On("ByID", request.ChunkID).Return(&request.ChunkDataPackRequestInfo, true).Once()
+
+	downloaded := make(chan struct{})
+	downloader.On("Download", testifymock.Anything, request.Height, response.BlobID, request.ChunkID).
+		Run(func(args testifymock.Arguments) {
+			close(downloaded)
+		}).
+		Return(nil, fmt.Errorf("invalid blob")).Once()
+
+	err := e.Process(channels.RequestChunks, originID, response)
+	require.Nil(t, err)
+
+	unittest.RequireCloseBefore(t, downloaded, time.Second, "could not download chunk data pack on time")
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine on time")
+
+	s.pendingRequests.AssertNotCalled(t, "PopAll", testifymock.Anything)
+	s.handler.AssertNotCalled(t, "HandleChunkDataPack", testifymock.Anything, testifymock.Anything)
+}
+
+// TestHandleChunkDataPack_BlobDeduplication evaluates that the blobs of a chunk data pack are downloaded only once at a
+// time, even if several execution nodes respond with the blob ID, and that the number of concurrent downloads is bounded.
+func TestHandleChunkDataPack_BlobDeduplication(t *testing.T) {
+	s := setupTest()
+	e := newRequesterEngine(t, s)
+	downloader := mockchdp.NewDownloader(t)
+	e.WithChunkDataPackDownloader(downloader)
+	e.WithMaxConcurrentBlobDownloads(1)
+
+	request := unittest.ChunkDataPackRequestFixture(unittest.WithChunkID(unittest.IdentifierFixture()))
+	other := unittest.ChunkDataPackRequestFixture(unittest.WithChunkID(unittest.IdentifierFixture()))
+	blobResponse := func(chunkID flow.Identifier) *messages.ChunkDataResponse {
+		return &messages.ChunkDataResponse{
+			ChunkDataPack: flow.ChunkDataPack{ChunkID: chunkID},
+			Nonce:         rand.Uint64(),
+			BlobID:        unittest.IdentifierFixture(),
+		}
+	}
+
+	s.pendingRequests.On("ByID", request.ChunkID).Return(&request.ChunkDataPackRequestInfo, true)
+	s.pendingRequests.On("ByID", other.ChunkID).Return(&other.ChunkDataPackRequestInfo, true)
+
+	// the first download blocks until released, keeping its slot taken
+	downloading := make(chan struct{})
+	release := make(chan struct{})
+	downloader.On("Download", testifymock.Anything, request.Height, testifymock.Anything, request.ChunkID).
+		Run(func(args testifymock.Arguments) {
+			close(downloading)
+			<-release
+		}).
+		Return(nil, fmt.Errorf("invalid blob")).Once()
+
+	require.NoError(t, e.Process(channels.RequestChunks, unittest.IdentifierFixture(), blobResponse(request.ChunkID)))
+	unittest.RequireCloseBefore(t, downloading, time.Second, "could not start download on time")
+
+	// responses for the chunk being downloaded, and for other chunks while all slots are taken, are dropped
+	require.NoError(t, e.Process(channels.RequestChunks, unittest.IdentifierFixture(), blobResponse(request.ChunkID)))
+	require.NoError(t, e.Process(channels.RequestChunks, unittest.IdentifierFixture(), blobResponse(other.ChunkID)))
+
+	close(release)
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine on time")
+
+	downloader.AssertNumberOfCalls(t, "Download", 1)
+	s.handler.AssertNotCalled(t, "HandleChunkDataPack", testifymock.Anything, testifymock.Anything)
+}
+
 // TestRequestPendingChunkSealedBlock evaluates that requester engine drops pending requests for chunks belonging to
 // sealed blocks, and also notifies the handler that this requested chunk has been sealed, so it no longer requests
 // from the network it.
@@ -213,6 +342,7 @@ func TestRequestPendingChunkSealedBlock(t *testing.T) {
 		unittest.WithAgrees(agrees),
 		unittest.WithDisagrees(disagrees))
 	vertestutils.MockLastSealedHeight(s.state, 10)
+	vertestutils.MockLastFinalizedHeight(s.state, 10)
 	s.pendingRequests.On("All").Return(requests.UniqueRequestInfo())
 	// check data pack request is never tried since its block has been sealed.
 	s.metrics.On("SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester", uint64(0)).Return().Once()
@@ -251,6 +381,7 @@ func TestCompleteRequestingUnsealedChunkLifeCycle(t *testing.T) {
 
 	// mocks the requester pipeline
 	vertestutils.MockLastSealedHeight(s.state, sealedHeight)
+	vertestutils.MockLastFinalizedHeight(s.state, sealedHeight)
 	s.pendingRequests.On("All").Return(requests.UniqueRequestInfo())
 	handlerWG := mockChunkDataPackHandler(t, s.handler, requests)
 	mockPendingRequestsPopAll(t, s.pendingRequests, requests)
@@ -308,6 +439,7 @@ func TestRequestPendingChunkSealedBlock_Hybrid(t *testing.T) {
 	requests := append(sealedRequests, unsealedRequests...)
 
 	vertestutils.MockLastSealedHeight(s.state, sealedHeight)
+	vertestutils.MockLastFinalizedHeight(s.state, sealedHeight)
 	s.pendingRequests.On("All").Return(requests.UniqueRequestInfo())
 
 	// makes all (unsealed) chunk requests being qualified for dispatch instantly
@@ -384,6 +516,7 @@ func TestHandleChunkDataPack_DuplicateChunkIDs_Sealed(t *testing.T) {
 	// mocks the requester pipeline
 	sealedHeight := uint64(10)
 	vertestutils.MockLastSealedHeight(s.state, sealedHeight)
+	vertestutils.MockLastFinalizedHeight(s.state, sealedHeight)
 
 	resultA, _, _, _ := vertestutils.ExecutionResultForkFixture(t)
 	duplicateChunkID := resultA.Chunks[0].ID()
@@ -437,6 +570,7 @@ func testRequestPendingChunkDataPack(t *testing.T, count int, attempts int) {
 		unittest.WithAgrees(agrees),
 		unittest.WithDisagrees(disagrees))
 	vertestutils.MockLastSealedHeight(s.state, 5)
+	vertestutils.MockLastFinalizedHeight(s.state, 5)
 	s.pendingRequests.On("All").Return(requests.UniqueRequestInfo())
 
 	// makes all chunk requests being qualified for dispatch instantly
@@ -483,6 +617,7 @@ func TestDispatchingRequests_Hybrid(t *testing.T) {
 	agrees := unittest.IdentifierListFixture(2)
 	disagrees := unittest.IdentifierListFixture(3)
 	vertestutils.MockLastSealedHeight(s.state, 5)
+	vertestutils.MockLastFinalizedHeight(s.state, 5)
 	// models new requests that are just added to the mempool and are ready to dispatch.
 	instantQualifiedRequests := unittest.ChunkDataPackRequestListFixture(10,
 		unittest.WithHeightGreaterThan(5),
@@ -532,6 +667,128 @@ func TestDispatchingRequests_Hybrid(t *testing.T) {
 	testifymock.AssertExpectationsForObjects(t, s.pendingRequests, s.metrics)
 }
 
+// TestDispatchingRequests_ClosestToDeadlineFirst evaluates that on each cycle the requester dispatches pending requests in
+// ascending order of the blocks left to their emergency-sealing deadline, i.e., requests for chunks of the lowest heights
+// are dispatched first, regardless of the order they reside in the mempool.
+func TestDispatchingRequests_ClosestToDeadlineFirst(t *testing.T) {
+	s := setupTest()
+	e := newRequesterEngine(t, s)
+
+	// creates requests for chunks of blocks above the last sealed height, in descending order of heights.
+	agrees := unittest.IdentifierListFixture(2)
+	vertestutils.MockLastSealedHeight(s.state, 5)
+	vertestutils.MockLastFinalizedHeight(s.state, 20)
+	count := 10
+	requests := verification.ChunkDataPackRequestList{}
+	for i := count; i > 0; i-- {
+		requests = append(requests, unittest.ChunkDataPackRequestFixture(
+			unittest.WithHeight(uint64(5+i)),
+			unittest.WithAgrees(agrees)))
+	}
+	heights := make(map[flow.Identifier]uint64)
+	for _, request := range requests {
+		heights[request.ChunkID] = request.Height
+	}
+	s.pendingRequests.On("All").Return(requests.UniqueRequestInfo())
+
+	// makes all chunk requests being qualified for dispatch instantly on every cycle.
+	s.pendingRequests.On("RequestHistory", testifymock.Anything).
+		Return(uint64(1), time.Now().Add(-1*time.Hour), time.Millisecond, true)
+	s.pendingRequests.On("UpdateRequestHistory", testifymock.Anything, testifymock.Anything).
+		Return(uint64(1), time.Now(), time.Millisecond, true)
+	s.metrics.On("OnChunkDataPackRequestDispatchedInNetworkByRequester").Return()
+	s.metrics.On("SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester", testifymock.Anything).Return()
+
+	// records the heights of dispatched requests in order of dispatch.
+	mu := &sync.Mutex{}
+	dispatched := make([]uint64, 0)
+	firstCycle := &sync.WaitGroup{}
+	firstCycle.Add(count)
+	s.con.On("Publish", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Run(func(args testifymock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			req, ok := args[0].(*messages.ChunkDataRequest)
+			require.True(t, ok)
+			if len(dispatched) < count {
+				dispatched = append(dispatched, heights[req.ChunkID])
+				firstCycle.Done()
+			}
+		}).Return(nil)
+
+	unittest.RequireCloseBefore(t, e.Ready(), time.Second, "could not start engine on time")
+	unittest.RequireReturnsBefore(t, firstCycle.Wait, 2*s.retryInterval, "could not dispatch requests on time")
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine on time")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.IsIncreasing(t, dispatched)
+}
+
+// TestDispatchingRequests_Budget evaluates that on each cycle the requester dispatches at most the configured number
+// of pending requests, and that the requests closest to their emergency-sealing deadline are dispatched within the
+// budget ahead of the others.
+func TestDispatchingRequests_Budget(t *testing.T) {
+	s := setupTest()
+	e := newRequesterEngine(t, s)
+	budget := 3
+	e.WithMaxDispatchesPerInterval(uint(budget))
+
+	// creates requests for chunks of blocks above the last sealed height, in descending order of heights.
+	// the chunk of height 6 has passed its deadline, and the next ones are the closest to it.
+	agrees := unittest.IdentifierListFixture(2)
+	vertestutils.MockLastSealedHeight(s.state, 5)
+	vertestutils.MockLastFinalizedHeight(s.state, 106)
+	count := 10
+	requests := verification.ChunkDataPackRequestList{}
+	for i := count; i > 0; i-- {
+		requests = append(requests, unittest.ChunkDataPackRequestFixture(
+			unittest.WithHeight(uint64(5+i)),
+			unittest.WithAgrees(agrees)))
+	}
+	heights := make(map[flow.Identifier]uint64)
+	for _, request := range requests {
+		heights[request.ChunkID] = request.Height
+	}
+	s.pendingRequests.On("All").Return(requests.UniqueRequestInfo())
+
+	// makes all chunk requests being qualified for dispatch instantly on every cycle.
+	s.pendingRequests.On("RequestHistory", testifymock.Anything).
+		Return(uint64(1), time.Now().Add(-1*time.Hour), time.Millisecond, true)
+	s.pendingRequests.On("UpdateRequestHistory", testifymock.Anything, testifymock.Anything).
+		Return(uint64(1), time.Now(), time.Millisecond, true)
+	s.metrics.On("OnChunkDataPackRequestDispatchedInNetworkByRequester").Return()
+	s.metrics.On("SetMaxChunkDataPackAttemptsForNextUnsealedHeightAtRequester", testifymock.Anything).Return()
+
+	// records the heights of dispatched requests over the first two cycles.
+	mu := &sync.Mutex{}
+	dispatched := make([]uint64, 0)
+	twoCycles := &sync.WaitGroup{}
+	twoCycles.Add(2 * budget)
+	s.con.On("Publish", testifymock.Anything, testifymock.Anything, testifymock.Anything).
+		Run(func(args testifymock.Arguments) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			req, ok := args[0].(*messages.ChunkDataRequest)
+			require.True(t, ok)
+			if len(dispatched) < 2*budget {
+				dispatched = append(dispatched, heights[req.ChunkID])
+				twoCycles.Done()
+			}
+		}).Return(nil)
+
+	unittest.RequireCloseBefore(t, e.Ready(), time.Second, "could not start engine on time")
+	unittest.RequireReturnsBefore(t, twoCycles.Wait, 3*s.retryInterval, "could not dispatch requests on time")
+	unittest.RequireCloseBefore(t, e.Done(), time.Second, "could not stop engine on time")
+
+	// as all requests remain qualified, each cycle spends its budget on the same requests closest to their deadline.
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, []uint64{6, 7, 8, 6, 7, 8}, dispatched)
+}
+
 // toChunkIDs is a test helper that extracts chunk ids from chunk data pack requests.
 func toChunkIDs(t *testing.T, requests verification.ChunkDataPackRequestList) flow.IdentifierList {
 	var chunkIDs flow.IdentifierList
@@ -413,6 +413,15 @@ func MockLastSealedHeight(state *mockprotocol.State, height uint64) {
 	snapshot.On("Head").Return(header, nil)
 }
 
+// MockLastFinalizedHeight mocks the protocol state for the specified last finalized height.
+func MockLastFinalizedHeight(state *mockprotocol.State, height uint64) {
+	snapshot := &mockprotocol.Snapshot{}
+	header := unittest.BlockHeaderFixture()
+	header.Height = height
+	state.On("Final").Return(snapshot)
+	snapshot.On("Head").Return(header, nil)
+}
+
 func NewVerificationHappyPathTest(t *testing.T,
 	authorized bool,
 	blockCount int,
@@ -0,0 +1,107 @@
+package lightclient
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/committees"
+	"github.com/onflow/flow-go/consensus/hotstuff/signature"
+	"github.com/onflow/flow-go/consensus/hotstuff/validator"
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/model/encodable"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/inmem"
+)
+
+// ValidatorFactory creates a validator for quorum certificates signed by the given consensus
+// participants of an epoch, with the given random beacon DKG.
+type ValidatorFactory func(participants flow.IdentityList, dkg protocol.DKG) (hotstuff.Validator, error)
+
+// NewQCValidator is the default ValidatorFactory, validating the aggregated staking and random
+// beacon signatures of quorum certificates in the same way as consensus followers do.
+//
+// The consensus committee of an epoch is given by the initial identities of the epoch. If a
+// consensus node is ejected during the epoch, quorum certificates signed after the ejection
+// will fail validation.
+func NewQCValidator(participants flow.IdentityList, dkg protocol.DKG) (hotstuff.Validator, error) {
+	committee, err := committees.NewStaticCommitteeWithDKG(participants.Filter(filter.IsVotingConsensusCommitteeMember), flow.ZeroID, dkg)
+	if err != nil {
+		return nil, fmt.Errorf("could not create committee: %w", err)
+	}
+	packer := signature.NewConsensusSigDataPacker(committee)
+	verifier := verification.NewCombinedVerifier(committee, packer)
+	return validator.New(committee, nil, verifier), nil
+}
+
+// epochSetup holds the information of an epoch which is available after its setup phase.
+type epochSetup struct {
+	counter      uint64
+	firstView    uint64
+	finalView    uint64
+	participants flow.IdentityList
+}
+
+// committedEpoch is an epoch for which the consensus committee is known.
+type committedEpoch struct {
+	epochSetup
+	validator hotstuff.Validator
+}
+
+// covers returns true if the given view is within the epoch.
+func (e *committedEpoch) covers(view uint64) bool {
+	return e.firstView <= view && view <= e.finalView
+}
+
+// setupFromEvent returns the setup information of an epoch given by an EpochSetup service event.
+func setupFromEvent(setup *flow.EpochSetup) epochSetup {
+	return epochSetup{
+		counter:      setup.Counter,
+		firstView:    setup.FirstView,
+		finalView:    setup.FinalView,
+		participants: setup.Participants,
+	}
+}
+
+// setupFromEpoch returns the setup information of an epoch given by a protocol snapshot.
+// Expected errors during normal operations:
+//   - protocol.ErrNextEpochNotSetup if the epoch has not been set up
+func setupFromEpoch(epoch protocol.Epoch) (epochSetup, error) {
+	counter, err := epoch.Counter()
+	if err != nil {
+		return epochSetup{}, fmt.Errorf("could not get counter: %w", err)
+	}
+	firstView, err := epoch.FirstView()
+	if err != nil {
+		return epochSetup{}, fmt.Errorf("could not get first view: %w", err)
+	}
+	finalView, err := epoch.FinalView()
+	if err != nil {
+		return epochSetup{}, fmt.Errorf("could not get final view: %w", err)
+	}
+	participants, err := epoch.InitialIdentities()
+	if err != nil {
+		return epochSetup{}, fmt.Errorf("could not get initial identities: %w", err)
+	}
+	return epochSetup{
+		counter:      counter,
+		firstView:    firstView,
+		finalView:    finalView,
+		participants: participants,
+	}, nil
+}
+
+// dkgFromEvent returns the random beacon DKG of an epoch given by an EpochCommit service event.
+func dkgFromEvent(setup epochSetup, commit *flow.EpochCommit) (protocol.DKG, error) {
+	lookup, err := flow.ToDKGParticipantLookup(setup.participants.Filter(filter.IsValidDKGParticipant), commit.DKGParticipantKeys)
+	if err != nil {
+		return nil, fmt.Errorf("could not construct dkg lookup: %w", err)
+	}
+	return inmem.DKGFromEncodable(inmem.EncodableDKG{
+		GroupKey: encodable.RandomBeaconPubKey{
+			PublicKey: commit.DKGGroupKey,
+		},
+		Participants: lookup,
+	})
+}
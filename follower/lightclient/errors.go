@@ -0,0 +1,28 @@
+package lightclient
+
+import (
+	"errors"
+)
+
+var (
+	// ErrUnknownEpoch is returned when a header can't be verified, because the consensus
+	// committee of its epoch is not known to the light client. The transition into the
+	// epoch must first be applied using ApplyEpochTransition.
+	ErrUnknownEpoch = errors.New("consensus committee of epoch is unknown")
+
+	// ErrInvalidHeader is returned when a header doesn't extend the chain known to the
+	// light client, or when the quorum certificate it contains for its parent is invalid.
+	ErrInvalidHeader = errors.New("invalid header")
+
+	// ErrInvalidEpochTransition is returned when an epoch transition is not proven by a
+	// sealing segment of finalized blocks, or is inconsistent with the known epochs.
+	ErrInvalidEpochTransition = errors.New("invalid epoch transition")
+
+	// ErrNotFinalized is returned when a header at a height above the latest finalized
+	// header known to the light client is requested.
+	ErrNotFinalized = errors.New("height is not finalized")
+
+	// ErrBelowRoot is returned when a header at a height below the trust root of the light
+	// client is requested.
+	ErrBelowRoot = errors.New("height is below trust root")
+)
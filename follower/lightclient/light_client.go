@@ -0,0 +1,391 @@
+// Package lightclient implements a light client for the main consensus chain, which follows
+// the chain of finalized block headers without downloading block payloads or maintaining the
+// protocol state.
+//
+// Starting from a trusted root snapshot, the light client verifies the quorum certificate
+// contained in each header for its parent against the consensus committee of the parent's
+// epoch, and applies the finalization rule of HotStuff to the verified headers. The consensus
+// committees of later epochs are learned from the EpochSetup and EpochCommit service events,
+// which are proven by sealing segments of finalized blocks containing the seals of the
+// execution results emitting the events.
+package lightclient
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// LightClient follows the chain of finalized block headers. All finalized headers above the
+// trust root are kept in memory.
+//
+// Safe for concurrent use.
+type LightClient struct {
+	mu           sync.RWMutex
+	newValidator ValidatorFactory
+	epochs       []*committedEpoch // committed epochs, in ascending order
+	pendingSetup *epochSetup       // setup of the next epoch, if it has been set up but not committed yet
+	finalized    []*flow.Header    // finalized headers in ascending height order, starting with the trust root
+	pending      []*flow.Header    // chain of verified headers descending from the latest finalized header
+}
+
+type Option func(*LightClient)
+
+// WithValidatorFactory sets the factory used to create the validators of quorum certificates
+// for each epoch. Defaults to NewQCValidator.
+func WithValidatorFactory(factory ValidatorFactory) Option {
+	return func(c *LightClient) {
+		c.newValidator = factory
+	}
+}
+
+// New creates a new light client, trusting the head of the given snapshot to be finalized,
+// and the epochs of the snapshot.
+func New(root protocol.Snapshot, opts ...Option) (*LightClient, error) {
+	c := &LightClient{
+		newValidator: NewQCValidator,
+	}
+	for _, apply := range opts {
+		apply(c)
+	}
+
+	head, err := root.Head()
+	if err != nil {
+		return nil, fmt.Errorf("could not get root header: %w", err)
+	}
+	c.finalized = []*flow.Header{head}
+
+	current, err := setupFromEpoch(root.Epochs().Current())
+	if err != nil {
+		return nil, fmt.Errorf("could not get current epoch: %w", err)
+	}
+	err = c.addCommittedEpoch(current, root.Epochs().Current())
+	if err != nil {
+		return nil, fmt.Errorf("could not add current epoch: %w", err)
+	}
+
+	// the next epoch is committed, set up, or not set up yet, depending on the epoch phase
+	phase, err := root.Phase()
+	if err != nil {
+		return nil, fmt.Errorf("could not get epoch phase: %w", err)
+	}
+	if phase == flow.EpochPhaseStaking {
+		return c, nil
+	}
+	next, err := setupFromEpoch(root.Epochs().Next())
+	if err != nil {
+		return nil, fmt.Errorf("could not get next epoch: %w", err)
+	}
+	if phase == flow.EpochPhaseSetup {
+		c.pendingSetup = &next
+		return c, nil
+	}
+	err = c.addCommittedEpoch(next, root.Epochs().Next())
+	if err != nil {
+		return nil, fmt.Errorf("could not add next epoch: %w", err)
+	}
+
+	return c, nil
+}
+
+// addCommittedEpoch adds an epoch of the root snapshot to the committed epochs.
+func (c *LightClient) addCommittedEpoch(setup epochSetup, epoch protocol.Epoch) error {
+	dkg, err := epoch.DKG()
+	if err != nil {
+		return fmt.Errorf("could not get dkg: %w", err)
+	}
+	validator, err := c.newValidator(setup.participants, dkg)
+	if err != nil {
+		return fmt.Errorf("could not create validator: %w", err)
+	}
+	c.epochs = append(c.epochs, &committedEpoch{
+		epochSetup: setup,
+		validator:  validator,
+	})
+	return nil
+}
+
+// FinalizedHead returns the latest header known to be finalized.
+func (c *LightClient) FinalizedHead() *flow.Header {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.finalized[len(c.finalized)-1]
+}
+
+// FinalizedHeaderByHeight returns the finalized header at the given height.
+// Expected errors during normal operations:
+//   - ErrNotFinalized if the height is above the latest finalized header
+//   - ErrBelowRoot if the height is below the trust root
+func (c *LightClient) FinalizedHeaderByHeight(height uint64) (*flow.Header, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.finalizedByHeight(height)
+}
+
+func (c *LightClient) finalizedByHeight(height uint64) (*flow.Header, error) {
+	root := c.finalized[0]
+	if height < root.Height {
+		return nil, fmt.Errorf("height %d is below root height %d: %w", height, root.Height, ErrBelowRoot)
+	}
+	index := height - root.Height
+	if index >= uint64(len(c.finalized)) {
+		return nil, fmt.Errorf("height %d is above finalized height %d: %w", height, c.finalized[len(c.finalized)-1].Height, ErrNotFinalized)
+	}
+	return c.finalized[index], nil
+}
+
+// Extend verifies the given headers, and adds them to the chain followed by the light client.
+// Each header must be a child of the latest finalized header, or of a header previously added
+// to the chain. If a header forks from the chain above the latest finalized header, the fork
+// replaces the previously added headers conflicting with it.
+// The headers are added one by one, so if a header is invalid, the headers preceding it are
+// still added.
+// Expected errors during normal operations:
+//   - ErrInvalidHeader if a header doesn't extend the chain, or contains an invalid QC
+//   - ErrUnknownEpoch if the committee of the parent of a header is not known
+func (c *LightClient) Extend(headers ...*flow.Header) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, header := range headers {
+		err := c.extend(header)
+		if err != nil {
+			return fmt.Errorf("could not extend chain with header %v at height %d: %w", header.ID(), header.Height, err)
+		}
+	}
+	return nil
+}
+
+func (c *LightClient) extend(header *flow.Header) error {
+	headerID := header.ID()
+	latest := c.finalized[len(c.finalized)-1]
+
+	// headers at finalized heights must be the finalized ones
+	if header.Height <= latest.Height {
+		finalized, err := c.finalizedByHeight(header.Height)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidHeader, err)
+		}
+		if finalized.ID() != headerID {
+			return fmt.Errorf("%w: header conflicts with finalized header %v", ErrInvalidHeader, finalized.ID())
+		}
+		return nil
+	}
+
+	// find the parent, which is either the latest finalized header or a pending header
+	parent := latest
+	parentIndex := -1
+	if header.ParentID != latest.ID() {
+		for i, pending := range c.pending {
+			if pending.ID() == header.ParentID {
+				parent = pending
+				parentIndex = i
+			}
+		}
+		if parentIndex < 0 {
+			return fmt.Errorf("%w: unknown parent %v", ErrInvalidHeader, header.ParentID)
+		}
+	}
+	if len(c.pending) > parentIndex+1 && c.pending[parentIndex+1].ID() == headerID {
+		// header has already been added
+		return nil
+	}
+
+	if header.ChainID != parent.ChainID {
+		return fmt.Errorf("%w: chain ID %v does not match parent chain ID %v", ErrInvalidHeader, header.ChainID, parent.ChainID)
+	}
+	if header.Height != parent.Height+1 {
+		return fmt.Errorf("%w: height %d does not follow parent height %d", ErrInvalidHeader, header.Height, parent.Height)
+	}
+	if header.View <= parent.View {
+		return fmt.Errorf("%w: view %d is not above parent view %d", ErrInvalidHeader, header.View, parent.View)
+	}
+
+	err := c.verifyParentQC(header, parent)
+	if err != nil {
+		return err
+	}
+
+	// the header replaces any pending headers conflicting with it
+	c.pending = append(c.pending[:parentIndex+1], header)
+	c.finalize()
+
+	return nil
+}
+
+// verifyParentQC verifies the QC for the parent contained in the given header.
+// Expected errors during normal operations:
+//   - ErrInvalidHeader if the QC is invalid
+//   - ErrUnknownEpoch if the committee of the epoch of the parent is not known
+func (c *LightClient) verifyParentQC(header *flow.Header, parent *flow.Header) error {
+	var epoch *committedEpoch
+	for _, e := range c.epochs {
+		if e.covers(parent.View) {
+			epoch = e
+			break
+		}
+	}
+	if epoch == nil {
+		return fmt.Errorf("no committed epoch for view %d: %w", parent.View, ErrUnknownEpoch)
+	}
+
+	qc := &flow.QuorumCertificate{
+		View:          parent.View,
+		BlockID:       header.ParentID,
+		SignerIndices: header.ParentVoterIndices,
+		SigData:       header.ParentVoterSigData,
+	}
+	err := epoch.validator.ValidateQC(qc, &model.Block{
+		BlockID: header.ParentID,
+		View:    parent.View,
+	})
+	if model.IsInvalidBlockError(err) {
+		return fmt.Errorf("%w: invalid QC for parent: %v", ErrInvalidHeader, err)
+	}
+	if err != nil {
+		return fmt.Errorf("could not validate QC for parent: %w", err)
+	}
+	return nil
+}
+
+// finalize moves the pending headers which are proven to be finalized to the finalized headers.
+//
+// We use the finalization rule of HotStuff, as implemented by the finalizer of the consensus
+// nodes: a block B0 is finalized if it is followed by a child B1 and grandchild B2 with
+// consecutive views, and the QC for B2 is known. All ancestors of a finalized block are
+// finalized as well. As the QC for a header is contained in its child, the QC for B2 is known
+// if B2 has a child.
+func (c *LightClient) finalize() {
+	chain := append([]*flow.Header{c.finalized[len(c.finalized)-1]}, c.pending...)
+
+	for i := len(chain) - 4; i > 0; i-- {
+		b := chain[i]
+		if chain[i+1].View == b.View+1 && chain[i+2].View == b.View+2 {
+			c.finalized = append(c.finalized, chain[1:i+1]...)
+			c.pending = append([]*flow.Header(nil), chain[i+1:]...)
+			return
+		}
+	}
+}
+
+// ApplyEpochTransition learns the consensus committees of later epochs from the EpochSetup and
+// EpochCommit service events in the given sealing segment. The highest block of the segment
+// must be finalized, so that the seals in the segment are proven. Service events which have
+// already been applied are ignored, so that overlapping segments can be applied.
+// Either all service events in the segment are applied, or none.
+// Expected errors during normal operations:
+//   - ErrNotFinalized if the highest block of the segment is not known to be finalized
+//   - ErrInvalidEpochTransition if the segment is invalid, or its service events are inconsistent
+//     with the known epochs
+func (c *LightClient) ApplyEpochTransition(segment *flow.SealingSegment) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(segment.Blocks) == 0 {
+		return fmt.Errorf("%w: empty sealing segment", ErrInvalidEpochTransition)
+	}
+
+	// the highest block must be finalized, which proves all blocks of the segment descending to it
+	highest := segment.Highest()
+	finalized, err := c.finalizedByHeight(highest.Header.Height)
+	if err != nil {
+		return fmt.Errorf("could not get finalized header for highest block of segment: %w", err)
+	}
+	if finalized.ID() != highest.ID() {
+		return fmt.Errorf("%w: highest block %v is not the finalized block %v", ErrInvalidEpochTransition, highest.ID(), finalized.ID())
+	}
+	for i, block := range segment.Blocks {
+		if !block.Valid() {
+			return fmt.Errorf("%w: payload of block %v does not match payload hash", ErrInvalidEpochTransition, block.ID())
+		}
+		if i > 0 && block.Header.ParentID != segment.Blocks[i-1].ID() {
+			return fmt.Errorf("%w: block %v is not a child of block %v", ErrInvalidEpochTransition, block.ID(), segment.Blocks[i-1].ID())
+		}
+	}
+
+	results := segment.ExecutionResults.Lookup()
+	for _, block := range segment.Blocks {
+		for _, result := range block.Payload.Results {
+			results[result.ID()] = result
+		}
+	}
+
+	// apply the service events to copies of the epoch information, so that nothing is applied
+	// if the segment is invalid
+	epochs := c.epochs[:len(c.epochs):len(c.epochs)]
+	pendingSetup := c.pendingSetup
+	for _, block := range segment.Blocks {
+		for _, seal := range block.Payload.Seals {
+			result, ok := results[seal.ResultID]
+			if !ok {
+				return fmt.Errorf("%w: missing result %v for seal %v", ErrInvalidEpochTransition, seal.ResultID, seal.ID())
+			}
+			for _, event := range result.ServiceEvents {
+				switch ev := event.Event.(type) {
+				case *flow.EpochSetup:
+					pendingSetup, err = applySetup(epochs, pendingSetup, ev)
+				case *flow.EpochCommit:
+					epochs, pendingSetup, err = c.applyCommit(epochs, pendingSetup, ev)
+				}
+				if err != nil {
+					return fmt.Errorf("could not apply %s service event sealed in block %v: %w", event.Type, block.ID(), err)
+				}
+			}
+		}
+	}
+
+	c.epochs = epochs
+	c.pendingSetup = pendingSetup
+	return nil
+}
+
+// applySetup applies an EpochSetup service event, and returns the resulting setup of the next epoch.
+func applySetup(epochs []*committedEpoch, pendingSetup *epochSetup, ev *flow.EpochSetup) (*epochSetup, error) {
+	latest := epochs[len(epochs)-1]
+	if ev.Counter <= latest.counter || (pendingSetup != nil && ev.Counter == pendingSetup.counter) {
+		// already applied
+		return pendingSetup, nil
+	}
+	if ev.Counter != latest.counter+1 {
+		return nil, fmt.Errorf("%w: setup for epoch %d does not follow epoch %d", ErrInvalidEpochTransition, ev.Counter, latest.counter)
+	}
+	if ev.FirstView != latest.finalView+1 {
+		return nil, fmt.Errorf("%w: first view %d of epoch %d does not follow final view %d of epoch %d",
+			ErrInvalidEpochTransition, ev.FirstView, ev.Counter, latest.finalView, latest.counter)
+	}
+	setup := setupFromEvent(ev)
+	return &setup, nil
+}
+
+// applyCommit applies an EpochCommit service event, and returns the resulting committed epochs
+// and setup of the next epoch.
+func (c *LightClient) applyCommit(epochs []*committedEpoch, pendingSetup *epochSetup, ev *flow.EpochCommit) ([]*committedEpoch, *epochSetup, error) {
+	latest := epochs[len(epochs)-1]
+	if ev.Counter <= latest.counter {
+		// already applied
+		return epochs, pendingSetup, nil
+	}
+	if pendingSetup == nil || pendingSetup.counter != ev.Counter {
+		return nil, nil, fmt.Errorf("%w: commit for epoch %d which has not been set up", ErrInvalidEpochTransition, ev.Counter)
+	}
+
+	dkg, err := dkgFromEvent(*pendingSetup, ev)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidEpochTransition, err)
+	}
+	validator, err := c.newValidator(pendingSetup.participants, dkg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create validator: %w", err)
+	}
+
+	epochs = append(epochs, &committedEpoch{
+		epochSetup: *pendingSetup,
+		validator:  validator,
+	})
+	return epochs, nil, nil
+}
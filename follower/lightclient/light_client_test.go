@@ -0,0 +1,285 @@
+package lightclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff"
+	"github.com/onflow/flow-go/consensus/hotstuff/mocks"
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/order"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+const rootHeight = 100
+
+// setup creates a light client trusting a root snapshot at height rootHeight and view 0, with
+// a current epoch ending at view 1000. The quorum certificates of the current epoch are
+// validated by the returned validator.
+func setup(t *testing.T, opts ...Option) (*LightClient, *flow.Header, flow.IdentityList, *mocks.Validator) {
+	participants := unittest.IdentityListFixture(5, unittest.WithAllRoles()).Sort(order.Canonical)
+	root := unittest.RootSnapshotFixture(participants, func(block *flow.Block) {
+		block.Header.Height = rootHeight
+	})
+	head, err := root.Head()
+	require.NoError(t, err)
+
+	validator := mocks.NewValidator(t)
+	opts = append([]Option{WithValidatorFactory(func(flow.IdentityList, protocol.DKG) (hotstuff.Validator, error) {
+		return validator, nil
+	})}, opts...)
+	client, err := New(root, opts...)
+	require.NoError(t, err)
+
+	return client, head, participants, validator
+}
+
+// chainFixture returns a chain of blocks descending from the given parent, with the given views.
+func chainFixture(parent *flow.Header, views ...uint64) []*flow.Block {
+	blocks := make([]*flow.Block, 0, len(views))
+	for _, view := range views {
+		block := unittest.BlockWithParentFixture(parent)
+		block.Header.View = view
+		blocks = append(blocks, block)
+		parent = block.Header
+	}
+	return blocks
+}
+
+func headers(blocks []*flow.Block) []*flow.Header {
+	headers := make([]*flow.Header, 0, len(blocks))
+	for _, block := range blocks {
+		headers = append(headers, block.Header)
+	}
+	return headers
+}
+
+// TestExtend_Finalization tests that headers are finalized according to the HotStuff finalization rule.
+func TestExtend_Finalization(t *testing.T) {
+	client, root, _, validator := setup(t)
+	validator.On("ValidateQC", mock.Anything, mock.Anything).Return(nil)
+
+	blocks := chainFixture(root, 1, 2, 3, 4, 6, 7, 8, 9)
+
+	// block 1 is followed by blocks with consecutive views, but the QC for block 3 is unknown
+	require.NoError(t, client.Extend(headers(blocks[:3])...))
+	assert.Equal(t, root, client.FinalizedHead())
+
+	// the QC for block 3 contained in block 4 finalizes block 1
+	require.NoError(t, client.Extend(blocks[3].Header))
+	assert.Equal(t, blocks[0].Header, client.FinalizedHead())
+
+	// the QC for block 7 contained in block 8 finalizes block 5 and its ancestors
+	require.NoError(t, client.Extend(headers(blocks[4:])...))
+	assert.Equal(t, blocks[4].Header, client.FinalizedHead())
+
+	for _, block := range blocks[:5] {
+		header, err := client.FinalizedHeaderByHeight(block.Header.Height)
+		require.NoError(t, err)
+		assert.Equal(t, block.Header, header)
+	}
+	header, err := client.FinalizedHeaderByHeight(rootHeight)
+	require.NoError(t, err)
+	assert.Equal(t, root, header)
+
+	_, err = client.FinalizedHeaderByHeight(blocks[5].Header.Height)
+	assert.ErrorIs(t, err, ErrNotFinalized)
+	_, err = client.FinalizedHeaderByHeight(rootHeight - 1)
+	assert.ErrorIs(t, err, ErrBelowRoot)
+
+	// adding known headers is a no-op
+	require.NoError(t, client.Extend(headers(blocks)...))
+	assert.Equal(t, blocks[4].Header, client.FinalizedHead())
+
+	// the QCs are validated against the parent
+	validator.AssertCalled(t, "ValidateQC",
+		&flow.QuorumCertificate{
+			View:          blocks[2].Header.View,
+			BlockID:       blocks[2].ID(),
+			SignerIndices: blocks[3].Header.ParentVoterIndices,
+			SigData:       blocks[3].Header.ParentVoterSigData,
+		},
+		&model.Block{BlockID: blocks[2].ID(), View: blocks[2].Header.View},
+	)
+}
+
+// TestExtend_Fork tests that a fork replaces the pending headers conflicting with it.
+func TestExtend_Fork(t *testing.T) {
+	client, root, _, validator := setup(t)
+	validator.On("ValidateQC", mock.Anything, mock.Anything).Return(nil)
+
+	blocks := chainFixture(root, 1, 2, 5)
+	fork := chainFixture(blocks[1].Header, 3, 4, 6)
+
+	require.NoError(t, client.Extend(headers(blocks)...))
+	require.NoError(t, client.Extend(headers(fork)...))
+	assert.Equal(t, blocks[1].Header, client.FinalizedHead())
+
+	// the replaced headers can't be extended anymore
+	err := client.Extend(chainFixture(blocks[2].Header, 7)[0].Header)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+}
+
+// TestExtend_InvalidHeaders tests that headers which don't extend the chain, or contain
+// invalid QCs, are rejected.
+func TestExtend_InvalidHeaders(t *testing.T) {
+	client, root, _, validator := setup(t)
+
+	blocks := chainFixture(root, 1, 2, 3, 4, 5)
+	invalidQC := blocks[4].Header
+	validator.On("ValidateQC", mock.Anything, &model.Block{BlockID: blocks[3].ID(), View: blocks[3].Header.View}).
+		Return(model.InvalidBlockError{BlockID: blocks[3].ID(), View: blocks[3].Header.View, Err: errors.New("invalid signature")})
+	validator.On("ValidateQC", mock.Anything, mock.Anything).Return(nil)
+	require.NoError(t, client.Extend(headers(blocks[:4])...))
+
+	t.Run("invalid QC", func(t *testing.T) {
+		err := client.Extend(invalidQC)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("unknown parent", func(t *testing.T) {
+		err := client.Extend(unittest.BlockHeaderWithParentFixture(unittest.BlockHeaderFixture()))
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("view not above parent view", func(t *testing.T) {
+		err := client.Extend(chainFixture(blocks[3].Header, blocks[3].Header.View)[0].Header)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("conflicting with finalized header", func(t *testing.T) {
+		err := client.Extend(chainFixture(root, 1)[0].Header)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	// the chain is unchanged
+	assert.Equal(t, blocks[0].Header, client.FinalizedHead())
+}
+
+// TestEpochTransition tests that headers of the next epoch can only be verified after the
+// transition into the epoch has been applied.
+func TestEpochTransition(t *testing.T) {
+	rootValidator := mocks.NewValidator(t)
+	rootValidator.On("ValidateQC", mock.Anything, mock.Anything).Return(nil)
+	nextValidator := mocks.NewValidator(t)
+	nextValidator.On("ValidateQC", mock.Anything, mock.Anything).Return(nil)
+	validators := []hotstuff.Validator{rootValidator, nextValidator}
+
+	client, root, participants, _ := setup(t, WithValidatorFactory(func(flow.IdentityList, protocol.DKG) (hotstuff.Validator, error) {
+		validator := validators[0]
+		validators = validators[1:]
+		return validator, nil
+	}))
+
+	// the root epoch ends at view 1000, the next epoch is set up and committed in the same result
+	epochSetup := unittest.EpochSetupFixture(
+		unittest.SetupWithCounter(2),
+		unittest.WithParticipants(participants),
+		unittest.WithFirstView(1001),
+		unittest.WithFinalView(2000),
+	)
+	epochCommit := unittest.EpochCommitFixture(
+		unittest.CommitWithCounter(2),
+		unittest.WithDKGFromParticipants(participants),
+	)
+	result := unittest.ExecutionResultFixture()
+	result.ServiceEvents = []flow.ServiceEvent{epochSetup.ServiceEvent(), epochCommit.ServiceEvent()}
+	seal := unittest.Seal.Fixture(unittest.Seal.WithResult(result))
+
+	sealing := unittest.BlockWithParentFixture(root)
+	sealing.Header.View = 1
+	sealing.SetPayload(flow.Payload{Seals: []*flow.Seal{seal}, Results: []*flow.ExecutionResult{result}})
+	blocks := append([]*flow.Block{sealing}, chainFixture(sealing.Header, 2, 3, 4, 1000, 1001, 1002)...)
+	segment := &flow.SealingSegment{Blocks: []*flow.Block{sealing}}
+
+	// the committee of epoch 2 is unknown, the blocks up to view 2 are finalized nonetheless
+	err := client.Extend(headers(blocks)...)
+	assert.ErrorIs(t, err, ErrUnknownEpoch)
+	assert.Equal(t, blocks[1].Header, client.FinalizedHead())
+
+	t.Run("not finalized", func(t *testing.T) {
+		err := client.ApplyEpochTransition(&flow.SealingSegment{Blocks: blocks[:3]})
+		assert.ErrorIs(t, err, ErrNotFinalized)
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		tampered := *sealing
+		tampered.Payload = &flow.Payload{Seals: []*flow.Seal{seal}}
+		err := client.ApplyEpochTransition(&flow.SealingSegment{Blocks: []*flow.Block{&tampered}})
+		assert.ErrorIs(t, err, ErrInvalidEpochTransition)
+	})
+
+	require.NoError(t, client.ApplyEpochTransition(segment))
+	assert.Len(t, client.epochs, 2)
+
+	// applying the transition again is a no-op
+	require.NoError(t, client.ApplyEpochTransition(segment))
+	assert.Len(t, client.epochs, 2)
+
+	// the QC for the first block of epoch 2 is validated by the committee of epoch 2
+	require.NoError(t, client.Extend(headers(blocks[5:])...))
+	nextValidator.AssertCalled(t, "ValidateQC", mock.Anything, &model.Block{BlockID: blocks[5].ID(), View: 1001})
+	rootValidator.AssertNotCalled(t, "ValidateQC", mock.Anything, &model.Block{BlockID: blocks[5].ID(), View: 1001})
+}
+
+// TestEpochTransition_Invalid tests that sealing segments with missing results or service
+// events inconsistent with the known epochs are rejected.
+func TestEpochTransition_Invalid(t *testing.T) {
+	client, _, participants, validator := setup(t)
+	validator.On("ValidateQC", mock.Anything, mock.Anything).Return(nil)
+
+	// finalizeSeal finalizes a block containing a seal for a result with the given service
+	// events, and returns the sealing segment of the block
+	finalizeSeal := func(includeResult bool, events ...flow.ServiceEvent) *flow.SealingSegment {
+		result := unittest.ExecutionResultFixture()
+		result.ServiceEvents = events
+		payload := flow.Payload{Seals: []*flow.Seal{unittest.Seal.Fixture(unittest.Seal.WithResult(result))}}
+		if includeResult {
+			payload.Results = []*flow.ExecutionResult{result}
+		}
+
+		parent := client.FinalizedHead()
+		block := unittest.BlockWithParentFixture(parent)
+		block.Header.View = parent.View + 1
+		block.SetPayload(payload)
+		blocks := append([]*flow.Block{block}, chainFixture(block.Header, parent.View+2, parent.View+3, parent.View+4)...)
+		require.NoError(t, client.Extend(headers(blocks)...))
+		require.Equal(t, block.Header, client.FinalizedHead())
+
+		return &flow.SealingSegment{Blocks: []*flow.Block{block}}
+	}
+
+	t.Run("missing result", func(t *testing.T) {
+		setup := unittest.EpochSetupFixture(unittest.SetupWithCounter(2), unittest.WithParticipants(participants), unittest.WithFirstView(1001))
+		err := client.ApplyEpochTransition(finalizeSeal(false, setup.ServiceEvent()))
+		assert.ErrorIs(t, err, ErrInvalidEpochTransition)
+	})
+
+	t.Run("commit without setup", func(t *testing.T) {
+		commit := unittest.EpochCommitFixture(unittest.CommitWithCounter(2), unittest.WithDKGFromParticipants(participants))
+		err := client.ApplyEpochTransition(finalizeSeal(true, commit.ServiceEvent()))
+		assert.ErrorIs(t, err, ErrInvalidEpochTransition)
+	})
+
+	t.Run("setup skipping an epoch", func(t *testing.T) {
+		setup := unittest.EpochSetupFixture(unittest.SetupWithCounter(3), unittest.WithParticipants(participants), unittest.WithFirstView(1001))
+		err := client.ApplyEpochTransition(finalizeSeal(true, setup.ServiceEvent()))
+		assert.ErrorIs(t, err, ErrInvalidEpochTransition)
+	})
+
+	t.Run("setup with gap in views", func(t *testing.T) {
+		setup := unittest.EpochSetupFixture(unittest.SetupWithCounter(2), unittest.WithParticipants(participants), unittest.WithFirstView(1002))
+		err := client.ApplyEpochTransition(finalizeSeal(true, setup.ServiceEvent()))
+		assert.ErrorIs(t, err, ErrInvalidEpochTransition)
+	})
+
+	// nothing has been applied
+	assert.Len(t, client.epochs, 1)
+	assert.Nil(t, client.pendingSetup)
+}
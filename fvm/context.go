@@ -246,6 +246,20 @@ func WithServiceAccount(enabled bool) Option {
 	}
 }
 
+// WithBeaconRandomSourceActivationHeight sets the block height from which on
+// the random numbers provided to Cadence are seeded with the random beacon
+// signature of the block, rather than with the block ID. Execution and
+// verification nodes must use the same height, otherwise verification of the
+// execution results fails, so nodes use the activation height of their chain, see
+// environment.BeaconRandomSourceActivationHeight. By default, the beacon random
+// source is disabled.
+func WithBeaconRandomSourceActivationHeight(height uint64) Option {
+	return func(ctx Context) Context {
+		ctx.BeaconRandomSourceActivationHeight = height
+		return ctx
+	}
+}
+
 // WithRestrictContractRemoval enables or disables restricted contract removal for a
 // virtual machine context. Warning! this would be overridden with the flag stored on chain.
 // this is just a fallback value
@@ -0,0 +1,98 @@
+package environment
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/onflow/flow-go/crypto/random"
+	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/trace"
+	"github.com/onflow/flow-go/state/protocol/seed"
+)
+
+// beaconRandomGenerator provides random numbers derived from the random beacon.
+//
+// Every block header carries the QC of its parent, which contains the random
+// beacon signature for the parent. This signature is unpredictable before the
+// parent has been certified, and it is available to everyone holding the
+// header, in particular to verification nodes re-executing chunks of the
+// block. The signature is used as the source of randomness of the block, and
+// a ChaCha20 PRG is derived from it for every transaction, customized by the
+// transaction index. Hence, transactions of the same block get independent
+// random numbers, while re-executing a transaction always yields the same
+// random numbers.
+type beaconRandomGenerator struct {
+	tracer *Tracer
+
+	blockHeader *flow.Header
+	txIndex     uint32
+
+	prg random.Rand
+}
+
+// NewBeaconRandomGenerator creates a random generator for the transaction with
+// the given index in the given block, seeded by the random beacon signature
+// carried in the block header.
+func NewBeaconRandomGenerator(
+	tracer *Tracer,
+	blockHeader *flow.Header,
+	txIndex uint32,
+) UnsafeRandomGenerator {
+	gen := &beaconRandomGenerator{
+		tracer:      tracer,
+		blockHeader: blockHeader,
+		txIndex:     txIndex,
+	}
+
+	return gen
+}
+
+// createPRG creates the PRG of the transaction. The PRG is created lazily,
+// since not a lot of transactions/scripts use it.
+// Expected errors:
+//   - OperationNotSupportedError if the block header carries no random beacon signature
+//   - RandomSourceFailure if no random source can be derived from the random beacon signature
+func (gen *beaconRandomGenerator) createPRG() error {
+	if gen.prg != nil {
+		return nil
+	}
+
+	// the root block and blocks built by the emulator don't carry a QC
+	if gen.blockHeader == nil || len(gen.blockHeader.ParentVoterSigData) == 0 {
+		return errors.NewOperationNotSupportedError("UnsafeRandom")
+	}
+
+	source, err := seed.FromParentQCSignature(gen.blockHeader.ParentVoterSigData)
+	if err != nil {
+		return errors.NewRandomSourceFailure(
+			fmt.Errorf("could not extract random source from block %v: %w", gen.blockHeader.ID(), err))
+	}
+
+	prg, err := seed.PRGFromRandomSource(source, seed.ExecutionTransaction(gen.txIndex))
+	if err != nil {
+		return errors.NewRandomSourceFailure(
+			fmt.Errorf("could not create PRG for transaction %d: %w", gen.txIndex, err))
+	}
+	gen.prg = prg
+
+	return nil
+}
+
+// UnsafeRandom returns a random uint64 derived from the random beacon. The
+// name is given by the Cadence runtime interface, the numbers are produced by
+// a cryptographically secure PRG.
+// This is not thread safe, which is Ok because a single transaction has a
+// single random generator and is run in a single thread.
+func (gen *beaconRandomGenerator) UnsafeRandom() (uint64, error) {
+	defer gen.tracer.StartExtensiveTracingSpanFromRoot(trace.FVMEnvUnsafeRandom).End()
+
+	err := gen.createPRG()
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 8)
+	gen.prg.Read(buf)
+	return binary.LittleEndian.Uint64(buf), nil
+}
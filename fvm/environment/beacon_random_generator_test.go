@@ -0,0 +1,70 @@
+package environment_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/fvm/environment"
+	"github.com/onflow/flow-go/fvm/errors"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestBeaconRandomGenerator(t *testing.T) {
+	// numbers returns the first n random numbers generated for the transaction
+	numbers := func(t *testing.T, header *flow.Header, txIndex uint32, n int) []uint64 {
+		gen := environment.NewBeaconRandomGenerator(&environment.Tracer{}, header, txIndex)
+		numbers := make([]uint64, n)
+		for i := range numbers {
+			u, err := gen.UnsafeRandom()
+			require.NoError(t, err)
+			numbers[i] = u
+		}
+		return numbers
+	}
+
+	t.Run("deterministic for the same block and transaction", func(t *testing.T) {
+		header := unittest.BlockHeaderFixture()
+
+		first := numbers(t, header, 3, 10)
+		require.Equal(t, first, numbers(t, header, 3, 10))
+
+		// extremely unlikely to get the same number all the time and just fail the test by chance
+		allEqual := true
+		for i := 1; i < len(first); i++ {
+			allEqual = allEqual && first[i] == first[0]
+		}
+		require.False(t, allEqual)
+	})
+
+	t.Run("different for different transactions", func(t *testing.T) {
+		header := unittest.BlockHeaderFixture()
+
+		require.NotEqual(t, numbers(t, header, 0, 10), numbers(t, header, 1, 10))
+		require.NotEqual(t, numbers(t, header, 1, 10), numbers(t, header, 1<<16+1, 10))
+	})
+
+	t.Run("different for different blocks", func(t *testing.T) {
+		require.NotEqual(t,
+			numbers(t, unittest.BlockHeaderFixture(), 0, 10),
+			numbers(t, unittest.BlockHeaderFixture(), 0, 10))
+	})
+
+	t.Run("not supported without random beacon signature", func(t *testing.T) {
+		for _, header := range []*flow.Header{nil, {Height: 42}} {
+			gen := environment.NewBeaconRandomGenerator(&environment.Tracer{}, header, 0)
+			_, err := gen.UnsafeRandom()
+			require.True(t, errors.IsOperationNotSupportedError(err))
+		}
+	})
+
+	t.Run("fails with malformed signature data", func(t *testing.T) {
+		header := unittest.BlockHeaderFixture()
+		header.ParentVoterSigData = unittest.RandomBytes(10)
+
+		gen := environment.NewBeaconRandomGenerator(&environment.Tracer{}, header, 0)
+		_, err := gen.UnsafeRandom()
+		require.True(t, errors.IsRandomSourceFailure(err))
+	})
+}
@@ -97,6 +97,7 @@ type EnvironmentParams struct {
 
 	BlockInfoParams
 	TransactionInfoParams
+	RandomGeneratorParams
 
 	ContractUpdaterParams
 }
@@ -112,6 +113,7 @@ func DefaultEnvironmentParams() EnvironmentParams {
 		EventEmitterParams:    DefaultEventEmitterParams(),
 		BlockInfoParams:       DefaultBlockInfoParams(),
 		TransactionInfoParams: DefaultTransactionInfoParams(),
+		RandomGeneratorParams: DefaultRandomGeneratorParams(),
 		ContractUpdaterParams: DefaultContractUpdaterParams(),
 	}
 }
@@ -74,11 +74,8 @@ func newFacadeEnvironment(
 		ProgramLogger: logger,
 		EventEmitter:  NoEventEmitter{},
 
-		UnsafeRandomGenerator: NewUnsafeRandomGenerator(
-			tracer,
-			params.BlockHeader,
-		),
-		CryptoLibrary: NewCryptoLibrary(tracer, meter),
+		UnsafeRandomGenerator: newRandomGenerator(tracer, params),
+		CryptoLibrary:         NewCryptoLibrary(tracer, meter),
 
 		BlockInfo: NewBlockInfo(
 			tracer,
@@ -140,6 +137,21 @@ func newFacadeEnvironment(
 	return env
 }
 
+// newRandomGenerator creates the random generator selected by the environment
+// parameters.
+func newRandomGenerator(
+	tracer *Tracer,
+	params EnvironmentParams,
+) UnsafeRandomGenerator {
+	if params.beaconRandomSourceActive(params.BlockHeader) {
+		return NewBeaconRandomGenerator(
+			tracer,
+			params.BlockHeader,
+			params.TxIndex)
+	}
+	return NewUnsafeRandomGenerator(tracer, params.BlockHeader)
+}
+
 func NewScriptEnvironment(
 	ctx context.Context,
 	params EnvironmentParams,
@@ -2,6 +2,7 @@ package environment
 
 import (
 	"encoding/binary"
+	"math"
 	"math/rand"
 	"sync"
 
@@ -51,6 +52,47 @@ func (gen ParseRestrictedUnsafeRandomGenerator) UnsafeRandom() (
 		gen.impl.UnsafeRandom)
 }
 
+// BeaconRandomSourceDisabled is the activation height at which the random
+// generator seeded by the random beacon is never activated.
+const BeaconRandomSourceDisabled = math.MaxUint64
+
+// BeaconRandomSourceActivationHeight returns the block height from which on the random generator
+// seeded by the random beacon is used on the given chain. The height is part of the protocol
+// configuration of the chain, rather than of the node configuration, since all execution and
+// verification nodes of a chain must agree on it. Transient chains use the beacon random source
+// from their first block. On long-lived chains it stays disabled, until an activation height is
+// set here for the chain as part of a network upgrade.
+func BeaconRandomSourceActivationHeight(chainID flow.ChainID) uint64 {
+	if chainID.Transient() {
+		return 0
+	}
+	return BeaconRandomSourceDisabled
+}
+
+type RandomGeneratorParams struct {
+	// BeaconRandomSourceActivationHeight is the block height from which on the
+	// random generator seeded by the random beacon (see
+	// NewBeaconRandomGenerator) replaces the generator seeded by the block ID.
+	// Execution and verification nodes must agree on this height, since it
+	// changes the execution results.
+	BeaconRandomSourceActivationHeight uint64
+}
+
+func DefaultRandomGeneratorParams() RandomGeneratorParams {
+	return RandomGeneratorParams{
+		BeaconRandomSourceActivationHeight: BeaconRandomSourceDisabled,
+	}
+}
+
+// beaconRandomSourceActive returns true if the random generator seeded by the
+// random beacon is used for the given block.
+func (params RandomGeneratorParams) beaconRandomSourceActive(
+	blockHeader *flow.Header,
+) bool {
+	return blockHeader != nil &&
+		blockHeader.Height >= params.BeaconRandomSourceActivationHeight
+}
+
 func NewUnsafeRandomGenerator(
 	tracer *Tracer,
 	blockHeader *flow.Header,
@@ -33,3 +33,14 @@ func TestUnsafeRandomGenerator(t *testing.T) {
 		require.True(t, !allEqual)
 	})
 }
+
+func TestBeaconRandomSourceActivationHeight(t *testing.T) {
+	// transient chains use the beacon random source from their first block
+	for _, chainID := range []flow.ChainID{flow.Emulator, flow.Localnet, flow.Benchnet, flow.BftTestnet} {
+		require.Equal(t, uint64(0), environment.BeaconRandomSourceActivationHeight(chainID), chainID)
+	}
+	// long-lived chains don't use it until it is activated by a network upgrade
+	for _, chainID := range []flow.ChainID{flow.Mainnet, flow.Testnet, flow.Sandboxnet} {
+		require.Equal(t, uint64(environment.BeaconRandomSourceDisabled), environment.BeaconRandomSourceActivationHeight(chainID), chainID)
+	}
+}
@@ -57,6 +57,21 @@ func NewBlockFinderFailure(err error) CodedError {
 		"can not retrieve the block")
 }
 
+// NewRandomSourceFailure constructs a new CodedError which captures a fatal
+// caused by a block header from which no random source can be derived.
+func NewRandomSourceFailure(err error) CodedError {
+	return WrapCodedError(
+		FailureCodeRandomSourceFailure,
+		err,
+		"can not derive the random source")
+}
+
+// IsRandomSourceFailure returns true if the error or any of the wrapped errors
+// is a random source failure
+func IsRandomSourceFailure(err error) bool {
+	return HasErrorCode(err, FailureCodeRandomSourceFailure)
+}
+
 // NewParseRestrictedModeInvalidAccessFailure constructs a CodedError which
 // captures a fatal caused by Cadence accessing an unexpected environment
 // operation while it is parsing programs.
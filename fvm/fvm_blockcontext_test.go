@@ -1663,6 +1663,82 @@ func TestBlockContext_UnsafeRandom(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, uint64(0x8872445cb397f6d2), num)
 	})
+
+	t.Run("uses block ID below beacon random source activation height", func(t *testing.T) {
+		beforeActivationCtx := fvm.NewContextFromParent(
+			ctx,
+			fvm.WithBeaconRandomSourceActivationHeight(header.Height+1),
+		)
+
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`
+                transaction {
+                    execute {
+                        let rand = unsafeRandom()
+                        log(rand)
+                    }
+                }
+            `))
+
+		err := testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+		require.NoError(t, err)
+
+		ledger := testutil.RootBootstrappedLedger(vm, beforeActivationCtx)
+
+		tx := fvm.Transaction(txBody, 0)
+
+		err = vm.Run(beforeActivationCtx, tx, ledger)
+		require.NoError(t, err)
+		require.NoError(t, tx.Err)
+
+		require.Len(t, tx.Logs, 1)
+
+		num, err := strconv.ParseUint(tx.Logs[0], 10, 64)
+		require.NoError(t, err)
+		require.Equal(t, uint64(0x8872445cb397f6d2), num)
+	})
+
+	t.Run("works with beacon random source", func(t *testing.T) {
+		beaconHeader := unittest.BlockHeaderFixture()
+		beaconCtx := fvm.NewContextFromParent(
+			ctx,
+			fvm.WithBlockHeader(beaconHeader),
+			fvm.WithBeaconRandomSourceActivationHeight(beaconHeader.Height),
+		)
+
+		txBody := flow.NewTransactionBody().
+			SetScript([]byte(`
+                transaction {
+                    execute {
+                        let rand = unsafeRandom()
+                        log(rand)
+                    }
+                }
+            `))
+
+		err := testutil.SignTransactionAsServiceAccount(txBody, 0, chain)
+		require.NoError(t, err)
+
+		// re-executing the transaction, as verification nodes do, yields the same number
+		var logs []string
+		for i := 0; i < 2; i++ {
+			ledger := testutil.RootBootstrappedLedger(vm, beaconCtx)
+
+			tx := fvm.Transaction(txBody, 0)
+
+			err = vm.Run(beaconCtx, tx, ledger)
+			require.NoError(t, err)
+			require.NoError(t, tx.Err)
+
+			require.Len(t, tx.Logs, 1)
+			logs = append(logs, tx.Logs[0])
+		}
+		require.Equal(t, logs[0], logs[1])
+
+		num, err := strconv.ParseUint(logs[0], 10, 64)
+		require.NoError(t, err)
+		require.NotEqual(t, uint64(0x8872445cb397f6d2), num)
+	})
 }
 
 func TestBlockContext_ExecuteTransaction_CreateAccount_WithMonotonicAddresses(t *testing.T) {
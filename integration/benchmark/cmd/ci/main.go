@@ -158,7 +158,7 @@ func main() {
 		},
 		benchmark.LoadParams{
 			NumberOfAccounts: maxInflight,
-			LoadType:         benchmark.LoadType(loadType),
+			LoadType:         benchmark.LoadTypeName(loadType),
 			FeedbackEnabled:  feedbackEnabled,
 		},
 		// We do support only one load type for now.
@@ -31,7 +31,8 @@ type LoadCase struct {
 
 func main() {
 	sleep := flag.Duration("sleep", 0, "duration to sleep before benchmarking starts")
-	loadTypeFlag := flag.String("load-type", "token-transfer", "type of loads (\"token-transfer\", \"add-keys\", \"computation-heavy\", \"event-heavy\", \"ledger-heavy\", \"const-exec\")")
+	loadTypeFlag := flag.String("load-type", "token-transfer", "type of loads (\"token-transfer\", \"add-keys\", \"computation-heavy\", \"event-heavy\", \"ledger-heavy\", \"const-exec\"), ignored if a workload is given")
+	workloadFlag := flag.String("workload", "", "YAML file defining a mix of load types and stages of TPS, which is used instead of the load-type, tps and tps-durations flags (the \"replay\" load type is only available in workloads)")
 	tpsFlag := flag.String("tps", "1", "transactions per second (TPS) to send, accepts a comma separated list of values if used in conjunction with `tps-durations`")
 	tpsDurationsFlag := flag.String("tps-durations", "0", "duration that each load test will run, accepts a comma separted list that will be applied to multiple values of the `tps` flag (defaults to infinite if not provided, meaning only the first tps case will be tested; additional values will be ignored)")
 	chainIDStr := flag.String("chain", string(flowsdk.Emulator), "chain ID")
@@ -95,10 +96,20 @@ func main() {
 
 	// run load cases and compute max tps
 	var maxTPS uint
-	loadCases := parseLoadCases(log, tpsFlag, tpsDurationsFlag)
-	for _, c := range loadCases {
-		if c.tps > maxTPS {
-			maxTPS = c.tps
+	var loadCases []LoadCase
+	var workload *benchmark.Workload
+	if *workloadFlag != "" {
+		workload, err = benchmark.LoadWorkload(*workloadFlag)
+		if err != nil {
+			log.Fatal().Err(err).Str("file", *workloadFlag).Msg("unable to load workload")
+		}
+		maxTPS = workload.MaxTPS()
+	} else {
+		loadCases = parseLoadCases(log, tpsFlag, tpsDurationsFlag)
+		for _, c := range loadCases {
+			if c.tps > maxTPS {
+				maxTPS = c.tps
+			}
 		}
 	}
 
@@ -123,7 +134,8 @@ func main() {
 		},
 		benchmark.LoadParams{
 			NumberOfAccounts: int(maxTPS) * *accountMultiplierFlag,
-			LoadType:         benchmark.LoadType(*loadTypeFlag),
+			LoadType:         benchmark.LoadTypeName(*loadTypeFlag),
+			Workload:         workload,
 			FeedbackEnabled:  *feedbackEnabled,
 		},
 		benchmark.ConstExecParams{
@@ -143,6 +155,14 @@ func main() {
 		log.Fatal().Err(err).Msg("unable to init loader")
 	}
 
+	if workload != nil {
+		err = workload.RunStages(ctx, log, lg)
+		if err != nil {
+			log.Fatal().Err(err).Msg("unable to run workload")
+		}
+		return
+	}
+
 	for i, c := range loadCases {
 		log.Info().
 			Str("load_type", *loadTypeFlag).
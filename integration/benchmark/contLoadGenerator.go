@@ -4,8 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"sync"
-
 	"time"
 
 	"github.com/onflow/cadence"
@@ -17,18 +17,6 @@ import (
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/access"
 	"github.com/onflow/flow-go-sdk/crypto"
-	"github.com/onflow/flow-go/model/flow"
-)
-
-type LoadType string
-
-const (
-	TokenTransferLoadType LoadType = "token-transfer"
-	TokenAddKeysLoadType  LoadType = "add-keys"
-	CompHeavyLoadType     LoadType = "computation-heavy"
-	EventHeavyLoadType    LoadType = "event-heavy"
-	LedgerHeavyLoadType   LoadType = "ledger-heavy"
-	ConstExecCostLoadType LoadType = "const-exec" // for an empty transactions with various tx arguments
 )
 
 const lostTransactionThreshold = 90 * time.Second
@@ -56,7 +44,7 @@ type ContLoadGenerator struct {
 	loaderMetrics      *metrics.LoaderCollector
 	loadParams         LoadParams
 	networkParams      NetworkParams
-	constExecParams    ConstExecParams
+	loads              []weightedLoad
 	flowClient         access.Client
 	serviceAccount     *flowAccount
 	favContractAddress *flowsdk.Address
@@ -65,7 +53,6 @@ type ContLoadGenerator struct {
 	workerStatsTracker *WorkerStatsTracker
 	stoppedChannel     chan struct{}
 	follower           TxFollower
-	workFunc           workFunc
 
 	workersMutex sync.Mutex
 	workers      []*Worker
@@ -80,7 +67,9 @@ type NetworkParams struct {
 
 type LoadParams struct {
 	NumberOfAccounts int
-	LoadType         LoadType
+	LoadType         LoadTypeName
+	// Workload defines a mix of load types, it takes precedence over LoadType if set.
+	Workload *Workload
 
 	// TODO(rbtz): inject a TxFollower
 	FeedbackEnabled bool
@@ -119,34 +108,16 @@ func New(
 		return nil, err
 	}
 
-	// check and cap params for const-exec mode
-	if loadParams.LoadType == ConstExecCostLoadType {
-		if constExecParams.MaxTxSizeInByte > flow.DefaultMaxTransactionByteSize {
-			errMsg := fmt.Sprintf("MaxTxSizeInByte(%d) is larger than DefaultMaxTransactionByteSize(%d).",
-				constExecParams.MaxTxSizeInByte,
-				flow.DefaultMaxTransactionByteSize)
-			log.Error().Msg(errMsg)
-
-			return nil, errors.New(errMsg)
-		}
-
-		// accounts[0] will be used as the proposer\payer
-		if constExecParams.AuthAccountNum > uint(loadParams.NumberOfAccounts-1) {
-			errMsg := fmt.Sprintf("Number of authorizer(%d) is larger than max possible(%d).",
-				constExecParams.AuthAccountNum,
-				loadParams.NumberOfAccounts-1)
-			log.Error().Msg(errMsg)
-
-			return nil, errors.New(errMsg)
-		}
-
-		if constExecParams.ArgSizeInByte > flow.DefaultMaxTransactionByteSize {
-			errMsg := fmt.Sprintf("ArgSizeInByte(%d) is larger than DefaultMaxTransactionByteSize(%d).",
-				constExecParams.ArgSizeInByte,
-				flow.DefaultMaxTransactionByteSize)
-			log.Error().Msg(errMsg)
-			return nil, errors.New(errMsg)
-		}
+	var loads []weightedLoad
+	if loadParams.Workload != nil {
+		loads, err = loadParams.Workload.loadTypes(loadParams, constExecParams)
+	} else {
+		var loadType LoadType
+		loadType, err = newLoadType(loadParams.LoadType, loadParams, constExecParams)
+		loads = []weightedLoad{{name: loadParams.LoadType, load: loadType, weight: 1}}
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	lg := &ContLoadGenerator{
@@ -154,7 +125,7 @@ func New(
 		loaderMetrics:      loaderMetrics,
 		loadParams:         loadParams,
 		networkParams:      networkParams,
-		constExecParams:    constExecParams,
+		loads:              loads,
 		flowClient:         flowClient,
 		serviceAccount:     servAcc,
 		accounts:           make([]*flowAccount, 0),
@@ -164,20 +135,6 @@ func New(
 		stoppedChannel:     make(chan struct{}),
 	}
 
-	// TODO(rbtz): hide load implementation behind an interface
-	switch loadParams.LoadType {
-	case TokenTransferLoadType:
-		lg.workFunc = lg.sendTokenTransferTx
-	case TokenAddKeysLoadType:
-		lg.workFunc = lg.sendAddKeyTx
-	case ConstExecCostLoadType:
-		lg.workFunc = lg.sendConstExecCostTx
-	case CompHeavyLoadType, EventHeavyLoadType, LedgerHeavyLoadType:
-		lg.workFunc = lg.sendFavContractTx
-	default:
-		return nil, fmt.Errorf("unknown load type: %s", loadParams.LoadType)
-	}
-
 	return lg, nil
 }
 
@@ -209,21 +166,10 @@ func (lg *ContLoadGenerator) Init() error {
 		}
 	}
 
-	// TODO(rbtz): create an interface for different load types: Setup()
-	if lg.loadParams.LoadType != ConstExecCostLoadType {
-		err := lg.setupFavContract()
-		if err != nil {
-			lg.log.Error().Err(err).Msg("failed to setup fav contract")
-			return err
-		}
-	} else {
-		lg.log.Info().Int("numberOfAccountsCreated", len(lg.accounts)).
-			Msg("new accounts created. Grabbing the first as the proposer/payer " +
-				"and adding multiple keys to that account")
-
-		err := lg.addKeysToProposerAccount(lg.accounts[0])
+	for _, load := range lg.loads {
+		err := load.load.Setup(lg)
 		if err != nil {
-			lg.log.Error().Msg("failed to create add-key transaction for const-exec")
+			lg.log.Error().Err(err).Str("load_type", string(load.name)).Msg("failed to setup load")
 			return err
 		}
 	}
@@ -271,7 +217,7 @@ func (lg *ContLoadGenerator) setupFavContract() error {
 
 func (lg *ContLoadGenerator) startWorkers(num int) error {
 	for i := 0; i < num; i++ {
-		worker := NewWorker(len(lg.workers), 1*time.Second, lg.workFunc)
+		worker := NewWorker(len(lg.workers), 1*time.Second, lg.sendLoadTx)
 		lg.log.Trace().Int("workerID", worker.workerID).Msg("starting worker")
 		worker.Start()
 		lg.workers = append(lg.workers, worker)
@@ -494,53 +440,12 @@ func (lg *ContLoadGenerator) createAddKeyTx(accountAddress flowsdk.Address, numb
 
 }
 
-func (lg *ContLoadGenerator) sendAddKeyTx(workerID int) {
-	log := lg.log.With().Int("workerID", workerID).Logger()
-
-	// TODO move this as a configurable parameter
-	numberOfKeysToAdd := uint(40)
-
-	log.Trace().Msg("getting next available account")
-
-	acc := <-lg.availableAccounts
-	defer func() { lg.availableAccounts <- acc }()
-
-	log.Trace().Msg("creating add proposer key script")
-
-	addKeysTx, err := lg.createAddKeyTx(*acc.address, numberOfKeysToAdd)
-	if err != nil {
-		log.Error().Err(err).Msg("error creating AddKey transaction")
-		return
-	}
-
-	log.Trace().Msg("creating transaction")
-
-	addKeysTx.SetReferenceBlockID(lg.follower.BlockID()).
-		SetProposalKey(*acc.address, 0, acc.seqNumber).
-		SetPayer(*acc.address).
-		AddAuthorizer(*acc.address)
-
-	log.Trace().Msg("signing transaction")
-	err = acc.signTx(addKeysTx, 0)
-	if err != nil {
-		log.Error().Err(err).Msg("error signing transaction")
-		return
-	}
-
-	ch, err := lg.sendTx(workerID, addKeysTx)
-	if err != nil {
-		return
-	}
-	<-ch
-	lg.workerStatsTracker.IncTxExecuted()
-}
-
-func (lg *ContLoadGenerator) addKeysToProposerAccount(proposerPayerAccount *flowAccount) error {
+func (lg *ContLoadGenerator) addKeysToProposerAccount(proposerPayerAccount *flowAccount, keyCount uint) error {
 	if proposerPayerAccount == nil {
 		return errors.New("proposerPayerAccount is nil")
 	}
 
-	addKeysToPayerTx, err := lg.createAddKeyTx(*lg.accounts[0].address, lg.constExecParams.PayerKeyCount)
+	addKeysToPayerTx, err := lg.createAddKeyTx(*lg.accounts[0].address, keyCount)
 	if err != nil {
 		lg.log.Error().Msg("failed to create add-key transaction for const-exec")
 		return err
@@ -568,89 +473,8 @@ func (lg *ContLoadGenerator) addKeysToProposerAccount(proposerPayerAccount *flow
 	return nil
 }
 
-func (lg *ContLoadGenerator) sendConstExecCostTx(workerID int) {
-	log := lg.log.With().Int("workerID", workerID).Logger()
-
-	txScriptNoComment := ConstExecCostTransaction(lg.constExecParams.AuthAccountNum, 0)
-
-	tx := flowsdk.NewTransaction().
-		SetReferenceBlockID(lg.follower.BlockID()).
-		SetScript(txScriptNoComment).
-		SetGasLimit(10). // const-exec tx has empty transaction
-		SetProposalKey(*lg.accounts[0].address, 0, lg.accounts[0].seqNumber).
-		SetPayer(*lg.accounts[0].address)
-	lg.accounts[0].seqNumber += 1
-
-	txArgStr := generateRandomStringWithLen(lg.constExecParams.ArgSizeInByte)
-	txArg, err := cadence.NewString(txArgStr)
-	if err != nil {
-		log.Trace().Msg("Failed to generate cadence String parameter. Using empty string.")
-	}
-	err = tx.AddArgument(txArg)
-	if err != nil {
-		log.Trace().Msg("Failed to add argument. Skipping.")
-	}
-
-	// Add authorizers. lg.accounts[0] used as proposer\payer
-	log.Trace().Msg("Adding tx authorizers")
-	for i := uint(1); i < lg.constExecParams.AuthAccountNum+1; i++ {
-		tx = tx.AddAuthorizer(*lg.accounts[i].address)
-	}
-
-	log.Trace().Msg("Authorizers signing tx")
-	for i := uint(1); i < lg.constExecParams.AuthAccountNum+1; i++ {
-		err := lg.accounts[i].signPayload(tx, 0)
-		if err != nil {
-			log.Error().Err(err).Msg("error signing payload")
-			return
-		}
-	}
-
-	log.Trace().Msg("Payer signing tx")
-	for i := uint(0); i < lg.constExecParams.PayerKeyCount; i++ {
-		err = lg.accounts[0].signTx(tx, int(i))
-		if err != nil {
-			log.Error().Err(err).Msg("error signing transaction")
-			return
-		}
-	}
-
-	// calculate RLP-encoded binary size of the transaction without comment
-	txSizeWithoutComment := uint(len(tx.Encode()))
-	if txSizeWithoutComment > lg.constExecParams.MaxTxSizeInByte {
-		log.Error().Msg(fmt.Sprintf("current tx size(%d) without comment "+
-			"is larger than max tx size configured(%d)",
-			txSizeWithoutComment, lg.constExecParams.MaxTxSizeInByte))
-		return
-	}
-
-	// now adding comment to fulfill the final transaction size
-	commentSizeInByte := lg.constExecParams.MaxTxSizeInByte - txSizeWithoutComment
-	txScriptWithComment := ConstExecCostTransaction(lg.constExecParams.AuthAccountNum, commentSizeInByte)
-	tx = tx.SetScript(txScriptWithComment)
-
-	txSizeWithComment := uint(len(tx.Encode()))
-	log.Trace().Uint("Max Tx Size", lg.constExecParams.MaxTxSizeInByte).
-		Uint("Actual Tx Size", txSizeWithComment).
-		Uint("Tx Arg Size", lg.constExecParams.ArgSizeInByte).
-		Uint("Num of Authorizers", lg.constExecParams.AuthAccountNum).
-		Uint("Num of payer keys", lg.constExecParams.PayerKeyCount).
-		Uint("Script comment length", commentSizeInByte).
-		Msg("Generating one const-exec transaction")
-
-	log.Trace().Msg("Issuing tx")
-	ch, err := lg.sendTx(workerID, tx)
-	if err != nil {
-		log.Error().Err(err).Msg("const-exec tx failed")
-		return
-	}
-	<-ch
-	lg.workerStatsTracker.IncTxExecuted()
-
-	log.Trace().Msg("const-exec tx suceeded")
-}
-
-func (lg *ContLoadGenerator) sendTokenTransferTx(workerID int) {
+// sendLoadTx sends a transaction of one of the loads, chosen at random according to the load weights.
+func (lg *ContLoadGenerator) sendLoadTx(workerID int) {
 	log := lg.log.With().Int("workerID", workerID).Logger()
 
 	log.Trace().
@@ -665,48 +489,28 @@ func (lg *ContLoadGenerator) sendTokenTransferTx(workerID int) {
 		return
 	}
 	defer func() { lg.availableAccounts <- acc }()
-	nextAcc := lg.accounts[(acc.i+1)%len(lg.accounts)]
 
-	log.Trace().
-		Float64("tokens", tokensPerTransfer).
-		Hex("srcAddress", acc.address.Bytes()).
-		Hex("dstAddress", nextAcc.address.Bytes()).
-		Int("srcAccount", acc.i).
-		Int("dstAccount", nextAcc.i).
-		Msg("creating transfer script")
-
-	transferTx, err := TokenTransferTransaction(
-		lg.networkParams.FungibleTokenAddress,
-		lg.networkParams.FlowTokenAddress,
-		nextAcc.address,
-		tokensPerTransfer)
-	if err != nil {
-		log.Error().Err(err).Msg("error creating token transfer script")
-		return
-	}
+	load := pickLoad(lg.loads, rand.Intn)
+	log = log.With().Str("load_type", string(load.name)).Logger()
 
-	log.Trace().Msg("creating token transfer transaction")
-	transferTx = transferTx.
-		SetReferenceBlockID(lg.follower.BlockID()).
-		SetGasLimit(9999).
-		SetProposalKey(*acc.address, 0, acc.seqNumber).
-		SetPayer(*acc.address).
-		AddAuthorizer(*acc.address)
+	log.Trace().
+		Hex("address", acc.address.Bytes()).
+		Int("account", acc.i).
+		Msg("creating transaction")
 
-	log.Trace().Msg("signing transaction")
-	err = acc.signTx(transferTx, 0)
+	tx, err := load.load.Transaction(lg, acc)
 	if err != nil {
-		log.Error().Err(err).Msg("error signing transaction")
+		log.Error().Err(err).Msg("error creating transaction")
 		return
 	}
 
 	startTime := time.Now()
-	ch, err := lg.sendTx(workerID, transferTx)
+	ch, err := lg.sendTx(workerID, tx)
 	if err != nil {
 		return
 	}
 
-	log = log.With().Hex("tx_id", transferTx.ID().Bytes()).Logger()
+	log = log.With().Hex("tx_id", tx.ID().Bytes()).Logger()
 	log.Trace().Msg("transaction sent")
 
 	t := time.NewTimer(lostTransactionThreshold)
@@ -714,12 +518,13 @@ func (lg *ContLoadGenerator) sendTokenTransferTx(workerID int) {
 
 	select {
 	case result := <-ch:
-		if result.Error != nil {
+		err := load.load.Validate(result)
+		if err != nil {
 			lg.workerStatsTracker.IncTxFailed()
 		}
 		log.Trace().
 			Dur("duration", time.Since(startTime)).
-			Err(result.Error).
+			Err(err).
 			Str("status", result.Status.String()).
 			Msg("transaction confirmed")
 	case <-t.C:
@@ -735,50 +540,6 @@ func (lg *ContLoadGenerator) sendTokenTransferTx(workerID int) {
 	lg.workerStatsTracker.IncTxExecuted()
 }
 
-// TODO update this to include loadtype
-func (lg *ContLoadGenerator) sendFavContractTx(workerID int) {
-	log := lg.log.With().Int("workerID", workerID).Logger()
-	log.Trace().Msg("getting next available account")
-
-	acc := <-lg.availableAccounts
-	defer func() { lg.availableAccounts <- acc }()
-	var txScript []byte
-
-	switch lg.loadParams.LoadType {
-	case CompHeavyLoadType:
-		txScript = ComputationHeavyScript(*lg.favContractAddress)
-	case EventHeavyLoadType:
-		txScript = EventHeavyScript(*lg.favContractAddress)
-	case LedgerHeavyLoadType:
-		txScript = LedgerHeavyScript(*lg.favContractAddress)
-	default:
-		log.Error().Msg("unknown load type")
-		return
-	}
-
-	log.Trace().Msg("creating transaction")
-	tx := flowsdk.NewTransaction().
-		SetReferenceBlockID(lg.follower.BlockID()).
-		SetScript(txScript).
-		SetGasLimit(9999).
-		SetProposalKey(*acc.address, 0, acc.seqNumber).
-		SetPayer(*acc.address).
-		AddAuthorizer(*acc.address)
-
-	log.Trace().Msg("signing transaction")
-	err := acc.signTx(tx, 0)
-	if err != nil {
-		log.Error().Err(err).Msg("error signing transaction")
-		return
-	}
-
-	ch, err := lg.sendTx(workerID, tx)
-	if err != nil {
-		return
-	}
-	<-ch
-}
-
 func (lg *ContLoadGenerator) sendTx(workerID int, tx *flowsdk.Transaction) (<-chan flowsdk.TransactionResult, error) {
 	log := lg.log.With().Int("workerID", workerID).Str("tx_id", tx.ID().String()).Logger()
 	log.Trace().Msg("sending transaction")
@@ -0,0 +1,287 @@
+package benchmark
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// LoadTypeName is the name of a load type, as used in flags and workload definitions.
+type LoadTypeName string
+
+const (
+	TokenTransferLoadType LoadTypeName = "token-transfer"
+	TokenAddKeysLoadType  LoadTypeName = "add-keys"
+	CompHeavyLoadType     LoadTypeName = "computation-heavy"
+	EventHeavyLoadType    LoadTypeName = "event-heavy"
+	LedgerHeavyLoadType   LoadTypeName = "ledger-heavy"
+	ConstExecCostLoadType LoadTypeName = "const-exec" // for an empty transactions with various tx arguments
+	ReplayLoadType        LoadTypeName = "replay"     // for transactions exported by export-json-transactions
+)
+
+// LoadType is a kind of transactions sent by the load generator.
+type LoadType interface {
+	// Setup prepares the network for the load, e.g. by deploying contracts. It is called once,
+	// after the benchmark accounts have been created and before any transaction is sent.
+	Setup(lg *ContLoadGenerator) error
+
+	// Transaction returns the next transaction of the load, using the given account as proposer
+	// and payer. The transaction must be signed, and the sequence number of the account incremented.
+	Transaction(lg *ContLoadGenerator, acc *flowAccount) (*flowsdk.Transaction, error)
+
+	// Validate checks the result of an executed transaction of the load, and returns an error if
+	// the transaction did not have the expected outcome.
+	Validate(result flowsdk.TransactionResult) error
+}
+
+// newLoadType returns the load type with the given name. The replay load type is not supported,
+// since it requires a recording, see NewReplayLoad.
+func newLoadType(name LoadTypeName, loadParams LoadParams, constExecParams ConstExecParams) (LoadType, error) {
+	switch name {
+	case TokenTransferLoadType:
+		return &tokenTransferLoad{}, nil
+	case TokenAddKeysLoadType:
+		return &addKeysLoad{}, nil
+	case ConstExecCostLoadType:
+		return newConstExecLoad(loadParams, constExecParams)
+	case CompHeavyLoadType:
+		return &favContractLoad{script: ComputationHeavyScript}, nil
+	case EventHeavyLoadType:
+		return &favContractLoad{script: EventHeavyScript, expectEvents: true}, nil
+	case LedgerHeavyLoadType:
+		return &favContractLoad{script: LedgerHeavyScript}, nil
+	case ReplayLoadType:
+		return nil, fmt.Errorf("load type %s requires a workload definition with a replay file", name)
+	default:
+		return nil, fmt.Errorf("unknown load type: %s", name)
+	}
+}
+
+// validateExecuted is the default validation, which only checks that the transaction didn't fail.
+func validateExecuted(result flowsdk.TransactionResult) error {
+	if result.Error != nil {
+		return fmt.Errorf("transaction failed: %w", result.Error)
+	}
+	return nil
+}
+
+// tokenTransferLoad transfers flow tokens from each account to the next.
+type tokenTransferLoad struct{}
+
+func (l *tokenTransferLoad) Setup(*ContLoadGenerator) error {
+	return nil
+}
+
+func (l *tokenTransferLoad) Transaction(lg *ContLoadGenerator, acc *flowAccount) (*flowsdk.Transaction, error) {
+	nextAcc := lg.accounts[(acc.i+1)%len(lg.accounts)]
+
+	transferTx, err := TokenTransferTransaction(
+		lg.networkParams.FungibleTokenAddress,
+		lg.networkParams.FlowTokenAddress,
+		nextAcc.address,
+		tokensPerTransfer)
+	if err != nil {
+		return nil, fmt.Errorf("error creating token transfer script: %w", err)
+	}
+
+	transferTx = transferTx.
+		SetReferenceBlockID(lg.follower.BlockID()).
+		SetGasLimit(9999).
+		SetProposalKey(*acc.address, 0, acc.seqNumber).
+		SetPayer(*acc.address).
+		AddAuthorizer(*acc.address)
+
+	err = acc.signTx(transferTx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error signing transaction: %w", err)
+	}
+	return transferTx, nil
+}
+
+func (l *tokenTransferLoad) Validate(result flowsdk.TransactionResult) error {
+	return validateExecuted(result)
+}
+
+// addKeysLoad adds keys to the sending account.
+type addKeysLoad struct{}
+
+// TODO move this as a configurable parameter
+const numberOfKeysToAdd = uint(40)
+
+func (l *addKeysLoad) Setup(*ContLoadGenerator) error {
+	return nil
+}
+
+func (l *addKeysLoad) Transaction(lg *ContLoadGenerator, acc *flowAccount) (*flowsdk.Transaction, error) {
+	addKeysTx, err := lg.createAddKeyTx(*acc.address, numberOfKeysToAdd)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AddKey transaction: %w", err)
+	}
+
+	addKeysTx.SetReferenceBlockID(lg.follower.BlockID()).
+		SetProposalKey(*acc.address, 0, acc.seqNumber).
+		SetPayer(*acc.address)
+
+	err = acc.signTx(addKeysTx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error signing transaction: %w", err)
+	}
+	return addKeysTx, nil
+}
+
+func (l *addKeysLoad) Validate(result flowsdk.TransactionResult) error {
+	return validateExecuted(result)
+}
+
+// favContractLoad calls a function of the fav contract, which is deployed during setup.
+type favContractLoad struct {
+	script       func(favContractAddress flowsdk.Address) []byte
+	expectEvents bool // whether the transaction must emit events
+}
+
+func (l *favContractLoad) Setup(lg *ContLoadGenerator) error {
+	// the contract is shared by all fav contract loads of a workload
+	if lg.favContractAddress != nil {
+		return nil
+	}
+	return lg.setupFavContract()
+}
+
+func (l *favContractLoad) Transaction(lg *ContLoadGenerator, acc *flowAccount) (*flowsdk.Transaction, error) {
+	tx := flowsdk.NewTransaction().
+		SetReferenceBlockID(lg.follower.BlockID()).
+		SetScript(l.script(*lg.favContractAddress)).
+		SetGasLimit(9999).
+		SetProposalKey(*acc.address, 0, acc.seqNumber).
+		SetPayer(*acc.address).
+		AddAuthorizer(*acc.address)
+
+	err := acc.signTx(tx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error signing transaction: %w", err)
+	}
+	return tx, nil
+}
+
+func (l *favContractLoad) Validate(result flowsdk.TransactionResult) error {
+	err := validateExecuted(result)
+	if err != nil {
+		return err
+	}
+	if l.expectEvents && len(result.Events) == 0 {
+		return errors.New("transaction emitted no events")
+	}
+	return nil
+}
+
+// constExecLoad sends empty transactions of a configured size, with the first account as the
+// proposer and payer, and the following accounts as authorizers.
+type constExecLoad struct {
+	params ConstExecParams
+}
+
+func newConstExecLoad(loadParams LoadParams, params ConstExecParams) (*constExecLoad, error) {
+	if params.MaxTxSizeInByte > flow.DefaultMaxTransactionByteSize {
+		return nil, fmt.Errorf("MaxTxSizeInByte(%d) is larger than DefaultMaxTransactionByteSize(%d)",
+			params.MaxTxSizeInByte,
+			flow.DefaultMaxTransactionByteSize)
+	}
+
+	// accounts[0] will be used as the proposer\payer
+	if params.AuthAccountNum > uint(loadParams.NumberOfAccounts-1) {
+		return nil, fmt.Errorf("number of authorizer(%d) is larger than max possible(%d)",
+			params.AuthAccountNum,
+			loadParams.NumberOfAccounts-1)
+	}
+
+	if params.ArgSizeInByte > flow.DefaultMaxTransactionByteSize {
+		return nil, fmt.Errorf("ArgSizeInByte(%d) is larger than DefaultMaxTransactionByteSize(%d)",
+			params.ArgSizeInByte,
+			flow.DefaultMaxTransactionByteSize)
+	}
+
+	return &constExecLoad{params: params}, nil
+}
+
+func (l *constExecLoad) Setup(lg *ContLoadGenerator) error {
+	lg.log.Info().Int("numberOfAccountsCreated", len(lg.accounts)).
+		Msg("grabbing the first account as the const-exec proposer/payer and adding multiple keys to that account")
+
+	return lg.addKeysToProposerAccount(lg.accounts[0], l.params.PayerKeyCount)
+}
+
+// Transaction ignores the given account, the transaction is always proposed and paid by the first account.
+func (l *constExecLoad) Transaction(lg *ContLoadGenerator, _ *flowAccount) (*flowsdk.Transaction, error) {
+	log := lg.log
+	payer := lg.accounts[0]
+
+	txScriptNoComment := ConstExecCostTransaction(l.params.AuthAccountNum, 0)
+
+	tx := flowsdk.NewTransaction().
+		SetReferenceBlockID(lg.follower.BlockID()).
+		SetScript(txScriptNoComment).
+		SetGasLimit(10). // const-exec tx has empty transaction
+		SetProposalKey(*payer.address, 0, payer.seqNumber).
+		SetPayer(*payer.address)
+	payer.seqNumber += 1
+
+	txArgStr := generateRandomStringWithLen(l.params.ArgSizeInByte)
+	txArg, err := cadence.NewString(txArgStr)
+	if err != nil {
+		log.Trace().Msg("Failed to generate cadence String parameter. Using empty string.")
+	}
+	err = tx.AddArgument(txArg)
+	if err != nil {
+		log.Trace().Msg("Failed to add argument. Skipping.")
+	}
+
+	// Add authorizers. lg.accounts[0] used as proposer\payer
+	for i := uint(1); i < l.params.AuthAccountNum+1; i++ {
+		tx = tx.AddAuthorizer(*lg.accounts[i].address)
+	}
+
+	for i := uint(1); i < l.params.AuthAccountNum+1; i++ {
+		err := lg.accounts[i].signPayload(tx, 0)
+		if err != nil {
+			return nil, fmt.Errorf("error signing payload: %w", err)
+		}
+	}
+
+	for i := uint(0); i < l.params.PayerKeyCount; i++ {
+		err = payer.signTx(tx, int(i))
+		if err != nil {
+			return nil, fmt.Errorf("error signing transaction: %w", err)
+		}
+	}
+
+	// calculate RLP-encoded binary size of the transaction without comment
+	txSizeWithoutComment := uint(len(tx.Encode()))
+	if txSizeWithoutComment > l.params.MaxTxSizeInByte {
+		return nil, fmt.Errorf("current tx size(%d) without comment is larger than max tx size configured(%d)",
+			txSizeWithoutComment, l.params.MaxTxSizeInByte)
+	}
+
+	// now adding comment to fulfill the final transaction size
+	commentSizeInByte := l.params.MaxTxSizeInByte - txSizeWithoutComment
+	txScriptWithComment := ConstExecCostTransaction(l.params.AuthAccountNum, commentSizeInByte)
+	tx = tx.SetScript(txScriptWithComment)
+
+	txSizeWithComment := uint(len(tx.Encode()))
+	log.Trace().Uint("Max Tx Size", l.params.MaxTxSizeInByte).
+		Uint("Actual Tx Size", txSizeWithComment).
+		Uint("Tx Arg Size", l.params.ArgSizeInByte).
+		Uint("Num of Authorizers", l.params.AuthAccountNum).
+		Uint("Num of payer keys", l.params.PayerKeyCount).
+		Uint("Script comment length", commentSizeInByte).
+		Msg("Generating one const-exec transaction")
+
+	return tx, nil
+}
+
+func (l *constExecLoad) Validate(result flowsdk.TransactionResult) error {
+	return validateExecuted(result)
+}
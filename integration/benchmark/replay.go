@@ -0,0 +1,137 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.uber.org/atomic"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go/cmd/util/cmd/export-json-transactions/transactions"
+)
+
+// defaultReplayGasLimit is the gas limit of replayed transactions which were recorded without one.
+const defaultReplayGasLimit = 9999
+
+// recordedTransaction is a transaction which can be replayed.
+type recordedTransaction struct {
+	script    []byte
+	arguments [][]byte
+	authorize bool // whether the sending account authorizes the transaction
+	gasLimit  uint64
+}
+
+// ReplayLoad replays recorded transaction scripts and arguments, as exported by the
+// export-json-transactions util command. The transactions are re-signed with the benchmark
+// accounts, which become proposer, payer and, if the recorded transaction had one, authorizer.
+//
+// Since the benchmark accounts can sign for a single authorizer only, recorded transactions with
+// more than one authorizer are skipped. The addresses of imported contracts can be replaced, to
+// replay transactions recorded on another network.
+type ReplayLoad struct {
+	transactions []recordedTransaction
+	next         *atomic.Uint64
+}
+
+var _ LoadType = (*ReplayLoad)(nil)
+
+// NewReplayLoad reads the recorded transactions from the given file, written by the
+// export-json-transactions util command. Every occurrence of a key of addresses in the
+// transaction scripts is replaced with the corresponding value.
+func NewReplayLoad(path string, addresses map[string]string) (*ReplayLoad, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read recorded transactions: %w", err)
+	}
+
+	var blocks []*transactions.BlockData
+	err = json.Unmarshal(data, &blocks)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode recorded transactions: %w", err)
+	}
+
+	return newReplayLoad(blocks, addresses)
+}
+
+func newReplayLoad(blocks []*transactions.BlockData, addresses map[string]string) (*ReplayLoad, error) {
+	replacements := make([]string, 0, 2*len(addresses))
+	for from, to := range addresses {
+		replacements = append(replacements, from, to)
+	}
+	replacer := strings.NewReplacer(replacements...)
+
+	recorded := make([]recordedTransaction, 0)
+	for _, block := range blocks {
+		for _, collection := range block.Collections {
+			for _, tx := range collection.Transactions {
+				if len(tx.Authorizers) > 1 {
+					continue
+				}
+
+				arguments := make([][]byte, 0, len(tx.Arguments))
+				for _, argument := range tx.Arguments {
+					arguments = append(arguments, []byte(argument))
+				}
+				gasLimit := tx.GasLimit
+				if gasLimit == 0 {
+					gasLimit = defaultReplayGasLimit
+				}
+
+				recorded = append(recorded, recordedTransaction{
+					script:    []byte(replacer.Replace(tx.Script)),
+					arguments: arguments,
+					authorize: len(tx.Authorizers) == 1,
+					gasLimit:  gasLimit,
+				})
+			}
+		}
+	}
+
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("no replayable transactions recorded, only transactions with at most one authorizer can be replayed")
+	}
+
+	return &ReplayLoad{
+		transactions: recorded,
+		next:         atomic.NewUint64(0),
+	}, nil
+}
+
+// Size returns the number of replayable transactions.
+func (l *ReplayLoad) Size() int {
+	return len(l.transactions)
+}
+
+func (l *ReplayLoad) Setup(*ContLoadGenerator) error {
+	return nil
+}
+
+// Transaction returns the next recorded transaction, the recording is replayed in a loop.
+func (l *ReplayLoad) Transaction(lg *ContLoadGenerator, acc *flowAccount) (*flowsdk.Transaction, error) {
+	recorded := l.transactions[(l.next.Inc()-1)%uint64(len(l.transactions))]
+
+	tx := flowsdk.NewTransaction().
+		SetReferenceBlockID(lg.follower.BlockID()).
+		SetScript(recorded.script).
+		SetGasLimit(recorded.gasLimit).
+		SetProposalKey(*acc.address, 0, acc.seqNumber).
+		SetPayer(*acc.address)
+	if recorded.authorize {
+		tx.AddAuthorizer(*acc.address)
+	}
+	for _, argument := range recorded.arguments {
+		tx.AddRawArgument(argument)
+	}
+
+	err := acc.signTx(tx, 0)
+	if err != nil {
+		return nil, fmt.Errorf("error signing transaction: %w", err)
+	}
+	return tx, nil
+}
+
+func (l *ReplayLoad) Validate(result flowsdk.TransactionResult) error {
+	return validateExecuted(result)
+}
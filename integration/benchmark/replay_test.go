@@ -0,0 +1,102 @@
+package benchmark
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/cmd/util/cmd/export-json-transactions/transactions"
+)
+
+func recordedBlocks() []*transactions.BlockData {
+	return []*transactions.BlockData{
+		{
+			Height: 1,
+			Collections: []*transactions.CollectionData{
+				{
+					Transactions: []*transactions.Transaction{
+						{
+							Script:      "import FungibleToken from 0xee82856bf20e2aa6\ntransaction(amount: UFix64) {}",
+							Arguments:   []string{`{"type":"UFix64","value":"1.00000000"}`},
+							Authorizers: []string{"0x01"},
+							GasLimit:    100,
+						},
+						{
+							Script:      "transaction {}",
+							Authorizers: []string{"0x01", "0x02"},
+							GasLimit:    100,
+						},
+					},
+				},
+			},
+		},
+		{
+			Height: 2,
+			Collections: []*transactions.CollectionData{
+				{
+					Transactions: []*transactions.Transaction{
+						{
+							Script: "transaction {}",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// TestNewReplayLoad tests that recorded transactions are filtered and prepared for replay.
+func TestNewReplayLoad(t *testing.T) {
+	t.Parallel()
+
+	t.Run("recorded transactions", func(t *testing.T) {
+		load, err := newReplayLoad(recordedBlocks(), map[string]string{"0xee82856bf20e2aa6": "0xf233dcee88fe0abe"})
+		require.NoError(t, err)
+
+		// the transaction with two authorizers is skipped
+		require.Equal(t, 2, load.Size())
+
+		assert.Equal(t, recordedTransaction{
+			script:    []byte("import FungibleToken from 0xf233dcee88fe0abe\ntransaction(amount: UFix64) {}"),
+			arguments: [][]byte{[]byte(`{"type":"UFix64","value":"1.00000000"}`)},
+			authorize: true,
+			gasLimit:  100,
+		}, load.transactions[0])
+
+		assert.Equal(t, recordedTransaction{
+			script:    []byte("transaction {}"),
+			arguments: [][]byte{},
+			authorize: false,
+			gasLimit:  defaultReplayGasLimit,
+		}, load.transactions[1])
+	})
+
+	t.Run("nothing to replay", func(t *testing.T) {
+		blocks := recordedBlocks()[:1]
+		blocks[0].Collections[0].Transactions = blocks[0].Collections[0].Transactions[1:]
+
+		_, err := newReplayLoad(blocks, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("from file", func(t *testing.T) {
+		data, err := json.Marshal(recordedBlocks())
+		require.NoError(t, err)
+
+		path := filepath.Join(t.TempDir(), "transactions.json")
+		require.NoError(t, os.WriteFile(path, data, 0644))
+
+		load, err := NewReplayLoad(path, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 2, load.Size())
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := NewReplayLoad(filepath.Join(t.TempDir(), "missing.json"), nil)
+		require.Error(t, err)
+	})
+}
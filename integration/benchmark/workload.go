@@ -0,0 +1,249 @@
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-yaml/yaml"
+	"github.com/rs/zerolog"
+)
+
+// rampInterval is the interval at which the TPS is adjusted while ramping.
+const rampInterval = time.Second
+
+// Workload is a declarative definition of a benchmark: a mix of load types, and a TPS profile.
+//
+// Example:
+//
+//	loads:
+//	  - type: token-transfer
+//	    weight: 3
+//	  - type: replay
+//	    weight: 1
+//	    replay_file: transactions.json
+//	    replay_addresses:
+//	      "0x1654653399040a61": "0x0ae53cb6e3f42a79"
+//	stages:
+//	  - tps: 10
+//	    duration: 1m
+//	  - tps: 100
+//	    duration: 5m
+//	    ramp: true
+type Workload struct {
+	Loads  []WorkloadLoad `yaml:"loads"`
+	Stages []Stage        `yaml:"stages"`
+}
+
+// WorkloadLoad is a load type of a workload. Each transaction is of a load type chosen at random,
+// with a probability proportional to the load type's weight.
+type WorkloadLoad struct {
+	Type   LoadTypeName `yaml:"type"`
+	Weight uint         `yaml:"weight"`
+
+	// ReplayFile is the file with the transactions to replay, only used by the replay load type.
+	// A relative path is relative to the workload file.
+	ReplayFile string `yaml:"replay_file"`
+	// ReplayAddresses replaces contract addresses in the replayed scripts, only used by the replay load type.
+	ReplayAddresses map[string]string `yaml:"replay_addresses"`
+}
+
+// Stage is a period of the benchmark with a target TPS.
+type Stage struct {
+	TPS uint `yaml:"tps"`
+	// Duration of the stage, 0 means forever.
+	Duration time.Duration `yaml:"duration"`
+	// Ramp linearly increases or decreases the TPS from the TPS of the previous stage to the TPS
+	// of this stage over the duration of the stage. The first stage ramps up from 0.
+	Ramp bool `yaml:"ramp"`
+}
+
+// LoadWorkload reads and validates a workload definition from a YAML file.
+func LoadWorkload(path string) (*Workload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read workload: %w", err)
+	}
+
+	workload, err := ParseWorkload(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// replay files are relative to the workload file
+	for i, load := range workload.Loads {
+		if load.ReplayFile != "" && !filepath.IsAbs(load.ReplayFile) {
+			workload.Loads[i].ReplayFile = filepath.Join(filepath.Dir(path), load.ReplayFile)
+		}
+	}
+	return workload, nil
+}
+
+// ParseWorkload decodes and validates a YAML workload definition.
+func ParseWorkload(data []byte) (*Workload, error) {
+	var workload Workload
+	err := yaml.UnmarshalStrict(data, &workload)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode workload: %w", err)
+	}
+
+	err = workload.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid workload: %w", err)
+	}
+	return &workload, nil
+}
+
+func (w *Workload) validate() error {
+	if len(w.Loads) == 0 {
+		return fmt.Errorf("no loads defined")
+	}
+	for _, load := range w.Loads {
+		if load.Weight == 0 {
+			return fmt.Errorf("load %s must have a positive weight", load.Type)
+		}
+		if (load.Type == ReplayLoadType) != (load.ReplayFile != "") {
+			return fmt.Errorf("load %s: a replay file must be given for, and only for, the replay load type", load.Type)
+		}
+	}
+
+	if len(w.Stages) == 0 {
+		return fmt.Errorf("no stages defined")
+	}
+	for i, stage := range w.Stages {
+		if stage.Duration == 0 && i != len(w.Stages)-1 {
+			return fmt.Errorf("only the last stage can run forever")
+		}
+		if stage.Ramp && stage.Duration == 0 {
+			return fmt.Errorf("ramping stages must have a duration")
+		}
+	}
+	return nil
+}
+
+// MaxTPS returns the highest TPS of all stages.
+func (w *Workload) MaxTPS() uint {
+	var max uint
+	for _, stage := range w.Stages {
+		if stage.TPS > max {
+			max = stage.TPS
+		}
+	}
+	return max
+}
+
+// loadTypes creates the load types of the workload.
+func (w *Workload) loadTypes(loadParams LoadParams, constExecParams ConstExecParams) ([]weightedLoad, error) {
+	loads := make([]weightedLoad, 0, len(w.Loads))
+	for _, load := range w.Loads {
+		var loadType LoadType
+		var err error
+		if load.Type == ReplayLoadType {
+			loadType, err = NewReplayLoad(load.ReplayFile, load.ReplayAddresses)
+		} else {
+			loadType, err = newLoadType(load.Type, loadParams, constExecParams)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not create load %s: %w", load.Type, err)
+		}
+		loads = append(loads, weightedLoad{name: load.Type, load: loadType, weight: load.Weight})
+	}
+	return loads, nil
+}
+
+// weightedLoad is a load type with the weight of its share of all transactions.
+type weightedLoad struct {
+	name   LoadTypeName
+	load   LoadType
+	weight uint
+}
+
+// pickLoad chooses one of the loads at random, with a probability proportional to its weight.
+func pickLoad(loads []weightedLoad, rnd func(n int) int) weightedLoad {
+	if len(loads) == 1 {
+		return loads[0]
+	}
+
+	total := uint(0)
+	for _, load := range loads {
+		total += load.weight
+	}
+
+	n := uint(rnd(int(total)))
+	for _, load := range loads {
+		if n < load.weight {
+			return load
+		}
+		n -= load.weight
+	}
+	return loads[len(loads)-1]
+}
+
+// stageTPS returns the TPS at the given time since the start of a stage, given the TPS of the
+// previous stage.
+func stageTPS(stage Stage, previousTPS uint, elapsed time.Duration) uint {
+	if !stage.Ramp || elapsed >= stage.Duration {
+		return stage.TPS
+	}
+
+	progress := float64(elapsed) / float64(stage.Duration)
+	return uint(float64(previousTPS) + progress*(float64(stage.TPS)-float64(previousTPS)) + 0.5)
+}
+
+// RunStages runs the stages of the workload on the given load generator, and returns once the
+// last stage is finished, or the context is cancelled.
+func (w *Workload) RunStages(ctx context.Context, log zerolog.Logger, lg *ContLoadGenerator) error {
+	previousTPS := uint(0)
+	for i, stage := range w.Stages {
+		log.Info().
+			Int("number", i).
+			Uint("tps", stage.TPS).
+			Dur("duration", stage.Duration).
+			Bool("ramp", stage.Ramp).
+			Msg("running stage")
+
+		err := runStage(ctx, lg, stage, previousTPS)
+		if err != nil {
+			return err
+		}
+		previousTPS = stage.TPS
+	}
+	return nil
+}
+
+func runStage(ctx context.Context, lg *ContLoadGenerator, stage Stage, previousTPS uint) error {
+	start := time.Now()
+	err := lg.SetTPS(stageTPS(stage, previousTPS, 0))
+	if err != nil {
+		return fmt.Errorf("unable to set tps: %w", err)
+	}
+
+	// if the duration is 0, the stage runs forever
+	var done <-chan time.Time
+	if stage.Duration != 0 {
+		done = time.After(stage.Duration)
+	}
+
+	var ramp <-chan time.Time
+	if stage.Ramp {
+		ticker := time.NewTicker(rampInterval)
+		defer ticker.Stop()
+		ramp = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-done:
+			return lg.SetTPS(stage.TPS)
+		case <-ramp:
+			err := lg.SetTPS(stageTPS(stage, previousTPS, time.Since(start)))
+			if err != nil {
+				return fmt.Errorf("unable to set tps: %w", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package benchmark
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestParseWorkload tests that workload definitions are decoded and validated.
+func TestParseWorkload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		workload, err := ParseWorkload([]byte(`
+loads:
+  - type: token-transfer
+    weight: 3
+  - type: replay
+    weight: 1
+    replay_file: transactions.json
+    replay_addresses:
+      "0x1654653399040a61": "0x0ae53cb6e3f42a79"
+stages:
+  - tps: 10
+    duration: 1m
+  - tps: 100
+    duration: 5m
+    ramp: true
+  - tps: 50
+`))
+		require.NoError(t, err)
+
+		require.Len(t, workload.Loads, 2)
+		assert.Equal(t, TokenTransferLoadType, workload.Loads[0].Type)
+		assert.Equal(t, uint(3), workload.Loads[0].Weight)
+		assert.Equal(t, ReplayLoadType, workload.Loads[1].Type)
+		assert.Equal(t, "transactions.json", workload.Loads[1].ReplayFile)
+		assert.Equal(t, map[string]string{"0x1654653399040a61": "0x0ae53cb6e3f42a79"}, workload.Loads[1].ReplayAddresses)
+
+		assert.Equal(t, []Stage{
+			{TPS: 10, Duration: time.Minute},
+			{TPS: 100, Duration: 5 * time.Minute, Ramp: true},
+			{TPS: 50},
+		}, workload.Stages)
+		assert.Equal(t, uint(100), workload.MaxTPS())
+	})
+
+	invalid := map[string]string{
+		"unknown field": `
+loads:
+  - type: token-transfer
+    weight: 1
+    color: blue
+stages:
+  - tps: 10
+`,
+		"no loads": `
+stages:
+  - tps: 10
+`,
+		"zero weight": `
+loads:
+  - type: token-transfer
+stages:
+  - tps: 10
+`,
+		"replay without file": `
+loads:
+  - type: replay
+    weight: 1
+stages:
+  - tps: 10
+`,
+		"file without replay": `
+loads:
+  - type: token-transfer
+    weight: 1
+    replay_file: transactions.json
+stages:
+  - tps: 10
+`,
+		"no stages": `
+loads:
+  - type: token-transfer
+    weight: 1
+`,
+		"endless stage before last": `
+loads:
+  - type: token-transfer
+    weight: 1
+stages:
+  - tps: 10
+  - tps: 20
+    duration: 1m
+`,
+		"endless ramp": `
+loads:
+  - type: token-transfer
+    weight: 1
+stages:
+  - tps: 10
+    ramp: true
+`,
+	}
+	for name, data := range invalid {
+		data := data
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseWorkload([]byte(data))
+			require.Error(t, err)
+		})
+	}
+}
+
+// TestLoadWorkload tests that relative replay files are resolved relative to the workload file.
+func TestLoadWorkload(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "workload.yml")
+	err := os.WriteFile(path, []byte(`
+loads:
+  - type: replay
+    weight: 1
+    replay_file: transactions.json
+  - type: replay
+    weight: 1
+    replay_file: /tmp/transactions.json
+stages:
+  - tps: 10
+`), 0644)
+	require.NoError(t, err)
+
+	workload, err := LoadWorkload(path)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "transactions.json"), workload.Loads[0].ReplayFile)
+	assert.Equal(t, "/tmp/transactions.json", workload.Loads[1].ReplayFile)
+}
+
+// TestPickLoad tests that loads are picked proportionally to their weights.
+func TestPickLoad(t *testing.T) {
+	t.Parallel()
+
+	loads := []weightedLoad{
+		{name: TokenTransferLoadType, weight: 3},
+		{name: TokenAddKeysLoadType, weight: 1},
+		{name: CompHeavyLoadType, weight: 2},
+	}
+
+	// iterate over all random values, so that each load is picked exactly its weight times
+	picked := make(map[LoadTypeName]uint)
+	for i := 0; i < 6; i++ {
+		load := pickLoad(loads, func(n int) int {
+			require.Equal(t, 6, n)
+			return i
+		})
+		picked[load.name]++
+	}
+	assert.Equal(t, map[LoadTypeName]uint{
+		TokenTransferLoadType: 3,
+		TokenAddKeysLoadType:  1,
+		CompHeavyLoadType:     2,
+	}, picked)
+
+	// a single load is always picked, without consulting the randomness
+	load := pickLoad(loads[:1], func(int) int {
+		require.Fail(t, "unexpected call")
+		return 0
+	})
+	assert.Equal(t, TokenTransferLoadType, load.name)
+}
+
+// TestStageTPS tests the TPS of ramping and constant stages.
+func TestStageTPS(t *testing.T) {
+	t.Parallel()
+
+	constant := Stage{TPS: 100, Duration: time.Minute}
+	assert.Equal(t, uint(100), stageTPS(constant, 10, 0))
+	assert.Equal(t, uint(100), stageTPS(constant, 10, 30*time.Second))
+
+	up := Stage{TPS: 100, Duration: 10 * time.Second, Ramp: true}
+	assert.Equal(t, uint(10), stageTPS(up, 10, 0))
+	assert.Equal(t, uint(55), stageTPS(up, 10, 5*time.Second))
+	assert.Equal(t, uint(100), stageTPS(up, 10, 10*time.Second))
+	assert.Equal(t, uint(100), stageTPS(up, 10, time.Minute))
+
+	down := Stage{TPS: 0, Duration: 10 * time.Second, Ramp: true}
+	assert.Equal(t, uint(100), stageTPS(down, 100, 0))
+	assert.Equal(t, uint(75), stageTPS(down, 100, 2500*time.Millisecond))
+	assert.Equal(t, uint(0), stageTPS(down, 100, 10*time.Second))
+}
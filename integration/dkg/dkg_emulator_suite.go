@@ -472,6 +472,7 @@ func (s *DKGSuite) initEngines(node *node, ids flow.IdentityList) {
 			core.Me,
 			[]module.DKGContractClient{node.dkgContractClient},
 			brokerTunnel,
+			dkgState,
 			config,
 		),
 		viewsObserver,
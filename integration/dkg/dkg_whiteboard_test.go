@@ -153,6 +153,7 @@ func createNode(
 			core.Me,
 			[]module.DKGContractClient{NewWhiteboardClient(id.NodeID, whiteboard)},
 			brokerTunnel,
+			dkgState,
 			config,
 		),
 		viewsObserver,
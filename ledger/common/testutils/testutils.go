@@ -5,10 +5,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/rand"
+	"sync"
 
 	l "github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/hash"
 	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 )
 
 // TrieUpdateFixture returns a trie update fixture
@@ -234,3 +236,109 @@ func RandomUniqueKeys(n, m, minByteSize, maxByteSize int) []l.Key {
 	}
 	return keys
 }
+
+// MemoryPayloadStore is an in-memory payload store for tests, keeping track of how often payloads
+// were stored and loaded, and how many batches were flushed.
+type MemoryPayloadStore struct {
+	mu         sync.Mutex
+	payloads   map[hash.Hash]*l.Payload
+	checkpoint *node.CheckpointRecord
+	Stores     int
+	Loads      int
+	Flushes    int
+}
+
+// NewMemoryPayloadStore returns a new empty in-memory payload store.
+func NewMemoryPayloadStore() *MemoryPayloadStore {
+	return &MemoryPayloadStore{payloads: make(map[hash.Hash]*l.Payload)}
+}
+
+// NewBatch returns a new batch for writing payloads to the store.
+func (s *MemoryPayloadStore) NewBatch() node.PayloadBatch {
+	return &memoryPayloadBatch{store: s, payloads: make(map[hash.Hash]*l.Payload)}
+}
+
+// Load returns the payload of the leaf with the given hash.
+func (s *MemoryPayloadStore) Load(leafHash hash.Hash) (*l.Payload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	payload, ok := s.payloads[leafHash]
+	if !ok {
+		return nil, fmt.Errorf("payload of leaf %s not found", leafHash)
+	}
+	s.Loads++
+	return payload, nil
+}
+
+// CollectGarbage deletes all payloads which are not live.
+func (s *MemoryPayloadStore) CollectGarbage(isLive func(leafHash hash.Hash) bool) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	deleted := 0
+	for leafHash := range s.payloads {
+		if !isLive(leafHash) {
+			delete(s.payloads, leafHash)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// Checkpoint returns the recorded checkpoint, or nil if none was recorded.
+func (s *MemoryPayloadStore) Checkpoint() (*node.CheckpointRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.checkpoint, nil
+}
+
+// SetCheckpoint records the given checkpoint.
+func (s *MemoryPayloadStore) SetCheckpoint(checkpoint *node.CheckpointRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkpoint = checkpoint
+	return nil
+}
+
+// Size returns the number of stored payloads.
+func (s *MemoryPayloadStore) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.payloads)
+}
+
+// memoryPayloadBatch collects payloads written to a MemoryPayloadStore on flush.
+type memoryPayloadBatch struct {
+	store    *MemoryPayloadStore
+	payloads map[hash.Hash]*l.Payload
+}
+
+func (b *memoryPayloadBatch) Add(leafHash hash.Hash, payload *l.Payload) error {
+	b.payloads[leafHash] = payload
+	return nil
+}
+
+func (b *memoryPayloadBatch) AddEncoded(leafHash hash.Hash, encodedPayload []byte) error {
+	// checkpoint files encode payloads with the current payload version
+	payload, err := l.DecodePayloadWithoutPrefix(encodedPayload, false, l.PayloadVersion)
+	if err != nil {
+		return err
+	}
+	b.payloads[leafHash] = payload
+	return nil
+}
+
+func (b *memoryPayloadBatch) Flush() error {
+	b.store.mu.Lock()
+	defer b.store.mu.Unlock()
+	for leafHash, payload := range b.payloads {
+		b.store.payloads[leafHash] = payload
+	}
+	b.store.Stores += len(b.payloads)
+	b.store.Flushes++
+	b.payloads = nil
+	return nil
+}
+
+func (b *memoryPayloadBatch) Cancel() {
+	b.payloads = nil
+}
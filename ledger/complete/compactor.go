@@ -46,6 +46,7 @@ type checkpointResult struct {
 // This will be resolved automaticaly after the forest LRU Cache
 // (code outside checkpointing) is replaced by something like a FIFO queue.
 type Compactor struct {
+	ledger                               *Ledger
 	checkpointer                         *realWAL.Checkpointer
 	wal                                  realWAL.LedgerWAL
 	trieQueue                            *realWAL.TrieQueue
@@ -103,6 +104,7 @@ func NewCompactor(
 	trieQueue := realWAL.NewTrieQueueWithValues(checkpointCapacity, tries)
 
 	return &Compactor{
+		ledger:                               l,
 		checkpointer:                         checkpointer,
 		wal:                                  w,
 		trieQueue:                            trieQueue,
@@ -305,6 +307,16 @@ func (c *Compactor) checkpoint(ctx context.Context, tries []*trie.MTrie, checkpo
 		return &removeCheckpointError{err: err}
 	}
 
+	// Paged out payloads are only needed as long as their tries are part of the forest, or of the
+	// checkpoint which is loaded on restart.
+	// Failing to collect garbage only delays the deletion of payloads, so it is not an error.
+	deleted, err := c.ledger.CollectPayloadGarbage(realWAL.NumberToFilename(checkpointNum), tries)
+	if err != nil {
+		c.logger.Warn().Err(err).Msgf("failed to collect garbage payloads after checkpoint %d", checkpointNum)
+	} else if deleted > 0 {
+		c.logger.Info().Int("deleted", deleted).Msgf("collected garbage payloads after checkpoint %d", checkpointNum)
+	}
+
 	if checkpointNum > 0 {
 		for observer := range c.observers {
 			// Don't notify observer if context is canceled.
@@ -48,11 +48,12 @@ func NewLedger(
 	capacity int,
 	metrics module.LedgerMetrics,
 	log zerolog.Logger,
-	pathFinderVer uint8) (*Ledger, error) {
+	pathFinderVer uint8,
+	forestOpts ...mtrie.ForestOption) (*Ledger, error) {
 
 	logger := log.With().Str("ledger_mod", "complete").Logger()
 
-	forest, err := mtrie.NewForest(capacity, metrics, nil)
+	forest, err := mtrie.NewForest(capacity, metrics, nil, forestOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create forest: %w", err)
 	}
@@ -263,6 +264,7 @@ func (l *Ledger) set(trieUpdate *ledger.TrieUpdate) (newState ledger.State, err
 		return ledger.State(hash.DummyHash), fmt.Errorf("cannot update state: %w", err)
 	}
 	if walError != nil {
+		l.forest.DiscardTrie(newTrie)
 		return ledger.State(hash.DummyHash), fmt.Errorf("error while writing LedgerWAL: %w", walError)
 	}
 
@@ -319,6 +321,22 @@ func (l *Ledger) Tries() ([]*trie.MTrie, error) {
 	return l.forest.GetTries()
 }
 
+// Trie returns the trie with the given root hash stored in the forest
+// warning, use this function for read-only operation
+func (l *Ledger) Trie(rootHash ledger.RootHash) (*trie.MTrie, error) {
+	return l.forest.GetTrie(rootHash)
+}
+
+// CollectPayloadGarbage deletes the paged out payloads which are referenced neither by a trie
+// stored in the forest nor by the given tries of the most recent checkpoint, and returns the
+// number of deleted payloads. The payload store keeps the payloads of the checkpoint, so loading
+// it on restart doesn't require writing its payloads again.
+// It is a no-op if the forest keeps all payloads in memory.
+// No errors are expected during normal operation.
+func (l *Ledger) CollectPayloadGarbage(checkpointFileName string, checkpointTries []*trie.MTrie) (int, error) {
+	return l.forest.CollectPayloadGarbage(checkpointFileName, checkpointTries)
+}
+
 // Checkpointer returns a checkpointer instance
 func (l *Ledger) Checkpointer() (*realWAL.Checkpointer, error) {
 	checkpointer, err := l.wal.NewCheckpointer()
@@ -375,7 +393,10 @@ func (l *Ledger) ExportCheckpointAt(
 		// preCheckpointReporters, which doesn't use the payloads.
 	} else {
 		// get all payloads
-		payloads = t.AllPayloads()
+		payloads, err = t.AllPayloads()
+		if err != nil {
+			return ledger.State(hash.DummyHash), fmt.Errorf("failed to get payloads: %w", err)
+		}
 		payloadSize := len(payloads)
 
 		// migrate payloads
@@ -467,7 +488,10 @@ func (l *Ledger) ExportCheckpointAt(
 	if noMigration {
 		// when there is no mgiration, we generate the payloads now before
 		// running the postCheckpointReporters
-		payloads = newTrie.AllPayloads()
+		payloads, err = newTrie.AllPayloads()
+		if err != nil {
+			return ledger.State(hash.DummyHash), fmt.Errorf("failed to get payloads: %w", err)
+		}
 	}
 
 	// running post checkpoint reporters
@@ -31,7 +31,8 @@ const (
 	encodedTrieSize = encNodeIndexSize + encRegCountSize + encRegSizeSize + encHashSize
 )
 
-const payloadEncodingVersion = 1
+// PayloadEncodingVersion is the version of the payload encoding used for leaf nodes.
+const PayloadEncodingVersion = 1
 
 // encodeLeafNode encodes leaf node in the following format:
 // - node type (1 byte)
@@ -47,9 +48,14 @@ const payloadEncodingVersion = 1
 // WARNING: The returned buffer is likely to share the same underlying array as
 // the scratch buffer. Caller is responsible for copying or using returned buffer
 // before scratch buffer is used again.
-func encodeLeafNode(n *node.Node, scratch []byte) []byte {
+func encodeLeafNode(n *node.Node, scratch []byte) ([]byte, error) {
 
-	encPayloadSize := ledger.EncodedPayloadLengthWithoutPrefix(n.Payload(), payloadEncodingVersion)
+	payload, err := n.Payload()
+	if err != nil {
+		return nil, fmt.Errorf("could not get payload of leaf node: %w", err)
+	}
+
+	encPayloadSize := ledger.EncodedPayloadLengthWithoutPrefix(payload, PayloadEncodingVersion)
 
 	encodedNodeSize := encNodeTypeSize +
 		encHeightSize +
@@ -93,9 +99,9 @@ func encodeLeafNode(n *node.Node, scratch []byte) []byte {
 
 	// EncodeAndAppendPayloadWithoutPrefix appends encoded payload to the resliced buf.
 	// Returned buf is resliced to include appended payload.
-	buf = ledger.EncodeAndAppendPayloadWithoutPrefix(buf[:pos], n.Payload(), payloadEncodingVersion)
+	buf = ledger.EncodeAndAppendPayloadWithoutPrefix(buf[:pos], payload, PayloadEncodingVersion)
 
-	return buf
+	return buf, nil
 }
 
 // encodeInterimNode encodes interim node in the following format:
@@ -159,11 +165,13 @@ func encodeInterimNode(n *node.Node, lchildIndex uint64, rchildIndex uint64, scr
 // WARNING: The returned buffer is likely to share the same underlying array as
 // the scratch buffer. Caller is responsible for copying or using returned buffer
 // before scratch buffer is used again.
-func EncodeNode(n *node.Node, lchildIndex uint64, rchildIndex uint64, scratch []byte) []byte {
+// No errors are expected during normal operation, errors can only occur if the payload of
+// a leaf was paged out and can't be loaded (see node.Node.Payload).
+func EncodeNode(n *node.Node, lchildIndex uint64, rchildIndex uint64, scratch []byte) ([]byte, error) {
 	if n.IsLeaf() {
 		return encodeLeafNode(n, scratch)
 	}
-	return encodeInterimNode(n, lchildIndex, rchildIndex, scratch)
+	return encodeInterimNode(n, lchildIndex, rchildIndex, scratch), nil
 }
 
 // ReadNode reconstructs a node from data read from reader.
@@ -172,6 +180,34 @@ func EncodeNode(n *node.Node, lchildIndex uint64, rchildIndex uint64, scratch []
 // so any extra capacity will not be utilized.
 // If len(scratch) < 1024, then a new buffer will be allocated and used.
 func ReadNode(reader io.Reader, scratch []byte, getNode func(nodeIndex uint64) (*node.Node, error)) (*node.Node, error) {
+	return readNode(reader, scratch, getNode, nil, nil)
+}
+
+// ReadNodeToPayloadStore reconstructs a node from data read from reader, like ReadNode.
+// The payload of a leaf node is not decoded, but added to the given batch as encoded in the
+// checkpoint, and the returned leaf references it in the given store. Hence, the payload can
+// only be read once the batch was flushed. If batch is nil, the payload is skipped, as it is
+// expected to be stored already. If store is nil, it behaves like ReadNode.
+func ReadNodeToPayloadStore(
+	reader io.Reader,
+	scratch []byte,
+	getNode func(nodeIndex uint64) (*node.Node, error),
+	store node.PayloadStore,
+	batch node.PayloadBatch,
+) (*node.Node, error) {
+	return readNode(reader, scratch, getNode, store, batch)
+}
+
+// readNode reconstructs a node from data read from reader. If a payload store is given, the
+// leaf node references its payload in the store, and the payload is added to the given batch
+// unless the batch is nil.
+func readNode(
+	reader io.Reader,
+	scratch []byte,
+	getNode func(nodeIndex uint64) (*node.Node, error),
+	store node.PayloadStore,
+	batch node.PayloadBatch,
+) (*node.Node, error) {
 
 	// minBufSize should be large enough for interim node and leaf node with small payload.
 	// minBufSize is a failsafe and is only used when len(scratch) is much smaller
@@ -225,6 +261,21 @@ func ReadNode(reader io.Reader, scratch []byte, getNode func(nodeIndex uint64) (
 			return nil, fmt.Errorf("failed to decode path of serialized node: %w", err)
 		}
 
+		if store != nil {
+			// Read encoded payload data and page it out without decoding.
+			encPayload, err := readEncodedPayloadFromReader(reader, scratch)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read payload of serialized node: %w", err)
+			}
+			if batch != nil {
+				err = batch.AddEncoded(nodeHash, encPayload)
+				if err != nil {
+					return nil, fmt.Errorf("failed to page out payload of serialized node: %w", err)
+				}
+			}
+			return node.NewPagedLeaf(int(height), path, nodeHash, store), nil
+		}
+
 		// Read encoded payload data and create ledger.Payload.
 		payload, err := readPayloadFromReader(reader, scratch)
 		if err != nil {
@@ -360,6 +411,25 @@ func ReadTrie(reader io.Reader, scratch []byte, getNode func(nodeIndex uint64) (
 // Returned payload is a copy.
 func readPayloadFromReader(reader io.Reader, scratch []byte) (*ledger.Payload, error) {
 
+	encPayload, err := readEncodedPayloadFromReader(reader, scratch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode and copy payload
+	payload, err := ledger.DecodePayloadWithoutPrefix(encPayload, false, PayloadEncodingVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	return payload, nil
+}
+
+// readEncodedPayloadFromReader reads the encoded payload from reader.
+// WARNING: The returned buffer is likely to share the same underlying array as
+// the scratch buffer.
+func readEncodedPayloadFromReader(reader io.Reader, scratch []byte) ([]byte, error) {
+
 	if len(scratch) < encPayloadLengthSize {
 		scratch = make([]byte, encPayloadLengthSize)
 	}
@@ -384,11 +454,5 @@ func readPayloadFromReader(reader io.Reader, scratch []byte) (*ledger.Payload, e
 		return nil, fmt.Errorf("cannot read payload: %w", err)
 	}
 
-	// Decode and copy payload
-	payload, err := ledger.DecodePayloadWithoutPrefix(scratch, false, payloadEncodingVersion)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode payload: %w", err)
-	}
-
-	return payload, nil
+	return scratch, nil
 }
@@ -106,7 +106,8 @@ func TestLeafNodeEncodingDecoding(t *testing.T) {
 			}
 
 			for _, scratch := range scratchBuffers {
-				encodedNode := flattener.EncodeNode(tc.node, 0, 0, scratch)
+				encodedNode, err := flattener.EncodeNode(tc.node, 0, 0, scratch)
+				require.NoError(t, err)
 				assert.Equal(t, tc.encodedNode, encodedNode)
 
 				if len(scratch) > 0 {
@@ -164,7 +165,8 @@ func TestRandomLeafNodeEncodingDecoding(t *testing.T) {
 
 		n := node.NewNode(height, nil, nil, paths[i], payloads[i], hashValue)
 
-		encodedNode := flattener.EncodeNode(n, 0, 0, writeScratch)
+		encodedNode, err := flattener.EncodeNode(n, 0, 0, writeScratch)
+		require.NoError(t, err)
 
 		if len(writeScratch) >= len(encodedNode) {
 			// reuse scratch buffer
@@ -225,7 +227,8 @@ func TestInterimNodeEncodingDecoding(t *testing.T) {
 		}
 
 		for _, scratch := range scratchBuffers {
-			data := flattener.EncodeNode(interimNode, lchildIndex, rchildIndex, scratch)
+			data, err := flattener.EncodeNode(interimNode, lchildIndex, rchildIndex, scratch)
+			require.NoError(t, err)
 			assert.Equal(t, encodedInterimNode, data)
 		}
 	})
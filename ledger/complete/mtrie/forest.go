@@ -2,9 +2,11 @@ package mtrie
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
 	"github.com/onflow/flow-go/module"
 )
@@ -28,6 +30,32 @@ type Forest struct {
 	forestCapacity int
 	onTreeEvicted  func(tree *trie.MTrie)
 	metrics        module.LedgerMetrics
+
+	// payloadStore is the store leaf payloads are paged out to, nil if all payloads are
+	// kept in memory.
+	payloadStore node.PayloadStore
+
+	// updateLock is held for reading while tries are constructed and added to the forest, and
+	// for writing while the live payloads are determined by CollectPayloadGarbage. Hence, the
+	// collection sees the parents of all tries which are being constructed.
+	updateLock sync.RWMutex
+	// pinnedLock protects pinned.
+	pinnedLock sync.Mutex
+	// pinned holds the tries created by NewTrie which were not added to the forest yet. Their
+	// payloads are live, even if their parents are evicted from the forest in the meantime.
+	pinned map[*trie.MTrie]int
+}
+
+// ForestOption configures optional behaviour of the Forest.
+type ForestOption func(*Forest)
+
+// WithPayloadStore makes the Forest page out the payloads of all leaves to the given store,
+// keeping only the interim nodes and the leaves' paths and hashes in memory. Payloads are
+// transparently loaded from the store when the tries are read or updated.
+func WithPayloadStore(store node.PayloadStore) ForestOption {
+	return func(f *Forest) {
+		f.payloadStore = store
+	}
 }
 
 // NewForest returns a new instance of memory forest.
@@ -36,11 +64,15 @@ type Forest struct {
 // If more tries are added than the capacity, the Least Recently Added trie is removed (evicted) from the Forest (FIFO queue).
 // Make sure you chose a sufficiently large forestCapacity, such that, when reaching the capacity, the
 // Least Recently Added trie will never be needed again.
-func NewForest(forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie)) (*Forest, error) {
+func NewForest(forestCapacity int, metrics module.LedgerMetrics, onTreeEvicted func(tree *trie.MTrie), opts ...ForestOption) (*Forest, error) {
 	forest := &Forest{tries: NewTrieCache(uint(forestCapacity), onTreeEvicted),
 		forestCapacity: forestCapacity,
 		onTreeEvicted:  onTreeEvicted,
 		metrics:        metrics,
+		pinned:         make(map[*trie.MTrie]int),
+	}
+	for _, opt := range opts {
+		opt(forest)
 	}
 
 	// add trie with no allocated registers
@@ -82,7 +114,10 @@ func (f *Forest) ValueSizes(r *ledger.TrieRead) ([]int, error) {
 		pathOrgIndex[path] = append(indices, i)
 	}
 
-	sizes := trie.UnsafeValueSizes(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	sizes, err := trie.UnsafeValueSizes(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	if err != nil {
+		return nil, fmt.Errorf("could not read value sizes: %w", err)
+	}
 
 	// reconstruct value sizes in the same key order that called the method
 	orderedValueSizes := make([]int, len(r.Paths))
@@ -109,7 +144,10 @@ func (f *Forest) ReadSingleValue(r *ledger.TrieReadSingleValue) (ledger.Value, e
 		return nil, err
 	}
 
-	payload := trie.ReadSinglePayload(r.Path)
+	payload, err := trie.ReadSinglePayload(r.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read payload: %w", err)
+	}
 	return payload.Value().DeepCopy(), nil
 }
 
@@ -129,7 +167,10 @@ func (f *Forest) Read(r *ledger.TrieRead) ([]ledger.Value, error) {
 
 	// call ReadSinglePayload if there is only one path
 	if len(r.Paths) == 1 {
-		payload := trie.ReadSinglePayload(r.Paths[0])
+		payload, err := trie.ReadSinglePayload(r.Paths[0])
+		if err != nil {
+			return nil, fmt.Errorf("could not read payload: %w", err)
+		}
 		return []ledger.Value{payload.Value().DeepCopy()}, nil
 	}
 
@@ -149,7 +190,10 @@ func (f *Forest) Read(r *ledger.TrieRead) ([]ledger.Value, error) {
 		pathOrgIndex[path] = append(indices, i)
 	}
 
-	payloads := trie.UnsafeRead(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	payloads, err := trie.UnsafeRead(deduplicatedPaths) // this sorts deduplicatedPaths IN-PLACE
+	if err != nil {
+		return nil, fmt.Errorf("could not read payloads: %w", err)
+	}
 
 	// reconstruct the payloads in the same key order that called the method
 	orderedValues := make([]ledger.Value, len(r.Paths))
@@ -179,10 +223,10 @@ func (f *Forest) Update(u *ledger.TrieUpdate) (ledger.RootHash, error) {
 		return ledger.RootHash(hash.DummyHash), err
 	}
 
-	err = f.AddTrie(t)
-	if err != nil {
-		return ledger.RootHash(hash.DummyHash), fmt.Errorf("adding updated trie to forest failed: %w", err)
-	}
+	// the payloads of the new trie were already paged out by NewTrie
+	f.updateLock.RLock()
+	f.addTrie(t)
+	f.updateLock.RUnlock()
 
 	return t.RootHash(), nil
 }
@@ -191,8 +235,12 @@ func (f *Forest) Update(u *ledger.TrieUpdate) (ledger.RootHash, error) {
 // and returns new trie and error (if any).
 // In case there are multiple updates to the same register, NewTrie will persist
 // the latest written value.
-// Note: NewTrie doesn't add new trie to forest, unlike Update().
+// Note: NewTrie doesn't add new trie to forest, unlike Update(). If the forest pages out
+// payloads, the new trie's payloads are kept until it is added by AddTrie or discarded by
+// DiscardTrie.
 func (f *Forest) NewTrie(u *ledger.TrieUpdate) (*trie.MTrie, error) {
+	f.updateLock.RLock()
+	defer f.updateLock.RUnlock()
 
 	parentTrie, err := f.GetTrie(u.RootHash)
 	if err != nil {
@@ -200,6 +248,7 @@ func (f *Forest) NewTrie(u *ledger.TrieUpdate) (*trie.MTrie, error) {
 	}
 
 	if len(u.Paths) == 0 { // no key no change
+		f.pin(parentTrie)
 		return parentTrie, nil
 	}
 
@@ -242,9 +291,58 @@ func (f *Forest) NewTrie(u *ledger.TrieUpdate) (*trie.MTrie, error) {
 	f.metrics.LatestTrieRegSizeDiff(int64(newTrie.AllocatedRegSize() - parentTrie.AllocatedRegSize()))
 	f.metrics.LatestTrieMaxDepthTouched(maxDepthTouched)
 
+	if f.payloadStore != nil {
+		// only the leaves which are not shared with the parent trie have to be paged out
+		err = newTrie.PageOutPayloads(parentTrie, f.payloadStore)
+		if err != nil {
+			return nil, fmt.Errorf("paging out payloads of updated trie failed: %w", err)
+		}
+	}
+
+	f.pin(newTrie)
 	return newTrie, nil
 }
 
+// DiscardTrie releases a trie created by NewTrie, which is not going to be added to the forest.
+func (f *Forest) DiscardTrie(t *trie.MTrie) {
+	f.unpin(t)
+}
+
+// pin keeps the payloads of the given trie, which is not part of the forest yet, live.
+func (f *Forest) pin(t *trie.MTrie) {
+	if f.payloadStore == nil {
+		return
+	}
+	f.pinnedLock.Lock()
+	defer f.pinnedLock.Unlock()
+	f.pinned[t]++
+}
+
+// unpin releases a trie pinned by pin.
+func (f *Forest) unpin(t *trie.MTrie) {
+	if f.payloadStore == nil {
+		return
+	}
+	f.pinnedLock.Lock()
+	defer f.pinnedLock.Unlock()
+	if f.pinned[t] <= 1 {
+		delete(f.pinned, t)
+		return
+	}
+	f.pinned[t]--
+}
+
+// pinnedTries returns the tries pinned by pin.
+func (f *Forest) pinnedTries() []*trie.MTrie {
+	f.pinnedLock.Lock()
+	defer f.pinnedLock.Unlock()
+	tries := make([]*trie.MTrie, 0, len(f.pinned))
+	for t := range f.pinned {
+		tries = append(tries, t)
+	}
+	return tries
+}
+
 // Proofs returns a batch proof for the given paths.
 //
 // Proves are generally _not_ provided in the register order of the query.
@@ -297,7 +395,10 @@ func (f *Forest) Proofs(r *ledger.TrieRead) (*ledger.TrieBatchProof, error) {
 		stateTrie = newTrie
 	}
 
-	bp := stateTrie.UnsafeProofs(r.Paths)
+	bp, err := stateTrie.UnsafeProofs(r.Paths)
+	if err != nil {
+		return nil, fmt.Errorf("could not create proofs: %w", err)
+	}
 	return bp, nil
 }
 
@@ -333,22 +434,133 @@ func (f *Forest) AddTries(newTries []*trie.MTrie) error {
 	return nil
 }
 
-// AddTrie adds a trie to the forest
+// AddTrie adds a trie to the forest.
+// If the forest pages out payloads, the payloads of the given trie are paged out, unless the
+// trie is already part of the forest or its payloads were paged out already (e.g. for tries
+// created by NewTrie). Hence, the trie must not be accessed concurrently while it is added,
+// see trie.MTrie.PageOutPayloads for details.
 func (f *Forest) AddTrie(newTrie *trie.MTrie) error {
 	if newTrie == nil {
 		return nil
 	}
 
+	f.updateLock.RLock()
+	defer f.updateLock.RUnlock()
+
 	// TODO: check Thread safety
 	rootHash := newTrie.RootHash()
 	if _, found := f.tries.Get(rootHash); found {
 		// do no op
+		f.unpin(newTrie)
 		return nil
 	}
+
+	if f.payloadStore != nil && !newTrie.PayloadsPagedOut() {
+		// Subtries shared with the most recently added trie were paged out with it. Tries are
+		// usually added in the order they were created, so only the new leaves are traversed.
+		err := newTrie.PageOutPayloads(f.tries.LastAddedTrie(), f.payloadStore)
+		if err != nil {
+			return fmt.Errorf("paging out payloads of trie %s failed: %w", rootHash, err)
+		}
+	}
+
+	f.addTrie(newTrie)
+	return nil
+}
+
+// addTrie adds a trie, whose payloads were paged out if necessary, to the forest, and releases
+// it if it was pinned by NewTrie.
+// The caller must hold the update lock for reading.
+func (f *Forest) addTrie(newTrie *trie.MTrie) {
+	defer f.unpin(newTrie)
+	if _, found := f.tries.Get(newTrie.RootHash()); found {
+		// do no op
+		return
+	}
 	f.tries.Push(newTrie)
 	f.metrics.ForestNumberOfTrees(uint64(f.tries.Count()))
+}
 
-	return nil
+// PayloadStore returns the store leaf payloads are paged out to, or nil if all payloads are
+// kept in memory.
+func (f *Forest) PayloadStore() node.PayloadStore {
+	return f.payloadStore
+}
+
+// CollectPayloadGarbage records that the payload store holds the payloads of the given checkpoint,
+// whose tries were just written to the checkpoint file with the given name, and deletes the paged
+// out payloads which are referenced neither by a trie of the forest nor by the checkpoint. It
+// returns the number of deleted payloads. See node.PayloadStore.CollectGarbage for details.
+// Keeping the payloads of the checkpoint allows to load it without writing its payloads again.
+// It is a no-op if the forest keeps all payloads in memory.
+// No errors are expected during normal operation.
+func (f *Forest) CollectPayloadGarbage(checkpointFileName string, checkpointTries []*trie.MTrie) (int, error) {
+	if f.payloadStore == nil {
+		return 0, nil
+	}
+
+	checkpoint := &node.CheckpointRecord{
+		FileName:   checkpointFileName,
+		RootHashes: make([]ledger.RootHash, 0, len(checkpointTries)),
+	}
+	var previous *trie.MTrie
+	for _, t := range checkpointTries {
+		err := t.StoreLeafPayloads(previous, f.payloadStore)
+		if err != nil {
+			return 0, fmt.Errorf("could not store payloads of checkpoint %s: %w", checkpointFileName, err)
+		}
+		checkpoint.RootHashes = append(checkpoint.RootHashes, t.RootHash())
+		previous = t
+	}
+	err := f.payloadStore.SetCheckpoint(checkpoint)
+	if err != nil {
+		return 0, fmt.Errorf("could not record checkpoint %s: %w", checkpointFileName, err)
+	}
+
+	live := f.livePayloads(checkpointTries)
+
+	deleted, err := f.payloadStore.CollectGarbage(live.mayContain)
+	if err != nil {
+		return deleted, fmt.Errorf("could not collect garbage payloads: %w", err)
+	}
+	return deleted, nil
+}
+
+// livePayloads returns a filter of the hashes of the payloads referenced by the tries of the
+// forest, the tries created by NewTrie which were not added yet, and the given checkpoint tries.
+// Tries constructed concurrently share their unchanged leaves with their parents, which might be
+// evicted from the forest in the meantime. Hence, the forest is not updated while the live payloads
+// are determined, so the parents of tries created later were live.
+func (f *Forest) livePayloads(checkpointTries []*trie.MTrie) *leafHashFilter {
+	f.updateLock.Lock()
+	defer f.updateLock.Unlock()
+
+	tries := append(f.tries.Tries(), f.pinnedTries()...)
+
+	// the forest holds at most a few more leaves than its largest trie, unless the tries are unrelated
+	maxRegCount := uint64(0)
+	for _, t := range append(tries, checkpointTries...) {
+		if t.AllocatedRegCount() > maxRegCount {
+			maxRegCount = t.AllocatedRegCount()
+		}
+	}
+	live := newLeafHashFilter(2 * maxRegCount)
+
+	// tries are ordered from the least to the most recently added, so subtries shared
+	// with the previous trie were visited already
+	var previous *trie.MTrie
+	for _, t := range tries {
+		t.VisitPagedLeaves(previous, live.add)
+		previous = t
+	}
+
+	// the checkpoint's payloads are stored under the hashes of its leaves
+	previous = nil
+	for _, t := range checkpointTries {
+		t.VisitLeafHashes(previous, live.add)
+		previous = t
+	}
+	return live
 }
 
 // GetEmptyRootHash returns the rootHash of empty Trie
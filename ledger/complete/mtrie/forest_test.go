@@ -11,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
 	prf "github.com/onflow/flow-go/ledger/common/proof"
 	"github.com/onflow/flow-go/ledger/common/testutils"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
@@ -1093,3 +1094,206 @@ func TestPurgeCacheExcept(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, forest.tries.Count())
 }
+
+// TestPayloadStore verifies that a forest paging out payloads to a store computes the same root
+// hashes and returns the same values and proofs as a forest keeping all payloads in memory.
+func TestPayloadStore(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+	pagedForest, err := NewForest(5, &metrics.NoopCollector{}, nil, WithPayloadStore(store))
+	require.NoError(t, err)
+	forest, err := NewForest(5, &metrics.NoopCollector{}, nil)
+	require.NoError(t, err)
+
+	rootHash := forest.GetEmptyRootHash()
+	for i := 0; i < 3; i++ {
+		paths := testutils.RandomPaths(20)
+		payloads := testutils.RandomPayloads(20, 10, 20)
+
+		update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: payloads}
+		pagedRootHash, err := pagedForest.Update(update)
+		require.NoError(t, err)
+		rootHash, err = forest.Update(update)
+		require.NoError(t, err)
+		require.Equal(t, rootHash, pagedRootHash)
+
+		read := &ledger.TrieRead{RootHash: rootHash, Paths: paths}
+		pagedValues, err := pagedForest.Read(read)
+		require.NoError(t, err)
+		values, err := forest.Read(read)
+		require.NoError(t, err)
+		require.Equal(t, values, pagedValues)
+
+		pagedProofs, err := pagedForest.Proofs(read)
+		require.NoError(t, err)
+		proofs, err := forest.Proofs(read)
+		require.NoError(t, err)
+		require.True(t, proofs.Equals(pagedProofs))
+	}
+	// leaves moved to a different height by later updates are paged out again
+	require.GreaterOrEqual(t, store.Size(), 60)
+	require.Greater(t, store.Loads, 0)
+	storedBeforeAdd := store.Size()
+
+	// tries added to the forest directly, e.g. when loading a checkpoint, are paged out entirely
+	paths := testutils.RandomPaths(10)
+	payloads := testutils.RandomPayloads(10, 10, 20)
+	// NewTrieWithUpdatedRegisters reorders the given slices
+	updatePaths := append([]ledger.Path(nil), paths...)
+	newTrie, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), updatePaths, derefPayloads(payloads), true)
+	require.NoError(t, err)
+	require.NoError(t, pagedForest.AddTrie(newTrie))
+	require.Equal(t, storedBeforeAdd+10, store.Size())
+
+	read := &ledger.TrieRead{RootHash: newTrie.RootHash(), Paths: paths}
+	values, err := pagedForest.Read(read)
+	require.NoError(t, err)
+	for i, payload := range payloads {
+		require.Equal(t, payload.Value(), values[i])
+	}
+}
+
+// TestPayloadStore_AddNewTrie verifies that adding a trie created by NewTrie does not page out
+// its payloads again.
+func TestPayloadStore_AddNewTrie(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+	forest, err := NewForest(5, &metrics.NoopCollector{}, nil, WithPayloadStore(store))
+	require.NoError(t, err)
+
+	update := &ledger.TrieUpdate{
+		RootHash: forest.GetEmptyRootHash(),
+		Paths:    testutils.RandomPaths(20),
+		Payloads: testutils.RandomPayloads(20, 10, 20),
+	}
+	newTrie, err := forest.NewTrie(update)
+	require.NoError(t, err)
+	require.True(t, newTrie.PayloadsPagedOut())
+	require.Equal(t, 20, store.Stores)
+	require.Equal(t, 1, store.Flushes)
+
+	require.NoError(t, forest.AddTrie(newTrie))
+	require.Equal(t, 20, store.Stores)
+	require.Equal(t, 1, store.Flushes)
+}
+
+// TestCollectPayloadGarbage verifies that only the payloads of leaves which are not part of any
+// trie of the forest anymore are deleted.
+func TestCollectPayloadGarbage(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+	forest, err := NewForest(2, &metrics.NoopCollector{}, nil, WithPayloadStore(store))
+	require.NoError(t, err)
+
+	paths := testutils.RandomPaths(20)
+	rootHash := forest.GetEmptyRootHash()
+	for i := 0; i < 4; i++ {
+		update := &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: testutils.RandomPayloads(20, 10, 20)}
+		rootHash, err = forest.Update(update)
+		require.NoError(t, err)
+	}
+	// every update overwrites all payloads
+	require.Equal(t, 80, store.Size())
+
+	// the forest only holds the two most recent tries, which are also checkpointed
+	tries := forest.tries.Tries()
+	deleted, err := forest.CollectPayloadGarbage("checkpoint", tries)
+	require.NoError(t, err)
+	require.Equal(t, 40, deleted)
+	require.Equal(t, 40, store.Size())
+
+	// all values of the held tries can still be read
+	for _, tr := range tries {
+		_, err := forest.Read(&ledger.TrieRead{RootHash: tr.RootHash(), Paths: paths})
+		require.NoError(t, err)
+	}
+
+	checkpoint, err := store.Checkpoint()
+	require.NoError(t, err)
+	require.Equal(t, "checkpoint", checkpoint.FileName)
+	require.True(t, checkpoint.Matches([]ledger.RootHash{tries[0].RootHash(), tries[1].RootHash()}))
+}
+
+// TestCollectPayloadGarbage_Checkpoint verifies that the payloads of the checkpointed tries are kept
+// after the tries were evicted from the forest, and that the payloads of compactified leaves are
+// stored under their own hashes, as they are referenced when the checkpoint is loaded.
+func TestCollectPayloadGarbage_Checkpoint(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+	forest, err := NewForest(1, &metrics.NoopCollector{}, nil, WithPayloadStore(store))
+	require.NoError(t, err)
+
+	// two leaves in the left half of the trie, and one in the right half
+	paths := []ledger.Path{testutils.PathByUint8(0), testutils.PathByUint8(1), testutils.PathByUint8(128)}
+	payloads := testutils.RandomPayloads(3, 10, 20)
+	update := &ledger.TrieUpdate{RootHash: forest.GetEmptyRootHash(), Paths: paths, Payloads: payloads}
+	rootHash, err := forest.Update(update)
+	require.NoError(t, err)
+
+	// removing the second leaf compactifies the first one to a higher level
+	update = &ledger.TrieUpdate{RootHash: rootHash, Paths: paths[1:2], Payloads: []*ledger.Payload{ledger.EmptyPayload()}}
+	rootHash, err = forest.Update(update)
+	require.NoError(t, err)
+	checkpointed, err := forest.GetTrie(rootHash)
+	require.NoError(t, err)
+
+	deleted, err := forest.CollectPayloadGarbage("checkpoint", []*trie.MTrie{checkpointed})
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted) // the payload of the removed leaf
+
+	// the checkpointed trie is evicted by an update overwriting all its leaves
+	update = &ledger.TrieUpdate{RootHash: rootHash, Paths: []ledger.Path{paths[0], paths[2]}, Payloads: testutils.RandomPayloads(2, 10, 20)}
+	_, err = forest.Update(update)
+	require.NoError(t, err)
+	require.False(t, forest.HasTrie(rootHash))
+
+	_, err = forest.CollectPayloadGarbage("checkpoint", []*trie.MTrie{checkpointed})
+	require.NoError(t, err)
+
+	// all leaves of the checkpointed trie can be loaded by their own hashes
+	checkpointed.VisitLeafHashes(nil, func(leafHash hash.Hash) {
+		_, err := store.Load(leafHash)
+		require.NoError(t, err)
+	})
+}
+
+// TestCollectPayloadGarbage_NewTrie verifies that the payloads of a trie created by NewTrie are kept
+// until it is added to the forest, even if its parent was evicted from the forest in the meantime.
+func TestCollectPayloadGarbage_NewTrie(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+	forest, err := NewForest(2, &metrics.NoopCollector{}, nil, WithPayloadStore(store))
+	require.NoError(t, err)
+
+	paths := testutils.RandomPaths(20)
+	update := &ledger.TrieUpdate{RootHash: forest.GetEmptyRootHash(), Paths: paths, Payloads: testutils.RandomPayloads(20, 10, 20)}
+	parentHash, err := forest.Update(update)
+	require.NoError(t, err)
+
+	// the new trie shares all but one leaf with its parent
+	update = &ledger.TrieUpdate{RootHash: parentHash, Paths: paths[:1], Payloads: testutils.RandomPayloads(1, 10, 20)}
+	newTrie, err := forest.NewTrie(update)
+	require.NoError(t, err)
+
+	// the parent is evicted by updates overwriting all its leaves before the new trie is added
+	rootHash := parentHash
+	for i := 0; i < 2; i++ {
+		update = &ledger.TrieUpdate{RootHash: rootHash, Paths: paths, Payloads: testutils.RandomPayloads(20, 10, 20)}
+		rootHash, err = forest.Update(update)
+		require.NoError(t, err)
+	}
+	require.False(t, forest.HasTrie(parentHash))
+
+	_, err = forest.CollectPayloadGarbage("checkpoint", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, forest.AddTrie(newTrie))
+	_, err = forest.Read(&ledger.TrieRead{RootHash: newTrie.RootHash(), Paths: paths})
+	require.NoError(t, err)
+
+	// once added, the trie is not pinned anymore
+	require.Empty(t, forest.pinnedTries())
+}
+
+func derefPayloads(payloads []*ledger.Payload) []ledger.Payload {
+	deref := make([]ledger.Payload, len(payloads))
+	for i, p := range payloads {
+		deref[i] = *p
+	}
+	return deref
+}
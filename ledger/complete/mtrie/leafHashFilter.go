@@ -0,0 +1,58 @@
+package mtrie
+
+import (
+	"encoding/binary"
+
+	"github.com/onflow/flow-go/ledger/common/hash"
+)
+
+const (
+	// leafHashFilterBitsPerEntry is the number of filter bits per expected entry. Together with
+	// the 4 bits set per entry, this yields a false positive rate of about 1.2%.
+	leafHashFilterBitsPerEntry = 10
+
+	// leafHashFilterMinBits is the minimum size of the filter.
+	leafHashFilterMinBits = 1 << 16
+)
+
+// leafHashFilter is a bloom filter of leaf hashes, used to determine the live payloads during
+// garbage collection without holding all leaf hashes in memory. As leaf hashes are uniformly
+// distributed, the filter's bit positions are taken directly from the hash.
+//
+// leafHashFilter is not safe for concurrent use.
+type leafHashFilter struct {
+	bits []uint64
+	size uint64 // number of bits
+}
+
+// newLeafHashFilter returns an empty filter sized for the expected number of entries.
+func newLeafHashFilter(expectedEntries uint64) *leafHashFilter {
+	size := expectedEntries * leafHashFilterBitsPerEntry
+	if size < leafHashFilterMinBits {
+		size = leafHashFilterMinBits
+	}
+	return &leafHashFilter{
+		bits: make([]uint64, (size+63)/64),
+		size: size,
+	}
+}
+
+// add adds the leaf hash to the filter.
+func (f *leafHashFilter) add(leafHash hash.Hash) {
+	for i := 0; i < len(leafHash); i += 8 {
+		bit := binary.BigEndian.Uint64(leafHash[i:]) % f.size
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// mayContain returns false if the leaf hash was not added to the filter. It might return true
+// for leaf hashes which were not added.
+func (f *leafHashFilter) mayContain(leafHash hash.Hash) bool {
+	for i := 0; i < len(leafHash); i += 8 {
+		bit := binary.BigEndian.Uint64(leafHash[i:]) % f.size
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
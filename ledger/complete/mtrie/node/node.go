@@ -36,6 +36,7 @@ type Node struct {
 	height    int             // height where the Node is at
 	path      ledger.Path     // the storage path (dummy value for interim nodes)
 	payload   *ledger.Payload // the payload this node is storing (leaf nodes only)
+	paged     *pagedPayload   // reference to the payload, if it was paged out to a PayloadStore (leaf nodes only)
 	hashValue hash.Hash       // hash value of node (cached)
 }
 
@@ -122,11 +123,11 @@ func NewInterimCompactifiedNode(height int, lChild, rChild *Node) *Node {
 	// an empty subtrie => in total we have one allocated register, which we represent as single leaf node
 	if rChild == nil && lChild.IsLeaf() {
 		h := hash.HashInterNode(lChild.hashValue, ledger.GetDefaultHashForHeight(lChild.height))
-		return &Node{height: height, path: lChild.path, payload: lChild.payload, hashValue: h}
+		return &Node{height: height, path: lChild.path, payload: lChild.payload, paged: lChild.paged, hashValue: h}
 	}
 	if lChild == nil && rChild.IsLeaf() {
 		h := hash.HashInterNode(ledger.GetDefaultHashForHeight(rChild.height), rChild.hashValue)
-		return &Node{height: height, path: rChild.path, payload: rChild.payload, hashValue: h}
+		return &Node{height: height, path: rChild.path, payload: rChild.payload, paged: rChild.paged, hashValue: h}
 	}
 
 	// CASE (b): both children contain some allocated registers => we can't compactify; return a full interim leaf
@@ -180,6 +181,15 @@ func verifyCachedHashRecursive(n *Node) bool {
 		return false
 	}
 
+	if n.paged != nil {
+		// the payload of a paged out leaf must be loaded to recompute the hash
+		payload, err := n.paged.load()
+		if err != nil {
+			return false
+		}
+		return n.hashValue == ledger.ComputeCompactValue(hash.Hash(n.path), payload.Value(), n.height)
+	}
+
 	computedHash := n.computeHash()
 	return n.hashValue == computedHash
 }
@@ -212,9 +222,15 @@ func (n *Node) Path() *ledger.Path {
 }
 
 // Payload returns the the Node's payload.
+// If the payload was paged out, it is loaded from the PayloadStore.
 // Do NOT MODIFY returned slices!
-func (n *Node) Payload() *ledger.Payload {
-	return n.payload
+// No errors are expected during normal operation, failing to load a paged out
+// payload means the PayloadStore is corrupted or unavailable.
+func (n *Node) Payload() (*ledger.Payload, error) {
+	if n.paged != nil {
+		return n.paged.load()
+	}
+	return n.payload, nil
 }
 
 // LeftChild returns the the Node's left child.
@@ -244,29 +260,36 @@ func (n *Node) FmtStr(prefix string, subpath string) string {
 		left = fmt.Sprintf("\n%v", n.lChild.FmtStr(prefix+"\t", subpath+"0"))
 	}
 	payloadSize := 0
-	if n.payload != nil {
-		payloadSize = n.payload.Size()
+	if payload, err := n.Payload(); err == nil && payload != nil {
+		payloadSize = payload.Size()
 	}
 	hashStr := hex.EncodeToString(n.hashValue[:])
 	hashStr = hashStr[:3] + "..." + hashStr[len(hashStr)-3:]
 	return fmt.Sprintf("%v%v: (path:%v, payloadSize:%d hash:%v)[%s] (obj %p) %v %v ", prefix, n.height, n.path, payloadSize, hashStr, subpath, n, left, right)
 }
 
-// AllPayloads returns the payload of this node and all payloads of the subtrie
-func (n *Node) AllPayloads() []ledger.Payload {
+// AllPayloads returns the payload of this node and all payloads of the subtrie.
+// No errors are expected during normal operation, see Payload for details.
+func (n *Node) AllPayloads() ([]ledger.Payload, error) {
 	return n.appendSubtreePayloads([]ledger.Payload{})
 }
 
 // appendSubtreePayloads appends the payloads of the subtree with this node as root
 // to the provided Payload slice. Follows same pattern as Go's native append method.
-func (n *Node) appendSubtreePayloads(result []ledger.Payload) []ledger.Payload {
+func (n *Node) appendSubtreePayloads(result []ledger.Payload) ([]ledger.Payload, error) {
 	if n == nil {
-		return result
+		return result, nil
 	}
 	if n.IsLeaf() {
-		return append(result, *n.Payload())
+		payload, err := n.Payload()
+		if err != nil {
+			return nil, err
+		}
+		return append(result, *payload), nil
+	}
+	result, err := n.lChild.appendSubtreePayloads(result)
+	if err != nil {
+		return nil, err
 	}
-	result = n.lChild.appendSubtreePayloads(result)
-	result = n.rChild.appendSubtreePayloads(result)
-	return result
+	return n.rChild.appendSubtreePayloads(result)
 }
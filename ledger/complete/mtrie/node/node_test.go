@@ -100,7 +100,9 @@ func Test_AllPayloads(t *testing.T) {
 	n3 := node.NewLeaf(path, payload, 1)
 	n4 := node.NewInterimNode(1, n1, n2)
 	n5 := node.NewInterimNode(2, n4, n3)
-	require.Equal(t, 3, len(n5.AllPayloads()))
+	payloads, err := n5.AllPayloads()
+	require.NoError(t, err)
+	require.Equal(t, 3, len(payloads))
 }
 
 func Test_VerifyCachedHash(t *testing.T) {
@@ -295,3 +297,92 @@ func requireIsLeafWithHash(t *testing.T, node *node.Node, expectedHash hash.Hash
 	require.True(t, node.VerifyCachedHash())
 	require.True(t, node.IsLeaf())
 }
+
+// Test_PageOut verifies that paging out the payload of a leaf releases the in-memory payload
+// without affecting the node's hash or the returned payload.
+func Test_PageOut(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+
+	path := testutils.PathByUint16(56809)
+	payload := testutils.LightPayload(56810, 59656)
+	n := node.NewLeaf(path, payload, 1)
+	expectedHash := n.Hash()
+
+	require.NoError(t, node.PageOut(store, []*node.Node{n}))
+	require.True(t, n.IsPagedOut())
+	require.Equal(t, 1, store.Stores)
+	require.Equal(t, expectedHash, n.Hash())
+	requirePayload(t, payload, n)
+	require.True(t, n.VerifyCachedHash())
+
+	// paging out a second time is a no-op
+	require.NoError(t, node.PageOut(store, []*node.Node{n}))
+	require.Equal(t, 1, store.Stores)
+	require.Equal(t, 1, store.Flushes)
+
+	// compactifying a paged out leaf keeps referencing the stored payload
+	compactified := node.NewInterimCompactifiedNode(2, n, nil)
+	require.True(t, compactified.IsLeaf())
+	require.True(t, compactified.IsPagedOut())
+	requirePayload(t, payload, compactified)
+	require.True(t, compactified.VerifyCachedHash())
+	leafHash, ok := compactified.PagedLeafHash()
+	require.True(t, ok)
+	require.Equal(t, expectedHash, leafHash)
+}
+
+// Test_PageOut_Batched verifies that the payloads of all given leaves are written in a single batch.
+func Test_PageOut_Batched(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+
+	leaves := make([]*node.Node, 10)
+	for i := range leaves {
+		leaves[i] = node.NewLeaf(testutils.PathByUint16(uint16(i)), testutils.LightPayload(uint16(i), uint16(i)), 0)
+	}
+
+	require.NoError(t, node.PageOut(store, leaves))
+	require.Equal(t, len(leaves), store.Stores)
+	require.Equal(t, 1, store.Flushes)
+	for i, n := range leaves {
+		require.True(t, n.IsPagedOut())
+		requirePayload(t, testutils.LightPayload(uint16(i), uint16(i)), n)
+	}
+}
+
+// Test_PageOut_NoPayload verifies that interim nodes and leaves without payload are not paged out.
+func Test_PageOut_NoPayload(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+
+	empty := node.NewLeaf(testutils.PathByUint16(56809), nil, 0)
+	leaf := node.NewLeaf(testutils.PathByUint16(56809), testutils.LightPayload(1, 2), 0)
+	interim := node.NewInterimNode(1, leaf, nil)
+	require.NoError(t, node.PageOut(store, []*node.Node{empty, interim}))
+	require.False(t, empty.IsPagedOut())
+	require.False(t, interim.IsPagedOut())
+
+	require.Equal(t, 0, store.Stores)
+	require.Equal(t, 0, store.Flushes)
+}
+
+// Test_PagedPayload_LoadError verifies that failing to load a paged out payload is returned as error.
+func Test_PagedPayload_LoadError(t *testing.T) {
+	store := testutils.NewMemoryPayloadStore()
+
+	n := node.NewLeaf(testutils.PathByUint16(56809), testutils.LightPayload(1, 2), 0)
+	require.NoError(t, node.PageOut(store, []*node.Node{n}))
+
+	// remove the payload from the store
+	_, err := store.CollectGarbage(func(hash.Hash) bool { return false })
+	require.NoError(t, err)
+
+	_, err = n.Payload()
+	require.Error(t, err)
+	require.False(t, n.VerifyCachedHash())
+}
+
+// requirePayload requires that the node's payload equals the expected payload.
+func requirePayload(t *testing.T, expected *ledger.Payload, n *node.Node) {
+	payload, err := n.Payload()
+	require.NoError(t, err)
+	require.True(t, payload.Equals(expected))
+}
@@ -0,0 +1,163 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+)
+
+// PayloadStore keeps leaf payloads outside of memory, indexed by the hash of the leaf
+// node they were paged out from. As the hash of a leaf commits to its path, height and
+// value, a leaf hash uniquely identifies the payload.
+//
+// Implementations must be safe for concurrent use.
+type PayloadStore interface {
+	// NewBatch returns a new batch for writing payloads to the store.
+	NewBatch() PayloadBatch
+
+	// Load returns the payload of the leaf with the given hash.
+	Load(leafHash hash.Hash) (*ledger.Payload, error)
+
+	// CollectGarbage deletes the payloads of leaves which are not live anymore, i.e. which are
+	// not part of any trie referencing the store. Payloads written since the previous collection
+	// started are never deleted, as they might belong to tries which were not live yet when the
+	// live leaves were determined. False positives of isLive only delay the deletion of a payload.
+	// It returns the number of deleted payloads.
+	CollectGarbage(isLive func(leafHash hash.Hash) bool) (int, error)
+
+	// Checkpoint returns the checkpoint recorded by SetCheckpoint, or nil if none was recorded.
+	Checkpoint() (*CheckpointRecord, error)
+
+	// SetCheckpoint records that the store holds the payloads of all leaves of the given checkpoint,
+	// under the leaf hashes recorded in the checkpoint file. When the checkpoint is loaded again,
+	// its payloads don't have to be written to the store again.
+	SetCheckpoint(checkpoint *CheckpointRecord) error
+}
+
+// CheckpointRecord identifies a checkpoint whose payloads are held by a PayloadStore.
+type CheckpointRecord struct {
+	// FileName is the file name of the checkpoint (header) file.
+	FileName string
+	// RootHashes are the root hashes of the checkpoint's tries, in the order they are stored in
+	// the checkpoint. They ensure that a different checkpoint with the same file name, e.g. a new
+	// root checkpoint, is not mistaken for the recorded one.
+	RootHashes []ledger.RootHash
+}
+
+// Matches returns true if the record holds the given root hashes, in the same order.
+func (c *CheckpointRecord) Matches(rootHashes []ledger.RootHash) bool {
+	if len(c.RootHashes) != len(rootHashes) {
+		return false
+	}
+	for i, rootHash := range rootHashes {
+		if c.RootHashes[i] != rootHash {
+			return false
+		}
+	}
+	return true
+}
+
+// PayloadBatch collects payloads which are written to a PayloadStore at once.
+// Payloads added to a batch can only be loaded once the batch was flushed. A batch
+// can't be used anymore after it was flushed or canceled.
+// Batches are not safe for concurrent use.
+type PayloadBatch interface {
+	// Add adds the payload of the leaf with the given hash to the batch.
+	Add(leafHash hash.Hash, payload *ledger.Payload) error
+
+	// AddEncoded adds the payload of the leaf with the given hash to the batch, encoded the same
+	// way as the payloads of leaves in checkpoint files. The encoded payload is copied.
+	AddEncoded(leafHash hash.Hash, encodedPayload []byte) error
+
+	// Flush writes the payloads added to the batch to the store.
+	Flush() error
+
+	// Cancel discards the payloads added to the batch, which were not flushed yet.
+	Cancel()
+}
+
+// pagedPayload references a payload which was paged out to a PayloadStore.
+// It is shared by all nodes holding the same payload, e.g. compactified leaves.
+type pagedPayload struct {
+	store    PayloadStore
+	leafHash hash.Hash
+}
+
+// load loads the payload from the store.
+// A payload which was paged out successfully must always be available in the store, therefore
+// any error means the store is corrupted or unavailable.
+func (p *pagedPayload) load() (*ledger.Payload, error) {
+	payload, err := p.store.Load(p.leafHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not load paged out payload of leaf %s: %w", p.leafHash, err)
+	}
+	return payload, nil
+}
+
+// NewPagedLeaf creates a leaf Node whose payload was already written to the given store,
+// under the given hash of the leaf.
+// UNCHECKED requirement: the payload stored under hashValue matches the leaf's path and height
+func NewPagedLeaf(height int, path ledger.Path, hashValue hash.Hash, store PayloadStore) *Node {
+	return &Node{
+		height:    height,
+		path:      path,
+		paged:     &pagedPayload{store: store, leafHash: hashValue},
+		hashValue: hashValue,
+	}
+}
+
+// IsPagedOut returns true if the node's payload is kept in a PayloadStore rather than
+// in memory.
+func (n *Node) IsPagedOut() bool {
+	return n != nil && n.paged != nil
+}
+
+// PagedLeafHash returns the hash the node's payload is stored under in the PayloadStore, and
+// false if the payload is not paged out. Compactified leaves share the payload of the leaf they
+// were created from, so the hash can differ from the node's hash.
+func (n *Node) PagedLeafHash() (hash.Hash, bool) {
+	if !n.IsPagedOut() {
+		return hash.DummyHash, false
+	}
+	return n.paged.leafHash, true
+}
+
+// PageOut moves the payloads of the given leaves to the store in a single batch, and releases
+// their in-memory copies. Interim nodes, leaves without payload and leaves which were already
+// paged out are left unchanged. The nodes' hashes are not affected.
+//
+// CAUTION: nodes are treated as immutable once they are part of a trie that may be accessed
+// concurrently. PageOut must only be called on nodes which are not yet shared, i.e. while
+// constructing or loading the trie holding them.
+func PageOut(store PayloadStore, leaves []*Node) error {
+	batch := store.NewBatch()
+	pagedOut := make([]*Node, 0, len(leaves))
+	for _, n := range leaves {
+		if n == nil || !n.IsLeaf() || n.paged != nil || n.payload == nil {
+			continue
+		}
+		err := batch.Add(n.hashValue, n.payload)
+		if err != nil {
+			batch.Cancel()
+			return fmt.Errorf("could not page out payload of leaf %s: %w", n.hashValue, err)
+		}
+		pagedOut = append(pagedOut, n)
+	}
+	if len(pagedOut) == 0 {
+		batch.Cancel()
+		return nil
+	}
+
+	err := batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not write paged out payloads: %w", err)
+	}
+
+	// the payloads can only be released once they are available in the store
+	for _, n := range pagedOut {
+		n.paged = &pagedPayload{store: store, leafHash: n.hashValue}
+		n.payload = nil
+	}
+	return nil
+}
@@ -0,0 +1,343 @@
+package payloadstore
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/dgraph-io/badger/v2"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/utils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+const (
+	// DefaultCacheSize is the default number of hot payloads kept in memory.
+	DefaultCacheSize = 100_000
+
+	// collectionBatchSize is the number of keys checked and deleted in a single transaction
+	// during garbage collection.
+	collectionBatchSize = 10_000
+)
+
+// checkpointKey is the key of the checkpoint record (see SetCheckpoint). As it is shorter than a
+// hash, it can't collide with the key of a payload.
+var checkpointKey = []byte("checkpoint")
+
+// Store is a node.PayloadStore keeping leaf payloads in a dedicated badger database, keyed by
+// leaf hash. The most recently stored or loaded payloads are kept in an in-memory LRU cache.
+//
+// The store has the same layout as the leaves of checkpoint files: the key of an entry is the
+// leaf hash recorded in the checkpoint, and the value is the payload encoded as in the
+// checkpoint. This allows to page out payloads while a checkpoint is read, by copying the
+// encoded payloads of its leaves to the store (see PayloadBatch.AddEncoded).
+//
+// Store is safe for concurrent use.
+type Store struct {
+	db    *badger.DB
+	cache *lru.Cache
+
+	// collectionLock ensures that only one garbage collection runs at a time.
+	collectionLock sync.Mutex
+	// protectedTs is the badger timestamp at which the previous garbage collection started.
+	// Payloads written after it are never deleted.
+	protectedTs uint64
+}
+
+var _ node.PayloadStore = (*Store)(nil)
+
+// New returns a new payload store using the given database, which must not be used for
+// anything else, and caching up to cacheSize payloads in memory.
+func New(db *badger.DB, cacheSize int) (*Store, error) {
+	cache, err := lru.New(cacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not create payload cache: %w", err)
+	}
+	return &Store{
+		db:    db,
+		cache: cache,
+		// payloads written before the store was opened are not referenced by tries which are
+		// being constructed, so they can be collected by the first collection
+		protectedTs: currentTs(db),
+	}, nil
+}
+
+// NewBatch returns a new batch for writing payloads to the store.
+func (s *Store) NewBatch() node.PayloadBatch {
+	return &batch{
+		store: s,
+		wb:    s.db.NewWriteBatch(),
+	}
+}
+
+// Load returns the payload of the leaf with the given hash, from the cache if possible.
+func (s *Store) Load(leafHash hash.Hash) (*ledger.Payload, error) {
+	if cached, ok := s.cache.Get(leafHash); ok {
+		return cached.(*ledger.Payload), nil
+	}
+
+	var payload *ledger.Payload
+	err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(leafHash[:])
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			// the value is only valid within the transaction, so it must not be decoded zero-copy
+			payload, err = ledger.DecodePayloadWithoutPrefix(val, false, flattener.PayloadEncodingVersion)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not load payload: %w", err)
+	}
+
+	s.cache.Add(leafHash, payload)
+	return payload, nil
+}
+
+// Checkpoint returns the checkpoint recorded by SetCheckpoint, or nil if none was recorded.
+// No errors are expected during normal operation.
+func (s *Store) Checkpoint() (*node.CheckpointRecord, error) {
+	var checkpoint *node.CheckpointRecord
+	err := s.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(checkpointKey)
+		if errors.Is(err, badger.ErrKeyNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			checkpoint, err = decodeCheckpointRecord(val)
+			return err
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not read checkpoint record: %w", err)
+	}
+	return checkpoint, nil
+}
+
+// SetCheckpoint records that the store holds the payloads of all leaves of the given checkpoint.
+// No errors are expected during normal operation.
+func (s *Store) SetCheckpoint(checkpoint *node.CheckpointRecord) error {
+	err := s.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(checkpointKey, encodeCheckpointRecord(checkpoint))
+	})
+	if err != nil {
+		return fmt.Errorf("could not write checkpoint record: %w", err)
+	}
+	return nil
+}
+
+// CollectGarbage deletes the payloads which are not live, and which were written before the
+// previous garbage collection started. It returns the number of deleted payloads.
+// Payloads which are written again while they are collected are not deleted.
+// No errors are expected during normal operation.
+func (s *Store) CollectGarbage(isLive func(leafHash hash.Hash) bool) (int, error) {
+	s.collectionLock.Lock()
+	defer s.collectionLock.Unlock()
+
+	startTs := currentTs(s.db)
+	deleted := 0
+
+	var lastKey []byte
+	for {
+		keys, err := s.collectionCandidates(lastKey, isLive)
+		if err != nil {
+			return deleted, fmt.Errorf("could not find garbage payloads: %w", err)
+		}
+		if len(keys) == 0 {
+			break
+		}
+		lastKey = keys[len(keys)-1]
+
+		n, err := s.deleteUnchanged(keys)
+		if err != nil {
+			return deleted, fmt.Errorf("could not delete garbage payloads: %w", err)
+		}
+		deleted += n
+	}
+
+	s.protectedTs = startTs
+	return deleted, nil
+}
+
+// collectionCandidates returns up to collectionBatchSize keys after the given key, whose payloads
+// are not live and which were written before the protected timestamp.
+func (s *Store) collectionCandidates(after []byte, isLive func(leafHash hash.Hash) bool) ([][]byte, error) {
+	var keys [][]byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		it.Seek(after)
+		if after != nil && it.Valid() && bytes.Equal(it.Item().Key(), after) {
+			it.Next()
+		}
+		for ; it.Valid() && len(keys) < collectionBatchSize; it.Next() {
+			item := it.Item()
+			if item.Version() > s.protectedTs || bytes.Equal(item.Key(), checkpointKey) {
+				continue
+			}
+			leafHash, err := hash.ToHash(item.Key())
+			if err != nil {
+				return fmt.Errorf("invalid payload key %x: %w", item.Key(), err)
+			}
+			if isLive(leafHash) {
+				continue
+			}
+			keys = append(keys, item.KeyCopy(nil))
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// deleteUnchanged deletes the payloads with the given keys, unless they were written again since
+// they were found to be garbage. It returns the number of deleted payloads.
+func (s *Store) deleteUnchanged(keys [][]byte) (int, error) {
+	deleted := 0
+	err := s.db.Update(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			// reading the key makes the transaction conflict with concurrent writes of the key
+			item, err := txn.Get(key)
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if item.Version() > s.protectedTs {
+				continue
+			}
+			err = txn.Delete(key)
+			if err != nil {
+				return err
+			}
+			deleted++
+		}
+		return nil
+	})
+	if errors.Is(err, badger.ErrConflict) {
+		// some of the payloads were written concurrently, they are reconsidered by the next collection
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
+// encodeCheckpointRecord encodes the checkpoint record as the file name (short data), followed
+// by the root hashes.
+func encodeCheckpointRecord(checkpoint *node.CheckpointRecord) []byte {
+	buf := make([]byte, 0, 2+len(checkpoint.FileName)+len(checkpoint.RootHashes)*hash.HashLen)
+	buf = utils.AppendShortData(buf, []byte(checkpoint.FileName))
+	for _, rootHash := range checkpoint.RootHashes {
+		buf = append(buf, rootHash[:]...)
+	}
+	return buf
+}
+
+// decodeCheckpointRecord decodes a checkpoint record encoded by encodeCheckpointRecord.
+func decodeCheckpointRecord(buf []byte) (*node.CheckpointRecord, error) {
+	fileName, rest, err := utils.ReadShortData(buf)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode checkpoint file name: %w", err)
+	}
+	if len(rest)%hash.HashLen != 0 {
+		return nil, fmt.Errorf("malformed checkpoint root hashes of %d bytes", len(rest))
+	}
+	checkpoint := &node.CheckpointRecord{FileName: string(fileName)}
+	for ; len(rest) > 0; rest = rest[hash.HashLen:] {
+		var rootHash ledger.RootHash
+		copy(rootHash[:], rest)
+		checkpoint.RootHashes = append(checkpoint.RootHashes, rootHash)
+	}
+	return checkpoint, nil
+}
+
+// currentTs returns the timestamp of the most recent write to the database.
+func currentTs(db *badger.DB) uint64 {
+	txn := db.NewTransaction(false)
+	defer txn.Discard()
+	return txn.ReadTs()
+}
+
+// batch writes payloads to the store using a badger write batch, which commits the payloads in
+// as few transactions as possible.
+type batch struct {
+	store   *Store
+	wb      *badger.WriteBatch
+	entries int
+	cached  []cachedPayload
+}
+
+// cachedPayload is a payload added to the cache once it was written.
+type cachedPayload struct {
+	leafHash hash.Hash
+	payload  *ledger.Payload
+}
+
+var _ node.PayloadBatch = (*batch)(nil)
+
+// Add adds the payload of the leaf with the given hash to the batch.
+func (b *batch) Add(leafHash hash.Hash, payload *ledger.Payload) error {
+	encoded := make([]byte, 0, ledger.EncodedPayloadLengthWithoutPrefix(payload, flattener.PayloadEncodingVersion))
+	encoded = ledger.EncodeAndAppendPayloadWithoutPrefix(encoded, payload, flattener.PayloadEncodingVersion)
+	err := b.set(leafHash, encoded)
+	if err != nil {
+		return err
+	}
+	b.cached = append(b.cached, cachedPayload{leafHash: leafHash, payload: payload})
+	return nil
+}
+
+// AddEncoded adds the payload of the leaf with the given hash to the batch, encoded the same way
+// as the payloads of leaves in checkpoint files. The encoded payload is copied.
+func (b *batch) AddEncoded(leafHash hash.Hash, encodedPayload []byte) error {
+	encoded := make([]byte, len(encodedPayload))
+	copy(encoded, encodedPayload)
+	return b.set(leafHash, encoded)
+}
+
+// set adds the encoded payload to the write batch, which keeps referencing key and value until
+// they are committed.
+func (b *batch) set(leafHash hash.Hash, encoded []byte) error {
+	key := make([]byte, len(leafHash))
+	copy(key, leafHash[:])
+	err := b.wb.Set(key, encoded)
+	if err != nil {
+		return fmt.Errorf("could not add payload to batch: %w", err)
+	}
+	b.entries++
+	return nil
+}
+
+// Flush writes the payloads added to the batch to the store.
+func (b *batch) Flush() error {
+	err := b.wb.Flush()
+	if err != nil {
+		return fmt.Errorf("could not write %d payloads: %w", b.entries, err)
+	}
+	for _, c := range b.cached {
+		b.store.cache.Add(c.leafHash, c.payload)
+	}
+	b.cached = nil
+	return nil
+}
+
+// Cancel discards the payloads added to the batch.
+func (b *batch) Cancel() {
+	b.wb.Cancel()
+	b.cached = nil
+}
@@ -0,0 +1,198 @@
+package payloadstore_test
+
+import (
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/payloadstore"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestStoreAndLoad verifies that stored payloads are loaded from the database once they were
+// evicted from the cache.
+func TestStoreAndLoad(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store, err := payloadstore.New(db, 2)
+		require.NoError(t, err)
+
+		payloads := testutils.RandomPayloads(10, 1, 100)
+		leafHashes := storePayloads(t, store, payloads)
+
+		// all but the last two payloads were evicted from the cache
+		for i, leafHash := range leafHashes {
+			loaded, err := store.Load(leafHash)
+			require.NoError(t, err)
+			require.True(t, payloads[i].Equals(loaded))
+		}
+
+		// a store using the same database loads all payloads from disk
+		reopened, err := payloadstore.New(db, payloadstore.DefaultCacheSize)
+		require.NoError(t, err)
+		for i, leafHash := range leafHashes {
+			loaded, err := reopened.Load(leafHash)
+			require.NoError(t, err)
+			require.True(t, payloads[i].Equals(loaded))
+		}
+	})
+}
+
+// TestLoadUnknown verifies that loading a payload which was not stored fails.
+func TestLoadUnknown(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store, err := payloadstore.New(db, payloadstore.DefaultCacheSize)
+		require.NoError(t, err)
+
+		_, err = store.Load(hash.DummyHash)
+		require.ErrorIs(t, err, badger.ErrKeyNotFound)
+	})
+}
+
+// TestPagedLeaf verifies that a leaf paged out to the store can be read back.
+func TestPagedLeaf(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		// a single cached payload, so the leaf's payload can be evicted
+		store, err := payloadstore.New(db, 1)
+		require.NoError(t, err)
+
+		payload := ledger.NewPayload(
+			ledger.NewKey([]ledger.KeyPart{ledger.NewKeyPart(0, []byte("owner")), ledger.NewKeyPart(2, []byte("key"))}),
+			[]byte("value"),
+		)
+		leaf := node.NewLeaf(testutils.PathByUint16(1), payload, 3)
+		require.NoError(t, node.PageOut(store, []*node.Node{leaf}))
+		storePayloads(t, store, []*ledger.Payload{testutils.LightPayload(1, 1)}) // evict the leaf's payload
+
+		require.True(t, leaf.IsPagedOut())
+		loaded, err := leaf.Payload()
+		require.NoError(t, err)
+		require.True(t, payload.Equals(loaded))
+		require.True(t, leaf.VerifyCachedHash())
+	})
+}
+
+// TestAddEncoded verifies that payloads encoded as in checkpoint files can be loaded.
+func TestAddEncoded(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store, err := payloadstore.New(db, payloadstore.DefaultCacheSize)
+		require.NoError(t, err)
+
+		payload := testutils.LightPayload(1, 2)
+		leafHash := node.NewLeaf(testutils.PathByUint16(1), payload, 0).Hash()
+		encoded := ledger.EncodeAndAppendPayloadWithoutPrefix(nil, payload, flattener.PayloadEncodingVersion)
+
+		batch := store.NewBatch()
+		require.NoError(t, batch.AddEncoded(leafHash, encoded))
+		// the batch copies the encoded payload
+		encoded[0]++
+		require.NoError(t, batch.Flush())
+
+		loaded, err := store.Load(leafHash)
+		require.NoError(t, err)
+		require.True(t, payload.Equals(loaded))
+	})
+}
+
+// TestCancel verifies that payloads of canceled batches are not written.
+func TestCancel(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store, err := payloadstore.New(db, payloadstore.DefaultCacheSize)
+		require.NoError(t, err)
+
+		batch := store.NewBatch()
+		require.NoError(t, batch.Add(hash.DummyHash, testutils.LightPayload(1, 2)))
+		batch.Cancel()
+
+		_, err = store.Load(hash.DummyHash)
+		require.ErrorIs(t, err, badger.ErrKeyNotFound)
+	})
+}
+
+// TestCollectGarbage verifies that payloads which are not live are deleted, unless they were
+// written since the previous collection started.
+func TestCollectGarbage(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store, err := payloadstore.New(db, payloadstore.DefaultCacheSize)
+		require.NoError(t, err)
+
+		payloads := testutils.RandomPayloads(4, 1, 100)
+		leafHashes := storePayloads(t, store, payloads)
+		isLive := func(leafHash hash.Hash) bool {
+			return leafHash == leafHashes[0] || leafHash == leafHashes[1]
+		}
+
+		// the payloads were written after the store was opened, so they are protected
+		deleted, err := store.CollectGarbage(isLive)
+		require.NoError(t, err)
+		require.Equal(t, 0, deleted)
+
+		later := storePayloads(t, store, testutils.RandomPayloads(1, 1, 100))
+
+		// the payloads written before the previous collection which are not live are deleted
+		deleted, err = store.CollectGarbage(isLive)
+		require.NoError(t, err)
+		require.Equal(t, 2, deleted)
+
+		// a store without cached payloads shows which payloads are left
+		reopened, err := payloadstore.New(db, payloadstore.DefaultCacheSize)
+		require.NoError(t, err)
+		for i, leafHash := range append(leafHashes, later...) {
+			_, err := reopened.Load(leafHash)
+			if i == 2 || i == 3 {
+				require.ErrorIs(t, err, badger.ErrKeyNotFound)
+			} else {
+				require.NoError(t, err)
+			}
+		}
+	})
+}
+
+// TestCheckpointRecord verifies that the recorded checkpoint is kept across restarts, and is not
+// mistaken for a payload by the garbage collection.
+func TestCheckpointRecord(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		store, err := payloadstore.New(db, payloadstore.DefaultCacheSize)
+		require.NoError(t, err)
+
+		recorded, err := store.Checkpoint()
+		require.NoError(t, err)
+		require.Nil(t, recorded)
+
+		checkpoint := &node.CheckpointRecord{
+			FileName:   "checkpoint.00000010",
+			RootHashes: []ledger.RootHash{testutils.RootHashFixture(), testutils.RootHashFixture()},
+		}
+		require.NoError(t, store.SetCheckpoint(checkpoint))
+
+		// the collection only considers entries written before the previous collection
+		_, err = store.CollectGarbage(func(hash.Hash) bool { return false })
+		require.NoError(t, err)
+		_, err = store.CollectGarbage(func(hash.Hash) bool { return false })
+		require.NoError(t, err)
+
+		reopened, err := payloadstore.New(db, payloadstore.DefaultCacheSize)
+		require.NoError(t, err)
+		recorded, err = reopened.Checkpoint()
+		require.NoError(t, err)
+		require.Equal(t, checkpoint, recorded)
+	})
+}
+
+// storePayloads writes the payloads of leaves holding the given payloads to the store in
+// a single batch, and returns the leaf hashes.
+func storePayloads(t *testing.T, store *payloadstore.Store, payloads []*ledger.Payload) []hash.Hash {
+	leafHashes := make([]hash.Hash, len(payloads))
+	batch := store.NewBatch()
+	for i, payload := range payloads {
+		leafHashes[i] = node.NewLeaf(testutils.PathByUint16(uint16(i)), payload, 0).Hash()
+		require.NoError(t, batch.Add(leafHashes[i], payload))
+	}
+	require.NoError(t, batch.Flush())
+	return leafHashes
+}
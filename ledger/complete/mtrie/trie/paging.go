@@ -0,0 +1,136 @@
+package trie
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+)
+
+// PageOutPayloads moves the payloads of the trie's leaves to the given store in a single batch.
+// Subtries shared with the given parent trie are skipped, as their leaves were paged out together
+// with the parent. If parent is nil, all leaves of the trie are paged out.
+// Paging out payloads does not change the trie's root hash.
+//
+// CAUTION: must only be called on a trie which is not yet accessed concurrently, i.e. right
+// after it was constructed or loaded. See node.PageOut for details.
+func (mt *MTrie) PageOutPayloads(parent *MTrie, store node.PayloadStore) error {
+	var parentRoot *node.Node
+	if parent != nil {
+		parentRoot = parent.root
+	}
+
+	var leaves []*node.Node
+	visitUnshared(mt.root, parentRoot, func(n *node.Node) {
+		if n.IsLeaf() && !n.IsPagedOut() {
+			leaves = append(leaves, n)
+		}
+	})
+
+	err := node.PageOut(store, leaves)
+	if err != nil {
+		return err
+	}
+	mt.pagedOut = true
+	return nil
+}
+
+// PayloadsPagedOut returns true if the payloads of the trie were paged out by PageOutPayloads.
+// Concurrency safe (as Tries are immutable structures by convention)
+func (mt *MTrie) PayloadsPagedOut() bool {
+	return mt.pagedOut
+}
+
+// VisitPagedLeaves calls visit with the hash each paged out payload of the trie is stored
+// under. Like PageOutPayloads, subtries shared with the given parent trie are skipped.
+// Concurrency safe (as Tries are immutable structures by convention)
+func (mt *MTrie) VisitPagedLeaves(parent *MTrie, visit func(leafHash hash.Hash)) {
+	var parentRoot *node.Node
+	if parent != nil {
+		parentRoot = parent.root
+	}
+
+	visitUnshared(mt.root, parentRoot, func(n *node.Node) {
+		if leafHash, ok := n.PagedLeafHash(); ok {
+			visit(leafHash)
+		}
+	})
+}
+
+// StoreLeafPayloads writes the payload of each leaf of the trie to the store under the leaf's own
+// hash, unless it is stored under this hash already. Leaves read from a checkpoint reference their
+// payloads by the leaf hash recorded in the checkpoint, whereas compactified leaves share the
+// payload of the leaf they were created from, which is stored under that leaf's hash. Hence, this
+// allows to read the trie from a checkpoint without writing its payloads again.
+// Like PageOutPayloads, subtries shared with the given parent trie are skipped.
+// No errors are expected during normal operation.
+func (mt *MTrie) StoreLeafPayloads(parent *MTrie, store node.PayloadStore) error {
+	var parentRoot *node.Node
+	if parent != nil {
+		parentRoot = parent.root
+	}
+
+	batch := store.NewBatch()
+	var err error
+	visitUnshared(mt.root, parentRoot, func(n *node.Node) {
+		if err != nil || !n.IsLeaf() {
+			return
+		}
+		if leafHash, ok := n.PagedLeafHash(); ok && leafHash == n.Hash() {
+			return
+		}
+		var payload *ledger.Payload
+		payload, err = n.Payload()
+		if err != nil {
+			return
+		}
+		err = batch.Add(n.Hash(), payload)
+	})
+	if err != nil {
+		batch.Cancel()
+		return fmt.Errorf("could not store payloads of trie %s: %w", mt.RootHash(), err)
+	}
+
+	err = batch.Flush()
+	if err != nil {
+		return fmt.Errorf("could not store payloads of trie %s: %w", mt.RootHash(), err)
+	}
+	return nil
+}
+
+// VisitLeafHashes calls visit with the hash of each leaf of the trie, which is the hash its payload
+// is stored under if the trie is read from a checkpoint (see StoreLeafPayloads). Like
+// PageOutPayloads, subtries shared with the given parent trie are skipped.
+// Concurrency safe (as Tries are immutable structures by convention)
+func (mt *MTrie) VisitLeafHashes(parent *MTrie, visit func(leafHash hash.Hash)) {
+	var parentRoot *node.Node
+	if parent != nil {
+		parentRoot = parent.root
+	}
+
+	visitUnshared(mt.root, parentRoot, func(n *node.Node) {
+		if n.IsLeaf() {
+			visit(n.Hash())
+		}
+	})
+}
+
+// visitUnshared calls visit for all nodes in the subtrie with root n, which are not shared
+// with the subtrie with root parentNode at the same position in the parent trie.
+func visitUnshared(n *node.Node, parentNode *node.Node, visit func(n *node.Node)) {
+	if n == nil || n == parentNode {
+		return
+	}
+	visit(n)
+	if n.IsLeaf() {
+		return
+	}
+
+	var lParent, rParent *node.Node
+	if parentNode != nil {
+		lParent, rParent = parentNode.LeftChild(), parentNode.RightChild()
+	}
+	visitUnshared(n.LeftChild(), lParent, visit)
+	visitUnshared(n.RightChild(), rParent, visit)
+}
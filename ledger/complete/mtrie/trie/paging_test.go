@@ -0,0 +1,138 @@
+package trie_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/ledger"
+	"github.com/onflow/flow-go/ledger/common/hash"
+	"github.com/onflow/flow-go/ledger/common/testutils"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
+	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
+)
+
+// Test_PageOutPayloads verifies that paging out the payloads of a trie does not change its root
+// hash nor the values read from it, and that all leaves are paged out afterwards.
+func Test_PageOutPayloads(t *testing.T) {
+	rng := &LinearCongruentialGenerator{seed: 0}
+	store := testutils.NewMemoryPayloadStore()
+
+	paths, payloads := deduplicateWrites(sampleRandomRegisterWrites(rng, 99))
+	mt, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths, payloads, true)
+	require.NoError(t, err)
+	expectedRootHash := mt.RootHash()
+
+	err = mt.PageOutPayloads(nil, store)
+	require.NoError(t, err)
+
+	require.Equal(t, expectedRootHash, mt.RootHash())
+	require.True(t, mt.IsAValidTrie())
+	require.Equal(t, len(payloads), store.Size())
+	require.Equal(t, 1, store.Flushes)
+	require.True(t, mt.PayloadsPagedOut())
+	requireAllLeavesPagedOut(t, mt.RootNode())
+
+	read, err := mt.UnsafeRead(paths)
+	require.NoError(t, err)
+	for i := range payloads {
+		require.True(t, payloads[i].Equals(read[i]))
+	}
+}
+
+// Test_PageOutPayloads_SharedSubtries verifies that only the leaves of an updated trie which are
+// not shared with its parent are paged out.
+func Test_PageOutPayloads_SharedSubtries(t *testing.T) {
+	rng := &LinearCongruentialGenerator{seed: 0}
+	store := testutils.NewMemoryPayloadStore()
+
+	paths1, payloads1 := deduplicateWrites(sampleRandomRegisterWrites(rng, 99))
+	parent, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths1, payloads1, true)
+	require.NoError(t, err)
+	require.NoError(t, parent.PageOutPayloads(nil, store))
+	storedByParent := store.Stores
+
+	paths2, payloads2 := deduplicateWrites(sampleRandomRegisterWrites(rng, 5))
+	updated, _, err := trie.NewTrieWithUpdatedRegisters(parent, paths2, payloads2, true)
+	require.NoError(t, err)
+	expectedRootHash := updated.RootHash()
+
+	require.NoError(t, updated.PageOutPayloads(parent, store))
+
+	// besides the updated leaves, only the leaves of the parent which were moved to a different
+	// height by the update are new nodes, which have to be paged out
+	storedByUpdate := store.Stores - storedByParent
+	require.GreaterOrEqual(t, storedByUpdate, len(paths2))
+	require.Less(t, storedByUpdate, len(paths1))
+
+	require.Equal(t, expectedRootHash, updated.RootHash())
+	require.True(t, updated.IsAValidTrie())
+	requireAllLeavesPagedOut(t, updated.RootNode())
+
+	read, err := updated.UnsafeRead(paths2)
+	require.NoError(t, err)
+	for i := range payloads2 {
+		require.True(t, payloads2[i].Equals(read[i]))
+	}
+
+	// the parent trie is not affected by the update
+	read, err = parent.UnsafeRead(paths1)
+	require.NoError(t, err)
+	for i := range payloads1 {
+		require.True(t, payloads1[i].Equals(read[i]))
+	}
+}
+
+// Test_VisitPagedLeaves verifies that the paged out leaves of an updated trie are visited, except
+// for the leaves shared with its parent.
+func Test_VisitPagedLeaves(t *testing.T) {
+	rng := &LinearCongruentialGenerator{seed: 0}
+	store := testutils.NewMemoryPayloadStore()
+
+	paths1, payloads1 := deduplicateWrites(sampleRandomRegisterWrites(rng, 99))
+	parent, _, err := trie.NewTrieWithUpdatedRegisters(trie.NewEmptyMTrie(), paths1, payloads1, true)
+	require.NoError(t, err)
+	require.NoError(t, parent.PageOutPayloads(nil, store))
+	storedByParent := store.Stores
+
+	paths2, payloads2 := deduplicateWrites(sampleRandomRegisterWrites(rng, 5))
+	updated, _, err := trie.NewTrieWithUpdatedRegisters(parent, paths2, payloads2, true)
+	require.NoError(t, err)
+	require.NoError(t, updated.PageOutPayloads(parent, store))
+
+	visited := make(map[hash.Hash]struct{})
+	parent.VisitPagedLeaves(nil, func(leafHash hash.Hash) {
+		visited[leafHash] = struct{}{}
+	})
+	require.Len(t, visited, storedByParent)
+
+	updated.VisitPagedLeaves(parent, func(leafHash hash.Hash) {
+		visited[leafHash] = struct{}{}
+	})
+	require.Len(t, visited, store.Size())
+
+	// all payloads referenced by the tries were visited
+	deleted, err := store.CollectGarbage(func(leafHash hash.Hash) bool {
+		_, ok := visited[leafHash]
+		return ok
+	})
+	require.NoError(t, err)
+	require.Zero(t, deleted)
+}
+
+// requireAllLeavesPagedOut verifies that all leaves holding a payload in the given subtrie were paged out.
+func requireAllLeavesPagedOut(t *testing.T, n *node.Node) {
+	if n == nil {
+		return
+	}
+	if n.IsLeaf() {
+		payload, err := n.Payload()
+		require.NoError(t, err)
+		if payload != nil && !payload.IsEmpty() {
+			require.True(t, n.IsPagedOut(), "leaf %v was not paged out", ledger.Path(*n.Path()))
+		}
+		return
+	}
+	requireAllLeavesPagedOut(t, n.LeftChild())
+	requireAllLeavesPagedOut(t, n.RightChild())
+}
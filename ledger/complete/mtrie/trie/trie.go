@@ -36,6 +36,7 @@ type MTrie struct {
 	root     *node.Node
 	regCount uint64 // number of registers allocated in the trie
 	regSize  uint64 // size of registers allocated in the trie
+	pagedOut bool   // true once the payloads of all leaves were paged out
 }
 
 // NewEmptyMTrie returns an empty Mtrie (root is nil)
@@ -109,11 +110,17 @@ func (mt *MTrie) String() string {
 //     the size operation completes, the order of `path` and `sizes` are such that
 //     for `path[i]` the corresponding register value size is referenced by `sizes[i]`.
 //
+// No errors are expected during normal operation, errors can only occur if payloads were
+// paged out and can't be loaded (see node.Node.Payload).
+//
 // TODO move consistency checks from Forest into Trie to obtain a safe, self-contained API
-func (mt *MTrie) UnsafeValueSizes(paths []ledger.Path) []int {
+func (mt *MTrie) UnsafeValueSizes(paths []ledger.Path) ([]int, error) {
 	sizes := make([]int, len(paths)) // pre-allocate slice for the result
-	valueSizes(sizes, paths, mt.root)
-	return sizes
+	err := valueSizes(sizes, paths, mt.root)
+	if err != nil {
+		return nil, err
+	}
+	return sizes, nil
 }
 
 // valueSizes returns value sizes of all the registers in `paths“ in subtree with `head` as root node.
@@ -121,22 +128,25 @@ func (mt *MTrie) UnsafeValueSizes(paths []ledger.Path) []int {
 // CAUTION:
 //   - while reading the payloads, `paths` is permuted IN-PLACE for optimized processing.
 //   - unchecked requirement: all paths must go through the `head` node
-func valueSizes(sizes []int, paths []ledger.Path, head *node.Node) {
+func valueSizes(sizes []int, paths []ledger.Path, head *node.Node) error {
 	// check for empty paths
 	if len(paths) == 0 {
-		return
+		return nil
 	}
 
 	// path not found
 	if head == nil {
-		return
+		return nil
 	}
 
 	// reached a leaf node
 	if head.IsLeaf() {
 		for i, p := range paths {
 			if *head.Path() == p {
-				payload := head.Payload()
+				payload, err := head.Payload()
+				if err != nil {
+					return err
+				}
 				if payload != nil {
 					sizes[i] = payload.Value().Size()
 				}
@@ -144,7 +154,7 @@ func valueSizes(sizes []int, paths []ledger.Path, head *node.Node) {
 				// doesn't require paths being deduplicated.
 			}
 		}
-		return
+		return nil
 	}
 
 	// reached an interim node with only one path
@@ -166,8 +176,7 @@ func valueSizes(sizes []int, paths []ledger.Path, head *node.Node) {
 			}
 		}
 
-		valueSizes(sizes, paths, head)
-		return
+		return valueSizes(sizes, paths, head)
 	}
 
 	// reached an interim node with more than one paths
@@ -183,32 +192,42 @@ func valueSizes(sizes []int, paths []ledger.Path, head *node.Node) {
 	// read values from left and right subtrees in parallel
 	parallelRecursionThreshold := 32 // threshold to avoid the parallelization going too deep in the recursion
 	if len(lpaths) < parallelRecursionThreshold || len(rpaths) < parallelRecursionThreshold {
-		valueSizes(lsizes, lpaths, head.LeftChild())
-		valueSizes(rsizes, rpaths, head.RightChild())
-	} else {
-		// concurrent read of left and right subtree
-		wg := sync.WaitGroup{}
-		wg.Add(1)
-		go func() {
-			valueSizes(lsizes, lpaths, head.LeftChild())
-			wg.Done()
-		}()
-		valueSizes(rsizes, rpaths, head.RightChild())
-		wg.Wait() // wait for all threads
+		err := valueSizes(lsizes, lpaths, head.LeftChild())
+		if err != nil {
+			return err
+		}
+		return valueSizes(rsizes, rpaths, head.RightChild())
+	}
+
+	// concurrent read of left and right subtree
+	var lErr error
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		lErr = valueSizes(lsizes, lpaths, head.LeftChild())
+		wg.Done()
+	}()
+	rErr := valueSizes(rsizes, rpaths, head.RightChild())
+	wg.Wait() // wait for all threads
+	if lErr != nil {
+		return lErr
 	}
+	return rErr
 }
 
 // ReadSinglePayload reads and returns a payload for a single path.
-func (mt *MTrie) ReadSinglePayload(path ledger.Path) *ledger.Payload {
+// No errors are expected during normal operation, errors can only occur if payloads were
+// paged out and can't be loaded (see node.Node.Payload).
+func (mt *MTrie) ReadSinglePayload(path ledger.Path) (*ledger.Payload, error) {
 	return readSinglePayload(path, mt.root)
 }
 
 // readSinglePayload reads and returns a payload for a single path in subtree with `head` as root node.
-func readSinglePayload(path ledger.Path, head *node.Node) *ledger.Payload {
+func readSinglePayload(path ledger.Path, head *node.Node) (*ledger.Payload, error) {
 	pathBytes := path[:]
 
 	if head == nil {
-		return ledger.EmptyPayload()
+		return ledger.EmptyPayload(), nil
 	}
 
 	depth := ledger.NodeMaxHeight - head.Height() // distance to the tree root
@@ -228,7 +247,7 @@ func readSinglePayload(path ledger.Path, head *node.Node) *ledger.Payload {
 		return head.Payload()
 	}
 
-	return ledger.EmptyPayload()
+	return ledger.EmptyPayload(), nil
 }
 
 // UnsafeRead reads payloads for the given paths.
@@ -240,11 +259,17 @@ func readSinglePayload(path ledger.Path, head *node.Node) *ledger.Payload {
 //     the read operation completes, the order of `path` and `payloads` are such that
 //     for `path[i]` the corresponding register value is referenced by 0`payloads[i]`.
 //
+// No errors are expected during normal operation, errors can only occur if payloads were
+// paged out and can't be loaded (see node.Node.Payload).
+//
 // TODO move consistency checks from Forest into Trie to obtain a safe, self-contained API
-func (mt *MTrie) UnsafeRead(paths []ledger.Path) []*ledger.Payload {
+func (mt *MTrie) UnsafeRead(paths []ledger.Path) ([]*ledger.Payload, error) {
 	payloads := make([]*ledger.Payload, len(paths)) // pre-allocate slice for the result
-	read(payloads, paths, mt.root)
-	return payloads
+	err := read(payloads, paths, mt.root)
+	if err != nil {
+		return nil, err
+	}
+	return payloads, nil
 }
 
 // read reads all the registers in subtree with `head` as root node. For each
@@ -252,10 +277,10 @@ func (mt *MTrie) UnsafeRead(paths []ledger.Path) []*ledger.Payload {
 // CAUTION:
 //   - while reading the payloads, `paths` is permuted IN-PLACE for optimized processing.
 //   - unchecked requirement: all paths must go through the `head` node
-func read(payloads []*ledger.Payload, paths []ledger.Path, head *node.Node) {
+func read(payloads []*ledger.Payload, paths []ledger.Path, head *node.Node) error {
 	// check for empty paths
 	if len(paths) == 0 {
-		return
+		return nil
 	}
 
 	// path not found
@@ -263,26 +288,34 @@ func read(payloads []*ledger.Payload, paths []ledger.Path, head *node.Node) {
 		for i := range paths {
 			payloads[i] = ledger.EmptyPayload()
 		}
-		return
+		return nil
 	}
 
 	// reached a leaf node
 	if head.IsLeaf() {
 		for i, p := range paths {
 			if *head.Path() == p {
-				payloads[i] = head.Payload()
+				payload, err := head.Payload()
+				if err != nil {
+					return err
+				}
+				payloads[i] = payload
 			} else {
 				payloads[i] = ledger.EmptyPayload()
 			}
 		}
-		return
+		return nil
 	}
 
 	// reached an interim node
 	if len(paths) == 1 {
 		// call readSinglePayload to skip partition and recursive calls when there is only one path
-		payloads[0] = readSinglePayload(paths[0], head)
-		return
+		payload, err := readSinglePayload(paths[0], head)
+		if err != nil {
+			return err
+		}
+		payloads[0] = payload
+		return nil
 	}
 
 	// partition step to quick sort the paths:
@@ -296,19 +329,27 @@ func read(payloads []*ledger.Payload, paths []ledger.Path, head *node.Node) {
 	// read values from left and right subtrees in parallel
 	parallelRecursionThreshold := 32 // threshold to avoid the parallelization going too deep in the recursion
 	if len(lpaths) < parallelRecursionThreshold || len(rpaths) < parallelRecursionThreshold {
-		read(lpayloads, lpaths, head.LeftChild())
-		read(rpayloads, rpaths, head.RightChild())
-	} else {
-		// concurrent read of left and right subtree
-		wg := sync.WaitGroup{}
-		wg.Add(1)
-		go func() {
-			read(lpayloads, lpaths, head.LeftChild())
-			wg.Done()
-		}()
-		read(rpayloads, rpaths, head.RightChild())
-		wg.Wait() // wait for all threads
+		err := read(lpayloads, lpaths, head.LeftChild())
+		if err != nil {
+			return err
+		}
+		return read(rpayloads, rpaths, head.RightChild())
 	}
+
+	// concurrent read of left and right subtree
+	var lErr error
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		lErr = read(lpayloads, lpaths, head.LeftChild())
+		wg.Done()
+	}()
+	rErr := read(rpayloads, rpaths, head.RightChild())
+	wg.Wait() // wait for all threads
+	if lErr != nil {
+		return lErr
+	}
+	return rErr
 }
 
 // NewTrieWithUpdatedRegisters constructs a new trie containing all registers from the parent trie,
@@ -335,7 +376,7 @@ func NewTrieWithUpdatedRegisters(
 	updatedPayloads []ledger.Payload,
 	prune bool,
 ) (*MTrie, uint16, error) {
-	updatedRoot, regCountDelta, regSizeDelta, lowestHeightTouched := update(
+	updatedRoot, regCountDelta, regSizeDelta, lowestHeightTouched, err := update(
 		ledger.NodeMaxHeight,
 		parentTrie.root,
 		updatedPaths,
@@ -343,6 +384,9 @@ func NewTrieWithUpdatedRegisters(
 		nil,
 		prune,
 	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("updating registers failed: %w", err)
+	}
 
 	updatedTrieRegCount := int64(parentTrie.AllocatedRegCount()) + regCountDelta
 	updatedTrieRegSize := int64(parentTrie.AllocatedRegSize()) + regSizeDelta
@@ -362,6 +406,7 @@ type updateResult struct {
 	allocatedRegCountDelta int64
 	allocatedRegSizeDelta  int64
 	lowestHeightTouched    int
+	err                    error
 }
 
 // update traverses the subtree, updates the stored registers, and returns:
@@ -369,6 +414,7 @@ type updateResult struct {
 //   - allocated register count delta in subtrie (allocatedRegCountDelta)
 //   - allocated register size delta in subtrie (allocatedRegSizeDelta)
 //   - lowest height reached during recursive update in subtrie (lowestHeightTouched)
+//   - error, if a paged out payload of the parent trie can't be loaded (see node.Node.Payload)
 //
 // allocatedRegCountDelta and allocatedRegSizeDelta are used to compute updated
 // trie's allocated register count and size.  lowestHeightTouched is used to
@@ -382,26 +428,30 @@ func update(
 	nodeHeight int, parentNode *node.Node,
 	paths []ledger.Path, payloads []ledger.Payload, compactLeaf *node.Node,
 	prune bool,
-) (n *node.Node, allocatedRegCountDelta int64, allocatedRegSizeDelta int64, lowestHeightTouched int) {
+) (n *node.Node, allocatedRegCountDelta int64, allocatedRegSizeDelta int64, lowestHeightTouched int, err error) {
 	// No new paths to write
 	if len(paths) == 0 {
 		// check is a compactLeaf from a higher height is still left.
 		if compactLeaf != nil {
 			// create a new node for the compact leaf path and payload. The old node shouldn't
 			// be recycled as it is still used by the tree copy before the update.
-			n = node.NewLeaf(*compactLeaf.Path(), compactLeaf.Payload(), nodeHeight)
-			return n, 0, 0, nodeHeight
+			payload, err := compactLeaf.Payload()
+			if err != nil {
+				return nil, 0, 0, 0, err
+			}
+			n = node.NewLeaf(*compactLeaf.Path(), payload, nodeHeight)
+			return n, 0, 0, nodeHeight, nil
 		}
-		return parentNode, 0, 0, nodeHeight
+		return parentNode, 0, 0, nodeHeight, nil
 	}
 
 	if len(paths) == 1 && parentNode == nil && compactLeaf == nil {
 		n = node.NewLeaf(paths[0], payloads[0].DeepCopy(), nodeHeight)
 		if payloads[0].IsEmpty() {
 			// Unallocated register doesn't affect allocatedRegCountDelta and allocatedRegSizeDelta.
-			return n, 0, 0, nodeHeight
+			return n, 0, 0, nodeHeight, nil
 		}
-		return n, 1, int64(payloads[0].Size()), nodeHeight
+		return n, 1, int64(payloads[0].Size()), nodeHeight, nil
 	}
 
 	if parentNode != nil && parentNode.IsLeaf() { // if we're here then compactLeaf == nil
@@ -410,24 +460,29 @@ func update(
 		parentPath := *parentNode.Path()
 		for i, p := range paths {
 			if p == parentPath {
+				parentPayload, err := parentNode.Payload()
+				if err != nil {
+					return nil, 0, 0, 0, err
+				}
+
 				// the case where the recursion stops: only one path to update
 				if len(paths) == 1 {
-					if !parentNode.Payload().ValueEquals(&payloads[i]) {
+					if !parentPayload.ValueEquals(&payloads[i]) {
 						n = node.NewLeaf(paths[i], payloads[i].DeepCopy(), nodeHeight)
 
 						allocatedRegCountDelta, allocatedRegSizeDelta =
-							computeAllocatedRegDeltas(parentNode.Payload(), &payloads[i])
+							computeAllocatedRegDeltas(parentPayload, &payloads[i])
 
-						return n, allocatedRegCountDelta, allocatedRegSizeDelta, nodeHeight
+						return n, allocatedRegCountDelta, allocatedRegSizeDelta, nodeHeight, nil
 					}
 					// avoid creating a new node when the same payload is written
-					return parentNode, 0, 0, nodeHeight
+					return parentNode, 0, 0, nodeHeight, nil
 				}
 				// the case where the recursion carries on: len(paths)>1
 				found = true
 
 				allocatedRegCountDelta, allocatedRegSizeDelta =
-					computeAllocatedRegDeltasFromHigherHeight(parentNode.Payload())
+					computeAllocatedRegDeltasFromHigherHeight(parentPayload)
 
 				break
 			}
@@ -476,11 +531,15 @@ func update(
 	var lRegCountDelta, rRegCountDelta int64
 	var lRegSizeDelta, rRegSizeDelta int64
 	var lLowestHeightTouched, rLowestHeightTouched int
+	var lErr, rErr error
 	parallelRecursionThreshold := 16
 	if len(lpaths) < parallelRecursionThreshold || len(rpaths) < parallelRecursionThreshold {
 		// runtime optimization: if there are _no_ updates for either left or right sub-tree, proceed single-threaded
-		lChild, lRegCountDelta, lRegSizeDelta, lLowestHeightTouched = update(nodeHeight-1, lchildParent, lpaths, lpayloads, lcompactLeaf, prune)
-		rChild, rRegCountDelta, rRegSizeDelta, rLowestHeightTouched = update(nodeHeight-1, rchildParent, rpaths, rpayloads, rcompactLeaf, prune)
+		lChild, lRegCountDelta, lRegSizeDelta, lLowestHeightTouched, lErr = update(nodeHeight-1, lchildParent, lpaths, lpayloads, lcompactLeaf, prune)
+		if lErr != nil {
+			return nil, 0, 0, 0, lErr
+		}
+		rChild, rRegCountDelta, rRegSizeDelta, rLowestHeightTouched, rErr = update(nodeHeight-1, rchildParent, rpaths, rpayloads, rcompactLeaf, prune)
 	} else {
 		// runtime optimization: process the left child is a separate thread
 
@@ -491,15 +550,21 @@ func update(
 		// channel is faster and uses fewer allocs/op in this case.
 		results := make(chan updateResult, 1)
 		go func(retChan chan<- updateResult) {
-			child, regCountDelta, regSizeDelta, lowestHeightTouched := update(nodeHeight-1, lchildParent, lpaths, lpayloads, lcompactLeaf, prune)
-			retChan <- updateResult{child, regCountDelta, regSizeDelta, lowestHeightTouched}
+			child, regCountDelta, regSizeDelta, lowestHeightTouched, err := update(nodeHeight-1, lchildParent, lpaths, lpayloads, lcompactLeaf, prune)
+			retChan <- updateResult{child, regCountDelta, regSizeDelta, lowestHeightTouched, err}
 		}(results)
 
-		rChild, rRegCountDelta, rRegSizeDelta, rLowestHeightTouched = update(nodeHeight-1, rchildParent, rpaths, rpayloads, rcompactLeaf, prune)
+		rChild, rRegCountDelta, rRegSizeDelta, rLowestHeightTouched, rErr = update(nodeHeight-1, rchildParent, rpaths, rpayloads, rcompactLeaf, prune)
 
 		// Wait for results from goroutine.
 		ret := <-results
-		lChild, lRegCountDelta, lRegSizeDelta, lLowestHeightTouched = ret.child, ret.allocatedRegCountDelta, ret.allocatedRegSizeDelta, ret.lowestHeightTouched
+		lChild, lRegCountDelta, lRegSizeDelta, lLowestHeightTouched, lErr = ret.child, ret.allocatedRegCountDelta, ret.allocatedRegSizeDelta, ret.lowestHeightTouched, ret.err
+		if lErr != nil {
+			return nil, 0, 0, 0, lErr
+		}
+	}
+	if rErr != nil {
+		return nil, 0, 0, 0, rErr
 	}
 
 	allocatedRegCountDelta += lRegCountDelta + rRegCountDelta
@@ -511,18 +576,18 @@ func update(
 	// unchanged. This is only sufficient for interim nodes (for leaf nodes, the children
 	// might be unchanged, i.e. both nil, but the payload could have changed).
 	if !parentNode.IsLeaf() && lChild == lchildParent && rChild == rchildParent {
-		return parentNode, 0, 0, lowestHeightTouched
+		return parentNode, 0, 0, lowestHeightTouched, nil
 	}
 
 	// In case the parent node was a leaf, we _cannot reuse_ it, because we potentially
 	// updated registers in the sub-trie
 	if prune {
 		n = node.NewInterimCompactifiedNode(nodeHeight, lChild, rChild)
-		return n, allocatedRegCountDelta, allocatedRegSizeDelta, lowestHeightTouched
+		return n, allocatedRegCountDelta, allocatedRegSizeDelta, lowestHeightTouched, nil
 	}
 
 	n = node.NewInterimNode(nodeHeight, lChild, rChild)
-	return n, allocatedRegCountDelta, allocatedRegSizeDelta, lowestHeightTouched
+	return n, allocatedRegCountDelta, allocatedRegSizeDelta, lowestHeightTouched, nil
 }
 
 // computeAllocatedRegDeltasFromHigherHeight returns the deltas
@@ -565,10 +630,15 @@ func computeAllocatedRegDeltas(oldPayload, newPayload *ledger.Payload) (allocate
 // UNSAFE: requires _all_ paths to have a length of mt.Height bits.
 // Paths in the input query don't have to be deduplicated, though deduplication would
 // result in allocating less dynamic memory to store the proofs.
-func (mt *MTrie) UnsafeProofs(paths []ledger.Path) *ledger.TrieBatchProof {
+// No errors are expected during normal operation, errors can only occur if payloads were
+// paged out and can't be loaded (see node.Node.Payload).
+func (mt *MTrie) UnsafeProofs(paths []ledger.Path) (*ledger.TrieBatchProof, error) {
 	batchProofs := ledger.NewTrieBatchProofWithEmptyProofs(len(paths))
-	prove(mt.root, paths, batchProofs.Proofs)
-	return batchProofs
+	err := prove(mt.root, paths, batchProofs.Proofs)
+	if err != nil {
+		return nil, err
+	}
+	return batchProofs, nil
 }
 
 // prove traverses the subtree and stores proofs for the given register paths in
@@ -577,17 +647,17 @@ func (mt *MTrie) UnsafeProofs(paths []ledger.Path) *ledger.TrieBatchProof {
 // UNSAFE: method requires the following conditions to be satisfied:
 //   - paths all share the same common prefix [0 : mt.maxHeight-1 - nodeHeight)
 //     (excluding the bit at index headHeight)
-func prove(head *node.Node, paths []ledger.Path, proofs []*ledger.TrieProof) {
+func prove(head *node.Node, paths []ledger.Path, proofs []*ledger.TrieProof) error {
 	// check for empty paths
 	if len(paths) == 0 {
-		return
+		return nil
 	}
 
 	// we've reached the end of a trie
 	// and path is not found (noninclusion proof)
 	if head == nil {
 		// by default, proofs are non-inclusion proofs
-		return
+		return nil
 	}
 
 	// we've reached a leaf
@@ -595,13 +665,17 @@ func prove(head *node.Node, paths []ledger.Path, proofs []*ledger.TrieProof) {
 		for i, path := range paths {
 			// value matches (inclusion proof)
 			if *head.Path() == path {
+				payload, err := head.Payload()
+				if err != nil {
+					return err
+				}
 				proofs[i].Path = *head.Path()
-				proofs[i].Payload = head.Payload()
+				proofs[i].Payload = payload
 				proofs[i].Inclusion = true
 			}
 		}
 		// by default, proofs are non-inclusion proofs
-		return
+		return nil
 	}
 
 	// increment steps for all the proofs
@@ -621,23 +695,31 @@ func prove(head *node.Node, paths []ledger.Path, proofs []*ledger.TrieProof) {
 	if len(lpaths) < parallelRecursionThreshold || len(rpaths) < parallelRecursionThreshold {
 		// runtime optimization: below the parallelRecursionThreshold, we proceed single-threaded
 		addSiblingTrieHashToProofs(head.RightChild(), depth, lproofs)
-		prove(head.LeftChild(), lpaths, lproofs)
+		err := prove(head.LeftChild(), lpaths, lproofs)
+		if err != nil {
+			return err
+		}
 
 		addSiblingTrieHashToProofs(head.LeftChild(), depth, rproofs)
-		prove(head.RightChild(), rpaths, rproofs)
-	} else {
-		wg := sync.WaitGroup{}
-		wg.Add(1)
-		go func() {
-			addSiblingTrieHashToProofs(head.RightChild(), depth, lproofs)
-			prove(head.LeftChild(), lpaths, lproofs)
-			wg.Done()
-		}()
+		return prove(head.RightChild(), rpaths, rproofs)
+	}
 
-		addSiblingTrieHashToProofs(head.LeftChild(), depth, rproofs)
-		prove(head.RightChild(), rpaths, rproofs)
-		wg.Wait()
+	var lErr error
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		addSiblingTrieHashToProofs(head.RightChild(), depth, lproofs)
+		lErr = prove(head.LeftChild(), lpaths, lproofs)
+		wg.Done()
+	}()
+
+	addSiblingTrieHashToProofs(head.LeftChild(), depth, rproofs)
+	rErr := prove(head.RightChild(), rpaths, rproofs)
+	wg.Wait()
+	if lErr != nil {
+		return lErr
 	}
+	return rErr
 }
 
 // addSiblingTrieHashToProofs inspects the sibling Trie and adds its root hash
@@ -698,7 +780,11 @@ func (mt *MTrie) DumpAsJSON(w io.Writer) error {
 func dumpAsJSON(n *node.Node, encoder *json.Encoder) error {
 	if n.IsLeaf() {
 		if n != nil {
-			err := encoder.Encode(n.Payload())
+			payload, err := n.Payload()
+			if err != nil {
+				return err
+			}
+			err = encoder.Encode(payload)
 			if err != nil {
 				return err
 			}
@@ -728,7 +814,8 @@ func EmptyTrieRootHash() ledger.RootHash {
 }
 
 // AllPayloads returns all payloads
-func (mt *MTrie) AllPayloads() []ledger.Payload {
+// No errors are expected during normal operation, see node.Node.Payload for details.
+func (mt *MTrie) AllPayloads() ([]ledger.Payload, error) {
 	return mt.root.AllPayloads()
 }
 
@@ -708,13 +708,15 @@ func Test_Pruning(t *testing.T) {
 				queryPaths = append(queryPaths, path)
 			}
 
-			payloads := activeTrie.UnsafeRead(queryPaths)
+			payloads, err := activeTrie.UnsafeRead(queryPaths)
+			require.NoError(t, err)
 			for i, pp := range payloads {
 				expectedPayload := allPaths[queryPaths[i]]
 				require.True(t, pp.Equals(&expectedPayload))
 			}
 
-			payloads = activeTrieWithPruning.UnsafeRead(queryPaths)
+			payloads, err = activeTrieWithPruning.UnsafeRead(queryPaths)
+			require.NoError(t, err)
 			for i, pp := range payloads {
 				expectedPayload := allPaths[queryPaths[i]]
 				require.True(t, pp.Equals(&expectedPayload))
@@ -737,7 +739,8 @@ func TestValueSizes(t *testing.T) {
 	t.Run("empty trie", func(t *testing.T) {
 		path := testutils.PathByUint16LeftPadded(0)
 		pathsToGetValueSize := []ledger.Path{path}
-		sizes := emptyTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err := emptyTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		require.Equal(t, 0, sizes[0])
 	})
@@ -759,7 +762,8 @@ func TestValueSizes(t *testing.T) {
 
 		pathsToGetValueSize := []ledger.Path{path1, path2}
 
-		sizes := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		require.Equal(t, payload1.Value().Size(), sizes[0])
 		require.Equal(t, 0, sizes[1])
@@ -801,7 +805,8 @@ func TestValueSizes(t *testing.T) {
 		}
 
 		// Test value sizes for a mix of existent and non-existent paths.
-		sizes := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		for i, p := range pathsToGetValueSize {
 			switch p {
@@ -817,13 +822,15 @@ func TestValueSizes(t *testing.T) {
 
 		// Test value size for a single existent path
 		pathsToGetValueSize = []ledger.Path{path1}
-		sizes = newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err = newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		require.Equal(t, payload1.Value().Size(), sizes[0])
 
 		// Test value size for a single non-existent path
 		pathsToGetValueSize = []ledger.Path{testutils.PathByUint16(3 << 12)}
-		sizes = newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		sizes, err = newTrie.UnsafeValueSizes(pathsToGetValueSize)
+		require.NoError(t, err)
 		require.Equal(t, len(pathsToGetValueSize), len(sizes))
 		require.Equal(t, 0, sizes[0])
 	})
@@ -852,7 +859,8 @@ func TestValueSizesWithDuplicatePaths(t *testing.T) {
 		path1, path2, path3,
 	}
 
-	sizes := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+	sizes, err := newTrie.UnsafeValueSizes(pathsToGetValueSize)
+	require.NoError(t, err)
 	require.Equal(t, len(pathsToGetValueSize), len(sizes))
 	for i, p := range pathsToGetValueSize {
 		switch p {
@@ -1116,7 +1124,8 @@ func TestReadSinglePayload(t *testing.T) {
 		savedRootHash := emptyTrie.RootHash()
 
 		path := testutils.PathByUint16LeftPadded(0)
-		payload := emptyTrie.ReadSinglePayload(path)
+		payload, err := emptyTrie.ReadSinglePayload(path)
+		require.NoError(t, err)
 		require.True(t, payload.IsEmpty())
 		require.Equal(t, savedRootHash, emptyTrie.RootHash())
 	})
@@ -1137,13 +1146,15 @@ func TestReadSinglePayload(t *testing.T) {
 		savedRootHash := newTrie.RootHash()
 
 		// Get payload for existent path path
-		retPayload := newTrie.ReadSinglePayload(path1)
+		retPayload, err := newTrie.ReadSinglePayload(path1)
+		require.NoError(t, err)
 		require.Equal(t, payload1, retPayload)
 		require.Equal(t, savedRootHash, newTrie.RootHash())
 
 		// Get payload for non-existent path
 		path2 := testutils.PathByUint16LeftPadded(1)
-		retPayload = newTrie.ReadSinglePayload(path2)
+		retPayload, err = newTrie.ReadSinglePayload(path2)
+		require.NoError(t, err)
 		require.True(t, retPayload.IsEmpty())
 		require.Equal(t, savedRootHash, newTrie.RootHash())
 	})
@@ -1185,7 +1196,8 @@ func TestReadSinglePayload(t *testing.T) {
 		for i := 0; i < 16; i++ {
 			path := testutils.PathByUint16(uint16(i << 12))
 
-			retPayload := newTrie.ReadSinglePayload(path)
+			retPayload, err := newTrie.ReadSinglePayload(path)
+			require.NoError(t, err)
 			require.Equal(t, savedRootHash, newTrie.RootHash())
 			switch path {
 			case path1:
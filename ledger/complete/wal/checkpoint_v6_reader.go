@@ -11,6 +11,7 @@ import (
 
 	"github.com/rs/zerolog"
 
+	"github.com/onflow/flow-go/ledger"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/flattener"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/node"
 	"github.com/onflow/flow-go/ledger/complete/mtrie/trie"
@@ -31,7 +32,11 @@ var ErrEOFNotReached = errors.New("expect to reach EOF, but actually didn't")
 // it returns (nil, os.ErrNotExist) if a certain file is missing, use (os.IsNotExist to check)
 // it returns (nil, ErrEOFNotReached) if a certain part file is malformed
 // it returns (nil, err) if running into any exception
-func readCheckpointV6(headerFile *os.File, logger *zerolog.Logger) ([]*trie.MTrie, error) {
+//
+// If a payload store is given, the payloads of leaves are paged out to the store while the
+// checkpoint is read, so they are never held in memory all at once. The returned tries are
+// marked as paged out (see trie.MTrie.PayloadsPagedOut).
+func readCheckpointV6(headerFile *os.File, logger *zerolog.Logger, payloadStore node.PayloadStore) ([]*trie.MTrie, error) {
 	// the full path of header file
 	headerPath := headerFile.Name()
 	dir, fileName := filepath.Split(headerPath)
@@ -51,19 +56,53 @@ func readCheckpointV6(headerFile *os.File, logger *zerolog.Logger) ([]*trie.MTri
 		return nil, fmt.Errorf("fail to check all checkpoint part file exist: %w", err)
 	}
 
-	// TODO making number of goroutine configable for reading subtries, which can help us
-	// test the code on machines that don't have as much RAM as EN by using fewer goroutines.
-	subtrieNodes, err := readSubTriesConcurrently(dir, fileName, subtrieChecksums, &lg)
+	// the store records the checkpoint whose payloads it holds already, so they don't have to
+	// be written again
+	var recorded *node.CheckpointRecord
+	if payloadStore != nil {
+		recorded, err = payloadStore.Checkpoint()
+		if err != nil {
+			return nil, fmt.Errorf("could not read checkpoint recorded by payload store: %w", err)
+		}
+		if recorded != nil && recorded.FileName != fileName {
+			recorded = nil
+		}
+	}
+
+	tries, err := readTries(dir, fileName, subtrieChecksums, topTrieChecksum, &lg, payloadStore, recorded != nil)
 	if err != nil {
-		return nil, fmt.Errorf("could not read subtrie from dir: %w", err)
+		return nil, err
 	}
 
-	lg.Info().Uint32("topsum", topTrieChecksum).
-		Msg("finish reading all v6 subtrie files, start reading top level tries")
+	if recorded != nil && !recorded.Matches(rootHashes(tries)) {
+		// a different checkpoint with the same file name, e.g. a new root checkpoint
+		lg.Warn().Msg("payload store holds the payloads of a different checkpoint, writing payloads again")
+		recorded = nil
+		tries, err = readTries(dir, fileName, subtrieChecksums, topTrieChecksum, &lg, payloadStore, false)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	tries, err := readTopLevelTries(dir, fileName, subtrieNodes, topTrieChecksum, &lg)
-	if err != nil {
-		return nil, fmt.Errorf("could not read top level nodes or tries: %w", err)
+	if payloadStore != nil {
+		// all leaves were paged out while reading, so this only marks the tries as paged out
+		var previous *trie.MTrie
+		for _, t := range tries {
+			err = t.PageOutPayloads(previous, payloadStore)
+			if err != nil {
+				return nil, fmt.Errorf("could not page out payloads: %w", err)
+			}
+			previous = t
+		}
+
+		if recorded == nil {
+			err = payloadStore.SetCheckpoint(&node.CheckpointRecord{FileName: fileName, RootHashes: rootHashes(tries)})
+			if err != nil {
+				return nil, fmt.Errorf("could not record checkpoint in payload store: %w", err)
+			}
+		} else {
+			lg.Info().Msg("payloads of checkpoint were held by payload store already")
+		}
 	}
 
 	lg.Info().Msgf("finish reading all trie roots, trie root count: %v", len(tries))
@@ -82,6 +121,44 @@ func readCheckpointV6(headerFile *os.File, logger *zerolog.Logger) ([]*trie.MTri
 	return tries, nil
 }
 
+// readTries reads the subtrie and top level trie files of the checkpoint. If payloadsStored is
+// true, the payloads of leaves are expected to be held by the given payload store already, and
+// are not written again.
+func readTries(
+	dir string,
+	fileName string,
+	subtrieChecksums []uint32,
+	topTrieChecksum uint32,
+	logger *zerolog.Logger,
+	payloadStore node.PayloadStore,
+	payloadsStored bool,
+) ([]*trie.MTrie, error) {
+	// TODO making number of goroutine configable for reading subtries, which can help us
+	// test the code on machines that don't have as much RAM as EN by using fewer goroutines.
+	subtrieNodes, err := readSubTriesConcurrently(dir, fileName, subtrieChecksums, logger, payloadStore, payloadsStored)
+	if err != nil {
+		return nil, fmt.Errorf("could not read subtrie from dir: %w", err)
+	}
+
+	logger.Info().Uint32("topsum", topTrieChecksum).
+		Msg("finish reading all v6 subtrie files, start reading top level tries")
+
+	tries, err := readTopLevelTries(dir, fileName, subtrieNodes, topTrieChecksum, logger, payloadStore, payloadsStored)
+	if err != nil {
+		return nil, fmt.Errorf("could not read top level nodes or tries: %w", err)
+	}
+	return tries, nil
+}
+
+// rootHashes returns the root hashes of the given tries.
+func rootHashes(tries []*trie.MTrie) []ledger.RootHash {
+	hashes := make([]ledger.RootHash, 0, len(tries))
+	for _, t := range tries {
+		hashes = append(hashes, t.RootHash())
+	}
+	return hashes
+}
+
 // OpenAndReadCheckpointV6 open the checkpoint file and read it with readCheckpointV6
 func OpenAndReadCheckpointV6(dir string, fileName string, logger *zerolog.Logger) (
 	tries []*trie.MTrie,
@@ -97,7 +174,7 @@ func OpenAndReadCheckpointV6(dir string, fileName string, logger *zerolog.Logger
 		errToReturn = closeAndMergeError(file, errToReturn)
 	}(f)
 
-	return readCheckpointV6(f, logger)
+	return readCheckpointV6(f, logger, nil)
 }
 
 func filePathCheckpointHeader(dir string, fileName string) string {
@@ -117,6 +194,18 @@ func filePathTopTries(dir string, fileName string) (string, string) {
 	return path.Join(dir, topTriesFileName), topTriesFileName
 }
 
+// CheckpointFileNames returns the names of all files of the checkpoint with the given
+// file name, starting with the header file, followed by the part files of the subtries
+// and the part file of the top level tries.
+func CheckpointFileNames(fileName string) []string {
+	names := make([]string, 0, subtrieCount+2)
+	names = append(names, fileName)
+	for i := 0; i <= subtrieCount; i++ {
+		names = append(names, partFileName(fileName, i))
+	}
+	return names
+}
+
 func partFileName(fileName string, index int) string {
 	return fmt.Sprintf("%v.%03d", fileName, index)
 }
@@ -278,7 +367,14 @@ type resultReadSubTrie struct {
 	Err   error
 }
 
-func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []uint32, logger *zerolog.Logger) ([][]*node.Node, error) {
+func readSubTriesConcurrently(
+	dir string,
+	fileName string,
+	subtrieChecksums []uint32,
+	logger *zerolog.Logger,
+	payloadStore node.PayloadStore,
+	payloadsStored bool,
+) ([][]*node.Node, error) {
 
 	numOfSubTries := len(subtrieChecksums)
 	jobs := make(chan jobReadSubtrie, numOfSubTries)
@@ -301,7 +397,7 @@ func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []ui
 	for i := 0; i < nWorker; i++ {
 		go func() {
 			for job := range jobs {
-				nodes, err := readCheckpointSubTrie(dir, fileName, job.Index, job.Checksum, logger)
+				nodes, err := readCheckpointSubTrie(dir, fileName, job.Index, job.Checksum, logger, payloadStore, payloadsStored)
 				job.Result <- &resultReadSubTrie{
 					Nodes: nodes,
 					Err:   err,
@@ -330,7 +426,18 @@ func readSubTriesConcurrently(dir string, fileName string, subtrieChecksums []ui
 // 2. nodes
 // 3. node count
 // 4. checksum
-func readCheckpointSubTrie(dir string, fileName string, index int, checksum uint32, logger *zerolog.Logger) (
+//
+// If a payload store is given, the payloads of leaves are paged out to the store in a single batch,
+// unless payloadsStored is true.
+func readCheckpointSubTrie(
+	dir string,
+	fileName string,
+	index int,
+	checksum uint32,
+	logger *zerolog.Logger,
+	payloadStore node.PayloadStore,
+	payloadsStored bool,
+) (
 	subtrieRootNodes []*node.Node,
 	errToReturn error,
 ) {
@@ -382,18 +489,23 @@ func readCheckpointSubTrie(dir string, fileName string, index int, checksum uint
 		return nil, fmt.Errorf("could not read version again for subtrie: %w", err)
 	}
 
+	batch, flush := newPayloadBatch(payloadStore, payloadsStored)
+	defer func() {
+		errToReturn = flush(errToReturn)
+	}()
+
 	// read file part index and verify
 	scratch := make([]byte, 1024*4) // must not be less than 1024
 	logging := logProgress(fmt.Sprintf("reading %v-th sub trie roots", index), int(nodesCount), logger)
 
 	nodes := make([]*node.Node, nodesCount+1) //+1 for 0 index meaning nil
 	for i := uint64(1); i <= nodesCount; i++ {
-		node, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+		node, err := flattener.ReadNodeToPayloadStore(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
 			if nodeIndex >= i {
 				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
 			}
 			return nodes[nodeIndex], nil
-		})
+		}, payloadStore, batch)
 		if err != nil {
 			return nil, fmt.Errorf("cannot read node %d: %w", i, err)
 		}
@@ -431,6 +543,29 @@ func readCheckpointSubTrie(dir string, fileName string, index int, checksum uint
 	return nodes[1:], nil
 }
 
+// newPayloadBatch returns a new batch for paging out the payloads of the leaves read from a
+// checkpoint file to the given store, and a function to be deferred by the reader, which flushes
+// the batch if reading succeeded, and discards it otherwise. If the store is nil, or holds the
+// payloads already, the batch is nil.
+func newPayloadBatch(payloadStore node.PayloadStore, payloadsStored bool) (node.PayloadBatch, func(error) error) {
+	if payloadStore == nil || payloadsStored {
+		return nil, func(err error) error { return err }
+	}
+
+	batch := payloadStore.NewBatch()
+	return batch, func(err error) error {
+		if err != nil {
+			batch.Cancel()
+			return err
+		}
+		err = batch.Flush()
+		if err != nil {
+			return fmt.Errorf("could not page out payloads: %w", err)
+		}
+		return nil
+	}
+}
+
 func readSubTriesFooter(f *os.File) (uint64, uint32, error) {
 	const footerSize = encNodeCountSize // footer doesn't include crc32 sum
 	const footerOffset = footerSize + crc32SumSize
@@ -468,7 +603,15 @@ func readSubTriesFooter(f *os.File) (uint64, uint32, error) {
 // 5. node count
 // 6. trie count
 // 7. checksum
-func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node, topTrieChecksum uint32, logger *zerolog.Logger) (
+func readTopLevelTries(
+	dir string,
+	fileName string,
+	subtrieNodes [][]*node.Node,
+	topTrieChecksum uint32,
+	logger *zerolog.Logger,
+	payloadStore node.PayloadStore,
+	payloadsStored bool,
+) (
 	rootTries []*trie.MTrie,
 	errToReturn error,
 ) {
@@ -546,15 +689,20 @@ func readTopLevelTries(dir string, fileName string, subtrieNodes [][]*node.Node,
 	// be large enough to handle almost all payloads and 100% of interim nodes.
 	scratch := make([]byte, 1024*4) // must not be less than 1024
 
+	batch, flush := newPayloadBatch(payloadStore, payloadsStored)
+	defer func() {
+		errToReturn = flush(errToReturn)
+	}()
+
 	// read the nodes from subtrie level to the root level
 	for i := uint64(1); i <= topLevelNodesCount; i++ {
-		node, err := flattener.ReadNode(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
+		node, err := flattener.ReadNodeToPayloadStore(reader, scratch, func(nodeIndex uint64) (*node.Node, error) {
 			if nodeIndex >= i+uint64(totalSubTrieNodeCount) {
 				return nil, fmt.Errorf("sequence of serialized nodes does not satisfy Descendents-First-Relationship")
 			}
 
 			return getNodeByIndex(subtrieNodes, totalSubTrieNodeCount, topLevelNodes, nodeIndex)
-		})
+		}, payloadStore, batch)
 		if err != nil {
 			return nil, fmt.Errorf("cannot read node at index %d: %w", i, err)
 		}
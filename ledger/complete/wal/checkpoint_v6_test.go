@@ -175,7 +175,7 @@ func TestEncodeSubTrie(t *testing.T) {
 				uniqueIndices, nodeCount, checksum)
 
 			// all the nodes
-			nodes, err := readCheckpointSubTrie(dir, file, index, checksum, &logger)
+			nodes, err := readCheckpointSubTrie(dir, file, index, checksum, &logger, nil, false)
 			require.NoError(t, err)
 
 			for _, root := range roots {
@@ -257,6 +257,78 @@ func TestWriteAndReadCheckpointV6MultipleTries(t *testing.T) {
 	})
 }
 
+// TestReadCheckpointV6ToPayloadStore verifies that the payloads of a checkpoint are paged out
+// to the given store while the checkpoint is read.
+func TestReadCheckpointV6ToPayloadStore(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint-multi-file"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+
+		store := testutils.NewMemoryPayloadStore()
+		decoded, err := loadCheckpoint(filepath.Join(dir, fileName), &logger, store)
+		require.NoErrorf(t, err, "fail to read checkpoint %v/%v", dir, fileName)
+		requireTriesEqual(t, tries, decoded)
+
+		// every file of the checkpoint is written in a single batch
+		require.Equal(t, 1+subtrieCount, store.Flushes)
+		for i, decodedTrie := range decoded {
+			require.True(t, decodedTrie.PayloadsPagedOut())
+			require.True(t, decodedTrie.IsAValidTrie())
+
+			expected, err := tries[i].AllPayloads()
+			require.NoError(t, err)
+			actual, err := decodedTrie.AllPayloads()
+			require.NoError(t, err)
+			require.ElementsMatch(t, expected, actual)
+		}
+	})
+}
+
+// TestReadCheckpointV6FromPayloadStore verifies that the payloads of a checkpoint are not written
+// again if the store recorded that it holds them, unless the recorded root hashes don't match.
+func TestReadCheckpointV6FromPayloadStore(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createMultipleRandomTries(t)
+		fileName := "checkpoint-multi-file"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+
+		store := testutils.NewMemoryPayloadStore()
+		_, err := loadCheckpoint(filepath.Join(dir, fileName), &logger, store)
+		require.NoError(t, err)
+		recorded, err := store.Checkpoint()
+		require.NoError(t, err)
+		require.Equal(t, fileName, recorded.FileName)
+		require.True(t, recorded.Matches(rootHashes(tries)))
+		stores := store.Stores
+
+		// loading the recorded checkpoint again doesn't write any payloads
+		decoded, err := loadCheckpoint(filepath.Join(dir, fileName), &logger, store)
+		require.NoError(t, err)
+		requireTriesEqual(t, tries, decoded)
+		require.Equal(t, stores, store.Stores)
+		for i, decodedTrie := range decoded {
+			require.True(t, decodedTrie.PayloadsPagedOut())
+			expected, err := tries[i].AllPayloads()
+			require.NoError(t, err)
+			actual, err := decodedTrie.AllPayloads()
+			require.NoError(t, err)
+			require.ElementsMatch(t, expected, actual)
+		}
+
+		// a different checkpoint with the same file name has its payloads written again
+		require.NoError(t, store.SetCheckpoint(&node.CheckpointRecord{FileName: fileName, RootHashes: rootHashes(tries[1:])}))
+		_, err = loadCheckpoint(filepath.Join(dir, fileName), &logger, store)
+		require.NoError(t, err)
+		require.Equal(t, 2*stores, store.Stores)
+		recorded, err = store.Checkpoint()
+		require.NoError(t, err)
+		require.True(t, recorded.Matches(rootHashes(tries)))
+	})
+}
+
 // test running checkpointing twice will produce the same checkpoint file
 func TestCheckpointV6IsDeterminstic(t *testing.T) {
 	unittest.RunWithTempDir(t, func(dir string) {
@@ -462,3 +534,20 @@ func TestCopyCheckpointFileV6(t *testing.T) {
 		requireTriesEqual(t, tries, decoded)
 	})
 }
+
+func TestCheckpointFileNames(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		tries := createSimpleTrie(t)
+		fileName := "checkpoint"
+		logger := unittest.Logger()
+		require.NoErrorf(t, StoreCheckpointV6Concurrently(tries, dir, fileName, &logger), "fail to store checkpoint")
+
+		names := CheckpointFileNames(fileName)
+		expected := filePaths(dir, fileName, subtrieLevel)
+		require.Len(t, names, len(expected))
+		for i, name := range names {
+			require.Equal(t, expected[i], filepath.Join(dir, name))
+			require.FileExists(t, filepath.Join(dir, name))
+		}
+	})
+}
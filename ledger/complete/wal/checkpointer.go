@@ -230,7 +230,7 @@ func (c *Checkpointer) Checkpoint(to int) (err error) {
 			return err
 		}, func(rootHash ledger.RootHash) error {
 			return nil
-		}, true)
+		}, true, nil)
 
 	if err != nil {
 		return fmt.Errorf("cannot replay WAL: %w", err)
@@ -566,8 +566,11 @@ func storeUniqueNodes(
 			}
 		}
 
-		encNode := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
-		_, err := writer.Write(encNode)
+		encNode, err := flattener.EncodeNode(n, lchildIndex, rchildIndex, scratch)
+		if err != nil {
+			return 0, fmt.Errorf("cannot encode node: %w", err)
+		}
+		_, err = writer.Write(encNode)
 		if err != nil {
 			return 0, fmt.Errorf("cannot serialize node: %w", err)
 		}
@@ -605,13 +608,25 @@ func getNodesAtLevel(root *node.Node, level uint) []*node.Node {
 }
 
 func (c *Checkpointer) LoadCheckpoint(checkpoint int) ([]*trie.MTrie, error) {
+	return c.loadCheckpoint(checkpoint, nil)
+}
+
+// loadCheckpoint loads the given checkpoint. If a payload store is given, the payloads
+// of leaves are paged out to it while the checkpoint is read (see readCheckpoint).
+func (c *Checkpointer) loadCheckpoint(checkpoint int, payloadStore node.PayloadStore) ([]*trie.MTrie, error) {
 	filepath := path.Join(c.dir, NumberToFilename(checkpoint))
-	return LoadCheckpoint(filepath, &c.wal.log)
+	return loadCheckpoint(filepath, &c.wal.log, payloadStore)
 }
 
 func (c *Checkpointer) LoadRootCheckpoint() ([]*trie.MTrie, error) {
+	return c.loadRootCheckpoint(nil)
+}
+
+// loadRootCheckpoint loads the root checkpoint. If a payload store is given, the payloads
+// of leaves are paged out to it while the checkpoint is read (see readCheckpoint).
+func (c *Checkpointer) loadRootCheckpoint(payloadStore node.PayloadStore) ([]*trie.MTrie, error) {
 	filepath := path.Join(c.dir, bootstrap.FilenameWALRootCheckpoint)
-	return LoadCheckpoint(filepath, &c.wal.log)
+	return loadCheckpoint(filepath, &c.wal.log, payloadStore)
 }
 
 func (c *Checkpointer) HasRootCheckpoint() (bool, error) {
@@ -633,7 +648,11 @@ func (c *Checkpointer) RemoveCheckpoint(checkpoint int) error {
 	return deleteCheckpointFiles(c.dir, name)
 }
 
-func LoadCheckpoint(filepath string, logger *zerolog.Logger) (
+func LoadCheckpoint(filepath string, logger *zerolog.Logger) ([]*trie.MTrie, error) {
+	return loadCheckpoint(filepath, logger, nil)
+}
+
+func loadCheckpoint(filepath string, logger *zerolog.Logger, payloadStore node.PayloadStore) (
 	tries []*trie.MTrie,
 	errToReturn error) {
 	file, err := os.Open(filepath)
@@ -650,10 +669,14 @@ func LoadCheckpoint(filepath string, logger *zerolog.Logger) (
 		errToReturn = closeAndMergeError(file, errToReturn)
 	}()
 
-	return readCheckpoint(file, logger)
+	return readCheckpoint(file, logger, payloadStore)
 }
 
-func readCheckpoint(f *os.File, logger *zerolog.Logger) ([]*trie.MTrie, error) {
+// readCheckpoint reads the tries from the given checkpoint file. If a payload store is given,
+// the payloads of leaves in version 6 checkpoints are paged out to the store while they are read.
+// Tries of earlier versions are read into memory entirely, and are paged out once they are added
+// to the forest.
+func readCheckpoint(f *os.File, logger *zerolog.Logger, payloadStore node.PayloadStore) ([]*trie.MTrie, error) {
 
 	// Read header: magic (2 bytes) + version (2 bytes)
 	header := make([]byte, headerSize)
@@ -684,7 +707,7 @@ func readCheckpoint(f *os.File, logger *zerolog.Logger) ([]*trie.MTrie, error) {
 	case VersionV5:
 		return readCheckpointV5(f, logger)
 	case VersionV6:
-		return readCheckpointV6(f, logger)
+		return readCheckpointV6(f, logger, payloadStore)
 	default:
 		return nil, fmt.Errorf("unsupported file version %x", version)
 	}
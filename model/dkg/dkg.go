@@ -11,3 +11,50 @@ type DKGData struct {
 	PubGroupKey   crypto.PublicKey
 	PubKeyShares  []crypto.PublicKey
 }
+
+// ResumeState captures the progress of an in-progress DKG for the local node.
+// It is persisted while the DKG is running so that a node which restarts during
+// the EpochSetup phase can resume its participation, rather than dropping out
+// of the DKG for the epoch.
+//
+// The local Feldman VSS state is not serialized directly. Instead, it is
+// reconstructed by restarting the DKG with the same Seed (which deterministically
+// reproduces our secret polynomial) and replaying the ordered log of processed
+// messages and phase transitions (see LogEntry).
+//
+// CAUTION: the seed is confidential, as it determines the node's share of the
+// beacon key. It must only be stored in the secrets database.
+type ResumeState struct {
+	// Seed is the seed used to start the local DKG instance.
+	Seed []byte
+	// Phase is the last phase entered by the DKG controller.
+	Phase uint32
+	// BroadcastsSent is the number of broadcast messages published by this node.
+	BroadcastsSent uint
+	// PrivateMessagesSent is the number of private messages sent by this node.
+	PrivateMessagesSent uint
+	// BroadcastOffset is the number of messages read from the DKG smart contract.
+	BroadcastOffset uint
+}
+
+// LogEntryType enumerates the types of events recorded in the DKG replay log.
+type LogEntryType uint8
+
+const (
+	// LogEntryPrivateMessage records a processed private message.
+	LogEntryPrivateMessage LogEntryType = iota + 1
+	// LogEntryBroadcastMessage records a processed broadcast message.
+	LogEntryBroadcastMessage
+	// LogEntryTimeout records a phase transition (a DKG timeout).
+	LogEntryTimeout
+)
+
+// LogEntry is an event processed by the local DKG instance. Replaying the log
+// in sequence order, after restarting the DKG with the persisted seed, restores
+// the local DKG state.
+type LogEntry struct {
+	Seq                  uint64
+	Type                 LogEntryType
+	CommitteeMemberIndex uint64
+	Data                 []byte
+}
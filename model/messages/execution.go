@@ -9,15 +9,20 @@ import (
 // ChunkDataRequest represents a request for the a chunk data pack
 // which is specified by a chunk ID.
 type ChunkDataRequest struct {
-	ChunkID flow.Identifier
-	Nonce   uint64 // so that we aren't deduplicated by the network layer
+	ChunkID       flow.Identifier
+	Nonce         uint64 // so that we aren't deduplicated by the network layer
+	BlobsAccepted bool   // requester is able to retrieve the chunk data pack as content-addressed blobs
 }
 
 // ChunkDataResponse is the response to a chunk data pack request.
 // It contains the chunk data pack of the interest.
+// If BlobID is non-zero, the chunk data pack only carries the chunk ID, and its
+// content is published as content-addressed blobs rooted at BlobID, which can be
+// retrieved from any node holding them.
 type ChunkDataResponse struct {
 	ChunkDataPack flow.ChunkDataPack
-	Nonce         uint64 // so that we aren't deduplicated by the network layer
+	Nonce         uint64          // so that we aren't deduplicated by the network layer
+	BlobID        flow.Identifier // root blob of the published chunk data pack, if any
 }
 
 // ExecutionStateSyncRequest represents a request for state deltas between
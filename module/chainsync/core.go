@@ -24,6 +24,10 @@ const (
 	// DefaultQueuedHeightMultiplicity limits the number of heights we queue
 	// above the current finalized height.
 	DefaultQueuedHeightMultiplicity uint = 4
+
+	// DefaultReportedHeightWindow is the default time window in which the finalized
+	// heights reported by other nodes are considered for the reported height.
+	DefaultReportedHeightWindow = 2 * time.Minute
 )
 
 type Config struct {
@@ -60,6 +64,17 @@ type Core struct {
 	blockIDs             map[flow.Identifier]*chainsync.Status
 	metrics              module.ChainSyncMetrics
 	localFinalizedHeight uint64
+
+	// reportedHeights holds the latest finalized height reported by each other node,
+	// reports older than reportedHeightWindow are discarded.
+	reportedHeights      map[flow.Identifier]reportedHeight
+	reportedHeightWindow time.Duration
+}
+
+// reportedHeight is a finalized height reported by another node.
+type reportedHeight struct {
+	height   uint64
+	reported time.Time
 }
 
 func New(log zerolog.Logger, config Config, metrics module.ChainSyncMetrics) (*Core, error) {
@@ -70,6 +85,8 @@ func New(log zerolog.Logger, config Config, metrics module.ChainSyncMetrics) (*C
 		blockIDs:             make(map[flow.Identifier]*chainsync.Status),
 		metrics:              metrics,
 		localFinalizedHeight: 0,
+		reportedHeights:      make(map[flow.Identifier]reportedHeight),
+		reportedHeightWindow: DefaultReportedHeightWindow,
 	}
 	return core, nil
 }
@@ -102,10 +119,14 @@ func (c *Core) HandleBlock(header *flow.Header) bool {
 	return true
 }
 
-// HandleHeight handles receiving a new highest finalized height from another node.
+// HandleHeight handles receiving a new highest finalized height from the node with the given origin ID.
 // If the height difference between local and the reported height, we do nothing.
 // Otherwise, we queue each missing height.
-func (c *Core) HandleHeight(final *flow.Header, height uint64) {
+func (c *Core) HandleHeight(final *flow.Header, height uint64, originID flow.Identifier) {
+	c.mu.Lock()
+	c.reportedHeights[originID] = reportedHeight{height: height, reported: time.Now()}
+	c.mu.Unlock()
+
 	// don't bother queueing anything if we're within tolerance
 	if c.WithinTolerance(final, height) {
 		return
@@ -130,6 +151,34 @@ func (c *Core) HandleHeight(final *flow.Header, height uint64) {
 	}
 }
 
+// ReportedHeight returns the median of the latest finalized heights reported by other nodes
+// within the reporting window, or zero if no height has been reported within the window.
+// Each node contributes its latest report only, so that a minority of nodes can't skew the
+// reported height by reporting heights far above (or below) the finalized height of the network.
+func (c *Core) ReportedHeight() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	heights := make([]uint64, 0, len(c.reportedHeights))
+	cutoff := time.Now().Add(-c.reportedHeightWindow)
+	for originID, report := range c.reportedHeights {
+		if report.reported.Before(cutoff) {
+			delete(c.reportedHeights, originID)
+			continue
+		}
+		heights = append(heights, report.height)
+	}
+	if len(heights) == 0 {
+		return 0
+	}
+
+	// for an even number of reports, the lower median is used
+	sort.Slice(heights, func(i, j int) bool {
+		return heights[i] < heights[j]
+	})
+	return heights[(len(heights)-1)/2]
+}
+
 func (c *Core) RequestBlock(blockID flow.Identifier, height uint64) {
 	// requesting a block by its ID storing the height to prune more efficiently
 	c.mu.Lock()
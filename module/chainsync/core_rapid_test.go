@@ -74,7 +74,7 @@ func (r *rapidSync) HandleHeight(t *rapid.T) {
 	b := rapid.SampledFrom(r.store).Draw(t, "height_hint_request").(*flow.Header)
 	incr := rapid.IntRange(0, (int)(DefaultConfig().Tolerance)+1).Draw(t, "height increment").(int)
 	requestHeight := b.Height + (uint64)(incr)
-	r.core.HandleHeight(b, requestHeight)
+	r.core.HandleHeight(b, requestHeight, unittest.IdentifierFixture())
 	// Re-queueing by height should always succeed if beyond tolerance
 	if (uint)(incr) > DefaultConfig().Tolerance {
 		for h := b.Height + 1; h <= requestHeight; h++ {
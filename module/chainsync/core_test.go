@@ -182,6 +182,7 @@ func (ss *SyncSuite) TestHandleBlock() {
 }
 
 func (ss *SyncSuite) TestHandleHeight() {
+	originID := unittest.IdentifierFixture()
 
 	final := unittest.BlockHeaderFixture()
 	lower := final.Height - uint64(ss.core.Config.Tolerance)
@@ -189,19 +190,54 @@ func (ss *SyncSuite) TestHandleHeight() {
 	aboveOutsideTolerance := final.Height + uint64(ss.core.Config.Tolerance+1)
 
 	// a height lower than finalized should be a no-op
-	ss.core.HandleHeight(final, lower)
+	ss.core.HandleHeight(final, lower, originID)
 	ss.Assert().Len(ss.core.heights, 0)
 
 	// a height higher than finalized, but within tolerance, should be a no-op
-	ss.core.HandleHeight(final, aboveWithinTolerance)
+	ss.core.HandleHeight(final, aboveWithinTolerance, originID)
 	ss.Assert().Len(ss.core.heights, 0)
 
 	// a height higher than finalized and outside tolerance should queue missing heights
-	ss.core.HandleHeight(final, aboveOutsideTolerance)
+	ss.core.HandleHeight(final, aboveOutsideTolerance, originID)
 	ss.Assert().Len(ss.core.heights, int(aboveOutsideTolerance-final.Height))
 	for height := final.Height + 1; height <= aboveOutsideTolerance; height++ {
 		ss.Assert().Contains(ss.core.heights, height)
 	}
+
+	// the reported height should be tracked regardless of tolerance
+	ss.Assert().Equal(aboveOutsideTolerance, ss.core.ReportedHeight())
+}
+
+// TestReportedHeight tests that the reported height is the median of the latest heights reported by
+// distinct nodes within the reporting window, so that a single node can't raise it.
+func (ss *SyncSuite) TestReportedHeight() {
+	final := unittest.BlockHeaderFixture()
+	nodes := unittest.IdentifierListFixture(3)
+
+	// no heights reported yet
+	ss.Assert().Zero(ss.core.ReportedHeight())
+
+	ss.core.HandleHeight(final, 100, nodes[0])
+	ss.core.HandleHeight(final, 102, nodes[1])
+	ss.core.HandleHeight(final, 101, nodes[2])
+	ss.Assert().Equal(uint64(101), ss.core.ReportedHeight())
+
+	// a single node reporting an excessive height doesn't raise the reported height
+	ss.core.HandleHeight(final, 1_000_000, nodes[0])
+	ss.Assert().Equal(uint64(102), ss.core.ReportedHeight())
+	ss.core.HandleHeight(final, 2_000_000, nodes[0])
+	ss.Assert().Equal(uint64(102), ss.core.ReportedHeight())
+
+	// for an even number of nodes, the lower median is used
+	ss.core.HandleHeight(final, 103, unittest.IdentifierFixture())
+	ss.Assert().Equal(uint64(102), ss.core.ReportedHeight())
+
+	// reports outside the window are discarded
+	ss.core.reportedHeightWindow = time.Millisecond
+	time.Sleep(10 * time.Millisecond)
+	ss.core.HandleHeight(final, 110, nodes[1])
+	ss.Assert().Equal(uint64(110), ss.core.ReportedHeight())
+	ss.Assert().Len(ss.core.reportedHeights, 1)
 }
 
 func (ss *SyncSuite) TestGetRequestableItems() {
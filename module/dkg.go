@@ -80,6 +80,12 @@ type DKGController interface {
 // DKGControllerFactory is a factory to create instances of DKGController.
 type DKGControllerFactory interface {
 
-	// Create instantiates a new DKGController.
-	Create(dkgInstanceID string, participants flow.IdentityList, seed []byte) (DKGController, error)
+	// Create instantiates a new DKGController for the DKG preparing the epoch
+	// with the given counter.
+	Create(dkgInstanceID string, participants flow.IdentityList, epochCounter uint64, seed []byte) (DKGController, error)
+
+	// Resume instantiates a DKGController for a DKG which was started before
+	// the node restarted, restoring the DKG progress persisted for the epoch
+	// with the given counter.
+	Resume(dkgInstanceID string, participants flow.IdentityList, epochCounter uint64) (DKGController, error)
 }
@@ -69,9 +69,17 @@ type Broker struct {
 	broadcastMsgCh            chan messages.BroadcastDKGMessage // channel to forward incoming broadcast messages to consumers
 	messageOffset             uint                              // offset for next broadcast messages to fetch
 	shutdownCh                chan struct{}                     // channel to stop the broker from listening
+	persister                 *Persister                        // records the progress of the DKG, nil if the DKG is not persisted
 
 	broadcasts uint // broadcasts counts the number of attempted broadcasts
 
+	// When resuming a DKG, the restarted DKG instance re-emits all messages it
+	// sent before the node restarted. These counters track how many of the
+	// re-emitted messages must still be suppressed, as they were sent already.
+	skipBroadcasts      uint
+	skipPrivateMessages uint
+	skipLock            sync.Mutex
+
 	clientLock    sync.Mutex // lock around updates to current client
 	broadcastLock sync.Mutex // lock around outbound broadcasts
 	pollLock      sync.Mutex // lock around polls to read inbound broadcasts
@@ -80,7 +88,9 @@ type Broker struct {
 var _ module.DKGBroker = (*Broker)(nil)
 
 // NewBroker instantiates a new epoch-specific broker capable of communicating
-// with other nodes via a network engine and dkg smart-contract.
+// with other nodes via a network engine and dkg smart-contract. If a persister
+// is provided, the broker records its progress with it, and resumes from the
+// progress loaded by the persister when resuming a DKG.
 func NewBroker(
 	log zerolog.Logger,
 	dkgInstanceID string,
@@ -89,6 +99,7 @@ func NewBroker(
 	myIndex int,
 	dkgContractClients []module.DKGContractClient,
 	tunnel *BrokerTunnel,
+	persister *Persister,
 	opts ...BrokerOpt,
 ) *Broker {
 
@@ -110,6 +121,15 @@ func NewBroker(
 		privateMsgCh:       make(chan messages.PrivDKGMessageIn),
 		broadcastMsgCh:     make(chan messages.BroadcastDKGMessage),
 		shutdownCh:         make(chan struct{}),
+		persister:          persister,
+	}
+
+	if persister != nil && persister.Resuming() {
+		state := persister.State()
+		b.messageOffset = state.BroadcastOffset
+		b.broadcasts = state.BroadcastsSent
+		b.skipBroadcasts = state.BroadcastsSent
+		b.skipPrivateMessages = state.PrivateMessagesSent
 	}
 
 	go b.listen()
@@ -137,11 +157,35 @@ func (b *Broker) PrivateSend(dest int, data []byte) {
 		DKGMessage: messages.NewDKGMessage(data, b.dkgInstanceID),
 		DestID:     b.committee[dest].NodeID,
 	}
+
+	b.skipLock.Lock()
+	defer b.skipLock.Unlock()
+	if b.skipPrivateMessages > 0 {
+		b.skipPrivateMessages--
+		b.log.Debug().Msgf("skipping private message to participant %d, which was sent before resuming", dest)
+		return
+	}
+
 	b.tunnel.SendOut(dkgMessageOut)
+	if b.persister != nil {
+		err := b.persister.RecordPrivateMessageSent()
+		if err != nil {
+			b.log.Fatal().Err(err).Msg("failed to record sent private message")
+		}
+	}
 }
 
 // Broadcast signs and broadcasts a message to all participants.
 func (b *Broker) Broadcast(data []byte) {
+	b.skipLock.Lock()
+	if b.skipBroadcasts > 0 {
+		b.skipBroadcasts--
+		b.skipLock.Unlock()
+		b.log.Info().Msgf("skipping DKG broadcast with header %d, which was sent before resuming", data[0])
+		return
+	}
+	b.skipLock.Unlock()
+
 	b.unit.Launch(func() {
 
 		// NOTE: We're counting the number of times the underlying DKG requested
@@ -200,6 +244,13 @@ func (b *Broker) Broadcast(data []byte) {
 			return
 		}
 		log.Info().Msgf("dkg broadcast successfully on attempt %d", attempts)
+
+		if b.persister != nil {
+			err = b.persister.RecordBroadcastSent()
+			if err != nil {
+				log.Fatal().Err(err).Msg("failed to record sent broadcast message")
+			}
+		}
 	})
 }
 
@@ -355,6 +406,12 @@ func (b *Broker) Poll(referenceBlock flow.Identifier) error {
 	// update message offset to use for future polls, this avoids forwarding the
 	// same message more than once
 	b.messageOffset += uint(len(msgs))
+	if b.persister != nil && len(msgs) > 0 {
+		err = b.persister.SetBroadcastOffset(b.messageOffset)
+		if err != nil {
+			return fmt.Errorf("could not record broadcast message offset: %w", err)
+		}
+	}
 	return nil
 }
 
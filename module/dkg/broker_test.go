@@ -11,11 +11,13 @@ import (
 	mocks "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	model "github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
 	msg "github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/local"
 	"github.com/onflow/flow-go/module/mock"
+	storagemock "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -78,6 +80,7 @@ func TestPrivateSend_Valid(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	// expected DKGMessageOut
@@ -119,6 +122,7 @@ func TestPrivateSend_IndexOutOfRange(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	// Launch a background routine to capture messages sent through the tunnel.
@@ -138,6 +142,72 @@ func TestPrivateSend_IndexOutOfRange(t *testing.T) {
 	unittest.RequireNeverClosedWithin(t, doneCh, 50*time.Millisecond, "no invalid message should be sent")
 }
 
+// TestPrivateSend_Resume checks that a broker resuming a DKG suppresses the
+// private messages which were sent before the node restarted, records newly
+// sent messages, and resumes reading broadcast messages at the persisted offset.
+func TestPrivateSend_Resume(t *testing.T) {
+	committee, locals := initCommittee(2)
+	epochCounter := uint64(1)
+
+	dkgState := new(storagemock.DKGState)
+	dkgState.On("GetDKGResumeState", epochCounter).Return(&model.ResumeState{
+		Seed:                unittest.SeedFixture(20),
+		Phase:               uint32(Phase1),
+		PrivateMessagesSent: 1,
+		BroadcastOffset:     5,
+	}, nil)
+	dkgState.On("RetrieveDKGLog", epochCounter).Return([]*model.LogEntry{}, nil)
+	dkgState.On("SetDKGResumeState", epochCounter, mocks.MatchedBy(func(state *model.ResumeState) bool {
+		return state.PrivateMessagesSent == 2
+	})).Return(nil).Once()
+
+	persister, err := LoadPersister(dkgState, epochCounter)
+	require.NoError(t, err)
+	require.True(t, persister.Resuming())
+
+	sender := NewBroker(
+		zerolog.Logger{},
+		dkgInstanceID,
+		committee,
+		locals[orig],
+		orig,
+		[]module.DKGContractClient{&mock.DKGContractClient{}},
+		NewBrokerTunnel(),
+		persister,
+	)
+	require.Equal(t, uint(5), sender.messageOffset)
+
+	// the first message was sent before the restart and must be suppressed,
+	// the second message must be sent
+	received := make(chan msg.PrivDKGMessageOut, 2)
+	go func() {
+		for {
+			received <- <-sender.tunnel.MsgChOut
+		}
+	}()
+	sender.PrivateSend(dest, []byte("resent"))
+	sender.PrivateSend(dest, msgb)
+
+	select {
+	case out := <-received:
+		require.Equal(t, msgb, out.Data)
+	case <-time.After(time.Second):
+		t.Fatal("message not sent")
+	}
+	unittest.RequireNeverClosedWithin(t, closedOnReceive(received), 50*time.Millisecond, "suppressed message should not be sent")
+	dkgState.AssertExpectations(t)
+}
+
+// closedOnReceive returns a channel which is closed when a message is received on ch.
+func closedOnReceive(ch <-chan msg.PrivDKGMessageOut) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		<-ch
+		close(done)
+	}()
+	return done
+}
+
 // TestReceivePrivateMessage_Valid checks that a valid incoming DKG message is
 // correctly matched with origin's Identifier, and that the message is forwarded
 // to the message channel.
@@ -153,6 +223,7 @@ func TestReceivePrivateMessage_Valid(t *testing.T) {
 		dest,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	dkgMessage := msg.NewDKGMessage(msgb, dkgInstanceID)
@@ -200,6 +271,7 @@ func TestBroadcastMessage(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}, &mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 		func(config *BrokerConfig) { config.RetryInitialWait = 1 }, // disable waiting between retries for tests
 	)
 
@@ -244,6 +316,7 @@ func TestPoll(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	recipient := NewBroker(
@@ -254,6 +327,7 @@ func TestPoll(t *testing.T) {
 		dest,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	blockID := unittest.IdentifierFixture()
@@ -323,6 +397,7 @@ func TestLogHook(t *testing.T) {
 		orig,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	sender.Disqualify(1, "testing")
@@ -344,6 +419,7 @@ func TestProcessPrivateMessage_InvalidOrigin(t *testing.T) {
 		dest,
 		[]module.DKGContractClient{&mock.DKGContractClient{}},
 		NewBrokerTunnel(),
+		nil,
 	)
 
 	// Launch a background routine to capture messages forwared to the private
@@ -10,6 +10,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 )
@@ -86,6 +87,20 @@ type Controller struct {
 	// broker enables the controller to communicate with other nodes
 	broker module.DKGBroker
 
+	// persister records the progress of the DKG, so that it can be resumed if
+	// the node restarts. It is nil if the DKG progress is not persisted.
+	persister *Persister
+
+	// timeouts is the number of DKG timeouts (phase transitions) which have
+	// been applied to the underlying DKG instance
+	timeouts int
+
+	// replayedBroadcasts holds the broadcast messages which were replayed when
+	// resuming the DKG. The broker may deliver these messages again if the node
+	// restarted before it recorded the updated broadcast offset, in which case
+	// they must not be processed twice.
+	replayedBroadcasts map[string]struct{}
+
 	// Channels used internally to trigger state transitions
 	h1Ch       chan struct{}
 	h2Ch       chan struct{}
@@ -105,13 +120,17 @@ type Controller struct {
 	once   *sync.Once
 }
 
-// NewController instantiates a new Joint Feldman DKG controller.
+// NewController instantiates a new Joint Feldman DKG controller. If a
+// persister is provided, the controller records its progress with it. If the
+// persister was loaded from storage (see LoadPersister), the controller resumes
+// the DKG from the stored progress, rather than starting it with the given seed.
 func NewController(
 	log zerolog.Logger,
 	dkgInstanceID string,
 	dkg crypto.DKGState,
 	seed []byte,
 	broker module.DKGBroker,
+	persister *Persister,
 	config ControllerConfig,
 ) *Controller {
 
@@ -121,16 +140,18 @@ func NewController(
 		Logger()
 
 	return &Controller{
-		log:        logger,
-		dkg:        dkg,
-		seed:       seed,
-		broker:     broker,
-		h1Ch:       make(chan struct{}),
-		h2Ch:       make(chan struct{}),
-		endCh:      make(chan struct{}),
-		shutdownCh: make(chan struct{}),
-		once:       new(sync.Once),
-		config:     config,
+		log:                logger,
+		dkg:                dkg,
+		seed:               seed,
+		broker:             broker,
+		persister:          persister,
+		replayedBroadcasts: make(map[string]struct{}),
+		h1Ch:               make(chan struct{}),
+		h2Ch:               make(chan struct{}),
+		endCh:              make(chan struct{}),
+		shutdownCh:         make(chan struct{}),
+		once:               new(sync.Once),
+		config:             config,
 	}
 }
 
@@ -189,7 +210,10 @@ func (c *Controller) EndPhase1() error {
 		return NewInvalidStateTransitionError(state, Phase2)
 	}
 
-	c.SetState(Phase2)
+	err := c.setPhase(Phase2)
+	if err != nil {
+		return err
+	}
 	close(c.h1Ch)
 
 	return nil
@@ -202,7 +226,10 @@ func (c *Controller) EndPhase2() error {
 		return NewInvalidStateTransitionError(state, Phase3)
 	}
 
-	c.SetState(Phase3)
+	err := c.setPhase(Phase3)
+	if err != nil {
+		return err
+	}
 	close(c.h2Ch)
 
 	return nil
@@ -232,7 +259,10 @@ func (c *Controller) End() error {
 	c.publicKeys = publicKeys
 	c.artifactsLock.Unlock()
 
-	c.SetState(End)
+	err = c.setPhase(End)
+	if err != nil {
+		return err
+	}
 	close(c.endCh)
 
 	return nil
@@ -286,6 +316,7 @@ func (c *Controller) doBackgroundWork() {
 		case msg := <-privateMsgCh:
 			c.dkgLock.Lock()
 			err := c.dkg.HandlePrivateMsg(int(msg.CommitteeMemberIndex), msg.Data)
+			c.appendToLog(dkg.LogEntryPrivateMessage, msg.CommitteeMemberIndex, msg.Data)
 			c.dkgLock.Unlock()
 			if err != nil {
 				c.log.Err(err).Msg("error processing DKG private message")
@@ -293,6 +324,14 @@ func (c *Controller) doBackgroundWork() {
 
 		case msg := <-broadcastMsgCh:
 
+			// skip broadcast messages which were already processed before resuming
+			key := broadcastKey(msg.CommitteeMemberIndex, msg.Data)
+			if _, replayed := c.replayedBroadcasts[key]; replayed {
+				delete(c.replayedBroadcasts, key)
+				c.log.Debug().Msgf("skipping broadcast message from participant %d, which was processed before resuming", msg.CommitteeMemberIndex)
+				continue
+			}
+
 			// before processing a broadcast message during phase 1, sleep for a
 			// random delay to avoid synchronizing this expensive operation across
 			// all consensus nodes
@@ -318,6 +357,7 @@ func (c *Controller) doBackgroundWork() {
 
 			c.dkgLock.Lock()
 			err := c.dkg.HandleBroadcastMsg(int(msg.CommitteeMemberIndex), msg.Data)
+			c.appendToLog(dkg.LogEntryBroadcastMessage, msg.CommitteeMemberIndex, msg.Data)
 			c.dkgLock.Unlock()
 			if err != nil {
 				c.log.Err(err).Msg("error processing DKG broadcast message")
@@ -335,6 +375,10 @@ func (c *Controller) start() error {
 		return fmt.Errorf("cannot execute start routine in state %s", state)
 	}
 
+	if c.persister != nil && c.persister.Resuming() {
+		return c.resume()
+	}
+
 	// before starting the DKG, sleep for a random delay to avoid synchronizing
 	// this expensive operation across all consensus nodes
 	delay := c.preStartDelay()
@@ -349,8 +393,67 @@ func (c *Controller) start() error {
 	}
 
 	c.log.Debug().Msg("DKG engine started")
-	c.SetState(Phase1)
-	return nil
+	return c.setPhase(Phase1)
+}
+
+// resume restores the state of a DKG which was started before the node
+// restarted. The DKG is restarted with the persisted seed, which reproduces
+// our secret polynomial and the messages we sent (the broker suppresses the
+// messages which were sent already). The persisted log of processed messages
+// and timeouts is then replayed in order, after which the controller continues
+// in the phase reached by the replayed timeouts (see ResumePhase).
+func (c *Controller) resume() error {
+	progress := c.persister.State()
+	entries := c.persister.Log()
+	c.log.Info().Msgf("resuming DKG from %s, replaying %d logged events", State(progress.Phase), len(entries))
+
+	c.dkgLock.Lock()
+	defer c.dkgLock.Unlock()
+
+	err := c.dkg.Start(progress.Seed)
+	if err != nil {
+		return fmt.Errorf("Error restarting DKG: %w", err)
+	}
+
+	broadcasts := 0
+	for _, entry := range entries {
+		switch entry.Type {
+		case dkg.LogEntryPrivateMessage:
+			err := c.dkg.HandlePrivateMsg(int(entry.CommitteeMemberIndex), entry.Data)
+			if err != nil {
+				c.log.Err(err).Msg("error replaying DKG private message")
+			}
+		case dkg.LogEntryBroadcastMessage:
+			c.replayedBroadcasts[broadcastKey(entry.CommitteeMemberIndex, entry.Data)] = struct{}{}
+			broadcasts++
+			err := c.dkg.HandleBroadcastMsg(int(entry.CommitteeMemberIndex), entry.Data)
+			if err != nil {
+				c.log.Err(err).Msg("error replaying DKG broadcast message")
+			}
+		case dkg.LogEntryTimeout:
+			err := c.dkg.NextTimeout()
+			if err != nil {
+				return fmt.Errorf("Error replaying NextTimeout: %w", err)
+			}
+			c.timeouts++
+		default:
+			return fmt.Errorf("unknown dkg log entry type %d (seq: %d)", entry.Type, entry.Seq)
+		}
+	}
+
+	// the randomized delay before processing the first broadcast message only
+	// applies if no broadcast message was processed before resuming
+	if broadcasts > 0 {
+		c.once.Do(func() {})
+	}
+
+	phase, err := ResumePhase(entries)
+	if err != nil {
+		return err
+	}
+
+	c.log.Info().Msgf("DKG resumed in %s", phase)
+	return c.setPhase(phase)
 }
 
 func (c *Controller) phase1() error {
@@ -376,9 +479,7 @@ func (c *Controller) phase2() error {
 		return fmt.Errorf("Cannot execute phase2 routine in state %s", state)
 	}
 
-	c.dkgLock.Lock()
-	err := c.dkg.NextTimeout()
-	c.dkgLock.Unlock()
+	err := c.nextTimeout(1)
 	if err != nil {
 		return fmt.Errorf("Error calling NextTimeout: %w", err)
 	}
@@ -400,9 +501,7 @@ func (c *Controller) phase3() error {
 		return fmt.Errorf("Cannot execute phase3 routine in state %s", state)
 	}
 
-	c.dkgLock.Lock()
-	err := c.dkg.NextTimeout()
-	c.dkgLock.Unlock()
+	err := c.nextTimeout(2)
 	if err != nil {
 		return fmt.Errorf("Error calling NextTimeout: %w", err)
 	}
@@ -418,6 +517,56 @@ func (c *Controller) phase3() error {
 	}
 }
 
+// nextTimeout applies the n-th DKG timeout to the underlying DKG instance,
+// unless it was already applied when replaying the log of a resumed DKG.
+func (c *Controller) nextTimeout(n int) error {
+	c.dkgLock.Lock()
+	defer c.dkgLock.Unlock()
+
+	if c.timeouts >= n {
+		return nil
+	}
+	err := c.dkg.NextTimeout()
+	if err != nil {
+		return err
+	}
+	c.timeouts++
+	c.appendToLog(dkg.LogEntryTimeout, 0, nil)
+	return nil
+}
+
+// setPhase sets the controller state and records it with the persister.
+func (c *Controller) setPhase(phase State) error {
+	c.SetState(phase)
+	if c.persister == nil {
+		return nil
+	}
+	err := c.persister.SetPhase(phase)
+	if err != nil {
+		return fmt.Errorf("could not record dkg phase %s: %w", phase, err)
+	}
+	return nil
+}
+
+// appendToLog records an event processed by the underlying DKG instance with
+// the persister. Must be called while holding dkgLock, so that the log order
+// matches the processing order. Failing to persist the event is an unexpected
+// storage failure, which we cannot recover from.
+func (c *Controller) appendToLog(entryType dkg.LogEntryType, committeeMemberIndex uint64, data []byte) {
+	if c.persister == nil {
+		return
+	}
+	err := c.persister.Append(entryType, committeeMemberIndex, data)
+	if err != nil {
+		c.log.Fatal().Err(err).Msg("failed to record processed DKG event")
+	}
+}
+
+// broadcastKey returns a key identifying a broadcast message by its sender and content.
+func broadcastKey(committeeMemberIndex uint64, data []byte) string {
+	return fmt.Sprintf("%d:%x", committeeMemberIndex, data)
+}
+
 // preStartDelay returns a duration to delay prior to starting the DKG process.
 // This prevents synchronization of the DKG starting (an expensive operation)
 // across the network, which can impact finalization.
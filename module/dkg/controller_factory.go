@@ -9,28 +9,32 @@ import (
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
 	"github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/storage"
 )
 
 // ControllerFactory is a factory object that creates new Controllers for new
 // epochs. Each Controller produced by a factory shares the same underlying
 // Local object to sign broadcast messages, the same tunnel tying it to the
-// MessagingEngine, and the same client to communicate with the DKG
-// smart-contract.
+// MessagingEngine, the same client to communicate with the DKG
+// smart-contract, and the same storage to persist the DKG progress.
 type ControllerFactory struct {
 	log                zerolog.Logger
 	me                 module.Local
 	dkgContractClients []module.DKGContractClient
 	tunnel             *BrokerTunnel
+	dkgState           storage.DKGState
 	config             ControllerConfig
 }
 
 // NewControllerFactory creates a new factory that generates Controllers with
-// the same underlying Local object, tunnel and dkg smart-contract client.
+// the same underlying Local object, tunnel, dkg smart-contract client and
+// dkg state storage.
 func NewControllerFactory(
 	log zerolog.Logger,
 	me module.Local,
 	dkgContractClients []module.DKGContractClient,
 	tunnel *BrokerTunnel,
+	dkgState storage.DKGState,
 	config ControllerConfig) *ControllerFactory {
 
 	return &ControllerFactory{
@@ -38,17 +42,52 @@ func NewControllerFactory(
 		me:                 me,
 		dkgContractClients: dkgContractClients,
 		tunnel:             tunnel,
+		dkgState:           dkgState,
 		config:             config,
 	}
 }
 
 // Create creates a new epoch-specific Controller equipped with a broker which
-// is capable of communicating with other nodes.
+// is capable of communicating with other nodes. The seed is persisted before
+// the controller is created, so that the DKG can be resumed after a restart.
 func (f *ControllerFactory) Create(
 	dkgInstanceID string,
 	participants flow.IdentityList,
+	epochCounter uint64,
 	seed []byte) (module.DKGController, error) {
 
+	persister, err := NewPersister(f.dkgState, epochCounter, seed)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize dkg persister: %w", err)
+	}
+
+	return f.create(dkgInstanceID, participants, seed, persister)
+}
+
+// Resume creates an epoch-specific Controller for a DKG which was started
+// before the node restarted. The Controller restores the DKG progress
+// persisted for the epoch when it is run.
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no DKG progress was persisted for the epoch
+func (f *ControllerFactory) Resume(
+	dkgInstanceID string,
+	participants flow.IdentityList,
+	epochCounter uint64) (module.DKGController, error) {
+
+	persister, err := LoadPersister(f.dkgState, epochCounter)
+	if err != nil {
+		return nil, fmt.Errorf("could not load dkg persister: %w", err)
+	}
+
+	return f.create(dkgInstanceID, participants, persister.State().Seed, persister)
+}
+
+func (f *ControllerFactory) create(
+	dkgInstanceID string,
+	participants flow.IdentityList,
+	seed []byte,
+	persister *Persister) (module.DKGController, error) {
+
 	myIndex, ok := participants.GetIndex(f.me.NodeID())
 	if !ok {
 		return nil, fmt.Errorf("failed to create controller factory, node %s is not part of DKG committee", f.me.NodeID().String())
@@ -62,6 +101,7 @@ func (f *ControllerFactory) Create(
 		int(myIndex),
 		f.dkgContractClients,
 		f.tunnel,
+		persister,
 	)
 
 	n := len(participants)
@@ -77,6 +117,7 @@ func (f *ControllerFactory) Create(
 		dkg,
 		seed,
 		broker,
+		persister,
 		f.config,
 	)
 
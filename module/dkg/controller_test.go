@@ -8,12 +8,15 @@ import (
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
 	msg "github.com/onflow/flow-go/model/messages"
 	"github.com/onflow/flow-go/module/signature"
+	mockstorage "github.com/onflow/flow-go/storage/mock"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -266,6 +269,7 @@ func initNodes(t *testing.T, n int, phase1Duration, phase2Duration, phase3Durati
 			dkg,
 			seed,
 			broker,
+			nil,
 			config,
 		)
 		require.NoError(t, err)
@@ -330,6 +334,96 @@ func checkArtifacts(t *testing.T, nodes []*node, totalNodes int) {
 	}
 }
 
+// TestResumeWithoutTimeoutEntry tests resuming a controller whose transition to
+// phase 2 was persisted, but which restarted before the timeout ending phase 1
+// was logged. The controller must resume in phase 1, so that the transition to
+// phase 2 can be triggered again.
+func TestResumeWithoutTimeoutEntry(t *testing.T) {
+	const epochCounter = 1
+	n := 3
+
+	dkgState := mockstorage.NewDKGState(t)
+	dkgState.On("GetDKGResumeState", uint64(epochCounter)).Return(&dkg.ResumeState{
+		Seed:  unittest.SeedFixture(20),
+		Phase: uint32(Phase2),
+	}, nil)
+	dkgState.On("RetrieveDKGLog", uint64(epochCounter)).Return([]*dkg.LogEntry{}, nil)
+	dkgState.On("SetDKGResumeState", uint64(epochCounter), mock.Anything).Return(nil)
+	timeoutLogged := make(chan struct{}, 1)
+	dkgState.On("InsertDKGLogEntry", uint64(epochCounter), mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		if args.Get(1).(*dkg.LogEntry).Type == dkg.LogEntryTimeout {
+			timeoutLogged <- struct{}{}
+		}
+	})
+
+	persister, err := LoadPersister(dkgState, epochCounter)
+	require.NoError(t, err)
+	phase, err := ResumePhase(persister.Log())
+	require.NoError(t, err)
+	assert.Equal(t, Phase1, phase)
+
+	privateChannels := make([]chan msg.PrivDKGMessageIn, 0, n)
+	broadcastChannels := make([]chan msg.BroadcastDKGMessage, 0, n)
+	for i := 0; i < n; i++ {
+		privateChannels = append(privateChannels, make(chan msg.PrivDKGMessageIn, 5*n*n))
+		broadcastChannels = append(broadcastChannels, make(chan msg.BroadcastDKGMessage, 5*n*n))
+	}
+	logger := unittest.Logger()
+	broker := &broker{
+		privateChannels:   privateChannels,
+		broadcastChannels: broadcastChannels,
+		logger:            logger,
+	}
+	jointFeldman, err := crypto.NewJointFeldman(n, signature.RandomBeaconThreshold(n), 0, broker)
+	require.NoError(t, err)
+	controller := NewController(logger, "dkg_test", jointFeldman, nil, broker, persister, ControllerConfig{})
+
+	runErrCh := make(chan error, 1)
+	go func() {
+		runErrCh <- controller.Run()
+	}()
+
+	// the controller resumes in phase 1, and can transition to phase 2
+	require.Eventually(t, func() bool {
+		return controller.GetState() == Phase1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, controller.EndPhase1())
+	require.Equal(t, Phase2, controller.GetState())
+
+	// the timeout ending phase 1 is logged once phase 2 starts
+	select {
+	case <-timeoutLogged:
+	case <-time.After(time.Second):
+		t.Fatal("timeout ending phase 1 was not logged")
+	}
+
+	controller.Shutdown()
+	require.NoError(t, <-runErrCh)
+}
+
+// TestResumePhase tests that the phase a DKG resumes in is derived from the
+// number of timeouts in the replay log.
+func TestResumePhase(t *testing.T) {
+	entry := func(entryType dkg.LogEntryType) *dkg.LogEntry {
+		return &dkg.LogEntry{Type: entryType}
+	}
+
+	phase, err := ResumePhase(nil)
+	require.NoError(t, err)
+	assert.Equal(t, Phase1, phase)
+
+	phase, err = ResumePhase([]*dkg.LogEntry{entry(dkg.LogEntryBroadcastMessage), entry(dkg.LogEntryTimeout), entry(dkg.LogEntryPrivateMessage)})
+	require.NoError(t, err)
+	assert.Equal(t, Phase2, phase)
+
+	phase, err = ResumePhase([]*dkg.LogEntry{entry(dkg.LogEntryTimeout), entry(dkg.LogEntryTimeout)})
+	require.NoError(t, err)
+	assert.Equal(t, Phase3, phase)
+
+	_, err = ResumePhase([]*dkg.LogEntry{entry(dkg.LogEntryTimeout), entry(dkg.LogEntryTimeout), entry(dkg.LogEntryTimeout)})
+	require.Error(t, err)
+}
+
 func TestDelay(t *testing.T) {
 
 	t.Run("should return 0 delay for <=0 inputs", func(t *testing.T) {
@@ -43,6 +43,20 @@ The End and Shutdown states differ in that the End state can only be arrived at
 from Phase 3 and after successfully computing the DKG artifacts. Whereas the
 Shutdown state can be reached from any other state.
 
+# Resuming
+
+The controller and broker record the progress of the DKG through a Persister,
+which writes to the DKG state storage (secrets database). The Persister stores
+the seed used to start the DKG, the current phase, the number of messages sent,
+the offset of broadcast messages read from the DKG smart-contract, and an
+ordered log of all processed private messages, broadcast messages and timeouts.
+
+If a node restarts during the DKG, a controller created with a Persister loaded
+from storage (see LoadPersister) restarts the DKG with the persisted seed, which
+deterministically reproduces the local Feldman VSS state, and replays the log.
+The broker suppresses messages re-emitted by the replay which were already sent,
+and reads the remaining broadcast messages from the persisted offset onward.
+
 # Broker
 
 The controller requires a broker to communicate with other nodes over the
@@ -0,0 +1,200 @@
+package dkg
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/model/dkg"
+	"github.com/onflow/flow-go/storage"
+)
+
+// Persister records the progress of a DKG instance in the DKG state storage,
+// so that the DKG can be resumed if the node restarts during the EpochSetup
+// phase. It is shared by the Controller, which records the seed, phase and the
+// ordered log of processed messages and timeouts, and the Broker, which records
+// the number of messages it has sent and the offset of the broadcast messages it
+// has read from the DKG smart contract.
+//
+// A new Persister must be instantiated for every epoch.
+type Persister struct {
+	lock         sync.Mutex
+	dkgState     storage.DKGState
+	epochCounter uint64
+	state        dkg.ResumeState
+	log          []*dkg.LogEntry // log loaded from storage when resuming, nil otherwise
+	nextSeq      uint64
+}
+
+// NewPersister creates a Persister for a DKG that is started for the first
+// time with the given seed. The seed is stored immediately, before the DKG
+// sends any message derived from it.
+// No errors are expected during normal operation.
+func NewPersister(dkgState storage.DKGState, epochCounter uint64, seed []byte) (*Persister, error) {
+	p := &Persister{
+		dkgState:     dkgState,
+		epochCounter: epochCounter,
+		state: dkg.ResumeState{
+			Seed:  seed,
+			Phase: uint32(Init),
+		},
+	}
+	err := p.store()
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// LoadPersister creates a Persister for a DKG that was previously started,
+// loading the progress and replay log stored before the node restarted.
+// Expected errors during normal operation:
+//   - storage.ErrNotFound if no progress was stored for the epoch
+func LoadPersister(dkgState storage.DKGState, epochCounter uint64) (*Persister, error) {
+	state, err := dkgState.GetDKGResumeState(epochCounter)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dkg progress for epoch %d: %w", epochCounter, err)
+	}
+	log, err := dkgState.RetrieveDKGLog(epochCounter)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dkg log for epoch %d: %w", epochCounter, err)
+	}
+	if log == nil {
+		log = make([]*dkg.LogEntry, 0)
+	}
+	for i, entry := range log {
+		if entry.Seq != uint64(i) {
+			return nil, fmt.Errorf("dkg log for epoch %d has a gap at sequence number %d", epochCounter, i)
+		}
+	}
+
+	return &Persister{
+		dkgState:     dkgState,
+		epochCounter: epochCounter,
+		state:        *state,
+		log:          log,
+		nextSeq:      uint64(len(log)),
+	}, nil
+}
+
+// IsResumable returns true if progress of a DKG for the given epoch was stored,
+// and the DKG can therefore be resumed with LoadPersister.
+// No errors are expected during normal operation.
+func IsResumable(dkgState storage.DKGState, epochCounter uint64) (bool, error) {
+	_, err := dkgState.GetDKGResumeState(epochCounter)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve dkg progress for epoch %d: %w", epochCounter, err)
+	}
+	return true, nil
+}
+
+// ResumePhase returns the phase in which a DKG resumed from the given replay log continues.
+// The phase is derived solely from the timeouts recorded in the log, not from the persisted
+// phase: a phase is persisted when its transition is triggered, but the corresponding timeout
+// is only applied and logged afterwards. A node restarting in between must therefore resume in
+// the preceding phase, and trigger the transition again.
+// No errors are expected during normal operation.
+func ResumePhase(log []*dkg.LogEntry) (State, error) {
+	timeouts := 0
+	for _, entry := range log {
+		if entry.Type == dkg.LogEntryTimeout {
+			timeouts++
+		}
+	}
+
+	switch timeouts {
+	case 0:
+		return Phase1, nil
+	case 1:
+		return Phase2, nil
+	case 2:
+		return Phase3, nil
+	default:
+		return Init, fmt.Errorf("dkg log contains %d timeouts, expected at most 2", timeouts)
+	}
+}
+
+// Resuming returns true if this Persister was loaded from storage.
+func (p *Persister) Resuming() bool {
+	return p.log != nil
+}
+
+// State returns a copy of the stored progress.
+func (p *Persister) State() dkg.ResumeState {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.state
+}
+
+// Log returns the replay log loaded from storage when resuming.
+func (p *Persister) Log() []*dkg.LogEntry {
+	return p.log
+}
+
+// SetPhase records the phase entered by the controller.
+// No errors are expected during normal operation.
+func (p *Persister) SetPhase(phase State) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.state.Phase = uint32(phase)
+	return p.store()
+}
+
+// RecordBroadcastSent records that a broadcast message was published.
+// No errors are expected during normal operation.
+func (p *Persister) RecordBroadcastSent() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.state.BroadcastsSent++
+	return p.store()
+}
+
+// RecordPrivateMessageSent records that a private message was sent.
+// No errors are expected during normal operation.
+func (p *Persister) RecordPrivateMessageSent() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.state.PrivateMessagesSent++
+	return p.store()
+}
+
+// SetBroadcastOffset records the number of messages read from the DKG smart contract.
+// No errors are expected during normal operation.
+func (p *Persister) SetBroadcastOffset(offset uint) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.state.BroadcastOffset = offset
+	return p.store()
+}
+
+// Append appends an entry of the given type to the replay log.
+// No errors are expected during normal operation.
+func (p *Persister) Append(entryType dkg.LogEntryType, committeeMemberIndex uint64, data []byte) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	entry := &dkg.LogEntry{
+		Seq:                  p.nextSeq,
+		Type:                 entryType,
+		CommitteeMemberIndex: committeeMemberIndex,
+		Data:                 data,
+	}
+	err := p.dkgState.InsertDKGLogEntry(p.epochCounter, entry)
+	if err != nil {
+		return fmt.Errorf("could not store dkg log entry %d: %w", entry.Seq, err)
+	}
+	p.nextSeq++
+	return nil
+}
+
+// store writes the current progress to storage. Must be called with the lock held.
+func (p *Persister) store() error {
+	state := p.state
+	err := p.dkgState.SetDKGResumeState(p.epochCounter, &state)
+	if err != nil {
+		return fmt.Errorf("could not store dkg progress for epoch %d: %w", p.epochCounter, err)
+	}
+	return nil
+}
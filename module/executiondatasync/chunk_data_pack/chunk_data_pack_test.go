@@ -0,0 +1,162 @@
+package chunk_data_pack_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	blocks "github.com/ipfs/go-block-format"
+	"github.com/ipfs/go-cid"
+	"github.com/ipfs/go-datastore"
+	dssync "github.com/ipfs/go-datastore/sync"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
+	"github.com/onflow/flow-go/network"
+	"github.com/onflow/flow-go/network/mocknetwork"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// blobServiceFixture returns a mock blob service backed by the given blobstore, where getBlob
+// is used to serve the requested blobs.
+func blobServiceFixture(t *testing.T, blobstore blobs.Blobstore, getBlob func(ctx context.Context, c cid.Cid) (blobs.Blob, error)) *mocknetwork.BlobService {
+	blobService := new(mocknetwork.BlobService)
+	blobService.On("AddBlobs", mock.Anything, mock.Anything).Return(
+		func(ctx context.Context, bs []blobs.Blob) error {
+			return blobstore.PutMany(ctx, bs)
+		},
+	)
+
+	blobGetter := new(mocknetwork.BlobGetter)
+	blobService.On("GetSession", mock.Anything).Return(blobGetter, nil)
+	blobGetter.On("GetBlob", mock.Anything, mock.AnythingOfType("cid.Cid")).Return(
+		func(ctx context.Context, c cid.Cid) blobs.Blob {
+			blob, _ := getBlob(ctx, c)
+			return blob
+		},
+		func(ctx context.Context, c cid.Cid) error {
+			_, err := getBlob(ctx, c)
+			if errors.Is(err, blobs.ErrNotFound) {
+				return network.ErrBlobNotFound
+			}
+			return err
+		},
+	)
+
+	return blobService
+}
+
+// largeChunkDataPackFixture returns a chunk data pack for the given chunk, which spans multiple blobs.
+func largeChunkDataPackFixture(chunkID flow.Identifier) *flow.ChunkDataPack {
+	collection := unittest.CollectionFixture(1)
+	collection.Transactions[0].Script = unittest.RandomBytes(3 * execution_data.DefaultMaxBlobSize)
+	return unittest.ChunkDataPackFixture(chunkID, unittest.WithChunkDataPackCollection(&collection))
+}
+
+// TestPublishAndDownload evaluates that a published chunk data pack can be downloaded by the ID of its root blob,
+// and its blobs are tracked at the height of its block on both sides.
+func TestPublishAndDownload(t *testing.T) {
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	blobService := blobServiceFixture(t, blobstore, blobstore.Get)
+
+	publisherPruned := make(map[cid.Cid]struct{})
+	publisherStorage, err := tracker.OpenStorage(t.TempDir(), 0, zerolog.Nop(), tracker.WithPruneCallback(func(c cid.Cid) error {
+		publisherPruned[c] = struct{}{}
+		return nil
+	}))
+	require.NoError(t, err)
+	downloaderPruned := make(map[cid.Cid]struct{})
+	downloaderStorage, err := tracker.OpenStorage(t.TempDir(), 0, zerolog.Nop(), tracker.WithPruneCallback(func(c cid.Cid) error {
+		downloaderPruned[c] = struct{}{}
+		return nil
+	}))
+	require.NoError(t, err)
+
+	publisher := chunk_data_pack.NewPublisher(zerolog.Nop(), execution_data.DefaultSerializer, blobService, publisherStorage)
+	downloader := chunk_data_pack.NewDownloader(blobService, execution_data.DefaultSerializer, chunk_data_pack.WithTracker(downloaderStorage))
+
+	chunkID := unittest.IdentifierFixture()
+	cdp := largeChunkDataPackFixture(chunkID)
+
+	blobID, err := publisher.Publish(context.Background(), 10, cdp)
+	require.NoError(t, err)
+
+	downloaded, err := downloader.Download(context.Background(), 10, blobID, chunkID)
+	require.NoError(t, err)
+	assert.Equal(t, cdp, downloaded)
+
+	// publishing the same chunk data pack again results in the same root blob
+	again, err := publisher.Publish(context.Background(), 10, cdp)
+	require.NoError(t, err)
+	assert.Equal(t, blobID, again)
+
+	// pruning the sealed height of the block releases all blobs of the chunk data pack on both sides
+	require.NoError(t, publisherStorage.SetFulfilledHeight(10))
+	require.NoError(t, publisherStorage.PruneUpToHeight(10))
+	require.NoError(t, downloaderStorage.SetFulfilledHeight(10))
+	require.NoError(t, downloaderStorage.PruneUpToHeight(10))
+
+	// the chunk data pack spans multiple blobs, plus the root blob referencing them
+	assert.Greater(t, len(publisherPruned), 3)
+	assert.Equal(t, publisherPruned, downloaderPruned)
+	assert.Contains(t, publisherPruned, flow.IdToCid(blobID))
+}
+
+// TestDownloadInvalidBlob evaluates that the downloader rejects blobs whose content does not hash to the requested CID.
+func TestDownloadInvalidBlob(t *testing.T) {
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	// a malicious node serves arbitrary content for the requested CID
+	blobService := blobServiceFixture(t, blobstore, func(ctx context.Context, c cid.Cid) (blobs.Blob, error) {
+		return blocks.NewBlockWithCid(unittest.RandomBytes(128), c)
+	})
+
+	storage, err := tracker.OpenStorage(t.TempDir(), 0, zerolog.Nop())
+	require.NoError(t, err)
+
+	publisher := chunk_data_pack.NewPublisher(zerolog.Nop(), execution_data.DefaultSerializer, blobService, storage)
+	downloader := chunk_data_pack.NewDownloader(blobService, execution_data.DefaultSerializer)
+
+	chunkID := unittest.IdentifierFixture()
+	blobID, err := publisher.Publish(context.Background(), 10, unittest.ChunkDataPackFixture(chunkID))
+	require.NoError(t, err)
+
+	_, err = downloader.Download(context.Background(), 10, blobID, chunkID)
+	assert.True(t, chunk_data_pack.IsInvalidBlobError(err))
+}
+
+// TestDownloadMismatchingChunk evaluates that the downloader rejects chunk data packs of a chunk other than the requested one.
+func TestDownloadMismatchingChunk(t *testing.T) {
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	blobService := blobServiceFixture(t, blobstore, blobstore.Get)
+
+	storage, err := tracker.OpenStorage(t.TempDir(), 0, zerolog.Nop())
+	require.NoError(t, err)
+
+	publisher := chunk_data_pack.NewPublisher(zerolog.Nop(), execution_data.DefaultSerializer, blobService, storage)
+	downloader := chunk_data_pack.NewDownloader(blobService, execution_data.DefaultSerializer)
+
+	blobID, err := publisher.Publish(context.Background(), 10, unittest.ChunkDataPackFixture(unittest.IdentifierFixture()))
+	require.NoError(t, err)
+
+	_, err = downloader.Download(context.Background(), 10, blobID, unittest.IdentifierFixture())
+	var malformedDataError *execution_data.MalformedDataError
+	assert.ErrorAs(t, err, &malformedDataError)
+}
+
+// TestDownloadBlobNotFound evaluates that the downloader returns a BlobNotFoundError for unknown root blobs.
+func TestDownloadBlobNotFound(t *testing.T) {
+	blobstore := blobs.NewBlobstore(dssync.MutexWrap(datastore.NewMapDatastore()))
+	blobService := blobServiceFixture(t, blobstore, blobstore.Get)
+	downloader := chunk_data_pack.NewDownloader(blobService, execution_data.DefaultSerializer)
+
+	_, err := downloader.Download(context.Background(), 10, unittest.IdentifierFixture(), unittest.IdentifierFixture())
+	var blobNotFoundError *execution_data.BlobNotFoundError
+	assert.ErrorAs(t, err, &blobNotFoundError)
+}
@@ -0,0 +1,190 @@
+package chunk_data_pack
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
+	"github.com/onflow/flow-go/network"
+)
+
+// InvalidBlobError is returned when the content of a retrieved blob does not hash to its CID,
+// or the blob exceeds the maximum allowed size.
+type InvalidBlobError struct {
+	cid cid.Cid
+	err error
+}
+
+func NewInvalidBlobError(cid cid.Cid, err error) *InvalidBlobError {
+	return &InvalidBlobError{cid: cid, err: err}
+}
+
+func (e *InvalidBlobError) Error() string {
+	return fmt.Sprintf("invalid blob %v: %v", e.cid.String(), e.err)
+}
+
+func (e *InvalidBlobError) Unwrap() error { return e.err }
+
+// IsInvalidBlobError returns whether an error is InvalidBlobError
+func IsInvalidBlobError(err error) bool {
+	var invalidBlobErr *InvalidBlobError
+	return errors.As(err, &invalidBlobErr)
+}
+
+// Downloader is used to download chunk data pack blobs from the network via a blob service.
+type Downloader interface {
+	module.ReadyDoneAware
+
+	// Download downloads and returns the chunk data pack of the given chunk from the network by the ID of its root blob.
+	// The blobs are tracked at the given height of the executed block, so that they are pruned once the block is sealed.
+	// The returned error will be:
+	// - InvalidBlobError if some blob in the blob tree does not hash to its CID, or exceeds the maximum allowed size
+	// - MalformedDataError if some level of the blob tree cannot be properly deserialized, or the chunk data pack
+	//   does not belong to the given chunk
+	// - BlobNotFoundError if some CID in the blob tree could not be found from the blob service
+	Download(ctx context.Context, blockHeight uint64, blobID flow.Identifier, chunkID flow.Identifier) (*flow.ChunkDataPack, error)
+}
+
+type DownloaderOption func(*downloader)
+
+// WithTracker configures the downloader to track the downloaded blobs in the given storage,
+// so that they can be pruned from the local blob store later.
+func WithTracker(storage tracker.Storage) DownloaderOption {
+	return func(d *downloader) {
+		d.storage = storage
+	}
+}
+
+type downloader struct {
+	blobService network.BlobService
+	maxBlobSize int
+	serializer  execution_data.Serializer
+	storage     tracker.Storage
+}
+
+var _ Downloader = (*downloader)(nil)
+
+// NewDownloader creates a new chunk data pack Downloader.
+func NewDownloader(blobService network.BlobService, serializer execution_data.Serializer, opts ...DownloaderOption) *downloader {
+	d := &downloader{
+		blobService: blobService,
+		maxBlobSize: execution_data.DefaultMaxBlobSize,
+		serializer:  serializer,
+	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+func (d *downloader) Ready() <-chan struct{} {
+	return d.blobService.Ready()
+}
+
+func (d *downloader) Done() <-chan struct{} {
+	return d.blobService.Done()
+}
+
+// Download downloads and returns the chunk data pack of the given chunk from the network by the ID of its root blob.
+// The returned error will be:
+// - InvalidBlobError if some blob in the blob tree does not hash to its CID, or exceeds the maximum allowed size
+// - MalformedDataError if some level of the blob tree cannot be properly deserialized, or the chunk data pack
+// does not belong to the given chunk
+// - BlobNotFoundError if some CID in the blob tree could not be found from the blob service
+func (d *downloader) Download(ctx context.Context, blockHeight uint64, blobID flow.Identifier, chunkID flow.Identifier) (*flow.ChunkDataPack, error) {
+	blobGetter := d.blobService.GetSession(ctx)
+
+	// iteratively process each level of the blob tree, from the root down, until a chunk data pack is
+	// returned or an error is encountered.
+	cids := []cid.Cid{flow.IdToCid(blobID)}
+	var downloaded []cid.Cid
+	var chunkDataPack *flow.ChunkDataPack
+	for i := 0; chunkDataPack == nil; i++ {
+		v, err := d.getBlobs(ctx, blobGetter, cids)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get level %d of blob tree: %w", i, err)
+		}
+		downloaded = append(downloaded, cids...)
+
+		switch v := v.(type) {
+		case *flow.ChunkDataPack:
+			chunkDataPack = v
+		case *[]cid.Cid:
+			cids = *v
+		default:
+			return nil, execution_data.NewMalformedDataError(fmt.Errorf("blob tree contains unexpected type %T at level %d", v, i))
+		}
+	}
+
+	if chunkDataPack.ChunkID != chunkID {
+		return nil, execution_data.NewMalformedDataError(fmt.Errorf("chunk data pack belongs to chunk %v, expected %v", chunkDataPack.ChunkID, chunkID))
+	}
+
+	if d.storage != nil {
+		err := d.storage.Update(func(trackBlobs tracker.TrackBlobsFn) error {
+			return trackBlobs(blockHeight, downloaded...)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to track blobs: %w", err)
+		}
+	}
+
+	return chunkDataPack, nil
+}
+
+// getBlobs gets the given CIDs from the blob getter, validates them against their CIDs, reassembles the blobs,
+// and deserializes the reassembled data into an object.
+func (d *downloader) getBlobs(ctx context.Context, blobGetter network.BlobGetter, cids []cid.Cid) (interface{}, error) {
+	buf := new(bytes.Buffer)
+
+	for _, c := range cids {
+		blob, err := blobGetter.GetBlob(ctx, c)
+		if err != nil {
+			if errors.Is(err, network.ErrBlobNotFound) {
+				return nil, execution_data.NewBlobNotFoundError(c)
+			}
+
+			return nil, fmt.Errorf("failed to get blob: %w", err)
+		}
+
+		err = d.validateBlob(c, blob)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = buf.Write(blob.RawData())
+		if err != nil {
+			return nil, fmt.Errorf("failed to write blob %s to deserialization buffer: %w", c.String(), err)
+		}
+	}
+
+	v, err := d.serializer.Deserialize(buf)
+	if err != nil {
+		return nil, execution_data.NewMalformedDataError(err)
+	}
+
+	return v, nil
+}
+
+// validateBlob checks that the given blob is within the size limit, and its content hashes to the requested CID.
+func (d *downloader) validateBlob(c cid.Cid, blob blobs.Blob) error {
+	if len(blob.RawData()) > d.maxBlobSize {
+		return NewInvalidBlobError(c, fmt.Errorf("blob size %d exceeds maximum blob size %d", len(blob.RawData()), d.maxBlobSize))
+	}
+
+	if actual := blobs.NewBlob(blob.RawData()).Cid(); !actual.Equals(c) {
+		return NewInvalidBlobError(c, fmt.Errorf("blob content hashes to %v", actual.String()))
+	}
+
+	return nil
+}
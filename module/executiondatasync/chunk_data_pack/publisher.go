@@ -0,0 +1,149 @@
+package chunk_data_pack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ipfs/go-cid"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/execution_data"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
+	"github.com/onflow/flow-go/network"
+)
+
+// Publisher is used to publish chunk data packs as content-addressed blobs over the network via a blob service,
+// so that they can be retrieved from any node holding them.
+type Publisher interface {
+	// Publish adds the given chunk data pack to the blob service and returns the ID of the root blob
+	// the chunk data pack can be retrieved by. The blobs are tracked at the given height of the
+	// executed block, so that they are pruned once the block is sealed.
+	// No errors are expected during normal operation.
+	Publish(ctx context.Context, blockHeight uint64, chunkDataPack *flow.ChunkDataPack) (flow.Identifier, error)
+}
+
+type PublisherOption func(*publisher)
+
+// WithBlobSizeLimit configures the maximum size of the blobs a chunk data pack is split into.
+func WithBlobSizeLimit(size int) PublisherOption {
+	return func(p *publisher) {
+		p.maxBlobSize = size
+	}
+}
+
+type publisher struct {
+	logger      zerolog.Logger
+	maxBlobSize int
+	serializer  execution_data.Serializer
+	blobService network.BlobService
+	storage     tracker.Storage
+}
+
+var _ Publisher = (*publisher)(nil)
+
+// NewPublisher creates a new chunk data pack Publisher.
+func NewPublisher(
+	logger zerolog.Logger,
+	serializer execution_data.Serializer,
+	blobService network.BlobService,
+	storage tracker.Storage,
+	opts ...PublisherOption,
+) *publisher {
+	p := &publisher{
+		logger:      logger.With().Str("component", "chunk_data_pack_publisher").Logger(),
+		maxBlobSize: execution_data.DefaultMaxBlobSize,
+		serializer:  serializer,
+		blobService: blobService,
+		storage:     storage,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Publish adds the given chunk data pack to the blob service and returns the ID of the root blob
+// the chunk data pack can be retrieved by.
+// No errors are expected during normal operation.
+func (p *publisher) Publish(ctx context.Context, blockHeight uint64, chunkDataPack *flow.ChunkDataPack) (flow.Identifier, error) {
+	var v interface{} = chunkDataPack
+	var blbs []blobs.Blob
+	var cids []cid.Cid
+
+	// builds the blob tree bottom up, until a single root blob remains
+	for i := 0; ; i++ {
+		levelBlobs, err := p.splitIntoBlobs(v)
+		if err != nil {
+			return flow.ZeroID, fmt.Errorf("failed to build blob tree level at height %d: %w", i, err)
+		}
+
+		levelCids := make([]cid.Cid, 0, len(levelBlobs))
+		for _, blob := range levelBlobs {
+			levelCids = append(levelCids, blob.Cid())
+		}
+		blbs = append(blbs, levelBlobs...)
+		cids = append(cids, levelCids...)
+
+		if len(levelCids) == 1 {
+			break
+		}
+		v = levelCids
+	}
+
+	err := p.storage.Update(func(trackBlobs tracker.TrackBlobsFn) error {
+		// track new blobs so that they can be pruned once the block is sealed
+		if err := trackBlobs(blockHeight, cids...); err != nil {
+			return fmt.Errorf("failed to track blobs: %w", err)
+		}
+
+		if err := p.blobService.AddBlobs(ctx, blbs); err != nil {
+			return fmt.Errorf("failed to add blobs: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return flow.ZeroID, err
+	}
+
+	rootID, err := flow.CidToId(cids[len(cids)-1])
+	if err != nil {
+		return flow.ZeroID, fmt.Errorf("failed to convert root blob cid to id: %w", err)
+	}
+
+	p.logger.Debug().
+		Hex("chunk_id", chunkDataPack.ChunkID[:]).
+		Uint64("height", blockHeight).
+		Str("root_id", rootID.String()).
+		Int("blobs", len(blbs)).
+		Msg("chunk data pack published")
+
+	return rootID, nil
+}
+
+// splitIntoBlobs serializes the given object and splits the serialized data into blobs of at most the maximum blob size.
+func (p *publisher) splitIntoBlobs(v interface{}) ([]blobs.Blob, error) {
+	buf := new(bytes.Buffer)
+	if err := p.serializer.Serialize(buf, v); err != nil {
+		return nil, fmt.Errorf("failed to serialize object: %w", err)
+	}
+
+	data := buf.Bytes()
+	var blbs []blobs.Blob
+	for len(data) > 0 {
+		blobLen := p.maxBlobSize
+		if len(data) < blobLen {
+			blobLen = len(data)
+		}
+
+		blbs = append(blbs, blobs.NewBlob(data[:blobLen]))
+		data = data[blobLen:]
+	}
+
+	return blbs, nil
+}
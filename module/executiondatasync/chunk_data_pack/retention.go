@@ -0,0 +1,146 @@
+package chunk_data_pack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/executiondatasync/pruner"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/util"
+	"github.com/onflow/flow-go/state/protocol"
+	"github.com/onflow/flow-go/state/protocol/events"
+)
+
+// DefaultRetentionThreshold is the default number of sealed heights whose chunk data pack blobs are
+// accumulated before they are pruned together.
+const DefaultRetentionThreshold = uint64(100)
+
+// Retention is a component that ties the retention of chunk data pack blobs to sealing:
+// once a block is sealed its chunks no longer need to be verified, hence the blobs tracked
+// at its height are pruned. It consumes finalization events of the protocol state, and
+// advances the fulfilled height of the tracker storage to the latest sealed height.
+type Retention struct {
+	events.Noop // satisfy protocol events consumer interface
+	component.Component
+	cm *component.ComponentManager
+
+	log      zerolog.Logger
+	state    protocol.State
+	storage  tracker.Storage
+	pruner   *pruner.Pruner
+	notifier engine.Notifier
+}
+
+var _ protocol.Consumer = (*Retention)(nil)
+
+// NewRetention creates a new Retention component, which prunes the blobs tracked in the given storage
+// once their height is sealed. Pruning is performed once every threshold many sealed heights, after
+// which the given prune callback is invoked to reclaim the space of the deleted blobs.
+func NewRetention(
+	log zerolog.Logger,
+	metrics module.ExecutionDataPrunerMetrics,
+	state protocol.State,
+	storage tracker.Storage,
+	threshold uint64,
+	pruneCallback func(context.Context) error,
+) (*Retention, error) {
+	p, err := pruner.NewPruner(
+		log,
+		metrics,
+		storage,
+		// blobs of sealed heights are no longer needed, hence all of them are pruned
+		pruner.WithHeightRangeTarget(0),
+		pruner.WithThreshold(threshold),
+		pruner.WithPruneCallback(pruneCallback),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create pruner: %w", err)
+	}
+
+	r := &Retention{
+		log:      log.With().Str("component", "chunk_data_pack_retention").Logger(),
+		state:    state,
+		storage:  storage,
+		pruner:   p,
+		notifier: engine.NewNotifier(),
+	}
+
+	r.cm = component.NewComponentManagerBuilder().
+		AddWorker(r.runPruner).
+		AddWorker(r.processSealedHeights).
+		Build()
+	r.Component = r.cm
+
+	return r, nil
+}
+
+// BlockFinalized implements protocol.Consumer. As the latest sealed block only changes upon
+// finalization, it notifies the worker to check the latest sealed height.
+func (r *Retention) BlockFinalized(*flow.Header) {
+	r.notifier.Notify()
+}
+
+// runPruner runs the underlying pruner component.
+func (r *Retention) runPruner(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	r.pruner.Start(ctx)
+
+	err := util.WaitClosed(ctx, r.pruner.Ready())
+	if err == nil {
+		ready()
+	}
+
+	<-r.pruner.Done()
+}
+
+// processSealedHeights advances the fulfilled height of the tracker storage to the latest sealed height
+// whenever notified, and forwards it to the pruner.
+func (r *Retention) processSealedHeights(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.notifier.Channel():
+			err := r.onSealedHeight()
+			if err != nil {
+				ctx.Throw(err)
+			}
+		}
+	}
+}
+
+// onSealedHeight sets the fulfilled height of the tracker storage to the latest sealed height,
+// if it increased. No errors are expected during normal operation.
+func (r *Retention) onSealedHeight() error {
+	sealed, err := r.state.Sealed().Head()
+	if err != nil {
+		return fmt.Errorf("could not get last sealed: %w", err)
+	}
+
+	fulfilled, err := r.storage.GetFulfilledHeight()
+	if err != nil {
+		return fmt.Errorf("could not get fulfilled height: %w", err)
+	}
+
+	if sealed.Height <= fulfilled {
+		return nil
+	}
+
+	err = r.storage.SetFulfilledHeight(sealed.Height)
+	if err != nil {
+		return fmt.Errorf("could not set fulfilled height: %w", err)
+	}
+
+	r.log.Debug().Uint64("sealed_height", sealed.Height).Msg("chunk data pack blobs fulfilled up to sealed height")
+	r.pruner.NotifyFulfilledHeight(sealed.Height)
+
+	return nil
+}
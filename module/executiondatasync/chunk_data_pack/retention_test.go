@@ -0,0 +1,92 @@
+package chunk_data_pack_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/blobs"
+	"github.com/onflow/flow-go/module/executiondatasync/chunk_data_pack"
+	"github.com/onflow/flow-go/module/executiondatasync/tracker"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestRetention evaluates that the blobs tracked at a height are pruned once the height is sealed,
+// while the blobs of unsealed heights are retained.
+func TestRetention(t *testing.T) {
+	sealedHeight := atomic.NewUint64(0)
+	state := protocol.NewState(t)
+	snapshot := protocol.NewSnapshot(t)
+	state.On("Sealed").Return(snapshot)
+	snapshot.On("Head").Return(
+		func() *flow.Header {
+			header := unittest.BlockHeaderFixture()
+			header.Height = sealedHeight.Load()
+			return header
+		},
+		func() error { return nil },
+	)
+
+	pruned := make(chan cid.Cid, 10)
+	storage, err := tracker.OpenStorage(t.TempDir(), 0, zerolog.Nop(), tracker.WithPruneCallback(func(c cid.Cid) error {
+		pruned <- c
+		return nil
+	}))
+	require.NoError(t, err)
+
+	sealedBlob := blobs.NewBlob(unittest.RandomBytes(128)).Cid()
+	unsealedBlob := blobs.NewBlob(unittest.RandomBytes(128)).Cid()
+	require.NoError(t, storage.Update(func(trackBlobs tracker.TrackBlobsFn) error {
+		if err := trackBlobs(5, sealedBlob); err != nil {
+			return err
+		}
+		return trackBlobs(11, unsealedBlob)
+	}))
+
+	gcCalled := make(chan struct{}, 10)
+	retention, err := chunk_data_pack.NewRetention(
+		zerolog.Nop(),
+		metrics.NewNoopCollector(),
+		state,
+		storage,
+		0,
+		func(context.Context) error {
+			gcCalled <- struct{}{}
+			return nil
+		},
+	)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signalerCtx, _ := irrecoverable.WithSignaler(ctx)
+	retention.Start(signalerCtx)
+	unittest.RequireCloseBefore(t, retention.Ready(), time.Second, "could not start retention")
+
+	sealedHeight.Store(10)
+	retention.BlockFinalized(unittest.BlockHeaderFixture())
+
+	unittest.RequireReturnsBefore(t, func() {
+		require.Equal(t, sealedBlob, <-pruned)
+		<-gcCalled
+	}, time.Second, "blobs of sealed height were not pruned")
+
+	fulfilled, err := storage.GetFulfilledHeight()
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), fulfilled)
+
+	// blob of the unsealed height is retained
+	require.Empty(t, pruned)
+
+	cancel()
+	unittest.RequireCloseBefore(t, retention.Done(), time.Second, "could not stop retention")
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/onflow/flow-go/model/encoding"
 	"github.com/onflow/flow-go/model/encoding/cbor"
+	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/network"
 	"github.com/onflow/flow-go/network/compressor"
 )
@@ -42,6 +43,7 @@ const (
 	codeRecursiveCIDs = iota + 1
 	codeExecutionDataRoot
 	codeChunkExecutionData
+	codeChunkDataPack
 )
 
 func getCode(v interface{}) (byte, error) {
@@ -50,6 +52,8 @@ func getCode(v interface{}) (byte, error) {
 		return codeExecutionDataRoot, nil
 	case *ChunkExecutionData:
 		return codeChunkExecutionData, nil
+	case *flow.ChunkDataPack:
+		return codeChunkDataPack, nil
 	case []cid.Cid:
 		return codeRecursiveCIDs, nil
 	default:
@@ -63,6 +67,8 @@ func getPrototype(code byte) (interface{}, error) {
 		return &BlockExecutionDataRoot{}, nil
 	case codeChunkExecutionData:
 		return &ChunkExecutionData{}, nil
+	case codeChunkDataPack:
+		return &flow.ChunkDataPack{}, nil
 	case codeRecursiveCIDs:
 		return &[]cid.Cid{}, nil
 	default:
@@ -70,8 +76,8 @@ func getPrototype(code byte) (interface{}, error) {
 	}
 }
 
-// Serializer is used to serialize / deserialize Execution Data and CID lists for the
-// Execution Data Service.
+// Serializer is used to serialize / deserialize Execution Data, Chunk Data Packs and CID lists for the
+// Execution Data Service and the Chunk Data Pack Service.
 type Serializer interface {
 	Serialize(io.Writer, interface{}) error
 	Deserialize(io.Reader) (interface{}, error)
@@ -0,0 +1,104 @@
+package health
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-go/state/protocol"
+)
+
+// Names of the domain specific checks.
+const (
+	FinalizedHeightLagCheck = "finalized-height-lag"
+	SealedHeightLagCheck    = "sealed-height-lag"
+	ExecutedHeightLagCheck  = "executed-height-lag"
+	PeerCountCheck          = "peer-count"
+)
+
+// Config configures the health server and the thresholds of the domain specific checks.
+type Config struct {
+	Addr                  string // address to serve the health endpoints on, disabled if empty
+	MaxFinalizedHeightLag uint64 // maximum number of blocks the local finalized height may be behind the highest height reported by other nodes
+	MaxSealedHeightLag    uint64 // maximum number of blocks the latest sealed block may be behind the latest finalized block
+	MaxExecutedHeightLag  uint64 // maximum number of blocks the highest executed block may be behind the latest finalized block (execution nodes only)
+	MinPeers              uint   // minimum number of peers the node must be connected to
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Addr:                  "",
+		MaxFinalizedHeightLag: 100,
+		MaxSealedHeightLag:    500,
+		MaxExecutedHeightLag:  100,
+		MinPeers:              1,
+	}
+}
+
+// NewFinalizedHeightLagCheck returns a check which fails if the local finalized height is more
+// than maxLag blocks behind the finalized height reported by other nodes, as returned by
+// reportedHeight.
+func NewFinalizedHeightLagCheck(state protocol.State, reportedHeight func() uint64, maxLag uint64) CheckFunc {
+	return func() error {
+		final, err := state.Final().Head()
+		if err != nil {
+			return fmt.Errorf("could not get finalized header: %w", err)
+		}
+		reported := reportedHeight()
+		if reported > final.Height && reported-final.Height > maxLag {
+			return fmt.Errorf("finalized height %d is %d blocks behind reported height %d (max lag: %d)",
+				final.Height, reported-final.Height, reported, maxLag)
+		}
+		return nil
+	}
+}
+
+// NewSealedHeightLagCheck returns a check which fails if the latest sealed block is more than
+// maxLag blocks behind the latest finalized block.
+func NewSealedHeightLagCheck(state protocol.State, maxLag uint64) CheckFunc {
+	return func() error {
+		final, err := state.Final().Head()
+		if err != nil {
+			return fmt.Errorf("could not get finalized header: %w", err)
+		}
+		sealed, err := state.Sealed().Head()
+		if err != nil {
+			return fmt.Errorf("could not get sealed header: %w", err)
+		}
+		if final.Height-sealed.Height > maxLag {
+			return fmt.Errorf("sealed height %d is %d blocks behind finalized height %d (max lag: %d)",
+				sealed.Height, final.Height-sealed.Height, final.Height, maxLag)
+		}
+		return nil
+	}
+}
+
+// NewExecutedHeightLagCheck returns a check which fails if the highest executed block, as
+// returned by executedHeight, is more than maxLag blocks behind the latest finalized block.
+func NewExecutedHeightLagCheck(state protocol.State, executedHeight func() (uint64, error), maxLag uint64) CheckFunc {
+	return func() error {
+		final, err := state.Final().Head()
+		if err != nil {
+			return fmt.Errorf("could not get finalized header: %w", err)
+		}
+		executed, err := executedHeight()
+		if err != nil {
+			return fmt.Errorf("could not get highest executed height: %w", err)
+		}
+		if final.Height > executed && final.Height-executed > maxLag {
+			return fmt.Errorf("executed height %d is %d blocks behind finalized height %d (max lag: %d)",
+				executed, final.Height-executed, final.Height, maxLag)
+		}
+		return nil
+	}
+}
+
+// NewPeerCountCheck returns a check which fails if the number of connected peers, as returned
+// by peerCount, is below minPeers.
+func NewPeerCountCheck(peerCount func() int, minPeers uint) CheckFunc {
+	return func() error {
+		count := peerCount()
+		if count < int(minPeers) {
+			return fmt.Errorf("connected to %d peers (min: %d)", count, minPeers)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,75 @@
+package health
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	protocol "github.com/onflow/flow-go/state/protocol/mock"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// mockState returns a protocol state with the given finalized and sealed heights.
+func mockState(t *testing.T, finalized uint64, sealed uint64) *protocol.State {
+	state := protocol.NewState(t)
+
+	final := protocol.NewSnapshot(t)
+	final.On("Head").Return(unittest.BlockHeaderFixture(unittest.WithHeaderHeight(finalized)), nil).Maybe()
+	state.On("Final").Return(final).Maybe()
+
+	seal := protocol.NewSnapshot(t)
+	seal.On("Head").Return(unittest.BlockHeaderFixture(unittest.WithHeaderHeight(sealed)), nil).Maybe()
+	state.On("Sealed").Return(seal).Maybe()
+
+	return state
+}
+
+func TestFinalizedHeightLagCheck(t *testing.T) {
+	state := mockState(t, 100, 90)
+
+	reported := uint64(0)
+	check := NewFinalizedHeightLagCheck(state, func() uint64 { return reported }, 10)
+
+	// nothing reported yet
+	assert.NoError(t, check())
+
+	reported = 110
+	assert.NoError(t, check())
+
+	reported = 111
+	assert.Error(t, check())
+}
+
+func TestSealedHeightLagCheck(t *testing.T) {
+	assert.NoError(t, NewSealedHeightLagCheck(mockState(t, 100, 90), 10)())
+	assert.Error(t, NewSealedHeightLagCheck(mockState(t, 100, 89), 10)())
+}
+
+func TestExecutedHeightLagCheck(t *testing.T) {
+	state := mockState(t, 100, 90)
+
+	var executed uint64
+	var executedErr error
+	check := NewExecutedHeightLagCheck(state, func() (uint64, error) { return executed, executedErr }, 10)
+
+	executed = 90
+	assert.NoError(t, check())
+
+	executed = 89
+	assert.Error(t, check())
+
+	executed = 100
+	executedErr = fmt.Errorf("not found")
+	assert.Error(t, check())
+}
+
+func TestPeerCountCheck(t *testing.T) {
+	peers := 0
+	check := NewPeerCountCheck(func() int { return peers }, 2)
+
+	assert.Error(t, check())
+
+	peers = 2
+	assert.NoError(t, check())
+}
@@ -0,0 +1,246 @@
+package health
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrAlreadyRegistered is returned when a component or check is registered with a name
+// conflicting with an already registered one.
+var ErrAlreadyRegistered = fmt.Errorf("name already registered")
+
+// ComponentState is the lifecycle state of a component run by the node.
+type ComponentState int
+
+const (
+	// ComponentStarting indicates the component has not yet reported being ready.
+	ComponentStarting ComponentState = iota
+	// ComponentReady indicates the component has started up and is running.
+	ComponentReady
+	// ComponentRestarting indicates the component encountered an irrecoverable error
+	// and is being restarted by its error handler.
+	ComponentRestarting
+	// ComponentStopped indicates the component has shut down gracefully.
+	ComponentStopped
+	// ComponentFailed indicates the component encountered an irrecoverable error
+	// which was not handled by a restart.
+	ComponentFailed
+)
+
+func (s ComponentState) String() string {
+	switch s {
+	case ComponentStarting:
+		return "starting"
+	case ComponentReady:
+		return "ready"
+	case ComponentRestarting:
+		return "restarting"
+	case ComponentStopped:
+		return "stopped"
+	case ComponentFailed:
+		return "failed"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(s))
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler, so the state is reported by name.
+func (s ComponentState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, the inverse of MarshalText.
+func (s *ComponentState) UnmarshalText(text []byte) error {
+	for state := ComponentStarting; state <= ComponentFailed; state++ {
+		if state.String() == string(text) {
+			*s = state
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown component state: %s", text)
+}
+
+// CheckFunc is a domain specific health check. It returns a descriptive error if the
+// node is not healthy with respect to the checked property, and nil otherwise.
+// Checks are run on every readiness request and must therefore be cheap.
+type CheckFunc func() error
+
+// ComponentStatus is the reported status of a single component.
+type ComponentStatus struct {
+	Name        string         `json:"name"`
+	State       ComponentState `json:"state"`
+	Restartable bool           `json:"restartable,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	Since       time.Time      `json:"since"`
+}
+
+// CheckStatus is the result of running a single CheckFunc.
+type CheckStatus struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Report summarizes the health of the node.
+type Report struct {
+	Healthy    bool              `json:"healthy"`
+	Components []ComponentStatus `json:"components"`
+	Checks     []CheckStatus     `json:"checks,omitempty"`
+}
+
+// Registry keeps track of the state of the components of a node, as well as of the
+// domain specific checks which determine whether the node is ready to serve traffic.
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu         sync.RWMutex
+	components map[string]*ComponentStatus
+	checks     map[string]CheckFunc
+}
+
+// NewRegistry returns a new empty health registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		components: make(map[string]*ComponentStatus),
+		checks:     make(map[string]CheckFunc),
+	}
+}
+
+// RegisterComponent registers a component in the ComponentStarting state.
+// Restartable components are those the node does not depend on for safe operation,
+// they are reported but do not affect liveness or readiness.
+// Returns ErrAlreadyRegistered if a component with the same name is already registered.
+func (r *Registry) RegisterComponent(name string, restartable bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.components[name]; exists {
+		return fmt.Errorf("can't register component %s: %w", name, ErrAlreadyRegistered)
+	}
+	r.components[name] = &ComponentStatus{
+		Name:        name,
+		State:       ComponentStarting,
+		Restartable: restartable,
+		Since:       time.Now(),
+	}
+	return nil
+}
+
+// SetComponentState updates the state of the given component. The error is optional and
+// describes the cause of the ComponentRestarting and ComponentFailed states.
+// ComponentFailed is terminal, so that the cause of the failure is still reported once the
+// component has shut down.
+// No-op if the component is not registered or has failed.
+func (r *Registry) SetComponentState(name string, state ComponentState, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status, exists := r.components[name]
+	if !exists || status.State == ComponentFailed {
+		return
+	}
+	status.State = state
+	status.Since = time.Now()
+	status.Error = ""
+	if err != nil {
+		status.Error = err.Error()
+	}
+}
+
+// AddCheck registers a domain specific check which must pass for the node to be ready.
+// Returns ErrAlreadyRegistered if a check with the same name is already registered.
+func (r *Registry) AddCheck(name string, check CheckFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.checks[name]; exists {
+		return fmt.Errorf("can't register check %s: %w", name, ErrAlreadyRegistered)
+	}
+	r.checks[name] = check
+	return nil
+}
+
+// Liveness reports whether the node is alive, which is the case unless a component
+// the node depends on has failed.
+func (r *Registry) Liveness() Report {
+	components := r.componentStatuses()
+
+	healthy := true
+	for _, status := range components {
+		if !status.Restartable && status.State == ComponentFailed {
+			healthy = false
+		}
+	}
+
+	return Report{
+		Healthy:    healthy,
+		Components: components,
+	}
+}
+
+// Readiness reports whether the node is ready, which is the case if all components the
+// node depends on are ready and all registered checks pass.
+func (r *Registry) Readiness() Report {
+	components := r.componentStatuses()
+
+	healthy := true
+	for _, status := range components {
+		if !status.Restartable && status.State != ComponentReady {
+			healthy = false
+		}
+	}
+
+	checks := r.runChecks()
+	for _, check := range checks {
+		if !check.Healthy {
+			healthy = false
+		}
+	}
+
+	return Report{
+		Healthy:    healthy,
+		Components: components,
+		Checks:     checks,
+	}
+}
+
+// componentStatuses returns a copy of the status of all components, sorted by name.
+func (r *Registry) componentStatuses() []ComponentStatus {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	statuses := make([]ComponentStatus, 0, len(r.components))
+	for _, status := range r.components {
+		statuses = append(statuses, *status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+	return statuses
+}
+
+// runChecks runs all registered checks and returns their results, sorted by name.
+// Checks are run without holding the lock, so they don't block component state updates.
+func (r *Registry) runChecks() []CheckStatus {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.checks))
+	checks := make(map[string]CheckFunc, len(r.checks))
+	for name, check := range r.checks {
+		names = append(names, name)
+		checks[name] = check
+	}
+	r.mu.RUnlock()
+
+	sort.Strings(names)
+	statuses := make([]CheckStatus, 0, len(names))
+	for _, name := range names {
+		status := CheckStatus{Name: name, Healthy: true}
+		if err := checks[name](); err != nil {
+			status.Healthy = false
+			status.Error = err.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
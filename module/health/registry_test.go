@@ -0,0 +1,86 @@
+package health
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegistry_RegisterTwice tests that components and checks can't be registered twice.
+func TestRegistry_RegisterTwice(t *testing.T) {
+	registry := NewRegistry()
+
+	require.NoError(t, registry.RegisterComponent("engine", false))
+	err := registry.RegisterComponent("engine", true)
+	assert.ErrorIs(t, err, ErrAlreadyRegistered)
+
+	require.NoError(t, registry.AddCheck("check", func() error { return nil }))
+	err = registry.AddCheck("check", func() error { return nil })
+	assert.ErrorIs(t, err, ErrAlreadyRegistered)
+}
+
+// TestRegistry_Liveness tests that the node is alive unless a non-restartable component failed.
+func TestRegistry_Liveness(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterComponent("engine", false))
+	require.NoError(t, registry.RegisterComponent("optional engine", true))
+
+	// starting components don't affect liveness
+	assert.True(t, registry.Liveness().Healthy)
+
+	// failing restartable components don't affect liveness
+	registry.SetComponentState("optional engine", ComponentFailed, fmt.Errorf("boom"))
+	assert.True(t, registry.Liveness().Healthy)
+
+	registry.SetComponentState("engine", ComponentFailed, fmt.Errorf("boom"))
+	report := registry.Liveness()
+	assert.False(t, report.Healthy)
+	require.Len(t, report.Components, 2)
+	assert.Equal(t, "engine", report.Components[0].Name)
+	assert.Equal(t, ComponentFailed, report.Components[0].State)
+	assert.Equal(t, "boom", report.Components[0].Error)
+	assert.Empty(t, report.Checks)
+
+	// failed is terminal
+	registry.SetComponentState("engine", ComponentStopped, nil)
+	report = registry.Liveness()
+	assert.False(t, report.Healthy)
+	assert.Equal(t, ComponentFailed, report.Components[0].State)
+}
+
+// TestRegistry_Readiness tests that the node is ready only once all non-restartable components
+// are ready and all checks pass.
+func TestRegistry_Readiness(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterComponent("engine", false))
+	require.NoError(t, registry.RegisterComponent("optional engine", true))
+
+	var checkErr error
+	require.NoError(t, registry.AddCheck("check", func() error { return checkErr }))
+
+	assert.False(t, registry.Readiness().Healthy)
+
+	// restartable components are not required to be ready
+	registry.SetComponentState("engine", ComponentReady, nil)
+	report := registry.Readiness()
+	assert.True(t, report.Healthy)
+	require.Len(t, report.Checks, 1)
+	assert.True(t, report.Checks[0].Healthy)
+
+	registry.SetComponentState("optional engine", ComponentRestarting, fmt.Errorf("boom"))
+	assert.True(t, registry.Readiness().Healthy)
+
+	// failing checks make the node unready
+	checkErr = fmt.Errorf("lagging behind")
+	report = registry.Readiness()
+	assert.False(t, report.Healthy)
+	assert.False(t, report.Checks[0].Healthy)
+	assert.Equal(t, "lagging behind", report.Checks[0].Error)
+
+	// a stopped component makes the node unready
+	checkErr = nil
+	registry.SetComponentState("engine", ComponentStopped, nil)
+	assert.False(t, registry.Readiness().Healthy)
+}
@@ -0,0 +1,69 @@
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/module/httpserver"
+)
+
+const (
+	// LivenessEndpoint serves the liveness Report. It responds with 200 if the node is alive
+	// and with 503 otherwise.
+	LivenessEndpoint = "/health/live"
+	// ReadinessEndpoint serves the readiness Report. It responds with 200 if the node is ready
+	// and with 503 otherwise.
+	ReadinessEndpoint = "/health/ready"
+)
+
+// Server is the http server serving the liveness and readiness of a node, e.g. for
+// Kubernetes probes.
+type Server struct {
+	*httpserver.Server
+}
+
+// NewServer creates a new server that will listen on the specified address and serve
+// the health reports compiled by the given registry.
+func NewServer(log zerolog.Logger, registry *Registry, addr string) *Server {
+	log = log.With().
+		Str("component", "health_server").
+		Strs("endpoints", []string{LivenessEndpoint, ReadinessEndpoint}).
+		Logger()
+
+	return &Server{
+		Server: httpserver.NewServer(log, "health", addr, newHandler(log, registry)),
+	}
+}
+
+// newHandler returns the handler serving the health endpoints.
+func newHandler(log zerolog.Logger, registry *Registry) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(LivenessEndpoint, reportHandler(log, registry.Liveness))
+	mux.Handle(ReadinessEndpoint, reportHandler(log, registry.Readiness))
+	return mux
+}
+
+// reportHandler returns a handler writing the report compiled by the given function as json.
+func reportHandler(log zerolog.Logger, compile func() Report) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		report := compile()
+
+		w.Header().Set("Content-Type", "application/json")
+		if report.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		err := json.NewEncoder(w).Encode(report)
+		if err != nil {
+			log.Warn().Err(err).Msg("could not write health report")
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestHandler tests that the liveness and readiness endpoints respond with the status code
+// matching the health of the node, and report the state of components and checks.
+func TestHandler(t *testing.T) {
+	registry := NewRegistry()
+	require.NoError(t, registry.RegisterComponent("engine", false))
+
+	var checkErr error
+	require.NoError(t, registry.AddCheck(PeerCountCheck, func() error { return checkErr }))
+
+	handler := newHandler(unittest.Logger(), registry)
+
+	get := func(endpoint string) (int, Report) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, endpoint, nil))
+
+		var report Report
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &report))
+		return recorder.Code, report
+	}
+
+	code, report := get(LivenessEndpoint)
+	assert.Equal(t, http.StatusOK, code)
+	assert.True(t, report.Healthy)
+
+	code, report = get(ReadinessEndpoint)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	require.Len(t, report.Components, 1)
+	assert.Equal(t, "engine", report.Components[0].Name)
+
+	registry.SetComponentState("engine", ComponentReady, nil)
+	code, report = get(ReadinessEndpoint)
+	assert.Equal(t, http.StatusOK, code)
+	require.Len(t, report.Checks, 1)
+	assert.Equal(t, PeerCountCheck, report.Checks[0].Name)
+
+	checkErr = fmt.Errorf("connected to 0 peers (min: 1)")
+	code, report = get(ReadinessEndpoint)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, checkErr.Error(), report.Checks[0].Error)
+
+	registry.SetComponentState("engine", ComponentFailed, fmt.Errorf("boom"))
+	code, _ = get(LivenessEndpoint)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, LivenessEndpoint, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,37 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"golang.org/x/time/rate"
+)
+
+// Authenticate rejects requests which do not carry the given token as bearer token in their
+// Authorization header.
+func Authenticate(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		provided := strings.TrimPrefix(header, "Bearer ")
+		if provided == header || subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RateLimit rejects requests exceeding the rate of the given limiter, asking clients to retry
+// after the given number of seconds.
+func RateLimit(limiter *rate.Limiter, retryAfterSeconds int, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.Allow() {
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
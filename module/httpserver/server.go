@@ -0,0 +1,72 @@
+package httpserver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// shutdownTimeout is the time in-flight requests are given to complete on shutdown.
+const shutdownTimeout = 5 * time.Second
+
+// Server runs an http server as a ReadyDoneAware component of a node. It starts serving
+// when Ready is called, and shuts down when Done is called. It logs once it listens on its
+// address, so wrapping servers don't need to log their start themselves.
+type Server struct {
+	server *http.Server
+	log    zerolog.Logger
+	name   string
+}
+
+// NewServer creates a new server with the given name, e.g. "metrics", which will listen on
+// the specified address and serve requests with the given handler.
+func NewServer(log zerolog.Logger, name string, addr string, handler http.Handler) *Server {
+	return &Server{
+		server: &http.Server{Addr: addr, Handler: handler},
+		log:    log.With().Str("server", name).Str("address", addr).Logger(),
+		name:   name,
+	}
+}
+
+// Ready returns a channel that will close when the server is ready, i.e. when it listens on its
+// address, or failed to do so.
+func (s *Server) Ready() <-chan struct{} {
+	ready := make(chan struct{})
+	go func() {
+		listener, err := net.Listen("tcp", s.server.Addr)
+		if err != nil {
+			s.log.Err(err).Msgf("could not start %s server", s.name)
+			close(ready)
+			return
+		}
+		s.log.Info().Msgf("%s server started", s.name)
+		close(ready)
+
+		if err := s.server.Serve(listener); err != nil {
+			// http.ErrServerClosed is returned when Close or Shutdown is called
+			// we don't consider this an error, so print this with debug level instead
+			if errors.Is(err, http.ErrServerClosed) {
+				s.log.Debug().Err(err).Msgf("%s server shutdown", s.name)
+			} else {
+				s.log.Err(err).Msgf("error shutting down %s server", s.name)
+			}
+		}
+	}()
+	return ready
+}
+
+// Done returns a channel that will close when shutdown is complete.
+func (s *Server) Done() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		_ = s.server.Shutdown(ctx)
+		cancel()
+		close(done)
+	}()
+	return done
+}
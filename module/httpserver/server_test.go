@@ -0,0 +1,26 @@
+package httpserver
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestServer_LogsStart tests that the server logs its start once it listens, rather than when
+// it is created.
+func TestServer_LogsStart(t *testing.T) {
+	var logs bytes.Buffer
+	server := NewServer(zerolog.New(&logs), "test", "127.0.0.1:0", http.NotFoundHandler())
+	assert.Empty(t, logs.String())
+
+	unittest.RequireCloseBefore(t, server.Ready(), time.Second, "server did not start")
+	assert.Contains(t, logs.String(), "test server started")
+
+	unittest.RequireCloseBefore(t, server.Done(), time.Second, "server did not stop")
+}
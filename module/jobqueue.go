@@ -5,8 +5,8 @@ import (
 )
 
 const (
-	ConsumeProgressVerificationBlockHeight = "ConsumeProgressVerificationBlockHeight"
-	ConsumeProgressVerificationChunkIndex  = "ConsumeProgressVerificationChunkIndex"
+	ConsumeProgressVerificationBlockHeight       = "ConsumeProgressVerificationBlockHeight"
+	ConsumeProgressVerificationChunkLocatorIndex = "ConsumeProgressVerificationChunkLocatorIndex"
 
 	ConsumeProgressExecutionDataRequesterBlockHeight  = "ConsumeProgressExecutionDataRequesterBlockHeight"
 	ConsumeProgressExecutionDataRequesterNotification = "ConsumeProgressExecutionDataRequesterNotification"
@@ -42,6 +42,10 @@ type JobConsumer interface {
 	// the next job from the job queue if there are workers available. It returns the last processed job index.
 	NotifyJobIsDone(JobID) uint64
 
+	// NotifyJobFailed let the consumer know a job has failed, so that the consumer retries it later, or
+	// sets it aside if it failed too many times. It returns the last processed job index.
+	NotifyJobFailed(JobID, error) uint64
+
 	// Size returns the number of processing jobs in consumer.
 	Size() uint
 }
@@ -77,3 +81,12 @@ type JobQueue interface {
 type ProcessingNotifier interface {
 	Notify(entityID flow.Identifier)
 }
+
+// ProcessingFailureNotifier is a ProcessingNotifier which the worker's underneath engine can also
+// report failures to, so that the consumer retries processing the entity later, or sets it aside
+// if it failed too many times.
+type ProcessingFailureNotifier interface {
+	ProcessingNotifier
+	// NotifyFailed reports that processing the entity failed with the given error.
+	NotifyFailed(entityID flow.Identifier, err error)
+}
@@ -16,7 +16,7 @@ type ComponentConsumer struct {
 	component.Component
 
 	cm           *component.ComponentManager
-	consumer     module.JobConsumer
+	consumer     *Consumer
 	jobs         module.Jobs
 	workSignal   <-chan struct{}
 	preNotifier  NotifyDone
@@ -34,6 +34,7 @@ func NewComponentConsumer(
 	processor JobProcessor, // method used to process jobs
 	maxProcessing uint64,
 	maxSearchAhead uint64,
+	opts ...ConsumerOption,
 ) *ComponentConsumer {
 
 	c := &ComponentConsumer{
@@ -48,7 +49,7 @@ func NewComponentConsumer(
 		func(id module.JobID) { c.NotifyJobIsDone(id) },
 		maxProcessing,
 	)
-	c.consumer = NewConsumer(c.log, c.jobs, progress, worker, maxProcessing, maxSearchAhead)
+	c.consumer = NewConsumer(c.log, c.jobs, progress, worker, maxProcessing, maxSearchAhead, opts...)
 
 	builder := component.NewComponentManagerBuilder().
 		AddWorker(func(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
@@ -124,6 +125,27 @@ func (c *ComponentConsumer) NotifyJobIsDone(jobID module.JobID) uint64 {
 	return processedIndex
 }
 
+// NotifyJobFailed is invoked by the worker to let the consumer know that it failed processing a job.
+// The job is retried, or dead-lettered if it failed too many times and dead letters are enabled.
+func (c *ComponentConsumer) NotifyJobFailed(jobID module.JobID, err error) uint64 {
+	return c.consumer.NotifyJobFailed(jobID, err)
+}
+
+// DeadLetters returns the dead-lettered jobs, ordered by job index.
+func (c *ComponentConsumer) DeadLetters() ([]*storage.DeadLetter, error) {
+	return c.consumer.DeadLetters()
+}
+
+// RetryDeadLetter processes the dead-lettered job at the given index again.
+func (c *ComponentConsumer) RetryDeadLetter(index uint64) error {
+	return c.consumer.RetryDeadLetter(index)
+}
+
+// DropDeadLetter removes the dead letter of the job at the given index, without processing the job.
+func (c *ComponentConsumer) DropDeadLetter(index uint64) error {
+	return c.consumer.DropDeadLetter(index)
+}
+
 // Size returns number of in-memory block jobs that block consumer is processing.
 func (c *ComponentConsumer) Size() uint {
 	return c.consumer.Size()
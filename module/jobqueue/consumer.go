@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 	"go.uber.org/atomic"
@@ -22,8 +23,9 @@ type Consumer struct {
 	log zerolog.Logger
 
 	// Storage
-	jobs     module.Jobs              // storage to read jobs from
-	progress storage.ConsumerProgress // to resume from first unprocessed job after restarting
+	jobs      module.Jobs              // storage to read jobs from
+	compactor Compactor                // to remove processed jobs, nil if the jobs are not compacted
+	progress  storage.ConsumerProgress // to resume from first unprocessed job after restarting
 
 	// dependency
 	worker Worker // to process job and notify consumer when finish processing a job
@@ -31,6 +33,9 @@ type Consumer struct {
 	// Config
 	maxProcessing  uint64 // max number of jobs to be processed concurrently
 	maxSearchAhead uint64 // max number of jobs beyond processedIndex to process. 0 means no limit
+	maxOutOfOrder  uint64 // max number of completed jobs beyond processedIndex to keep. 0 means no limit
+	retry          RetryConfig
+	deadLetters    storage.DeadLetters // nil if failed jobs are retried forever
 
 	// State Variables
 	running bool // a signal to control whether to start processing more jobs. Useful for waiting
@@ -43,6 +48,16 @@ type Consumer struct {
 	processings      map[uint64]*jobStatus   // keep track of the status of each on going job
 	processingsIndex map[module.JobID]uint64 // lookup the index of the job, useful when fast forwarding the
 	// `processed` variable
+	deadLetterRetries map[module.JobID]uint64 // index of dead-lettered jobs which are manually retried
+}
+
+// WithOutOfOrderWindow limits the number of completed jobs which are kept in memory while a job with a
+// lower index is still processing. Once the limit is reached, the consumer doesn't start new jobs until
+// the processed index advances. 0 means no limit.
+func WithOutOfOrderWindow(window uint64) ConsumerOption {
+	return func(c *Consumer) {
+		c.maxOutOfOrder = window
+	}
 }
 
 func NewConsumer(
@@ -52,8 +67,9 @@ func NewConsumer(
 	worker Worker,
 	maxProcessing uint64,
 	maxSearchAhead uint64,
+	opts ...ConsumerOption,
 ) *Consumer {
-	return &Consumer{
+	c := &Consumer{
 		log: log.With().Str("sub_module", "job_queue").Logger(),
 
 		// store dependency
@@ -64,14 +80,27 @@ func NewConsumer(
 		// update config
 		maxProcessing:  maxProcessing,
 		maxSearchAhead: maxSearchAhead,
+		retry:          DefaultRetryConfig,
 
 		// init state variables
-		running:          false,
-		isChecking:       atomic.NewBool(false),
-		processedIndex:   0,
-		processings:      make(map[uint64]*jobStatus),
-		processingsIndex: make(map[module.JobID]uint64),
+		running:           false,
+		isChecking:        atomic.NewBool(false),
+		processedIndex:    0,
+		processings:       make(map[uint64]*jobStatus),
+		processingsIndex:  make(map[module.JobID]uint64),
+		deadLetterRetries: make(map[module.JobID]uint64),
+	}
+
+	// the jobs of push-style job queues are removed once processed
+	if compactor, ok := jobs.(Compactor); ok {
+		c.compactor = compactor
+	}
+
+	for _, apply := range opts {
+		apply(c)
 	}
+
+	return c
 }
 
 // Start starts consuming the jobs from the job queue.
@@ -123,6 +152,13 @@ func (c *Consumer) Start(defaultIndex uint64) error {
 func (c *Consumer) Stop() {
 	c.mu.Lock()
 	c.running = false
+	// jobs waiting for a retry are not retried anymore, they are processed again after restarting
+	for _, status := range c.processings {
+		if status.retry != nil {
+			status.retry.Stop()
+			status.retry = nil
+		}
+	}
 	// not to use `defer`, otherwise runningJobs.Wait will hold the lock and cause deadlock
 	c.mu.Unlock()
 
@@ -157,10 +193,188 @@ func (c *Consumer) NotifyJobIsDone(jobID module.JobID) uint64 {
 	if c.doneJob(jobID) {
 		c.checkProcessable()
 	}
+	c.doneDeadLetter(jobID)
+
+	return c.processedIndex
+}
+
+// NotifyJobFailed let the consumer know a job has failed. The job is retried after a backoff. If dead
+// letters are enabled and the job failed for the configured number of attempts, it is dead-lettered
+// instead and considered done, so that the consumer can take the next job. It returns the last processed
+// job index.
+func (c *Consumer) NotifyJobFailed(jobID module.JobID, jobErr error) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index, ok := c.processingsIndex[jobID]
+	if !ok || c.processings[index].done {
+		// a job which was dead-lettered can only fail again when being retried manually
+		c.failedDeadLetter(jobID, jobErr)
+		return c.processedIndex
+	}
+
+	status := c.processings[index]
+	status.attempts++
+
+	log := c.log.With().
+		Str("job_id", string(jobID)).
+		Uint64("index", index).
+		Uint64("attempts", status.attempts).
+		Logger()
+
+	if c.deadLetters != nil && status.attempts >= c.retry.MaxAttempts {
+		err := c.deadLetters.Store(&storage.DeadLetter{
+			Index:    index,
+			JobID:    string(jobID),
+			Error:    jobErr.Error(),
+			Attempts: status.attempts,
+			FailedAt: time.Now(),
+		})
+		if err == nil {
+			log.Error().Err(jobErr).Msg("job failed too many times, dead-lettered")
+			status.done = true
+			c.checkProcessable()
+			return c.processedIndex
+		}
+		// the job must not be considered done without being dead-lettered, keep retrying
+		log.Error().Err(err).Msg("could not store dead letter, will retry job")
+	}
+
+	delay := c.retry.backoff(status.attempts)
+	log.Warn().Err(jobErr).Dur("backoff", delay).Msg("job failed, will retry")
+
+	status.retry = time.AfterFunc(delay, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+
+		// the job might have been completed in the meantime, or the consumer stopped
+		if !c.running || status.done || c.processings[index] != status {
+			return
+		}
+		status.retry = nil
+		c.launchJob(status.job)
+	})
 
 	return c.processedIndex
 }
 
+// DeadLetters returns the dead-lettered jobs, ordered by job index.
+// Returns ErrDeadLettersDisabled if the consumer was created without dead letter storage.
+func (c *Consumer) DeadLetters() ([]*storage.DeadLetter, error) {
+	if c.deadLetters == nil {
+		return nil, ErrDeadLettersDisabled
+	}
+	return c.deadLetters.All()
+}
+
+// RetryDeadLetter processes the dead-lettered job at the given index again. If it succeeds, the dead
+// letter is removed, otherwise the dead letter is updated with the new error.
+// Expected errors during normal operations:
+//   - ErrDeadLettersDisabled if the consumer was created without dead letter storage
+//   - storage.ErrNotFound if the job at the given index is not dead-lettered
+func (c *Consumer) RetryDeadLetter(index uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.deadLetters == nil {
+		return ErrDeadLettersDisabled
+	}
+	if !c.running {
+		return fmt.Errorf("consumer is not running")
+	}
+
+	_, err := c.deadLetters.ByIndex(index)
+	if err != nil {
+		return fmt.Errorf("could not read dead letter at index %v: %w", index, err)
+	}
+
+	job, err := c.jobs.AtIndex(index)
+	if err != nil {
+		return fmt.Errorf("could not read job at index %v: %w", index, err)
+	}
+
+	if _, retrying := c.deadLetterRetries[job.ID()]; retrying {
+		return nil
+	}
+	c.deadLetterRetries[job.ID()] = index
+	c.launchJob(job)
+
+	c.log.Info().Str("job_id", string(job.ID())).Uint64("index", index).Msg("retrying dead-lettered job")
+	return nil
+}
+
+// DropDeadLetter removes the dead letter of the job at the given index, without processing the job.
+// Expected errors during normal operations:
+//   - ErrDeadLettersDisabled if the consumer was created without dead letter storage
+//   - storage.ErrNotFound if the job at the given index is not dead-lettered
+func (c *Consumer) DropDeadLetter(index uint64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.deadLetters == nil {
+		return ErrDeadLettersDisabled
+	}
+
+	letter, err := c.deadLetters.ByIndex(index)
+	if err != nil {
+		return fmt.Errorf("could not read dead letter at index %v: %w", index, err)
+	}
+	err = c.deadLetters.Remove(index)
+	if err != nil {
+		return fmt.Errorf("could not remove dead letter at index %v: %w", index, err)
+	}
+	delete(c.deadLetterRetries, module.JobID(letter.JobID))
+	// the job was retained by previous compactions only because it was dead-lettered
+	c.compact()
+
+	c.log.Info().Str("job_id", letter.JobID).Uint64("index", index).Msg("dropped dead-lettered job")
+	return nil
+}
+
+// doneDeadLetter removes the dead letter of the given job if it was retried manually.
+func (c *Consumer) doneDeadLetter(jobID module.JobID) {
+	index, ok := c.deadLetterRetries[jobID]
+	if !ok {
+		return
+	}
+	delete(c.deadLetterRetries, jobID)
+
+	err := c.deadLetters.Remove(index)
+	if err != nil {
+		c.log.Error().Err(err).Uint64("index", index).Msg("could not remove dead letter of retried job")
+		return
+	}
+	// the job was retained by previous compactions only because it was dead-lettered
+	c.compact()
+	c.log.Info().Str("job_id", string(jobID)).Uint64("index", index).Msg("dead-lettered job succeeded")
+}
+
+// failedDeadLetter updates the dead letter of the given job if it was retried manually.
+func (c *Consumer) failedDeadLetter(jobID module.JobID, jobErr error) {
+	index, ok := c.deadLetterRetries[jobID]
+	if !ok {
+		return
+	}
+	delete(c.deadLetterRetries, jobID)
+
+	letter, err := c.deadLetters.ByIndex(index)
+	if err != nil {
+		// the dead letter might have been dropped in the meantime
+		c.log.Warn().Err(err).Uint64("index", index).Msg("could not read dead letter of retried job")
+		return
+	}
+	letter.Error = jobErr.Error()
+	letter.Attempts++
+	letter.FailedAt = time.Now()
+
+	err = c.deadLetters.Store(letter)
+	if err != nil {
+		c.log.Error().Err(err).Uint64("index", index).Msg("could not update dead letter of retried job")
+		return
+	}
+	c.log.Warn().Err(jobErr).Str("job_id", string(jobID)).Uint64("index", index).Msg("dead-lettered job failed again")
+}
+
 // Check allows the job publisher to notify the consumer that a new job has been added, so that
 // the consumer can check if the job is processable
 // since multiple checks at the same time are unnecessary, we could only keep one check by checking.
@@ -223,17 +437,11 @@ func (c *Consumer) run() (int64, error) {
 		c.processingsIndex[jobID] = indexedJob.index
 		c.processings[indexedJob.index] = &jobStatus{
 			jobID: jobID,
+			job:   indexedJob.job,
 			done:  false,
 		}
 
-		c.runningJobs.Add(1)
-		go func(j *jobAtIndex) {
-			err := c.worker.Run(j.job)
-			if err != nil {
-				c.log.Fatal().Err(err).Msg("could not run the job")
-			}
-			c.runningJobs.Done()
-		}(indexedJob)
+		c.launchJob(indexedJob.job)
 	}
 
 	err = c.progress.SetProcessedIndex(processedTo)
@@ -253,15 +461,44 @@ func (c *Consumer) run() (int64, error) {
 
 	c.processedIndex = processedTo
 
+	if processedTo > processedFrom {
+		c.compact()
+	}
+
 	return int64(len(processables)), nil
 }
 
+// compact removes the processed jobs from the job queue, if the jobs are compacted.
+func (c *Consumer) compact() {
+	if c.compactor == nil {
+		return
+	}
+	err := c.compactor.Compact(c.processedIndex)
+	if err != nil {
+		// the jobs which were not removed are removed by the next compaction
+		c.log.Warn().Err(err).Uint64("processed_index", c.processedIndex).Msg("could not compact processed jobs")
+	}
+}
+
+// launchJob gives the job to the worker in a separate goroutine.
+func (c *Consumer) launchJob(job module.Job) {
+	c.runningJobs.Add(1)
+	go func() {
+		err := c.worker.Run(job)
+		if err != nil {
+			c.log.Fatal().Err(err).Msg("could not run the job")
+		}
+		c.runningJobs.Done()
+	}()
+}
+
 func (c *Consumer) processableJobs() ([]*jobAtIndex, uint64, error) {
 	processables, processedTo, err := processableJobs(
 		c.jobs,
 		c.processings,
 		c.maxProcessing,
 		c.maxSearchAhead,
+		c.maxOutOfOrder,
 		c.processedIndex,
 	)
 
@@ -281,7 +518,7 @@ func (c *Consumer) processableJobs() ([]*jobAtIndex, uint64, error) {
 // processableJobs check the worker's capacity and if sufficient, read
 // jobs from the storage, return the processable jobs, and the processed
 // index
-func processableJobs(jobs module.Jobs, processings map[uint64]*jobStatus, maxProcessing uint64, maxSearchAhead uint64, processedIndex uint64) ([]*jobAtIndex, uint64,
+func processableJobs(jobs module.Jobs, processings map[uint64]*jobStatus, maxProcessing uint64, maxSearchAhead uint64, maxOutOfOrder uint64, processedIndex uint64) ([]*jobAtIndex, uint64,
 	error) {
 	processables := make([]*jobAtIndex, 0)
 
@@ -299,8 +536,19 @@ func processableJobs(jobs module.Jobs, processings map[uint64]*jobStatus, maxPro
 		return index-processedIndex > maxSearchAhead
 	}
 
+	// count how many jobs are completed while a lower job is still processing, in order to apply
+	// backpressure rather than caching an unlimited number of completed jobs
+	outOfOrder := uint64(0)
+	tooManyCompleted := func() bool {
+		if maxOutOfOrder == 0 {
+			return false
+		}
+
+		return outOfOrder >= maxOutOfOrder
+	}
+
 	// if still have processing capacity, find the next processable job
-	for i := processedIndex + 1; processing < maxProcessing && !shouldPause(i); i++ {
+	for i := processedIndex + 1; processing < maxProcessing && !shouldPause(i) && !tooManyCompleted(); i++ {
 		status, ok := processings[i]
 
 		// if no worker is processing the next job, try to read it and process
@@ -336,6 +584,8 @@ func processableJobs(jobs module.Jobs, processings map[uint64]*jobStatus, maxPro
 
 		if i == processedIndex+1 {
 			processedIndex++
+		} else {
+			outOfOrder++
 		}
 	}
 
@@ -366,6 +616,10 @@ func (c *Consumer) doneJob(jobID module.JobID) bool {
 	}
 
 	status.done = true
+	if status.retry != nil {
+		status.retry.Stop()
+		status.retry = nil
+	}
 	return true
 }
 
@@ -375,6 +629,9 @@ type jobAtIndex struct {
 }
 
 type jobStatus struct {
-	jobID module.JobID
-	done  bool
+	jobID    module.JobID
+	job      module.Job
+	done     bool
+	attempts uint64      // number of failed attempts
+	retry    *time.Timer // non-nil while the job is waiting to be retried
 }
@@ -41,7 +41,7 @@ func TestProcessableJobs(t *testing.T) {
 		processings := map[uint64]*jobStatus{}
 		processedIndex := uint64(0)
 
-		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, processedIndex)
+		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, 0, processedIndex)
 
 		require.NoError(t, err)
 		require.Equal(t, uint64(0), processedTo)
@@ -56,7 +56,7 @@ func TestProcessableJobs(t *testing.T) {
 		// 4, 6, 7, 8, 9, 10, 11 are finished, 7 finished in total
 		processings := populate(3, 11, []uint64{3, 5})
 
-		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, processedIndex)
+		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, 0, processedIndex)
 
 		require.NoError(t, err)
 		require.Equal(t, uint64(2), processedTo)
@@ -74,7 +74,7 @@ func TestProcessableJobs(t *testing.T) {
 		// 4, 7, 8, 9, 10, 11, 12 are finished, 7 finished in total
 		processings := populate(3, 12, []uint64{3, 5, 6})
 
-		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, processedIndex)
+		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, 0, processedIndex)
 
 		require.NoError(t, err)
 		require.Equal(t, uint64(2), processedTo)
@@ -93,7 +93,7 @@ func TestProcessableJobs(t *testing.T) {
 		processings := populate(3, processedIndex+maxSearchAhead, []uint64{3, 5})
 
 		// it will not process any job, because the consumer is paused
-		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, maxSearchAhead, processedIndex)
+		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, maxSearchAhead, 0, processedIndex)
 
 		require.NoError(t, err)
 		require.Equal(t, processedIndex, processedTo)
@@ -103,7 +103,7 @@ func TestProcessableJobs(t *testing.T) {
 		processings[uint64(3)].done = true
 
 		// Job 3 is done, so it should return 2 more jobs 8-9 and pause again with one available worker
-		jobsToRun, processedTo, err = processableJobs(jobs, processings, maxProcessing, maxSearchAhead, processedIndex)
+		jobsToRun, processedTo, err = processableJobs(jobs, processings, maxProcessing, maxSearchAhead, 0, processedIndex)
 
 		require.NoError(t, err)
 		require.Equal(t, uint64(4), processedTo)
@@ -113,7 +113,7 @@ func TestProcessableJobs(t *testing.T) {
 		processings[uint64(5)].done = true
 
 		// job 5 is processed, it should return jobs 8-11 (one job for each worker)
-		jobsToRun, processedTo, err = processableJobs(jobs, processings, maxProcessing, maxSearchAhead, processedIndex)
+		jobsToRun, processedTo, err = processableJobs(jobs, processings, maxProcessing, maxSearchAhead, 0, processedIndex)
 
 		require.NoError(t, err)
 		require.Equal(t, uint64(7), processedTo)
@@ -128,13 +128,35 @@ func TestProcessableJobs(t *testing.T) {
 		// 4, 5, 6, 7, 8, 9, 10 are finished, 7 finished in total
 		processings := populate(3, 11, []uint64{3, 11})
 
-		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, processedIndex)
+		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, 0, processedIndex)
 
 		require.NoError(t, err)
 		require.Equal(t, uint64(2), processedTo)
 		assertJobs(t, []uint64{}, jobsToRun)
 	})
 
+	t.Run("out of order window reached", func(t *testing.T) {
+		jobs := NewMockJobs()
+		require.NoError(t, jobs.PushN(20)) // enough jobs in the queue
+
+		// job 3 is not done, 4, 5, 6, 7 are done out of order
+		processings := populate(3, 7, []uint64{3})
+
+		// it will not process any job, because too many jobs are completed out of order
+		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, 3, processedIndex)
+
+		require.NoError(t, err)
+		require.Equal(t, processedIndex, processedTo)
+		assertJobs(t, []uint64{}, jobsToRun)
+
+		// with a larger window, it will process more jobs until reaching max processing
+		jobsToRun, processedTo, err = processableJobs(jobs, processings, maxProcessing, 0, 10, processedIndex)
+
+		require.NoError(t, err)
+		require.Equal(t, processedIndex, processedTo)
+		assertJobs(t, []uint64{8, 9}, jobsToRun)
+	})
+
 	t.Run("next jobs were done", func(t *testing.T) {
 		jobs := NewMockJobs()
 		require.NoError(t, jobs.PushN(20)) // enough jobs in the queue
@@ -143,7 +165,7 @@ func TestProcessableJobs(t *testing.T) {
 		// job 4, 6 are not done, which have not reached max processing
 		processings := populate(3, 6, []uint64{4, 6})
 
-		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, processedIndex)
+		jobsToRun, processedTo, err := processableJobs(jobs, processings, maxProcessing, 0, 0, processedIndex)
 
 		require.NoError(t, err)
 		require.Equal(t, uint64(3), processedTo)
@@ -188,6 +210,98 @@ func TestProcessedIndexDeletion(t *testing.T) {
 	})
 }
 
+func TestRetryBackoff(t *testing.T) {
+	config := RetryConfig{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	require.Equal(t, time.Second, config.backoff(1))
+	require.Equal(t, 2*time.Second, config.backoff(2))
+	require.Equal(t, 4*time.Second, config.backoff(3))
+	require.Equal(t, 5*time.Second, config.backoff(4))
+	require.Equal(t, 5*time.Second, config.backoff(100))
+}
+
+// Test failed jobs are retried until they succeed, and block the processed index meanwhile
+func TestJobRetries(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badgerdb.DB) {
+		jobs := NewMockJobs()
+		progress := badger.NewConsumerProgress(db, "consumer")
+		worker := newFailingWorker(3, 3)
+		c := NewConsumer(unittest.Logger(), jobs, progress, worker, 3, 0,
+			WithRetries(RetryConfig{InitialBackoff: time.Millisecond, MaxBackoff: 10 * time.Millisecond}))
+		worker.WithConsumer(c)
+
+		require.NoError(t, jobs.PushN(10))
+		require.NoError(t, c.Start(0))
+		defer c.Stop()
+
+		require.Eventually(t, func() bool {
+			return c.LastProcessedIndex() == uint64(10)
+		}, 2*time.Second, 10*time.Millisecond)
+		require.Equal(t, 4, worker.Runs(3))
+
+		_, err := c.DeadLetters()
+		require.ErrorIs(t, err, ErrDeadLettersDisabled)
+	})
+}
+
+// Test jobs which failed too many times are dead-lettered, and can be retried or dropped manually
+func TestDeadLetters(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badgerdb.DB) {
+		jobs := NewMockJobs()
+		progress := badger.NewConsumerProgress(db, "consumer")
+		deadLetters := badger.NewDeadLetters(db, "consumer")
+		worker := newFailingWorker(3, 2)
+		c := NewConsumer(unittest.Logger(), jobs, progress, worker, 3, 0,
+			WithRetries(RetryConfig{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+			WithDeadLetters(deadLetters))
+		worker.WithConsumer(c)
+
+		require.NoError(t, jobs.PushN(10))
+		require.NoError(t, c.Start(0))
+		defer c.Stop()
+
+		// the dead-lettered job does not block the processed index
+		require.Eventually(t, func() bool {
+			return c.LastProcessedIndex() == uint64(10)
+		}, 2*time.Second, 10*time.Millisecond)
+		require.Equal(t, 2, worker.Runs(3))
+
+		letters, err := c.DeadLetters()
+		require.NoError(t, err)
+		require.Len(t, letters, 1)
+		require.Equal(t, uint64(3), letters[0].Index)
+		require.Equal(t, string(JobIDAtIndex(3)), letters[0].JobID)
+		require.Equal(t, uint64(2), letters[0].Attempts)
+		require.Contains(t, letters[0].Error, "failure 2")
+
+		// the job is failing once more when retried manually
+		worker.SetFailures(3)
+		require.NoError(t, c.RetryDeadLetter(3))
+		require.Eventually(t, func() bool {
+			letter, err := deadLetters.ByIndex(3)
+			require.NoError(t, err)
+			return letter.Attempts == 3
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// the dead letter is removed once the job succeeds
+		require.NoError(t, c.RetryDeadLetter(3))
+		require.Eventually(t, func() bool {
+			letters, err := c.DeadLetters()
+			require.NoError(t, err)
+			return len(letters) == 0
+		}, 2*time.Second, 10*time.Millisecond)
+
+		require.ErrorIs(t, c.RetryDeadLetter(3), storage.ErrNotFound)
+		require.ErrorIs(t, c.DropDeadLetter(3), storage.ErrNotFound)
+
+		require.NoError(t, deadLetters.Store(&storage.DeadLetter{Index: 5, JobID: string(JobIDAtIndex(5))}))
+		require.NoError(t, c.DropDeadLetter(5))
+		letters, err = c.DeadLetters()
+		require.NoError(t, err)
+		require.Empty(t, letters)
+	})
+}
+
 func assertJobs(t *testing.T, expectedIndex []uint64, jobsToRun []*jobAtIndex) {
 	actualIndex := make([]uint64, 0, len(jobsToRun))
 	for _, jobAtIndex := range jobsToRun {
@@ -335,3 +449,57 @@ func (w *mockWorker) Run(job module.Job) error {
 	w.consumer.NotifyJobIsDone(job.ID())
 	return nil
 }
+
+// failingWorker fails the job at the given index for the given number of runs, and succeeds otherwise.
+type failingWorker struct {
+	mu       sync.Mutex
+	consumer *Consumer
+	index    uint64
+	failures int
+	runs     map[uint64]int
+}
+
+func newFailingWorker(index uint64, failures int) *failingWorker {
+	return &failingWorker{
+		index:    index,
+		failures: failures,
+		runs:     make(map[uint64]int),
+	}
+}
+
+func (w *failingWorker) WithConsumer(c *Consumer) {
+	w.consumer = c
+}
+
+// SetFailures sets the total number of runs of the job at the configured index that fail.
+func (w *failingWorker) SetFailures(failures int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.failures = failures
+}
+
+func (w *failingWorker) Runs(index uint64) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.runs[index]
+}
+
+func (w *failingWorker) Run(job module.Job) error {
+	index, err := JobIDToIndex(job.ID())
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.runs[index]++
+	runs := w.runs[index]
+	fail := index == w.index && runs <= w.failures
+	w.mu.Unlock()
+
+	if fail {
+		w.consumer.NotifyJobFailed(job.ID(), fmt.Errorf("failure %d", runs))
+		return nil
+	}
+	w.consumer.NotifyJobIsDone(job.ID())
+	return nil
+}
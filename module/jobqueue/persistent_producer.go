@@ -0,0 +1,152 @@
+package jobqueue
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-go/engine"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+)
+
+// JobEncoder encodes a job to be persisted by the PersistentJobProducer.
+type JobEncoder func(job module.Job) ([]byte, error)
+
+// JobDecoder decodes a job persisted by the PersistentJobProducer.
+type JobDecoder func(id module.JobID, payload []byte) (module.Job, error)
+
+// Compactor is implemented by the jobs of push-style job queues, whose jobs are not needed anymore
+// once processed. The consumer calls Compact whenever its processed index advances.
+type Compactor interface {
+	// Compact removes the jobs up to and including the given processed index.
+	// No errors are expected during normal operation.
+	Compact(processedIndex uint64) error
+}
+
+// PersistentJobProducer is a producer for push-style job queues. Pushed jobs are persisted and
+// assigned sequential indices, so that a consumer can read them via the module.Jobs interface and
+// resume after a restart. Job IDs are unique, a job can only be pushed once.
+//
+// Jobs which have been processed by the consumer are not needed anymore, and are removed by the
+// consumer reading from the producer, see Compactor.
+type PersistentJobProducer struct {
+	log         zerolog.Logger
+	store       storage.PersistentJobQueue
+	deadLetters storage.DeadLetters // nil if the consumer doesn't dead-letter jobs
+	encode      JobEncoder
+	decode      JobDecoder
+	notifier    engine.Notifier
+}
+
+var _ module.Jobs = (*PersistentJobProducer)(nil)
+var _ module.JobQueue = (*PersistentJobProducer)(nil)
+var _ Compactor = (*PersistentJobProducer)(nil)
+
+// NewPersistentJobProducer creates a new producer storing the jobs in the given storage.
+// If the consumer of the queue dead-letters jobs, its dead letters must be given, so that
+// dead-lettered jobs are not compacted and can still be retried. Otherwise, deadLetters is nil.
+func NewPersistentJobProducer(
+	log zerolog.Logger,
+	store storage.PersistentJobQueue,
+	deadLetters storage.DeadLetters,
+	encode JobEncoder,
+	decode JobDecoder,
+) *PersistentJobProducer {
+	return &PersistentJobProducer{
+		log:         log.With().Str("sub_module", "job_producer").Logger(),
+		store:       store,
+		deadLetters: deadLetters,
+		encode:      encode,
+		decode:      decode,
+		notifier:    engine.NewNotifier(),
+	}
+}
+
+// Push persists the given job, notifies the consumer, and returns the index of the job.
+// Expected errors during normal operation:
+//   - storage.ErrAlreadyExists if a job with the same ID was pushed before
+func (p *PersistentJobProducer) Push(job module.Job) (uint64, error) {
+	payload, err := p.encode(job)
+	if err != nil {
+		return 0, fmt.Errorf("could not encode job %v: %w", job.ID(), err)
+	}
+
+	index, err := p.store.Append(&storage.StoredJob{
+		ID:      string(job.ID()),
+		Payload: payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not store job %v: %w", job.ID(), err)
+	}
+
+	p.notifier.Notify()
+	return index, nil
+}
+
+// Add persists the given job and notifies the consumer.
+// Expected errors during normal operation:
+//   - storage.ErrAlreadyExists if a job with the same ID was pushed before
+func (p *PersistentJobProducer) Add(job module.Job) error {
+	_, err := p.Push(job)
+	return err
+}
+
+// AtIndex returns the job at the given index.
+// Error returns:
+//   - storage.ErrNotFound if no job was pushed at the given index, or if it was compacted
+func (p *PersistentJobProducer) AtIndex(index uint64) (module.Job, error) {
+	stored, err := p.store.AtIndex(index)
+	if err != nil {
+		return nil, fmt.Errorf("could not read job at index %v: %w", index, err)
+	}
+
+	job, err := p.decode(module.JobID(stored.ID), stored.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode job %v at index %v: %w", stored.ID, index, err)
+	}
+	return job, nil
+}
+
+// Head returns the index of the last pushed job, or 0 if no job was pushed yet.
+func (p *PersistentJobProducer) Head() (uint64, error) {
+	return p.store.Head()
+}
+
+// WorkSignal returns a channel which receives a signal whenever new jobs were pushed. It is meant
+// to be the work signal of a ComponentConsumer. Alternatively, use the Check method of the consumer
+// after pushing.
+func (p *PersistentJobProducer) WorkSignal() <-chan struct{} {
+	return p.notifier.Channel()
+}
+
+// Compact removes the jobs up to and including the given processed index, except dead-lettered jobs.
+// No errors are expected during normal operation.
+func (p *PersistentJobProducer) Compact(processedIndex uint64) error {
+	retained := make(map[uint64]struct{})
+	if p.deadLetters != nil {
+		letters, err := p.deadLetters.All()
+		if err != nil {
+			return fmt.Errorf("could not read dead letters: %w", err)
+		}
+		for _, letter := range letters {
+			retained[letter.Index] = struct{}{}
+		}
+	}
+
+	removed, err := p.store.Compact(processedIndex, func(index uint64) bool {
+		_, ok := retained[index]
+		return ok
+	})
+	if err != nil {
+		return fmt.Errorf("could not compact jobs up to index %v: %w", processedIndex, err)
+	}
+
+	if removed > 0 {
+		p.log.Debug().
+			Uint64("processed_index", processedIndex).
+			Int("removed", removed).
+			Msg("compacted processed jobs")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package jobqueue
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	badgerdb "github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func encodeTestJob(job module.Job) ([]byte, error) {
+	return []byte(job.ID()), nil
+}
+
+func decodeTestJob(id module.JobID, payload []byte) (module.Job, error) {
+	index, err := JobIDToIndex(module.JobID(payload))
+	if err != nil {
+		return nil, err
+	}
+	return &TestJob{index: index}, nil
+}
+
+// Test pushed jobs are consumed, and compacted by the consumer once processed, except dead-lettered jobs
+func TestPersistentJobProducer(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badgerdb.DB) {
+		deadLetters := badger.NewDeadLetters(db, "consumer")
+		producer := NewPersistentJobProducer(unittest.Logger(), badger.NewPersistentJobQueue(db, "queue"), deadLetters,
+			encodeTestJob, decodeTestJob)
+
+		head, err := producer.Head()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), head)
+
+		// the job at index 3 fails and is dead-lettered
+		worker := newFailingWorker(3, 1)
+		c := NewConsumer(unittest.Logger(), producer, badger.NewConsumerProgress(db, "consumer"), worker, 3, 0,
+			WithRetries(RetryConfig{MaxAttempts: 1, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+			WithDeadLetters(deadLetters))
+		worker.WithConsumer(c)
+		require.NoError(t, c.Start(0))
+		defer c.Stop()
+
+		maker := NewJobMaker()
+		for i := uint64(1); i <= 10; i++ {
+			index, err := producer.Push(maker.Next())
+			require.NoError(t, err)
+			require.Equal(t, i, index)
+			c.Check()
+		}
+
+		// the producer signals new jobs
+		select {
+		case <-producer.WorkSignal():
+		default:
+			t.Fatal("expected work signal")
+		}
+
+		require.Eventually(t, func() bool {
+			return c.LastProcessedIndex() == uint64(10)
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// processed jobs are compacted, except the dead-lettered one
+		for i := uint64(1); i <= 10; i++ {
+			_, err := producer.AtIndex(i)
+			if i == 3 {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, storage.ErrNotFound)
+			}
+		}
+
+		// the dead-lettered job can still be retried
+		worker.SetFailures(0)
+		require.NoError(t, c.RetryDeadLetter(3))
+		require.Eventually(t, func() bool {
+			letters, err := deadLetters.All()
+			require.NoError(t, err)
+			return len(letters) == 0
+		}, 2*time.Second, 10*time.Millisecond)
+
+		// the retried job is compacted once it succeeded
+		_, err = producer.AtIndex(3)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		// jobs can't be pushed twice, also after they were compacted
+		_, err = producer.Push(&TestJob{index: 1})
+		require.ErrorIs(t, err, storage.ErrAlreadyExists)
+
+		head, err = producer.Head()
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), head)
+
+		// new jobs are processed and compacted as usual
+		_, err = producer.Push(maker.Next())
+		require.NoError(t, err)
+		c.Check()
+		require.Eventually(t, func() bool {
+			_, err := producer.AtIndex(11)
+			return errors.Is(err, storage.ErrNotFound)
+		}, 2*time.Second, 10*time.Millisecond)
+	})
+}
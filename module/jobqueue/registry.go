@@ -0,0 +1,99 @@
+package jobqueue
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// ErrAlreadyRegistered is returned when a job queue is registered with a name
+// conflicting with an already registered job queue.
+var ErrAlreadyRegistered = fmt.Errorf("job queue name already registered")
+
+// DeadLetterQueue provides access to the dead-lettered jobs of a job queue consumer.
+// It is implemented by Consumer and ComponentConsumer.
+type DeadLetterQueue interface {
+	// DeadLetters returns the dead-lettered jobs, ordered by job index.
+	DeadLetters() ([]*storage.DeadLetter, error)
+
+	// RetryDeadLetter processes the dead-lettered job at the given index again.
+	// Expected errors during normal operations:
+	//   - ErrDeadLettersDisabled if the consumer was created without dead letter storage
+	//   - storage.ErrNotFound if the job at the given index is not dead-lettered
+	RetryDeadLetter(index uint64) error
+
+	// DropDeadLetter removes the dead letter of the job at the given index, without processing the job.
+	// Expected errors during normal operations:
+	//   - ErrDeadLettersDisabled if the consumer was created without dead letter storage
+	//   - storage.ErrNotFound if the job at the given index is not dead-lettered
+	DropDeadLetter(index uint64) error
+}
+
+var _ DeadLetterQueue = (*Consumer)(nil)
+var _ DeadLetterQueue = (*ComponentConsumer)(nil)
+
+// RegisteredQueue is a job queue registered with the Registry.
+type RegisteredQueue struct {
+	// Name is the name of the job queue, it must be unique for the node.
+	Name string
+	// DeadLetters provides access to the dead-lettered jobs of the queue.
+	DeadLetters DeadLetterQueue
+}
+
+// Registry keeps track of the job queues of a node with dead letters enabled, so that
+// their dead-lettered jobs can be listed, retried and dropped via admin commands.
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu     sync.RWMutex
+	queues map[string]RegisteredQueue
+}
+
+// NewRegistry returns a new empty job queue registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		queues: make(map[string]RegisteredQueue),
+	}
+}
+
+// Register registers a job queue with the given name.
+// Returns ErrAlreadyRegistered if a job queue with the same name is already registered.
+func (r *Registry) Register(name string, deadLetters DeadLetterQueue) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.queues[name]; exists {
+		return fmt.Errorf("can't register job queue %s: %w", name, ErrAlreadyRegistered)
+	}
+	r.queues[name] = RegisteredQueue{
+		Name:        name,
+		DeadLetters: deadLetters,
+	}
+	return nil
+}
+
+// ByName returns the job queue registered with the given name.
+// The boolean return value is false if no job queue is registered with the given name.
+func (r *Registry) ByName(name string) (RegisteredQueue, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	queue, exists := r.queues[name]
+	return queue, exists
+}
+
+// All returns all registered job queues, sorted by name.
+func (r *Registry) All() []RegisteredQueue {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]RegisteredQueue, 0, len(r.queues))
+	for _, queue := range r.queues {
+		all = append(all, queue)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Name < all[j].Name
+	})
+	return all
+}
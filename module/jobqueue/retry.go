@@ -0,0 +1,61 @@
+package jobqueue
+
+import (
+	"errors"
+	"time"
+
+	"github.com/onflow/flow-go/storage"
+)
+
+// ErrDeadLettersDisabled is returned when managing the dead letters of a consumer which was
+// created without dead letter storage.
+var ErrDeadLettersDisabled = errors.New("dead letters are not enabled for this consumer")
+
+// RetryConfig configures how the consumer retries failed jobs.
+type RetryConfig struct {
+	// MaxAttempts is the number of failed attempts after which a job is dead-lettered. It only
+	// applies if dead letters are enabled, otherwise failed jobs are retried forever.
+	MaxAttempts uint64
+	// InitialBackoff is the delay before the first retry, it is doubled for every following retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryConfig is the retry config of consumers created without the WithRetries option.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts:    5,
+	InitialBackoff: time.Second,
+	MaxBackoff:     time.Minute,
+}
+
+// backoff returns the delay before retrying a job which failed the given number of times.
+func (c RetryConfig) backoff(attempts uint64) time.Duration {
+	delay := c.InitialBackoff
+	for i := uint64(1); i < attempts && delay > 0 && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxBackoff {
+		return c.MaxBackoff
+	}
+	return delay
+}
+
+// ConsumerOption configures optional behaviour of the consumer.
+type ConsumerOption func(*Consumer)
+
+// WithRetries sets how the consumer retries failed jobs.
+func WithRetries(config RetryConfig) ConsumerOption {
+	return func(c *Consumer) {
+		c.retry = config
+	}
+}
+
+// WithDeadLetters enables dead letters: jobs which failed RetryConfig.MaxAttempts times are stored
+// in the given storage and considered done, so that they no longer block the processed index.
+// Dead-lettered jobs can be retried or dropped manually.
+func WithDeadLetters(deadLetters storage.DeadLetters) ConsumerOption {
+	return func(c *Consumer) {
+		c.deadLetters = deadLetters
+	}
+}
@@ -32,3 +32,18 @@ type Local interface {
 	SignFunc([]byte, hash.Hasher, func(crypto.PrivateKey, []byte, hash.Hasher) (crypto.Signature,
 		error)) (crypto.Signature, error)
 }
+
+// VoteSigner is implemented by Local implementations which protect the node from signing
+// conflicting HotStuff votes, and therefore need to know the view and block of the votes they sign.
+// Vote signers use it instead of Sign when it is implemented.
+type VoteSigner interface {
+
+	// SignVote signs the vote message for the given view and block, see verification.MakeVoteMessage,
+	// using the node's private key and the input hasher.
+	SignVote(view uint64, blockID flow.Identifier, hasher hash.Hasher) (crypto.Signature, error)
+
+	// ProtectVote records the consensus vote for the given view and block, which the node signs with a
+	// key the vote signer doesn't hold, i.e. its random beacon key. It must be called before the vote is
+	// signed, and returns an error if the vote conflicts with a previously signed or recorded vote.
+	ProtectVote(view uint64, blockID flow.Identifier) error
+}
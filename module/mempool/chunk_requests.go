@@ -90,6 +90,10 @@ type ChunkRequests interface {
 	// The updates under this method are atomic, thread-safe, and done in isolation.
 	UpdateRequestHistory(chunkID flow.Identifier, updater ChunkRequestHistoryUpdaterFunc) (uint64, time.Time, time.Duration, bool)
 
+	// ByID returns the chunk request for the specified chunk ID.
+	// The boolean return value indicates whether a chunk request for this chunk ID exists in the memory pool.
+	ByID(chunkID flow.Identifier) (*verification.ChunkDataPackRequestInfo, bool)
+
 	// All returns all chunk requests stored in this memory pool.
 	All() verification.ChunkDataPackRequestInfoList
 
@@ -106,4 +110,6 @@ type ChunkDataPackRequest struct {
 	ChunkId flow.Identifier
 	// Identifier of the requester node.
 	RequesterId flow.Identifier
+	// BlobsAccepted is true if the requester is able to retrieve the chunk data pack as content-addressed blobs.
+	BlobsAccepted bool
 }
@@ -199,6 +199,26 @@ func (et *ExecutionTree) reachableReceipts(vertex forest.Vertex, blockFilter mem
 	return receipts
 }
 
+// ReceiptsForBlock returns all receipts stored in the mempool for results of the given block.
+func (et *ExecutionTree) ReceiptsForBlock(block *flow.Header) []*flow.ExecutionReceipt {
+	et.RLock()
+	defer et.RUnlock()
+
+	blockID := block.ID()
+	var receipts []*flow.ExecutionReceipt
+	iter := et.forest.GetVerticesAtLevel(block.Height)
+	for iter.HasNext() {
+		receiptsForResult := iter.NextVertex().(*ReceiptsOfSameResult)
+		if receiptsForResult.result.BlockID != blockID {
+			continue
+		}
+		for _, recMeta := range receiptsForResult.receipts {
+			receipts = append(receipts, flow.ExecutionReceiptFromMeta(*recMeta, *receiptsForResult.result))
+		}
+	}
+	return receipts
+}
+
 // PruneUpToHeight prunes all results for all blocks with height up to but
 // NOT INCLUDING `newLowestHeight`. Errors if newLowestHeight is lower than
 // the previous value (as we cannot recover previously pruned results).
@@ -418,3 +418,18 @@ func (et *ExecutionTreeTestSuite) toSet(receiptNames ...string) map[string]struc
 	}
 	return set
 }
+
+// Test_ReceiptsForBlock verifies that ReceiptsForBlock returns the receipts for all results
+// of the given block, and no receipts of other blocks at the same height.
+func (et *ExecutionTreeTestSuite) Test_ReceiptsForBlock() {
+	blocks, _, receipts := et.createExecutionTree()
+	et.addReceipts2ReceiptsForest(receipts, blocks)
+
+	expected := []*flow.ExecutionReceipt{receipts["ER[r[B11]_1]_1"], receipts["ER[r[B11]_1]_2"], receipts["ER[r[B11]_2]"]}
+	et.Assert().ElementsMatch(expected, et.Forest.ReceiptsForBlock(blocks["B11"].Header))
+
+	expected = []*flow.ExecutionReceipt{receipts["ER[r[C11]]_1"], receipts["ER[r[C11]]_2"]}
+	et.Assert().ElementsMatch(expected, et.Forest.ReceiptsForBlock(blocks["C11"].Header))
+
+	et.Assert().Empty(et.Forest.ReceiptsForBlock(blocks["C12"].Header))
+}
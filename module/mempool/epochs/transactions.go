@@ -14,18 +14,34 @@ import (
 // pools across epochs, while maintaining the property that one transaction
 // pool is only valid for a single epoch.
 type TransactionPools struct {
-	mu     sync.RWMutex
-	pools  map[uint64]mempool.Transactions
-	create func(uint64) mempool.Transactions
+	mu      sync.RWMutex
+	pools   map[uint64]mempool.Transactions
+	create  func(uint64) mempool.Transactions
+	release func(uint64)
+}
+
+// TransactionPoolsOption configures the epoch-scoped transaction pools.
+type TransactionPoolsOption func(*TransactionPools)
+
+// WithRelease sets a function which releases any resources allocated by the create function
+// for an epoch, e.g. metrics registered for the pool. It is invoked when the pool of an epoch
+// is removed.
+func WithRelease(release func(uint64)) TransactionPoolsOption {
+	return func(t *TransactionPools) {
+		t.release = release
+	}
 }
 
 // NewTransactionPools returns a new set of epoch-scoped transaction pools.
-func NewTransactionPools(create func(uint64) mempool.Transactions) *TransactionPools {
+func NewTransactionPools(create func(uint64) mempool.Transactions, opts ...TransactionPoolsOption) *TransactionPools {
 
 	pools := &TransactionPools{
 		pools:  make(map[uint64]mempool.Transactions),
 		create: create,
 	}
+	for _, apply := range opts {
+		apply(pools)
+	}
 	return pools
 }
 
@@ -43,11 +59,36 @@ func (t *TransactionPools) ForEpoch(epoch uint64) mempool.Transactions {
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	// the pool may have been created while waiting for the lock
+	pool, exists = t.pools[epoch]
+	if exists {
+		return pool
+	}
+
 	pool = t.create(epoch)
 	t.pools[epoch] = pool
 	return pool
 }
 
+// RemoveForEpoch clears and removes the transaction pool for the given epoch, once the
+// epoch has ended and its pool is no longer used. It is a no-op if there is no pool for
+// the given epoch.
+func (t *TransactionPools) RemoveForEpoch(epoch uint64) {
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	pool, exists := t.pools[epoch]
+	if !exists {
+		return
+	}
+	pool.Clear()
+	delete(t.pools, epoch)
+	if t.release != nil {
+		t.release(epoch)
+	}
+}
+
 // CombinedSize returns the sum of the sizes of all transaction pools.
 func (t *TransactionPools) CombinedSize() uint {
 
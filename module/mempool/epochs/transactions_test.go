@@ -82,3 +82,37 @@ func TestCombinedSize(t *testing.T) {
 
 	assert.Equal(t, expected, pools.CombinedSize())
 }
+
+// test that removing the pool of an epoch clears it and releases its resources
+func TestRemoveForEpoch(t *testing.T) {
+
+	created := make(map[uint64]int)
+	released := make(map[uint64]int)
+	create := func(epoch uint64) mempool.Transactions {
+		created[epoch]++
+		return herocache.NewTransactions(100, unittest.Logger(), metrics.NewNoopCollector())
+	}
+	release := func(epoch uint64) {
+		released[epoch]++
+	}
+	pools := epochs.NewTransactionPools(create, epochs.WithRelease(release))
+
+	pool := pools.ForEpoch(1)
+	tx := unittest.TransactionBodyFixture()
+	pool.Add(&tx)
+	pools.ForEpoch(2).Add(&tx)
+	assert.Equal(t, uint(2), pools.CombinedSize())
+
+	pools.RemoveForEpoch(1)
+	assert.Equal(t, uint(0), pool.Size())
+	assert.Equal(t, uint(1), pools.CombinedSize())
+	assert.Equal(t, map[uint64]int{1: 1}, released)
+
+	// removing an epoch without a pool is a no-op
+	pools.RemoveForEpoch(3)
+	assert.Equal(t, map[uint64]int{1: 1}, released)
+
+	// pools of the remaining epochs are not re-created
+	pools.ForEpoch(2)
+	assert.Equal(t, map[uint64]int{1: 1, 2: 1}, created)
+}
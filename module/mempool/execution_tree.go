@@ -78,6 +78,10 @@ type ExecutionTree interface {
 	// * all other error are unexpected and potential indicators of corrupted internal state
 	ReachableReceipts(resultID flow.Identifier, blockFilter BlockFilter, receiptFilter ReceiptFilter) ([]*flow.ExecutionReceipt, error)
 
+	// ReceiptsForBlock returns all receipts stored in the mempool for results of the
+	// given block. Requires the block header, as receipts are indexed by height.
+	ReceiptsForBlock(block *flow.Header) []*flow.ExecutionReceipt
+
 	// Size returns the number of receipts stored in the mempool
 	Size() uint
 
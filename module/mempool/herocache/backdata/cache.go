@@ -10,6 +10,7 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool"
 	"github.com/onflow/flow-go/module/mempool/herocache/backdata/heropool"
 	"github.com/onflow/flow-go/utils/logging"
 )
@@ -17,6 +18,20 @@ import (
 //go:linkname runtimeNano runtime.nanotime
 func runtimeNano() int64
 
+var (
+	// wallBase and monoBase relate the runtime's monotonic clock to the wall clock, so that the
+	// time at which entities are added is read from the cheaper monotonic clock.
+	wallBase = time.Now().UnixNano()
+	monoBase = runtimeNano()
+)
+
+// unixNano returns the current unix time in nanoseconds, derived from the monotonic clock.
+// It doesn't follow adjustments of the wall clock after startup, which is accurate enough
+// to tell the age of entities.
+func unixNano() int64 {
+	return wallBase + runtimeNano() - monoBase
+}
+
 const (
 	slotsPerBucket = uint64(16)
 
@@ -35,6 +50,9 @@ const (
 	telemetryDurationInterval = 10 * time.Second
 )
 
+var _ mempool.BackData = (*Cache)(nil)
+var _ mempool.Introspector = (*Cache)(nil)
+
 // bucketIndex is data type representing a bucket index.
 type bucketIndex uint64
 
@@ -127,7 +145,7 @@ func NewCache(sizeLimit uint32,
 		sizeLimit:              sizeLimit,
 		buckets:                make([]slotBucket, bucketNum),
 		ejectionMode:           ejectionMode,
-		entities:               heropool.NewHeroPool(sizeLimit, ejectionMode),
+		entities:               heropool.NewHeroPool(sizeLimit, ejectionMode, unixNano),
 		availableSlotHistogram: make([]uint64, slotsPerBucket+1), // +1 is to account for empty buckets as well.
 		interactionCounter:     atomic.NewUint64(0),
 		lastTelemetryDump:      atomic.NewInt64(0),
@@ -248,12 +266,44 @@ func (c Cache) Entities() []flow.Entity {
 	return entities
 }
 
+// Entries returns information about all entities stored in the backdata.
+func (c *Cache) Entries() []mempool.EntryInfo {
+	defer c.logTelemetry()
+
+	entries := make([]mempool.EntryInfo, c.entities.Size())
+	for i, p := range c.entities.All() {
+		entries[i] = mempool.EntryInfo{
+			EntityID: p.Id(),
+			Entity:   p.Entity(),
+			Added:    p.Added(),
+		}
+	}
+
+	return entries
+}
+
+// Entry returns information about the entity with the given identifier.
+func (c *Cache) Entry(entityID flow.Identifier) (mempool.EntryInfo, bool) {
+	defer c.logTelemetry()
+
+	_, b, s, ok := c.get(entityID)
+	if !ok {
+		return mempool.EntryInfo{}, false
+	}
+	p := c.entities.GetPoolEntity(c.buckets[b].slots[s].entityIndex)
+	return mempool.EntryInfo{
+		EntityID: p.Id(),
+		Entity:   p.Entity(),
+		Added:    p.Added(),
+	}, true
+}
+
 // Clear removes all entities from the backdata.
 func (c *Cache) Clear() {
 	defer c.logTelemetry()
 
 	c.buckets = make([]slotBucket, c.bucketNum)
-	c.entities = heropool.NewHeroPool(c.sizeLimit, c.ejectionMode)
+	c.entities = heropool.NewHeroPool(c.sizeLimit, c.ejectionMode, unixNano)
 	c.availableSlotHistogram = make([]uint64, slotsPerBucket+1)
 	c.interactionCounter = atomic.NewUint64(0)
 	c.lastTelemetryDump = atomic.NewInt64(0)
@@ -2,6 +2,7 @@ package heropool
 
 import (
 	"math/rand"
+	"time"
 
 	"github.com/onflow/flow-go/model/flow"
 )
@@ -36,6 +37,9 @@ type PoolEntity struct {
 
 	// Actual entity itself.
 	entity flow.Entity
+
+	// Unix time in nanoseconds at which the entity was added to the pool, read from the clock of the pool.
+	added int64
 }
 
 func (p PoolEntity) Id() flow.Identifier {
@@ -46,15 +50,27 @@ func (p PoolEntity) Entity() flow.Entity {
 	return p.entity
 }
 
+// Added returns the time at which the entity was added to the pool.
+func (p PoolEntity) Added() time.Time {
+	return time.Unix(0, p.added)
+}
+
+// Clock returns the current unix time in nanoseconds. The pool reads it to record the time at which
+// entities are added, so it is read on every addition.
+type Clock func() int64
+
 type Pool struct {
 	size         uint32
 	free         state // keeps track of free slots.
 	used         state // keeps track of allocated slots to cachedEntities.
 	poolEntities []poolEntity
 	ejectionMode EjectionMode
+	clock        Clock
 }
 
-func NewHeroPool(sizeLimit uint32, ejectionMode EjectionMode) *Pool {
+// NewHeroPool creates a pool of at most sizeLimit entities, which records the time at which entities
+// are added with the given clock.
+func NewHeroPool(sizeLimit uint32, ejectionMode EjectionMode, clock Clock) *Pool {
 	l := &Pool{
 		free: state{
 			head: poolIndex{index: 0},
@@ -66,6 +82,7 @@ func NewHeroPool(sizeLimit uint32, ejectionMode EjectionMode) *Pool {
 		},
 		poolEntities: make([]poolEntity, sizeLimit),
 		ejectionMode: ejectionMode,
+		clock:        clock,
 	}
 
 	l.initFreeEntities()
@@ -99,6 +116,7 @@ func (p *Pool) Add(entityId flow.Identifier, entity flow.Entity, owner uint64) (
 	if slotAvailable {
 		p.poolEntities[entityIndex].entity = entity
 		p.poolEntities[entityIndex].id = entityId
+		p.poolEntities[entityIndex].added = p.clock()
 		p.poolEntities[entityIndex].owner = owner
 		p.poolEntities[entityIndex].node.next.setUndefined()
 		p.poolEntities[entityIndex].node.prev.setUndefined()
@@ -128,6 +146,11 @@ func (p Pool) Get(entityIndex EIndex) (flow.Identifier, flow.Entity, uint64) {
 	return p.poolEntities[entityIndex].id, p.poolEntities[entityIndex].entity, p.poolEntities[entityIndex].owner
 }
 
+// GetPoolEntity returns the pool entity corresponding to the entity index from the underlying list.
+func (p Pool) GetPoolEntity(entityIndex EIndex) PoolEntity {
+	return p.poolEntities[entityIndex].PoolEntity
+}
+
 // All returns all stored entities in this pool.
 func (p Pool) All() []PoolEntity {
 	all := make([]PoolEntity, p.size)
@@ -3,6 +3,7 @@ package heropool
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -156,6 +157,24 @@ func TestStoreAndRetrieval_With_Random_Ejection(t *testing.T) {
 	}
 }
 
+// TestAddedTime checks that the pool records the time at which entities are added from its clock.
+func TestAddedTime(t *testing.T) {
+	now := int64(1_000_000)
+	pool := NewHeroPool(10, LRUEjection, func() int64 { return now })
+
+	entities := unittest.EntityListFixture(3)
+	for _, entity := range entities {
+		now += int64(time.Second)
+		_, slotAvailable, _ := pool.Add(entity.ID(), entity, 0)
+		require.True(t, slotAvailable)
+	}
+
+	for i, entity := range pool.All() {
+		require.Equal(t, entities[i].ID(), entity.Id())
+		require.Equal(t, time.Unix(0, 1_000_000+int64(i+1)*int64(time.Second)), entity.Added())
+	}
+}
+
 // TestInvalidateEntity checks the health of heroPool for invalidating entities under random, LRU, and LIFO scenarios.
 // Invalidating an entity removes it from the used state and moves its node to the free state.
 func TestInvalidateEntity(t *testing.T) {
@@ -628,7 +647,7 @@ func withTestScenario(t *testing.T,
 	ejectionMode EjectionMode,
 	helpers ...func(*testing.T, *Pool, []*unittest.MockEntity)) {
 
-	pool := NewHeroPool(limit, ejectionMode)
+	pool := NewHeroPool(limit, ejectionMode, func() int64 { return time.Now().UnixNano() })
 
 	// head on underlying linked-list value should be uninitialized
 	require.True(t, pool.used.head.isUndefined())
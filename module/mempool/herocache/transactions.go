@@ -7,6 +7,7 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/mempool"
 	herocache "github.com/onflow/flow-go/module/mempool/herocache/backdata"
 	"github.com/onflow/flow-go/module/mempool/herocache/backdata/heropool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
@@ -44,6 +45,16 @@ func (t *Transactions) Add(tx *flow.TransactionBody) bool {
 	return t.c.Add(*tx)
 }
 
+// Entries returns information about all transactions in the mempool.
+func (t Transactions) Entries() []mempool.EntryInfo {
+	return t.c.Entries()
+}
+
+// Entry returns information about the transaction with the given ID in the mempool.
+func (t Transactions) Entry(txID flow.Identifier) (mempool.EntryInfo, bool) {
+	return t.c.Entry(txID)
+}
+
 // ByID returns the transaction with the given ID from the mempool.
 func (t Transactions) ByID(txID flow.Identifier) (*flow.TransactionBody, bool) {
 	entity, exists := t.c.ByID(txID)
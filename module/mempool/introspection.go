@@ -0,0 +1,121 @@
+package mempool
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrAlreadyRegistered is returned when a mempool is registered with a name
+// conflicting with an already registered mempool.
+var ErrAlreadyRegistered = fmt.Errorf("mempool name already registered")
+
+// EntryInfo describes an entity stored in a mempool.
+type EntryInfo struct {
+	// EntityID is the identifier the entity is stored under.
+	EntityID flow.Identifier
+	// Entity is the stored entity itself.
+	Entity flow.Entity
+	// Added is the time the entity was added to (or last adjusted in) the mempool.
+	// It is the zero time if the underlying data structure does not track it.
+	Added time.Time
+}
+
+// Introspector provides read-only access to the contents of a mempool. It exists
+// for debugging purposes, e.g. to inspect mempools which seem to be stuck via admin
+// commands, and should not be used by the protocol logic.
+type Introspector interface {
+	// Entries returns information about all entities stored in the mempool.
+	Entries() []EntryInfo
+
+	// Entry returns information about the entity with the given identifier.
+	// The boolean return value is false if there is no such entity in the mempool.
+	Entry(entityID flow.Identifier) (EntryInfo, bool)
+}
+
+// RemoveFunc removes the entity with the given identifier from a mempool. It returns
+// true if the entity was removed, and false if there was no such entity in the mempool.
+type RemoveFunc func(entityID flow.Identifier) bool
+
+// RegisteredMempool is a mempool registered with the Registry.
+type RegisteredMempool struct {
+	// Name is the name of the mempool, it must be unique for the node.
+	Name string
+	// Introspector provides the contents of the mempool.
+	Introspector Introspector
+	// Remove removes entities from the mempool. It must be the removal method of the
+	// mempool itself, rather than of the underlying backend, so that any secondary
+	// indices maintained by the mempool stay consistent.
+	Remove RemoveFunc
+}
+
+// Registry keeps track of the mempools of a node, so that they can be inspected and
+// evicted via admin commands.
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	mempools map[string]RegisteredMempool
+}
+
+// NewRegistry returns a new empty mempool registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		mempools: make(map[string]RegisteredMempool),
+	}
+}
+
+// Register registers a mempool with the given name.
+// Returns ErrAlreadyRegistered if a mempool with the same name is already registered.
+func (r *Registry) Register(name string, introspector Introspector, remove RemoveFunc) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.mempools[name]; exists {
+		return fmt.Errorf("can't register mempool %s: %w", name, ErrAlreadyRegistered)
+	}
+	r.mempools[name] = RegisteredMempool{
+		Name:         name,
+		Introspector: introspector,
+		Remove:       remove,
+	}
+	return nil
+}
+
+// Unregister removes the mempool registered with the given name, once the mempool is no
+// longer used. It returns false if no mempool is registered with the given name.
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, exists := r.mempools[name]
+	delete(r.mempools, name)
+	return exists
+}
+
+// ByName returns the mempool registered with the given name.
+// The boolean return value is false if no mempool is registered with the given name.
+func (r *Registry) ByName(name string) (RegisteredMempool, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	mempool, exists := r.mempools[name]
+	return mempool, exists
+}
+
+// All returns all registered mempools, sorted by name.
+func (r *Registry) All() []RegisteredMempool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make([]RegisteredMempool, 0, len(r.mempools))
+	for _, mempool := range r.mempools {
+		all = append(all, mempool)
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].Name < all[j].Name
+	})
+	return all
+}
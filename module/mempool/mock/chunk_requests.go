@@ -50,6 +50,29 @@ func (_m *ChunkRequests) All() verification.ChunkDataPackRequestInfoList {
 	return r0
 }
 
+// ByID provides a mock function with given fields: chunkID
+func (_m *ChunkRequests) ByID(chunkID flow.Identifier) (*verification.ChunkDataPackRequestInfo, bool) {
+	ret := _m.Called(chunkID)
+
+	var r0 *verification.ChunkDataPackRequestInfo
+	if rf, ok := ret.Get(0).(func(flow.Identifier) *verification.ChunkDataPackRequestInfo); ok {
+		r0 = rf(chunkID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*verification.ChunkDataPackRequestInfo)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(flow.Identifier) bool); ok {
+		r1 = rf(chunkID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // IncrementAttempt provides a mock function with given fields: chunkID
 func (_m *ChunkRequests) IncrementAttempt(chunkID flow.Identifier) bool {
 	ret := _m.Called(chunkID)
@@ -114,6 +114,22 @@ func (_m *ExecutionTree) ReachableReceipts(resultID flow.Identifier, blockFilter
 	return r0, r1
 }
 
+// ReceiptsForBlock provides a mock function with given fields: block
+func (_m *ExecutionTree) ReceiptsForBlock(block *flow.Header) []*flow.ExecutionReceipt {
+	ret := _m.Called(block)
+
+	var r0 []*flow.ExecutionReceipt
+	if rf, ok := ret.Get(0).(func(*flow.Header) []*flow.ExecutionReceipt); ok {
+		r0 = rf(block)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*flow.ExecutionReceipt)
+		}
+	}
+
+	return r0
+}
+
 // Size provides a mock function with given fields:
 func (_m *ExecutionTree) Size() uint {
 	ret := _m.Called()
@@ -1,19 +1,30 @@
 package backdata
 
 import (
+	"time"
+
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/mempool"
 )
 
+var _ mempool.Introspector = (*MapBackData)(nil)
+
+// mapEntry is an entity stored in MapBackData along with the time it was added.
+type mapEntry struct {
+	entity flow.Entity
+	added  time.Time
+}
+
 // MapBackData implements a map-based generic memory BackData backed by a Go map.
 type MapBackData struct {
 	// NOTE: as a BackData implementation, MapBackData must be non-blocking.
 	// Concurrency management is done by overlay Backend.
-	entities map[flow.Identifier]flow.Entity
+	entities map[flow.Identifier]mapEntry
 }
 
 func NewMapBackData() *MapBackData {
 	bd := &MapBackData{
-		entities: make(map[flow.Identifier]flow.Entity),
+		entities: make(map[flow.Identifier]mapEntry),
 	}
 	return bd
 }
@@ -30,42 +41,42 @@ func (b *MapBackData) Add(entityID flow.Identifier, entity flow.Entity) bool {
 	if exists {
 		return false
 	}
-	b.entities[entityID] = entity
+	b.entities[entityID] = mapEntry{entity: entity, added: time.Now()}
 	return true
 }
 
 // Remove removes the entity with the given identifier.
 func (b *MapBackData) Remove(entityID flow.Identifier) (flow.Entity, bool) {
-	entity, exists := b.entities[entityID]
+	entry, exists := b.entities[entityID]
 	if !exists {
 		return nil, false
 	}
 	delete(b.entities, entityID)
-	return entity, true
+	return entry.entity, true
 }
 
 // Adjust adjusts the entity using the given function if the given identifier can be found.
 // Returns a bool which indicates whether the entity was updated as well as the updated entity.
 func (b *MapBackData) Adjust(entityID flow.Identifier, f func(flow.Entity) flow.Entity) (flow.Entity, bool) {
-	entity, ok := b.entities[entityID]
+	entry, ok := b.entities[entityID]
 	if !ok {
 		return nil, false
 	}
-	newentity := f(entity)
+	newentity := f(entry.entity)
 	newentityID := newentity.ID()
 
 	delete(b.entities, entityID)
-	b.entities[newentityID] = newentity
+	b.entities[newentityID] = mapEntry{entity: newentity, added: time.Now()}
 	return newentity, true
 }
 
 // ByID returns the given entity from the backdata.
 func (b MapBackData) ByID(entityID flow.Identifier) (flow.Entity, bool) {
-	entity, exists := b.entities[entityID]
+	entry, exists := b.entities[entityID]
 	if !exists {
 		return nil, false
 	}
-	return entity, true
+	return entry.entity, true
 }
 
 // Size returns the size of the backdata, i.e., total number of stored (entityId, entity)
@@ -76,8 +87,8 @@ func (b MapBackData) Size() uint {
 // All returns all entities stored in the backdata.
 func (b MapBackData) All() map[flow.Identifier]flow.Entity {
 	entities := make(map[flow.Identifier]flow.Entity)
-	for entityID, entity := range b.entities {
-		entities[entityID] = entity
+	for entityID, entry := range b.entities {
+		entities[entityID] = entry.entity
 	}
 	return entities
 }
@@ -97,14 +108,40 @@ func (b MapBackData) Identifiers() flow.IdentifierList {
 func (b MapBackData) Entities() []flow.Entity {
 	entities := make([]flow.Entity, len(b.entities))
 	i := 0
-	for _, entity := range b.entities {
-		entities[i] = entity
+	for _, entry := range b.entities {
+		entities[i] = entry.entity
 		i++
 	}
 	return entities
 }
 
+// Entries returns information about all entities stored in the backdata.
+func (b MapBackData) Entries() []mempool.EntryInfo {
+	entries := make([]mempool.EntryInfo, 0, len(b.entities))
+	for entityID, entry := range b.entities {
+		entries = append(entries, mempool.EntryInfo{
+			EntityID: entityID,
+			Entity:   entry.entity,
+			Added:    entry.added,
+		})
+	}
+	return entries
+}
+
+// Entry returns information about the entity with the given identifier.
+func (b MapBackData) Entry(entityID flow.Identifier) (mempool.EntryInfo, bool) {
+	entry, exists := b.entities[entityID]
+	if !exists {
+		return mempool.EntryInfo{}, false
+	}
+	return mempool.EntryInfo{
+		EntityID: entityID,
+		Entity:   entry.entity,
+		Added:    entry.added,
+	}, true
+}
+
 // Clear removes all entities from the backdata.
 func (b *MapBackData) Clear() {
-	b.entities = make(map[flow.Identifier]flow.Entity)
+	b.entities = make(map[flow.Identifier]mapEntry)
 }
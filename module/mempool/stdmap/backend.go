@@ -12,6 +12,8 @@ import (
 	_ "github.com/onflow/flow-go/utils/binstat"
 )
 
+var _ mempool.Introspector = (*Backend)(nil)
+
 // Backend provides synchronized access to a backdata
 type Backend struct {
 	sync.RWMutex
@@ -154,6 +156,47 @@ func (b *Backend) All() []flow.Entity {
 	return b.backData.Entities()
 }
 
+// Entries returns information about all entities in the pool. The time each entity
+// was added is only available if the backdata implements mempool.Introspector.
+func (b *Backend) Entries() []mempool.EntryInfo {
+	b.RLock()
+	defer b.RUnlock()
+
+	if introspector, ok := b.backData.(mempool.Introspector); ok {
+		return introspector.Entries()
+	}
+
+	all := b.backData.All()
+	entries := make([]mempool.EntryInfo, 0, len(all))
+	for entityID, entity := range all {
+		entries = append(entries, mempool.EntryInfo{
+			EntityID: entityID,
+			Entity:   entity,
+		})
+	}
+	return entries
+}
+
+// Entry returns information about the entity with the given identifier in the pool.
+// The time the entity was added is only available if the backdata implements mempool.Introspector.
+func (b *Backend) Entry(entityID flow.Identifier) (mempool.EntryInfo, bool) {
+	b.RLock()
+	defer b.RUnlock()
+
+	if introspector, ok := b.backData.(mempool.Introspector); ok {
+		return introspector.Entry(entityID)
+	}
+
+	entity, exists := b.backData.ByID(entityID)
+	if !exists {
+		return mempool.EntryInfo{}, false
+	}
+	return mempool.EntryInfo{
+		EntityID: entityID,
+		Entity:   entity,
+	}, true
+}
+
 // Clear removes all entities from the pool.
 func (b *Backend) Clear() {
 	//bs1 := binstat.EnterTime(binstat.BinStdmap + ".w_lock.(Backend)Clear")
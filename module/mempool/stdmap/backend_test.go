@@ -14,7 +14,10 @@ import (
 
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module/mempool"
+	herocache "github.com/onflow/flow-go/module/mempool/herocache/backdata"
+	"github.com/onflow/flow-go/module/mempool/herocache/backdata/heropool"
 	"github.com/onflow/flow-go/module/mempool/stdmap"
+	"github.com/onflow/flow-go/module/metrics"
 	"github.com/onflow/flow-go/utils/unittest"
 )
 
@@ -249,3 +252,49 @@ func TestBackend_All(t *testing.T) {
 		require.Equal(t, expected, actual)
 	}
 }
+
+// TestBackend_Entries checks that the entries of the backend are introspected along with the time
+// they were added, for both the map based and the HeroCache based backdata.
+func TestBackend_Entries(t *testing.T) {
+	backends := map[string]struct {
+		backend *stdmap.Backend
+		// precision of the recorded times, HeroCache records them with a coarse clock of one second
+		precision time.Duration
+	}{
+		"map": {backend: stdmap.NewBackend()},
+		"herocache": {
+			backend: stdmap.NewBackend(stdmap.WithBackData(
+				herocache.NewCache(100, 8, heropool.LRUEjection, unittest.Logger(), metrics.NewNoopCollector()))),
+			precision: time.Second,
+		},
+	}
+
+	for name, tc := range backends {
+		backend := tc.backend
+		t.Run(name, func(t *testing.T) {
+			entities := unittest.EntityListFixture(10)
+			before := time.Now()
+			for _, e := range entities {
+				require.True(t, backend.Add(e))
+			}
+			after := time.Now()
+
+			entries := backend.Entries()
+			require.Len(t, entries, len(entities))
+			for _, entry := range entries {
+				assert.Equal(t, entry.EntityID, entry.Entity.ID())
+				assert.False(t, entry.Added.Before(before.Add(-tc.precision)))
+				assert.False(t, entry.Added.After(after))
+			}
+
+			entry, ok := backend.Entry(entities[0].ID())
+			require.True(t, ok)
+			assert.Equal(t, entities[0], entry.Entity)
+
+			require.True(t, backend.Remove(entities[0].ID()))
+			_, ok = backend.Entry(entities[0].ID())
+			assert.False(t, ok)
+			assert.Len(t, backend.Entries(), len(entities)-1)
+		})
+	}
+}
@@ -157,6 +157,17 @@ func (cs *ChunkRequests) IncrementAttempt(chunkID flow.Identifier) bool {
 	return err == nil
 }
 
+// ByID returns the chunk request for the specified chunk ID.
+// The boolean return value indicates whether a chunk request for this chunk ID exists in the memory pool.
+func (cs *ChunkRequests) ByID(chunkID flow.Identifier) (*verification.ChunkDataPackRequestInfo, bool) {
+	entity, exists := cs.Backend.ByID(chunkID)
+	if !exists {
+		return nil, false
+	}
+	requestInfo := toChunkRequestStatus(entity).RequestInfo
+	return &requestInfo, true
+}
+
 // All returns all chunk requests stored in this memory pool.
 func (cs *ChunkRequests) All() verification.ChunkDataPackRequestInfoList {
 	all := cs.Backend.All()
@@ -257,3 +257,32 @@ func TestAddingDuplicateChunkIDs(t *testing.T) {
 	require.False(t, ok)
 	require.Nil(t, locators)
 }
+
+// TestChunkRequests_ByID evaluates that the request info of a chunk is looked up by its chunk ID.
+func TestChunkRequests_ByID(t *testing.T) {
+	requests := stdmap.NewChunkRequests(10)
+
+	request := &verification.ChunkDataPackRequest{
+		Locator: chunks.Locator{
+			ResultID: unittest.IdentifierFixture(),
+			Index:    0,
+		},
+		ChunkDataPackRequestInfo: verification.ChunkDataPackRequestInfo{
+			ChunkID: unittest.IdentifierFixture(),
+			Height:  10,
+		},
+	}
+	require.True(t, requests.Add(request))
+
+	info, ok := requests.ByID(request.ChunkID)
+	require.True(t, ok)
+	require.Equal(t, request.ChunkID, info.ChunkID)
+	require.Equal(t, request.Height, info.Height)
+
+	_, ok = requests.ByID(unittest.IdentifierFixture())
+	require.False(t, ok)
+
+	require.True(t, requests.Remove(request.ChunkID))
+	_, ok = requests.ByID(request.ChunkID)
+	require.False(t, ok)
+}
@@ -324,6 +324,14 @@ type VerificationMetrics interface {
 	// OnVerifiableChunkSentToVerifier increments a counter that keeps track of number of verifiable chunks fetcher engine sent to verifier engine.
 	OnVerifiableChunkSentToVerifier()
 
+	// OnSealedChunkSkippedByFetcher increments a counter that keeps track of number of assigned chunks fetcher engine dropped
+	// without verifying, since their block got sealed via approvals of other verification nodes.
+	OnSealedChunkSkippedByFetcher()
+
+	// OnLateChunkReceivedAtFetcher increments a counter that keeps track of number of assigned chunks arrived at fetcher engine
+	// while their block was already past its emergency-sealing deadline.
+	OnLateChunkReceivedAtFetcher()
+
 	// OnResultApprovalDispatchedInNetwork increments a counter that keeps track of number of result approvals dispatched in the network
 	// by verifier engine.
 	OnResultApprovalDispatchedInNetworkByVerifier()
@@ -457,7 +465,22 @@ type ExecutionDataPrunerMetrics interface {
 	Pruned(height uint64, duration time.Duration)
 }
 
+// ClientRateLimitMetrics tracks the requests of Access API clients, by the tier of the client.
+type ClientRateLimitMetrics interface {
+	// ClientRequestAllowed tracks the number of requests of clients in the given tier which are within their limits
+	ClientRequestAllowed(tier string)
+
+	// ClientRequestRateLimited tracks the number of requests of clients in the given tier which are rejected
+	// for exceeding their limits, by the exceeded limit
+	ClientRequestRateLimited(tier string, reason string)
+
+	// ClientScriptComputeUsed tracks the time spent executing scripts for clients in the given tier
+	ClientScriptComputeUsed(tier string, duration time.Duration)
+}
+
 type AccessMetrics interface {
+	ClientRateLimitMetrics
+
 	// TotalConnectionsInPool updates the number connections to collection/execution nodes stored in the pool, and the size of the pool
 	TotalConnectionsInPool(connectionCount uint, connectionPoolSize uint)
 
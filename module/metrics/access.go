@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -13,6 +15,9 @@ type AccessCollector struct {
 	connectionInvalidated prometheus.Counter
 	connectionUpdated     prometheus.Counter
 	connectionEvicted     prometheus.Counter
+	clientRequests        *prometheus.CounterVec
+	clientRateLimited     *prometheus.CounterVec
+	clientScriptCompute   *prometheus.CounterVec
 }
 
 func NewAccessCollector() *AccessCollector {
@@ -59,6 +64,24 @@ func NewAccessCollector() *AccessCollector {
 			Subsystem: subsystemConnectionPool,
 			Help:      "counter for the number of times a cached connection is evicted from the connection pool",
 		}),
+		clientRequests: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "requests_allowed_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemClientRateLimit,
+			Help:      "counter for the number of client requests within the limits of the client's tier",
+		}, []string{LabelClientTier}),
+		clientRateLimited: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "requests_rate_limited_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemClientRateLimit,
+			Help:      "counter for the number of client requests rejected for exceeding the limits of the client's tier",
+		}, []string{LabelClientTier, LabelRateLimitReason}),
+		clientScriptCompute: promauto.NewCounterVec(prometheus.CounterOpts{
+			Name:      "script_compute_seconds_total",
+			Namespace: namespaceAccess,
+			Subsystem: subsystemClientRateLimit,
+			Help:      "counter for the time spent executing scripts for clients",
+		}, []string{LabelClientTier}),
 	}
 
 	return ac
@@ -92,3 +115,15 @@ func (ac *AccessCollector) ConnectionFromPoolUpdated() {
 func (ac *AccessCollector) ConnectionFromPoolEvicted() {
 	ac.connectionEvicted.Inc()
 }
+
+func (ac *AccessCollector) ClientRequestAllowed(tier string) {
+	ac.clientRequests.WithLabelValues(tier).Inc()
+}
+
+func (ac *AccessCollector) ClientRequestRateLimited(tier string, reason string) {
+	ac.clientRateLimited.WithLabelValues(tier, reason).Inc()
+}
+
+func (ac *AccessCollector) ClientScriptComputeUsed(tier string, duration time.Duration) {
+	ac.clientScriptCompute.WithLabelValues(tier).Add(duration.Seconds())
+}
@@ -159,6 +159,8 @@ func demo() {
 			tryRandomCall(vc.OnVerifiableChunkSentToVerifier)
 			tryRandomCall(vc.OnChunkDataPackArrivedAtFetcher)
 			tryRandomCall(vc.OnChunkDataPackRequestSentByFetcher)
+			tryRandomCall(vc.OnSealedChunkSkippedByFetcher)
+			tryRandomCall(vc.OnLateChunkReceivedAtFetcher)
 
 			// requester
 			tryRandomCall(vc.OnChunkDataPackRequestReceivedByRequester)
@@ -159,31 +159,7 @@ func NewHeroCacheCollector(nameSpace string, cacheName string, registrar prometh
 		Help:      "total number of emergency key ejections at bucket level",
 	})
 
-	registrar.MustRegister(
-		// available slot distribution
-		histogramNormalizedBucketSlotAvailable,
-
-		// size
-		size,
-
-		// read
-		countKeyGetSuccess,
-		countKeyGetFailure,
-
-		// write
-		countKeyPutSuccess,
-		countKeyPutDeduplicated,
-		countKeyPutDrop,
-		countKeyPutAttempt,
-
-		// remove
-		countKeyRemoved,
-
-		// ejection
-		countKeyEjectionDueToFullCapacity,
-		countKeyEjectionDueToEmergency)
-
-	return &HeroCacheCollector{
+	collector := &HeroCacheCollector{
 		histogramNormalizedBucketSlotAvailable: histogramNormalizedBucketSlotAvailable,
 		size:                                   size,
 		countKeyGetSuccess:                     countKeyGetSuccess,
@@ -199,6 +175,45 @@ func NewHeroCacheCollector(nameSpace string, cacheName string, registrar prometh
 		countKeyEjectionDueToFullCapacity: countKeyEjectionDueToFullCapacity,
 		countKeyEjectionDueToEmergency:    countKeyEjectionDueToEmergency,
 	}
+	registrar.MustRegister(collector.collectors()...)
+
+	return collector
+}
+
+// collectors returns all metrics of the HeroCache.
+func (h *HeroCacheCollector) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		// available slot distribution
+		h.histogramNormalizedBucketSlotAvailable,
+
+		// size
+		h.size,
+
+		// read
+		h.countKeyGetSuccess,
+		h.countKeyGetFailure,
+
+		// write
+		h.countKeyPutSuccess,
+		h.countKeyPutDeduplicated,
+		h.countKeyPutDrop,
+		h.countKeyPutAttempt,
+
+		// remove
+		h.countKeyRemoved,
+
+		// ejection
+		h.countKeyEjectionDueToFullCapacity,
+		h.countKeyEjectionDueToEmergency,
+	}
+}
+
+// Unregister unregisters the metrics of the HeroCache from the given registrar, once the
+// HeroCache is no longer used.
+func (h *HeroCacheCollector) Unregister(registrar prometheus.Registerer) {
+	for _, c := range h.collectors() {
+		registrar.Unregister(c)
+	}
 }
 
 // BucketAvailableSlots keeps track of number of available slots in buckets of cache.
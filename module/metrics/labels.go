@@ -126,3 +126,4 @@ const ExecutionDataRequestRetryable = "retryable"
 
 const LabelViolationReason = "reason"
 const LabelRateLimitReason = "reason"
+const LabelClientTier = "tier"
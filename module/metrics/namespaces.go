@@ -40,11 +40,13 @@ const (
 	subsystemTransactionTiming     = "transaction_timing"
 	subsystemTransactionSubmission = "transaction_submission"
 	subsystemConnectionPool        = "connection_pool"
+	subsystemClientRateLimit       = "client_rate_limit"
 )
 
 // Observer subsystem
 const (
-	subsystemObserverGRPC = "observer_grpc"
+	subsystemObserverGRPC          = "observer_grpc"
+	subsystemObserverResponseCache = "observer_response_cache"
 )
 
 // Collection subsystem
@@ -115,6 +115,8 @@ func (nc *NoopCollector) OnChunkDataPackRequestReceivedByRequester()
 func (nc *NoopCollector) OnChunkDataPackArrivedAtFetcher()                                      {}
 func (nc *NoopCollector) OnChunkDataPackSentToFetcher()                                         {}
 func (nc *NoopCollector) OnVerifiableChunkSentToVerifier()                                      {}
+func (nc *NoopCollector) OnSealedChunkSkippedByFetcher()                                        {}
+func (nc *NoopCollector) OnLateChunkReceivedAtFetcher()                                         {}
 func (nc *NoopCollector) OnBlockConsumerJobDone(uint64)                                         {}
 func (nc *NoopCollector) OnChunkConsumerJobDone(uint64)                                         {}
 func (nc *NoopCollector) OnChunkDataPackResponseReceivedFromNetworkByRequester()                {}
@@ -125,6 +127,9 @@ func (nc *NoopCollector) NewConnectionEstablished()
 func (nc *NoopCollector) ConnectionFromPoolInvalidated()                                        {}
 func (nc *NoopCollector) ConnectionFromPoolUpdated()                                            {}
 func (nc *NoopCollector) ConnectionFromPoolEvicted()                                            {}
+func (nc *NoopCollector) ClientRequestAllowed(tier string)                                      {}
+func (nc *NoopCollector) ClientRequestRateLimited(tier string, reason string)                   {}
+func (nc *NoopCollector) ClientScriptComputeUsed(tier string, duration time.Duration)           {}
 func (nc *NoopCollector) StartBlockReceivedToExecuted(blockID flow.Identifier)                  {}
 func (nc *NoopCollector) FinishBlockReceivedToExecuted(blockID flow.Identifier)                 {}
 func (nc *NoopCollector) ExecutionComputationUsedPerBlock(computation uint64)                   {}
@@ -7,7 +7,9 @@ import (
 )
 
 type ObserverCollector struct {
-	rpcs *prometheus.CounterVec
+	rpcs        *prometheus.CounterVec
+	cacheHits   *prometheus.CounterVec
+	cacheMisses *prometheus.CounterVec
 }
 
 func NewObserverCollector() *ObserverCollector {
@@ -18,6 +20,18 @@ func NewObserverCollector() *ObserverCollector {
 			Name:      "handler_grpc_counter",
 			Help:      "tracking error/success rate of each rpc for the observer service",
 		}, []string{"handler", "grpc_method", "grpc_code"}),
+		cacheHits: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceObserver,
+			Subsystem: subsystemObserverResponseCache,
+			Name:      "hits_total",
+			Help:      "number of upstream requests served from the response cache of the observer service",
+		}, []string{"grpc_method"}),
+		cacheMisses: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespaceObserver,
+			Subsystem: subsystemObserverResponseCache,
+			Name:      "misses_total",
+			Help:      "number of upstream requests not found in the response cache of the observer service",
+		}, []string{"grpc_method"}),
 	}
 }
 
@@ -28,3 +42,13 @@ func (oc *ObserverCollector) RecordRPC(handler, rpc string, code codes.Code) {
 		"grpc_code":   code.String(),
 	}).Inc()
 }
+
+// ResponseCacheHit records a request served from the upstream response cache.
+func (oc *ObserverCollector) ResponseCacheHit(rpc string) {
+	oc.cacheHits.WithLabelValues(rpc).Inc()
+}
+
+// ResponseCacheMiss records a request not found in the upstream response cache.
+func (oc *ObserverCollector) ResponseCacheMiss(rpc string) {
+	oc.cacheMisses.WithLabelValues(rpc).Inc()
+}
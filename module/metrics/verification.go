@@ -24,6 +24,8 @@ type VerificationCollector struct {
 	sentVerifiableChunksTotalFetcher   prometheus.Counter // total verifiable chunk sent by fetcher engine and sent to verifier engine.
 	receivedChunkDataPackTotalFetcher  prometheus.Counter // total chunk data packs received by fetcher engine
 	requestedChunkDataPackTotalFetcher prometheus.Counter // total number of chunk data packs requested by fetcher engine
+	skippedSealedChunksTotalFetcher    prometheus.Counter // total number of assigned chunks dropped by fetcher engine as their block got sealed.
+	receivedLateChunksTotalFetcher     prometheus.Counter // total number of assigned chunks received by fetcher engine past their emergency-sealing deadline.
 
 	// Requester Engine
 	//
@@ -147,6 +149,20 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		Help:      "total number of chunk data packs requested by fetcher engine",
 	})
 
+	skippedSealedChunksTotalFetcher := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "sealed_chunk_skipped_total",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemFetcherEngine,
+		Help:      "total number of assigned chunks dropped by fetcher engine without verification since their block got sealed",
+	})
+
+	receivedLateChunksTotalFetcher := prometheus.NewCounter(prometheus.CounterOpts{
+		Name:      "late_chunk_received_total",
+		Namespace: namespaceVerification,
+		Subsystem: subsystemFetcherEngine,
+		Help:      "total number of assigned chunks received by fetcher engine while their block was past its emergency-sealing deadline",
+	})
+
 	maxChunkDataPackRequestAttemptForNextUnsealedHeight := prometheus.NewGauge(prometheus.GaugeOpts{
 		Name:      "next_unsealed_height_max_chunk_data_pack_request_attempt_times",
 		Namespace: namespaceVerification,
@@ -190,6 +206,8 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		sentVerifiableChunksTotalFetcher,
 		receivedChunkDataPackTotalFetcher,
 		requestedChunkDataPackTotalFetcher,
+		skippedSealedChunksTotalFetcher,
+		receivedLateChunksTotalFetcher,
 
 		// requester engine
 		receivedChunkDataPackRequestsTotalRequester,
@@ -220,6 +238,8 @@ func NewVerificationCollector(tracer module.Tracer, registerer prometheus.Regist
 		receivedChunkDataPackTotalFetcher:  receivedChunkDataPackTotalFetcher,
 		requestedChunkDataPackTotalFetcher: requestedChunkDataPackTotalFetcher,
 		sentVerifiableChunksTotalFetcher:   sentVerifiableChunksTotalFetcher,
+		skippedSealedChunksTotalFetcher:    skippedSealedChunksTotalFetcher,
+		receivedLateChunksTotalFetcher:     receivedLateChunksTotalFetcher,
 
 		// verifier
 		sentResultApprovalTotalVerifier:      sentResultApprovalTotalVerifier,
@@ -321,6 +341,18 @@ func (vc *VerificationCollector) OnVerifiableChunkSentToVerifier() {
 	vc.sentVerifiableChunksTotalFetcher.Inc()
 }
 
+// OnSealedChunkSkippedByFetcher increments a counter that keeps track of number of assigned chunks fetcher engine dropped
+// without verifying, since their block got sealed via approvals of other verification nodes.
+func (vc *VerificationCollector) OnSealedChunkSkippedByFetcher() {
+	vc.skippedSealedChunksTotalFetcher.Inc()
+}
+
+// OnLateChunkReceivedAtFetcher increments a counter that keeps track of number of assigned chunks arrived at fetcher engine
+// while their block was already past its emergency-sealing deadline.
+func (vc *VerificationCollector) OnLateChunkReceivedAtFetcher() {
+	vc.receivedLateChunksTotalFetcher.Inc()
+}
+
 // OnChunkConsumerJobDone is invoked by chunk consumer whenever it is notified a job is done by a worker. It
 // sets the last processed chunk job index.
 func (vc *VerificationCollector) OnChunkConsumerJobDone(processedIndex uint64) {
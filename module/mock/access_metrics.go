@@ -2,13 +2,32 @@
 
 package mock
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
 
 // AccessMetrics is an autogenerated mock type for the AccessMetrics type
 type AccessMetrics struct {
 	mock.Mock
 }
 
+// ClientRequestAllowed provides a mock function with given fields: tier
+func (_m *AccessMetrics) ClientRequestAllowed(tier string) {
+	_m.Called(tier)
+}
+
+// ClientRequestRateLimited provides a mock function with given fields: tier, reason
+func (_m *AccessMetrics) ClientRequestRateLimited(tier string, reason string) {
+	_m.Called(tier, reason)
+}
+
+// ClientScriptComputeUsed provides a mock function with given fields: tier, duration
+func (_m *AccessMetrics) ClientScriptComputeUsed(tier string, duration time.Duration) {
+	_m.Called(tier, duration)
+}
+
 // ConnectionAddedToPool provides a mock function with given fields:
 func (_m *AccessMetrics) ConnectionAddedToPool() {
 	_m.Called()
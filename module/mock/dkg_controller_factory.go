@@ -14,13 +14,13 @@ type DKGControllerFactory struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: dkgInstanceID, participants, seed
-func (_m *DKGControllerFactory) Create(dkgInstanceID string, participants flow.IdentityList, seed []byte) (module.DKGController, error) {
-	ret := _m.Called(dkgInstanceID, participants, seed)
+// Create provides a mock function with given fields: dkgInstanceID, participants, epochCounter, seed
+func (_m *DKGControllerFactory) Create(dkgInstanceID string, participants flow.IdentityList, epochCounter uint64, seed []byte) (module.DKGController, error) {
+	ret := _m.Called(dkgInstanceID, participants, epochCounter, seed)
 
 	var r0 module.DKGController
-	if rf, ok := ret.Get(0).(func(string, flow.IdentityList, []byte) module.DKGController); ok {
-		r0 = rf(dkgInstanceID, participants, seed)
+	if rf, ok := ret.Get(0).(func(string, flow.IdentityList, uint64, []byte) module.DKGController); ok {
+		r0 = rf(dkgInstanceID, participants, epochCounter, seed)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(module.DKGController)
@@ -28,8 +28,31 @@ func (_m *DKGControllerFactory) Create(dkgInstanceID string, participants flow.I
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(string, flow.IdentityList, []byte) error); ok {
-		r1 = rf(dkgInstanceID, participants, seed)
+	if rf, ok := ret.Get(1).(func(string, flow.IdentityList, uint64, []byte) error); ok {
+		r1 = rf(dkgInstanceID, participants, epochCounter, seed)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Resume provides a mock function with given fields: dkgInstanceID, participants, epochCounter
+func (_m *DKGControllerFactory) Resume(dkgInstanceID string, participants flow.IdentityList, epochCounter uint64) (module.DKGController, error) {
+	ret := _m.Called(dkgInstanceID, participants, epochCounter)
+
+	var r0 module.DKGController
+	if rf, ok := ret.Get(0).(func(string, flow.IdentityList, uint64) module.DKGController); ok {
+		r0 = rf(dkgInstanceID, participants, epochCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(module.DKGController)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, flow.IdentityList, uint64) error); ok {
+		r1 = rf(dkgInstanceID, participants, epochCounter)
 	} else {
 		r1 = ret.Error(1)
 	}
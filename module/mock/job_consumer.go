@@ -31,6 +31,20 @@ func (_m *JobConsumer) LastProcessedIndex() uint64 {
 	return r0
 }
 
+// NotifyJobFailed provides a mock function with given fields: _a0, _a1
+func (_m *JobConsumer) NotifyJobFailed(_a0 module.JobID, _a1 error) uint64 {
+	ret := _m.Called(_a0, _a1)
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func(module.JobID, error) uint64); ok {
+		r0 = rf(_a0, _a1)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	return r0
+}
+
 // NotifyJobIsDone provides a mock function with given fields: _a0
 func (_m *JobConsumer) NotifyJobIsDone(_a0 module.JobID) uint64 {
 	ret := _m.Called(_a0)
@@ -33,9 +33,9 @@ func (_m *SyncCore) HandleBlock(header *flow.Header) bool {
 	return r0
 }
 
-// HandleHeight provides a mock function with given fields: final, height
-func (_m *SyncCore) HandleHeight(final *flow.Header, height uint64) {
-	_m.Called(final, height)
+// HandleHeight provides a mock function with given fields: final, height, originID
+func (_m *SyncCore) HandleHeight(final *flow.Header, height uint64, originID flow.Identifier) {
+	_m.Called(final, height, originID)
 }
 
 // RangeRequested provides a mock function with given fields: ran
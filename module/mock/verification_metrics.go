@@ -74,11 +74,21 @@ func (_m *VerificationMetrics) OnFinalizedBlockArrivedAtAssigner(height uint64)
 	_m.Called(height)
 }
 
+// OnLateChunkReceivedAtFetcher provides a mock function with given fields:
+func (_m *VerificationMetrics) OnLateChunkReceivedAtFetcher() {
+	_m.Called()
+}
+
 // OnResultApprovalDispatchedInNetworkByVerifier provides a mock function with given fields:
 func (_m *VerificationMetrics) OnResultApprovalDispatchedInNetworkByVerifier() {
 	_m.Called()
 }
 
+// OnSealedChunkSkippedByFetcher provides a mock function with given fields:
+func (_m *VerificationMetrics) OnSealedChunkSkippedByFetcher() {
+	_m.Called()
+}
+
 // OnVerifiableChunkReceivedAtVerifierEngine provides a mock function with given fields:
 func (_m *VerificationMetrics) OnVerifiableChunkReceivedAtVerifierEngine() {
 	_m.Called()
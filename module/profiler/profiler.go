@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/pprof"
+	"sync"
 	"time"
 
 	"github.com/google/pprof/profile"
@@ -34,14 +35,24 @@ type ProfilerConfig struct {
 	Enabled         bool
 	UploaderEnabled bool
 
-	Dir      string
-	Interval time.Duration
-	Duration time.Duration
+	Dir         string
+	Interval    time.Duration
+	Duration    time.Duration
+	MaxCaptures uint // maximum number of interval and manual captures kept in Dir, 0 to keep all
+
+	Triggers TriggerConfig
+}
+
+// captureRequest requests a profiler run.
+type captureRequest struct {
+	duration time.Duration
+	trigger  string
+	reason   string
 }
 
 type AutoProfiler struct {
 	unit     *engine.Unit
-	dir      string
+	store    *Store
 	log      zerolog.Logger
 	interval time.Duration
 	duration time.Duration
@@ -50,30 +61,43 @@ type AutoProfiler struct {
 	enabled  *atomic.Bool
 
 	// used to trigger a profile run for a given duration
-	trigger chan time.Duration
+	trigger chan captureRequest
+
+	// rate limiting of captures triggered by anomalies
+	triggerMu       sync.Mutex
+	lastTriggered   time.Time
+	triggers        TriggerConfig
+	triggerDuration time.Duration
 }
 
 // New creates a new AutoProfiler instance performing profiling every interval for duration.
 func New(log zerolog.Logger, uploader Uploader, cfg ProfilerConfig) (*AutoProfiler, error) {
 
-	err := os.MkdirAll(cfg.Dir, os.ModePerm)
+	store, err := NewStore(cfg.Dir, cfg.MaxCaptures, cfg.Triggers.MaxCaptures)
 	if err != nil {
-		return nil, fmt.Errorf("could not create profile dir %v: %w", cfg.Dir, err)
+		return nil, err
 	}
 
 	// add 50% jitter to the interval
 	jitter := time.Duration(rand.Int63n(int64(cfg.Interval)))
 	interval := cfg.Interval/2 + jitter
 
+	triggerDuration := cfg.Triggers.Duration
+	if triggerDuration == 0 {
+		triggerDuration = cfg.Duration
+	}
+
 	p := &AutoProfiler{
-		unit:     engine.NewUnit(),
-		log:      log.With().Str("component", "profiler").Logger(),
-		dir:      cfg.Dir,
-		interval: interval,
-		duration: cfg.Duration,
-		uploader: uploader,
-		enabled:  atomic.NewBool(cfg.Enabled),
-		trigger:  make(chan time.Duration),
+		unit:            engine.NewUnit(),
+		log:             log.With().Str("component", "profiler").Logger(),
+		store:           store,
+		interval:        interval,
+		duration:        cfg.Duration,
+		uploader:        uploader,
+		enabled:         atomic.NewBool(cfg.Enabled),
+		trigger:         make(chan captureRequest),
+		triggers:        cfg.Triggers,
+		triggerDuration: triggerDuration,
 	}
 
 	go p.runForever()
@@ -93,10 +117,52 @@ func (p *AutoProfiler) Enabled() bool {
 	return p.enabled.Load()
 }
 
+// Store returns the store holding the captured profiles.
+func (p *AutoProfiler) Store() *Store {
+	return p.store
+}
+
+// Triggers returns the configuration of the anomalies triggering a capture.
+func (p *AutoProfiler) Triggers() TriggerConfig {
+	return p.triggers
+}
+
 // TriggerRun manually triggers a profile run if one is not already running.
 func (p *AutoProfiler) TriggerRun(d time.Duration) error {
+	return p.requestCapture(captureRequest{duration: d, trigger: TriggerManual})
+}
+
+// TriggerCapture triggers a profile run because of the given anomaly, described by the
+// trigger name and a human-readable reason. Triggered captures are rate limited: a capture is
+// only started if no other capture was triggered within the configured cooldown.
+// Returns ErrProfilerDisabled if the profiler is disabled, ErrCaptureRateLimited if the capture
+// was rate limited, and an error if profiling is already in progress.
+func (p *AutoProfiler) TriggerCapture(trigger string, reason string) error {
+	if !p.Enabled() {
+		return ErrProfilerDisabled
+	}
+
+	p.triggerMu.Lock()
+	defer p.triggerMu.Unlock()
+
+	now := time.Now()
+	if !p.lastTriggered.IsZero() && now.Sub(p.lastTriggered) < p.triggers.Cooldown {
+		return ErrCaptureRateLimited
+	}
+
+	err := p.requestCapture(captureRequest{duration: p.triggerDuration, trigger: trigger, reason: reason})
+	if err != nil {
+		return err
+	}
+	p.lastTriggered = now
+	p.log.Info().Str("trigger", trigger).Str("reason", reason).Msg("profile capture triggered")
+	return nil
+}
+
+// requestCapture starts a profile run if one is not already running.
+func (p *AutoProfiler) requestCapture(req captureRequest) error {
 	select {
-	case p.trigger <- d:
+	case p.trigger <- req:
 		return nil
 	default:
 		return errors.New("profiling is already in progress")
@@ -111,10 +177,10 @@ func (p *AutoProfiler) runForever() {
 		select {
 		case <-t.C:
 			if p.Enabled() {
-				p.runOnce(p.duration)
+				p.runOnce(captureRequest{duration: p.duration, trigger: TriggerInterval})
 			}
-		case d := <-p.trigger:
-			p.runOnce(d)
+		case req := <-p.trigger:
+			p.runOnce(req)
 		case <-p.unit.Quit():
 			return
 		}
@@ -134,9 +200,22 @@ func (p *AutoProfiler) Done() <-chan struct{} {
 	return p.unit.Done()
 }
 
-func (p *AutoProfiler) runOnce(d time.Duration) {
+func (p *AutoProfiler) runOnce(req captureRequest) {
+	d := req.duration
 	startTime := time.Now()
-	p.log.Info().Msg("starting profile trace")
+	p.log.Info().Str("trigger", req.trigger).Msg("starting profile trace")
+
+	capture := Capture{
+		ID:      newCaptureID(startTime, req.trigger),
+		Trigger: req.trigger,
+		Reason:  req.reason,
+		Start:   startTime,
+	}
+	captureDir, err := p.store.CreateCaptureDir(capture.ID)
+	if err != nil {
+		p.log.Err(err).Msg("failed to create capture dir")
+		return
+	}
 
 	for _, prof := range [...]profileDef{
 		{profileName: "goroutine", profileType: pb.ProfileType_THREADS, profileFunc: func(w io.Writer, _ time.Duration) error { return newProfileFunc("goroutine")(w) }},
@@ -145,12 +224,13 @@ func (p *AutoProfiler) runOnce(d time.Duration) {
 		{profileName: "block", profileType: pb.ProfileType_CONTENTION, profileFunc: p.pprofBlock},
 		{profileName: "cpu", profileType: pb.ProfileType_WALL, profileFunc: p.pprofCpu},
 	} {
-		path := filepath.Join(p.dir, fmt.Sprintf("%s-%s", prof.profileName, time.Now().Format(time.RFC3339)))
+		fileName := fmt.Sprintf("%s.pb.gz", prof.profileName)
+		path := filepath.Join(captureDir, fileName)
 
 		logger := p.log.With().Str("profileName", prof.profileName).Str("profilePath", path).Logger()
 		logger.Info().Str("file", path).Msg("capturing")
 
-		f, err := os.CreateTemp(p.dir, "profile")
+		f, err := os.CreateTemp(captureDir, "profile")
 		if err != nil {
 			logger.Err(err).Msg("failed to create temp profile")
 			continue
@@ -186,6 +266,12 @@ func (p *AutoProfiler) runOnce(d time.Duration) {
 			continue
 		}
 
+		var size int64
+		if info, err := os.Stat(path); err == nil {
+			size = info.Size()
+		}
+		capture.Profiles = append(capture.Profiles, ProfileFile{Name: prof.profileName, File: fileName, Size: size})
+
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
 
@@ -195,7 +281,13 @@ func (p *AutoProfiler) runOnce(d time.Duration) {
 			continue
 		}
 	}
-	p.log.Info().Dur("duration", time.Since(startTime)).Msg("finished profile trace")
+
+	capture.Duration = time.Since(startTime)
+	err = p.store.Save(capture)
+	if err != nil {
+		p.log.Error().Err(err).Msg("failed to save capture")
+	}
+	p.log.Info().Dur("duration", capture.Duration).Str("capture", capture.ID).Msg("finished profile trace")
 }
 
 func (p *AutoProfiler) pprof(f *os.File, fn timedProfileFunc, d time.Duration) (err error) {
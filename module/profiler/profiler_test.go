@@ -2,7 +2,7 @@ package profiler_test
 
 import (
 	"os"
-	"strings"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -42,8 +42,13 @@ func TestProfiler(t *testing.T) {
 			t.Logf("profiler ready %s", tempDir)
 
 			require.Eventuallyf(t, func() bool {
-				dirEnts, err := os.ReadDir(tempDir)
+				captures, err := p.Store().List()
 				require.NoError(t, err)
+				if len(captures) == 0 {
+					return false
+				}
+				require.Len(t, captures, 1)
+				require.Equal(t, profiler.TriggerManual, captures[0].Trigger)
 
 				foundPtypes := make(map[string]bool)
 				for _, pType := range []string{"heap", "allocs", "goroutine", "cpu", "block"} {
@@ -51,8 +56,10 @@ func TestProfiler(t *testing.T) {
 				}
 
 				for pName := range foundPtypes {
-					for _, ent := range dirEnts {
-						if strings.Contains(ent.Name(), pName) {
+					for _, prof := range captures[0].Profiles {
+						if prof.Name == pName {
+							_, err := os.Stat(filepath.Join(tempDir, captures[0].ID, prof.File))
+							require.NoError(t, err)
 							foundPtypes[pName] = true
 						}
 					}
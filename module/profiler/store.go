@@ -0,0 +1,244 @@
+package profiler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrCaptureNotFound is returned when a capture or profile is not kept by the Store.
+var ErrCaptureNotFound = errors.New("capture not found")
+
+// metadataFile is the name of the file holding the Capture metadata within a capture directory.
+const metadataFile = "capture.json"
+
+// ProfileFile describes a single profile of a Capture.
+type ProfileFile struct {
+	Name string `json:"name"` // profile name, e.g. "heap" or "cpu"
+	File string `json:"file"` // file name within the capture directory
+	Size int64  `json:"size"`
+}
+
+// Capture describes the profiles captured by a single profiler run, together with what
+// triggered the run.
+type Capture struct {
+	ID       string        `json:"id"`
+	Trigger  string        `json:"trigger"`
+	Reason   string        `json:"reason,omitempty"`
+	Start    time.Time     `json:"start"`
+	Duration time.Duration `json:"duration"`
+	Profiles []ProfileFile `json:"profiles"`
+}
+
+// Triggered returns true if the capture was triggered by an anomaly, rather than by the periodic
+// run of the profiler or by an operator.
+func (c Capture) Triggered() bool {
+	return c.Trigger != TriggerInterval && c.Trigger != TriggerManual
+}
+
+// Store is a local rotating store of captures. Each capture is kept in its own directory,
+// named by the capture ID. Once more than the maximum number of captures are stored, the
+// oldest captures are deleted. Captures triggered by anomalies have their own maximum, so
+// that frequent anomalies don't rotate out the periodic captures, and vice versa.
+// Store is safe for concurrent use.
+type Store struct {
+	mu           sync.Mutex
+	dir          string
+	maxCaptures  uint
+	maxTriggered uint
+}
+
+// NewStore creates a store keeping up to maxCaptures periodic and manual captures, and up to
+// maxTriggered captures triggered by anomalies in the given directory. A maximum of 0 keeps
+// all captures of the respective kind.
+func NewStore(dir string, maxCaptures uint, maxTriggered uint) (*Store, error) {
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("could not create profile dir %v: %w", dir, err)
+	}
+	return &Store{
+		dir:          dir,
+		maxCaptures:  maxCaptures,
+		maxTriggered: maxTriggered,
+	}, nil
+}
+
+// newCaptureID returns the ID of a capture started at the given time. IDs sort by start time.
+func newCaptureID(start time.Time, trigger string) string {
+	return fmt.Sprintf("%s-%s", start.UTC().Format("20060102T150405.000Z"), trigger)
+}
+
+// CreateCaptureDir creates the directory the profiles of the capture with the given ID are
+// written to, and returns its path.
+func (s *Store) CreateCaptureDir(id string) (string, error) {
+	dir := filepath.Join(s.dir, id)
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return "", fmt.Errorf("could not create capture dir %v: %w", dir, err)
+	}
+	return dir, nil
+}
+
+// Save persists the metadata of a capture, whose profiles were written to the directory
+// returned by CreateCaptureDir, and deletes the oldest captures exceeding the maximum.
+func (s *Store) Save(capture Capture) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(capture, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode capture metadata: %w", err)
+	}
+	err = os.WriteFile(filepath.Join(s.dir, capture.ID, metadataFile), data, 0644)
+	if err != nil {
+		return fmt.Errorf("could not write capture metadata: %w", err)
+	}
+
+	return s.rotate()
+}
+
+// rotate deletes the oldest captures exceeding the maximum number of captures of their kind.
+// Must be called while holding the lock.
+func (s *Store) rotate() error {
+	if s.maxCaptures == 0 && s.maxTriggered == 0 {
+		return nil
+	}
+	ids, err := s.captureIDs()
+	if err != nil {
+		return err
+	}
+
+	var captured, triggered []string
+	for _, id := range ids {
+		capture, err := s.readCapture(id)
+		if err != nil {
+			return err
+		}
+		if capture.Triggered() {
+			triggered = append(triggered, id)
+		} else {
+			captured = append(captured, id)
+		}
+	}
+
+	err = s.removeOldest(captured, s.maxCaptures)
+	if err != nil {
+		return err
+	}
+	return s.removeOldest(triggered, s.maxTriggered)
+}
+
+// removeOldest deletes the oldest of the given captures, ordered oldest first, exceeding the
+// given limit. A limit of 0 keeps all captures.
+// Must be called while holding the lock.
+func (s *Store) removeOldest(ids []string, limit uint) error {
+	if limit == 0 {
+		return nil
+	}
+	for len(ids) > int(limit) {
+		err := os.RemoveAll(filepath.Join(s.dir, ids[0]))
+		if err != nil {
+			return fmt.Errorf("could not remove capture %s: %w", ids[0], err)
+		}
+		ids = ids[1:]
+	}
+	return nil
+}
+
+// captureIDs returns the IDs of all stored captures, oldest first. Directories without
+// metadata, e.g. of captures still in progress, are skipped.
+// Must be called while holding the lock.
+func (s *Store) captureIDs() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return nil, fmt.Errorf("could not read profile dir: %w", err)
+	}
+	var ids []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(s.dir, entry.Name(), metadataFile)); err != nil {
+			continue
+		}
+		ids = append(ids, entry.Name())
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// List returns all stored captures, most recent first.
+func (s *Store) List() ([]Capture, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids, err := s.captureIDs()
+	if err != nil {
+		return nil, err
+	}
+	captures := make([]Capture, 0, len(ids))
+	for i := len(ids) - 1; i >= 0; i-- {
+		capture, err := s.readCapture(ids[i])
+		if err != nil {
+			return nil, err
+		}
+		captures = append(captures, capture)
+	}
+	return captures, nil
+}
+
+// Get returns the capture with the given ID.
+// Returns ErrCaptureNotFound if no such capture is stored.
+func (s *Store) Get(id string) (Capture, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.readCapture(id)
+}
+
+// ReadProfile returns the content of the named profile of the capture with the given ID.
+// Returns ErrCaptureNotFound if no such capture or profile is stored.
+func (s *Store) ReadProfile(id string, name string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	capture, err := s.readCapture(id)
+	if err != nil {
+		return nil, err
+	}
+	for _, profile := range capture.Profiles {
+		if profile.Name == name {
+			return os.ReadFile(filepath.Join(s.dir, id, profile.File))
+		}
+	}
+	return nil, fmt.Errorf("no %s profile in capture %s: %w", name, id, ErrCaptureNotFound)
+}
+
+// readCapture reads the metadata of the capture with the given ID.
+// Must be called while holding the lock.
+func (s *Store) readCapture(id string) (Capture, error) {
+	// the ID must name a directory within the store
+	if id != filepath.Base(id) || id == "." || id == ".." {
+		return Capture{}, fmt.Errorf("invalid capture id %q: %w", id, ErrCaptureNotFound)
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.dir, id, metadataFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return Capture{}, fmt.Errorf("capture %s: %w", id, ErrCaptureNotFound)
+	}
+	if err != nil {
+		return Capture{}, fmt.Errorf("could not read capture metadata: %w", err)
+	}
+
+	var capture Capture
+	err = json.Unmarshal(data, &capture)
+	if err != nil {
+		return Capture{}, fmt.Errorf("could not decode capture metadata: %w", err)
+	}
+	return capture, nil
+}
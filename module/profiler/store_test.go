@@ -0,0 +1,127 @@
+package profiler_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module/profiler"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// saveCapture stores a capture triggered by the heap threshold with a single heap profile with
+// the given content.
+func saveCapture(t *testing.T, store *profiler.Store, id string, content []byte) {
+	saveTriggeredCapture(t, store, id, profiler.TriggerHeap, content)
+}
+
+// saveTriggeredCapture stores a capture with the given trigger and a single heap profile with the
+// given content.
+func saveTriggeredCapture(t *testing.T, store *profiler.Store, id string, trigger string, content []byte) {
+	dir, err := store.CreateCaptureDir(id)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "heap.pb.gz"), content, 0644))
+
+	err = store.Save(profiler.Capture{
+		ID:       id,
+		Trigger:  trigger,
+		Reason:   "test",
+		Start:    time.Now(),
+		Profiles: []profiler.ProfileFile{{Name: "heap", File: "heap.pb.gz", Size: int64(len(content))}},
+	})
+	require.NoError(t, err)
+}
+
+func TestStore(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		store, err := profiler.NewStore(dir, 0, 2)
+		require.NoError(t, err)
+
+		saveCapture(t, store, "1", []byte("first"))
+		saveCapture(t, store, "2", []byte("second"))
+
+		// captures in progress are not listed
+		_, err = store.CreateCaptureDir("4")
+		require.NoError(t, err)
+
+		captures, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, captures, 2)
+		require.Equal(t, "2", captures[0].ID)
+		require.Equal(t, "1", captures[1].ID)
+		require.Equal(t, profiler.TriggerHeap, captures[0].Trigger)
+
+		content, err := store.ReadProfile("1", "heap")
+		require.NoError(t, err)
+		require.Equal(t, []byte("first"), content)
+
+		// the oldest capture is deleted once the maximum is exceeded
+		saveCapture(t, store, "3", []byte("third"))
+		captures, err = store.List()
+		require.NoError(t, err)
+		require.Len(t, captures, 2)
+		require.Equal(t, "3", captures[0].ID)
+		require.Equal(t, "2", captures[1].ID)
+
+		_, err = store.Get("1")
+		require.ErrorIs(t, err, profiler.ErrCaptureNotFound)
+		_, err = os.Stat(filepath.Join(dir, "1"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+
+		capture, err := store.Get("3")
+		require.NoError(t, err)
+		require.Equal(t, "test", capture.Reason)
+	})
+}
+
+// TestStore_TriggeredCaptures verifies that captures triggered by anomalies are rotated separately
+// from periodic and manual captures.
+func TestStore_TriggeredCaptures(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		store, err := profiler.NewStore(dir, 2, 1)
+		require.NoError(t, err)
+
+		saveTriggeredCapture(t, store, "1", profiler.TriggerInterval, []byte("first"))
+		saveTriggeredCapture(t, store, "2", profiler.TriggerManual, []byte("second"))
+		saveTriggeredCapture(t, store, "3", profiler.TriggerHeap, []byte("third"))
+		saveTriggeredCapture(t, store, "4", profiler.TriggerCPU, []byte("fourth"))
+
+		// the heap capture is rotated out by the cpu capture, but doesn't rotate out the interval capture
+		captures, err := store.List()
+		require.NoError(t, err)
+		require.Len(t, captures, 3)
+		require.Equal(t, "4", captures[0].ID)
+		require.Equal(t, "2", captures[1].ID)
+		require.Equal(t, "1", captures[2].ID)
+
+		saveTriggeredCapture(t, store, "5", profiler.TriggerInterval, []byte("fifth"))
+		captures, err = store.List()
+		require.NoError(t, err)
+		require.Len(t, captures, 3)
+		require.Equal(t, "5", captures[0].ID)
+		require.Equal(t, "4", captures[1].ID)
+		require.Equal(t, "2", captures[2].ID)
+	})
+}
+
+func TestStore_NotFound(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		store, err := profiler.NewStore(dir, 0, 0)
+		require.NoError(t, err)
+		saveCapture(t, store, "1", []byte("first"))
+
+		_, err = store.ReadProfile("1", "cpu")
+		require.ErrorIs(t, err, profiler.ErrCaptureNotFound)
+		_, err = store.ReadProfile("2", "heap")
+		require.ErrorIs(t, err, profiler.ErrCaptureNotFound)
+
+		// IDs must not escape the store directory
+		for _, id := range []string{"..", "../1", "1/..", "."} {
+			_, err = store.Get(id)
+			require.ErrorIs(t, err, profiler.ErrCaptureNotFound, id)
+		}
+	})
+}
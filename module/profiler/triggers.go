@@ -0,0 +1,247 @@
+package profiler
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/metrics"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/shirou/gopsutil/v3/process"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/component"
+	"github.com/onflow/flow-go/module/irrecoverable"
+)
+
+// Names of the triggers of a capture.
+const (
+	TriggerInterval          = "interval"           // periodic run of the profiler
+	TriggerManual            = "manual"             // run requested by an operator
+	TriggerCPU               = "cpu"                // CPU usage crossed the threshold
+	TriggerHeap              = "heap"               // heap size crossed the threshold
+	TriggerGoroutines        = "goroutines"         // goroutine count crossed the threshold
+	TriggerFinalizationStall = "finalization-stall" // finalized height did not progress
+	TriggerSlowBlock         = "slow-block"         // processing a block took longer than the threshold
+)
+
+// ErrCaptureRateLimited is returned when a triggered capture is not started, because another
+// capture was triggered within the cooldown.
+var ErrCaptureRateLimited = errors.New("capture rate limited")
+
+// ErrProfilerDisabled is returned when a triggered capture is not started, because the profiler
+// is disabled.
+var ErrProfilerDisabled = errors.New("profiler is disabled")
+
+// heapMetric is the runtime metric reporting the memory occupied by live and not yet swept heap objects.
+const heapMetric = "/memory/classes/heap/objects:bytes"
+
+// TriggerConfig configures the anomalies which trigger a capture. A zero threshold disables
+// the respective trigger.
+type TriggerConfig struct {
+	CheckInterval      time.Duration // interval to check CPU, heap, goroutines and finalization at
+	CPUThreshold       float64       // CPU usage of the process, in percent of a single core
+	HeapThreshold      uint64        // heap size in bytes
+	GoroutineThreshold uint          // number of goroutines
+	FinalizationStall  time.Duration // maximum duration without new finalized blocks
+	SlowBlockThreshold time.Duration // maximum duration of executing (execution nodes) or processing (consensus nodes) a block
+
+	Cooldown    time.Duration // minimum duration between triggered captures
+	Duration    time.Duration // duration of triggered captures, the profiler duration if zero
+	MaxCaptures uint          // maximum number of triggered captures kept, 0 to keep all
+}
+
+// Capturer captures profiles when triggered by an anomaly.
+type Capturer interface {
+	// TriggerCapture triggers a capture because of the given anomaly.
+	// Returns ErrProfilerDisabled if the profiler is disabled, ErrCaptureRateLimited if the
+	// capture was rate limited, and an error if profiling is already in progress.
+	TriggerCapture(trigger string, reason string) error
+}
+
+// AnomalyMonitor periodically checks the CPU usage, heap size, goroutine count and finalization
+// progress of the node, and triggers a capture when one of them crosses the configured threshold.
+type AnomalyMonitor struct {
+	component.Component
+	log             zerolog.Logger
+	capturer        Capturer
+	config          TriggerConfig
+	finalizedHeight func() (uint64, error) // nil if finalization stalls are not detected
+	proc            *process.Process
+
+	lastHeight   uint64
+	lastProgress time.Time
+}
+
+// NewAnomalyMonitor creates a monitor triggering captures by the given capturer.
+// finalizedHeight returns the latest finalized height, it may be nil to disable the detection
+// of finalization stalls.
+func NewAnomalyMonitor(
+	log zerolog.Logger,
+	capturer Capturer,
+	config TriggerConfig,
+	finalizedHeight func() (uint64, error),
+) (*AnomalyMonitor, error) {
+	m := &AnomalyMonitor{
+		log:             log.With().Str("component", "profiler_anomaly_monitor").Logger(),
+		capturer:        capturer,
+		config:          config,
+		finalizedHeight: finalizedHeight,
+	}
+
+	if config.CPUThreshold > 0 {
+		proc, err := process.NewProcess(int32(os.Getpid()))
+		if err != nil {
+			return nil, fmt.Errorf("could not inspect process: %w", err)
+		}
+		m.proc = proc
+	}
+
+	m.Component = component.NewComponentManagerBuilder().
+		AddWorker(m.loop).
+		Build()
+	return m, nil
+}
+
+func (m *AnomalyMonitor) loop(ctx irrecoverable.SignalerContext, ready component.ReadyFunc) {
+	ready()
+
+	if m.config.CheckInterval == 0 {
+		return
+	}
+
+	m.lastProgress = time.Now()
+	ticker := time.NewTicker(m.config.CheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.check()
+		}
+	}
+}
+
+// check checks all configured thresholds, and triggers a capture for the first anomaly found.
+func (m *AnomalyMonitor) check() {
+	trigger, reason := m.detect()
+	if trigger == "" {
+		return
+	}
+
+	err := m.capturer.TriggerCapture(trigger, reason)
+	if err != nil && !errors.Is(err, ErrCaptureRateLimited) && !errors.Is(err, ErrProfilerDisabled) {
+		m.log.Debug().Err(err).Str("trigger", trigger).Msg("could not trigger capture")
+	}
+}
+
+// detect returns the trigger and reason of the first anomaly found, or an empty trigger if
+// there is none.
+func (m *AnomalyMonitor) detect() (string, string) {
+	if m.proc != nil {
+		// percentage since the previous check
+		percent, err := m.proc.Percent(0)
+		if err != nil {
+			m.log.Debug().Err(err).Msg("could not get cpu usage")
+		} else if percent >= m.config.CPUThreshold {
+			return TriggerCPU, fmt.Sprintf("cpu usage %.1f%% crossed threshold %.1f%%", percent, m.config.CPUThreshold)
+		}
+	}
+
+	if m.config.HeapThreshold > 0 {
+		samples := []metrics.Sample{{Name: heapMetric}}
+		metrics.Read(samples)
+		if samples[0].Value.Kind() == metrics.KindUint64 {
+			heap := samples[0].Value.Uint64()
+			if heap >= m.config.HeapThreshold {
+				return TriggerHeap, fmt.Sprintf("heap size %d bytes crossed threshold %d bytes", heap, m.config.HeapThreshold)
+			}
+		}
+	}
+
+	if m.config.GoroutineThreshold > 0 {
+		count := runtime.NumGoroutine()
+		if uint(count) >= m.config.GoroutineThreshold {
+			return TriggerGoroutines, fmt.Sprintf("goroutine count %d crossed threshold %d", count, m.config.GoroutineThreshold)
+		}
+	}
+
+	if m.finalizedHeight != nil && m.config.FinalizationStall > 0 {
+		height, err := m.finalizedHeight()
+		if err != nil {
+			m.log.Debug().Err(err).Msg("could not get finalized height")
+			return "", ""
+		}
+		now := time.Now()
+		if height != m.lastHeight {
+			m.lastHeight = height
+			m.lastProgress = now
+		} else if stalled := now.Sub(m.lastProgress); stalled >= m.config.FinalizationStall {
+			return TriggerFinalizationStall, fmt.Sprintf("no block finalized for %s since height %d", stalled.Truncate(time.Second), height)
+		}
+	}
+
+	return "", ""
+}
+
+// slowBlockExecutionMetrics triggers a capture when executing a block takes longer than the threshold.
+type slowBlockExecutionMetrics struct {
+	module.ExecutionMetrics
+	capturer  Capturer
+	threshold time.Duration
+}
+
+// NewSlowBlockExecutionMetrics decorates the given metrics to trigger a capture when executing
+// a block takes longer than the threshold. A zero threshold returns the given metrics.
+func NewSlowBlockExecutionMetrics(metrics module.ExecutionMetrics, capturer Capturer, threshold time.Duration) module.ExecutionMetrics {
+	if threshold == 0 {
+		return metrics
+	}
+	return &slowBlockExecutionMetrics{
+		ExecutionMetrics: metrics,
+		capturer:         capturer,
+		threshold:        threshold,
+	}
+}
+
+func (m *slowBlockExecutionMetrics) ExecutionBlockExecuted(dur time.Duration, stats module.ExecutionResultStats) {
+	m.ExecutionMetrics.ExecutionBlockExecuted(dur, stats)
+	if dur >= m.threshold {
+		_ = m.capturer.TriggerCapture(TriggerSlowBlock,
+			fmt.Sprintf("executing block with %d transactions took %s", stats.NumberOfTransactions, dur))
+	}
+}
+
+// slowBlockHotstuffMetrics triggers a capture when processing a block proposal takes longer than the threshold.
+type slowBlockHotstuffMetrics struct {
+	module.HotstuffMetrics
+	capturer  Capturer
+	threshold time.Duration
+	event     string
+}
+
+// NewSlowBlockHotstuffMetrics decorates the given metrics to trigger a capture when HotStuff is
+// busy processing an event of the given type, e.g. a block proposal, for longer than the threshold.
+// A zero threshold returns the given metrics.
+func NewSlowBlockHotstuffMetrics(metrics module.HotstuffMetrics, capturer Capturer, threshold time.Duration, event string) module.HotstuffMetrics {
+	if threshold == 0 {
+		return metrics
+	}
+	return &slowBlockHotstuffMetrics{
+		HotstuffMetrics: metrics,
+		capturer:        capturer,
+		threshold:       threshold,
+		event:           event,
+	}
+}
+
+func (m *slowBlockHotstuffMetrics) HotStuffBusyDuration(duration time.Duration, event string) {
+	m.HotstuffMetrics.HotStuffBusyDuration(duration, event)
+	if event == m.event && duration >= m.threshold {
+		_ = m.capturer.TriggerCapture(TriggerSlowBlock, fmt.Sprintf("hotstuff busy processing %s for %s", event, duration))
+	}
+}
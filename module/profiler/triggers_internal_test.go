@@ -0,0 +1,182 @@
+package profiler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/irrecoverable"
+	"github.com/onflow/flow-go/module/metrics"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// recordingCapturer records the triggered captures.
+type recordingCapturer struct {
+	mu       sync.Mutex
+	triggers []string
+}
+
+func (c *recordingCapturer) TriggerCapture(trigger string, _ string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.triggers = append(c.triggers, trigger)
+	return nil
+}
+
+func TestAnomalyMonitor_Detect(t *testing.T) {
+	t.Run("no anomaly", func(t *testing.T) {
+		m, err := NewAnomalyMonitor(zerolog.Nop(), &recordingCapturer{}, TriggerConfig{
+			HeapThreshold:      1 << 50,
+			GoroutineThreshold: 1 << 20,
+		}, nil)
+		require.NoError(t, err)
+
+		trigger, _ := m.detect()
+		require.Empty(t, trigger)
+	})
+
+	t.Run("heap", func(t *testing.T) {
+		m, err := NewAnomalyMonitor(zerolog.Nop(), &recordingCapturer{}, TriggerConfig{HeapThreshold: 1}, nil)
+		require.NoError(t, err)
+
+		trigger, reason := m.detect()
+		require.Equal(t, TriggerHeap, trigger)
+		require.NotEmpty(t, reason)
+	})
+
+	t.Run("goroutines", func(t *testing.T) {
+		m, err := NewAnomalyMonitor(zerolog.Nop(), &recordingCapturer{}, TriggerConfig{GoroutineThreshold: 1}, nil)
+		require.NoError(t, err)
+
+		trigger, _ := m.detect()
+		require.Equal(t, TriggerGoroutines, trigger)
+	})
+
+	t.Run("finalization stall", func(t *testing.T) {
+		height := uint64(10)
+		m, err := NewAnomalyMonitor(zerolog.Nop(), &recordingCapturer{}, TriggerConfig{FinalizationStall: time.Minute},
+			func() (uint64, error) { return height, nil })
+		require.NoError(t, err)
+
+		// the first observed height counts as progress
+		trigger, _ := m.detect()
+		require.Empty(t, trigger)
+
+		m.lastProgress = time.Now().Add(-2 * time.Minute)
+		trigger, _ = m.detect()
+		require.Equal(t, TriggerFinalizationStall, trigger)
+
+		height++
+		trigger, _ = m.detect()
+		require.Empty(t, trigger)
+	})
+
+	t.Run("finalized height unavailable", func(t *testing.T) {
+		m, err := NewAnomalyMonitor(zerolog.Nop(), &recordingCapturer{}, TriggerConfig{FinalizationStall: time.Nanosecond},
+			func() (uint64, error) { return 0, errors.New("unavailable") })
+		require.NoError(t, err)
+
+		trigger, _ := m.detect()
+		require.Empty(t, trigger)
+	})
+}
+
+// TestAnomalyMonitor_Loop verifies that the monitor triggers captures while running.
+func TestAnomalyMonitor_Loop(t *testing.T) {
+	capturer := &recordingCapturer{}
+	m, err := NewAnomalyMonitor(zerolog.Nop(), capturer, TriggerConfig{
+		CheckInterval:      10 * time.Millisecond,
+		GoroutineThreshold: 1,
+	}, nil)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.Start(irrecoverable.NewMockSignalerContext(t, ctx))
+	unittest.RequireCloseBefore(t, m.Ready(), time.Second, "monitor not ready")
+
+	require.Eventually(t, func() bool {
+		capturer.mu.Lock()
+		defer capturer.mu.Unlock()
+		return len(capturer.triggers) > 0 && capturer.triggers[0] == TriggerGoroutines
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+	unittest.RequireCloseBefore(t, m.Done(), time.Second, "monitor not done")
+}
+
+func TestSlowBlockMetrics(t *testing.T) {
+	capturer := &recordingCapturer{}
+
+	exeMetrics := NewSlowBlockExecutionMetrics(metrics.NewNoopCollector(), capturer, time.Second)
+	exeMetrics.ExecutionBlockExecuted(100*time.Millisecond, module.ExecutionResultStats{})
+	require.Empty(t, capturer.triggers)
+	exeMetrics.ExecutionBlockExecuted(2*time.Second, module.ExecutionResultStats{})
+	require.Equal(t, []string{TriggerSlowBlock}, capturer.triggers)
+
+	hotstuffMetrics := NewSlowBlockHotstuffMetrics(metrics.NewNoopCollector(), capturer, time.Second, metrics.HotstuffEventTypeOnProposal)
+	hotstuffMetrics.HotStuffBusyDuration(2*time.Second, metrics.HotstuffEventTypeTimeout)
+	hotstuffMetrics.HotStuffBusyDuration(100*time.Millisecond, metrics.HotstuffEventTypeOnProposal)
+	require.Len(t, capturer.triggers, 1)
+	hotstuffMetrics.HotStuffBusyDuration(2*time.Second, metrics.HotstuffEventTypeOnProposal)
+	require.Len(t, capturer.triggers, 2)
+
+	// a zero threshold disables the trigger
+	noop := metrics.NewNoopCollector()
+	require.Equal(t, module.ExecutionMetrics(noop), NewSlowBlockExecutionMetrics(noop, capturer, 0))
+}
+
+// TestTriggerCapture_Cooldown verifies that triggered captures are rate limited.
+func TestTriggerCapture_Cooldown(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		p, err := New(zerolog.Nop(), &NoopUploader{}, ProfilerConfig{
+			Enabled:  true,
+			Dir:      dir,
+			Interval: time.Hour,
+			Duration: 10 * time.Millisecond,
+			Triggers: TriggerConfig{Cooldown: time.Hour},
+		})
+		require.NoError(t, err)
+		unittest.AssertClosesBefore(t, p.Ready(), 5*time.Second)
+
+		require.Eventually(t, func() bool {
+			return p.TriggerCapture(TriggerHeap, "test") == nil
+		}, time.Second, 10*time.Millisecond)
+		require.ErrorIs(t, p.TriggerCapture(TriggerGoroutines, "test"), ErrCaptureRateLimited)
+
+		require.Eventually(t, func() bool {
+			captures, err := p.Store().List()
+			require.NoError(t, err)
+			return len(captures) == 1 && captures[0].Trigger == TriggerHeap && captures[0].Reason == "test"
+		}, 5*time.Second, 10*time.Millisecond)
+
+		unittest.AssertClosesBefore(t, p.Done(), 5*time.Second)
+	})
+}
+
+// TestTriggerCapture_Disabled verifies that no capture is triggered while the profiler is disabled.
+func TestTriggerCapture_Disabled(t *testing.T) {
+	unittest.RunWithTempDir(t, func(dir string) {
+		p, err := New(zerolog.Nop(), &NoopUploader{}, ProfilerConfig{
+			Dir:      dir,
+			Interval: time.Hour,
+			Duration: 10 * time.Millisecond,
+		})
+		require.NoError(t, err)
+		unittest.AssertClosesBefore(t, p.Ready(), 5*time.Second)
+
+		require.ErrorIs(t, p.TriggerCapture(TriggerHeap, "test"), ErrProfilerDisabled)
+
+		require.NoError(t, p.SetEnabled(true))
+		require.Eventually(t, func() bool {
+			return p.TriggerCapture(TriggerHeap, "test") == nil
+		}, time.Second, 10*time.Millisecond)
+
+		unittest.AssertClosesBefore(t, p.Done(), 5*time.Second)
+	})
+}
@@ -0,0 +1,15 @@
+//go:build relic
+// +build relic
+
+package remotesigner
+
+import (
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/module/remotesigner/signer"
+)
+
+// signFunctions are the signing functions, other than the Sign method of the private key, which
+// can be passed to the SignFunc method of the remote Local.
+var signFunctions = map[signer.Function]signFunc{
+	signer.Function_SPOCK_PROVE: crypto.SPOCKProve,
+}
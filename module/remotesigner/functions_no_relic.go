@@ -0,0 +1,13 @@
+//go:build !relic
+// +build !relic
+
+package remotesigner
+
+import (
+	"github.com/onflow/flow-go/module/remotesigner/signer"
+)
+
+// signFunctions are the signing functions, other than the Sign method of the private key, which
+// can be passed to the SignFunc method of the remote Local. crypto.SPOCKProve requires the relic
+// build tag.
+var signFunctions = map[signer.Function]signFunc{}
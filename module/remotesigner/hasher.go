@@ -0,0 +1,117 @@
+package remotesigner
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/module/remotesigner/signer"
+	msig "github.com/onflow/flow-go/module/signature"
+)
+
+// DomainTags are the domain separation tags of the KMAC128 hashers which can be used with the
+// remote signer. KMAC128 hashers don't expose their domain tag, so the tag of a hasher is resolved
+// by comparing its output with the output of the hashers of all known tags.
+var DomainTags = []string{
+	msig.RandomBeaconTag,
+	msig.ConsensusVoteTag,
+	msig.CollectorVoteTag,
+	msig.ExecutionReceiptTag,
+	msig.ResultApprovalTag,
+	msig.SPOCKTag,
+	msig.DKGMessageTag,
+}
+
+// tagProbe is the message hashed to resolve the domain tag of KMAC128 hashers.
+var tagProbe = []byte("FLOW-REMOTE-SIGNER-TAG-PROBE")
+
+// hasherEncoder encodes hashers to be sent to the remote signer.
+// hasherEncoder is safe for concurrent use, the hashers themselves are not.
+type hasherEncoder struct {
+	newBLSHasher func(tag string) hash.Hasher
+
+	once         sync.Once
+	fingerprints map[string]string // fingerprint of the hasher of each domain tag -> domain tag
+
+	mu   sync.RWMutex
+	tags map[hash.Hasher]string // cache of the resolved domain tags
+}
+
+func newHasherEncoder(newBLSHasher func(tag string) hash.Hasher) *hasherEncoder {
+	return &hasherEncoder{
+		newBLSHasher: newBLSHasher,
+		tags:         make(map[hash.Hasher]string),
+	}
+}
+
+// encode returns the protobuf representation of the given hasher.
+// Returns an error if the hasher is a KMAC128 hasher with an unknown domain tag.
+func (e *hasherEncoder) encode(hasher hash.Hasher) (*signer.Hasher, error) {
+	encoded := &signer.Hasher{Algorithm: uint32(hasher.Algorithm())}
+	if hasher.Algorithm() != hash.KMAC128 {
+		return encoded, nil
+	}
+
+	tag, err := e.tag(hasher)
+	if err != nil {
+		return nil, err
+	}
+	encoded.DomainTag = tag
+	return encoded, nil
+}
+
+func (e *hasherEncoder) tag(hasher hash.Hasher) (string, error) {
+	e.mu.RLock()
+	tag, ok := e.tags[hasher]
+	e.mu.RUnlock()
+	if ok {
+		return tag, nil
+	}
+
+	// the hashers are only created once needed, since BLS hashers require the relic build tag
+	e.once.Do(func() {
+		e.fingerprints = make(map[string]string, len(DomainTags))
+		for _, tag := range DomainTags {
+			e.fingerprints[string(e.newBLSHasher(tag).ComputeHash(tagProbe))] = tag
+		}
+	})
+
+	tag, ok = e.fingerprints[string(hasher.ComputeHash(tagProbe))]
+	if !ok {
+		return "", fmt.Errorf("hasher with unknown domain tag can't be used with the remote signer")
+	}
+
+	e.mu.Lock()
+	e.tags[hasher] = tag
+	e.mu.Unlock()
+	return tag, nil
+}
+
+// decodeHasher returns the hasher of the given protobuf representation.
+func decodeHasher(encoded *signer.Hasher, newBLSHasher func(tag string) hash.Hasher) (hash.Hasher, error) {
+	if encoded == nil {
+		return nil, fmt.Errorf("missing hasher")
+	}
+
+	switch hash.HashingAlgorithm(encoded.Algorithm) {
+	case hash.SHA2_256:
+		return hash.NewSHA2_256(), nil
+	case hash.SHA2_384:
+		return hash.NewSHA2_384(), nil
+	case hash.SHA3_256:
+		return hash.NewSHA3_256(), nil
+	case hash.SHA3_384:
+		return hash.NewSHA3_384(), nil
+	case hash.Keccak_256:
+		return hash.NewKeccak_256(), nil
+	case hash.KMAC128:
+		for _, tag := range DomainTags {
+			if encoded.DomainTag == tag {
+				return newBLSHasher(tag), nil
+			}
+		}
+		return nil, fmt.Errorf("unknown domain tag %q", encoded.DomainTag)
+	default:
+		return nil, fmt.Errorf("unsupported hashing algorithm %d", encoded.Algorithm)
+	}
+}
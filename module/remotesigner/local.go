@@ -0,0 +1,148 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/model/flow/filter"
+	"github.com/onflow/flow-go/module"
+	"github.com/onflow/flow-go/module/remotesigner/signer"
+	msig "github.com/onflow/flow-go/module/signature"
+)
+
+// requestTimeout is the timeout of the requests to the remote signer.
+const requestTimeout = 10 * time.Second
+
+// Local is a module.Local implementation which doesn't hold the staking key of the node, but
+// delegates signing to a remote signer, e.g. a Server running in a separate process.
+//
+// The remote signer can't run arbitrary signing functions, so SignFunc only supports the
+// functions the remote signer knows, i.e. crypto.SPOCKProve.
+type Local struct {
+	me      *flow.Identity
+	client  signer.RemoteSignerClient
+	hashers *hasherEncoder
+}
+
+var _ module.Local = (*Local)(nil)
+var _ module.VoteSigner = (*Local)(nil)
+
+// New creates a Local delegating signing to the given remote signer.
+// Returns an error if the remote signer is not reachable, or doesn't hold the staking key of the
+// given identity.
+func New(id *flow.Identity, client signer.RemoteSignerClient) (*Local, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	keys, err := client.PublicKeys(ctx, &signer.PublicKeysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("could not get public keys of remote signer: %w", err)
+	}
+	if !bytes.Equal(keys.GetStakingKey(), id.StakingPubKey.Encode()) {
+		return nil, fmt.Errorf("cannot initialize with mismatching keys, expect %v, but remote signer holds %x",
+			id.StakingPubKey, keys.GetStakingKey())
+	}
+
+	l := &Local{
+		me:      id,
+		client:  client,
+		hashers: newHasherEncoder(msig.NewBLSHasher),
+	}
+	return l, nil
+}
+
+func (l *Local) NodeID() flow.Identifier {
+	return l.me.NodeID
+}
+
+func (l *Local) Address() string {
+	return l.me.Address
+}
+
+func (l *Local) Sign(msg []byte, hasher hash.Hasher) (crypto.Signature, error) {
+	return l.sign(msg, hasher, signer.Function_SIGN)
+}
+
+// SignVote signs the vote message for the given view and block with the staking key. The remote
+// signer refuses to sign consensus votes conflicting with votes it signed before.
+func (l *Local) SignVote(view uint64, blockID flow.Identifier, hasher hash.Hasher) (crypto.Signature, error) {
+	encoded, err := l.hashers.encode(hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := l.client.SignVote(ctx, &signer.SignVoteRequest{
+		View:    view,
+		BlockID: blockID[:],
+		Hasher:  encoded,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote signer could not sign vote for block %v at view %d: %w", blockID, view, err)
+	}
+	return resp.GetSignature(), nil
+}
+
+// ProtectVote records the consensus vote for the given view and block with the remote signer,
+// before the node signs it with its random beacon key. The remote signer refuses votes conflicting
+// with votes it signed or recorded before.
+func (l *Local) ProtectVote(view uint64, blockID flow.Identifier) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	_, err := l.client.ProtectVote(ctx, &signer.ProtectVoteRequest{
+		View:    view,
+		BlockID: blockID[:],
+	})
+	if err != nil {
+		return fmt.Errorf("remote signer could not protect vote for block %v at view %d: %w", blockID, view, err)
+	}
+	return nil
+}
+
+func (l *Local) NotMeFilter() flow.IdentityFilter {
+	return filter.Not(filter.HasNodeID(l.NodeID()))
+}
+
+// SignFunc provides a signature oracle that given a message, a hasher, and a signing function, it
+// generates and returns a signature over the message using the node's private key
+// as well as the input hasher by invoking the given signing function on the remote signer.
+// Returns an error if the remote signer doesn't support the given signing function.
+func (l *Local) SignFunc(data []byte, hasher hash.Hasher, f func(crypto.PrivateKey, []byte, hash.Hasher) (crypto.Signature,
+	error)) (crypto.Signature, error) {
+	for function, known := range signFunctions {
+		if reflect.ValueOf(f).Pointer() == reflect.ValueOf(known).Pointer() {
+			return l.sign(data, hasher, function)
+		}
+	}
+	return nil, fmt.Errorf("signing function is not supported by the remote signer")
+}
+
+func (l *Local) sign(msg []byte, hasher hash.Hasher, function signer.Function) (crypto.Signature, error) {
+	encoded, err := l.hashers.encode(hasher)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := l.client.Sign(ctx, &signer.SignRequest{
+		Key:      signer.KeyType_STAKING,
+		Message:  msg,
+		Hasher:   encoded,
+		Function: function,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote signer could not sign message: %w", err)
+	}
+	return resp.GetSignature(), nil
+}
@@ -0,0 +1,318 @@
+package remotesigner
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+	msig "github.com/onflow/flow-go/module/signature"
+	"github.com/onflow/flow-go/network/p2p/keyutils"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// testBLSHasher returns a KMAC128 hasher for the given tag. BLS hashers require the relic build
+// tag, so the tests use ECDSA keys with KMAC128 hashers instead.
+func testBLSHasher(tag string) hash.Hasher {
+	hasher, err := hash.NewKMAC_128([]byte(tag), []byte("H2C"), 128)
+	if err != nil {
+		panic(err)
+	}
+	return hasher
+}
+
+// identityFixture returns an identity with the given staking and networking keys.
+// unittest.IdentityFixture generates BLS keys, which require the relic build tag.
+func identityFixture(stakingKey crypto.PublicKey, networkingKey crypto.PublicKey) *flow.Identity {
+	return &flow.Identity{
+		NodeID:        unittest.IdentifierFixture(),
+		Address:       "localhost:3569",
+		Role:          flow.RoleConsensus,
+		StakingPubKey: stakingKey,
+		NetworkPubKey: networkingKey,
+	}
+}
+
+// remoteSigner is a remote signer, and a Local connected to it via a unix socket.
+type remoteSigner struct {
+	key           crypto.PrivateKey
+	networkingKey crypto.PrivateKey
+	me            *flow.Identity
+	local         *Local
+	stop          func()
+}
+
+func startRemoteSigner(t *testing.T) *remoteSigner {
+	dir := t.TempDir()
+	key := unittest.NetworkingPrivKeyFixture()
+	networkingKey := unittest.NetworkingPrivKeyFixture()
+	me := identityFixture(key.PublicKey(), networkingKey.PublicKey())
+
+	votes, err := NewVoteProtection(filepath.Join(dir, "signed-votes.json"))
+	require.NoError(t, err)
+
+	certs := generateCerts(t, dir)
+	serverCreds, err := ServerCredentials(certs.serverCert, certs.serverKey, certs.ca)
+	require.NoError(t, err)
+
+	server := NewServer(zerolog.Nop(), key, networkingKey, votes)
+	server.newBLSHasher = testBLSHasher
+
+	address := "unix://" + filepath.Join(dir, "signer.sock")
+	listener, err := Listen(address)
+	require.NoError(t, err)
+	grpcServer := NewGRPCServer(server, serverCreds)
+	go func() {
+		_ = grpcServer.Serve(listener)
+	}()
+
+	clientCreds, err := ClientCredentials(certs.clientCert, certs.clientKey, certs.ca, "")
+	require.NoError(t, err)
+	client, conn, err := Dial(address, clientCreds)
+	require.NoError(t, err)
+
+	local, err := New(me, client)
+	require.NoError(t, err)
+	local.hashers = newHasherEncoder(testBLSHasher)
+
+	return &remoteSigner{
+		key:           key,
+		networkingKey: networkingKey,
+		me:            me,
+		local:         local,
+		stop: func() {
+			_ = conn.Close()
+			grpcServer.Stop()
+		},
+	}
+}
+
+// TestRemoteSign tests signing messages with the remote signer.
+func TestRemoteSign(t *testing.T) {
+	signer := startRemoteSigner(t)
+	defer signer.stop()
+
+	assert.Equal(t, signer.me.NodeID, signer.local.NodeID())
+	assert.Equal(t, signer.me.Address, signer.local.Address())
+
+	t.Run("sign", func(t *testing.T) {
+		msg := []byte("message")
+		for _, hasher := range []hash.Hasher{hash.NewSHA3_256(), testBLSHasher(msig.ResultApprovalTag)} {
+			sig, err := signer.local.Sign(msg, hasher)
+			require.NoError(t, err)
+
+			valid, err := signer.key.PublicKey().Verify(sig, msg, hasher)
+			require.NoError(t, err)
+			assert.True(t, valid)
+		}
+	})
+
+	t.Run("unknown domain tag", func(t *testing.T) {
+		_, err := signer.local.Sign([]byte("message"), testBLSHasher("FLOW-Unknown-V00-CS00-with-"))
+		assert.Error(t, err)
+	})
+
+	t.Run("consensus vote", func(t *testing.T) {
+		// consensus votes can't bypass the vote protection
+		_, err := signer.local.Sign([]byte("message"), testBLSHasher(msig.ConsensusVoteTag))
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported signing function", func(t *testing.T) {
+		_, err := signer.local.SignFunc([]byte("message"), hash.NewSHA3_256(),
+			func(sk crypto.PrivateKey, msg []byte, hasher hash.Hasher) (crypto.Signature, error) {
+				return sk.Sign(msg, hasher)
+			})
+		assert.Error(t, err)
+	})
+}
+
+// TestRemoteSignVote tests that the remote signer refuses to sign conflicting consensus votes.
+func TestRemoteSignVote(t *testing.T) {
+	signer := startRemoteSigner(t)
+	defer signer.stop()
+
+	hasher := testBLSHasher(msig.ConsensusVoteTag)
+	blockID := unittest.IdentifierFixture()
+
+	sig, err := signer.local.SignVote(10, blockID, hasher)
+	require.NoError(t, err)
+	valid, err := signer.key.PublicKey().Verify(sig, verification.MakeVoteMessage(10, blockID), hasher)
+	require.NoError(t, err)
+	assert.True(t, valid)
+
+	_, err = signer.local.SignVote(10, unittest.IdentifierFixture(), hasher)
+	assert.Error(t, err)
+	_, err = signer.local.SignVote(9, unittest.IdentifierFixture(), hasher)
+	assert.Error(t, err)
+
+	_, err = signer.local.SignVote(11, unittest.IdentifierFixture(), hasher)
+	require.NoError(t, err)
+
+	// collector votes are not protected, as cluster views restart every epoch
+	collectorHasher := testBLSHasher(msig.CollectorVoteTag)
+	_, err = signer.local.SignVote(1, unittest.IdentifierFixture(), collectorHasher)
+	require.NoError(t, err)
+	_, err = signer.local.SignVote(1, unittest.IdentifierFixture(), collectorHasher)
+	require.NoError(t, err)
+
+	// votes must be hashed with a vote tag
+	_, err = signer.local.SignVote(12, unittest.IdentifierFixture(), testBLSHasher(msig.ResultApprovalTag))
+	assert.Error(t, err)
+}
+
+// TestRemoteProtectVote tests that the remote signer refuses to protect and sign votes conflicting
+// with votes which the node signed itself with its random beacon key.
+func TestRemoteProtectVote(t *testing.T) {
+	signer := startRemoteSigner(t)
+	defer signer.stop()
+
+	hasher := testBLSHasher(msig.ConsensusVoteTag)
+	blockID := unittest.IdentifierFixture()
+
+	require.NoError(t, signer.local.ProtectVote(10, blockID))
+	// protecting the same vote again is allowed, e.g. for the proposal and the vote of a block
+	require.NoError(t, signer.local.ProtectVote(10, blockID))
+
+	// conflicting votes are refused, whether signed by the signer or by the node
+	assert.Error(t, signer.local.ProtectVote(10, unittest.IdentifierFixture()))
+	assert.Error(t, signer.local.ProtectVote(9, unittest.IdentifierFixture()))
+	_, err := signer.local.SignVote(10, unittest.IdentifierFixture(), hasher)
+	assert.Error(t, err)
+
+	_, err = signer.local.SignVote(11, blockID, hasher)
+	require.NoError(t, err)
+	assert.Error(t, signer.local.ProtectVote(11, unittest.IdentifierFixture()))
+}
+
+// TestRemoteSignerKeyMismatch tests that a Local can't be created for another node's identity.
+func TestRemoteSignerKeyMismatch(t *testing.T) {
+	signer := startRemoteSigner(t)
+	defer signer.stop()
+
+	other := identityFixture(unittest.NetworkingPrivKeyFixture().PublicKey(), unittest.NetworkingPrivKeyFixture().PublicKey())
+	_, err := New(other, signer.local.client)
+	assert.Error(t, err)
+	_, err = NewNetworkingKey(other, signer.local.client)
+	assert.Error(t, err)
+}
+
+// TestRemoteNetworkingKey tests signing with the networking key held by the remote signer, both
+// directly and via the libp2p key it is converted to.
+func TestRemoteNetworkingKey(t *testing.T) {
+	signer := startRemoteSigner(t)
+	defer signer.stop()
+
+	key, err := NewNetworkingKey(signer.me, signer.local.client)
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey().Equals(signer.networkingKey.PublicKey()))
+	assert.Nil(t, key.Encode())
+
+	t.Run("sign", func(t *testing.T) {
+		msg := []byte("message")
+		sig, err := key.Sign(msg, hash.NewSHA2_256())
+		require.NoError(t, err)
+
+		valid, err := signer.networkingKey.PublicKey().Verify(sig, msg, hash.NewSHA2_256())
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		_, err = key.Sign(msg, testBLSHasher(msig.ResultApprovalTag))
+		assert.Error(t, err)
+	})
+
+	t.Run("libp2p", func(t *testing.T) {
+		lkey, err := keyutils.LibP2PPrivKeyFromFlow(key)
+		require.NoError(t, err)
+		lpublic, err := keyutils.LibP2PPublicKeyFromFlow(signer.networkingKey.PublicKey())
+		require.NoError(t, err)
+		assert.True(t, lpublic.Equals(lkey.GetPublic()))
+
+		msg := []byte("handshake")
+		sig, err := lkey.Sign(msg)
+		require.NoError(t, err)
+		valid, err := lpublic.Verify(msg, sig)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+}
+
+type certFiles struct {
+	ca         string
+	serverCert string
+	serverKey  string
+	clientCert string
+	clientKey  string
+}
+
+// generateCerts generates a CA, and server and client certificates signed by it, in the given directory.
+func generateCerts(t *testing.T, dir string) certFiles {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{Organization: []string{"Flow Test CA"}},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	files := certFiles{ca: filepath.Join(dir, "ca.pem")}
+	writePEM(t, files.ca, "CERTIFICATE", caDER)
+
+	issue := func(serial int64, usage x509.ExtKeyUsage, certFile string, keyFile string) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{Organization: []string{"Flow Test"}},
+			DNSNames:     []string{"localhost"},
+			NotBefore:    time.Now(),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+
+		writePEM(t, certFile, "CERTIFICATE", der)
+		writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	}
+
+	files.serverCert, files.serverKey = filepath.Join(dir, "server.pem"), filepath.Join(dir, "server-key.pem")
+	issue(2, x509.ExtKeyUsageServerAuth, files.serverCert, files.serverKey)
+	files.clientCert, files.clientKey = filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	issue(3, x509.ExtKeyUsageClientAuth, files.clientCert, files.clientKey)
+
+	return files
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	var buf bytes.Buffer
+	require.NoError(t, pem.Encode(&buf, &pem.Block{Type: blockType, Bytes: der}))
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0600))
+}
@@ -0,0 +1,105 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/remotesigner/signer"
+	"github.com/onflow/flow-go/network/p2p/keyutils"
+)
+
+// NetworkingKey is the networking key of a node held by a remote signer. It is a crypto.PrivateKey
+// which delegates signing to the remote signer, so that libp2p can use it for its handshakes,
+// see keyutils.RemotePrivateKey. The key material is not available, Encode returns nil.
+type NetworkingKey struct {
+	publicKey crypto.PublicKey
+	client    signer.RemoteSignerClient
+}
+
+var _ keyutils.RemotePrivateKey = (*NetworkingKey)(nil)
+
+// NewNetworkingKey creates a networking key delegating signing to the given remote signer.
+// Returns an error if the remote signer is not reachable, or doesn't hold the networking key of
+// the given identity.
+func NewNetworkingKey(id *flow.Identity, client signer.RemoteSignerClient) (*NetworkingKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	keys, err := client.PublicKeys(ctx, &signer.PublicKeysRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("could not get public keys of remote signer: %w", err)
+	}
+	if !bytes.Equal(keys.GetNetworkingKey(), id.NetworkPubKey.Encode()) {
+		return nil, fmt.Errorf("cannot initialize with mismatching keys, expect %v, but remote signer holds %x",
+			id.NetworkPubKey, keys.GetNetworkingKey())
+	}
+
+	k := &NetworkingKey{
+		publicKey: id.NetworkPubKey,
+		client:    client,
+	}
+	return k, nil
+}
+
+// Remote marks the key as held by the remote signer.
+func (k *NetworkingKey) Remote() {}
+
+func (k *NetworkingKey) Algorithm() crypto.SigningAlgorithm {
+	return k.publicKey.Algorithm()
+}
+
+func (k *NetworkingKey) Size() int {
+	switch k.Algorithm() {
+	case crypto.ECDSAP256:
+		return crypto.PrKeyLenECDSAP256
+	case crypto.ECDSASecp256k1:
+		return crypto.PrKeyLenECDSASecp256k1
+	default:
+		return 0
+	}
+}
+
+func (k *NetworkingKey) String() string {
+	return fmt.Sprintf("remote networking key %v", k.publicKey)
+}
+
+// Sign signs the message with the networking key held by the remote signer.
+func (k *NetworkingKey) Sign(msg []byte, hasher hash.Hasher) (crypto.Signature, error) {
+	// networking keys are ECDSA keys, which are never used with the domain tagged KMAC128 hashers
+	if hasher.Algorithm() == hash.KMAC128 {
+		return nil, fmt.Errorf("networking key can't be used with KMAC128 hashers")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+
+	resp, err := k.client.Sign(ctx, &signer.SignRequest{
+		Key:      signer.KeyType_NETWORKING,
+		Message:  msg,
+		Hasher:   &signer.Hasher{Algorithm: uint32(hasher.Algorithm())},
+		Function: signer.Function_SIGN,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remote signer could not sign message with networking key: %w", err)
+	}
+	return resp.GetSignature(), nil
+}
+
+func (k *NetworkingKey) PublicKey() crypto.PublicKey {
+	return k.publicKey
+}
+
+// Encode returns nil, since the key material is held by the remote signer.
+func (k *NetworkingKey) Encode() []byte {
+	return nil
+}
+
+// Equals returns true if the given key is a remote networking key with the same public key.
+func (k *NetworkingKey) Equals(other crypto.PrivateKey) bool {
+	remote, ok := other.(*NetworkingKey)
+	return ok && k.publicKey.Equals(remote.publicKey)
+}
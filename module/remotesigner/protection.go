@@ -0,0 +1,135 @@
+package remotesigner
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ErrConflictingVote is returned when signing a vote which conflicts with a previously signed vote.
+var ErrConflictingVote = errors.New("conflicting vote")
+
+// signedVote is the persisted record of the vote with the highest view signed so far.
+type signedVote struct {
+	View    uint64          `json:"view"`
+	BlockID flow.Identifier `json:"block_id"`
+}
+
+// VoteProtection keeps the remote signer from signing conflicting consensus votes, which could get
+// the node slashed. A vote conflicts with the previously signed votes if
+//   - it is for the same view as a previous vote, but for a different block, or
+//   - it is for a lower view than a previous vote. HotStuff never votes for a lower view than a
+//     previous vote, so such a request indicates that the node was restored from a backup, or that
+//     a duplicate instance of the node is running.
+//
+// The vote with the highest view is persisted in a file before the vote is signed, so that the
+// protection holds across restarts of the signer.
+// VoteProtection is safe for concurrent use.
+type VoteProtection struct {
+	mu   sync.Mutex
+	path string
+	last *signedVote // nil if no vote was signed yet
+}
+
+// NewVoteProtection creates a vote protection persisting the signed votes in the file at the
+// given path. If the file exists, the previously signed votes are read from it.
+func NewVoteProtection(path string) (*VoteProtection, error) {
+	p := &VoteProtection{
+		path: path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read signed votes: %w", err)
+	}
+
+	var last signedVote
+	err = json.Unmarshal(data, &last)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode signed votes: %w", err)
+	}
+	p.last = &last
+	return p, nil
+}
+
+// LastView returns the highest view a vote was signed for. The boolean return value is false if no
+// vote was signed yet.
+func (p *VoteProtection) LastView() (uint64, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.last == nil {
+		return 0, false
+	}
+	return p.last.View, true
+}
+
+// Protect records the vote for the given view and block, and calls the given function to sign it,
+// unless the vote conflicts with a previously signed vote.
+// Expected errors during normal operations:
+//   - ErrConflictingVote if the vote conflicts with a previously signed vote
+func (p *VoteProtection) Protect(view uint64, blockID flow.Identifier, sign func() error) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.last != nil {
+		if view < p.last.View {
+			return fmt.Errorf("vote for view %d is below the last signed view %d: %w", view, p.last.View, ErrConflictingVote)
+		}
+		if view == p.last.View && blockID != p.last.BlockID {
+			return fmt.Errorf("vote for block %v at view %d conflicts with signed vote for block %v: %w",
+				blockID, view, p.last.BlockID, ErrConflictingVote)
+		}
+	}
+
+	if p.last == nil || view > p.last.View {
+		err := p.persist(&signedVote{View: view, BlockID: blockID})
+		if err != nil {
+			return fmt.Errorf("could not persist signed vote: %w", err)
+		}
+	}
+
+	return sign()
+}
+
+// persist atomically replaces the persisted vote with the given vote.
+func (p *VoteProtection) persist(vote *signedVote) error {
+	data, err := json.Marshal(vote)
+	if err != nil {
+		return fmt.Errorf("could not encode signed vote: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(p.path), filepath.Base(p.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("could not create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+	closeErr := tmp.Close()
+	if err != nil {
+		return fmt.Errorf("could not write temporary file: %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("could not close temporary file: %w", closeErr)
+	}
+
+	err = os.Rename(tmp.Name(), p.path)
+	if err != nil {
+		return fmt.Errorf("could not replace signed votes: %w", err)
+	}
+
+	p.last = vote
+	return nil
+}
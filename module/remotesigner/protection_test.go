@@ -0,0 +1,60 @@
+package remotesigner
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+// TestVoteProtection tests that conflicting votes are refused, also after a restart.
+func TestVoteProtection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signed-votes.json")
+	votes, err := NewVoteProtection(path)
+	require.NoError(t, err)
+
+	_, ok := votes.LastView()
+	assert.False(t, ok)
+
+	signed := 0
+	sign := func() error {
+		signed++
+		return nil
+	}
+
+	blockID := unittest.IdentifierFixture()
+	require.NoError(t, votes.Protect(10, blockID, sign))
+	// signing the same vote again is fine, e.g. after a crash before the vote was sent
+	require.NoError(t, votes.Protect(10, blockID, sign))
+	assert.Equal(t, 2, signed)
+
+	// a different block at the same view is refused
+	err = votes.Protect(10, unittest.IdentifierFixture(), sign)
+	assert.ErrorIs(t, err, ErrConflictingVote)
+	// a lower view is refused
+	err = votes.Protect(9, unittest.IdentifierFixture(), sign)
+	assert.ErrorIs(t, err, ErrConflictingVote)
+	assert.Equal(t, 2, signed)
+
+	// the protection holds after a restart
+	votes, err = NewVoteProtection(path)
+	require.NoError(t, err)
+	view, ok := votes.LastView()
+	require.True(t, ok)
+	assert.Equal(t, uint64(10), view)
+
+	err = votes.Protect(10, unittest.IdentifierFixture(), sign)
+	assert.ErrorIs(t, err, ErrConflictingVote)
+	require.NoError(t, votes.Protect(10, blockID, sign))
+
+	// a higher view is fine, and becomes the last signed view even if signing fails
+	signErr := errors.New("signing failed")
+	err = votes.Protect(11, unittest.IdentifierFixture(), func() error { return signErr })
+	assert.ErrorIs(t, err, signErr)
+	view, _ = votes.LastView()
+	assert.Equal(t, uint64(11), view)
+}
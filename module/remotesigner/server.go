@@ -0,0 +1,183 @@
+package remotesigner
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/verification"
+	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/module/remotesigner/signer"
+	msig "github.com/onflow/flow-go/module/signature"
+)
+
+// signFunc is the signature of the signing functions of module.Local's SignFunc.
+type signFunc func(crypto.PrivateKey, []byte, hash.Hasher) (crypto.Signature, error)
+
+// Server is a reference implementation of the remote signer, holding the staking and networking
+// keys of a node and serving signatures over the RemoteSigner gRPC API.
+//
+// Consensus votes are only signed if they don't conflict with previously signed votes, see
+// VoteProtection. Votes which the node signs itself with its random beacon key are recorded
+// with ProtectVote, so that they are covered by the same protection. Collector votes are not protected, since the views of the cluster consensus
+// restart with every epoch, and the signer can't tell the clusters apart.
+type Server struct {
+	signer.UnimplementedRemoteSignerServer
+	log          zerolog.Logger
+	keys         map[signer.KeyType]crypto.PrivateKey
+	votes        *VoteProtection
+	newBLSHasher func(tag string) hash.Hasher
+}
+
+var _ signer.RemoteSignerServer = (*Server)(nil)
+
+// NewServer creates a remote signer for the given keys. Either key can be nil, if the signer
+// doesn't hold it.
+func NewServer(
+	log zerolog.Logger,
+	stakingKey crypto.PrivateKey,
+	networkingKey crypto.PrivateKey,
+	votes *VoteProtection,
+) *Server {
+	keys := make(map[signer.KeyType]crypto.PrivateKey)
+	if stakingKey != nil {
+		keys[signer.KeyType_STAKING] = stakingKey
+	}
+	if networkingKey != nil {
+		keys[signer.KeyType_NETWORKING] = networkingKey
+	}
+
+	return &Server{
+		log:          log.With().Str("component", "remote_signer").Logger(),
+		keys:         keys,
+		votes:        votes,
+		newBLSHasher: msig.NewBLSHasher,
+	}
+}
+
+// PublicKeys returns the public keys of the private keys held by the signer.
+func (s *Server) PublicKeys(context.Context, *signer.PublicKeysRequest) (*signer.PublicKeysResponse, error) {
+	resp := &signer.PublicKeysResponse{}
+	if key, ok := s.keys[signer.KeyType_STAKING]; ok {
+		resp.StakingKey = key.PublicKey().Encode()
+	}
+	if key, ok := s.keys[signer.KeyType_NETWORKING]; ok {
+		resp.NetworkingKey = key.PublicKey().Encode()
+	}
+	return resp, nil
+}
+
+// Sign signs a message with one of the private keys held by the signer.
+// Consensus votes are refused, they must be signed with SignVote.
+func (s *Server) Sign(_ context.Context, req *signer.SignRequest) (*signer.SignResponse, error) {
+	key, ok := s.keys[req.GetKey()]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "signer holds no %v key", req.GetKey())
+	}
+
+	hasher, err := decodeHasher(req.GetHasher(), s.newBLSHasher)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid hasher: %v", err)
+	}
+	if req.GetHasher().GetDomainTag() == msig.ConsensusVoteTag {
+		return nil, status.Error(codes.InvalidArgument, "consensus votes must be signed with SignVote")
+	}
+
+	sign := signFunc(func(sk crypto.PrivateKey, msg []byte, hasher hash.Hasher) (crypto.Signature, error) {
+		return sk.Sign(msg, hasher)
+	})
+	if req.GetFunction() != signer.Function_SIGN {
+		sign, ok = signFunctions[req.GetFunction()]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "unsupported signing function %v", req.GetFunction())
+		}
+	}
+
+	sig, err := sign(key, req.GetMessage(), hasher)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not sign message: %v", err)
+	}
+	return &signer.SignResponse{Signature: sig}, nil
+}
+
+// SignVote signs a HotStuff vote with the staking key. Consensus votes which conflict with a
+// previously signed vote are refused.
+func (s *Server) SignVote(_ context.Context, req *signer.SignVoteRequest) (*signer.SignResponse, error) {
+	key, ok := s.keys[signer.KeyType_STAKING]
+	if !ok {
+		return nil, status.Errorf(codes.FailedPrecondition, "signer holds no %v key", signer.KeyType_STAKING)
+	}
+
+	tag := req.GetHasher().GetDomainTag()
+	if hash.HashingAlgorithm(req.GetHasher().GetAlgorithm()) != hash.KMAC128 ||
+		(tag != msig.ConsensusVoteTag && tag != msig.CollectorVoteTag) {
+		return nil, status.Error(codes.InvalidArgument, "invalid hasher: votes must be hashed with a vote domain tag")
+	}
+	hasher := s.newBLSHasher(tag)
+
+	blockID, err := flow.ByteSliceToId(req.GetBlockID())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block ID: %v", err)
+	}
+
+	var sig crypto.Signature
+	sign := func() error {
+		var signErr error
+		sig, signErr = key.Sign(verification.MakeVoteMessage(req.GetView(), blockID), hasher)
+		return signErr
+	}
+
+	log := s.log.With().
+		Uint64("view", req.GetView()).
+		Hex("block_id", blockID[:]).
+		Logger()
+
+	if tag == msig.CollectorVoteTag {
+		err = sign()
+	} else {
+		err = s.votes.Protect(req.GetView(), blockID, sign)
+	}
+	if errors.Is(err, ErrConflictingVote) {
+		log.Error().Err(err).Msg("refused to sign conflicting vote")
+		return nil, status.Errorf(codes.FailedPrecondition, "refused to sign vote: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not sign vote: %v", err)
+	}
+
+	log.Debug().Bool("consensus", tag == msig.ConsensusVoteTag).Msg("signed vote")
+	return &signer.SignResponse{Signature: sig}, nil
+}
+
+// ProtectVote records a consensus vote which the node signs itself with its random beacon key,
+// so that conflicting votes are refused afterwards. Votes which conflict with a previously signed
+// or recorded vote are refused.
+func (s *Server) ProtectVote(_ context.Context, req *signer.ProtectVoteRequest) (*signer.ProtectVoteResponse, error) {
+	blockID, err := flow.ByteSliceToId(req.GetBlockID())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid block ID: %v", err)
+	}
+
+	log := s.log.With().
+		Uint64("view", req.GetView()).
+		Hex("block_id", blockID[:]).
+		Logger()
+
+	// the node signs the vote, the signer only records it
+	err = s.votes.Protect(req.GetView(), blockID, func() error { return nil })
+	if errors.Is(err, ErrConflictingVote) {
+		log.Error().Err(err).Msg("refused to protect conflicting vote")
+		return nil, status.Errorf(codes.FailedPrecondition, "refused to protect vote: %v", err)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not protect vote: %v", err)
+	}
+
+	log.Debug().Msg("protected vote")
+	return &signer.ProtectVoteResponse{}, nil
+}
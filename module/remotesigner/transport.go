@@ -0,0 +1,95 @@
+package remotesigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/onflow/flow-go/module/remotesigner/signer"
+)
+
+// unixPrefix is the prefix of unix socket addresses, e.g. unix:///var/run/signer.sock.
+// Other addresses are TCP addresses.
+const unixPrefix = "unix://"
+
+// ServerCredentials returns the credentials of a remote signer, which requires its clients to
+// authenticate with a certificate signed by one of the CAs in clientCAFile.
+func ServerCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load server certificate: %w", err)
+	}
+	clientCAs, err := loadCertPool(clientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+	}), nil
+}
+
+// ClientCredentials returns the credentials of a client of a remote signer, which requires the
+// signer to authenticate with a certificate for serverName signed by one of the CAs in serverCAFile.
+func ClientCredentials(certFile, keyFile, serverCAFile, serverName string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("could not load client certificate: %w", err)
+	}
+	serverCAs, err := loadCertPool(serverCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(&tls.Config{
+		MinVersion:   tls.VersionTLS13,
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      serverCAs,
+		ServerName:   serverName,
+	}), nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no CA certificates found in %s", file)
+	}
+	return pool, nil
+}
+
+// Listen listens on the given unix socket (unix:///path/to/socket) or TCP address.
+func Listen(address string) (net.Listener, error) {
+	if strings.HasPrefix(address, unixPrefix) {
+		return net.Listen("unix", strings.TrimPrefix(address, unixPrefix))
+	}
+	return net.Listen("tcp", address)
+}
+
+// Dial connects to the remote signer at the given unix socket (unix:///path/to/socket) or TCP address.
+func Dial(address string, creds credentials.TransportCredentials) (signer.RemoteSignerClient, *grpc.ClientConn, error) {
+	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not connect to remote signer at %s: %w", address, err)
+	}
+	return signer.NewRemoteSignerClient(conn), conn, nil
+}
+
+// NewGRPCServer returns a gRPC server serving the given remote signer with the given credentials.
+func NewGRPCServer(s *Server, creds credentials.TransportCredentials) *grpc.Server {
+	server := grpc.NewServer(grpc.Creds(creds))
+	signer.RegisterRemoteSignerServer(server, s)
+	return server
+}
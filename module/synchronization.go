@@ -29,8 +29,8 @@ type SyncCore interface {
 	// if it should be discarded.
 	HandleBlock(header *flow.Header) bool
 
-	// HandleHeight handles receiving a new highest finalized height from another node.
-	HandleHeight(final *flow.Header, height uint64)
+	// HandleHeight handles receiving a new highest finalized height from the node with the given origin ID.
+	HandleHeight(final *flow.Header, height uint64, originID flow.Identifier)
 
 	// ScanPending scans all pending block statuses for blocks that should be
 	// requested. It apportions requestable items into range and batch requests
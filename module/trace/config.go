@@ -0,0 +1,77 @@
+package trace
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Names of the supported span exporters.
+const (
+	// ExporterOTLPGRPC exports spans to an OTLP collector via gRPC. Connection parameters are
+	// extracted from environment variables, e.g. `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT`.
+	ExporterOTLPGRPC = "otlp-grpc"
+	// ExporterOTLPHTTP exports spans to an OTLP collector via HTTP. Connection parameters are
+	// extracted from environment variables, e.g. `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT`.
+	ExporterOTLPHTTP = "otlp-http"
+	// ExporterFile appends spans to a file as JSON lines, for offline analysis.
+	ExporterFile = "file"
+	// ExporterMemory keeps the most recent spans in an in-memory ring buffer, which can be
+	// queried via the admin API.
+	ExporterMemory = "memory"
+)
+
+// DefaultMemoryBufferSize is the default number of spans kept by the in-memory exporter.
+const DefaultMemoryBufferSize = 10_000
+
+// Config configures the exporters and sampling policies of the Tracer.
+type Config struct {
+	Exporters        []string // names of the exporters spans are exported to
+	FilePath         string   // file the ExporterFile exporter appends to
+	MemoryBufferSize uint     // number of spans kept by the ExporterMemory exporter
+	Sampling         SamplingConfig
+}
+
+// SamplingConfig configures the sampling policies of the Tracer. Sampling policies are applied
+// in addition to the tracer sensitivity.
+type SamplingConfig struct {
+	// SpanRatios maps span names to the fraction of those spans which are sampled. Spans which are
+	// not sampled are dropped together with their children. Spans not listed are always sampled.
+	SpanRatios map[string]float64
+	// SlowSpanThreshold is the duration above which spans are always exported, even if they were
+	// not sampled. Zero disables the policy.
+	SlowSpanThreshold time.Duration
+	// BlockRatio is the fraction of blocks which are traced. The decision is keyed by block ID,
+	// so that either the whole lifecycle of a block is traced or none of it.
+	BlockRatio float64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Exporters:        []string{ExporterOTLPGRPC},
+		FilePath:         "",
+		MemoryBufferSize: DefaultMemoryBufferSize,
+		Sampling: SamplingConfig{
+			SpanRatios:        map[string]float64{},
+			SlowSpanThreshold: 0,
+			BlockRatio:        1,
+		},
+	}
+}
+
+// ParseSpanRatios parses the per-span-name sampling ratios from their string representation,
+// e.g. as provided by command line flags.
+func ParseSpanRatios(ratios map[string]string) (map[string]float64, error) {
+	parsed := make(map[string]float64, len(ratios))
+	for name, ratio := range ratios {
+		r, err := strconv.ParseFloat(ratio, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sampling ratio for span %s: %w", name, err)
+		}
+		if r < 0 || r > 1 {
+			return nil, fmt.Errorf("sampling ratio for span %s must be within [0, 1], got %v", name, r)
+		}
+		parsed[name] = r
+	}
+	return parsed, nil
+}
@@ -0,0 +1,218 @@
+package trace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// SpanRecord is the representation of a finished span by the file and in-memory exporters.
+type SpanRecord struct {
+	TraceID    string            `json:"trace_id"`
+	SpanID     string            `json:"span_id"`
+	ParentID   string            `json:"parent_id,omitempty"`
+	Name       string            `json:"name"`
+	Start      time.Time         `json:"start"`
+	End        time.Time         `json:"end"`
+	Duration   time.Duration     `json:"duration"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Status     string            `json:"status,omitempty"`
+	Error      string            `json:"error,omitempty"`
+}
+
+func newSpanRecord(s sdktrace.ReadOnlySpan) SpanRecord {
+	record := SpanRecord{
+		TraceID:  s.SpanContext().TraceID().String(),
+		SpanID:   s.SpanContext().SpanID().String(),
+		Name:     s.Name(),
+		Start:    s.StartTime(),
+		End:      s.EndTime(),
+		Duration: s.EndTime().Sub(s.StartTime()),
+		Status:   s.Status().Code.String(),
+		Error:    s.Status().Description,
+	}
+	if s.Parent().HasSpanID() {
+		record.ParentID = s.Parent().SpanID().String()
+	}
+	if attrs := s.Attributes(); len(attrs) > 0 {
+		record.Attributes = make(map[string]string, len(attrs))
+		for _, attr := range attrs {
+			record.Attributes[string(attr.Key)] = attr.Value.Emit()
+		}
+	}
+	return record
+}
+
+// FileExporter is a span exporter appending spans as JSON lines to a file.
+type FileExporter struct {
+	mu      sync.Mutex
+	file    *os.File
+	encoder *json.Encoder
+}
+
+var _ sdktrace.SpanExporter = (*FileExporter)(nil)
+
+// NewFileExporter creates an exporter appending to the file at the given path, which is
+// created if it does not exist.
+func NewFileExporter(path string) (*FileExporter, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open trace file (path: %s): %w", path, err)
+	}
+	return &FileExporter{
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+func (e *FileExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, span := range spans {
+		err := e.encoder.Encode(newSpanRecord(span))
+		if err != nil {
+			return fmt.Errorf("could not write span: %w", err)
+		}
+	}
+	return nil
+}
+
+func (e *FileExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.file.Close()
+}
+
+// SpanFilter selects the spans returned by MemoryExporter.Spans. Zero values match all spans.
+type SpanFilter struct {
+	TraceID     string
+	NamePrefix  string
+	MinDuration time.Duration
+}
+
+func (f SpanFilter) matches(record *SpanRecord) bool {
+	return (f.TraceID == "" || record.TraceID == f.TraceID) &&
+		strings.HasPrefix(record.Name, f.NamePrefix) &&
+		record.Duration >= f.MinDuration
+}
+
+// MemoryExporter is a span exporter keeping the most recent spans in a ring buffer.
+type MemoryExporter struct {
+	mu      sync.RWMutex
+	records []SpanRecord
+	next    int  // index the next record is written to
+	full    bool // whether the buffer wrapped around
+}
+
+var _ sdktrace.SpanExporter = (*MemoryExporter)(nil)
+
+// NewMemoryExporter creates an exporter keeping up to size spans.
+func NewMemoryExporter(size uint) *MemoryExporter {
+	return &MemoryExporter{
+		records: make([]SpanRecord, size),
+	}
+}
+
+func (e *MemoryExporter) ExportSpans(_ context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(e.records) == 0 {
+		return nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, span := range spans {
+		e.records[e.next] = newSpanRecord(span)
+		e.next++
+		if e.next == len(e.records) {
+			e.next = 0
+			e.full = true
+		}
+	}
+	return nil
+}
+
+func (e *MemoryExporter) Shutdown(context.Context) error {
+	return nil
+}
+
+// Spans returns up to limit of the buffered spans matching the filter, most recent first.
+func (e *MemoryExporter) Spans(filter SpanFilter, limit uint) []SpanRecord {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	count := e.next
+	if e.full {
+		count = len(e.records)
+	}
+
+	spans := make([]SpanRecord, 0)
+	for i := 0; i < count && uint(len(spans)) < limit; i++ {
+		record := &e.records[(e.next-1-i+len(e.records))%len(e.records)]
+		if filter.matches(record) {
+			spans = append(spans, *record)
+		}
+	}
+	return spans
+}
+
+// newSpanProcessors creates the span processors exporting to the configured exporters.
+// The in-memory exporter is returned separately, nil if it is not configured.
+func newSpanProcessors(ctx context.Context, config Config) ([]sdktrace.SpanProcessor, *MemoryExporter, error) {
+	var processors []sdktrace.SpanProcessor
+	var memory *MemoryExporter
+
+	for _, name := range config.Exporters {
+		var processor sdktrace.SpanProcessor
+		switch name {
+		case ExporterOTLPGRPC:
+			// For more information, see OpenTelemetry specification:
+			// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.12.0/specification/protocol/exporter.md
+			exporter, err := otlptracegrpc.New(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create otlp grpc trace exporter: %w", err)
+			}
+			processor = sdktrace.NewBatchSpanProcessor(exporter)
+		case ExporterOTLPHTTP:
+			exporter, err := otlptracehttp.New(ctx)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create otlp http trace exporter: %w", err)
+			}
+			processor = sdktrace.NewBatchSpanProcessor(exporter)
+		case ExporterFile:
+			exporter, err := NewFileExporter(config.FilePath)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to create file trace exporter: %w", err)
+			}
+			processor = sdktrace.NewBatchSpanProcessor(exporter)
+		case ExporterMemory:
+			if memory != nil {
+				return nil, nil, fmt.Errorf("duplicate trace exporter: %s", name)
+			}
+			memory = NewMemoryExporter(config.MemoryBufferSize)
+			processor = sdktrace.NewSimpleSpanProcessor(memory)
+		default:
+			return nil, nil, fmt.Errorf("unknown trace exporter: %s", name)
+		}
+
+		if config.Sampling.SlowSpanThreshold > 0 {
+			processor = &slowSpanProcessor{
+				SpanProcessor: processor,
+				threshold:     config.Sampling.SlowSpanThreshold,
+			}
+		}
+		processors = append(processors, processor)
+	}
+
+	return processors, memory, nil
+}
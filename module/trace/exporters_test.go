@@ -0,0 +1,121 @@
+package trace
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanFixture returns a finished span with the given name and duration.
+func spanFixture(traceID byte, name string, duration time.Duration) sdktrace.ReadOnlySpan {
+	start := time.Now()
+	return tracetest.SpanStub{
+		Name: name,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    trace.TraceID{traceID},
+			SpanID:     trace.SpanID{traceID, 1},
+			TraceFlags: trace.FlagsSampled,
+		}),
+		StartTime:  start,
+		EndTime:    start.Add(duration),
+		Attributes: []attribute.KeyValue{attribute.Int("height", 42)},
+	}.Snapshot()
+}
+
+// TestMemoryExporter verifies that the in-memory exporter keeps the most recent spans and
+// filters them.
+func TestMemoryExporter(t *testing.T) {
+	exporter := NewMemoryExporter(3)
+
+	err := exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{
+		spanFixture(1, "a.first", time.Millisecond),
+		spanFixture(2, "b.second", 2*time.Millisecond),
+	})
+	require.NoError(t, err)
+
+	spans := exporter.Spans(SpanFilter{}, 10)
+	require.Len(t, spans, 2)
+	require.Equal(t, "b.second", spans[0].Name)
+	require.Equal(t, "a.first", spans[1].Name)
+	require.Equal(t, "42", spans[0].Attributes["height"])
+
+	// exceeding the capacity evicts the oldest span
+	err = exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{
+		spanFixture(3, "a.third", 3*time.Millisecond),
+		spanFixture(4, "b.fourth", 4*time.Millisecond),
+	})
+	require.NoError(t, err)
+
+	spans = exporter.Spans(SpanFilter{}, 10)
+	require.Len(t, spans, 3)
+	require.Equal(t, []string{"b.fourth", "a.third", "b.second"}, []string{spans[0].Name, spans[1].Name, spans[2].Name})
+
+	// limit
+	spans = exporter.Spans(SpanFilter{}, 1)
+	require.Len(t, spans, 1)
+	require.Equal(t, "b.fourth", spans[0].Name)
+
+	// filters
+	spans = exporter.Spans(SpanFilter{NamePrefix: "a."}, 10)
+	require.Len(t, spans, 1)
+	require.Equal(t, "a.third", spans[0].Name)
+
+	spans = exporter.Spans(SpanFilter{MinDuration: 3 * time.Millisecond}, 10)
+	require.Len(t, spans, 2)
+
+	spans = exporter.Spans(SpanFilter{TraceID: trace.TraceID{2}.String()}, 10)
+	require.Len(t, spans, 1)
+	require.Equal(t, "b.second", spans[0].Name)
+}
+
+// TestFileExporter verifies that the file exporter appends spans as JSON lines.
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+
+	for i := byte(0); i < 2; i++ {
+		exporter, err := NewFileExporter(path)
+		require.NoError(t, err)
+		err = exporter.ExportSpans(context.Background(), []sdktrace.ReadOnlySpan{
+			spanFixture(2*i, "first", time.Millisecond),
+			spanFixture(2*i+1, "second", time.Millisecond),
+		})
+		require.NoError(t, err)
+		require.NoError(t, exporter.Shutdown(context.Background()))
+	}
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var records []SpanRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record SpanRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, record)
+	}
+	require.NoError(t, scanner.Err())
+
+	require.Len(t, records, 4)
+	for i, record := range records {
+		require.Equal(t, trace.TraceID{byte(i)}.String(), record.TraceID)
+		require.Equal(t, time.Millisecond, record.Duration)
+	}
+}
+
+func TestUnknownExporter(t *testing.T) {
+	config := DefaultConfig()
+	config.Exporters = []string{"unknown"}
+	_, _, err := newSpanProcessors(context.Background(), config)
+	require.Error(t, err)
+}
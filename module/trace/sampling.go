@@ -0,0 +1,122 @@
+package trace
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// ratioBound converts a sampling ratio into the upper bound a uniformly distributed 63 bit
+// value has to be below to be sampled.
+func ratioBound(ratio float64) uint64 {
+	if ratio >= 1 {
+		return math.MaxUint64
+	}
+	if ratio <= 0 {
+		return 0
+	}
+	return uint64(ratio * (1 << 63))
+}
+
+// isBlockSampled deterministically decides whether the block with the given ID is traced, such
+// that a fraction of all blocks given by the bound is traced.
+// The decision is based on the second half of the first 16 bytes of the block ID, which form the
+// trace ID of the block's spans. The first 8 bytes are used by the tracer sensitivity, hence the
+// two decisions are independent.
+func isBlockSampled(blockID flow.Identifier, bound uint64) bool {
+	if bound == math.MaxUint64 {
+		return true
+	}
+	return binary.BigEndian.Uint64(blockID[8:16])>>1 < bound
+}
+
+// sampler is the sdktrace.Sampler implementing the per-span-name sampling ratios.
+// Spans whose parent was not sampled are not sampled either. Spans which are not sampled
+// are still recorded if slow spans have to be captured, see slowSpanProcessor.
+type sampler struct {
+	spanBounds      map[string]uint64
+	recordUnsampled bool
+}
+
+var _ sdktrace.Sampler = (*sampler)(nil)
+
+func newSampler(config SamplingConfig) *sampler {
+	bounds := make(map[string]uint64, len(config.SpanRatios))
+	for name, ratio := range config.SpanRatios {
+		bounds[name] = ratioBound(ratio)
+	}
+	return &sampler{
+		spanBounds:      bounds,
+		recordUnsampled: config.SlowSpanThreshold > 0,
+	}
+}
+
+func (s *sampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	parent := trace.SpanContextFromContext(p.ParentContext)
+
+	sampled := !parent.IsValid() || parent.IsSampled()
+	if bound, ok := s.spanBounds[p.Name]; sampled && ok {
+		sampled = spanNameHash(p.TraceID, p.Name) < bound
+	}
+
+	decision := sdktrace.Drop
+	if sampled {
+		decision = sdktrace.RecordAndSample
+	} else if s.recordUnsampled {
+		decision = sdktrace.RecordOnly
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: parent.TraceState(),
+	}
+}
+
+func (s *sampler) Description() string {
+	return fmt.Sprintf("FlowSampler{spans:%d,recordUnsampled:%v}", len(s.spanBounds), s.recordUnsampled)
+}
+
+// spanNameHash returns a uniformly distributed 63 bit value derived from the trace ID and the span
+// name. Including the span name keeps the decisions for different span names of the same trace,
+// and the per-block decision, independent of each other.
+func spanNameHash(traceID trace.TraceID, name string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write(traceID[:])
+	_, _ = h.Write([]byte(name))
+	return h.Sum64() >> 1
+}
+
+// slowSpanProcessor forwards the sampled spans to the wrapped processor, as well as unsampled
+// spans which took at least the configured threshold to complete.
+type slowSpanProcessor struct {
+	sdktrace.SpanProcessor
+	threshold time.Duration
+}
+
+var _ sdktrace.SpanProcessor = (*slowSpanProcessor)(nil)
+
+func (p *slowSpanProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		p.SpanProcessor.OnEnd(s)
+		return
+	}
+	if s.EndTime().Sub(s.StartTime()) >= p.threshold {
+		p.SpanProcessor.OnEnd(slowSpan{s})
+	}
+}
+
+// slowSpan marks an unsampled span as sampled, so it is exported by the span processors.
+type slowSpan struct {
+	sdktrace.ReadOnlySpan
+}
+
+func (s slowSpan) SpanContext() trace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
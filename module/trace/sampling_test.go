@@ -0,0 +1,129 @@
+package trace
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// newMemoryTracer returns a tracer exporting to the in-memory exporter only.
+func newMemoryTracer(t *testing.T, sampling SamplingConfig) *Tracer {
+	config := DefaultConfig()
+	config.Exporters = []string{ExporterMemory}
+	config.Sampling = sampling
+
+	tracer, err := NewTracerWithConfig(zerolog.Nop(), "test", string(flow.Localnet), SensitivityCaptureAll, config)
+	require.NoError(t, err)
+	require.NotNil(t, tracer.Buffer())
+
+	<-tracer.Ready()
+	t.Cleanup(func() {
+		<-tracer.Done()
+	})
+	return tracer
+}
+
+// randomIdentifiers returns n random identifiers.
+func randomIdentifiers(t *testing.T, n int) []flow.Identifier {
+	ids := make([]flow.Identifier, n)
+	for i := range ids {
+		_, err := rand.Read(ids[i][:])
+		require.NoError(t, err)
+	}
+	return ids
+}
+
+// TestBlockSampling verifies that blocks are sampled according to the block ratio, and that
+// the decision is the same for all spans of a block.
+func TestBlockSampling(t *testing.T) {
+	tracer := newMemoryTracer(t, SamplingConfig{BlockRatio: 0.5})
+
+	blockIDs := randomIdentifiers(t, 1000)
+	sampledCount := 0
+	for _, blockID := range blockIDs {
+		span, _, sampled := tracer.StartBlockSpan(context.Background(), blockID, "first")
+		span.End()
+		if sampled {
+			sampledCount++
+		}
+
+		// the decision for a block is deterministic
+		span, _, sampledAgain := tracer.StartBlockSpan(context.Background(), blockID, "second")
+		span.End()
+		require.Equal(t, sampled, sampledAgain)
+	}
+	assert.InDelta(t, 500, sampledCount, 100)
+
+	// none of the blocks is sampled with a ratio of 0, all are with a ratio of 1
+	tracer = newMemoryTracer(t, SamplingConfig{BlockRatio: 0})
+	_, _, sampled := tracer.StartBlockSpan(context.Background(), blockIDs[0], "test")
+	require.False(t, sampled)
+	tracer = newMemoryTracer(t, SamplingConfig{BlockRatio: 1})
+	_, _, sampled = tracer.StartBlockSpan(context.Background(), blockIDs[0], "test")
+	require.True(t, sampled)
+}
+
+// TestSpanRatios verifies that spans are sampled according to the ratio configured for their name,
+// and that spans without ratio are always sampled.
+func TestSpanRatios(t *testing.T) {
+	tracer := newMemoryTracer(t, SamplingConfig{
+		SpanRatios: map[string]float64{"half": 0.5, "none": 0},
+		BlockRatio: 1,
+	})
+
+	for _, txID := range randomIdentifiers(t, 1000) {
+		parent, _, sampled := tracer.StartTransactionSpan(context.Background(), txID, "parent")
+		require.True(t, sampled)
+		for _, name := range []SpanName{"half", "none"} {
+			tracer.StartSpanFromParent(parent, name).End()
+		}
+		parent.End()
+	}
+
+	buffer := tracer.Buffer()
+	assert.Len(t, buffer.Spans(SpanFilter{NamePrefix: "parent"}, 10_000), 1000)
+	assert.InDelta(t, 500, len(buffer.Spans(SpanFilter{NamePrefix: "half"}, 10_000)), 100)
+	assert.Empty(t, buffer.Spans(SpanFilter{NamePrefix: "none"}, 10_000))
+}
+
+// TestSlowSpans verifies that unsampled spans are exported if they exceed the slow span threshold.
+func TestSlowSpans(t *testing.T) {
+	tracer := newMemoryTracer(t, SamplingConfig{
+		SpanRatios:        map[string]float64{"dropped": 0},
+		SlowSpanThreshold: 50 * time.Millisecond,
+		BlockRatio:        1,
+	})
+
+	parent, _, sampled := tracer.StartTransactionSpan(context.Background(), randomIdentifiers(t, 1)[0], "parent")
+	require.True(t, sampled)
+
+	fast := tracer.StartSpanFromParent(parent, "dropped")
+	fast.End()
+
+	slow := tracer.StartSpanFromParent(parent, "dropped", trace.WithTimestamp(time.Now().Add(-100*time.Millisecond)))
+	slow.End()
+	parent.End()
+
+	spans := tracer.Buffer().Spans(SpanFilter{NamePrefix: "dropped"}, 10)
+	require.Len(t, spans, 1)
+	require.Equal(t, slow.SpanContext().SpanID().String(), spans[0].SpanID)
+}
+
+func TestParseSpanRatios(t *testing.T) {
+	ratios, err := ParseSpanRatios(map[string]string{"a": "0.25", "b": "1"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"a": 0.25, "b": 1}, ratios)
+
+	_, err = ParseSpanRatios(map[string]string{"a": "abc"})
+	require.Error(t, err)
+	_, err = ParseSpanRatios(map[string]string{"a": "1.5"})
+	require.Error(t, err)
+}
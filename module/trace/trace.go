@@ -9,7 +9,6 @@ import (
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
@@ -44,14 +43,30 @@ type Tracer struct {
 	spanCache   *lru.Cache
 	chainID     string
 	sensitivity uint
+	blockBound  uint64          // upper bound for sampling blocks, see isBlockSampled
+	buffer      *MemoryExporter // nil if the in-memory exporter is not configured
 }
 
-// NewTracer creates a new OpenTelemetry-based tracer.
+// NewTracer creates a new OpenTelemetry-based tracer, exporting spans via OTLP gRPC.
+// Connection parameters for the exporter are extracted from environment variables,
+// e.g.: `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT`.
 func NewTracer(
 	log zerolog.Logger,
 	serviceName string,
 	chainID string,
 	sensitivity uint,
+) (*Tracer, error) {
+	return NewTracerWithConfig(log, serviceName, chainID, sensitivity, DefaultConfig())
+}
+
+// NewTracerWithConfig creates a new OpenTelemetry-based tracer, exporting spans to the configured
+// exporters and applying the configured sampling policies.
+func NewTracerWithConfig(
+	log zerolog.Logger,
+	serviceName string,
+	chainID string,
+	sensitivity uint,
+	config Config,
 ) (*Tracer, error) {
 	ctx := context.TODO()
 	res, err := resource.New(
@@ -65,20 +80,19 @@ func NewTracer(
 		return nil, fmt.Errorf("failed to create resource: %w", err)
 	}
 
-	// OLTP trace gRPC client initialization. Connection parameters for the exporter are extracted
-	// from environment variables. e.g.: `OTEL_EXPORTER_OTLP_TRACES_ENDPOINT`.
-	//
-	// For more information, see OpenTelemetry specification:
-	// https://github.com/open-telemetry/opentelemetry-specification/blob/v1.12.0/specification/protocol/exporter.md
-	traceExporter, err := otlptracegrpc.New(ctx)
+	processors, buffer, err := newSpanProcessors(ctx, config)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create trace exporter: %w", err)
+		return nil, fmt.Errorf("failed to create trace exporters: %w", err)
 	}
 
-	tracerProvider := sdktrace.NewTracerProvider(
+	opts := []sdktrace.TracerProviderOption{
 		sdktrace.WithResource(res),
-		sdktrace.WithBatcher(traceExporter),
-	)
+		sdktrace.WithSampler(newSampler(config.Sampling)),
+	}
+	for _, processor := range processors {
+		opts = append(opts, sdktrace.WithSpanProcessor(processor))
+	}
+	tracerProvider := sdktrace.NewTracerProvider(opts...)
 
 	otel.SetTracerProvider(tracerProvider)
 	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
@@ -97,9 +111,17 @@ func NewTracer(
 		spanCache:   spanCache,
 		sensitivity: sensitivity,
 		chainID:     chainID,
+		blockBound:  ratioBound(config.Sampling.BlockRatio),
+		buffer:      buffer,
 	}, nil
 }
 
+// Buffer returns the in-memory exporter holding the most recent spans, or nil if the
+// in-memory exporter is not configured.
+func (t *Tracer) Buffer() *MemoryExporter {
+	return t.buffer
+}
+
 // Ready returns a channel that will close when the network stack is ready.
 func (t *Tracer) Ready() <-chan struct{} {
 	ready := make(chan struct{})
@@ -177,6 +199,9 @@ func (t *Tracer) StartBlockSpan(
 	spanName SpanName,
 	opts ...trace.SpanStartOption,
 ) (trace.Span, context.Context, bool) {
+	if !isBlockSampled(blockID, t.blockBound) {
+		return NoopSpan, ctx, false
+	}
 	return t.startEntitySpan(ctx, blockID, EntityTypeBlock, spanName, opts...)
 }
 
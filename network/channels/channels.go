@@ -161,6 +161,9 @@ const (
 
 	// Execution data service
 	ExecutionDataService = Channel("execution-data-service")
+
+	// Chunk data pack service
+	ChunkDataPackService = Channel("chunk-data-pack-service")
 )
 
 // initializeChannelRoleMap initializes an instance of channelRoleMap and populates it
@@ -300,6 +300,48 @@ func AuthorizedRequester(
 	}
 }
 
+// AuthorizedChunkDataPackRequester returns a callback function used by bitswap to authorize block
+// requests for chunk data pack blobs.
+// A request is authorized if the peer is
+// * known by the identity provider
+// * not ejected
+// * an Execution or Verification node
+func AuthorizedChunkDataPackRequester(
+	identityProvider module.IdentityProvider,
+	logger zerolog.Logger,
+) func(peer.ID, cid.Cid) bool {
+	return func(peerID peer.ID, _ cid.Cid) bool {
+		lg := logger.With().
+			Str("component", "chunk_data_pack_blob_service").
+			Str("peer_id", peerID.String()).
+			Logger()
+
+		id, ok := identityProvider.ByPeerID(peerID)
+
+		if !ok {
+			lg.Warn().
+				Bool(logging.KeySuspicious, true).
+				Msg("rejecting request from unknown peer")
+			return false
+		}
+
+		lg = lg.With().
+			Str("peer_node_id", id.NodeID.String()).
+			Str("role", id.Role.String()).
+			Logger()
+
+		if (id.Role != flow.RoleExecution && id.Role != flow.RoleVerification) || id.Ejected {
+			lg.Warn().
+				Bool(logging.KeySuspicious, true).
+				Msg("rejecting request from peer: unauthorized")
+			return false
+		}
+
+		lg.Debug().Msg("accepting request from peer")
+		return true
+	}
+}
+
 type Tracer struct {
 	logger zerolog.Logger
 }
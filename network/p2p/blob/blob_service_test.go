@@ -122,6 +122,53 @@ func TestAuthorizedRequester(t *testing.T) {
 	})
 }
 
+func TestAuthorizedChunkDataPackRequester(t *testing.T) {
+	providerData := map[peer.ID]*flow.Identity{}
+
+	// known and should be allowed
+	en1, en1PeerID := mockIdentity(t, flow.RoleExecution)
+	providerData[en1PeerID] = en1
+	vn1, vn1PeerID := mockIdentity(t, flow.RoleVerification)
+	providerData[vn1PeerID] = vn1
+
+	// known but not an execution or verification node
+	an1, an1PeerID := mockIdentity(t, flow.RoleAccess)
+	providerData[an1PeerID] = an1
+
+	// unknown and should never be allowed
+	_, vn2PeerID := mockIdentity(t, flow.RoleVerification)
+
+	idProvider := modmock.NewIdentityProvider(t)
+	idProvider.On("ByPeerID", mock.AnythingOfType("peer.ID")).Return(
+		func(peerId peer.ID) *flow.Identity {
+			return providerData[peerId]
+		}, func(peerId peer.ID) bool {
+			_, ok := providerData[peerId]
+			return ok
+		})
+
+	authorizer := blob.AuthorizedChunkDataPackRequester(idProvider, unittest.Logger())
+
+	t.Run("allows EN and VN", func(t *testing.T) {
+		assert.True(t, authorizer(en1PeerID, cid.Cid{}))
+		assert.True(t, authorizer(vn1PeerID, cid.Cid{}))
+	})
+
+	t.Run("denies AN", func(t *testing.T) {
+		assert.False(t, authorizer(an1PeerID, cid.Cid{}))
+	})
+
+	t.Run("denies unknown peer", func(t *testing.T) {
+		assert.False(t, authorizer(vn2PeerID, cid.Cid{}))
+	})
+
+	vn1.Ejected = true
+
+	t.Run("denies ejected VN", func(t *testing.T) {
+		assert.False(t, authorizer(vn1PeerID, cid.Cid{}))
+	})
+}
+
 func mockIdentity(t *testing.T, role flow.Role) (*flow.Identity, peer.ID) {
 	identity, _ := unittest.IdentityWithNetworkingKeyFixture(unittest.WithRole(role))
 	peerID, err := unittest.PeerIDFromFlowID(identity)
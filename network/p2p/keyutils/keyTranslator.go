@@ -63,6 +63,7 @@ func PeerIDFromFlowPublicKey(networkPubKey fcrypto.PublicKey) (pid peer.ID, err
 }
 
 // LibP2PPrivKeyFromFlow converts a Flow private key to a LibP2P Private key
+// Remote keys are converted to LibP2P keys delegating signing to them, see RemotePrivateKey.
 func LibP2PPrivKeyFromFlow(fpk fcrypto.PrivateKey) (lcrypto.PrivKey, error) {
 	// get the signature algorithm
 	keyType, err := keyType(fpk.Algorithm())
@@ -70,6 +71,10 @@ func LibP2PPrivKeyFromFlow(fpk fcrypto.PrivateKey) (lcrypto.PrivKey, error) {
 		return nil, err
 	}
 
+	if remote, ok := fpk.(RemotePrivateKey); ok {
+		return newRemotePrivKey(remote, keyType)
+	}
+
 	// based on the signature algorithm, get the appropriate libp2p unmarshaller
 	um, ok := lcrypto.PrivKeyUnmarshallers[keyType]
 	if !ok {
@@ -60,6 +60,45 @@ func (k *KeyTranslatorTestSuite) TestPrivateKeyConversion() {
 	}
 }
 
+// remoteKey is a RemotePrivateKey wrapping a local Flow private key.
+type remoteKey struct {
+	fcrypto.PrivateKey
+}
+
+func (remoteKey) Remote() {}
+
+// TestRemotePrivateKeyConversion tests that remote keys are converted to LibP2P keys delegating
+// signing to them, whose signatures are verified by the LibP2P public key.
+func (k *KeyTranslatorTestSuite) TestRemotePrivateKeyConversion() {
+	sa := []fcrypto.SigningAlgorithm{fcrypto.ECDSAP256, fcrypto.ECDSASecp256k1}
+	loops := 50
+	for _, s := range sa {
+		for i := 0; i < loops; i++ {
+			fpk, err := fcrypto.GeneratePrivateKey(s, k.createSeed())
+			require.NoError(k.T(), err)
+
+			lpk, err := LibP2PPrivKeyFromFlow(remoteKey{fpk})
+			require.NoError(k.T(), err)
+
+			// the key material is not available
+			_, err = lpk.Raw()
+			require.Error(k.T(), err)
+
+			// the public key and peer ID match the ones of the Flow key
+			lpublic, err := LibP2PPublicKeyFromFlow(fpk.PublicKey())
+			require.NoError(k.T(), err)
+			require.True(k.T(), lpublic.Equals(lpk.GetPublic()))
+
+			msg := []byte(fmt.Sprintf("message %d", i))
+			sig, err := lpk.Sign(msg)
+			require.NoError(k.T(), err)
+			valid, err := lpublic.Verify(msg, sig)
+			require.NoError(k.T(), err)
+			require.True(k.T(), valid)
+		}
+	}
+}
+
 // RawUncompressed returns the bytes of the key in an uncompressed format (like Flow library)
 // This function is added to the test since Raw function from libp2p only returns the compressed format
 func rawUncompressed(key lcrypto.PubKey) ([]byte, error) {
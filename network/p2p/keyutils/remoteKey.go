@@ -0,0 +1,91 @@
+package keyutils
+
+import (
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+	lcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	lcrypto_pb "github.com/libp2p/go-libp2p/core/crypto/pb"
+
+	fcrypto "github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/crypto/hash"
+)
+
+// RemotePrivateKey is a Flow private key which is not held by the node, e.g. a networking key held
+// by a remote signer. Its key material can't be encoded, so it is converted to a LibP2P private key
+// which delegates signing to the Flow key instead.
+type RemotePrivateKey interface {
+	fcrypto.PrivateKey
+	// Remote marks the key as held outside of the node.
+	Remote()
+}
+
+// remotePrivKey is a LibP2P private key delegating signing to a remote Flow private key.
+// LibP2P signs with ECDSA over the SHA2-256 hash of the message, and expects DER encoded
+// signatures, while Flow signatures are the concatenation of r and s.
+type remotePrivKey struct {
+	key     RemotePrivateKey
+	pub     lcrypto.PubKey
+	keyType lcrypto_pb.KeyType
+}
+
+var _ lcrypto.PrivKey = (*remotePrivKey)(nil)
+
+func newRemotePrivKey(key RemotePrivateKey, keyType lcrypto_pb.KeyType) (*remotePrivKey, error) {
+	pub, err := LibP2PPublicKeyFromFlow(key.PublicKey())
+	if err != nil {
+		return nil, fmt.Errorf("could not convert public key of remote key: %w", err)
+	}
+	return &remotePrivKey{
+		key:     key,
+		pub:     pub,
+		keyType: keyType,
+	}, nil
+}
+
+// Sign signs the given bytes with the remote key, and returns the DER encoded signature.
+func (k *remotePrivKey) Sign(data []byte) ([]byte, error) {
+	sig, err := k.key.Sign(data, hash.NewSHA2_256())
+	if err != nil {
+		return nil, fmt.Errorf("could not sign with remote key: %w", err)
+	}
+	if len(sig) == 0 || len(sig)%2 != 0 {
+		return nil, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	rBytes, sBytes := sig[:len(sig)/2], sig[len(sig)/2:]
+
+	if k.keyType == lcrypto_pb.KeyType_Secp256k1 {
+		var r, s btcec.ModNScalar
+		if r.SetByteSlice(rBytes) || s.SetByteSlice(sBytes) {
+			return nil, fmt.Errorf("invalid signature: scalar overflows the curve order")
+		}
+		return ecdsa.NewSignature(&r, &s).Serialize(), nil
+	}
+
+	return asn1.Marshal(lcrypto.ECDSASig{
+		R: new(big.Int).SetBytes(rBytes),
+		S: new(big.Int).SetBytes(sBytes),
+	})
+}
+
+func (k *remotePrivKey) GetPublic() lcrypto.PubKey {
+	return k.pub
+}
+
+// Raw returns an error, since the key material of a remote key is not available.
+func (k *remotePrivKey) Raw() ([]byte, error) {
+	return nil, fmt.Errorf("key material of remote key is not available")
+}
+
+func (k *remotePrivKey) Type() lcrypto_pb.KeyType {
+	return k.keyType
+}
+
+// Equals returns true if the given key is a remote key with the same public key.
+func (k *remotePrivKey) Equals(other lcrypto.Key) bool {
+	remote, ok := other.(*remotePrivKey)
+	return ok && k.pub.Equals(remote.pub)
+}
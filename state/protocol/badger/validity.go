@@ -197,6 +197,24 @@ func isValidEpochCommit(commit *flow.EpochCommit, setup *flow.EpochSetup) error
 	return nil
 }
 
+// ValidateEpochTransition checks whether the EpochSetup and EpochCommit service
+// events for the next epoch are valid with respect to the active epoch. It applies
+// the same checks as the mutator when incorporating the service events into a
+// fork in which the next epoch has not been set up yet. This is intended for
+// tooling which previews an epoch transition before it happens.
+// Expected errors:
+//   - protocol.InvalidServiceEventError or state.InvalidExtensionError if
+//     either of the service events is invalid
+func ValidateEpochTransition(activeSetup *flow.EpochSetup, nextSetup *flow.EpochSetup, nextCommit *flow.EpochCommit) error {
+	status := &flow.EpochStatus{}
+	err := isValidExtendingEpochSetup(nextSetup, activeSetup, status)
+	if err != nil {
+		return err
+	}
+	status.NextEpoch.SetupID = nextSetup.ID()
+	return isValidExtendingEpochCommit(nextCommit, nextSetup, activeSetup, status)
+}
+
 // IsValidRootSnapshot checks internal consistency of root state snapshot
 // if verifyResultID allows/disallows Result ID verification
 func IsValidRootSnapshot(snap protocol.Snapshot, verifyResultID bool) error {
@@ -15,6 +15,8 @@ var (
 	collectorClusterLeaderSelectionPrefix = []uint16{0, 0}
 	// executionChunkPrefix is the prefix of the customizer for executing chunks
 	executionChunkPrefix = []uint16{1}
+	// executionTransactionPrefix is the prefix of the customizer for the randomness provided to transactions
+	executionTransactionPrefix = []uint16{2}
 )
 
 // ProtocolCollectorClusterLeaderSelection returns the indices for the leader selection for the i-th collector cluster
@@ -29,6 +31,14 @@ func ExecutionChunk(chunkIndex uint16) []byte {
 	return customizerFromIndices(indices)
 }
 
+// ExecutionTransaction returns the indices for the randomness provided to the i-th transaction of a block.
+// The transaction index is encoded over two indices, so that all transaction indices map to customizers
+// of the same length.
+func ExecutionTransaction(txIndex uint32) []byte {
+	indices := append(executionTransactionPrefix, uint16(txIndex), uint16(txIndex>>16))
+	return customizerFromIndices(indices)
+}
+
 // customizerFromIndices maps the input indices into a slice of bytes.
 // The implementation insures there are no collisions of mapping of different indices.
 //
@@ -0,0 +1,63 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// DeadLetters persists the dead-lettered jobs of a single job queue.
+type DeadLetters struct {
+	db    *badger.DB
+	queue string // to distinguish the dead letters of different job queues
+}
+
+var _ storage.DeadLetters = (*DeadLetters)(nil)
+
+func NewDeadLetters(db *badger.DB, queue string) *DeadLetters {
+	return &DeadLetters{
+		db:    db,
+		queue: queue,
+	}
+}
+
+func (d *DeadLetters) Store(letter *storage.DeadLetter) error {
+	err := operation.RetryOnConflict(d.db.Update, operation.UpsertDeadLetter(d.queue, letter))
+	if err != nil {
+		return fmt.Errorf("could not store dead letter: %w", err)
+	}
+	return nil
+}
+
+func (d *DeadLetters) ByIndex(index uint64) (*storage.DeadLetter, error) {
+	var letter storage.DeadLetter
+	err := d.db.View(operation.RetrieveDeadLetter(d.queue, index, &letter))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dead letter: %w", err)
+	}
+	return &letter, nil
+}
+
+func (d *DeadLetters) All() ([]*storage.DeadLetter, error) {
+	var letters []*storage.DeadLetter
+	err := d.db.View(operation.TraverseDeadLetters(d.queue, func(letter *storage.DeadLetter) error {
+		letters = append(letters, letter)
+		return nil
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve dead letters: %w", err)
+	}
+	return letters, nil
+}
+
+func (d *DeadLetters) Remove(index uint64) error {
+	err := operation.RetryOnConflict(d.db.Update, operation.RemoveDeadLetter(d.queue, index))
+	if err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return fmt.Errorf("could not remove dead letter: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package badger_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestDeadLetters(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		letters := bstorage.NewDeadLetters(db, "queue")
+		// a queue whose name starts with the name of the first queue
+		other := bstorage.NewDeadLetters(db, "queue-other")
+
+		for _, index := range []uint64{7, 3, 300} {
+			err := letters.Store(&storage.DeadLetter{
+				Index:    index,
+				JobID:    "job",
+				Error:    "failed",
+				Attempts: 3,
+				FailedAt: time.Now().UTC().Truncate(time.Second),
+			})
+			require.NoError(t, err)
+		}
+		require.NoError(t, other.Store(&storage.DeadLetter{Index: 1}))
+
+		all, err := letters.All()
+		require.NoError(t, err)
+		require.Len(t, all, 3)
+		assert.Equal(t, []uint64{3, 7, 300}, []uint64{all[0].Index, all[1].Index, all[2].Index})
+
+		// storing again replaces the dead letter
+		letter, err := letters.ByIndex(7)
+		require.NoError(t, err)
+		letter.Attempts++
+		require.NoError(t, letters.Store(letter))
+		updated, err := letters.ByIndex(7)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), updated.Attempts)
+		assert.True(t, letter.FailedAt.Equal(updated.FailedAt))
+
+		require.NoError(t, letters.Remove(7))
+		_, err = letters.ByIndex(7)
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+		// removing a job which is not dead-lettered is a no-op
+		require.NoError(t, letters.Remove(7))
+
+		all, err = letters.All()
+		require.NoError(t, err)
+		assert.Len(t, all, 2)
+
+		all, err = other.All()
+		require.NoError(t, err)
+		assert.Len(t, all, 1)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/dgraph-io/badger/v2"
 
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/module"
@@ -123,6 +124,39 @@ func (ds *DKGState) GetDKGEndState(epochCounter uint64) (flow.DKGEndState, error
 	return endState, err
 }
 
+// SetDKGResumeState stores the progress of the in-progress DKG for the given
+// epoch, overwriting any previously stored progress.
+func (ds *DKGState) SetDKGResumeState(epochCounter uint64, state *dkg.ResumeState) error {
+	return ds.db.Update(operation.UpsertDKGResumeState(epochCounter, state))
+}
+
+// GetDKGResumeState retrieves the progress of the in-progress DKG for the given epoch.
+// Returns storage.ErrNotFound if no progress was stored for the epoch.
+func (ds *DKGState) GetDKGResumeState(epochCounter uint64) (*dkg.ResumeState, error) {
+	var state dkg.ResumeState
+	err := ds.db.View(operation.RetrieveDKGResumeState(epochCounter, &state))
+	if err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// InsertDKGLogEntry appends an entry to the replay log of the DKG for the given epoch.
+func (ds *DKGState) InsertDKGLogEntry(epochCounter uint64, entry *dkg.LogEntry) error {
+	return operation.RetryOnConflict(ds.db.Update, operation.InsertDKGLogEntry(epochCounter, entry))
+}
+
+// RetrieveDKGLog retrieves the replay log of the DKG for the given epoch,
+// ordered by sequence number. Returns an empty log if no entries were stored.
+func (ds *DKGState) RetrieveDKGLog(epochCounter uint64) ([]*dkg.LogEntry, error) {
+	var entries []*dkg.LogEntry
+	err := ds.db.View(operation.RetrieveDKGLog(epochCounter, &entries))
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
 // SafeBeaconPrivateKeys is the safe beacon key storage backed by Badger DB.
 type SafeBeaconPrivateKeys struct {
 	state *DKGState
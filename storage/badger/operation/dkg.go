@@ -5,6 +5,7 @@ import (
 
 	"github.com/dgraph-io/badger/v2"
 
+	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
@@ -61,3 +62,46 @@ func InsertDKGEndStateForEpoch(epochCounter uint64, endState flow.DKGEndState) f
 func RetrieveDKGEndStateForEpoch(epochCounter uint64, endState *flow.DKGEndState) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeDKGEnded, epochCounter), endState)
 }
+
+// UpsertDKGResumeState stores the progress of the in-progress DKG for the epoch,
+// overwriting any previously stored progress.
+//
+// CAUTION: This method stores confidential information and should only be
+// used in the context of the secrets database. This is enforced in the above
+// layer (see storage.DKGState).
+func UpsertDKGResumeState(epochCounter uint64, state *dkg.ResumeState) func(*badger.Txn) error {
+	return upsert(makePrefix(codeDKGResumeState, epochCounter), state)
+}
+
+// RetrieveDKGResumeState retrieves the progress of the in-progress DKG for the epoch.
+//
+// CAUTION: This method stores confidential information and should only be
+// used in the context of the secrets database. This is enforced in the above
+// layer (see storage.DKGState).
+func RetrieveDKGResumeState(epochCounter uint64, state *dkg.ResumeState) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeDKGResumeState, epochCounter), state)
+}
+
+// InsertDKGLogEntry stores an entry of the DKG replay log for the epoch.
+func InsertDKGLogEntry(epochCounter uint64, entry *dkg.LogEntry) func(*badger.Txn) error {
+	return insert(makePrefix(codeDKGLogEntry, epochCounter, entry.Seq), entry)
+}
+
+// RetrieveDKGLog retrieves all entries of the DKG replay log for the epoch,
+// ordered by sequence number.
+func RetrieveDKGLog(epochCounter uint64, entries *[]*dkg.LogEntry) func(*badger.Txn) error {
+	return traverse(makePrefix(codeDKGLogEntry, epochCounter), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var entry dkg.LogEntry
+		create := func() interface{} {
+			return &entry
+		}
+		handle := func() error {
+			*entries = append(*entries, &entry)
+			return nil
+		}
+		return check, create, handle
+	})
+}
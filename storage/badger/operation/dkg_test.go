@@ -7,6 +7,7 @@ import (
 	"github.com/dgraph-io/badger/v2"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/encodable"
 	"github.com/onflow/flow-go/model/flow"
 	"github.com/onflow/flow-go/storage"
@@ -98,3 +99,60 @@ func TestDKGEndStateForEpoch(t *testing.T) {
 		assert.ErrorIs(t, err, storage.ErrAlreadyExists)
 	})
 }
+
+// TestDKGResumeStateForEpoch tests storing and overwriting the progress of an in-progress DKG.
+func TestDKGResumeStateForEpoch(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		epochCounter := rand.Uint64()
+
+		var stored dkg.ResumeState
+		err := db.View(RetrieveDKGResumeState(epochCounter, &stored))
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+
+		state := &dkg.ResumeState{Seed: unittest.SeedFixture(64), Phase: 1}
+		err = db.Update(UpsertDKGResumeState(epochCounter, state))
+		assert.NoError(t, err)
+
+		// should be able to overwrite the stored progress
+		state.Phase = 2
+		state.BroadcastOffset = 10
+		err = db.Update(UpsertDKGResumeState(epochCounter, state))
+		assert.NoError(t, err)
+
+		err = db.View(RetrieveDKGResumeState(epochCounter, &stored))
+		assert.NoError(t, err)
+		assert.Equal(t, state, &stored)
+	})
+}
+
+// TestDKGLogForEpoch tests that the DKG replay log is retrieved in sequence order.
+func TestDKGLogForEpoch(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		epochCounter := rand.Uint64()
+
+		var entries []*dkg.LogEntry
+		err := db.View(RetrieveDKGLog(epochCounter, &entries))
+		assert.NoError(t, err)
+		assert.Empty(t, entries)
+
+		expected := make([]*dkg.LogEntry, 0, 300)
+		for seq := uint64(0); seq < 300; seq++ {
+			entry := &dkg.LogEntry{
+				Seq:                  seq,
+				Type:                 dkg.LogEntryBroadcastMessage,
+				CommitteeMemberIndex: seq % 10,
+				Data:                 unittest.RandomBytes(16),
+			}
+			expected = append(expected, entry)
+			err = db.Update(InsertDKGLogEntry(epochCounter, entry))
+			assert.NoError(t, err)
+		}
+		// entries for other epochs should not be retrieved
+		err = db.Update(InsertDKGLogEntry(epochCounter+1, &dkg.LogEntry{Seq: 0, Type: dkg.LogEntryTimeout}))
+		assert.NoError(t, err)
+
+		err = db.View(RetrieveDKGLog(epochCounter, &entries))
+		assert.NoError(t, err)
+		assert.Equal(t, expected, entries)
+	})
+}
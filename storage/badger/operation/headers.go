@@ -98,3 +98,24 @@ func FindHeaders(filter func(header *flow.Header) bool, found *[]flow.Header) fu
 		return check, create, handle
 	})
 }
+
+// TraverseBlockHeights calls the given function with the height and ID of every finalized
+// block indexed by height, in order of increasing height.
+func TraverseBlockHeights(fn func(height uint64, blockID flow.Identifier) error) func(*badger.Txn) error {
+	return traverse(makePrefix(codeHeightToBlock), func() (checkFunc, createFunc, handleFunc) {
+		var height uint64
+		check := func(key []byte) bool {
+			var ok bool
+			height, ok = HeightFromKey(key)
+			return ok
+		}
+		var blockID flow.Identifier
+		create := func() interface{} {
+			return &blockID
+		}
+		handle := func() error {
+			return fn(height, blockID)
+		}
+		return check, create, handle
+	})
+}
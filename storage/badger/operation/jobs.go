@@ -1,9 +1,12 @@
 package operation
 
 import (
+	"encoding/binary"
+
 	"github.com/dgraph-io/badger/v2"
 
 	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/storage"
 )
 
 func RetrieveJobLatestIndex(queue string, index *uint64) func(*badger.Txn) error {
@@ -28,6 +31,65 @@ func InsertJobAtIndex(queue string, index uint64, entity flow.Identifier) func(*
 	return insert(makePrefix(codeJobQueue, queue, index), entity)
 }
 
+// InsertStoredJob inserts a job of a persistent job queue at the given index.
+func InsertStoredJob(queue string, index uint64, job *storage.StoredJob) func(*badger.Txn) error {
+	return insert(makePrefix(codeJobQueue, queue, index), job)
+}
+
+// RetrieveStoredJob retrieves the job of a persistent job queue at the given index.
+func RetrieveStoredJob(queue string, index uint64, job *storage.StoredJob) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeJobQueue, queue, index), job)
+}
+
+// IndexJobByID indexes the index of the job with the given ID in a persistent job queue.
+// Returns storage.ErrAlreadyExists if a job with the same ID was indexed before.
+func IndexJobByID(queue string, jobID string, index uint64) func(*badger.Txn) error {
+	return insert(jobIDKey(queue, jobID), index)
+}
+
+// LookupJobByID retrieves the index of the job with the given ID in a persistent job queue.
+func LookupJobByID(queue string, jobID string, index *uint64) func(*badger.Txn) error {
+	return retrieve(jobIDKey(queue, jobID), index)
+}
+
+// jobIDKey returns the key of the index of the job with the given ID. The length of the queue name
+// is part of the key, so that the keys of different queues can't collide.
+func jobIDKey(queue string, jobID string) []byte {
+	return makePrefix(codeJobIndexByID, uint32(len(queue)), queue, jobID)
+}
+
+// RemoveJobAtIndex removes the job at the given index.
+func RemoveJobAtIndex(queue string, index uint64) func(*badger.Txn) error {
+	return remove(makePrefix(codeJobQueue, queue, index))
+}
+
+// LookupJobIndices retrieves the indices of the stored jobs of the queue, up to and including the
+// given index, in increasing order. Only keys are read, so the jobs are not decoded.
+func LookupJobIndices(queue string, upTo uint64, indices *[]uint64) func(*badger.Txn) error {
+	return func(tx *badger.Txn) error {
+		prefix := makePrefix(codeJobQueue, queue)
+
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := tx.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			key := it.Item().Key()
+			// skip the jobs of queues whose name starts with the given queue name
+			if len(key) != len(prefix)+8 {
+				continue
+			}
+			index := binary.BigEndian.Uint64(key[len(prefix):])
+			if index > upTo {
+				break
+			}
+			*indices = append(*indices, index)
+		}
+		return nil
+	}
+}
+
 // RetrieveProcessedIndex returns the processed index for a job consumer
 func RetrieveProcessedIndex(jobName string, processed *uint64) func(*badger.Txn) error {
 	return retrieve(makePrefix(codeJobConsumerProcessed, jobName), processed)
@@ -41,3 +103,38 @@ func InsertProcessedIndex(jobName string, processed uint64) func(*badger.Txn) er
 func SetProcessedIndex(jobName string, processed uint64) func(*badger.Txn) error {
 	return update(makePrefix(codeJobConsumerProcessed, jobName), processed)
 }
+
+// UpsertDeadLetter inserts or replaces the dead letter of the job at the letter's index.
+func UpsertDeadLetter(queue string, letter *storage.DeadLetter) func(*badger.Txn) error {
+	return upsert(makePrefix(codeJobDeadLetter, queue, letter.Index), letter)
+}
+
+// RetrieveDeadLetter retrieves the dead letter of the job at the given index.
+func RetrieveDeadLetter(queue string, index uint64, letter *storage.DeadLetter) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeJobDeadLetter, queue, index), letter)
+}
+
+// RemoveDeadLetter removes the dead letter of the job at the given index.
+func RemoveDeadLetter(queue string, index uint64) func(*badger.Txn) error {
+	return remove(makePrefix(codeJobDeadLetter, queue, index))
+}
+
+// TraverseDeadLetters calls the given function with every dead letter of the queue, in order of
+// increasing job index.
+func TraverseDeadLetters(queue string, fn func(letter *storage.DeadLetter) error) func(*badger.Txn) error {
+	prefix := makePrefix(codeJobDeadLetter, queue)
+	return traverse(prefix, func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			// skip the dead letters of queues whose name starts with the given queue name
+			return len(key) == len(prefix)+8
+		}
+		var letter storage.DeadLetter
+		create := func() interface{} {
+			return &letter
+		}
+		handle := func() error {
+			return fn(&letter)
+		}
+		return check, create, handle
+	})
+}
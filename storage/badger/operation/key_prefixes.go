@@ -0,0 +1,146 @@
+package operation
+
+import (
+	"encoding/binary"
+
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// KeyLayout describes the first key component following the prefix code of a key.
+type KeyLayout int
+
+const (
+	// KeyLayoutOther keys are followed by a component which can not be related to a block height,
+	// e.g. an entity ID, or by nothing at all.
+	KeyLayoutOther KeyLayout = iota
+	// KeyLayoutHeight keys are followed by a big-endian uint64 block height.
+	KeyLayoutHeight
+	// KeyLayoutBlockID keys are followed by the ID of a block.
+	KeyLayoutBlockID
+)
+
+// KeyPrefix describes the entries stored under a prefix code.
+type KeyPrefix struct {
+	Code   byte
+	Name   string
+	Layout KeyLayout
+}
+
+// keyPrefixes is the table of all prefix codes, see prefix.go.
+var keyPrefixes = []KeyPrefix{
+	{codeMax, "max key size", KeyLayoutOther},
+	{codeDBType, "db type", KeyLayoutOther},
+
+	{codeStartedView, "started view", KeyLayoutOther},
+	{codeVotedView, "voted view", KeyLayoutOther},
+	{codeSlashingProtection, "slashing protection", KeyLayoutOther},
+	{codeSlashingEvidence, "slashing evidence", KeyLayoutOther},
+
+	{codeRootQuorumCertificate, "root quorum certificate", KeyLayoutOther},
+	{codeSporkID, "spork id", KeyLayoutOther},
+	{codeProtocolVersion, "protocol version", KeyLayoutOther},
+
+	{codeFinalizedHeight, "finalized height", KeyLayoutOther},
+	{codeSealedHeight, "sealed height", KeyLayoutOther},
+	{codeClusterHeight, "cluster height", KeyLayoutOther},
+	{codeExecutedBlock, "executed block", KeyLayoutOther},
+	{codeRootHeight, "root height", KeyLayoutOther},
+	{codeLastCompleteBlockHeight, "last complete block height", KeyLayoutOther},
+
+	{codeHeader, "header", KeyLayoutBlockID},
+	{codeGuarantee, "guarantee", KeyLayoutOther},
+	{codeSeal, "seal", KeyLayoutOther},
+	{codeTransaction, "transaction", KeyLayoutOther},
+	{codeCollection, "collection", KeyLayoutOther},
+	// codeExecutionReceiptMeta shares the code of codeExecutionResult
+	{codeExecutionResult, "execution result / receipt meta", KeyLayoutOther},
+	{codeResultApproval, "result approval", KeyLayoutOther},
+	{codeChunk, "chunk locator", KeyLayoutOther},
+
+	{codeHeightToBlock, "height to block", KeyLayoutHeight},
+	{codeBlockIDToLatestSealID, "block to latest seal", KeyLayoutBlockID},
+	{codeClusterBlockToRefBlock, "cluster block to reference block", KeyLayoutOther},
+	{codeBlockValidity, "block validity", KeyLayoutBlockID},
+	{codeRefHeightToClusterBlock, "reference height to cluster block", KeyLayoutHeight},
+	{codeBlockIDToFinalizedSeal, "block to finalized seal", KeyLayoutBlockID},
+
+	{codeBlockChildren, "block children", KeyLayoutBlockID},
+	{codePayloadGuarantees, "payload guarantees", KeyLayoutBlockID},
+	{codePayloadSeals, "payload seals", KeyLayoutBlockID},
+	{codeCollectionBlock, "collection to block", KeyLayoutOther},
+	{codeOwnBlockReceipt, "own block receipt", KeyLayoutBlockID},
+	{codeBlockEpochStatus, "block epoch status", KeyLayoutBlockID},
+	{codePayloadReceipts, "payload receipts", KeyLayoutBlockID},
+	{codePayloadResults, "payload results", KeyLayoutBlockID},
+	{codeAllBlockReceipts, "all block receipts", KeyLayoutBlockID},
+	{codeIndexBlockByChunkID, "chunk to block", KeyLayoutOther},
+
+	{codeEpochSetup, "epoch setup", KeyLayoutOther},
+	{codeEpochCommit, "epoch commit", KeyLayoutOther},
+	{codeBeaconPrivateKey, "beacon private key", KeyLayoutOther},
+	{codeDKGStarted, "dkg started", KeyLayoutOther},
+	{codeDKGEnded, "dkg ended", KeyLayoutOther},
+	{codeDKGResumeState, "dkg resume state", KeyLayoutOther},
+	{codeDKGLogEntry, "dkg log entry", KeyLayoutOther},
+
+	{codeComputationResults, "computation result upload status", KeyLayoutBlockID},
+
+	{codeJobConsumerProcessed, "job consumer processed", KeyLayoutOther},
+	{codeJobQueue, "job queue", KeyLayoutOther},
+	{codeJobQueuePointer, "job queue pointer", KeyLayoutOther},
+	{codeJobDeadLetter, "job dead letter", KeyLayoutOther},
+	{codeJobIndexByID, "job index by id", KeyLayoutOther},
+
+	{codeChunkDataPack, "chunk data pack", KeyLayoutOther},
+	{codeCommit, "state commitment", KeyLayoutBlockID},
+	{codeEvent, "event", KeyLayoutBlockID},
+	{codeExecutionStateInteractions, "execution state interactions", KeyLayoutBlockID},
+	{codeTransactionResult, "transaction result", KeyLayoutBlockID},
+	{codeFinalizedCluster, "finalized cluster block", KeyLayoutOther},
+	{codeServiceEvent, "service event", KeyLayoutBlockID},
+	{codeTransactionResultIndex, "transaction result by index", KeyLayoutBlockID},
+	{codeIndexCollection, "block collections index", KeyLayoutBlockID},
+	{codeIndexExecutionResultByBlock, "block to execution result", KeyLayoutBlockID},
+	{codeIndexCollectionByTransaction, "transaction to collection", KeyLayoutOther},
+	{codeIndexResultApprovalByChunk, "chunk to result approval", KeyLayoutOther},
+
+	{blockedNodeIDs, "blocked node ids", KeyLayoutOther},
+
+	{codeExecutionFork, "execution fork", KeyLayoutOther},
+	{codeEpochEmergencyFallbackTriggered, "epoch emergency fallback triggered", KeyLayoutOther},
+}
+
+// KeyPrefixes returns the description of all known prefix codes.
+func KeyPrefixes() []KeyPrefix {
+	prefixes := make([]KeyPrefix, len(keyPrefixes))
+	copy(prefixes, keyPrefixes)
+	return prefixes
+}
+
+// LookupKeyPrefix returns the description of the given prefix code, and false if the code is unknown.
+func LookupKeyPrefix(code byte) (KeyPrefix, bool) {
+	for _, prefix := range keyPrefixes {
+		if prefix.Code == code {
+			return prefix, true
+		}
+	}
+	return KeyPrefix{}, false
+}
+
+// HeightFromKey returns the block height embedded in a key with the KeyLayoutHeight layout.
+// Returns false if the key is too short to embed a height.
+func HeightFromKey(key []byte) (uint64, bool) {
+	if len(key) < 1+8 {
+		return 0, false
+	}
+	return binary.BigEndian.Uint64(key[1:9]), true
+}
+
+// BlockIDFromKey returns the block ID embedded in a key with the KeyLayoutBlockID layout.
+// Returns false if the key is too short to embed a block ID.
+func BlockIDFromKey(key []byte) (flow.Identifier, bool) {
+	if len(key) < 1+flow.IdentifierLen {
+		return flow.ZeroID, false
+	}
+	return flow.HashToID(key[1 : 1+flow.IdentifierLen]), true
+}
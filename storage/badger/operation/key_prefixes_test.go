@@ -0,0 +1,101 @@
+package operation
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/model/flow"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestKeyPrefixes(t *testing.T) {
+	codes := make(map[byte]struct{})
+	for _, prefix := range KeyPrefixes() {
+		_, duplicate := codes[prefix.Code]
+		assert.False(t, duplicate, "duplicate code %d", prefix.Code)
+		codes[prefix.Code] = struct{}{}
+		assert.NotEmpty(t, prefix.Name)
+	}
+
+	prefix, ok := LookupKeyPrefix(codeHeader)
+	require.True(t, ok)
+	assert.Equal(t, KeyLayoutBlockID, prefix.Layout)
+
+	_, ok = LookupKeyPrefix(3)
+	assert.False(t, ok)
+}
+
+// TestKeyPrefixesComplete evaluates that every prefix code declared in prefix.go is
+// described by the key prefix table, so codes added later can't be missed.
+func TestKeyPrefixesComplete(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "prefix.go", nil, 0)
+	require.NoError(t, err)
+
+	checked := 0
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				lit, ok := value.Values[i].(*ast.BasicLit)
+				require.True(t, ok, "prefix code %s must be an integer literal", name.Name)
+				code, err := strconv.ParseUint(lit.Value, 0, 8)
+				require.NoError(t, err)
+
+				_, ok = LookupKeyPrefix(byte(code))
+				assert.True(t, ok, "prefix code %s (%d) is missing from the key prefix table", name.Name, code)
+				checked++
+			}
+		}
+	}
+	require.NotZero(t, checked)
+}
+
+func TestKeyComponents(t *testing.T) {
+	height, ok := HeightFromKey(makePrefix(codeHeightToBlock, uint64(1337)))
+	require.True(t, ok)
+	assert.Equal(t, uint64(1337), height)
+
+	blockID := unittest.IdentifierFixture()
+	txID := unittest.IdentifierFixture()
+	actual, ok := BlockIDFromKey(makePrefix(codeTransactionResult, blockID, txID))
+	require.True(t, ok)
+	assert.Equal(t, blockID, actual)
+
+	_, ok = HeightFromKey(makePrefix(codeHeightToBlock))
+	assert.False(t, ok)
+	_, ok = BlockIDFromKey(makePrefix(codeHeader))
+	assert.False(t, ok)
+}
+
+func TestTraverseBlockHeights(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		expected := make(map[uint64]flow.Identifier)
+		for height := uint64(1); height <= 10; height++ {
+			blockID := unittest.IdentifierFixture()
+			expected[height] = blockID
+			require.NoError(t, db.Update(IndexBlockHeight(height, blockID)))
+		}
+
+		actual := make(map[uint64]flow.Identifier)
+		var last uint64
+		err := db.View(TraverseBlockHeights(func(height uint64, blockID flow.Identifier) error {
+			assert.Greater(t, height, last)
+			last = height
+			actual[height] = blockID
+			return nil
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	})
+}
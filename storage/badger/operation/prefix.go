@@ -24,6 +24,10 @@ const (
 	codeSporkID               = 13
 	codeProtocolVersion       = 14
 
+	// codes for slashing protection and evidence of hotstuff
+	codeSlashingProtection = 15 // blocks signed by hotstuff per view, keyed by chain ID
+	codeSlashingEvidence   = 16 // evidence of slashable offenses detected by hotstuff, keyed by ID
+
 	// code for heights with special meaning
 	codeFinalizedHeight         = 20 // latest finalized block height
 	codeSealedHeight            = 21 // latest sealed block height
@@ -71,6 +75,8 @@ const (
 	codeBeaconPrivateKey = 63 // BeaconPrivateKey, keyed by epoch counter
 	codeDKGStarted       = 64 // flag that the DKG for an epoch has been started
 	codeDKGEnded         = 65 // flag that the DKG for an epoch has ended (stores end state)
+	codeDKGResumeState   = 67 // progress of an in-progress DKG, keyed by epoch counter
+	codeDKGLogEntry      = 68 // messages and phase transitions processed by an in-progress DKG, keyed by epoch counter and sequence number
 
 	// code for ComputationResult upload status storage
 	// NOTE: for now only GCP uploader is supported. When other uploader (AWS e.g.) needs to
@@ -81,6 +87,8 @@ const (
 	codeJobConsumerProcessed = 70
 	codeJobQueue             = 71
 	codeJobQueuePointer      = 72
+	codeJobDeadLetter        = 73
+	codeJobIndexByID         = 74 // index mapping job ID to index in a push-style job queue
 
 	// legacy codes (should be cleaned up)
 	codeChunkDataPack                = 100
@@ -0,0 +1,31 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// InsertSlashingEvidence inserts the evidence of a slashable offense, keyed by its ID.
+func InsertSlashingEvidence(evidenceID flow.Identifier, evidence *model.SlashingEvidence) func(*badger.Txn) error {
+	return insert(makePrefix(codeSlashingEvidence, evidenceID), evidence)
+}
+
+// TraverseSlashingEvidence calls the given function with every stored evidence.
+func TraverseSlashingEvidence(fn func(evidence *model.SlashingEvidence) error) func(*badger.Txn) error {
+	return traverse(makePrefix(codeSlashingEvidence), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var evidence model.SlashingEvidence
+		create := func() interface{} {
+			evidence = model.SlashingEvidence{}
+			return &evidence
+		}
+		handle := func() error {
+			return fn(&evidence)
+		}
+		return check, create, handle
+	})
+}
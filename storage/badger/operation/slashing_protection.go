@@ -0,0 +1,37 @@
+package operation
+
+import (
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/consensus/hotstuff/model"
+	"github.com/onflow/flow-go/model/flow"
+)
+
+// UpsertSlashingProtection inserts or overwrites the slashing protection state of the given chain.
+func UpsertSlashingProtection(chainID flow.ChainID, state *model.SlashingProtectionState) func(*badger.Txn) error {
+	return upsert(makePrefix(codeSlashingProtection, chainID), state)
+}
+
+// RetrieveSlashingProtection retrieves the slashing protection state of the given chain.
+func RetrieveSlashingProtection(chainID flow.ChainID, state *model.SlashingProtectionState) func(*badger.Txn) error {
+	return retrieve(makePrefix(codeSlashingProtection, chainID), state)
+}
+
+// TraverseSlashingProtection calls the given function with the slashing protection state of
+// every chain.
+func TraverseSlashingProtection(fn func(state *model.SlashingProtectionState) error) func(*badger.Txn) error {
+	return traverse(makePrefix(codeSlashingProtection), func() (checkFunc, createFunc, handleFunc) {
+		check := func(key []byte) bool {
+			return true
+		}
+		var state model.SlashingProtectionState
+		create := func() interface{} {
+			state = model.SlashingProtectionState{}
+			return &state
+		}
+		handle := func() error {
+			return fn(&state)
+		}
+		return check, create, handle
+	})
+}
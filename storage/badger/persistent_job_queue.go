@@ -0,0 +1,125 @@
+package badger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v2"
+
+	"github.com/onflow/flow-go/storage"
+	"github.com/onflow/flow-go/storage/badger/operation"
+)
+
+// compactionBatchSize is the maximum number of jobs removed in a single transaction,
+// to stay within badger's transaction size limits.
+const compactionBatchSize = 1000
+
+// PersistentJobQueue stores the jobs of a single push-style job queue. The index of every appended
+// job is indexed by the job ID, and kept when the job is compacted, so that a job can't be appended
+// twice.
+type PersistentJobQueue struct {
+	db    *badger.DB
+	queue string // to distinguish the jobs of different job queues
+}
+
+var _ storage.PersistentJobQueue = (*PersistentJobQueue)(nil)
+
+func NewPersistentJobQueue(db *badger.DB, queue string) *PersistentJobQueue {
+	return &PersistentJobQueue{
+		db:    db,
+		queue: queue,
+	}
+}
+
+func (q *PersistentJobQueue) Append(job *storage.StoredJob) (uint64, error) {
+	var next uint64
+	err := operation.RetryOnConflict(q.db.Update, func(tx *badger.Txn) error {
+		var latest uint64
+		err := operation.RetrieveJobLatestIndex(q.queue, &latest)(tx)
+		if errors.Is(err, storage.ErrNotFound) {
+			// no job was appended yet, the first job is stored at index 1
+			next = 1
+			err = operation.InitJobLatestIndex(q.queue, next)(tx)
+		} else if err != nil {
+			return fmt.Errorf("could not retrieve latest index: %w", err)
+		} else {
+			next = latest + 1
+			err = operation.SetJobLatestIndex(q.queue, next)(tx)
+		}
+		if err != nil {
+			return fmt.Errorf("could not update latest index to %v: %w", next, err)
+		}
+
+		err = operation.IndexJobByID(q.queue, job.ID, next)(tx)
+		if err != nil {
+			return fmt.Errorf("could not index job %v: %w", job.ID, err)
+		}
+
+		err = operation.InsertStoredJob(q.queue, next, job)(tx)
+		if err != nil {
+			return fmt.Errorf("could not insert job at index %v: %w", next, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("could not append job %v: %w", job.ID, err)
+	}
+	return next, nil
+}
+
+func (q *PersistentJobQueue) Head() (uint64, error) {
+	var latest uint64
+	err := q.db.View(operation.RetrieveJobLatestIndex(q.queue, &latest))
+	if errors.Is(err, storage.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve latest index: %w", err)
+	}
+	return latest, nil
+}
+
+func (q *PersistentJobQueue) AtIndex(index uint64) (*storage.StoredJob, error) {
+	var job storage.StoredJob
+	err := q.db.View(operation.RetrieveStoredJob(q.queue, index, &job))
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve job at index %v: %w", index, err)
+	}
+	return &job, nil
+}
+
+func (q *PersistentJobQueue) Compact(upTo uint64, retain func(index uint64) bool) (int, error) {
+	var indices []uint64
+	err := q.db.View(operation.LookupJobIndices(q.queue, upTo, &indices))
+	if err != nil {
+		return 0, fmt.Errorf("could not look up jobs to compact: %w", err)
+	}
+
+	removable := make([]uint64, 0, len(indices))
+	for _, index := range indices {
+		if !retain(index) {
+			removable = append(removable, index)
+		}
+	}
+
+	for start := 0; start < len(removable); start += compactionBatchSize {
+		end := start + compactionBatchSize
+		if end > len(removable) {
+			end = len(removable)
+		}
+		err := operation.RetryOnConflict(q.db.Update, func(tx *badger.Txn) error {
+			for _, index := range removable[start:end] {
+				err := operation.RemoveJobAtIndex(q.queue, index)(tx)
+				if err != nil && !errors.Is(err, storage.ErrNotFound) {
+					return fmt.Errorf("could not remove job at index %v: %w", index, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return start, fmt.Errorf("could not compact jobs: %w", err)
+		}
+	}
+
+	return len(removable), nil
+}
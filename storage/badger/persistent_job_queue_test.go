@@ -0,0 +1,84 @@
+package badger_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/dgraph-io/badger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-go/storage"
+	bstorage "github.com/onflow/flow-go/storage/badger"
+	"github.com/onflow/flow-go/utils/unittest"
+)
+
+func TestPersistentJobQueue(t *testing.T) {
+	unittest.RunWithBadgerDB(t, func(db *badger.DB) {
+		queue := bstorage.NewPersistentJobQueue(db, "queue")
+		// a queue whose name starts with the name of the other queue
+		other := bstorage.NewPersistentJobQueue(db, "queue2")
+
+		head, err := queue.Head()
+		require.NoError(t, err)
+		require.Equal(t, uint64(0), head)
+
+		for i := uint64(1); i <= 10; i++ {
+			index, err := queue.Append(&storage.StoredJob{ID: fmt.Sprint(i), Payload: []byte{byte(i)}})
+			require.NoError(t, err)
+			require.Equal(t, i, index)
+		}
+		_, err = other.Append(&storage.StoredJob{ID: "other"})
+		require.NoError(t, err)
+
+		// job IDs are unique within a queue
+		_, err = queue.Append(&storage.StoredJob{ID: "3"})
+		require.ErrorIs(t, err, storage.ErrAlreadyExists)
+		_, err = other.Append(&storage.StoredJob{ID: "3"})
+		require.NoError(t, err)
+
+		head, err = queue.Head()
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), head)
+
+		job, err := queue.AtIndex(3)
+		require.NoError(t, err)
+		require.Equal(t, &storage.StoredJob{ID: "3", Payload: []byte{3}}, job)
+
+		_, err = queue.AtIndex(11)
+		require.ErrorIs(t, err, storage.ErrNotFound)
+
+		// compact the jobs up to index 5, except job 2
+		removed, err := queue.Compact(5, func(index uint64) bool { return index == 2 })
+		require.NoError(t, err)
+		require.Equal(t, 4, removed)
+
+		for i := uint64(1); i <= 10; i++ {
+			_, err := queue.AtIndex(i)
+			if i <= 5 && i != 2 {
+				require.ErrorIs(t, err, storage.ErrNotFound)
+			} else {
+				require.NoError(t, err)
+			}
+		}
+
+		// the head is not affected by compaction
+		head, err = queue.Head()
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), head)
+
+		// retained jobs are removed by a later compaction once they are no longer retained
+		removed, err = queue.Compact(5, func(uint64) bool { return false })
+		require.NoError(t, err)
+		require.Equal(t, 1, removed)
+
+		// compacted jobs can't be appended again
+		_, err = queue.Append(&storage.StoredJob{ID: "1"})
+		require.ErrorIs(t, err, storage.ErrAlreadyExists)
+		head, err = queue.Head()
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), head)
+
+		_, err = other.AtIndex(1)
+		require.NoError(t, err)
+	})
+}
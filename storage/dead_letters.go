@@ -0,0 +1,33 @@
+package storage
+
+import (
+	"time"
+)
+
+// DeadLetter is a job which a job consumer failed to process within the configured number of
+// attempts, and which was set aside so that the processing of later jobs can continue.
+type DeadLetter struct {
+	Index    uint64    // index of the job in the job queue
+	JobID    string    // ID of the job
+	Error    string    // error of the last attempt
+	Attempts uint64    // number of attempts made to process the job
+	FailedAt time.Time // time of the last attempt
+}
+
+// DeadLetters reads and writes the dead-lettered jobs of a job queue.
+type DeadLetters interface {
+	// Store stores the given dead letter, replacing any dead letter of the job at the same index.
+	Store(letter *DeadLetter) error
+
+	// ByIndex returns the dead letter of the job at the given index.
+	// Error returns:
+	//   * storage.ErrNotFound if the job at the given index is not dead-lettered
+	ByIndex(index uint64) (*DeadLetter, error)
+
+	// All returns all dead letters, ordered by job index.
+	All() ([]*DeadLetter, error)
+
+	// Remove removes the dead letter of the job at the given index. It is a no-op if the job
+	// is not dead-lettered.
+	Remove(index uint64) error
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"github.com/onflow/flow-go/crypto"
+	"github.com/onflow/flow-go/model/dkg"
 	"github.com/onflow/flow-go/model/flow"
 )
 
@@ -35,6 +36,21 @@ type DKGState interface {
 	// canonical key vector and may not be valid for use in signing. Use SafeBeaconKeys
 	// to guarantee only keys safe for signing are returned
 	RetrieveMyBeaconPrivateKey(epochCounter uint64) (crypto.PrivateKey, error)
+
+	// SetDKGResumeState stores the progress of the in-progress DKG for the given
+	// epoch, overwriting any previously stored progress.
+	SetDKGResumeState(epochCounter uint64, state *dkg.ResumeState) error
+
+	// GetDKGResumeState retrieves the progress of the in-progress DKG for the given epoch.
+	// Returns storage.ErrNotFound if no progress was stored for the epoch.
+	GetDKGResumeState(epochCounter uint64) (*dkg.ResumeState, error)
+
+	// InsertDKGLogEntry appends an entry to the replay log of the DKG for the given epoch.
+	InsertDKGLogEntry(epochCounter uint64, entry *dkg.LogEntry) error
+
+	// RetrieveDKGLog retrieves the replay log of the DKG for the given epoch,
+	// ordered by sequence number. Returns an empty log if no entries were stored.
+	RetrieveDKGLog(epochCounter uint64) ([]*dkg.LogEntry, error)
 }
 
 // SafeBeaconKeys is a safe way to access beacon keys.
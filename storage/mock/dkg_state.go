@@ -4,6 +4,8 @@ package mock
 
 import (
 	crypto "github.com/onflow/flow-go/crypto"
+	dkg "github.com/onflow/flow-go/model/dkg"
+
 	flow "github.com/onflow/flow-go/model/flow"
 
 	mock "github.com/stretchr/testify/mock"
@@ -35,6 +37,29 @@ func (_m *DKGState) GetDKGEndState(epochCounter uint64) (flow.DKGEndState, error
 	return r0, r1
 }
 
+// GetDKGResumeState provides a mock function with given fields: epochCounter
+func (_m *DKGState) GetDKGResumeState(epochCounter uint64) (*dkg.ResumeState, error) {
+	ret := _m.Called(epochCounter)
+
+	var r0 *dkg.ResumeState
+	if rf, ok := ret.Get(0).(func(uint64) *dkg.ResumeState); ok {
+		r0 = rf(epochCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dkg.ResumeState)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(epochCounter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetDKGStarted provides a mock function with given fields: epochCounter
 func (_m *DKGState) GetDKGStarted(epochCounter uint64) (bool, error) {
 	ret := _m.Called(epochCounter)
@@ -56,6 +81,20 @@ func (_m *DKGState) GetDKGStarted(epochCounter uint64) (bool, error) {
 	return r0, r1
 }
 
+// InsertDKGLogEntry provides a mock function with given fields: epochCounter, entry
+func (_m *DKGState) InsertDKGLogEntry(epochCounter uint64, entry *dkg.LogEntry) error {
+	ret := _m.Called(epochCounter, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, *dkg.LogEntry) error); ok {
+		r0 = rf(epochCounter, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // InsertMyBeaconPrivateKey provides a mock function with given fields: epochCounter, key
 func (_m *DKGState) InsertMyBeaconPrivateKey(epochCounter uint64, key crypto.PrivateKey) error {
 	ret := _m.Called(epochCounter, key)
@@ -70,6 +109,29 @@ func (_m *DKGState) InsertMyBeaconPrivateKey(epochCounter uint64, key crypto.Pri
 	return r0
 }
 
+// RetrieveDKGLog provides a mock function with given fields: epochCounter
+func (_m *DKGState) RetrieveDKGLog(epochCounter uint64) ([]*dkg.LogEntry, error) {
+	ret := _m.Called(epochCounter)
+
+	var r0 []*dkg.LogEntry
+	if rf, ok := ret.Get(0).(func(uint64) []*dkg.LogEntry); ok {
+		r0 = rf(epochCounter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*dkg.LogEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(uint64) error); ok {
+		r1 = rf(epochCounter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RetrieveMyBeaconPrivateKey provides a mock function with given fields: epochCounter
 func (_m *DKGState) RetrieveMyBeaconPrivateKey(epochCounter uint64) (crypto.PrivateKey, error) {
 	ret := _m.Called(epochCounter)
@@ -107,6 +169,20 @@ func (_m *DKGState) SetDKGEndState(epochCounter uint64, endState flow.DKGEndStat
 	return r0
 }
 
+// SetDKGResumeState provides a mock function with given fields: epochCounter, state
+func (_m *DKGState) SetDKGResumeState(epochCounter uint64, state *dkg.ResumeState) error {
+	ret := _m.Called(epochCounter, state)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(uint64, *dkg.ResumeState) error); ok {
+		r0 = rf(epochCounter, state)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetDKGStarted provides a mock function with given fields: epochCounter
 func (_m *DKGState) SetDKGStarted(epochCounter uint64) error {
 	ret := _m.Called(epochCounter)
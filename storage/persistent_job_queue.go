@@ -0,0 +1,29 @@
+package storage
+
+// StoredJob is a job pushed to a persistent job queue.
+type StoredJob struct {
+	ID      string // ID of the job
+	Payload []byte // encoded job
+}
+
+// PersistentJobQueue stores the jobs of a push-style job queue, which are assigned
+// sequential indices in the order they are appended. The first job has index 1.
+// Job IDs are unique within a queue, also after the job was compacted.
+type PersistentJobQueue interface {
+	// Append stores the given job at the index following the head, and returns that index.
+	// Error returns:
+	//   * storage.ErrAlreadyExists if a job with the same ID was appended before
+	Append(job *StoredJob) (uint64, error)
+
+	// Head returns the index of the last appended job, or 0 if no job was appended yet.
+	Head() (uint64, error)
+
+	// AtIndex returns the job at the given index.
+	// Error returns:
+	//   * storage.ErrNotFound if no job was appended at the given index, or if it was compacted
+	AtIndex(index uint64) (*StoredJob, error)
+
+	// Compact removes all jobs with an index up to and including the given index, except the
+	// jobs for which retain returns true. It returns the number of removed jobs.
+	Compact(upTo uint64, retain func(index uint64) bool) (int, error)
+}